@@ -0,0 +1,2 @@
+ALTER TABLE seasons DROP CONSTRAINT IF EXISTS seasons_no_overlap;
+ALTER TABLE seasons DROP COLUMN IF EXISTS closed_at;
@@ -0,0 +1,12 @@
+-- Add closed_at column to track seasons that have been closed
+ALTER TABLE seasons
+ADD COLUMN closed_at TIMESTAMP DEFAULT NULL;
+
+COMMENT ON COLUMN seasons.closed_at IS
+'Timestamp when the season was closed. NULL means the season is still open and can be modified.';
+
+-- Seasons cannot overlap, also when two of them are created or moved at the same time
+-- Both ends are inclusive, a season starts the day after the previous one ends
+ALTER TABLE seasons
+ADD CONSTRAINT seasons_no_overlap
+EXCLUDE USING gist (daterange(starts_at, ends_at, '[]') WITH &&);
@@ -0,0 +1 @@
+ALTER TABLE seasons DROP COLUMN IF EXISTS preemption_ends_at;
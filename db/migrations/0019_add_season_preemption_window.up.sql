@@ -0,0 +1,6 @@
+-- Add preemption_ends_at column to configure the right of preemption window of a season
+ALTER TABLE seasons
+ADD COLUMN preemption_ends_at DATE DEFAULT NULL;
+
+COMMENT ON COLUMN seasons.preemption_ends_at IS
+'Last day (inclusive) in which facilities rented in the previous season can only be rented by their previous holder. NULL means no preemption window.';
@@ -0,0 +1,7 @@
+ALTER TABLE membership_periods DROP COLUMN IF EXISTS exclusion_reason;
+
+UPDATE membership_periods
+SET status_id = (SELECT id FROM membership_statuses WHERE status = 'ACTIVE')
+WHERE status_id = (SELECT id FROM membership_statuses WHERE status = 'EXPIRED');
+
+DELETE FROM membership_statuses WHERE status = 'EXPIRED';
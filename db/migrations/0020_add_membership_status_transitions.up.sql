@@ -0,0 +1,11 @@
+-- Add the EXPIRED status for memberships that have not been renewed
+INSERT INTO membership_statuses (status)
+VALUES ('EXPIRED')
+ON CONFLICT (status) DO NOTHING;
+
+-- Add exclusion_reason column to record why the board excluded a member
+ALTER TABLE membership_periods
+ADD COLUMN exclusion_reason TEXT DEFAULT NULL;
+
+COMMENT ON COLUMN membership_periods.exclusion_reason IS
+'Reason of the board decision recorded in exclusion_deliberated_at. NULL unless the membership is excluded.';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS membership_expiry_runs;
+
+ALTER TABLE rented_facilities
+DROP COLUMN IF EXISTS flag_reason,
+DROP COLUMN IF EXISTS flagged_at;
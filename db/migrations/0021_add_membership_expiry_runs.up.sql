@@ -0,0 +1,19 @@
+-- Flag rentals whose holder no longer has an active membership in the rental season
+ALTER TABLE rented_facilities
+ADD COLUMN flagged_at TIMESTAMP DEFAULT NULL,
+ADD COLUMN flag_reason TEXT DEFAULT NULL;
+
+-- Record every execution of the membership expiry job
+CREATE TABLE IF NOT EXISTS membership_expiry_runs (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    trigger VARCHAR(20) NOT NULL CHECK (trigger IN ('SCHEDULED', 'MANUAL')),
+    reference_date DATE NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NOT NULL DEFAULT now(),
+    expired_memberships INT NOT NULL DEFAULT 0,
+    flagged_rentals INT NOT NULL DEFAULT 0,
+    details JSONB NOT NULL DEFAULT '{}'::jsonb
+);
+
+CREATE INDEX IF NOT EXISTS idx_membership_expiry_runs_started_at
+ON membership_expiry_runs(started_at DESC);
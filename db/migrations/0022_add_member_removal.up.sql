@@ -0,0 +1,6 @@
+-- Add removed_at column to mark members removed definitively after exclusion
+ALTER TABLE members
+ADD COLUMN removed_at TIMESTAMP DEFAULT NULL;
+
+COMMENT ON COLUMN members.removed_at IS
+'When the member was removed definitively. Personal data of removed members is anonymised, memberships, payments and rentals are kept.';
@@ -0,0 +1,19 @@
+-- Legal guardians of minor members, either another member or a contact
+CREATE TABLE IF NOT EXISTS member_guardians (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    member_id BIGINT NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    guardian_member_id BIGINT REFERENCES members(id),
+    first_name VARCHAR(100),
+    last_name VARCHAR(100),
+    email VARCHAR(255),
+    phone_number VARCHAR(50),
+    relationship VARCHAR(50) NOT NULL DEFAULT '',
+    CHECK (guardian_member_id IS NOT NULL OR (first_name IS NOT NULL AND last_name IS NOT NULL)),
+    CHECK (guardian_member_id IS NULL OR guardian_member_id <> member_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_member_guardians_member
+ON member_guardians(member_id);
+
+CREATE INDEX IF NOT EXISTS idx_member_guardians_guardian_member
+ON member_guardians(guardian_member_id);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS family_pricing_schemes;
+
+DROP INDEX IF EXISTS idx_members_household;
+
+ALTER TABLE members
+DROP COLUMN IF EXISTS household_id;
+
+DROP TABLE IF EXISTS households;
@@ -0,0 +1,26 @@
+-- Households group members of the same family for shared billing
+CREATE TABLE IF NOT EXISTS households (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE members
+ADD COLUMN IF NOT EXISTS household_id BIGINT REFERENCES households(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_members_household
+ON members(household_id) WHERE household_id IS NOT NULL;
+
+-- Family pricing of the membership fee, only the latest active scheme applies
+CREATE TABLE IF NOT EXISTS family_pricing_schemes (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    full_price NUMERIC(10,2) NOT NULL CHECK (full_price >= 0),
+    additional_member_price NUMERIC(10,2) NOT NULL CHECK (additional_member_price >= 0),
+    child_price NUMERIC(10,2) NOT NULL CHECK (child_price >= 0),
+    child_max_age INT NOT NULL DEFAULT 0 CHECK (child_max_age >= 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO family_pricing_schemes (full_price, additional_member_price, child_price, child_max_age)
+VALUES (130.00, 100.00, 0.00, 14);
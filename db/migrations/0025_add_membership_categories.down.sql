@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_membership_periods_category;
+
+ALTER TABLE membership_periods
+DROP COLUMN IF EXISTS category_id;
+
+DROP TABLE IF EXISTS membership_category_prices;
+
+DROP TABLE IF EXISTS membership_categories;
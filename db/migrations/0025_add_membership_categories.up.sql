@@ -0,0 +1,51 @@
+-- Kinds of membership, honorary members do not pay the membership fee
+CREATE TABLE IF NOT EXISTS membership_categories (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    payment_required BOOLEAN NOT NULL DEFAULT TRUE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO membership_categories (code, name, payment_required)
+VALUES
+    ('ORDINARY', 'Ordinario', TRUE),
+    ('JUNIOR', 'Junior', TRUE),
+    ('HONORARY', 'Onorario', FALSE),
+    ('FOUNDING', 'Fondatore', TRUE),
+    ('FAMILY', 'Familiare', TRUE);
+
+-- Membership price of each category in each season
+CREATE TABLE IF NOT EXISTS membership_category_prices (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    category_id BIGINT NOT NULL REFERENCES membership_categories(id) ON DELETE CASCADE,
+    season_id BIGINT NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    price NUMERIC(10, 2) NOT NULL CHECK (price >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'EUR',
+    UNIQUE (category_id, season_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_membership_category_prices_season
+ON membership_category_prices(season_id);
+
+-- Existing seasons keep the price used so far for every paying category
+-- Family memberships are priced by the family pricing scheme instead
+INSERT INTO membership_category_prices (category_id, season_id, price)
+SELECT c.id, s.id, 130.00
+FROM membership_categories c
+CROSS JOIN seasons s
+WHERE c.code IN ('ORDINARY', 'JUNIOR', 'FOUNDING');
+
+ALTER TABLE membership_periods
+ADD COLUMN IF NOT EXISTS category_id BIGINT REFERENCES membership_categories(id);
+
+UPDATE membership_periods
+SET category_id = (SELECT id FROM membership_categories WHERE code = 'ORDINARY')
+WHERE category_id IS NULL;
+
+ALTER TABLE membership_periods
+ALTER COLUMN category_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_membership_periods_category
+ON membership_periods(category_id);
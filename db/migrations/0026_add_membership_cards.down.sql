@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_membership_periods_season_card;
+
+ALTER TABLE membership_periods
+DROP COLUMN IF EXISTS card_code,
+DROP COLUMN IF EXISTS card_number;
+
+DROP TABLE IF EXISTS membership_numbering_policies;
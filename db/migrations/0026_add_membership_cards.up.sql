@@ -0,0 +1,28 @@
+-- Numbering of the membership cards, only the latest active policy applies
+-- GLOBAL cards carry the membership number of the member, SEASON cards are numbered again every season
+CREATE TABLE IF NOT EXISTS membership_numbering_policies (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    scope VARCHAR(20) NOT NULL CHECK (scope IN ('GLOBAL', 'SEASON')),
+    prefix VARCHAR(20) NOT NULL DEFAULT '',
+    digits INT NOT NULL DEFAULT 0 CHECK (digits >= 0 AND digits <= 10),
+    reuse_freed_numbers BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO membership_numbering_policies (scope, prefix, digits, reuse_freed_numbers)
+VALUES ('GLOBAL', '', 0, FALSE);
+
+-- Card of each membership period, the code is the number formatted when the card was assigned
+ALTER TABLE membership_periods
+ADD COLUMN IF NOT EXISTS card_number BIGINT,
+ADD COLUMN IF NOT EXISTS card_code VARCHAR(50);
+
+UPDATE membership_periods mp
+SET card_number = mem.number,
+    card_code = mem.number::TEXT
+FROM memberships mem
+WHERE mem.id = mp.membership_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_membership_periods_season_card
+ON membership_periods(season_id, card_number) WHERE card_number IS NOT NULL;
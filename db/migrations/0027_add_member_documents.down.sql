@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_member_documents_member_type;
+
+DROP TABLE IF EXISTS member_documents;
@@ -0,0 +1,20 @@
+-- Documents of the members (medical certificates, ID scans, signed forms)
+-- The file lives in the document storage under storage_key
+CREATE TABLE IF NOT EXISTS member_documents (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    member_id BIGINT NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL
+        CHECK (type IN ('MEDICAL_CERTIFICATE', 'ID_DOCUMENT', 'PRIVACY_CONSENT', 'SIGNED_FORM', 'OTHER')),
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes >= 0),
+    storage_key VARCHAR(500) NOT NULL UNIQUE,
+    issued_at DATE,
+    expires_at DATE,
+    notes TEXT,
+    uploaded_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (expires_at IS NULL OR issued_at IS NULL OR expires_at >= issued_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_member_documents_member_type
+ON member_documents(member_id, type);
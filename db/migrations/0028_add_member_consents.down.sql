@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_member_consents_member_type;
+
+DROP TABLE IF EXISTS member_consents;
+
+DROP TABLE IF EXISTS privacy_policies;
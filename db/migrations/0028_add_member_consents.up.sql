@@ -0,0 +1,21 @@
+-- Versions of the privacy policy, the current one is the latest published
+CREATE TABLE IF NOT EXISTS privacy_policies (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    version VARCHAR(50) NOT NULL UNIQUE,
+    text TEXT NOT NULL,
+    published_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Consents given or revoked by the members, never updated: the latest row of each type is the current one
+CREATE TABLE IF NOT EXISTS member_consents (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    member_id BIGINT NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    consent_type VARCHAR(50) NOT NULL
+        CHECK (consent_type IN ('PRIVACY_POLICY', 'PHOTO', 'MARKETING')),
+    granted BOOLEAN NOT NULL,
+    policy_version VARCHAR(50) REFERENCES privacy_policies(version),
+    recorded_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_member_consents_member_type
+ON member_consents(member_id, consent_type, recorded_at DESC);
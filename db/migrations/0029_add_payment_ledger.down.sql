@@ -0,0 +1,8 @@
+DROP VIEW IF EXISTS rented_facility_ledgers;
+
+DROP VIEW IF EXISTS membership_period_ledgers;
+
+DROP INDEX IF EXISTS idx_payments_rented_facility;
+
+ALTER TABLE payments
+DROP COLUMN IF EXISTS type;
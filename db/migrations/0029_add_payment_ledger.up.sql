@@ -0,0 +1,57 @@
+-- Payments become a ledger: several payments and refunds can settle a membership period or a rental
+-- Amounts stay positive, the type tells whether the money came in or went back
+ALTER TABLE payments
+ADD COLUMN IF NOT EXISTS type VARCHAR(20) NOT NULL DEFAULT 'PAYMENT'
+    CHECK (type IN ('PAYMENT', 'REFUND'));
+
+CREATE INDEX IF NOT EXISTS idx_payments_rented_facility
+ON payments(rented_facility_id);
+
+-- Ledger of each membership period, settled when the payments net of refunds cover the price
+-- Transactions are a JSON array, oldest first
+CREATE OR REPLACE VIEW membership_period_ledgers AS
+SELECT
+    mp.id AS membership_period_id,
+    mp.price AS due,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.type = 'PAYMENT'), 0) AS paid,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.type = 'REFUND'), 0) AS refunded,
+    COALESCE(SUM(CASE WHEN p.type = 'REFUND' THEN -p.amount ELSE p.amount END), 0) >= mp.price AS settled,
+    COALESCE(
+        json_agg(json_build_object(
+            'id', p.id,
+            'type', p.type,
+            'amount', p.amount,
+            'currency', p.currency,
+            'paid_at', p.paid_at,
+            'payment_method', p.payment_method,
+            'notes', p.notes
+        ) ORDER BY p.paid_at, p.id) FILTER (WHERE p.id IS NOT NULL),
+        '[]'::json
+    ) AS transactions
+FROM membership_periods mp
+LEFT JOIN payments p ON p.membership_period_id = mp.id
+GROUP BY mp.id, mp.price;
+
+-- Ledger of each rental, settled when the payments net of refunds cover the price
+CREATE OR REPLACE VIEW rented_facility_ledgers AS
+SELECT
+    rf.id AS rented_facility_id,
+    rf.price AS due,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.type = 'PAYMENT'), 0) AS paid,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.type = 'REFUND'), 0) AS refunded,
+    COALESCE(SUM(CASE WHEN p.type = 'REFUND' THEN -p.amount ELSE p.amount END), 0) >= rf.price AS settled,
+    COALESCE(
+        json_agg(json_build_object(
+            'id', p.id,
+            'type', p.type,
+            'amount', p.amount,
+            'currency', p.currency,
+            'paid_at', p.paid_at,
+            'payment_method', p.payment_method,
+            'notes', p.notes
+        ) ORDER BY p.paid_at, p.id) FILTER (WHERE p.id IS NOT NULL),
+        '[]'::json
+    ) AS transactions
+FROM rented_facilities rf
+LEFT JOIN payments p ON p.rented_facility_id = rf.id
+GROUP BY rf.id, rf.price;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_payments_paid_at;
+
+DROP VIEW IF EXISTS payment_records;
@@ -0,0 +1,27 @@
+-- Every payment and refund with the member and the season it was made for, used by the payment search
+CREATE OR REPLACE VIEW payment_records AS
+SELECT
+    p.id,
+    p.type,
+    p.amount,
+    p.currency,
+    p.paid_at,
+    p.payment_method,
+    p.notes,
+    CASE WHEN p.membership_period_id IS NOT NULL THEN 'MEMBERSHIP' ELSE 'FACILITY' END AS target,
+    p.membership_period_id,
+    p.rented_facility_id,
+    m.id AS member_id,
+    m.first_name,
+    m.last_name,
+    COALESCE(mp.season_id, rf.season_id) AS season_id,
+    f.identifier AS facility_identifier
+FROM payments p
+LEFT JOIN membership_periods mp ON mp.id = p.membership_period_id
+LEFT JOIN memberships mem ON mem.id = mp.membership_id
+LEFT JOIN rented_facilities rf ON rf.id = p.rented_facility_id
+LEFT JOIN facilities f ON f.id = rf.facility_id
+JOIN members m ON m.id = COALESCE(mem.member_id, rf.member_id);
+
+CREATE INDEX IF NOT EXISTS idx_payments_paid_at
+ON payments(paid_at);
@@ -0,0 +1,11 @@
+-- Days of the cash register (prima nota) recorded by the treasurer
+-- The payments of a closed day cannot be changed anymore
+CREATE TABLE IF NOT EXISTS cash_register_days (
+    day DATE PRIMARY KEY,
+    opening_balance NUMERIC(10,2) NOT NULL DEFAULT 0 CHECK (opening_balance >= 0),
+    counted_cash NUMERIC(10,2) CHECK (counted_cash >= 0),
+    bank_transfers NUMERIC(10,2) CHECK (bank_transfers >= 0),
+    notes TEXT,
+    closed_at TIMESTAMP,
+    CHECK (closed_at IS NULL OR counted_cash IS NOT NULL)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bank_transactions;
+DROP TABLE IF EXISTS bank_statements;
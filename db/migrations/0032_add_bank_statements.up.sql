@@ -0,0 +1,27 @@
+-- Bank statements imported to match incoming transfers against memberships and rentals
+CREATE TABLE IF NOT EXISTS bank_statements (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    file_name VARCHAR(255) NOT NULL,
+    format VARCHAR(10) NOT NULL CHECK (format IN ('CSV', 'CAMT053')),
+    account_iban VARCHAR(34),
+    imported_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Incoming transfers of the statements, the fingerprint keeps overlapping statements from importing them twice
+-- payment_id is set once the transfer is matched and its payment recorded
+CREATE TABLE IF NOT EXISTS bank_transactions (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    bank_statement_id BIGINT NOT NULL REFERENCES bank_statements(id) ON DELETE CASCADE,
+    fingerprint VARCHAR(64) NOT NULL UNIQUE,
+    reference VARCHAR(255),
+    booking_date DATE NOT NULL,
+    amount NUMERIC(10,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    counterparty_name VARCHAR(255),
+    counterparty_iban VARCHAR(34),
+    remittance_info TEXT,
+    payment_id BIGINT UNIQUE REFERENCES payments(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_transactions_statement
+ON bank_transactions(bank_statement_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS fiscal_document_lines;
+DROP TABLE IF EXISTS fiscal_documents;
+DROP TABLE IF EXISTS fiscal_document_sequences;
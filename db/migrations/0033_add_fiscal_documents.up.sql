@@ -0,0 +1,40 @@
+-- Last number issued in each sequence, the row is locked while a document is issued so that numbers have no gaps
+CREATE TABLE IF NOT EXISTS fiscal_document_sequences (
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('RECEIPT', 'INVOICE')),
+    fiscal_year INT NOT NULL,
+    last_number INT NOT NULL CHECK (last_number > 0),
+    PRIMARY KEY (kind, fiscal_year)
+);
+
+-- Receipts and invoices issued for payments
+-- Issuer and recipient are copied as they were at issue time, so that the document can be generated again unchanged
+CREATE TABLE IF NOT EXISTS fiscal_documents (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('RECEIPT', 'INVOICE')),
+    fiscal_year INT NOT NULL,
+    number INT NOT NULL CHECK (number > 0),
+    issued_on DATE NOT NULL,
+    member_id BIGINT REFERENCES members(id) ON DELETE SET NULL,
+    issuer JSONB NOT NULL,
+    recipient JSONB NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'EUR',
+    notes JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (kind, fiscal_year, number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fiscal_documents_member
+ON fiscal_documents(member_id);
+
+-- A payment appears on one document at most, the line keeps its amount if the payment is later removed
+CREATE TABLE IF NOT EXISTS fiscal_document_lines (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    fiscal_document_id BIGINT NOT NULL REFERENCES fiscal_documents(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    payment_id BIGINT UNIQUE REFERENCES payments(id) ON DELETE SET NULL,
+    description VARCHAR(255) NOT NULL,
+    paid_on DATE NOT NULL,
+    payment_method VARCHAR(50) NOT NULL,
+    amount NUMERIC(10,2) NOT NULL,
+    UNIQUE (fiscal_document_id, position)
+);
@@ -0,0 +1,8 @@
+ALTER TABLE fiscal_document_lines DROP COLUMN IF EXISTS vat_rate;
+
+ALTER TABLE members
+DROP CONSTRAINT IF EXISTS members_billing_details,
+DROP COLUMN IF EXISTS pec,
+DROP COLUMN IF EXISTS recipient_code,
+DROP COLUMN IF EXISTS vat_number,
+DROP COLUMN IF EXISTS company_name;
@@ -0,0 +1,17 @@
+-- Billing details of the members billed as a business, all set or all missing
+ALTER TABLE members
+ADD COLUMN company_name VARCHAR(80),
+ADD COLUMN vat_number VARCHAR(30),
+ADD COLUMN recipient_code VARCHAR(7),
+ADD COLUMN pec VARCHAR(255),
+ADD CONSTRAINT members_billing_details CHECK (
+    (company_name IS NULL) = (vat_number IS NULL)
+    AND (company_name IS NULL) = (recipient_code IS NULL)
+);
+
+COMMENT ON COLUMN members.recipient_code IS
+'SdI code (Codice Destinatario) the electronic invoices are delivered to, 0000000 for the tax drawer or the PEC';
+
+-- VAT rate of the lines, in percent, 0 when the payment is out of the scope of VAT
+ALTER TABLE fiscal_document_lines
+ADD COLUMN vat_rate NUMERIC(5,2) NOT NULL DEFAULT 0 CHECK (vat_rate >= 0 AND vat_rate <= 100);
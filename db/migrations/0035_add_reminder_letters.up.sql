@@ -0,0 +1,17 @@
+-- Reminder letters sent to members who have not paid what they owe in a season
+-- Letters escalate from a first reminder to a formal notice before exclusion, each level is sent once per season
+-- Recipient and items are copied as they were when the letter was sent, so that it can be printed again unchanged
+CREATE TABLE IF NOT EXISTS reminder_letters (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    member_id BIGINT NOT NULL REFERENCES members(id) ON DELETE CASCADE,
+    season_id BIGINT NOT NULL REFERENCES seasons(id),
+    level VARCHAR(20) NOT NULL CHECK (level IN ('FIRST_REMINDER', 'SECOND_REMINDER', 'FORMAL_NOTICE')),
+    sent_on DATE NOT NULL,
+    recipient JSONB NOT NULL,
+    items JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (member_id, season_id, level)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminder_letters_season
+ON reminder_letters(season_id, sent_on);
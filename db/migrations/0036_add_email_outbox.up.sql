@@ -0,0 +1,25 @@
+-- Emails to members, stored before they are sent so that none is lost when the mail server is down
+-- Pending emails are attempted when next_attempt_at is due, failed ones are only retried on request
+-- Cancelled emails were still pending when their member was removed, they are never sent
+CREATE TABLE IF NOT EXISTS email_outbox (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    kind VARCHAR(50) NOT NULL,
+    member_id BIGINT REFERENCES members(id) ON DELETE SET NULL,
+    recipient_email VARCHAR(255) NOT NULL,
+    recipient_name VARCHAR(255) NOT NULL DEFAULT '',
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'SENT', 'FAILED', 'CANCELLED')),
+    attempts INT NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_outbox_due
+ON email_outbox(next_attempt_at)
+WHERE status = 'PENDING';
+
+CREATE INDEX IF NOT EXISTS idx_email_outbox_member
+ON email_outbox(member_id);
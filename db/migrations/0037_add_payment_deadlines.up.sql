@@ -0,0 +1,17 @@
+-- Days by which memberships and the rentals of each facility type must be paid in a season
+-- A deadline can charge a late fee, fixed or as a percentage of what is still owed, after some days of grace
+CREATE TABLE IF NOT EXISTS payment_deadlines (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    season_id BIGINT NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    target VARCHAR(20) NOT NULL CHECK (target IN ('MEMBERSHIP', 'FACILITY')),
+    facility_type_id BIGINT REFERENCES facilities_catalog(id),
+    due_on DATE NOT NULL,
+    late_fee_kind VARCHAR(20) CHECK (late_fee_kind IN ('FIXED', 'PERCENTAGE')),
+    late_fee_amount NUMERIC(10,2) CHECK (late_fee_amount > 0),
+    late_fee_after_days INTEGER NOT NULL DEFAULT 0 CHECK (late_fee_after_days >= 0),
+    CHECK ((target = 'FACILITY') = (facility_type_id IS NOT NULL)),
+    CHECK ((late_fee_kind IS NULL) = (late_fee_amount IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_deadlines_season_target
+ON payment_deadlines(season_id, target, COALESCE(facility_type_id, 0));
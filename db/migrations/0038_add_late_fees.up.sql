@@ -0,0 +1,12 @@
+-- Late fees charged on memberships and rentals still owed after their deadline
+-- The fee is computed on the balance of the day the item first becomes liable to it and kept as charged then,
+-- later payments or changes to the deadline do not change it
+CREATE TABLE IF NOT EXISTS late_fees (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    membership_period_id BIGINT UNIQUE REFERENCES membership_periods(id) ON DELETE CASCADE,
+    rented_facility_id BIGINT UNIQUE REFERENCES rented_facilities(id) ON DELETE CASCADE,
+    amount NUMERIC(10,2) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'EUR',
+    charged_on DATE NOT NULL,
+    CHECK ((membership_period_id IS NULL) <> (rented_facility_id IS NULL))
+);
@@ -0,0 +1,290 @@
+package club
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// DunningLevel is the level of a reminder letter, letters escalate up to a formal notice before exclusion
+type DunningLevel string
+
+const (
+	NoReminder     DunningLevel = "NONE" // Members owing something who have not been reminded yet
+	FirstReminder  DunningLevel = "FIRST_REMINDER"
+	SecondReminder DunningLevel = "SECOND_REMINDER"
+	FormalNotice   DunningLevel = "FORMAL_NOTICE"
+)
+
+// DunningLevels are the levels of the letters, in the order they are sent
+var DunningLevels = []DunningLevel{FirstReminder, SecondReminder, FormalNotice}
+
+// DefaultDaysBetweenReminders is how long a member has to pay after a letter before the next one can be sent
+const DefaultDaysBetweenReminders = 15
+
+// DunningRepository defines the interface for the debts of a season and the letters sent for them
+type DunningRepository interface {
+	// GetOutstandingItems returns the memberships and rentals of the season still to be settled
+	GetOutstandingItems(seasonId int64) result.Result[[]payment.OutstandingItem]
+	// GetReminders returns the letters matching the criteria, oldest first
+	GetReminders(criteria ReminderCriteria) result.Result[[]ReminderLetter]
+	// GetReminder returns a letter, NotFoundError when it does not exist
+	GetReminder(id int64) result.Result[ReminderLetter]
+	// CreateReminders stores the letters in a single transaction, DunningError when one was already sent
+	CreateReminders(letters []ReminderLetter) result.Result[[]ReminderLetter]
+}
+
+// ReminderLetter is a letter sent to a member for what they owed in a season
+// Recipient and items are copied when the letter is sent, so that it can be printed again as it was
+type ReminderLetter struct {
+	Id         int64
+	MemberId   domain.Id[membership.Member]
+	SeasonId   int64
+	SeasonCode string
+	Level      DunningLevel
+	SentOn     time.Time
+	Recipient  ReminderRecipient
+	Items      []ReminderItem
+}
+
+// ReminderCriteria filters the letters of a season, nil fields are ignored
+type ReminderCriteria struct {
+	SeasonId int64
+	MemberId *int64
+	SentOn   *time.Time
+}
+
+// ReminderRecipient is the member the letter is addressed to
+type ReminderRecipient struct {
+	FirstName string
+	LastName  string
+	Email     string
+	Address   *membership.Address // First address of the member, if any
+}
+
+// ReminderItem is a membership or a rental still to be paid when the letter was sent
+type ReminderItem struct {
+	Description string
+	Due         money.Money
+	Balance     money.Money
+}
+
+// MemberDebt is what a member owes in a season, with the letters already sent for it
+type MemberDebt struct {
+	MemberId  domain.Id[membership.Member]
+	FirstName string
+	LastName  string
+	TaxCode   string
+	Items     []payment.OutstandingItem
+	Reminders []ReminderLetter // Oldest first
+}
+
+// DunningDashboard lists the members owing something in a season and where their reminders stand
+type DunningDashboard struct {
+	Season               Season
+	Today                time.Time
+	DaysBetweenReminders int
+	Debtors              []MemberDebt
+}
+
+// NewMemberDebts groups the outstanding items by member, sorted by name
+// Letters of members who have paid everything since are left out with them
+func NewMemberDebts(items []payment.OutstandingItem, reminders []ReminderLetter) []MemberDebt {
+	byMember := map[int64]*MemberDebt{}
+	for _, item := range items {
+		debt, ok := byMember[item.MemberId]
+		if !ok {
+			debt = &MemberDebt{
+				MemberId:  domain.NewId[membership.Member](item.MemberId),
+				FirstName: item.FirstName,
+				LastName:  item.LastName,
+				TaxCode:   item.TaxCode,
+				Items:     []payment.OutstandingItem{},
+				Reminders: []ReminderLetter{},
+			}
+			byMember[item.MemberId] = debt
+		}
+		debt.Items = append(debt.Items, item)
+	}
+
+	for _, reminder := range reminders {
+		if debt, ok := byMember[reminder.MemberId.Value]; ok {
+			debt.Reminders = append(debt.Reminders, reminder)
+		}
+	}
+
+	debts := make([]MemberDebt, 0, len(byMember))
+	for _, debt := range byMember {
+		sort.SliceStable(debt.Reminders, func(i, j int) bool {
+			return levelIndex(debt.Reminders[i].Level) < levelIndex(debt.Reminders[j].Level)
+		})
+		debts = append(debts, *debt)
+	}
+	sort.Slice(debts, func(i, j int) bool {
+		a, b := debts[i], debts[j]
+		if !strings.EqualFold(a.LastName, b.LastName) {
+			return strings.ToLower(a.LastName) < strings.ToLower(b.LastName)
+		}
+		if !strings.EqualFold(a.FirstName, b.FirstName) {
+			return strings.ToLower(a.FirstName) < strings.ToLower(b.FirstName)
+		}
+		return a.MemberId.Value < b.MemberId.Value
+	})
+	return debts
+}
+
+// Total is what the member still owes in the season
+func (d MemberDebt) Total() money.Money {
+	var total int64
+	for _, item := range d.Items {
+		total += item.Balance.MinorUnits()
+	}
+	return money.New(total, money.DefaultCurrency)
+}
+
+// LastLevel is the level of the last letter sent, NoReminder when none was
+func (d MemberDebt) LastLevel() DunningLevel {
+	if len(d.Reminders) == 0 {
+		return NoReminder
+	}
+	return d.Reminders[len(d.Reminders)-1].Level
+}
+
+// NextLevel is the level of the next letter, nil when the formal notice was already sent
+func (d MemberDebt) NextLevel() *DunningLevel {
+	next := levelIndex(d.LastLevel()) + 1
+	if next >= len(DunningLevels) {
+		return nil
+	}
+	return &DunningLevels[next]
+}
+
+// NextReminderOn is the first day the next letter can be sent, nil before the first letter, which can be sent any time
+// After the formal notice it is the day the member becomes liable to exclusion
+func (d MemberDebt) NextReminderOn(daysBetweenReminders int) *time.Time {
+	if len(d.Reminders) == 0 {
+		return nil
+	}
+	day := payment.Day(d.Reminders[len(d.Reminders)-1].SentOn).AddDate(0, 0, daysBetweenReminders)
+	return &day
+}
+
+// IsReminderDue tells whether the next letter can be sent on the given day
+func (d MemberDebt) IsReminderDue(today time.Time, daysBetweenReminders int) bool {
+	if d.NextLevel() == nil || !d.Total().IsPositive() {
+		return false
+	}
+	next := d.NextReminderOn(daysBetweenReminders)
+	return next == nil || !payment.Day(today).Before(*next)
+}
+
+// IsLiableToExclusion tells whether the formal notice was sent and its deadline has passed without payment
+func (d MemberDebt) IsLiableToExclusion(today time.Time, daysBetweenReminders int) bool {
+	if d.LastLevel() != FormalNotice || !d.Total().IsPositive() {
+		return false
+	}
+	return !payment.Day(today).Before(*d.NextReminderOn(daysBetweenReminders))
+}
+
+// NewReminderLetter builds the next letter for the debt, DunningError when it cannot be sent on the given day
+func NewReminderLetter(debt MemberDebt, season Season, recipient ReminderRecipient, sentOn time.Time, daysBetweenReminders int) result.Result[ReminderLetter] {
+	name := strings.TrimSpace(debt.FirstName + " " + debt.LastName)
+	level := debt.NextLevel()
+	if level == nil {
+		return result.Err[ReminderLetter](errors.DunningError{Description: "the formal notice was already sent to " + name})
+	}
+	if !debt.IsReminderDue(sentOn, daysBetweenReminders) {
+		return result.Err[ReminderLetter](errors.DunningError{Description: "the next letter to " + name + " cannot be sent before " + debt.NextReminderOn(daysBetweenReminders).Format("2006-01-02")})
+	}
+
+	letter := ReminderLetter{
+		MemberId:   debt.MemberId,
+		SeasonId:   season.ID,
+		SeasonCode: season.Code,
+		Level:      *level,
+		SentOn:     payment.Day(sentOn),
+		Recipient:  recipient,
+		Items:      make([]ReminderItem, 0, len(debt.Items)),
+	}
+	for _, item := range debt.Items {
+		letter.Items = append(letter.Items, ReminderItem{
+			Description: itemDescription(item, season.Code),
+			Due:         item.Due,
+			Balance:     item.Balance,
+		})
+	}
+	return result.Ok(letter)
+}
+
+// Total is what the member owed when the letter was sent
+func (l ReminderLetter) Total() money.Money {
+	var total int64
+	for _, item := range l.Items {
+		total += item.Balance.MinorUnits()
+	}
+	return money.New(total, money.DefaultCurrency)
+}
+
+// TotalOutstanding is what the members owe in the season
+func (d DunningDashboard) TotalOutstanding() money.Money {
+	var total int64
+	for _, debt := range d.Debtors {
+		total += debt.Total().MinorUnits()
+	}
+	return money.New(total, money.DefaultCurrency)
+}
+
+// CountByLevel counts the debtors by the level of the last letter they were sent
+func (d DunningDashboard) CountByLevel() map[DunningLevel]int {
+	counts := map[DunningLevel]int{NoReminder: 0}
+	for _, level := range DunningLevels {
+		counts[level] = 0
+	}
+	for _, debt := range d.Debtors {
+		counts[debt.LastLevel()]++
+	}
+	return counts
+}
+
+// DueReminders are the debtors the next letter can be sent to today
+func (d DunningDashboard) DueReminders() []MemberDebt {
+	due := []MemberDebt{}
+	for _, debt := range d.Debtors {
+		if debt.IsReminderDue(d.Today, d.DaysBetweenReminders) {
+			due = append(due, debt)
+		}
+	}
+	return due
+}
+
+func itemDescription(item payment.OutstandingItem, seasonCode string) string {
+	season := ""
+	if seasonCode != "" {
+		season = " - stagione " + seasonCode
+	}
+	if item.Target == payment.FacilityTarget {
+		facility := strings.TrimSpace(item.FacilityName + " " + item.FacilityIdentifier)
+		if facility == "" {
+			facility = "Servizio"
+		}
+		return facility + season
+	}
+	return "Quota associativa" + season
+}
+
+// levelIndex is the position of the level in the escalation, -1 before the first letter
+func levelIndex(level DunningLevel) int {
+	for i, known := range DunningLevels {
+		if level == known {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,135 @@
+package club
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// DunningService follows up the members who have not paid, sending them letters of increasing severity
+type DunningService struct {
+	repository           DunningRepository
+	memberRepository     membership.MemberRepository
+	seasonRepository     SeasonRepository
+	daysBetweenReminders int
+}
+
+func NewDunningService(repository DunningRepository, memberRepository membership.MemberRepository, seasonRepository SeasonRepository, daysBetweenReminders int) *DunningService {
+	if daysBetweenReminders <= 0 {
+		daysBetweenReminders = DefaultDaysBetweenReminders
+	}
+	return &DunningService{
+		repository:           repository,
+		memberRepository:     memberRepository,
+		seasonRepository:     seasonRepository,
+		daysBetweenReminders: daysBetweenReminders,
+	}
+}
+
+// GetDashboard returns what each member owes in the season and where their reminders stand on the given day
+func (this DunningService) GetDashboard(seasonId int64, today time.Time) result.Result[DunningDashboard] {
+	season := this.seasonRepository.GetSeasonById(seasonId)
+	if !season.IsSuccess() {
+		return result.Err[DunningDashboard](season.Error())
+	}
+	items := this.repository.GetOutstandingItems(seasonId)
+	if !items.IsSuccess() {
+		return result.Err[DunningDashboard](items.Error())
+	}
+	reminders := this.repository.GetReminders(ReminderCriteria{SeasonId: seasonId})
+	if !reminders.IsSuccess() {
+		return result.Err[DunningDashboard](reminders.Error())
+	}
+
+	return result.Ok(DunningDashboard{
+		Season:               season.Value(),
+		Today:                payment.Day(today),
+		DaysBetweenReminders: this.daysBetweenReminders,
+		Debtors:              NewMemberDebts(items.Value(), reminders.Value()),
+	})
+}
+
+// SendReminders records the next letter, dated today, for the given members of the season
+// Without members, every debtor whose next letter is due gets it; with them, each must have a letter due
+func (this DunningService) SendReminders(seasonId int64, memberIds []int64, today time.Time) result.Result[[]ReminderLetter] {
+	dashboard := this.GetDashboard(seasonId, today)
+	if !dashboard.IsSuccess() {
+		return result.Err[[]ReminderLetter](dashboard.Error())
+	}
+
+	debts := dashboard.Value().DueReminders()
+	if len(memberIds) > 0 {
+		byMember := map[int64]MemberDebt{}
+		for _, debt := range dashboard.Value().Debtors {
+			byMember[debt.MemberId.Value] = debt
+		}
+		debts = make([]MemberDebt, 0, len(memberIds))
+		seen := map[int64]bool{}
+		for _, id := range memberIds {
+			if seen[id] {
+				return result.Err[[]ReminderLetter](errors.DunningError{Description: fmt.Sprintf("member %d is listed more than once", id)})
+			}
+			seen[id] = true
+			debt, ok := byMember[id]
+			if !ok {
+				return result.Err[[]ReminderLetter](errors.DunningError{Description: fmt.Sprintf("member %d owes nothing in the season", id)})
+			}
+			debts = append(debts, debt)
+		}
+	}
+	if len(debts) == 0 {
+		return result.Ok([]ReminderLetter{})
+	}
+
+	letters := make([]ReminderLetter, 0, len(debts))
+	for _, debt := range debts {
+		recipient := this.recipient(debt, seasonId)
+		if !recipient.IsSuccess() {
+			return result.Err[[]ReminderLetter](recipient.Error())
+		}
+		letter := NewReminderLetter(debt, dashboard.Value().Season, recipient.Value(), today, this.daysBetweenReminders)
+		if !letter.IsSuccess() {
+			return result.Err[[]ReminderLetter](letter.Error())
+		}
+		letters = append(letters, letter.Value())
+	}
+	return this.repository.CreateReminders(letters)
+}
+
+func (this DunningService) GetReminders(criteria ReminderCriteria) result.Result[[]ReminderLetter] {
+	return this.repository.GetReminders(criteria)
+}
+
+func (this DunningService) GetReminder(id int64) result.Result[ReminderLetter] {
+	return this.repository.GetReminder(id)
+}
+
+// GetUnpaidMembers returns the members whose latest membership, or one of whose rentals, is still unpaid
+func (this DunningService) GetUnpaidMembers(target payment.PaymentTarget) result.Result[[]membership.Member] {
+	switch target {
+	case payment.MembershipTarget:
+		return result.Ok(this.memberRepository.GetMembersWhoDidNotPayForMembership())
+	case payment.FacilityTarget:
+		return result.Ok(this.memberRepository.GetMembersWhoDidNotPayForServices())
+	}
+	return result.Err[[]membership.Member](errors.DunningError{Description: "unknown payment target " + string(target) + ", expected MEMBERSHIP or FACILITY"})
+}
+
+// recipient is the member as the letter is addressed to them, at their first address
+func (this DunningService) recipient(debt MemberDebt, seasonId int64) result.Result[ReminderRecipient] {
+	return result.Map(this.memberRepository.GetMemberById(debt.MemberId, seasonId), func(member membership.MemberDetails) ReminderRecipient {
+		recipient := ReminderRecipient{FirstName: member.FirstName, LastName: member.LastName}
+		if member.Email != nil {
+			recipient.Email = member.Email.Value
+		}
+		if len(member.Addresses) > 0 {
+			address := member.Addresses[0]
+			recipient.Address = &address
+		}
+		return recipient
+	})
+}
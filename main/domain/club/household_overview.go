@@ -0,0 +1,111 @@
+package club
+
+import (
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// HouseholdOverview lists the members of a household in a season, with what the family still owes
+type HouseholdOverview struct {
+	Household          membership.Household
+	SeasonId           int64
+	Members            []HouseholdMemberOverview
+	OutstandingBalance money.Money
+}
+
+// HouseholdMemberOverview is a member of the household with memberships and rentals of the season
+type HouseholdMemberOverview struct {
+	Member             membership.MemberDetails
+	Rentals            []facilityrental.RentedFacility
+	OutstandingBalance money.Money
+}
+
+// HouseholdOverviewService gathers memberships, rentals and balances of the members of a household
+type HouseholdOverviewService struct {
+	householdRepository membership.HouseholdRepository
+	memberRepository    membership.MemberRepository
+	facilityRepository  facilityrental.FacilityRepository
+}
+
+func NewHouseholdOverviewService(
+	householdRepository membership.HouseholdRepository,
+	memberRepository membership.MemberRepository,
+	facilityRepository facilityrental.FacilityRepository,
+) *HouseholdOverviewService {
+	return &HouseholdOverviewService{
+		householdRepository: householdRepository,
+		memberRepository:    memberRepository,
+		facilityRepository:  facilityRepository,
+	}
+}
+
+func (this HouseholdOverviewService) GetHouseholdOverview(id domain.Id[membership.Household], seasonId int64) result.Result[HouseholdOverview] {
+	household := this.householdRepository.GetHouseholdById(id)
+	if !household.IsSuccess() {
+		return result.Err[HouseholdOverview](household.Error())
+	}
+
+	overview := HouseholdOverview{
+		Household: household.Value(),
+		SeasonId:  seasonId,
+		Members:   []HouseholdMemberOverview{},
+	}
+	for _, user := range household.Value().Members {
+		details := this.memberRepository.GetMemberById(domain.Id[membership.Member]{Value: user.Id.Value}, seasonId)
+		if !details.IsSuccess() {
+			return result.Err[HouseholdOverview](details.Error())
+		}
+		rentals := this.facilityRepository.GetFacilitiesRentedByMember(user.Id, seasonId)
+		balance := OutstandingBalance(details.Value().Memberships, rentals)
+		if !balance.IsSuccess() {
+			return result.Err[HouseholdOverview](balance.Error())
+		}
+		total := overview.OutstandingBalance.Add(balance.Value())
+		if !total.IsSuccess() {
+			return result.Err[HouseholdOverview](total.Error())
+		}
+
+		overview.Members = append(overview.Members, HouseholdMemberOverview{
+			Member:             details.Value(),
+			Rentals:            rentals,
+			OutstandingBalance: balance.Value(),
+		})
+		overview.OutstandingBalance = total.Value()
+	}
+
+	return result.Ok(overview)
+}
+
+// OutstandingBalance sums what is still owed on the memberships and rentals,
+// memberships exempt from payment are never outstanding and overpayments do not offset other debts,
+// CurrencyMismatchError when they are not all in the same currency
+func OutstandingBalance(memberships []membership.Membership, rentals []facilityrental.RentedFacility) result.Result[money.Money] {
+	balances := []money.Money{}
+	for _, m := range memberships {
+		paid := m.IsPaid()
+		if !paid.IsSuccess() {
+			return result.Err[money.Money](paid.Error())
+		}
+		if paid.Value() {
+			continue
+		}
+		balance := m.Ledger().Balance()
+		if !balance.IsSuccess() {
+			return result.Err[money.Money](balance.Error())
+		}
+		balances = append(balances, balance.Value())
+	}
+	for _, rental := range rentals {
+		balance := rental.GetLedger().Balance()
+		if !balance.IsSuccess() {
+			return result.Err[money.Money](balance.Error())
+		}
+		if balance.Value().IsPositive() {
+			balances = append(balances, balance.Value())
+		}
+	}
+	return money.Sum("", balances...)
+}
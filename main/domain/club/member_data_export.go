@@ -0,0 +1,194 @@
+package club
+
+import (
+	"math"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/notification"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// MemberDataExport collects everything the club holds on a member
+type MemberDataExport struct {
+	ExportedAt         time.Time
+	Member             membership.MemberDetails // Personal data with contacts, guardians and current consents
+	Rentals            []SeasonRentals
+	WaitingListEntries []facilityrental.WaitingListEntry
+	Documents          []membership.MemberDocument
+	ConsentHistory     []membership.Consent
+	GuardianOf         []membership.User // Members the member is the legal guardian of
+	Payments           []payment.PaymentRecord
+	FiscalDocuments    []payment.FiscalDocument
+	ReminderLetters    []ReminderLetter
+	Emails             []notification.OutboxMessage
+}
+
+// SeasonRentals are the facilities rented by a member in a season
+type SeasonRentals struct {
+	Season  Season
+	Rentals []facilityrental.RentedFacility
+}
+
+// MemberDataExportService gathers the personal data, memberships, payments, rentals, documents
+// and correspondence of a member
+type MemberDataExportService struct {
+	memberRepository         membership.MemberRepository
+	seasonRepository         SeasonRepository
+	facilityRepository       facilityrental.FacilityRepository
+	waitingListRepository    facilityrental.WaitingListRepository
+	documentRepository       membership.MemberDocumentRepository
+	consentRepository        membership.ConsentRepository
+	paymentRepository        payment.PaymentRepository
+	fiscalDocumentRepository payment.FiscalDocumentRepository
+	dunningRepository        DunningRepository
+	outboxRepository         notification.OutboxRepository
+}
+
+func NewMemberDataExportService(
+	memberRepository membership.MemberRepository,
+	seasonRepository SeasonRepository,
+	facilityRepository facilityrental.FacilityRepository,
+	waitingListRepository facilityrental.WaitingListRepository,
+	documentRepository membership.MemberDocumentRepository,
+	consentRepository membership.ConsentRepository,
+	paymentRepository payment.PaymentRepository,
+	fiscalDocumentRepository payment.FiscalDocumentRepository,
+	dunningRepository DunningRepository,
+	outboxRepository notification.OutboxRepository,
+) *MemberDataExportService {
+	return &MemberDataExportService{
+		memberRepository:         memberRepository,
+		seasonRepository:         seasonRepository,
+		facilityRepository:       facilityRepository,
+		waitingListRepository:    waitingListRepository,
+		documentRepository:       documentRepository,
+		consentRepository:        consentRepository,
+		paymentRepository:        paymentRepository,
+		fiscalDocumentRepository: fiscalDocumentRepository,
+		dunningRepository:        dunningRepository,
+		outboxRepository:         outboxRepository,
+	}
+}
+
+// ExportMemberData returns the data held on the member across all seasons
+func (this MemberDataExportService) ExportMemberData(id domain.Id[membership.Member]) result.Result[MemberDataExport] {
+	seasons := this.seasonRepository.GetAllSeasons()
+	if !seasons.IsSuccess() {
+		return result.Err[MemberDataExport](seasons.Error())
+	}
+
+	export := MemberDataExport{
+		ExportedAt:         time.Now(),
+		Rentals:            []SeasonRentals{},
+		WaitingListEntries: []facilityrental.WaitingListEntry{},
+	}
+	export.Member.Memberships = []membership.Membership{}
+
+	found := false
+	for _, season := range seasons.Value() {
+		details := this.memberRepository.GetMemberById(id, season.ID)
+		if !details.IsSuccess() {
+			if _, ok := details.Error().(errors.NotFoundError); ok {
+				break
+			}
+			return result.Err[MemberDataExport](details.Error())
+		}
+		if !found {
+			export.Member.User = details.Value().User
+			found = true
+		}
+		export.Member.Memberships = append(export.Member.Memberships, details.Value().Memberships...)
+
+		rentals := this.facilityRepository.GetFacilitiesRentedByMember(domain.Id[membership.User]{Value: id.Value}, season.ID)
+		if len(rentals) > 0 {
+			export.Rentals = append(export.Rentals, SeasonRentals{Season: season, Rentals: rentals})
+		}
+	}
+	if !found {
+		return result.Err[MemberDataExport](errors.NotFoundError{Description: "member not found"})
+	}
+
+	for _, facilityType := range this.facilityRepository.GetFacilitiesCatalog() {
+		entry := this.waitingListRepository.GetMemberEntry(facilityType.Id, id)
+		if entry.IsSuccess() {
+			export.WaitingListEntries = append(export.WaitingListEntries, entry.Value())
+		} else if _, ok := entry.Error().(errors.NotFoundError); !ok {
+			return result.Err[MemberDataExport](entry.Error())
+		}
+	}
+
+	return this.exportRecords(id, export)
+}
+
+// exportRecords adds the documents, consents, payments and correspondence of the member to the export
+func (this MemberDataExportService) exportRecords(id domain.Id[membership.Member], export MemberDataExport) result.Result[MemberDataExport] {
+	userId := domain.Id[membership.User]{Value: id.Value}
+	memberId := id.Value
+
+	documents := this.documentRepository.GetDocuments(userId)
+	if !documents.IsSuccess() {
+		return result.Err[MemberDataExport](documents.Error())
+	}
+	export.Documents = documents.Value()
+
+	consents := this.consentRepository.GetConsentHistory(userId)
+	if !consents.IsSuccess() {
+		return result.Err[MemberDataExport](consents.Error())
+	}
+	export.ConsentHistory = consents.Value()
+
+	guardianOf := this.memberRepository.GetGuardedMembers(id)
+	if !guardianOf.IsSuccess() {
+		return result.Err[MemberDataExport](guardianOf.Error())
+	}
+	export.GuardianOf = guardianOf.Value()
+
+	payments := this.exportPayments(memberId)
+	if !payments.IsSuccess() {
+		return result.Err[MemberDataExport](payments.Error())
+	}
+	export.Payments = payments.Value()
+
+	fiscalDocuments := this.fiscalDocumentRepository.GetDocuments(payment.FiscalDocumentCriteria{MemberId: &memberId})
+	if !fiscalDocuments.IsSuccess() {
+		return result.Err[MemberDataExport](fiscalDocuments.Error())
+	}
+	export.FiscalDocuments = fiscalDocuments.Value()
+
+	// A criteria without season selects the letters of every season
+	reminders := this.dunningRepository.GetReminders(ReminderCriteria{MemberId: &memberId})
+	if !reminders.IsSuccess() {
+		return result.Err[MemberDataExport](reminders.Error())
+	}
+	export.ReminderLetters = reminders.Value()
+
+	emails := this.outboxRepository.GetMessages(notification.OutboxCriteria{MemberId: &memberId, Limit: math.MaxInt32})
+	if !emails.IsSuccess() {
+		return result.Err[MemberDataExport](emails.Error())
+	}
+	export.Emails = emails.Value()
+
+	return result.Ok(export)
+}
+
+// exportPayments reads every page of the transactions of the member
+func (this MemberDataExportService) exportPayments(memberId int64) result.Result[[]payment.PaymentRecord] {
+	payments := []payment.PaymentRecord{}
+	criteria := payment.PaymentSearchCriteria{MemberId: &memberId, Limit: payment.MaxPaymentSearchLimit}
+	for {
+		page := this.paymentRepository.SearchPayments(criteria)
+		if !page.IsSuccess() {
+			return result.Err[[]payment.PaymentRecord](page.Error())
+		}
+		payments = append(payments, page.Value().Payments...)
+		criteria.Offset += criteria.Limit
+		if len(page.Value().Payments) < criteria.Limit || criteria.Offset >= page.Value().Total {
+			return result.Ok(payments)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package club
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/notification"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// MemberNotificationService tells members by email about what happens to their memberships, rentals and payments
+// Members without an email are skipped, the notifications queued are returned
+type MemberNotificationService struct {
+	notificationService      *notification.NotificationService
+	memberRepository         membership.MemberRepository
+	seasonRepository         SeasonRepository
+	paymentRepository        payment.PaymentRepository
+	fiscalDocumentRepository payment.FiscalDocumentRepository
+}
+
+func NewMemberNotificationService(
+	notificationService *notification.NotificationService,
+	memberRepository membership.MemberRepository,
+	seasonRepository SeasonRepository,
+	paymentRepository payment.PaymentRepository,
+	fiscalDocumentRepository payment.FiscalDocumentRepository,
+) *MemberNotificationService {
+	return &MemberNotificationService{
+		notificationService:      notificationService,
+		memberRepository:         memberRepository,
+		seasonRepository:         seasonRepository,
+		paymentRepository:        paymentRepository,
+		fiscalDocumentRepository: fiscalDocumentRepository,
+	}
+}
+
+// MembershipCreated notifies a member of the membership added for the season
+func (this MemberNotificationService) MembershipCreated(details membership.MemberDetails, seasonId int64) result.Result[[]notification.OutboxMessage] {
+	if len(details.Memberships) == 0 {
+		return result.Ok([]notification.OutboxMessage{})
+	}
+	season := this.seasonRepository.GetSeasonById(seasonId)
+	if !season.IsSuccess() {
+		return result.Err[[]notification.OutboxMessage](season.Error())
+	}
+
+	added := details.Memberships[0]
+	data := notification.MembershipCreatedData{
+		FirstName:        details.FirstName,
+		SeasonCode:       season.Value().Code,
+		MembershipNumber: added.Number,
+		Price:            added.Price,
+	}
+	if added.Category != nil {
+		data.Category = added.Category.Name
+	}
+	return this.notify(details.User, notification.MembershipCreated, data)
+}
+
+// FacilityRented notifies a member of the facility rented in the season
+func (this MemberNotificationService) FacilityRented(rental facilityrental.RentedFacility, seasonId int64) result.Result[[]notification.OutboxMessage] {
+	return result.Bind(this.memberRepository.GetMemberById(rental.GetMemberId(), seasonId), func(details membership.MemberDetails) result.Result[[]notification.OutboxMessage] {
+		facility := rental.GetFacility()
+		return this.notify(details.User, notification.FacilityRented, notification.FacilityRentedData{
+			FirstName:  details.FirstName,
+			Facility:   facilityDescription(facility.FacilityType),
+			Identifier: facility.Identifier,
+			From:       rental.GetValidity().FromDate,
+			To:         rental.GetValidity().ToDate,
+			Price:      rental.GetPrice(),
+		})
+	})
+}
+
+// PaymentReceived notifies a member of a payment recorded, with what is left to pay; refunds are not notified
+func (this MemberNotificationService) PaymentReceived(paymentId int64) result.Result[[]notification.OutboxMessage] {
+	payments := this.fiscalDocumentRepository.GetBillablePayments([]int64{paymentId})
+	if !payments.IsSuccess() {
+		return result.Err[[]notification.OutboxMessage](payments.Error())
+	}
+	if len(payments.Value()) == 0 || payments.Value()[0].Record.Transaction.IsRefund() {
+		return result.Ok([]notification.OutboxMessage{})
+	}
+	billable := payments.Value()[0]
+	record := billable.Record
+
+	ledger := this.paymentRepository.GetPaymentLedger(domain.NewId[payment.Transaction](paymentId))
+	if !ledger.IsSuccess() {
+		return result.Err[[]notification.OutboxMessage](ledger.Error())
+	}
+
+	balance := ledger.Value().Balance()
+	if !balance.IsSuccess() {
+		return result.Err[[]notification.OutboxMessage](balance.Error())
+	}
+
+	return result.Bind(this.memberRepository.GetMemberById(domain.NewId[membership.Member](record.MemberId), record.SeasonId), func(details membership.MemberDetails) result.Result[[]notification.OutboxMessage] {
+		return this.notify(details.User, notification.PaymentReceived, notification.PaymentReceivedData{
+			FirstName:     details.FirstName,
+			Description:   billable.Description(),
+			Amount:        record.Transaction.Amount,
+			PaidOn:        record.Transaction.Date,
+			PaymentMethod: record.Transaction.PaymentMethod,
+			Balance:       balance.Value(),
+		})
+	})
+}
+
+// WaitingListChanged notifies the members who moved in the waiting list since its previous state
+func (this MemberNotificationService) WaitingListChanged(previous facilityrental.WaitingList, current facilityrental.WaitingList) result.Result[[]notification.OutboxMessage] {
+	queued := []notification.OutboxMessage{}
+	for _, change := range current.PositionChanges(previous) {
+		// The season only selects the memberships returned, which are not needed here
+		details := this.memberRepository.GetMemberById(change.Entry.MemberId, 0)
+		if !details.IsSuccess() {
+			return result.Err[[]notification.OutboxMessage](details.Error())
+		}
+		messages := this.notify(details.Value().User, notification.WaitingListPositionChanged, notification.WaitingListPositionData{
+			FirstName:        details.Value().FirstName,
+			Facility:         facilityDescription(current.FacilityType),
+			PreviousPosition: change.PreviousPosition,
+			Position:         change.Position,
+		})
+		if !messages.IsSuccess() {
+			return messages
+		}
+		queued = append(queued, messages.Value()...)
+	}
+	return result.Ok(queued)
+}
+
+// notify queues the notification for the user, nothing when they have no email
+func (this MemberNotificationService) notify(user membership.User, kind notification.NotificationKind, data any) result.Result[[]notification.OutboxMessage] {
+	if user.Email == nil {
+		return result.Ok([]notification.OutboxMessage{})
+	}
+	memberId := user.Id.Value
+	return result.Map(this.notificationService.Notify(notification.Notification{
+		Kind:      kind,
+		MemberId:  &memberId,
+		Recipient: notification.Recipient{Name: strings.TrimSpace(user.FirstName + " " + user.LastName), Email: user.Email.Value},
+		Data:      data,
+	}, time.Now()), func(message notification.OutboxMessage) []notification.OutboxMessage {
+		return []notification.OutboxMessage{message}
+	})
+}
+
+func facilityDescription(facilityType facilityrental.FacilityType) string {
+	if facilityType.Description != "" {
+		return facilityType.Description
+	}
+	return string(facilityType.FacilityName)
+}
@@ -0,0 +1,55 @@
+package club
+
+import (
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// MembershipExpiryRepository defines the interface for expiring memberships whose season has ended
+type MembershipExpiryRepository interface {
+	// ExpireMemberships moves to EXPIRED every active or suspended period of a season ended before the given date,
+	// flags the rentals of members left without an active membership and records the run, in a single transaction
+	ExpireMemberships(date time.Time, trigger ExpiryTrigger, startedAt time.Time) result.Result[ExpiryRun]
+	// GetExpiryRuns returns the most recent runs, newest first
+	GetExpiryRuns(limit int) result.Result[[]ExpiryRun]
+}
+
+type ExpiryTrigger string
+
+const (
+	ScheduledExpiry ExpiryTrigger = "SCHEDULED"
+	ManualExpiry    ExpiryTrigger = "MANUAL"
+)
+
+// ExpiryRun records what a single execution of the expiry job did
+type ExpiryRun struct {
+	Id                 int64
+	Trigger            ExpiryTrigger
+	ReferenceDate      time.Time
+	StartedAt          time.Time
+	FinishedAt         time.Time
+	ExpiredMemberships []ExpiredMembership
+	FlaggedRentals     []FlaggedRental
+}
+
+// ExpiredMembership is a membership period moved to EXPIRED
+type ExpiredMembership struct {
+	PeriodId         int64
+	MemberId         domain.Id[membership.Member]
+	FirstName        string
+	LastName         string
+	MembershipNumber int64
+	SeasonId         int64
+}
+
+// FlaggedRental is a rental whose holder has no active membership in the rental season
+type FlaggedRental struct {
+	RentedFacilityId   int64
+	MemberId           domain.Id[membership.Member]
+	FacilityIdentifier string
+	SeasonId           int64
+	Reason             string
+}
@@ -0,0 +1,37 @@
+package club
+
+import (
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+const DefaultExpiryRunsLimit = 20
+
+// MembershipExpiryService expires the memberships of seasons that have ended
+type MembershipExpiryService struct {
+	repository MembershipExpiryRepository
+}
+
+func NewMembershipExpiryService(repository MembershipExpiryRepository) *MembershipExpiryService {
+	return &MembershipExpiryService{repository: repository}
+}
+
+// RunExpiry expires every membership whose season ended before today
+// Rentals of members left without an active membership are flagged for review, not freed
+// Only the day of today matters, so the run is recorded with the date at midnight
+func (this MembershipExpiryService) RunExpiry(today time.Time, trigger ExpiryTrigger) result.Result[ExpiryRun] {
+	if trigger != ScheduledExpiry && trigger != ManualExpiry {
+		return result.Err[ExpiryRun](errors.MembershipStatusError{Description: "unknown expiry trigger: " + string(trigger)})
+	}
+	referenceDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+	return this.repository.ExpireMemberships(referenceDate, trigger, time.Now())
+}
+
+func (this MembershipExpiryService) GetExpiryRuns(limit int) result.Result[[]ExpiryRun] {
+	if limit <= 0 {
+		limit = DefaultExpiryRunsLimit
+	}
+	return this.repository.GetExpiryRuns(limit)
+}
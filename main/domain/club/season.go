@@ -8,8 +8,36 @@ type Season struct {
 	Name     string
 	StartsAt time.Time
 	EndsAt   time.Time
+	ClosedAt *time.Time // Set once the season has been closed
+	// Last day (inclusive) in which facilities rented in the previous season are reserved
+	// to their previous holder. Nil means the season has no preemption window
+	PreemptionEndsAt *time.Time
 }
 
 func (s Season) GetCode() string {
 	return s.Code
 }
+
+// IsClosed reports whether the season has been closed
+func (s Season) IsClosed() bool {
+	return s.ClosedAt != nil
+}
+
+// Contains reports whether the given date falls within the season
+// The end date is inclusive, the season lasts until the end of its last day
+func (s Season) Contains(date time.Time) bool {
+	return !date.Before(s.StartsAt) && date.Before(s.EndsAt.AddDate(0, 0, 1))
+}
+
+// Overlaps reports whether two seasons share at least one day, both end dates being inclusive
+func (s Season) Overlaps(other Season) bool {
+	return !s.StartsAt.After(other.EndsAt) && !other.StartsAt.After(s.EndsAt)
+}
+
+// IsInPreemptionWindow reports whether the right of preemption still applies on the given date
+func (s Season) IsInPreemptionWindow(date time.Time) bool {
+	if s.PreemptionEndsAt == nil {
+		return false
+	}
+	return date.Before(s.PreemptionEndsAt.AddDate(0, 0, 1))
+}
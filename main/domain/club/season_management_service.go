@@ -0,0 +1,150 @@
+package club
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+type SeasonManagementService struct {
+	repository SeasonRepository
+}
+
+func NewSeasonManagementService(repository SeasonRepository) *SeasonManagementService {
+	return &SeasonManagementService{repository: repository}
+}
+
+func (this SeasonManagementService) GetAllSeasons() result.Result[[]Season] {
+	return this.repository.GetAllSeasons()
+}
+
+func (this SeasonManagementService) GetSeasonById(seasonId int64) result.Result[Season] {
+	return this.repository.GetSeasonById(seasonId)
+}
+
+// GetCurrentSeason returns the season containing the given date
+func (this SeasonManagementService) GetCurrentSeason(today time.Time) result.Result[Season] {
+	return this.repository.GetSeasonByDate(today)
+}
+
+// CreateSeason validates and stores a new season
+// The new season must not overlap with any existing season
+func (this SeasonManagementService) CreateSeason(code string, name string, startsAt time.Time, endsAt time.Time) result.Result[Season] {
+	season := Season{
+		Code:     strings.TrimSpace(code),
+		Name:     strings.TrimSpace(name),
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+	}
+
+	if err := this.validate(season); err != nil {
+		return result.Err[Season](err)
+	}
+
+	return this.repository.CreateSeason(season)
+}
+
+// UpdateSeason changes code, name and dates of a season that has not been closed yet
+func (this SeasonManagementService) UpdateSeason(seasonId int64, code string, name string, startsAt time.Time, endsAt time.Time) result.Result[Season] {
+	existing := this.repository.GetSeasonById(seasonId)
+	if !existing.IsSuccess() {
+		return existing
+	}
+
+	if existing.Value().IsClosed() {
+		return result.Err[Season](errors.SeasonError{Description: "a closed season cannot be modified"})
+	}
+
+	season := Season{
+		ID:       seasonId,
+		Code:     strings.TrimSpace(code),
+		Name:     strings.TrimSpace(name),
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+	}
+
+	if err := this.validate(season); err != nil {
+		return result.Err[Season](err)
+	}
+	// Moving the end of the season must not leave its preemption window past it
+	if err := validatePreemptionWindow(existing.Value().PreemptionEndsAt, endsAt); err != nil {
+		return result.Err[Season](err)
+	}
+
+	return this.repository.UpdateSeason(season)
+}
+
+// CloseSeason marks a season as closed, after which it can no longer be modified
+func (this SeasonManagementService) CloseSeason(seasonId int64) result.Result[Season] {
+	existing := this.repository.GetSeasonById(seasonId)
+	if !existing.IsSuccess() {
+		return existing
+	}
+
+	if existing.Value().IsClosed() {
+		return result.Err[Season](errors.SeasonError{Description: "season is already closed"})
+	}
+
+	return this.repository.CloseSeason(seasonId, time.Now())
+}
+
+// SetPreemptionWindow configures until when the holders of the previous season's rentals
+// have the exclusive right to rent the same facilities. A nil date removes the window
+func (this SeasonManagementService) SetPreemptionWindow(seasonId int64, preemptionEndsAt *time.Time) result.Result[Season] {
+	existing := this.repository.GetSeasonById(seasonId)
+	if !existing.IsSuccess() {
+		return existing
+	}
+
+	if existing.Value().IsClosed() {
+		return result.Err[Season](errors.SeasonError{Description: "a closed season cannot be modified"})
+	}
+
+	if err := validatePreemptionWindow(preemptionEndsAt, existing.Value().EndsAt); err != nil {
+		return result.Err[Season](err)
+	}
+
+	return this.repository.SetPreemptionWindow(seasonId, preemptionEndsAt)
+}
+
+// validatePreemptionWindow ensures the preemption window, if any, ends before the end of the season
+func validatePreemptionWindow(preemptionEndsAt *time.Time, seasonEndsAt time.Time) error {
+	if preemptionEndsAt != nil && !preemptionEndsAt.Before(seasonEndsAt) {
+		return errors.DateError{Description: "preemption window must end before the end of the season"}
+	}
+	return nil
+}
+
+// validate checks the season fields and ensures it does not overlap with other seasons
+func (this SeasonManagementService) validate(season Season) error {
+	if season.Code == "" {
+		return errors.SeasonError{Description: "season code is required"}
+	}
+	if season.Name == "" {
+		return errors.SeasonError{Description: "season name is required"}
+	}
+	if !season.EndsAt.After(season.StartsAt) {
+		return errors.DateError{Description: "season end date must be after its start date"}
+	}
+
+	seasons := this.repository.GetAllSeasons()
+	if !seasons.IsSuccess() {
+		return seasons.Error()
+	}
+
+	for _, other := range seasons.Value() {
+		if other.ID == season.ID {
+			continue
+		}
+		if other.Code == season.Code {
+			return errors.SeasonError{Description: "a season with code " + season.Code + " already exists"}
+		}
+		if other.Overlaps(season) {
+			return errors.SeasonError{Description: "season overlaps with season " + other.Code}
+		}
+	}
+
+	return nil
+}
@@ -1,9 +1,25 @@
 package club
 
-import "github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+import (
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
 
 // SeasonRepository defines the interface for accessing season data
 type SeasonRepository interface {
 	// GetSeasonById retrieves a season by its ID
 	GetSeasonById(seasonId int64) result.Result[Season]
+	// GetAllSeasons retrieves all seasons ordered by start date
+	GetAllSeasons() result.Result[[]Season]
+	// GetSeasonByDate retrieves the season containing the given date
+	GetSeasonByDate(date time.Time) result.Result[Season]
+	// CreateSeason stores a new season and returns it with its generated ID
+	CreateSeason(season Season) result.Result[Season]
+	// UpdateSeason updates code, name and dates of an existing season
+	UpdateSeason(season Season) result.Result[Season]
+	// CloseSeason marks a season as closed at the given time
+	CloseSeason(seasonId int64, closedAt time.Time) result.Result[Season]
+	// SetPreemptionWindow sets the last day of the preemption window, nil removes the window
+	SetPreemptionWindow(seasonId int64, preemptionEndsAt *time.Time) result.Result[Season]
 }
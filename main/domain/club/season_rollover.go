@@ -0,0 +1,111 @@
+package club
+
+import (
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental/pricing"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// SeasonRolloverRepository defines the interface for copying memberships and rentals between seasons
+type SeasonRolloverRepository interface {
+	// GetRenewableMemberships returns the membership periods of the source season with a renewable status,
+	// skipping removed members
+	GetRenewableMemberships(sourceSeasonId int64, targetSeasonId int64) result.Result[[]RenewableMembership]
+	// GetRenewableRentals returns the non-deleted rentals of the source season held by members
+	// with a renewable membership period in it, skipping removed members
+	GetRenewableRentals(sourceSeasonId int64, targetSeasonId int64) result.Result[[]RenewableRental]
+	// ApplyRollover stores every renewal of the plan in the target season in a single transaction
+	ApplyRollover(plan RolloverPlan) result.Result[RolloverResult]
+}
+
+// RenewableMembershipStatuses are the statuses of the source season periods copied by a rollover
+// Periods of an ended season are moved to EXPIRED by the expiry job, so they are renewed like active ones
+var RenewableMembershipStatuses = []membership.MembershipStatus{
+	membership.MembershipStatusActive,
+	membership.MembershipStatusExpired,
+}
+
+// RenewableMembership is a membership period with a renewable status found in the source season
+type RenewableMembership struct {
+	MemberId         domain.Id[membership.Member]
+	FirstName        string
+	LastName         string
+	BirthDate        time.Time
+	HouseholdId      *domain.Id[membership.Household]
+	MembershipId     int64
+	MembershipNumber int64
+	SourcePrice      money.Money
+	AlreadyInTarget  bool // The member already has a period in the target season
+	Category         *membership.MembershipCategory
+	TargetPrice      *money.Money // Price of the category in the target season, nil when not configured
+}
+
+// RenewableRental is a rental found in the source season
+type RenewableRental struct {
+	MemberId               domain.Id[membership.Member]
+	FirstName              string
+	LastName               string
+	Rental                 facilityrental.RentedFacility
+	FacilityRentedInTarget bool // The same facility is already rented in the target season
+}
+
+// MembershipRenewal is a membership period that will be created in the target season
+type MembershipRenewal struct {
+	MemberId         domain.Id[membership.Member]
+	FirstName        string
+	LastName         string
+	MembershipId     int64
+	MembershipNumber int64
+	PreviousPrice    money.Money
+	Price            money.Money
+	Category         *membership.MembershipCategory // Kept from the source season
+}
+
+// RentalRenewal is a rental that will be created in the target season
+type RentalRenewal struct {
+	MemberId        domain.Id[membership.Member]
+	FirstName       string
+	LastName        string
+	SourceRental    facilityrental.RentedFacility
+	PreviousPrice   money.Money
+	Price           money.Money
+	DiscountApplied bool
+	PricingMethod   pricing.PricingMethod
+}
+
+// SkippedRenewal describes an entry of the source season that will not be copied
+type SkippedRenewal struct {
+	MemberId  domain.Id[membership.Member]
+	FirstName string
+	LastName  string
+	Kind      RenewalKind
+	Reason    string
+}
+
+type RenewalKind string
+
+const (
+	MembershipRenewalKind RenewalKind = "MEMBERSHIP"
+	RentalRenewalKind     RenewalKind = "RENTAL"
+)
+
+// RolloverPlan is the preview of a season rollover
+type RolloverPlan struct {
+	SourceSeason Season
+	TargetSeason Season
+	Memberships  []MembershipRenewal
+	Rentals      []RentalRenewal
+	Skipped      []SkippedRenewal
+}
+
+// RolloverResult summarises a committed rollover
+type RolloverResult struct {
+	Plan                     RolloverPlan
+	CreatedMembershipPeriods int
+	CreatedRentals           int
+}
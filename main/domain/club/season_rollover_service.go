@@ -0,0 +1,239 @@
+package club
+
+import (
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental/pricing"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// SeasonRolloverService renews memberships and rentals of a season into the following one
+type SeasonRolloverService struct {
+	repository          SeasonRolloverRepository
+	seasonRepository    SeasonRepository
+	rentalService       *facilityrental.RentalManagementService
+	householdRepository membership.HouseholdRepository
+}
+
+func NewSeasonRolloverService(
+	repository SeasonRolloverRepository,
+	seasonRepository SeasonRepository,
+	rentalService *facilityrental.RentalManagementService,
+	householdRepository membership.HouseholdRepository,
+) *SeasonRolloverService {
+	return &SeasonRolloverService{
+		repository:          repository,
+		seasonRepository:    seasonRepository,
+		rentalService:       rentalService,
+		householdRepository: householdRepository,
+	}
+}
+
+// PreviewRollover computes what a rollover would create without storing anything
+func (this SeasonRolloverService) PreviewRollover(sourceSeasonId int64, targetSeasonId int64) result.Result[RolloverPlan] {
+	sourceSeason := this.seasonRepository.GetSeasonById(sourceSeasonId)
+	if !sourceSeason.IsSuccess() {
+		return result.Err[RolloverPlan](sourceSeason.Error())
+	}
+
+	targetSeason := this.seasonRepository.GetSeasonById(targetSeasonId)
+	if !targetSeason.IsSuccess() {
+		return result.Err[RolloverPlan](targetSeason.Error())
+	}
+
+	if sourceSeasonId == targetSeasonId {
+		return result.Err[RolloverPlan](errors.SeasonError{Description: "source and target season must be different"})
+	}
+	if !targetSeason.Value().StartsAt.After(sourceSeason.Value().StartsAt) {
+		return result.Err[RolloverPlan](errors.SeasonError{Description: "target season must start after the source season"})
+	}
+	if targetSeason.Value().IsClosed() {
+		return result.Err[RolloverPlan](errors.SeasonError{Description: "cannot roll over into a closed season"})
+	}
+
+	memberships := this.repository.GetRenewableMemberships(sourceSeasonId, targetSeasonId)
+	if !memberships.IsSuccess() {
+		return result.Err[RolloverPlan](memberships.Error())
+	}
+
+	rentals := this.repository.GetRenewableRentals(sourceSeasonId, targetSeasonId)
+	if !rentals.IsSuccess() {
+		return result.Err[RolloverPlan](rentals.Error())
+	}
+
+	plan := RolloverPlan{
+		SourceSeason: sourceSeason.Value(),
+		TargetSeason: targetSeason.Value(),
+		Memberships:  []MembershipRenewal{},
+		Rentals:      []RentalRenewal{},
+		Skipped:      []SkippedRenewal{},
+	}
+
+	// Household members renewed so far in the plan, counted as paying by the family pricing scheme
+	renewedByHousehold := make(map[int64]int)
+	for _, renewable := range memberships.Value() {
+		if renewable.AlreadyInTarget {
+			plan.Skipped = append(plan.Skipped, SkippedRenewal{
+				MemberId:  renewable.MemberId,
+				FirstName: renewable.FirstName,
+				LastName:  renewable.LastName,
+				Kind:      MembershipRenewalKind,
+				Reason:    "member already has a membership in the target season",
+			})
+			continue
+		}
+
+		// The category is kept, the price is the one of the category in the target season
+		price := membership.SuggestedMembershipPrice
+		if renewable.Category != nil {
+			price = membership.MembershipCategoryPrice{
+				Category: *renewable.Category,
+				SeasonId: targetSeasonId,
+				Price:    renewable.TargetPrice,
+			}.SuggestedPrice()
+		}
+		if renewable.Category != nil && renewable.Category.Code == membership.FamilyCategory {
+			familyPrice := this.familyPrice(renewable, targetSeason.Value(), renewedByHousehold)
+			if !familyPrice.IsSuccess() {
+				return result.Err[RolloverPlan](familyPrice.Error())
+			}
+			price = familyPrice.Value()
+		}
+		if renewable.HouseholdId != nil {
+			renewedByHousehold[renewable.HouseholdId.Value]++
+		}
+
+		plan.Memberships = append(plan.Memberships, MembershipRenewal{
+			MemberId:         renewable.MemberId,
+			FirstName:        renewable.FirstName,
+			LastName:         renewable.LastName,
+			MembershipId:     renewable.MembershipId,
+			MembershipNumber: renewable.MembershipNumber,
+			PreviousPrice:    renewable.SourcePrice,
+			Price:            price,
+			Category:         renewable.Category,
+		})
+	}
+
+	plan.Rentals, plan.Skipped = this.priceRentals(rentals.Value(), targetSeasonId, plan.Skipped)
+
+	return result.Ok(plan)
+}
+
+// familyPrice prices a family membership with the family pricing scheme, counting as paying the
+// household members already holding a membership in the target season and those renewed before in the plan
+func (this SeasonRolloverService) familyPrice(renewable RenewableMembership, targetSeason Season, renewedByHousehold map[int64]int) result.Result[money.Money] {
+	scheme := this.householdRepository.GetFamilyPricingScheme()
+	if !scheme.IsSuccess() {
+		return result.Err[money.Money](scheme.Error())
+	}
+	user := membership.User{BirthDate: renewable.BirthDate, HouseholdId: renewable.HouseholdId}
+	if renewable.HouseholdId == nil {
+		return result.Ok(scheme.Value().SuggestPrice(user, 0, targetSeason.StartsAt).Price)
+	}
+
+	payingMembers := this.householdRepository.CountPayingMembers(*renewable.HouseholdId, targetSeason.ID, &renewable.MemberId)
+	if !payingMembers.IsSuccess() {
+		return result.Err[money.Money](payingMembers.Error())
+	}
+	paying := payingMembers.Value() + renewedByHousehold[renewable.HouseholdId.Value]
+	return result.Ok(scheme.Value().SuggestPrice(user, paying, targetSeason.StartsAt).Price)
+}
+
+// ExecuteRollover computes the rollover plan and stores it in a single transaction
+func (this SeasonRolloverService) ExecuteRollover(sourceSeasonId int64, targetSeasonId int64) result.Result[RolloverResult] {
+	return result.Bind(this.PreviewRollover(sourceSeasonId, targetSeasonId), this.repository.ApplyRollover)
+}
+
+// priceRentals re-runs the pricing calculators for every renewable rental
+// Each member's renewed rentals are considered together, so discounts that depend on
+// another rented facility (e.g. a box) apply regardless of the order of the rentals,
+// and at most one discounted rental per member per season is granted
+func (this SeasonRolloverService) priceRentals(
+	renewables []RenewableRental,
+	targetSeasonId int64,
+	skipped []SkippedRenewal,
+) ([]RentalRenewal, []SkippedRenewal) {
+	renewalsByMember := make(map[int64][]RenewableRental)
+	memberOrder := []int64{}
+	for _, renewable := range renewables {
+		if renewable.FacilityRentedInTarget {
+			skipped = append(skipped, SkippedRenewal{
+				MemberId:  renewable.MemberId,
+				FirstName: renewable.FirstName,
+				LastName:  renewable.LastName,
+				Kind:      RentalRenewalKind,
+				Reason:    "facility " + renewable.Rental.GetFacility().Identifier + " is already rented in the target season",
+			})
+			continue
+		}
+
+		memberId := renewable.MemberId.Value
+		if _, seen := renewalsByMember[memberId]; !seen {
+			memberOrder = append(memberOrder, memberId)
+		}
+		renewalsByMember[memberId] = append(renewalsByMember[memberId], renewable)
+	}
+
+	renewals := []RentalRenewal{}
+	for _, memberId := range memberOrder {
+		memberRenewables := renewalsByMember[memberId]
+
+		// Facilities the member will hold in the target season: existing ones plus renewed ones
+		existingRentals := this.rentalService.GetFacilitiesRentedByMember(domain.Id[membership.User]{Value: memberId}, targetSeasonId)
+		heldFacilityTypes := make([]int64, 0, len(existingRentals)+len(memberRenewables))
+		memberHasDiscountedRental := false
+		for _, existing := range existingRentals {
+			heldFacilityTypes = append(heldFacilityTypes, existing.GetFacility().FacilityType.Id.Value)
+			if existing.GetDiscountApplied() {
+				memberHasDiscountedRental = true
+			}
+		}
+		for _, renewable := range memberRenewables {
+			heldFacilityTypes = append(heldFacilityTypes, renewable.Rental.GetFacility().FacilityType.Id.Value)
+		}
+
+		for i, renewable := range memberRenewables {
+			facilityType := renewable.Rental.GetFacility().FacilityType
+
+			var boatLength *float64
+			if boatRental, ok := renewable.Rental.(facilityrental.RentedFacilityWithBoat); ok {
+				boatLength = &boatRental.BoatInfo.LengthMeters
+			}
+
+			priceResult := this.rentalService.CalculatePrice(pricing.PriceCalculationContext{
+				FacilityTypeId:            facilityType.Id.Value,
+				BaseSuggestedPrice:        facilityType.SuggestedPrice,
+				MemberRentedFacilityTypes: otherFacilityTypes(heldFacilityTypes, len(existingRentals)+i),
+				MemberHasDiscountedRental: memberHasDiscountedRental,
+				BoatLengthMeters:          boatLength,
+			})
+			if priceResult.DiscountApplied {
+				memberHasDiscountedRental = true
+			}
+
+			renewals = append(renewals, RentalRenewal{
+				MemberId:        renewable.MemberId,
+				FirstName:       renewable.FirstName,
+				LastName:        renewable.LastName,
+				SourceRental:    renewable.Rental,
+				PreviousPrice:   renewable.Rental.GetPrice(),
+				Price:           priceResult.FinalPrice,
+				DiscountApplied: priceResult.DiscountApplied,
+				PricingMethod:   priceResult.PricingMethod,
+			})
+		}
+	}
+
+	return renewals, skipped
+}
+
+// otherFacilityTypes returns the facility types without the one at the given index
+func otherFacilityTypes(facilityTypes []int64, index int) []int64 {
+	others := make([]int64, 0, len(facilityTypes)-1)
+	others = append(others, facilityTypes[:index]...)
+	return append(others, facilityTypes[index+1:]...)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 )
 
 type RentedFacility interface {
@@ -13,10 +14,18 @@ type RentedFacility interface {
 	GetMemberId() domain.Id[membership.Member]
 	GetFacility() Facility
 	GetValidity() RentalValidity
-	GetPayment() payment.Payment
+	GetLedger() payment.Ledger
 	GetType() RentedFacilityType
-	GetPrice() float64
+	GetPrice() money.Money
 	GetDiscountApplied() bool
+	GetFlag() *RentalFlag
+}
+
+// RentalFlag marks a rental for review, set by the membership expiry when the holder has no active membership
+// in the rental season and cleared once the membership is active again
+type RentalFlag struct {
+	FlaggedAt time.Time
+	Reason    string
 }
 
 type RentedFacilityType string
@@ -32,9 +41,10 @@ type SimpleRentedFacility struct {
 	MemberId        domain.Id[membership.Member]
 	Facility        Facility
 	Validity        RentalValidity
-	Price           float64
-	Payment         payment.Payment
+	Price           money.Money
+	Transactions    []payment.Transaction
 	DiscountApplied bool
+	Flag            *RentalFlag
 }
 
 type RentedFacilityWithBoat struct {
@@ -42,10 +52,11 @@ type RentedFacilityWithBoat struct {
 	MemberId        domain.Id[membership.Member]
 	Facility        Facility
 	Validity        RentalValidity
-	Price           float64
-	Payment         payment.Payment
+	Price           money.Money
+	Transactions    []payment.Transaction
 	BoatInfo        BoatInfo
 	DiscountApplied bool
+	Flag            *RentalFlag
 }
 
 type RentedFacilityWithLeerboard struct {
@@ -53,10 +64,11 @@ type RentedFacilityWithLeerboard struct {
 	MemberId        domain.Id[membership.Member]
 	Facility        Facility
 	Validity        RentalValidity
-	Price           float64
-	Payment         payment.Payment
+	Price           money.Money
+	Transactions    []payment.Transaction
 	LeerboardInfo   LeerboardInfo
 	DiscountApplied bool
+	Flag            *RentalFlag
 }
 
 type RentalValidity struct {
@@ -80,12 +92,12 @@ func (s SimpleRentedFacility) GetValidity() RentalValidity {
 	return s.Validity
 }
 
-func (s SimpleRentedFacility) GetPrice() float64 {
+func (s SimpleRentedFacility) GetPrice() money.Money {
 	return s.Price
 }
 
-func (s SimpleRentedFacility) GetPayment() payment.Payment {
-	return s.Payment
+func (s SimpleRentedFacility) GetLedger() payment.Ledger {
+	return payment.Ledger{Due: s.Price, Transactions: s.Transactions}
 }
 
 func (s SimpleRentedFacility) GetType() RentedFacilityType {
@@ -96,6 +108,10 @@ func (s SimpleRentedFacility) GetDiscountApplied() bool {
 	return s.DiscountApplied
 }
 
+func (s SimpleRentedFacility) GetFlag() *RentalFlag {
+	return s.Flag
+}
+
 func (r RentedFacilityWithBoat) GetId() domain.Id[RentedFacility] {
 	return r.Id
 }
@@ -112,12 +128,12 @@ func (r RentedFacilityWithBoat) GetValidity() RentalValidity {
 	return r.Validity
 }
 
-func (r RentedFacilityWithBoat) GetPrice() float64 {
+func (r RentedFacilityWithBoat) GetPrice() money.Money {
 	return r.Price
 }
 
-func (r RentedFacilityWithBoat) GetPayment() payment.Payment {
-	return r.Payment
+func (r RentedFacilityWithBoat) GetLedger() payment.Ledger {
+	return payment.Ledger{Due: r.Price, Transactions: r.Transactions}
 }
 
 func (r RentedFacilityWithBoat) GetType() RentedFacilityType {
@@ -128,6 +144,10 @@ func (r RentedFacilityWithBoat) GetDiscountApplied() bool {
 	return r.DiscountApplied
 }
 
+func (r RentedFacilityWithBoat) GetFlag() *RentalFlag {
+	return r.Flag
+}
+
 func (r RentedFacilityWithLeerboard) GetId() domain.Id[RentedFacility] {
 	return r.Id
 }
@@ -144,12 +164,12 @@ func (r RentedFacilityWithLeerboard) GetValidity() RentalValidity {
 	return r.Validity
 }
 
-func (r RentedFacilityWithLeerboard) GetPrice() float64 {
+func (r RentedFacilityWithLeerboard) GetPrice() money.Money {
 	return r.Price
 }
 
-func (r RentedFacilityWithLeerboard) GetPayment() payment.Payment {
-	return r.Payment
+func (r RentedFacilityWithLeerboard) GetLedger() payment.Ledger {
+	return payment.Ledger{Due: r.Price, Transactions: r.Transactions}
 }
 
 func (r RentedFacilityWithLeerboard) GetType() RentedFacilityType {
@@ -159,3 +179,7 @@ func (r RentedFacilityWithLeerboard) GetType() RentedFacilityType {
 func (r RentedFacilityWithLeerboard) GetDiscountApplied() bool {
 	return r.DiscountApplied
 }
+
+func (r RentedFacilityWithLeerboard) GetFlag() *RentalFlag {
+	return r.Flag
+}
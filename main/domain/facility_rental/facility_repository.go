@@ -1,8 +1,11 @@
 package facilityrental
 
 import (
+	"time"
+
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
 )
 
@@ -14,27 +17,32 @@ type FacilityRepository interface {
 	GetFacilitiesRentedByMember(memberId domain.Id[membership.User], season int64) []RentedFacility
 	GetPricingRules() []PricingRule
 	GetBoatLengthPricingTiers() []BoatLengthPricingTier
+	// RentFacility stores the rental, RentError when the facility is reserved to another member by a right of
+	// preemption pending on the given date, checked in the same transaction
 	RentFacility(
 		memberId domain.Id[membership.User],
 		facilityId domain.Id[Facility],
 		season int64,
-		price float64,
+		price money.Money,
 		discountApplied bool,
 		boatInfo *BoatInfo,
 		leerboardInfo *LeerboardInfo,
+		date time.Time,
 	) result.Result[RentedFacility]
 	ChangeFacility(rentedFacilityId domain.Id[RentedFacility], newFacilityId domain.Id[Facility]) result.Result[RentedFacility]
 	UpdateBoatInfo(rentedFacilityId domain.Id[RentedFacility], boatInfo BoatInfo) result.Result[RentedFacility]
 	UpdateLeerboardInfo(rentedFacilityId domain.Id[RentedFacility], leerboardInfo LeerboardInfo) result.Result[RentedFacility]
-	UpdatePrice(rentedFacilityId domain.Id[RentedFacility], price float64) result.Result[RentedFacility]
+	UpdatePrice(rentedFacilityId domain.Id[RentedFacility], price money.Money) result.Result[RentedFacility]
 	FreeFacility(rentedFacilityId domain.Id[RentedFacility]) result.Result[bool]
+	// GetPendingPreemptions returns the preemption rights of the season still open at the given date, marking the holders removed or excluded since
+	GetPendingPreemptions(season int64, date time.Time) result.Result[[]PreemptionRight]
 }
 
 type PricingRule struct {
 	Id                     domain.Id[PricingRule]
 	FacilityTypeId         domain.Id[FacilityType]
 	RequiredFacilityTypeId domain.Id[FacilityType]
-	SpecialPrice           float64
+	SpecialPrice           money.Money
 	Description            string
 	Active                 bool
 }
@@ -44,7 +52,6 @@ type BoatLengthPricingTier struct {
 	FacilityTypeId  domain.Id[FacilityType]
 	MinLengthMeters float64
 	MaxLengthMeters *float64 // nil means no upper limit (infinity)
-	Price           float64
-	Currency        string
+	Price           money.Money
 	Active          bool
 }
@@ -1,12 +1,15 @@
 package facilityrental
 
-import "github.com/alessandro-marcantoni/cnc-backend/main/domain"
+import (
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+)
 
 type FacilityType struct {
 	Id             domain.Id[FacilityType]
 	FacilityName   FacilityName
 	Description    string
-	SuggestedPrice float64
+	SuggestedPrice money.Money
 	HasBoat        bool
 	HasLeerboard   bool
 }
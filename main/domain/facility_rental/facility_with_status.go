@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 )
 
 type FacilityWithStatus struct {
@@ -12,7 +13,7 @@ type FacilityWithStatus struct {
 	Identifier              string
 	FacilityTypeName        FacilityName
 	FacilityTypeDescription string
-	SuggestedPrice          float64
+	SuggestedPrice          money.Money
 	IsRented                bool
 	ExpiresAt               *time.Time
 	RentedByMemberId        *int64
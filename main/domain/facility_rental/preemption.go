@@ -0,0 +1,41 @@
+package facilityrental
+
+import (
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+)
+
+// PreemptionRight is the priority of the previous season's holder to rent the same facility again
+// It is pending while the season's preemption window is open and the facility has not been rented yet
+type PreemptionRight struct {
+	Facility         Facility
+	HolderId         domain.Id[membership.Member]
+	HolderFirstName  string
+	HolderLastName   string
+	PreviousSeasonId int64
+	ExpiresAt        time.Time // Last day (inclusive) of the preemption window
+	HolderLeft       bool      // The holder has been removed or excluded since, so the right has lapsed
+}
+
+// IsHeldBy reports whether the given member is the holder of the preemption right
+func (p PreemptionRight) IsHeldBy(memberId domain.Id[membership.User]) bool {
+	return p.HolderId.Value == memberId.Value
+}
+
+// CheckPreemption ensures that a facility under a pending right of preemption is only rented
+// by the member who held it in the previous season, RentError otherwise
+// Rights of holders removed or excluded since have lapsed and do not reserve the facility
+func CheckPreemption(preemptions []PreemptionRight, facilityId domain.Id[Facility], memberId domain.Id[membership.User]) error {
+	for _, preemption := range preemptions {
+		if preemption.Facility.Id.Value == facilityId.Value && !preemption.HolderLeft && !preemption.IsHeldBy(memberId) {
+			return errors.RentError{
+				Description: "facility " + preemption.Facility.Identifier +
+					" is reserved to its previous holder until " + preemption.ExpiresAt.Format("2006-01-02"),
+			}
+		}
+	}
+	return nil
+}
@@ -1,6 +1,10 @@
 package pricing
 
-import "math"
+import (
+	"math"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+)
 
 // BoatLengthTier represents a price tier based on boat length
 type BoatLengthTier struct {
@@ -10,7 +14,7 @@ type BoatLengthTier struct {
 	// Use math.Inf(1) for no upper limit
 	MaxLengthMeters float64
 	// Price is the price for boats in this length range
-	Price float64
+	Price money.Money
 }
 
 // BoatLengthPricingConfig holds the configuration for boat-length-based pricing
@@ -18,7 +22,7 @@ type BoatLengthPricingConfig struct {
 	FacilityTypeId int64
 	Tiers          []BoatLengthTier
 	// DefaultPrice is used when no tier matches or if boat length is not provided
-	DefaultPrice float64
+	DefaultPrice money.Money
 }
 
 // BoatLengthPriceCalculator calculates prices based on boat length
@@ -43,12 +47,12 @@ func NewBoatLengthPriceCalculator(configs []BoatLengthPricingConfig) *BoatLength
 func (c *BoatLengthPriceCalculator) CalculatePriceForBoatLength(
 	facilityTypeId int64,
 	boatLengthMeters float64,
-) float64 {
+) money.Money {
 	// Find pricing config for this facility type
 	config, exists := c.configs[facilityTypeId]
 	if !exists {
 		// No boat-length pricing configured for this facility type
-		return 0 // Caller should use base suggested price
+		return money.Money{} // Caller should use base suggested price
 	}
 
 	// If boat length is invalid (zero or negative), return default
@@ -87,10 +91,10 @@ func (c *BoatLengthPriceCalculator) HasBoatLengthPricing(facilityTypeId int64) b
 }
 
 // GetDefaultPrice returns the default price for a facility type when no tier matches
-func (c *BoatLengthPriceCalculator) GetDefaultPrice(facilityTypeId int64) (float64, bool) {
+func (c *BoatLengthPriceCalculator) GetDefaultPrice(facilityTypeId int64) (money.Money, bool) {
 	config, exists := c.configs[facilityTypeId]
 	if !exists {
-		return 0, false
+		return money.Money{}, false
 	}
 
 	return config.DefaultPrice, true
@@ -1,5 +1,7 @@
 package pricing
 
+import "github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+
 // CompositePriceCalculator combines multiple pricing strategies
 // to calculate the final suggested price for a facility rental
 type CompositePriceCalculator struct {
@@ -21,7 +23,7 @@ func NewCompositePriceCalculator(
 // PriceCalculationContext holds all information needed to calculate a price
 type PriceCalculationContext struct {
 	FacilityTypeId            int64
-	BaseSuggestedPrice        float64
+	BaseSuggestedPrice        money.Money
 	MemberRentedFacilityTypes []int64
 	MemberHasDiscountedRental bool     // True if member already has a rental with discount applied in this season
 	BoatLengthMeters          *float64 // Optional: only for boat facilities
@@ -29,13 +31,13 @@ type PriceCalculationContext struct {
 
 // PriceCalculationResult holds the result of price calculation with details
 type PriceCalculationResult struct {
-	FinalPrice            float64
-	BasePrice             float64
+	FinalPrice            money.Money
+	BasePrice             money.Money
 	PricingMethod         PricingMethod
 	DiscountApplied       bool
-	DiscountAmount        float64
+	DiscountAmount        money.Money
 	BoatLengthTierApplied bool
-	BoatLengthTierPrice   float64
+	BoatLengthTierPrice   money.Money
 }
 
 // PricingMethod indicates which pricing strategy was used
@@ -81,7 +83,7 @@ func (c *CompositePriceCalculator) CalculatePrice(ctx PriceCalculationContext) P
 			*ctx.BoatLengthMeters,
 		)
 
-		if boatLengthPrice > 0 {
+		if boatLengthPrice.IsPositive() {
 			result.BoatLengthTierApplied = true
 			result.BoatLengthTierPrice = boatLengthPrice
 			result.FinalPrice = boatLengthPrice
@@ -96,9 +98,9 @@ func (c *CompositePriceCalculator) CalculatePrice(ctx PriceCalculationContext) P
 					ctx.MemberRentedFacilityTypes,
 				)
 
-				if discountPrice < boatLengthPrice {
+				if discountPrice.LessThan(boatLengthPrice) {
 					result.DiscountApplied = true
-					result.DiscountAmount = boatLengthPrice - discountPrice
+					result.DiscountAmount = boatLengthPrice.Subtract(discountPrice).Value()
 					result.FinalPrice = discountPrice
 					result.PricingMethod = CombinedPricing
 				}
@@ -122,9 +124,9 @@ func (c *CompositePriceCalculator) CalculatePrice(ctx PriceCalculationContext) P
 			ctx.MemberRentedFacilityTypes,
 		)
 
-		if discountPrice < ctx.BaseSuggestedPrice {
+		if discountPrice.LessThan(ctx.BaseSuggestedPrice) {
 			result.DiscountApplied = true
-			result.DiscountAmount = ctx.BaseSuggestedPrice - discountPrice
+			result.DiscountAmount = ctx.BaseSuggestedPrice.Subtract(discountPrice).Value()
 			result.FinalPrice = discountPrice
 			result.PricingMethod = DiscountPricing
 			return result
@@ -137,7 +139,7 @@ func (c *CompositePriceCalculator) CalculatePrice(ctx PriceCalculationContext) P
 }
 
 // CalculateSimplePrice is a convenience method that returns just the final price
-func (c *CompositePriceCalculator) CalculateSimplePrice(ctx PriceCalculationContext) float64 {
+func (c *CompositePriceCalculator) CalculateSimplePrice(ctx PriceCalculationContext) money.Money {
 	result := c.CalculatePrice(ctx)
 	return result.FinalPrice
 }
@@ -145,7 +147,7 @@ func (c *CompositePriceCalculator) CalculateSimplePrice(ctx PriceCalculationCont
 // GetPricingInformation returns detailed pricing information for UI display
 func (c *CompositePriceCalculator) GetPricingInformation(
 	facilityTypeId int64,
-	baseSuggestedPrice float64,
+	baseSuggestedPrice money.Money,
 	memberRentedFacilityTypes []int64,
 ) PricingInformation {
 	info := PricingInformation{
@@ -176,7 +178,7 @@ func (c *CompositePriceCalculator) GetPricingInformation(
 // PricingInformation holds all pricing information for a facility type
 type PricingInformation struct {
 	FacilityTypeId       int64
-	BasePrice            float64
+	BasePrice            money.Money
 	HasBoatLengthPricing bool
 	BoatLengthTiers      []BoatLengthTier
 	HasDiscounts         bool
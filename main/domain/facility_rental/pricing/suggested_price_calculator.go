@@ -1,11 +1,13 @@
 package pricing
 
+import "github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+
 // PricingRule represents a special price that applies when a member already has a certain facility type
 type PricingRule struct {
 	// RequiredFacilityTypeId is the facility type the member must already have
 	RequiredFacilityTypeId int64
 	// SpecialPrice is the absolute price to apply (e.g., 80.00 EUR)
-	SpecialPrice float64
+	SpecialPrice money.Money
 }
 
 // FacilityTypePricingConfig holds all pricing rules for a specific facility type
@@ -30,9 +32,9 @@ func NewSuggestedPriceCalculator(pricingConfigs []FacilityTypePricingConfig) *Su
 // Returns the special price if a rule applies, otherwise returns the base suggested price
 func (c *SuggestedPriceCalculator) CalculateSuggestedPrice(
 	facilityTypeId int64,
-	baseSuggestedPrice float64,
+	baseSuggestedPrice money.Money,
 	memberRentedFacilityTypes []int64,
-) float64 {
+) money.Money {
 	// Find pricing config for this facility type
 	var config *FacilityTypePricingConfig
 	for i := range c.pricingConfigs {
@@ -52,9 +54,9 @@ func (c *SuggestedPriceCalculator) CalculateSuggestedPrice(
 	priceFound := false
 
 	for _, rule := range config.PricingRules {
-		// Check if member has the required facility type
-		if c.hasFacilityType(memberRentedFacilityTypes, rule.RequiredFacilityTypeId) {
-			if !priceFound || rule.SpecialPrice < bestPrice {
+		// Check if member has the required facility type, rules in another currency do not apply
+		if c.hasFacilityType(memberRentedFacilityTypes, rule.RequiredFacilityTypeId) && rule.SpecialPrice.SameCurrency(baseSuggestedPrice) {
+			if !priceFound || rule.SpecialPrice.LessThan(bestPrice) {
 				bestPrice = rule.SpecialPrice
 				priceFound = true
 			}
@@ -1,10 +1,13 @@
 package facilityrental
 
 import (
+	"time"
+
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental/pricing"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
 )
 
@@ -109,7 +112,7 @@ func buildBoatLengthPricingConfigs(repository FacilityRepository) []pricing.Boat
 
 	for facilityTypeId, tiers := range configMap {
 		// Find the facility type to get default price
-		var defaultPrice float64 = 0
+		var defaultPrice money.Money
 		for _, facilityType := range catalog {
 			if facilityType.Id.Value == facilityTypeId {
 				defaultPrice = facilityType.SuggestedPrice
@@ -131,13 +134,14 @@ func (this RentalManagementService) RentService(
 	facilityId domain.Id[Facility],
 	memberId domain.Id[membership.User],
 	season int64,
-	price float64,
+	price money.Money,
 	discountApplied bool,
 	boat *BoatInfo,
 	leerboard *LeerboardInfo,
+	today time.Time,
 ) result.Result[RentedFacility] {
-	// Rent the facility
-	rentResult := this.repository.RentFacility(memberId, facilityId, season, price, discountApplied, boat, leerboard)
+	// Rent the facility, unless it is reserved to its previous holder by a right of preemption pending today
+	rentResult := this.repository.RentFacility(memberId, facilityId, season, price, discountApplied, boat, leerboard, today)
 	if !rentResult.IsSuccess() {
 		return rentResult
 	}
@@ -169,6 +173,7 @@ func (this RentalManagementService) ChangeFacility(
 	newFacilityId domain.Id[Facility],
 	memberId domain.Id[membership.User],
 	season int64,
+	today time.Time,
 ) result.Result[RentedFacility] {
 	// Get the current rented facility
 	rentedFacilities := this.repository.GetFacilitiesRentedByMember(memberId, season)
@@ -211,6 +216,11 @@ func (this RentalManagementService) ChangeFacility(
 		})
 	}
 
+	// The new facility must not be reserved to another member by a right of preemption
+	if err := this.checkPreemption(newFacilityId, memberId, season, today); err != nil {
+		return result.Err[RentedFacility](err)
+	}
+
 	// Perform the change in repository
 	changeResult := this.repository.ChangeFacility(rentedFacilityId, newFacilityId)
 	if !changeResult.IsSuccess() {
@@ -236,10 +246,10 @@ func (this RentalManagementService) GetFacilitiesRentedByMember(memberId domain.
 // considering any discounts based on the member's existing rentals
 func (this RentalManagementService) GetSuggestedPriceForMember(
 	facilityTypeId domain.Id[FacilityType],
-	baseSuggestedPrice float64,
+	baseSuggestedPrice money.Money,
 	memberId domain.Id[membership.User],
 	season int64,
-) float64 {
+) money.Money {
 	// Get member's currently rented facilities for the season
 	rentedFacilities := this.repository.GetFacilitiesRentedByMember(memberId, season)
 
@@ -262,7 +272,7 @@ func (this RentalManagementService) GetSuggestedPriceForMember(
 // considering boat length (if applicable) and discounts based on member's existing rentals
 func (this RentalManagementService) GetSuggestedPriceWithBoatLength(
 	facilityTypeId domain.Id[FacilityType],
-	baseSuggestedPrice float64,
+	baseSuggestedPrice money.Money,
 	memberId domain.Id[membership.User],
 	season int64,
 	boatLengthMeters *float64,
@@ -303,11 +313,11 @@ func (this RentalManagementService) GetBoatLengthTiers(
 ) ([]pricing.BoatLengthTier, bool) {
 	return this.compositePriceCalculator.GetPricingInformation(
 			facilityTypeId.Value,
-			0, // Base price not needed for just getting tiers
+			money.Money{}, // Base price not needed for just getting tiers
 			nil,
 		).BoatLengthTiers, this.compositePriceCalculator.GetPricingInformation(
 			facilityTypeId.Value,
-			0,
+			money.Money{},
 			nil,
 		).HasBoatLengthPricing
 }
@@ -415,7 +425,42 @@ func (this RentalManagementService) FreeFacility(rentedFacilityId domain.Id[Rent
 // UpdatePrice updates the price of an existing facility rental
 func (this RentalManagementService) UpdatePrice(
 	rentedFacilityId domain.Id[RentedFacility],
-	price float64,
+	price money.Money,
 ) result.Result[RentedFacility] {
 	return this.repository.UpdatePrice(rentedFacilityId, price)
 }
+
+// CalculatePrice calculates a price for an explicit pricing context
+// This is useful when the member's rentals are not yet stored, e.g. when renewing several rentals at once
+func (this RentalManagementService) CalculatePrice(ctx pricing.PriceCalculationContext) pricing.PriceCalculationResult {
+	return this.compositePriceCalculator.CalculatePrice(ctx)
+}
+
+// GetPendingPreemptions returns the facilities of the season still reserved to their previous holder on the given day
+// Rights of holders removed or excluded since have lapsed and are left out
+func (this RentalManagementService) GetPendingPreemptions(season int64, today time.Time) result.Result[[]PreemptionRight] {
+	return result.Map(this.repository.GetPendingPreemptions(season, today), func(preemptions []PreemptionRight) []PreemptionRight {
+		pending := []PreemptionRight{}
+		for _, preemption := range preemptions {
+			if !preemption.HolderLeft {
+				pending = append(pending, preemption)
+			}
+		}
+		return pending
+	})
+}
+
+// checkPreemption ensures that a facility under a right of preemption pending on the given day
+// is only rented by the member who held it in the previous season
+func (this RentalManagementService) checkPreemption(
+	facilityId domain.Id[Facility],
+	memberId domain.Id[membership.User],
+	season int64,
+	today time.Time,
+) error {
+	preemptions := this.repository.GetPendingPreemptions(season, today)
+	if !preemptions.IsSuccess() {
+		return preemptions.Error()
+	}
+	return CheckPreemption(preemptions.Value(), facilityId, memberId)
+}
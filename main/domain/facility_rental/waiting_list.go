@@ -59,3 +59,27 @@ func ReconstructWaitingListEntry(
 		Notes:        notes,
 	}
 }
+
+// WaitingListPositionChange is a member who moved in the waiting list, positions start from 1
+type WaitingListPositionChange struct {
+	Entry            WaitingListEntry
+	PreviousPosition int
+	Position         int
+}
+
+// PositionChanges returns the members of the list whose position differs from the one they had in the previous list
+// Members who joined or left the list in between are left out
+func (w WaitingList) PositionChanges(previous WaitingList) []WaitingListPositionChange {
+	previousPositions := make(map[int64]int, len(previous.Entries))
+	for i, entry := range previous.Entries {
+		previousPositions[entry.Id.Value] = i + 1
+	}
+
+	changes := []WaitingListPositionChange{}
+	for i, entry := range w.Entries {
+		if position, ok := previousPositions[entry.Id.Value]; ok && position != i+1 {
+			changes = append(changes, WaitingListPositionChange{Entry: entry, PreviousPosition: position, Position: i + 1})
+		}
+	}
+	return changes
+}
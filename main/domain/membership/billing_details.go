@@ -0,0 +1,82 @@
+package membership
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// DefaultRecipientCode lets the SdI deliver electronic invoices to the tax drawer of the recipient
+const DefaultRecipientCode = "0000000"
+
+// BillingDetails are the data of a member billed as a business, such as a sole trader renting a facility
+type BillingDetails struct {
+	CompanyName   string
+	VATNumber     string        // Italian VAT number (Partita IVA), or a foreign one prefixed by its country code
+	RecipientCode string        // SdI code (Codice Destinatario) the electronic invoices are delivered to
+	PEC           *EmailAddress // Certified email the electronic invoices are delivered to without a recipient code
+}
+
+var (
+	italianVATNumberPattern = regexp.MustCompile(`^[0-9]{11}$`)
+	foreignVATNumberPattern = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{2,28}$`)
+	recipientCodePattern    = regexp.MustCompile(`^[A-Z0-9]{7}$`)
+)
+
+// NewBillingDetails creates validated billing details (smart constructor)
+// The recipient code defaults to the one of the tax drawer, pec is optional
+func NewBillingDetails(companyName string, vatNumber string, recipientCode string, pec string) result.Result[BillingDetails] {
+	companyName = strings.TrimSpace(companyName)
+	if companyName == "" || utf8.RuneCountInString(companyName) > 80 {
+		return result.Err[BillingDetails](errors.BillingError{Description: "the company name must be 1 to 80 characters long"})
+	}
+
+	vatNumber = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(vatNumber), " ", ""))
+	vatNumber = strings.TrimPrefix(vatNumber, "IT")
+	switch {
+	case italianVATNumberPattern.MatchString(vatNumber):
+		if !validVATNumberCheckDigit(vatNumber) {
+			return result.Err[BillingDetails](errors.BillingError{Description: "invalid VAT number check digit"})
+		}
+	case !foreignVATNumberPattern.MatchString(vatNumber):
+		return result.Err[BillingDetails](errors.BillingError{Description: "invalid VAT number, expected 11 digits or a foreign number prefixed by its country code"})
+	}
+
+	recipientCode = strings.ToUpper(strings.TrimSpace(recipientCode))
+	if recipientCode == "" {
+		recipientCode = DefaultRecipientCode
+	}
+	if !recipientCodePattern.MatchString(recipientCode) {
+		return result.Err[BillingDetails](errors.BillingError{Description: "invalid recipient code, expected 7 letters or digits"})
+	}
+
+	details := BillingDetails{CompanyName: companyName, VATNumber: vatNumber, RecipientCode: recipientCode}
+	if strings.TrimSpace(pec) != "" {
+		email := NewEmailAddress(pec)
+		if !email.IsSuccess() {
+			return result.Err[BillingDetails](errors.BillingError{Description: "invalid PEC: " + email.Error().Error()})
+		}
+		value := email.Value()
+		details.PEC = &value
+	}
+	return result.Ok(details)
+}
+
+// validVATNumberCheckDigit checks the last digit of an Italian VAT number, computed with the Luhn algorithm
+func validVATNumberCheckDigit(vatNumber string) bool {
+	sum := 0
+	for i := 0; i < 10; i++ {
+		digit := int(vatNumber[i] - '0')
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return (10-sum%10)%10 == int(vatNumber[10]-'0')
+}
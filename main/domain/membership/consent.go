@@ -0,0 +1,169 @@
+package membership
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+type ConsentType string
+
+const (
+	// PrivacyPolicyConsent is the acceptance of the privacy policy, required to become a member
+	PrivacyPolicyConsent ConsentType = "PRIVACY_POLICY"
+	PhotoConsent         ConsentType = "PHOTO"
+	MarketingConsent     ConsentType = "MARKETING"
+)
+
+// ConsentTypes lists the consents in the order they are shown
+var ConsentTypes = []ConsentType{PrivacyPolicyConsent, PhotoConsent, MarketingConsent}
+
+// RequiredConsents must be granted for the current privacy policy to create a member
+var RequiredConsents = []ConsentType{PrivacyPolicyConsent}
+
+const maxPolicyVersionLength = 50
+
+// PrivacyPolicy is a published version of the privacy policy text
+type PrivacyPolicy struct {
+	Version     string
+	Text        string
+	PublishedAt time.Time
+}
+
+// Consent is a consent given or revoked by a member, consents are never changed, a new one is recorded instead
+type Consent struct {
+	Type          ConsentType
+	Granted       bool
+	PolicyVersion string // Version of the privacy policy shown to the member, empty when none was published
+	RecordedAt    time.Time
+}
+
+// MemberConsents is the current state of the consents of a member with the history they come from
+type MemberConsents struct {
+	Current  []Consent // Latest consent of each type
+	History  []Consent // Every consent recorded, oldest first
+	UpToDate bool      // Whether the required consents are granted for the current privacy policy
+}
+
+type ConsentRepository interface {
+	// GetCurrentPolicy returns the latest published policy, NotFoundError when none was published
+	GetCurrentPolicy() result.Result[PrivacyPolicy]
+	// GetPolicies returns every published policy, the latest first
+	GetPolicies() result.Result[[]PrivacyPolicy]
+	// PublishPolicy stores a new version, which becomes the current one
+	PublishPolicy(policy PrivacyPolicy) result.Result[PrivacyPolicy]
+	// GetConsentHistory returns every consent recorded for the member, oldest first
+	GetConsentHistory(userId domain.Id[User]) result.Result[[]Consent]
+	// RecordConsents appends the consents to the history, NotFoundError when the member does not exist or was removed
+	RecordConsents(userId domain.Id[User], consents []Consent) result.Result[[]Consent]
+}
+
+func (p PrivacyPolicy) Validate() result.Result[PrivacyPolicy] {
+	p.Version = strings.TrimSpace(p.Version)
+	if p.Version == "" {
+		return result.Err[PrivacyPolicy](errors.ConsentError{Description: "policy version is required"})
+	}
+	if len(p.Version) > maxPolicyVersionLength {
+		return result.Err[PrivacyPolicy](errors.ConsentError{Description: "policy version cannot be longer than 50 characters"})
+	}
+	if strings.TrimSpace(p.Text) == "" {
+		return result.Err[PrivacyPolicy](errors.ConsentError{Description: "policy text is required"})
+	}
+	return result.Ok(p)
+}
+
+// ValidateConsents checks the consents refer to the current policy, nil when none was published,
+// and fills in its version where missing
+func ValidateConsents(consents []Consent, policy *PrivacyPolicy) result.Result[[]Consent] {
+	seen := map[ConsentType]bool{}
+	validated := make([]Consent, len(consents))
+	for i, consent := range consents {
+		if !isConsentType(consent.Type) {
+			return result.Err[[]Consent](errors.ConsentError{Description: "unknown consent type " + string(consent.Type)})
+		}
+		if seen[consent.Type] {
+			return result.Err[[]Consent](errors.ConsentError{Description: "consent " + string(consent.Type) + " given more than once"})
+		}
+		seen[consent.Type] = true
+
+		consent.PolicyVersion = strings.TrimSpace(consent.PolicyVersion)
+		switch {
+		case policy == nil && consent.PolicyVersion != "":
+			return result.Err[[]Consent](errors.ConsentError{Description: "no privacy policy has been published"})
+		case policy != nil && consent.PolicyVersion == "":
+			consent.PolicyVersion = policy.Version
+		case policy != nil && consent.PolicyVersion != policy.Version:
+			return result.Err[[]Consent](errors.ConsentError{Description: "consent refers to privacy policy " + consent.PolicyVersion + ", the current one is " + policy.Version})
+		}
+		validated[i] = consent
+	}
+	return result.Ok(validated)
+}
+
+// ValidateRequiredConsents validates the consents of a new member, who must grant the required ones
+// Nothing is required until a privacy policy is published
+func ValidateRequiredConsents(consents []Consent, policy *PrivacyPolicy) result.Result[[]Consent] {
+	return result.Bind(ValidateConsents(consents, policy), func(consents []Consent) result.Result[[]Consent] {
+		if policy == nil {
+			return result.Ok(consents)
+		}
+		for _, required := range RequiredConsents {
+			if !HasCurrentConsent(consents, required, policy) {
+				return result.Err[[]Consent](errors.ConsentError{Description: "consent " + string(required) + " is required"})
+			}
+		}
+		return result.Ok(consents)
+	})
+}
+
+// CurrentConsents returns the latest consent of each type from a history ordered oldest first
+func CurrentConsents(history []Consent) []Consent {
+	latest := map[ConsentType]Consent{}
+	for _, consent := range history {
+		latest[consent.Type] = consent
+	}
+
+	current := []Consent{}
+	for _, consentType := range ConsentTypes {
+		if consent, ok := latest[consentType]; ok {
+			current = append(current, consent)
+		}
+	}
+	return current
+}
+
+// HasCurrentConsent tells whether the consent is granted, the privacy policy one only counts for the current policy
+func HasCurrentConsent(current []Consent, consentType ConsentType, policy *PrivacyPolicy) bool {
+	for _, consent := range current {
+		if consent.Type != consentType || !consent.Granted {
+			continue
+		}
+		if consentType == PrivacyPolicyConsent && policy != nil && consent.PolicyVersion != policy.Version {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// NewMemberConsents summarises the history of the consents of a member
+func NewMemberConsents(history []Consent, policy *PrivacyPolicy) MemberConsents {
+	current := CurrentConsents(history)
+	upToDate := true
+	for _, required := range RequiredConsents {
+		upToDate = upToDate && (policy == nil || HasCurrentConsent(current, required, policy))
+	}
+	return MemberConsents{Current: current, History: history, UpToDate: upToDate}
+}
+
+func isConsentType(consentType ConsentType) bool {
+	for _, known := range ConsentTypes {
+		if known == consentType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package membership
+
+import (
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+type ConsentManagementService struct {
+	repository ConsentRepository
+}
+
+func NewConsentManagementService(repository ConsentRepository) *ConsentManagementService {
+	return &ConsentManagementService{repository: repository}
+}
+
+func (this ConsentManagementService) GetCurrentPolicy() result.Result[PrivacyPolicy] {
+	return this.repository.GetCurrentPolicy()
+}
+
+func (this ConsentManagementService) GetPolicies() result.Result[[]PrivacyPolicy] {
+	return this.repository.GetPolicies()
+}
+
+// PublishPolicy makes a new version current, privacy policy consents given for older versions stop counting
+func (this ConsentManagementService) PublishPolicy(policy PrivacyPolicy) result.Result[PrivacyPolicy] {
+	return result.Bind(policy.Validate(), this.repository.PublishPolicy)
+}
+
+func (this ConsentManagementService) GetConsents(userId domain.Id[User]) result.Result[MemberConsents] {
+	policy := currentPrivacyPolicy(this.repository)
+	if !policy.IsSuccess() {
+		return result.Err[MemberConsents](policy.Error())
+	}
+	return result.Map(this.repository.GetConsentHistory(userId), func(history []Consent) MemberConsents {
+		return NewMemberConsents(history, policy.Value())
+	})
+}
+
+// RecordConsents grants or revokes consents of a member, adding them to the history
+func (this ConsentManagementService) RecordConsents(userId domain.Id[User], consents []Consent) result.Result[MemberConsents] {
+	if len(consents) == 0 {
+		return result.Err[MemberConsents](errors.ConsentError{Description: "at least one consent is required"})
+	}
+	policy := currentPrivacyPolicy(this.repository)
+	if !policy.IsSuccess() {
+		return result.Err[MemberConsents](policy.Error())
+	}
+
+	recorded := result.Bind(ValidateConsents(consents, policy.Value()), func(consents []Consent) result.Result[[]Consent] {
+		return this.repository.RecordConsents(userId, consents)
+	})
+	if !recorded.IsSuccess() {
+		return result.Err[MemberConsents](recorded.Error())
+	}
+	return this.GetConsents(userId)
+}
+
+// currentPrivacyPolicy returns the current policy, nil when none was published
+func currentPrivacyPolicy(repository ConsentRepository) result.Result[*PrivacyPolicy] {
+	policy := repository.GetCurrentPolicy()
+	if !policy.IsSuccess() {
+		if _, ok := policy.Error().(errors.NotFoundError); ok {
+			return result.Ok[*PrivacyPolicy](nil)
+		}
+		return result.Err[*PrivacyPolicy](policy.Error())
+	}
+	current := policy.Value()
+	return result.Ok(&current)
+}
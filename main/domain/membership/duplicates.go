@@ -0,0 +1,143 @@
+package membership
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+)
+
+const DefaultDuplicateThreshold = 50
+
+// Weights of the matching fields when scoring a pair of members, the score is capped at 100
+const (
+	sameTaxCodeScore   = 50
+	sameEmailScore     = 30
+	sameNameScore      = 30
+	sameLastNameScore  = 10
+	sameBirthDateScore = 20
+	samePhoneScore     = 20
+)
+
+// DuplicateCandidate is a pair of members that are likely the same person
+type DuplicateCandidate struct {
+	First   User
+	Second  User
+	Score   int
+	Reasons []string
+}
+
+// MergeResult reports what was moved from the source member to the target member
+type MergeResult struct {
+	SourceId                domain.Id[Member]
+	TargetId                domain.Id[Member]
+	MovedMembershipPeriods  int
+	MergedMembershipPeriods int // periods of seasons both members had, whose payments moved to the target period
+	MovedRentals            int
+	MovedWaitingListEntries int
+	MovedPhoneNumbers       int
+	MovedAddresses          int
+	MovedDocuments          int
+	MovedFiscalDocuments    int
+	MovedReminderLetters    int
+}
+
+// ScoreDuplicate scores how likely two users are the same person, with the reasons behind the score
+func ScoreDuplicate(a User, b User) (int, []string) {
+	score := 0
+	reasons := []string{}
+
+	if sameTaxCode(a.TaxCode, b.TaxCode) {
+		score += sameTaxCodeScore
+		reasons = append(reasons, "same tax code")
+	}
+
+	if a.Email != nil && b.Email != nil && a.Email.Equals(*b.Email) {
+		score += sameEmailScore
+		reasons = append(reasons, "same email")
+	}
+
+	firstA, lastA := normalizeName(a.FirstName), normalizeName(a.LastName)
+	firstB, lastB := normalizeName(b.FirstName), normalizeName(b.LastName)
+	switch {
+	case firstA+lastA == firstB+lastB || firstA+lastA == lastB+firstB:
+		score += sameNameScore
+		reasons = append(reasons, "same name")
+	case lastA != "" && lastA == lastB:
+		score += sameLastNameScore
+		reasons = append(reasons, "same last name")
+	}
+
+	if !a.BirthDate.IsZero() && a.BirthDate.Equal(b.BirthDate) {
+		score += sameBirthDateScore
+		reasons = append(reasons, "same birth date")
+	}
+
+	if sharePhoneNumber(a.PhoneNumbers, b.PhoneNumbers) {
+		score += samePhoneScore
+		reasons = append(reasons, "same phone number")
+	}
+
+	return min(score, 100), reasons
+}
+
+// FindDuplicates returns the pairs of users scoring at least the threshold, highest score first
+func FindDuplicates(users []User, threshold int) []DuplicateCandidate {
+	if threshold <= 0 {
+		threshold = DefaultDuplicateThreshold
+	}
+
+	candidates := []DuplicateCandidate{}
+	for i := 0; i < len(users); i++ {
+		for j := i + 1; j < len(users); j++ {
+			score, reasons := ScoreDuplicate(users[i], users[j])
+			if score >= threshold {
+				candidates = append(candidates, DuplicateCandidate{
+					First:   users[i],
+					Second:  users[j],
+					Score:   score,
+					Reasons: reasons,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	return candidates
+}
+
+func sameTaxCode(a *TaxCode, b *TaxCode) bool {
+	return a != nil && b != nil && a.Equals(*b)
+}
+
+func sharePhoneNumber(a []PhoneNumber, b []PhoneNumber) bool {
+	numbers := map[string]bool{}
+	for _, phone := range a {
+		if number := normalizePhoneNumber(phone.Number); number != "" {
+			numbers[number] = true
+		}
+	}
+	for _, phone := range b {
+		if numbers[normalizePhoneNumber(phone.Number)] {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePhoneNumber keeps the digits and drops the Italian international prefix
+func normalizePhoneNumber(number string) string {
+	var digits strings.Builder
+	for _, r := range number {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	normalized := strings.TrimPrefix(digits.String(), "00")
+	if strings.HasPrefix(normalized, "39") && len(normalized) > 10 {
+		normalized = normalized[2:]
+	}
+	return normalized
+}
@@ -0,0 +1,104 @@
+package membership
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+const AgeOfMajority = 18
+
+// Guardian is the legal guardian of a minor member
+// The guardian can be another member of the club or just a contact
+type Guardian struct {
+	MemberId     *domain.Id[User] // Set when the guardian is a member
+	FirstName    string
+	LastName     string
+	Email        *EmailAddress
+	PhoneNumber  *PhoneNumber
+	Relationship string // e.g. mother, father, tutor
+}
+
+func (g Guardian) IsMember() bool {
+	return g.MemberId != nil
+}
+
+// AgeAt returns the age of the user at the given date
+func (u User) AgeAt(date time.Time) int {
+	age := date.Year() - u.BirthDate.Year()
+	if date.Month() < u.BirthDate.Month() || (date.Month() == u.BirthDate.Month() && date.Day() < u.BirthDate.Day()) {
+		age--
+	}
+	return age
+}
+
+// IsMinorAt tells whether the user is under age at the given date
+func (u User) IsMinorAt(date time.Time) bool {
+	return !u.BirthDate.IsZero() && u.AgeAt(date) < AgeOfMajority
+}
+
+// ValidateGuardians checks that a minor at the given date has at least one guardian
+// and that every guardian can be contacted
+func (u User) ValidateGuardians(date time.Time) result.Result[User] {
+	if u.IsMinorAt(date) && len(u.Guardians) == 0 {
+		return result.Err[User](errors.GuardianError{Description: "minor members must have a guardian"})
+	}
+	for _, guardian := range u.Guardians {
+		if guardian.IsMember() {
+			if guardian.MemberId.Value == u.Id.Value {
+				return result.Err[User](errors.GuardianError{Description: "a member cannot be their own guardian"})
+			}
+			continue
+		}
+		if strings.TrimSpace(guardian.FirstName) == "" || strings.TrimSpace(guardian.LastName) == "" {
+			return result.Err[User](errors.GuardianError{Description: "guardian first and last name are required"})
+		}
+		if guardian.Email == nil && guardian.PhoneNumber == nil {
+			return result.Err[User](errors.GuardianError{Description: "guardian must have an email or a phone number"})
+		}
+	}
+	return result.Ok(u)
+}
+
+// GuardianMemberIds returns the ids of the guardians who are members of the club
+func (u User) GuardianMemberIds() []domain.Id[User] {
+	ids := []domain.Id[User]{}
+	for _, guardian := range u.Guardians {
+		if guardian.IsMember() {
+			ids = append(ids, *guardian.MemberId)
+		}
+	}
+	return ids
+}
+
+// ValidateGuardianMembers checks the guardians who are members against the members found, they must not have been
+// removed from the club and must be of age at the given date
+func (u User) ValidateGuardianMembers(members []User, date time.Time) result.Result[User] {
+	found := map[int64]User{}
+	for _, member := range members {
+		found[member.Id.Value] = member
+	}
+	for _, id := range u.GuardianMemberIds() {
+		member, ok := found[id.Value]
+		if !ok {
+			return result.Err[User](errors.GuardianError{Description: fmt.Sprintf("guardian member %d has been removed or does not exist", id.Value)})
+		}
+		if member.IsMinorAt(date) {
+			return result.Err[User](errors.GuardianError{Description: fmt.Sprintf("guardian member %d is a minor", id.Value)})
+		}
+	}
+	return result.Ok(u)
+}
+
+// IsMinor tells whether the member is under age at the start of the season of its membership,
+// or today when the member has no membership
+func (d MemberDetails) IsMinor() bool {
+	if len(d.Memberships) > 0 && d.Memberships[0].Status != nil && !d.Memberships[0].Status.GetValidFromDate().IsZero() {
+		return d.IsMinorAt(d.Memberships[0].Status.GetValidFromDate())
+	}
+	return d.IsMinorAt(time.Now())
+}
@@ -0,0 +1,94 @@
+package membership
+
+import (
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// Household groups the members of the same family, who share billing and the family discount
+type Household struct {
+	Id      domain.Id[Household]
+	Name    string
+	Members []User
+}
+
+// FamilyPricingScheme sets the membership price of the members of a household
+// The first member paying the membership in a season pays the full price, the following ones
+// pay the additional member price, or the child price when younger than ChildMaxAge
+type FamilyPricingScheme struct {
+	FullPrice             money.Money
+	AdditionalMemberPrice money.Money
+	ChildPrice            money.Money
+	ChildMaxAge           int // 0 disables the child price
+}
+
+// DefaultFamilyPricingScheme applies the suggested price to everybody, used when no scheme is configured
+var DefaultFamilyPricingScheme = FamilyPricingScheme{
+	FullPrice:             SuggestedMembershipPrice,
+	AdditionalMemberPrice: SuggestedMembershipPrice,
+	ChildPrice:            SuggestedMembershipPrice,
+}
+
+// FamilyPricingRule tells which price of the scheme was applied
+type FamilyPricingRule string
+
+const (
+	FullPriceRule        FamilyPricingRule = "FULL"
+	AdditionalMemberRule FamilyPricingRule = "ADDITIONAL_MEMBER"
+	ChildRule            FamilyPricingRule = "CHILD"
+)
+
+// MembershipPriceSuggestion is the suggested membership price with the rule behind it
+type MembershipPriceSuggestion struct {
+	Price         money.Money
+	FullPrice     money.Money
+	Rule          FamilyPricingRule
+	HouseholdId   *domain.Id[Household]
+	PayingMembers int // household members already holding a membership in the season
+}
+
+// Validate checks that prices are not negative, in the same currency, and discounts do not exceed the full price
+func (s FamilyPricingScheme) Validate() result.Result[FamilyPricingScheme] {
+	if s.FullPrice.IsNegative() || s.AdditionalMemberPrice.IsNegative() || s.ChildPrice.IsNegative() {
+		return result.Err[FamilyPricingScheme](errors.HouseholdError{Description: "prices cannot be negative"})
+	}
+	if !s.AdditionalMemberPrice.SameCurrency(s.FullPrice) || !s.ChildPrice.SameCurrency(s.FullPrice) {
+		return result.Err[FamilyPricingScheme](errors.CurrencyMismatchError{Description: "family prices must be in the currency of the full price"})
+	}
+	if s.FullPrice.LessThan(s.AdditionalMemberPrice) || s.FullPrice.LessThan(s.ChildPrice) {
+		return result.Err[FamilyPricingScheme](errors.HouseholdError{Description: "family prices cannot exceed the full price"})
+	}
+	if s.ChildMaxAge < 0 || s.ChildMaxAge > AgeOfMajority {
+		return result.Err[FamilyPricingScheme](errors.HouseholdError{Description: "child max age must be between 0 and 18"})
+	}
+	return result.Ok(s)
+}
+
+// SuggestPrice suggests the membership price of a member, given how many members of the household
+// already hold a membership in the season and the start date of the season
+func (s FamilyPricingScheme) SuggestPrice(member User, payingMembers int, seasonStart time.Time) MembershipPriceSuggestion {
+	suggestion := MembershipPriceSuggestion{
+		Price:         s.FullPrice,
+		FullPrice:     s.FullPrice,
+		Rule:          FullPriceRule,
+		HouseholdId:   member.HouseholdId,
+		PayingMembers: payingMembers,
+	}
+	if member.HouseholdId == nil || payingMembers <= 0 {
+		return suggestion
+	}
+
+	if s.ChildMaxAge > 0 && !member.BirthDate.IsZero() && member.AgeAt(seasonStart) < s.ChildMaxAge {
+		suggestion.Price = s.ChildPrice
+		suggestion.Rule = ChildRule
+		return suggestion
+	}
+
+	suggestion.Price = s.AdditionalMemberPrice
+	suggestion.Rule = AdditionalMemberRule
+	return suggestion
+}
@@ -0,0 +1,102 @@
+package membership
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+type HouseholdManagementService struct {
+	repository       HouseholdRepository
+	memberRepository MemberRepository
+}
+
+func NewHouseholdManagementService(repository HouseholdRepository, memberRepository MemberRepository) *HouseholdManagementService {
+	return &HouseholdManagementService{repository: repository, memberRepository: memberRepository}
+}
+
+func (this HouseholdManagementService) GetAllHouseholds() result.Result[[]Household] {
+	return this.repository.GetAllHouseholds()
+}
+
+func (this HouseholdManagementService) GetHouseholdById(id domain.Id[Household]) result.Result[Household] {
+	return this.repository.GetHouseholdById(id)
+}
+
+func (this HouseholdManagementService) CreateHousehold(name string, memberIds []domain.Id[Member]) result.Result[Household] {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return result.Err[Household](errors.HouseholdError{Description: "household name is required"})
+	}
+	return this.repository.CreateHousehold(name, memberIds)
+}
+
+// DeleteHousehold deletes the household, its members are kept without a household
+func (this HouseholdManagementService) DeleteHousehold(id domain.Id[Household]) result.Result[bool] {
+	return this.repository.DeleteHousehold(id)
+}
+
+func (this HouseholdManagementService) AddMember(id domain.Id[Household], memberId domain.Id[Member]) result.Result[Household] {
+	return this.repository.AddMember(id, memberId)
+}
+
+func (this HouseholdManagementService) RemoveMember(id domain.Id[Household], memberId domain.Id[Member]) result.Result[bool] {
+	return this.repository.RemoveMember(id, memberId)
+}
+
+func (this HouseholdManagementService) GetFamilyPricingScheme() result.Result[FamilyPricingScheme] {
+	return this.repository.GetFamilyPricingScheme()
+}
+
+func (this HouseholdManagementService) UpdateFamilyPricingScheme(scheme FamilyPricingScheme) result.Result[FamilyPricingScheme] {
+	return result.Bind(scheme.Validate(), this.repository.SaveFamilyPricingScheme)
+}
+
+// SuggestMembershipPrice suggests the membership price of an existing member for the season
+func (this HouseholdManagementService) SuggestMembershipPrice(memberId domain.Id[Member], seasonId int64) result.Result[MembershipPriceSuggestion] {
+	details := this.memberRepository.GetMemberById(memberId, seasonId)
+	if !details.IsSuccess() {
+		return result.Err[MembershipPriceSuggestion](details.Error())
+	}
+	return this.suggestPrice(details.Value().User, &memberId, seasonId)
+}
+
+// SuggestNewMemberPrice suggests the membership price of somebody joining the household, not yet a member
+func (this HouseholdManagementService) SuggestNewMemberPrice(householdId *domain.Id[Household], birthDate time.Time, seasonId int64) result.Result[MembershipPriceSuggestion] {
+	return this.suggestPrice(User{BirthDate: birthDate, HouseholdId: householdId}, nil, seasonId)
+}
+
+func (this HouseholdManagementService) suggestPrice(user User, memberId *domain.Id[Member], seasonId int64) result.Result[MembershipPriceSuggestion] {
+	return suggestFamilyPrice(this.repository, this.memberRepository, user, memberId, seasonId)
+}
+
+// suggestFamilyPrice suggests the price of the member under the active family pricing scheme,
+// counting the other members of the household already holding a membership in the season
+func suggestFamilyPrice(
+	households HouseholdRepository,
+	members MemberRepository,
+	user User,
+	memberId *domain.Id[Member],
+	seasonId int64,
+) result.Result[MembershipPriceSuggestion] {
+	scheme := households.GetFamilyPricingScheme()
+	if !scheme.IsSuccess() {
+		return result.Err[MembershipPriceSuggestion](scheme.Error())
+	}
+	if user.HouseholdId == nil {
+		return result.Ok(scheme.Value().SuggestPrice(user, 0, time.Time{}))
+	}
+
+	seasonStart := members.GetSeasonStartDate(seasonId)
+	if !seasonStart.IsSuccess() {
+		return result.Err[MembershipPriceSuggestion](seasonStart.Error())
+	}
+	payingMembers := households.CountPayingMembers(*user.HouseholdId, seasonId, memberId)
+	if !payingMembers.IsSuccess() {
+		return result.Err[MembershipPriceSuggestion](payingMembers.Error())
+	}
+	return result.Ok(scheme.Value().SuggestPrice(user, payingMembers.Value(), seasonStart.Value()))
+}
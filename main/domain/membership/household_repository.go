@@ -0,0 +1,23 @@
+package membership
+
+import (
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+type HouseholdRepository interface {
+	GetAllHouseholds() result.Result[[]Household]
+	GetHouseholdById(id domain.Id[Household]) result.Result[Household]
+	CreateHousehold(name string, memberIds []domain.Id[Member]) result.Result[Household]
+	DeleteHousehold(id domain.Id[Household]) result.Result[bool]
+	// AddMember moves the member to the household, leaving any previous household
+	AddMember(id domain.Id[Household], memberId domain.Id[Member]) result.Result[Household]
+	RemoveMember(id domain.Id[Household], memberId domain.Id[Member]) result.Result[bool]
+	// CountPayingMembers counts the members of the household holding a membership in the season,
+	// the excluded member is not counted
+	CountPayingMembers(id domain.Id[Household], seasonId int64, excluded *domain.Id[Member]) result.Result[int]
+	// GetFamilyPricingScheme returns the active scheme, DefaultFamilyPricingScheme when none is configured
+	GetFamilyPricingScheme() result.Result[FamilyPricingScheme]
+	// SaveFamilyPricingScheme replaces the active scheme, keeping the previous ones as history
+	SaveFamilyPricingScheme(scheme FamilyPricingScheme) result.Result[FamilyPricingScheme]
+}
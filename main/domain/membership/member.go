@@ -1,17 +1,33 @@
 package membership
 
 import (
+	"strings"
 	"time"
 
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
 )
 
+// Placeholders stored in place of the personal data of removed members
+const (
+	RemovedMemberFirstName = "Removed"
+	RemovedMemberLastName  = "Member"
+)
+
+var RemovedMemberBirthDate = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+
 type Member struct {
 	User
 	Membership          Membership
 	HasUnpaidFacilities bool
 	HasRentedFacilities bool
+	HasFlaggedRentals   bool // Rentals of the season flagged for review by the membership expiry
+	// Set by FlagOverdue against the payment deadlines
+	MembershipOverdue    bool
+	HasOverdueFacilities bool
+	LateFees             money.Money
 }
 
 type MemberDetails struct {
@@ -19,6 +35,44 @@ type MemberDetails struct {
 	Memberships []Membership
 }
 
+// FlagOverdue marks the members whose membership or rentals are still owed after their deadline, with the late fees accrued
+// Items are matched by member, so they should be of the season the members are listed for
+// CurrencyMismatchError when the late fees of a member are not all in the same currency
+func FlagOverdue(members []Member, overdue []payment.OverdueItem) result.Result[[]Member] {
+	byMember := map[int64][]payment.OverdueItem{}
+	for _, item := range overdue {
+		byMember[item.Item.MemberId] = append(byMember[item.Item.MemberId], item)
+	}
+
+	flagged := make([]Member, len(members))
+	for i, member := range members {
+		member.LateFees = money.Zero(money.DefaultCurrency)
+		for j, item := range byMember[member.Id.Value] {
+			if item.Item.Target == payment.MembershipTarget {
+				member.MembershipOverdue = true
+			} else {
+				member.HasOverdueFacilities = true
+			}
+			if j == 0 {
+				member.LateFees = item.LateFee
+				continue
+			}
+			lateFees := member.LateFees.Add(item.LateFee)
+			if !lateFees.IsSuccess() {
+				return result.Err[[]Member](lateFees.Error())
+			}
+			member.LateFees = lateFees.Value()
+		}
+		flagged[i] = member
+	}
+	return result.Ok(flagged)
+}
+
+// IsOverdue tells whether the member owes something after its deadline
+func (m Member) IsOverdue() bool {
+	return m.MembershipOverdue || m.HasOverdueFacilities
+}
+
 func (m Member) IsActive() bool {
 	return m.Membership.Status.GetStatus() == MembershipStatusActive
 }
@@ -39,12 +93,62 @@ func (m Member) RenewMembership() result.Result[Member] {
 	})
 }
 
-func (m Member) Exclude(decisionDate time.Time) result.Result[Member] {
+func (m Member) Suspend() result.Result[Member] {
 	if m.Membership.Status.GetStatus() != MembershipStatusActive {
-		return result.Err[Member](errors.MembershipStatusError{Description: "only members who are active can be excluded"})
+		return result.Err[Member](errors.MembershipStatusError{Description: "only active members can be suspended"})
+	}
+	return result.Ok(Member{
+		User:       m.User,
+		Membership: SuspendedMembership(m.Membership),
+	})
+}
+
+func (m Member) Reinstate() result.Result[Member] {
+	if m.Membership.Status.GetStatus() != MembershipStatusSuspended {
+		return result.Err[Member](errors.MembershipStatusError{Description: "only suspended members can be reinstated"})
 	}
 	return result.Ok(Member{
 		User:       m.User,
-		Membership: ExcludedMembership(m.Membership, decisionDate),
+		Membership: ReinstatedMembership(m.Membership),
 	})
 }
+
+// Exclude records the board decision to exclude the member
+// Both active and suspended members can be excluded, and the exclusion is definitive
+func (m Member) Exclude(decisionDate time.Time, reason string) result.Result[Member] {
+	status := m.Membership.Status.GetStatus()
+	if status != MembershipStatusActive && status != MembershipStatusSuspended {
+		return result.Err[Member](errors.MembershipStatusError{Description: "only active or suspended members can be excluded"})
+	}
+	if decisionDate.IsZero() {
+		return result.Err[Member](errors.MembershipStatusError{Description: "exclusion decision date is required"})
+	}
+	if strings.TrimSpace(reason) == "" {
+		return result.Err[Member](errors.MembershipStatusError{Description: "exclusion reason is required"})
+	}
+	return result.Ok(Member{
+		User:       m.User,
+		Membership: ExcludedMembership(m.Membership, decisionDate, strings.TrimSpace(reason)),
+	})
+}
+
+// Expire ends a membership that has not been renewed
+func (m Member) Expire() result.Result[Member] {
+	status := m.Membership.Status.GetStatus()
+	if status != MembershipStatusActive && status != MembershipStatusSuspended {
+		return result.Err[Member](errors.MembershipStatusError{Description: "only active or suspended memberships can expire"})
+	}
+	return result.Ok(Member{
+		User:       m.User,
+		Membership: ExpiredMembership(m.Membership),
+	})
+}
+
+// Remove checks that the member can be removed definitively from the club
+// Removal is allowed only after the exclusion has been deliberated
+func (m Member) Remove() result.Result[Member] {
+	if m.Membership.Status.GetStatus() != MembershipStatusExcluded {
+		return result.Err[Member](errors.MembershipStatusError{Description: "only excluded members can be removed"})
+	}
+	return result.Ok(m)
+}
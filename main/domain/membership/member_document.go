@@ -0,0 +1,185 @@
+package membership
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+type DocumentType string
+
+const (
+	MedicalCertificateDocument DocumentType = "MEDICAL_CERTIFICATE"
+	IdDocument                 DocumentType = "ID_DOCUMENT"
+	PrivacyConsentDocument     DocumentType = "PRIVACY_CONSENT"
+	SignedFormDocument         DocumentType = "SIGNED_FORM"
+	OtherDocument              DocumentType = "OTHER"
+)
+
+// MaxDocumentSize is the largest file accepted, in bytes
+const MaxDocumentSize int64 = 10 << 20
+
+var documentContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+// MemberDocument is a file kept for a member, the content is in the document storage under StorageKey
+type MemberDocument struct {
+	Id          domain.Id[MemberDocument]
+	UserId      domain.Id[User]
+	Type        DocumentType
+	FileName    string
+	ContentType string
+	Size        int64
+	StorageKey  string
+	IssuedAt    *time.Time
+	ExpiresAt   *time.Time
+	Notes       string
+	UploadedAt  time.Time
+}
+
+// DocumentUpload describes a file being uploaded for a member
+type DocumentUpload struct {
+	Type        DocumentType
+	FileName    string
+	ContentType string
+	Size        int64
+	IssuedAt    *time.Time
+	ExpiresAt   *time.Time
+	Notes       string
+}
+
+// DocumentContent is a document with its content, to be closed by the reader
+type DocumentContent struct {
+	Document MemberDocument
+	Content  io.ReadCloser
+}
+
+type CertificateStatus string
+
+const (
+	CertificateValid   CertificateStatus = "VALID"
+	CertificateMissing CertificateStatus = "MISSING"
+	CertificateExpired CertificateStatus = "EXPIRED"
+)
+
+// CertificateCheck is the medical certificate situation of a member of a season
+type CertificateCheck struct {
+	User      User
+	ExpiresAt *time.Time // Expiry of the latest certificate, nil when the member has none
+	Status    CertificateStatus
+}
+
+// DocumentStorage keeps the content of the documents, keys are slash separated paths
+type DocumentStorage interface {
+	Save(key string, content io.Reader) error
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// UndeletedFile is the content of a deleted document the storage failed to delete, left behind to be deleted by hand
+type UndeletedFile struct {
+	StorageKey string
+	Reason     string
+}
+
+// DocumentFilesDeletion is the outcome of deleting the contents of documents already deleted
+type DocumentFilesDeletion struct {
+	DeletedFiles   int
+	UndeletedFiles []UndeletedFile
+}
+
+// deleteDocumentFiles deletes the contents stored under the keys, going on past the ones the storage fails to delete
+// Without a storage every content is left behind
+func deleteDocumentFiles(storage DocumentStorage, keys []string) DocumentFilesDeletion {
+	deletion := DocumentFilesDeletion{UndeletedFiles: []UndeletedFile{}}
+	for _, key := range keys {
+		if storage == nil {
+			deletion.UndeletedFiles = append(deletion.UndeletedFiles, UndeletedFile{StorageKey: key, Reason: "document storage unavailable"})
+			continue
+		}
+		if err := storage.Delete(key); err != nil {
+			deletion.UndeletedFiles = append(deletion.UndeletedFiles, UndeletedFile{StorageKey: key, Reason: err.Error()})
+			continue
+		}
+		deletion.DeletedFiles++
+	}
+	return deletion
+}
+
+type MemberDocumentRepository interface {
+	GetDocuments(userId domain.Id[User]) result.Result[[]MemberDocument]
+	GetDocument(userId domain.Id[User], id domain.Id[MemberDocument]) result.Result[MemberDocument]
+	// CreateDocument stores the document metadata, NotFoundError when the member does not exist or was removed
+	CreateDocument(document MemberDocument) result.Result[MemberDocument]
+	DeleteDocument(userId domain.Id[User], id domain.Id[MemberDocument]) result.Result[bool]
+	// GetCertificateExpiries returns the members holding a membership in the season, excluded ones aside,
+	// with the expiry of their latest medical certificate
+	GetCertificateExpiries(seasonId int64) result.Result[[]CertificateCheck]
+}
+
+func (u DocumentUpload) Validate() result.Result[DocumentUpload] {
+	u.FileName = strings.TrimSpace(filepath.Base(strings.ReplaceAll(u.FileName, "\\", "/")))
+	u.Notes = strings.TrimSpace(u.Notes)
+
+	switch u.Type {
+	case MedicalCertificateDocument, IdDocument, PrivacyConsentDocument, SignedFormDocument, OtherDocument:
+	default:
+		return result.Err[DocumentUpload](errors.DocumentError{Description: "unknown document type " + string(u.Type)})
+	}
+	if u.FileName == "" || u.FileName == "." || u.FileName == "/" {
+		return result.Err[DocumentUpload](errors.DocumentError{Description: "file name is required"})
+	}
+	if !documentContentTypes[u.ContentType] {
+		return result.Err[DocumentUpload](errors.DocumentError{Description: "only PDF, JPEG and PNG files are accepted"})
+	}
+	if u.Size <= 0 {
+		return result.Err[DocumentUpload](errors.DocumentError{Description: "file is empty"})
+	}
+	if u.Size > MaxDocumentSize {
+		return result.Err[DocumentUpload](errors.DocumentError{Description: "file is larger than 10 MB"})
+	}
+	if u.Type == MedicalCertificateDocument && u.ExpiresAt == nil {
+		return result.Err[DocumentUpload](errors.DocumentError{Description: "medical certificates require an expiry date"})
+	}
+	if u.IssuedAt != nil && u.ExpiresAt != nil && u.ExpiresAt.Before(*u.IssuedAt) {
+		return result.Err[DocumentUpload](errors.DocumentError{Description: "expiry date cannot precede the issue date"})
+	}
+	return result.Ok(u)
+}
+
+// IsValidAt tells whether the document has not expired at the given date, documents without expiry never do
+func (d MemberDocument) IsValidAt(date time.Time) bool {
+	return d.ExpiresAt == nil || !d.ExpiresAt.Before(truncateToDay(date))
+}
+
+// CheckCertificate tells whether a certificate expiring at the given date, nil when missing, is valid at the reference date
+func CheckCertificate(expiresAt *time.Time, at time.Time) CertificateStatus {
+	if expiresAt == nil {
+		return CertificateMissing
+	}
+	if expiresAt.Before(truncateToDay(at)) {
+		return CertificateExpired
+	}
+	return CertificateValid
+}
+
+// CertificateReferenceDate is the date certificates are checked at for a season:
+// the start of the season, or today once the season has started
+func CertificateReferenceDate(seasonStart time.Time, today time.Time) time.Time {
+	if today.After(seasonStart) {
+		return today
+	}
+	return seasonStart
+}
+
+func truncateToDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
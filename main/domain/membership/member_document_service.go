@@ -0,0 +1,118 @@
+package membership
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+type MemberDocumentService struct {
+	repository       MemberDocumentRepository
+	storage          DocumentStorage
+	memberRepository MemberRepository
+}
+
+func NewMemberDocumentService(repository MemberDocumentRepository, storage DocumentStorage, memberRepository MemberRepository) *MemberDocumentService {
+	return &MemberDocumentService{repository: repository, storage: storage, memberRepository: memberRepository}
+}
+
+func (this MemberDocumentService) GetDocuments(userId domain.Id[User]) result.Result[[]MemberDocument] {
+	return this.repository.GetDocuments(userId)
+}
+
+// UploadDocument stores the content first and the metadata then, the content is removed if the metadata cannot be saved
+func (this MemberDocumentService) UploadDocument(userId domain.Id[User], upload DocumentUpload, content io.Reader) result.Result[MemberDocument] {
+	validated := upload.Validate()
+	if !validated.IsSuccess() {
+		return result.Err[MemberDocument](validated.Error())
+	}
+	upload = validated.Value()
+
+	key, err := documentStorageKey(userId, upload.FileName)
+	if err != nil {
+		return result.Err[MemberDocument](err)
+	}
+	if err := this.storage.Save(key, io.LimitReader(content, MaxDocumentSize)); err != nil {
+		return result.Err[MemberDocument](errors.RepositoryError{Description: "failed to store document: " + err.Error()})
+	}
+
+	created := this.repository.CreateDocument(MemberDocument{
+		UserId:      userId,
+		Type:        upload.Type,
+		FileName:    upload.FileName,
+		ContentType: upload.ContentType,
+		Size:        upload.Size,
+		StorageKey:  key,
+		IssuedAt:    upload.IssuedAt,
+		ExpiresAt:   upload.ExpiresAt,
+		Notes:       upload.Notes,
+	})
+	if !created.IsSuccess() {
+		_ = this.storage.Delete(key)
+	}
+	return created
+}
+
+// OpenDocument returns the document with its content, the caller closes the content
+func (this MemberDocumentService) OpenDocument(userId domain.Id[User], id domain.Id[MemberDocument]) result.Result[DocumentContent] {
+	return result.Bind(this.repository.GetDocument(userId, id), func(document MemberDocument) result.Result[DocumentContent] {
+		content, err := this.storage.Open(document.StorageKey)
+		if err != nil {
+			return result.Err[DocumentContent](errors.RepositoryError{Description: "failed to open document: " + err.Error()})
+		}
+		return result.Ok(DocumentContent{Document: document, Content: content})
+	})
+}
+
+// DeleteDocument removes the metadata and then the content, reporting the content when the storage fails to delete it
+func (this MemberDocumentService) DeleteDocument(userId domain.Id[User], id domain.Id[MemberDocument]) result.Result[DocumentFilesDeletion] {
+	document := this.repository.GetDocument(userId, id)
+	if !document.IsSuccess() {
+		return result.Err[DocumentFilesDeletion](document.Error())
+	}
+	return result.Map(this.repository.DeleteDocument(userId, id), func(bool) DocumentFilesDeletion {
+		return deleteDocumentFiles(this.storage, []string{document.Value().StorageKey})
+	})
+}
+
+// GetMissingCertificates returns the members of the season whose medical certificate is missing or expired,
+// checked at the start of the season or today once the season has started
+func (this MemberDocumentService) GetMissingCertificates(seasonId int64, today time.Time) result.Result[[]CertificateCheck] {
+	seasonStart := this.memberRepository.GetSeasonStartDate(seasonId)
+	if !seasonStart.IsSuccess() {
+		return result.Err[[]CertificateCheck](seasonStart.Error())
+	}
+	referenceDate := CertificateReferenceDate(seasonStart.Value(), today)
+
+	return result.Map(this.repository.GetCertificateExpiries(seasonId), func(checks []CertificateCheck) []CertificateCheck {
+		missing := []CertificateCheck{}
+		for _, check := range checks {
+			check.Status = CheckCertificate(check.ExpiresAt, referenceDate)
+			if check.Status != CertificateValid {
+				missing = append(missing, check)
+			}
+		}
+		return missing
+	})
+}
+
+// documentStorageKey builds a unique key for the document, keeping the extension of the file
+func documentStorageKey(userId domain.Id[User], fileName string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", errors.RepositoryError{Description: "failed to generate document key: " + err.Error()}
+	}
+	extension := strings.ToLower(path.Ext(fileName))
+	if len(extension) > 10 || strings.ContainsAny(extension, "/\\ ") {
+		extension = ""
+	}
+	return fmt.Sprintf("members/%d/%s%s", userId.Value, hex.EncodeToString(random), extension), nil
+}
@@ -1,16 +1,36 @@
 package membership
 
 import (
+	"time"
+
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
 )
 
 type MemberManagementService struct {
-	repository MemberRepository
+	repository          MemberRepository
+	categoryRepository  MembershipCategoryRepository
+	consentRepository   ConsentRepository
+	householdRepository HouseholdRepository
+	documentStorage     DocumentStorage
 }
 
-func NewMemberManagementService(repository MemberRepository) *MemberManagementService {
-	return &MemberManagementService{repository: repository}
+func NewMemberManagementService(
+	repository MemberRepository,
+	categoryRepository MembershipCategoryRepository,
+	consentRepository ConsentRepository,
+	householdRepository HouseholdRepository,
+	documentStorage DocumentStorage,
+) *MemberManagementService {
+	return &MemberManagementService{
+		repository:          repository,
+		categoryRepository:  categoryRepository,
+		consentRepository:   consentRepository,
+		householdRepository: householdRepository,
+		documentStorage:     documentStorage,
+	}
 }
 
 func (this MemberManagementService) GetListOfAllMembers() result.Result[[]Member] {
@@ -21,6 +41,10 @@ func (this MemberManagementService) GetListOfMembersBySeason(seasonId int64) res
 	return this.repository.GetMembersBySeason(seasonId)
 }
 
+func (this MemberManagementService) SearchMembers(criteria MemberSearchCriteria) result.Result[MemberPage] {
+	return this.repository.SearchMembers(criteria.Normalized())
+}
+
 func (this MemberManagementService) GetMemberById(id domain.Id[Member], season int64) result.Result[MemberDetails] {
 	return this.repository.GetMemberById(id, season)
 }
@@ -33,14 +57,256 @@ func (this MemberManagementService) GetMembersWhoDidNotPayForMembership() []Memb
 	return this.repository.GetMembersWhoDidNotPayForMembership()
 }
 
-func (this MemberManagementService) CreateMember(user User, createMembership bool, seasonId *int64, price *float64) result.Result[MemberDetails] {
-	return this.repository.CreateMember(user, createMembership, seasonId, price)
+// CreateMember stores a new member, minors at the start of the membership season must have a guardian
+// and the required consents must be granted for the current privacy policy
+// The membership price defaults to the price of the category in the season, or to the family pricing
+// scheme for the family category
+func (this MemberManagementService) CreateMember(user User, createMembership bool, seasonId *int64, price *money.Money, category MembershipCategoryCode) result.Result[MemberDetails] {
+	consents := this.validateConsents(user.Consents)
+	if !consents.IsSuccess() {
+		return result.Err[MemberDetails](consents.Error())
+	}
+	user.Consents = consents.Value()
+
+	if !createMembership || seasonId == nil {
+		return result.Bind(this.validateGuardians(user, result.Ok(time.Now())), func(user User) result.Result[MemberDetails] {
+			return this.repository.CreateMember(user, createMembership, seasonId, price, MembershipCategory{})
+		})
+	}
+
+	categoryPrice := this.getCategoryPrice(category, *seasonId, user, nil)
+	if !categoryPrice.IsSuccess() {
+		return result.Err[MemberDetails](categoryPrice.Error())
+	}
+	membershipPrice := categoryPrice.Value().SuggestedPrice()
+	if price != nil {
+		membershipPrice = *price
+	}
+
+	return result.Bind(this.validateGuardians(user, this.repository.GetSeasonStartDate(*seasonId)), func(user User) result.Result[MemberDetails] {
+		return this.repository.CreateMember(user, createMembership, seasonId, &membershipPrice, categoryPrice.Value().Category)
+	})
+}
+
+// AddMembership adds a membership for the season, a minor at the start of the season must have a guardian
+// The price defaults to the price of the category in the season, or to the family pricing scheme
+// for the family category
+func (this MemberManagementService) AddMembership(memberId domain.Id[Member], seasonId int64, price *money.Money, category MembershipCategoryCode) result.Result[MemberDetails] {
+	details := this.repository.GetMemberById(memberId, seasonId)
+	if !details.IsSuccess() {
+		return details
+	}
+
+	categoryPrice := this.getCategoryPrice(category, seasonId, details.Value().User, &memberId)
+	if !categoryPrice.IsSuccess() {
+		return result.Err[MemberDetails](categoryPrice.Error())
+	}
+	membershipPrice := categoryPrice.Value().SuggestedPrice()
+	if price != nil {
+		membershipPrice = *price
+	}
+
+	validated := this.validateGuardians(details.Value().User, this.repository.GetSeasonStartDate(seasonId))
+	return result.Bind(validated, func(_ User) result.Result[MemberDetails] {
+		return this.repository.AddMembership(memberId, seasonId, membershipPrice, categoryPrice.Value().Category)
+	})
+}
+
+// GetMembershipCategories returns the categories with their price in the season
+func (this MemberManagementService) GetMembershipCategories(seasonId int64) result.Result[[]MembershipCategoryPrice] {
+	return this.categoryRepository.GetCategoryPrices(seasonId)
 }
 
-func (this MemberManagementService) AddMembership(memberId domain.Id[Member], seasonId int64, price float64) result.Result[MemberDetails] {
-	return this.repository.AddMembership(memberId, seasonId, price)
+// SetMembershipCategoryPrice sets the price of a category in the season
+// The family category is priced by the family pricing scheme instead
+func (this MemberManagementService) SetMembershipCategoryPrice(category MembershipCategoryCode, seasonId int64, price money.Money) result.Result[MembershipCategoryPrice] {
+	if category == FamilyCategory {
+		return result.Err[MembershipCategoryPrice](errors.MembershipCategoryError{Description: "the family category is priced by the family pricing scheme"})
+	}
+	if price.IsNegative() {
+		return result.Err[MembershipCategoryPrice](errors.MembershipCategoryError{Description: "price cannot be negative"})
+	}
+	return this.categoryRepository.SetCategoryPrice(category, seasonId, price)
 }
 
+// SuggestMembershipCategories returns the categories of the season with their suggested price,
+// flagging the category fitting the member when one is given
+func (this MemberManagementService) SuggestMembershipCategories(seasonId int64, memberId *domain.Id[Member]) result.Result[[]MembershipCategorySuggestion] {
+	categories := this.categoryRepository.GetCategoryPrices(seasonId)
+	if !categories.IsSuccess() {
+		return result.Err[[]MembershipCategorySuggestion](categories.Error())
+	}
+
+	// Without a member, the family category is priced as for a further member of a household
+	scheme := this.householdRepository.GetFamilyPricingScheme()
+	if !scheme.IsSuccess() {
+		return result.Err[[]MembershipCategorySuggestion](scheme.Error())
+	}
+	familyPrice := scheme.Value().AdditionalMemberPrice
+
+	suggested := MembershipCategoryCode("")
+	if memberId != nil {
+		details := this.repository.GetMemberById(*memberId, seasonId)
+		if !details.IsSuccess() {
+			return result.Err[[]MembershipCategorySuggestion](details.Error())
+		}
+		seasonStart := this.repository.GetSeasonStartDate(seasonId)
+		if !seasonStart.IsSuccess() {
+			return result.Err[[]MembershipCategorySuggestion](seasonStart.Error())
+		}
+		familySuggestion := suggestFamilyPrice(this.householdRepository, this.repository, details.Value().User, memberId, seasonId)
+		if !familySuggestion.IsSuccess() {
+			return result.Err[[]MembershipCategorySuggestion](familySuggestion.Error())
+		}
+		familyPrice = familySuggestion.Value().Price
+		suggested = SuggestCategory(details.Value().User, seasonStart.Value(), familySuggestion.Value().PayingMembers)
+	}
+
+	suggestions := make([]MembershipCategorySuggestion, len(categories.Value()))
+	for i, category := range categories.Value() {
+		category = category.WithFamilyPrice(familyPrice)
+		suggestions[i] = MembershipCategorySuggestion{
+			MembershipCategoryPrice: category,
+			SuggestedPrice:          category.SuggestedPrice(),
+			Suggested:               category.Category.Code == suggested,
+		}
+	}
+	return result.Ok(suggestions)
+}
+
+// getCategoryPrice returns the category with its price in the season,
+// the price of the family category is the one suggested to the user by the family pricing scheme
+func (this MemberManagementService) getCategoryPrice(category MembershipCategoryCode, seasonId int64, user User, memberId *domain.Id[Member]) result.Result[MembershipCategoryPrice] {
+	if category == "" {
+		category = OrdinaryCategory
+	}
+	categoryPrice := this.categoryRepository.GetCategoryPrice(category, seasonId)
+	if !categoryPrice.IsSuccess() || category != FamilyCategory {
+		return categoryPrice
+	}
+	return result.Map(suggestFamilyPrice(this.householdRepository, this.repository, user, memberId, seasonId), func(suggestion MembershipPriceSuggestion) MembershipCategoryPrice {
+		return categoryPrice.Value().WithFamilyPrice(suggestion.Price)
+	})
+}
+
+// UpdateMember updates the personal data, a minor at the start of the season must keep a guardian
 func (this MemberManagementService) UpdateMember(id domain.Id[Member], user User, season int64) result.Result[MemberDetails] {
-	return this.repository.UpdateMember(id, user, season)
+	user.Id = domain.Id[User]{Value: id.Value}
+	return result.Bind(this.validateGuardians(user, this.repository.GetSeasonStartDate(season)), func(user User) result.Result[MemberDetails] {
+		return this.repository.UpdateMember(id, user, season)
+	})
+}
+
+func (this MemberManagementService) validateConsents(consents []Consent) result.Result[[]Consent] {
+	return result.Bind(currentPrivacyPolicy(this.consentRepository), func(policy *PrivacyPolicy) result.Result[[]Consent] {
+		return ValidateRequiredConsents(consents, policy)
+	})
+}
+
+// validateGuardians checks the guardians of the user at the reference date, those who are members are loaded to check
+// that they are still in the club and of age
+func (this MemberManagementService) validateGuardians(user User, referenceDate result.Result[time.Time]) result.Result[User] {
+	return result.Bind(referenceDate, func(date time.Time) result.Result[User] {
+		return result.Bind(user.ValidateGuardians(date), func(user User) result.Result[User] {
+			ids := user.GuardianMemberIds()
+			if len(ids) == 0 {
+				return result.Ok(user)
+			}
+			return result.Bind(this.repository.GetGuardianMembers(ids), func(members []User) result.Result[User] {
+				return user.ValidateGuardianMembers(members, date)
+			})
+		})
+	})
+}
+
+func (this MemberManagementService) SuspendMembership(id domain.Id[Member], season int64) result.Result[MemberDetails] {
+	return this.changeMembershipStatus(id, season, Member.Suspend)
+}
+
+func (this MemberManagementService) ReinstateMembership(id domain.Id[Member], season int64) result.Result[MemberDetails] {
+	return this.changeMembershipStatus(id, season, Member.Reinstate)
+}
+
+func (this MemberManagementService) ExcludeMember(id domain.Id[Member], season int64, decisionDate time.Time, reason string) result.Result[MemberDetails] {
+	return this.changeMembershipStatus(id, season, func(member Member) result.Result[Member] {
+		return member.Exclude(decisionDate, reason)
+	})
+}
+
+func (this MemberManagementService) ExpireMembership(id domain.Id[Member], season int64) result.Result[MemberDetails] {
+	return this.changeMembershipStatus(id, season, Member.Expire)
+}
+
+// RemoveMember removes the member definitively, once the exclusion has been deliberated in the given season
+// Personal data is anonymised while the financial history is kept, and the exclusion extends to the
+// periods of other seasons still active or suspended
+// Documents are deleted along with their files, deleting the files is attempted once the removal is stored
+// The files that could not be deleted, because the storage failed or is unavailable, are reported
+func (this MemberManagementService) RemoveMember(id domain.Id[Member], season int64) result.Result[DocumentFilesDeletion] {
+	details := this.repository.GetMemberById(id, season)
+	if !details.IsSuccess() {
+		return result.Err[DocumentFilesDeletion](details.Error())
+	}
+
+	if len(details.Value().Memberships) == 0 {
+		return result.Err[DocumentFilesDeletion](errors.NotFoundError{Description: "member has no membership in this season"})
+	}
+
+	member := Member{
+		User:       details.Value().User,
+		Membership: details.Value().Memberships[0],
+	}
+
+	return result.Bind(member.Remove(), func(member Member) result.Result[DocumentFilesDeletion] {
+		return result.Map(this.repository.RemoveMember(id, member.Membership.Status.(Excluded)), func(storageKeys []string) DocumentFilesDeletion {
+			return deleteDocumentFiles(this.documentStorage, storageKeys)
+		})
+	})
+}
+
+// FindDuplicates returns the pairs of members that are likely the same person
+func (this MemberManagementService) FindDuplicates(threshold int) result.Result[[]DuplicateCandidate] {
+	return result.Map(this.repository.GetUsersForDuplicateCheck(), func(users []User) []DuplicateCandidate {
+		return FindDuplicates(users, threshold)
+	})
+}
+
+// MergeMembers merges the source member into the target member, which keeps its membership number
+// When both members have a period in the same season, the target period is kept and receives the payments
+func (this MemberManagementService) MergeMembers(sourceId domain.Id[Member], targetId domain.Id[Member]) result.Result[MergeResult] {
+	if sourceId.Value == targetId.Value {
+		return result.Err[MergeResult](errors.MergeError{Description: "cannot merge a member into itself"})
+	}
+	return this.repository.MergeMembers(sourceId, targetId)
+}
+
+// changeMembershipStatus applies a status transition to the member's membership of the given season
+// and stores the new status, returning the updated member details
+func (this MemberManagementService) changeMembershipStatus(
+	id domain.Id[Member],
+	season int64,
+	transition func(Member) result.Result[Member],
+) result.Result[MemberDetails] {
+	details := this.repository.GetMemberById(id, season)
+	if !details.IsSuccess() {
+		return details
+	}
+
+	if len(details.Value().Memberships) == 0 {
+		return result.Err[MemberDetails](errors.NotFoundError{Description: "member has no membership in this season"})
+	}
+
+	member := Member{
+		User:       details.Value().User,
+		Membership: details.Value().Memberships[0],
+	}
+
+	updated := result.Bind(transition(member), func(member Member) result.Result[Membership] {
+		return this.repository.UpdateMembershipStatus(member.Membership)
+	})
+	if !updated.IsSuccess() {
+		return result.Err[MemberDetails](updated.Error())
+	}
+
+	return this.repository.GetMemberById(id, season)
 }
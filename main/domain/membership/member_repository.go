@@ -1,7 +1,10 @@
 package membership
 
 import (
+	"time"
+
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
 )
 
@@ -9,9 +12,27 @@ type MemberRepository interface {
 	GetAllMembers() result.Result[[]Member]
 	GetMemberById(id domain.Id[Member], season int64) result.Result[MemberDetails]
 	GetMembersBySeason(seasonId int64) result.Result[[]Member]
+	// SearchMembers returns the page of members matching the criteria and the total number of matches
+	SearchMembers(criteria MemberSearchCriteria) result.Result[MemberPage]
 	GetMembersWhoDidNotPayForServices() []Member
 	GetMembersWhoDidNotPayForMembership() []Member
-	CreateMember(user User, createMembership bool, seasonId *int64, price *float64) result.Result[MemberDetails]
-	AddMembership(memberId domain.Id[Member], seasonId int64, price float64) result.Result[MemberDetails]
+	CreateMember(user User, createMembership bool, seasonId *int64, price *money.Money, category MembershipCategory) result.Result[MemberDetails]
+	AddMembership(memberId domain.Id[Member], seasonId int64, price money.Money, category MembershipCategory) result.Result[MemberDetails]
 	UpdateMember(id domain.Id[Member], user User, season int64) result.Result[MemberDetails]
+	UpdateMembershipStatus(membership Membership) result.Result[Membership]
+	// GetSeasonStartDate returns the first day of the season, used to tell whether a member is a minor
+	GetSeasonStartDate(seasonId int64) result.Result[time.Time]
+	// GetUsersForDuplicateCheck returns the personal data and phone numbers of every member not removed
+	GetUsersForDuplicateCheck() result.Result[[]User]
+	// MergeMembers moves memberships, rentals, waiting list entries, contacts, documents, consents and fiscal documents
+	// of the source member to the target member and deletes the source member, in a single transaction
+	MergeMembers(sourceId domain.Id[Member], targetId domain.Id[Member]) result.Result[MergeResult]
+	// RemoveMember anonymises the personal data of the member, keeping memberships, payments and rentals
+	// Periods of other seasons still active or suspended are excluded with the given decision, in the same transaction
+	// The documents of the member are deleted, their storage keys are returned so that the files can be deleted too
+	RemoveMember(id domain.Id[Member], exclusion Excluded) result.Result[[]string]
+	// GetGuardedMembers returns the members the given member is the legal guardian of
+	GetGuardedMembers(id domain.Id[Member]) result.Result[[]User]
+	// GetGuardianMembers returns the given members with their birth date, the removed or unknown ones are left out
+	GetGuardianMembers(ids []domain.Id[User]) result.Result[[]User]
 }
@@ -0,0 +1,67 @@
+package membership
+
+import "strings"
+
+const (
+	DefaultMemberSearchLimit = 50
+	MaxMemberSearchLimit     = 200
+)
+
+type MemberSortField string
+
+const (
+	SortByLastName         MemberSortField = "lastName"
+	SortByFirstName        MemberSortField = "firstName"
+	SortByMembershipNumber MemberSortField = "membershipNumber"
+	SortByBirthDate        MemberSortField = "birthDate"
+)
+
+func (f MemberSortField) IsValid() bool {
+	switch f {
+	case SortByLastName, SortByFirstName, SortByMembershipNumber, SortByBirthDate:
+		return true
+	}
+	return false
+}
+
+// MemberSearchCriteria filters, sorts and paginates the list of members
+// Nil filters are not applied
+type MemberSearchCriteria struct {
+	SeasonId            *int64 // nil searches the latest membership of every member
+	Query               string // matched against name, tax code, email and membership number
+	Status              *MembershipStatus
+	MembershipPaid      *bool
+	HasUnpaidFacilities *bool
+	FacilityTypeId      *int64       // members renting a facility of this type
+	WithoutConsent      *ConsentType // members whose current consent of this type is not granted, for the current policy
+	SortBy              MemberSortField
+	Descending          bool
+	Limit               int
+	Offset              int
+}
+
+// MemberPage is a page of the search results together with the total number of matches
+type MemberPage struct {
+	Members []Member
+	Total   int
+	Limit   int
+	Offset  int
+}
+
+// Normalized fills in the defaults and keeps the pagination within bounds
+func (c MemberSearchCriteria) Normalized() MemberSearchCriteria {
+	c.Query = strings.TrimSpace(c.Query)
+	if !c.SortBy.IsValid() {
+		c.SortBy = SortByLastName
+	}
+	if c.Limit <= 0 {
+		c.Limit = DefaultMemberSearchLimit
+	}
+	if c.Limit > MaxMemberSearchLimit {
+		c.Limit = MaxMemberSearchLimit
+	}
+	if c.Offset < 0 {
+		c.Offset = 0
+	}
+	return c
+}
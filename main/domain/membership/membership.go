@@ -5,16 +5,23 @@ import (
 
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 )
 
-const SuggestedMembershipPrice float64 = 130.0
+var SuggestedMembershipPrice = money.Euros(130)
 
 type Membership struct {
-	Id      domain.Id[Membership]
-	Number  int64
-	Status  MembershipInfo
-	Price   float64
-	Payment payment.Payment
+	Id           domain.Id[Membership]
+	Number       int64
+	Status       MembershipInfo
+	Category     *MembershipCategory
+	Price        money.Money
+	Transactions []payment.Transaction // Payments and refunds of the period, oldest first
+}
+
+// Ledger returns the price of the membership with the transactions settling it
+func (m Membership) Ledger() payment.Ledger {
+	return payment.Ledger{Due: m.Price, Transactions: m.Transactions}
 }
 
 type MembershipInfo interface {
@@ -50,7 +57,8 @@ type Excluded struct {
 	PeriodId       int64
 	ValidFromDate  time.Time
 	ValidUntilDate time.Time
-	ExcludedAt     time.Time
+	ExcludedAt     time.Time // Date of the board decision
+	Reason         string
 }
 
 type Expired struct {
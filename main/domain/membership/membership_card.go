@@ -0,0 +1,78 @@
+package membership
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+type NumberingScope string
+
+const (
+	// GlobalNumbering gives every card the membership number of the member
+	GlobalNumbering NumberingScope = "GLOBAL"
+	// SeasonNumbering numbers the cards again every season, starting from 1
+	SeasonNumbering NumberingScope = "SEASON"
+)
+
+const maxNumberingDigits = 10
+
+// MembershipNumberingPolicy decides how membership cards are numbered
+type MembershipNumberingPolicy struct {
+	Scope             NumberingScope
+	Prefix            string
+	Digits            int // Zero padding of the number, 0 for none
+	ReuseFreedNumbers bool
+}
+
+// DefaultMembershipNumberingPolicy is the numbering used when none is configured
+var DefaultMembershipNumberingPolicy = MembershipNumberingPolicy{Scope: GlobalNumbering}
+
+// MembershipCard is the card of a member for a season
+type MembershipCard struct {
+	MemberId         domain.Id[Member]
+	FirstName        string
+	LastName         string
+	BirthDate        time.Time
+	MembershipNumber int64
+	CardCode         string
+	SeasonId         int64
+	SeasonCode       string
+	SeasonName       string
+	ValidFrom        time.Time
+	ValidUntil       time.Time
+	Category         *MembershipCategory
+}
+
+type MembershipCardRepository interface {
+	// GetNumberingPolicy returns the active policy, DefaultMembershipNumberingPolicy when none is configured
+	GetNumberingPolicy() result.Result[MembershipNumberingPolicy]
+	// SaveNumberingPolicy replaces the active policy, cards already assigned keep their code
+	SaveNumberingPolicy(policy MembershipNumberingPolicy) result.Result[MembershipNumberingPolicy]
+	// GetMembershipCards returns the cards of the active memberships of the season, ordered by card number
+	GetMembershipCards(seasonId int64) result.Result[[]MembershipCard]
+	GetMembershipCard(memberId domain.Id[Member], seasonId int64) result.Result[MembershipCard]
+}
+
+func (p MembershipNumberingPolicy) Validate() result.Result[MembershipNumberingPolicy] {
+	p.Prefix = strings.TrimSpace(p.Prefix)
+	if p.Scope != GlobalNumbering && p.Scope != SeasonNumbering {
+		return result.Err[MembershipNumberingPolicy](errors.MembershipNumberingError{Description: "numbering scope must be GLOBAL or SEASON"})
+	}
+	if p.Digits < 0 || p.Digits > maxNumberingDigits {
+		return result.Err[MembershipNumberingPolicy](errors.MembershipNumberingError{Description: fmt.Sprintf("digits must be between 0 and %d", maxNumberingDigits)})
+	}
+	if len(p.Prefix) > 20 {
+		return result.Err[MembershipNumberingPolicy](errors.MembershipNumberingError{Description: "prefix cannot be longer than 20 characters"})
+	}
+	return result.Ok(p)
+}
+
+// Format returns the code printed on the card, the prefix followed by the zero padded number
+func (p MembershipNumberingPolicy) Format(number int64) string {
+	return fmt.Sprintf("%s%0*d", p.Prefix, p.Digits, number)
+}
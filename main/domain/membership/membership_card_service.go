@@ -0,0 +1,31 @@
+package membership
+
+import (
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+type MembershipCardService struct {
+	repository MembershipCardRepository
+}
+
+func NewMembershipCardService(repository MembershipCardRepository) *MembershipCardService {
+	return &MembershipCardService{repository: repository}
+}
+
+func (this MembershipCardService) GetNumberingPolicy() result.Result[MembershipNumberingPolicy] {
+	return this.repository.GetNumberingPolicy()
+}
+
+// UpdateNumberingPolicy applies to the cards assigned from now on
+func (this MembershipCardService) UpdateNumberingPolicy(policy MembershipNumberingPolicy) result.Result[MembershipNumberingPolicy] {
+	return result.Bind(policy.Validate(), this.repository.SaveNumberingPolicy)
+}
+
+func (this MembershipCardService) GetMembershipCards(seasonId int64) result.Result[[]MembershipCard] {
+	return this.repository.GetMembershipCards(seasonId)
+}
+
+func (this MembershipCardService) GetMembershipCard(memberId domain.Id[Member], seasonId int64) result.Result[MembershipCard] {
+	return this.repository.GetMembershipCard(memberId, seasonId)
+}
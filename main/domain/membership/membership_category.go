@@ -0,0 +1,97 @@
+package membership
+
+import (
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+type MembershipCategoryCode string
+
+const (
+	OrdinaryCategory MembershipCategoryCode = "ORDINARY"
+	JuniorCategory   MembershipCategoryCode = "JUNIOR"
+	HonoraryCategory MembershipCategoryCode = "HONORARY"
+	FoundingCategory MembershipCategoryCode = "FOUNDING"
+	FamilyCategory   MembershipCategoryCode = "FAMILY"
+)
+
+// MembershipCategory is the kind of membership, categories not requiring payment are exempt from the fee
+type MembershipCategory struct {
+	Id              domain.Id[MembershipCategory]
+	Code            MembershipCategoryCode
+	Name            string
+	PaymentRequired bool
+}
+
+// MembershipCategoryPrice is the price of a category in a season
+type MembershipCategoryPrice struct {
+	Category MembershipCategory
+	SeasonId int64
+	Price    *money.Money // nil when no price is configured for the season
+}
+
+// MembershipCategorySuggestion is a category with its price, flagged when it fits the member
+type MembershipCategorySuggestion struct {
+	MembershipCategoryPrice
+	SuggestedPrice money.Money
+	Suggested      bool
+}
+
+type MembershipCategoryRepository interface {
+	// GetCategoryPrices returns the active categories with their price in the season
+	GetCategoryPrices(seasonId int64) result.Result[[]MembershipCategoryPrice]
+	GetCategoryPrice(code MembershipCategoryCode, seasonId int64) result.Result[MembershipCategoryPrice]
+	SetCategoryPrice(code MembershipCategoryCode, seasonId int64, price money.Money) result.Result[MembershipCategoryPrice]
+}
+
+// SuggestedPrice is zero for categories exempt from the fee, the configured price otherwise,
+// falling back to SuggestedMembershipPrice when the season has no price
+func (p MembershipCategoryPrice) SuggestedPrice() money.Money {
+	if !p.Category.PaymentRequired {
+		return money.Zero(SuggestedMembershipPrice.Currency())
+	}
+	if p.Price != nil {
+		return *p.Price
+	}
+	return SuggestedMembershipPrice
+}
+
+// RequiresPayment tells whether the membership fee must be paid, memberships without a category always do
+func (m Membership) RequiresPayment() bool {
+	return m.Category == nil || m.Category.PaymentRequired
+}
+
+// IsPaid tells whether the membership is paid or exempt from payment,
+// CurrencyMismatchError when a payment is not in the currency of the price
+func (m Membership) IsPaid() result.Result[bool] {
+	if !m.RequiresPayment() {
+		return result.Ok(true)
+	}
+	return m.Ledger().IsSettled()
+}
+
+// SuggestCategory suggests the category of a member for a season starting at the given date:
+// family for members of a household joining others already paying the membership of the season,
+// junior for minors, ordinary otherwise, so the first member of a household pays the full price
+// Honorary and founding categories are granted by the club and never suggested
+func SuggestCategory(user User, seasonStart time.Time, payingMembers int) MembershipCategoryCode {
+	if user.HouseholdId != nil && payingMembers > 0 {
+		return FamilyCategory
+	}
+	if user.IsMinorAt(seasonStart) {
+		return JuniorCategory
+	}
+	return OrdinaryCategory
+}
+
+// WithFamilyPrice sets the price of the family category, which comes from the family pricing scheme
+// rather than from the prices of the season, other categories are returned unchanged
+func (p MembershipCategoryPrice) WithFamilyPrice(price money.Money) MembershipCategoryPrice {
+	if p.Category.Code == FamilyCategory {
+		p.Price = &price
+	}
+	return p
+}
@@ -3,7 +3,6 @@ package membership
 import (
 	"time"
 
-	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
 )
 
@@ -15,18 +14,76 @@ func RenewedMembership(currentMembership Membership) result.Result[Membership] {
 		Status: Active{
 			ValidUntilDate: newValidityDate,
 		},
-		Payment: payment.PaymentUnpaid{},
+		Category: currentMembership.Category,
 	})
 }
 
-func ExcludedMembership(currentMembership Membership, decisionDate time.Time) Membership {
+func ExcludedMembership(currentMembership Membership, decisionDate time.Time, reason string) Membership {
 	return Membership{
+		Id:     currentMembership.Id,
 		Number: currentMembership.Number,
 		Status: Excluded{
+			PeriodId:       periodIdOf(currentMembership),
 			ValidFromDate:  currentMembership.Status.GetValidFromDate(),
 			ValidUntilDate: currentMembership.Status.GetValidUntilDate(),
 			ExcludedAt:     decisionDate,
+			Reason:         reason,
 		},
-		Payment: currentMembership.Payment,
+		Category:     currentMembership.Category,
+		Price:        currentMembership.Price,
+		Transactions: currentMembership.Transactions,
 	}
 }
+
+func SuspendedMembership(currentMembership Membership) Membership {
+	return Membership{
+		Id:     currentMembership.Id,
+		Number: currentMembership.Number,
+		Status: Suspended{
+			PeriodId:       periodIdOf(currentMembership),
+			ValidFromDate:  currentMembership.Status.GetValidFromDate(),
+			ValidUntilDate: currentMembership.Status.GetValidUntilDate(),
+		},
+		Category:     currentMembership.Category,
+		Price:        currentMembership.Price,
+		Transactions: currentMembership.Transactions,
+	}
+}
+
+func ReinstatedMembership(currentMembership Membership) Membership {
+	return Membership{
+		Id:     currentMembership.Id,
+		Number: currentMembership.Number,
+		Status: Active{
+			PeriodId:       periodIdOf(currentMembership),
+			ValidFromDate:  currentMembership.Status.GetValidFromDate(),
+			ValidUntilDate: currentMembership.Status.GetValidUntilDate(),
+		},
+		Category:     currentMembership.Category,
+		Price:        currentMembership.Price,
+		Transactions: currentMembership.Transactions,
+	}
+}
+
+func ExpiredMembership(currentMembership Membership) Membership {
+	return Membership{
+		Id:     currentMembership.Id,
+		Number: currentMembership.Number,
+		Status: Expired{
+			PeriodId:       periodIdOf(currentMembership),
+			ValidFromDate:  currentMembership.Status.GetValidFromDate(),
+			ValidUntilDate: currentMembership.Status.GetValidUntilDate(),
+		},
+		Category:     currentMembership.Category,
+		Price:        currentMembership.Price,
+		Transactions: currentMembership.Transactions,
+	}
+}
+
+// periodIdOf returns the id of the membership period, or 0 if the membership has none
+func periodIdOf(membership Membership) int64 {
+	if periodId := membership.Status.GetPeriodId(); periodId != nil {
+		return *periodId
+	}
+	return 0
+}
@@ -0,0 +1,264 @@
+package membership
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// TaxCode is the Italian tax code (Codice Fiscale), an immutable value object
+type TaxCode struct {
+	Value      string
+	unverified bool // Kept as stored, without passing the checks of NewTaxCode
+}
+
+type Sex string
+
+const (
+	Male   Sex = "M"
+	Female Sex = "F"
+)
+
+// Digits can be replaced by letters (omocodia) when two people would get the same code
+var taxCodePattern = regexp.MustCompile(`^[A-Z]{6}[0-9LMNPQRSTUV]{2}[ABCDEHLMPRST][0-9LMNPQRSTUV]{2}[A-Z][0-9LMNPQRSTUV]{3}[A-Z]$`)
+
+var placeCodePattern = regexp.MustCompile(`^[A-Z][0-9]{3}$`)
+
+const (
+	monthCodes      = "ABCDEHLMPRST"
+	omocodiaLetters = "LMNPQRSTUV"
+)
+
+var omocodiaPositions = []int{6, 7, 9, 10, 12, 13, 14}
+
+// Values of the characters in odd positions for the check character, A-Z and 0-9 share the same values
+var oddCharValues = [26]int{1, 0, 5, 7, 9, 13, 15, 17, 19, 21, 2, 4, 18, 20, 11, 3, 6, 8, 12, 14, 16, 10, 22, 25, 24, 23}
+
+// NewTaxCode creates a validated tax code (smart constructor)
+func NewTaxCode(code string) result.Result[TaxCode] {
+	code = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+	if code == "" {
+		return result.Err[TaxCode](errors.TaxCodeError{Description: "empty tax code"})
+	}
+
+	if !taxCodePattern.MatchString(code) {
+		return result.Err[TaxCode](errors.TaxCodeError{Description: "invalid tax code format"})
+	}
+
+	taxCode := TaxCode{Value: code}
+	day := taxCode.birthDayCode()
+	if day < 1 || (day > 31 && day < 41) || day > 71 {
+		return result.Err[TaxCode](errors.TaxCodeError{Description: "invalid birth day in tax code"})
+	}
+
+	if checkCharacter(code[:15]) != code[15] {
+		return result.Err[TaxCode](errors.TaxCodeError{Description: "invalid tax code check character"})
+	}
+
+	return result.Ok(taxCode)
+}
+
+// StoredTaxCode returns a tax code read back from storage, kept as is when it no longer passes the checks
+// (older formats, wrong check characters) so that saving the member again does not lose it
+func StoredTaxCode(code string) TaxCode {
+	taxCodeResult := NewTaxCode(code)
+	if taxCodeResult.IsSuccess() {
+		return taxCodeResult.Value()
+	}
+	return TaxCode{Value: strings.TrimSpace(code), unverified: true}
+}
+
+// SuggestTaxCode computes the tax code from the personal data
+// The place code is the cadastral code of the birth municipality, or of the country for people born abroad
+func SuggestTaxCode(firstName string, lastName string, birthDate time.Time, sex Sex, placeCode string) result.Result[TaxCode] {
+	placeCode = strings.ToUpper(strings.TrimSpace(placeCode))
+	if !placeCodePattern.MatchString(placeCode) {
+		return result.Err[TaxCode](errors.TaxCodeError{Description: "invalid birth place code"})
+	}
+	if sex != Male && sex != Female {
+		return result.Err[TaxCode](errors.TaxCodeError{Description: "sex must be M or F"})
+	}
+	if normalizeName(firstName) == "" || normalizeName(lastName) == "" {
+		return result.Err[TaxCode](errors.TaxCodeError{Description: "first and last name are required"})
+	}
+	if birthDate.IsZero() {
+		return result.Err[TaxCode](errors.TaxCodeError{Description: "birth date is required"})
+	}
+
+	code := lastNameCode(lastName) + firstNameCode(firstName) + birthDateCode(birthDate, sex) + placeCode
+	return NewTaxCode(code + string(checkCharacter(code)))
+}
+
+// CheckAgainst cross-checks the tax code with the personal data of the user
+// The birth place is only checked for being in Italy or abroad, as municipality codes are not known here
+func (t TaxCode) CheckAgainst(user User) result.Result[TaxCode] {
+	mismatches := []string{}
+
+	if normalizeName(user.LastName) != "" && t.Value[0:3] != lastNameCode(user.LastName) {
+		mismatches = append(mismatches, "last name")
+	}
+	if normalizeName(user.FirstName) != "" && t.Value[3:6] != firstNameCode(user.FirstName) {
+		mismatches = append(mismatches, "first name")
+	}
+	if !user.BirthDate.IsZero() {
+		day := t.birthDayCode()
+		if day > 40 {
+			day -= 40
+		}
+		if t.decoded()[6:8] != user.BirthDate.Format("06") ||
+			t.Value[8] != monthCodes[user.BirthDate.Month()-1] ||
+			day != user.BirthDate.Day() {
+			mismatches = append(mismatches, "birth date")
+		}
+	}
+	if user.BirthPlace != nil && strings.TrimSpace(user.BirthPlace.Country) != "" {
+		bornAbroad := t.Value[11] == 'Z'
+		if bornAbroad == isItaly(user.BirthPlace.Country) {
+			mismatches = append(mismatches, "birth place")
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return result.Err[TaxCode](errors.TaxCodeError{Description: "tax code does not match " + strings.Join(mismatches, ", ")})
+	}
+	return result.Ok(t)
+}
+
+// IsValid tells whether the tax code passes the checks, false for stored codes that no longer do
+func (t TaxCode) IsValid() bool {
+	return !t.unverified
+}
+
+// Sex returns the sex encoded in the birth day, empty when the tax code is not valid
+func (t TaxCode) Sex() Sex {
+	if !t.IsValid() {
+		return ""
+	}
+	if t.birthDayCode() > 40 {
+		return Female
+	}
+	return Male
+}
+
+// PlaceCode returns the cadastral code of the birth place, empty when the tax code is not valid
+func (t TaxCode) PlaceCode() string {
+	if !t.IsValid() {
+		return ""
+	}
+	return t.decoded()[11:15]
+}
+
+// String implements Stringer interface
+func (t TaxCode) String() string {
+	return t.Value
+}
+
+// Equals checks equality (value object semantic)
+// Codes differing only by omocodia substitutions belong to the same person
+func (t TaxCode) Equals(other TaxCode) bool {
+	if !t.IsValid() || !other.IsValid() {
+		return strings.EqualFold(t.Value, other.Value)
+	}
+	return t.decoded()[:15] == other.decoded()[:15]
+}
+
+// decoded replaces the omocodia letters with the original digits, leaving the check character as is
+func (t TaxCode) decoded() string {
+	code := []byte(t.Value)
+	for _, position := range omocodiaPositions {
+		if index := strings.IndexByte(omocodiaLetters, code[position]); index >= 0 {
+			code[position] = byte('0' + index)
+		}
+	}
+	return string(code)
+}
+
+func (t TaxCode) birthDayCode() int {
+	decoded := t.decoded()
+	return int(decoded[9]-'0')*10 + int(decoded[10]-'0')
+}
+
+func checkCharacter(code string) byte {
+	sum := 0
+	for i := 0; i < len(code); i++ {
+		value := int(code[i] - 'A')
+		if code[i] >= '0' && code[i] <= '9' {
+			value = int(code[i] - '0')
+		}
+		// Positions are counted from 1, so even indexes are odd positions
+		if i%2 == 0 {
+			sum += oddCharValues[value]
+		} else {
+			sum += value
+		}
+	}
+	return byte('A' + sum%26)
+}
+
+func lastNameCode(lastName string) string {
+	consonants, vowels := splitLetters(normalizeName(lastName))
+	return (consonants + vowels + "XXX")[:3]
+}
+
+func firstNameCode(firstName string) string {
+	consonants, vowels := splitLetters(normalizeName(firstName))
+	if len(consonants) >= 4 {
+		return consonants[0:1] + consonants[2:4]
+	}
+	return (consonants + vowels + "XXX")[:3]
+}
+
+func birthDateCode(birthDate time.Time, sex Sex) string {
+	day := birthDate.Day()
+	if sex == Female {
+		day += 40
+	}
+	return birthDate.Format("06") + string(monthCodes[birthDate.Month()-1]) + twoDigits(day)
+}
+
+func twoDigits(n int) string {
+	return string([]byte{byte('0' + n/10), byte('0' + n%10)})
+}
+
+func splitLetters(name string) (consonants string, vowels string) {
+	for _, r := range name {
+		if strings.ContainsRune("AEIOU", r) {
+			vowels += string(r)
+		} else {
+			consonants += string(r)
+		}
+	}
+	return consonants, vowels
+}
+
+var accentReplacer = strings.NewReplacer(
+	"À", "A", "Á", "A", "Â", "A", "Ä", "A",
+	"È", "E", "É", "E", "Ê", "E", "Ë", "E",
+	"Ì", "I", "Í", "I", "Î", "I", "Ï", "I",
+	"Ò", "O", "Ó", "O", "Ô", "O", "Ö", "O",
+	"Ù", "U", "Ú", "U", "Û", "U", "Ü", "U",
+	"Ç", "C", "Ñ", "N",
+)
+
+// normalizeName keeps only the letters A-Z, mapping accented letters to their base letter
+func normalizeName(name string) string {
+	name = accentReplacer.Replace(strings.ToUpper(name))
+	var normalized strings.Builder
+	for _, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			normalized.WriteRune(r)
+		}
+	}
+	return normalized.String()
+}
+
+func isItaly(country string) bool {
+	switch strings.ToUpper(strings.TrimSpace(country)) {
+	case "IT", "ITA", "ITALIA", "ITALY":
+		return true
+	}
+	return false
+}
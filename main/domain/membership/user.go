@@ -12,8 +12,12 @@ type User struct {
 	LastName     string
 	BirthDate    time.Time
 	Email        *EmailAddress // Optional email
-	TaxCode      string        // Italian tax code (Codice Fiscale)
+	TaxCode      *TaxCode      // Optional Italian tax code (Codice Fiscale)
 	BirthPlace   *Address      // Optional birthplace (same structure as Address)
 	Addresses    []Address
 	PhoneNumbers []PhoneNumber
+	Guardians    []Guardian            // Legal guardians, required for minors
+	HouseholdId  *domain.Id[Household] // Family the member belongs to, if any
+	Consents     []Consent             // Current consents, the latest of each type
+	Billing      *BillingDetails       // Optional, for members billed as a business
 }
@@ -0,0 +1,204 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// NotificationKind is the event a member is notified of, each kind has its own email template
+type NotificationKind string
+
+const (
+	MembershipCreated          NotificationKind = "MEMBERSHIP_CREATED"
+	FacilityRented             NotificationKind = "FACILITY_RENTED"
+	PaymentReceived            NotificationKind = "PAYMENT_RECEIVED"
+	WaitingListPositionChanged NotificationKind = "WAITING_LIST_POSITION_CHANGED"
+)
+
+// OutboxStatus is where the delivery of an email stands
+type OutboxStatus string
+
+const (
+	Pending   OutboxStatus = "PENDING" // Waiting for its first attempt or for a retry
+	Sent      OutboxStatus = "SENT"
+	Failed    OutboxStatus = "FAILED"    // Every attempt failed, it is only retried on request
+	Cancelled OutboxStatus = "CANCELLED" // The member was removed before it was sent
+)
+
+// Delivery of an email is attempted up to MaxDeliveryAttempts times, waiting twice as long after each failure
+const (
+	MaxDeliveryAttempts = 5
+	RetryBaseDelay      = 5 * time.Minute
+)
+
+// DefaultDeliveryBatchSize is how many emails are sent at most in a delivery run
+const DefaultDeliveryBatchSize = 50
+
+// DeliveryClaimTimeout is how long a delivery run holds the emails it claimed, they are attempted again after it
+// when the run never records the outcome
+const DeliveryClaimTimeout = 10 * time.Minute
+
+// Notification is an event to tell a member about, Data is what its template shows
+type Notification struct {
+	Kind      NotificationKind
+	MemberId  *int64
+	Recipient Recipient
+	Data      any
+}
+
+// Recipient is the person an email is addressed to
+type Recipient struct {
+	Name  string
+	Email string
+}
+
+// EmailMessage is an email ready to be sent, the body is plain text
+type EmailMessage struct {
+	To      Recipient
+	Subject string
+	Body    string
+}
+
+// EmailSender delivers emails, an error means the message was not accepted and can be retried
+type EmailSender interface {
+	Send(message EmailMessage) error
+}
+
+// EmailRenderer writes the email of a notification from the template of its kind
+type EmailRenderer interface {
+	Render(notification Notification) (EmailMessage, error)
+}
+
+// MembershipCreatedData is shown in the email sent when a membership is added for a season
+type MembershipCreatedData struct {
+	FirstName        string
+	SeasonCode       string
+	MembershipNumber int64
+	Category         string // Empty when the membership has no category
+	Price            money.Money
+}
+
+// FacilityRentedData is shown in the email sent when a facility is rented
+type FacilityRentedData struct {
+	FirstName  string
+	Facility   string
+	Identifier string
+	From       time.Time
+	To         time.Time
+	Price      money.Money
+}
+
+// PaymentReceivedData is shown in the email sent when a payment is recorded
+type PaymentReceivedData struct {
+	FirstName     string
+	Description   string // What was paid for
+	Amount        money.Money
+	PaidOn        time.Time
+	PaymentMethod string
+	Balance       money.Money // Still owed after the payment, zero or less when settled
+}
+
+// WaitingListPositionData is shown in the email sent when a member moves in a waiting list
+type WaitingListPositionData struct {
+	FirstName        string
+	Facility         string
+	PreviousPosition int
+	Position         int
+}
+
+// OutboxMessage is an email stored until it is delivered, so that it survives failures and restarts
+type OutboxMessage struct {
+	Id            int64
+	Kind          NotificationKind
+	MemberId      *int64
+	Message       EmailMessage
+	Status        OutboxStatus
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	SentAt        *time.Time
+	CreatedAt     time.Time
+}
+
+// OutboxCriteria filters the emails, nil fields are ignored
+type OutboxCriteria struct {
+	Status   *OutboxStatus
+	MemberId *int64
+	Limit    int
+}
+
+// DeliveryReport counts the outcome of a delivery run
+type DeliveryReport struct {
+	Sent     int
+	Retrying int // Failed, to be attempted again later
+	Failed   int // Failed for the last time
+}
+
+type OutboxRepository interface {
+	// Enqueue stores a new email, its id is set on the returned message
+	Enqueue(message OutboxMessage) result.Result[OutboxMessage]
+	// ClaimDueMessages returns the pending emails whose next attempt is due, oldest first, postponing their next
+	// attempt to claimedUntil so that concurrent delivery runs skip them
+	ClaimDueMessages(now time.Time, claimedUntil time.Time, limit int) result.Result[[]OutboxMessage]
+	// GetMessages returns the emails matching the criteria, latest first
+	GetMessages(criteria OutboxCriteria) result.Result[[]OutboxMessage]
+	// GetMessage returns an email, NotFoundError when it does not exist
+	GetMessage(id int64) result.Result[OutboxMessage]
+	// UpdateMessage stores the outcome of a delivery attempt
+	UpdateMessage(message OutboxMessage) result.Result[OutboxMessage]
+}
+
+// NewOutboxMessage queues an email for its first attempt
+func NewOutboxMessage(kind NotificationKind, memberId *int64, message EmailMessage, now time.Time) OutboxMessage {
+	return OutboxMessage{
+		Kind:          kind,
+		MemberId:      memberId,
+		Message:       message,
+		Status:        Pending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+// RetryDelay is how long to wait after the given number of failed attempts
+func RetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	return RetryBaseDelay << (attempts - 1)
+}
+
+// Delivered marks the email as sent
+func (m OutboxMessage) Delivered(at time.Time) OutboxMessage {
+	m.Status = Sent
+	m.Attempts++
+	m.LastError = ""
+	m.SentAt = &at
+	return m
+}
+
+// DeliveryFailed records a failed attempt, the email fails for good after the last one
+func (m OutboxMessage) DeliveryFailed(err error, at time.Time) OutboxMessage {
+	m.Attempts++
+	m.LastError = err.Error()
+	if m.Attempts >= MaxDeliveryAttempts {
+		m.Status = Failed
+		return m
+	}
+	m.NextAttemptAt = at.Add(RetryDelay(m.Attempts))
+	return m
+}
+
+// Retry queues a failed email again with a new round of attempts
+func (m OutboxMessage) Retry(at time.Time) result.Result[OutboxMessage] {
+	if m.Status != Failed {
+		return result.Err[OutboxMessage](errors.NotificationError{Description: "only failed emails can be retried"})
+	}
+	m.Status = Pending
+	m.Attempts = 0
+	m.NextAttemptAt = at
+	return result.Ok(m)
+}
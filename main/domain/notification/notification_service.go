@@ -0,0 +1,76 @@
+package notification
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// NotificationService writes the emails of the notifications to the outbox and delivers them later,
+// so that a mail server being down never makes an operation fail
+type NotificationService struct {
+	repository OutboxRepository
+	renderer   EmailRenderer
+	sender     EmailSender
+}
+
+func NewNotificationService(repository OutboxRepository, renderer EmailRenderer, sender EmailSender) *NotificationService {
+	return &NotificationService{repository: repository, renderer: renderer, sender: sender}
+}
+
+// Notify renders the email of the notification and queues it, NotificationError when it has no recipient
+func (this NotificationService) Notify(notification Notification, now time.Time) result.Result[OutboxMessage] {
+	if strings.TrimSpace(notification.Recipient.Email) == "" {
+		return result.Err[OutboxMessage](errors.NotificationError{Description: "the notification has no recipient email"})
+	}
+
+	message, err := this.renderer.Render(notification)
+	if err != nil {
+		return result.Err[OutboxMessage](errors.NotificationError{Description: "failed to render " + string(notification.Kind) + " email: " + err.Error()})
+	}
+	return this.repository.Enqueue(NewOutboxMessage(notification.Kind, notification.MemberId, message, now))
+}
+
+// DeliverPending attempts the emails whose turn has come, failures are retried later
+func (this NotificationService) DeliverPending(now time.Time) result.Result[DeliveryReport] {
+	due := this.repository.ClaimDueMessages(now, now.Add(DeliveryClaimTimeout), DefaultDeliveryBatchSize)
+	if !due.IsSuccess() {
+		return result.Err[DeliveryReport](due.Error())
+	}
+
+	report := DeliveryReport{}
+	for _, message := range due.Value() {
+		if err := this.sender.Send(message.Message); err != nil {
+			message = message.DeliveryFailed(err, now)
+		} else {
+			message = message.Delivered(now)
+		}
+
+		updated := this.repository.UpdateMessage(message)
+		if !updated.IsSuccess() {
+			return result.Err[DeliveryReport](updated.Error())
+		}
+		switch message.Status {
+		case Sent:
+			report.Sent++
+		case Failed:
+			report.Failed++
+		default:
+			report.Retrying++
+		}
+	}
+	return result.Ok(report)
+}
+
+func (this NotificationService) GetMessages(criteria OutboxCriteria) result.Result[[]OutboxMessage] {
+	return this.repository.GetMessages(criteria)
+}
+
+// RetryMessage queues a failed email again, to be sent at the next delivery run
+func (this NotificationService) RetryMessage(id int64, now time.Time) result.Result[OutboxMessage] {
+	return result.Bind(this.repository.GetMessage(id), func(message OutboxMessage) result.Result[OutboxMessage] {
+		return result.Bind(message.Retry(now), this.repository.UpdateMessage)
+	})
+}
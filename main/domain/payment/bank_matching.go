@@ -0,0 +1,211 @@
+package payment
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+const (
+	// MinMatchScore is the score a candidate needs to be proposed
+	MinMatchScore = 40
+	// HighConfidenceScore is the score of the candidates that can be confirmed without a second look
+	HighConfidenceScore = 70
+	maxMatchCandidates  = 3
+)
+
+// Scores of the clues a bank transaction belongs to an outstanding item
+const (
+	taxCodeScore            = 50
+	exactAmountScore        = 30
+	lastNameScore           = 20
+	firstNameScore          = 10
+	facilityIdentifierScore = 15
+)
+
+type MatchReason string
+
+const (
+	TaxCodeReason            MatchReason = "TAX_CODE"
+	ExactAmountReason        MatchReason = "EXACT_AMOUNT"
+	LastNameReason           MatchReason = "LAST_NAME"
+	FirstNameReason          MatchReason = "FIRST_NAME"
+	FacilityIdentifierReason MatchReason = "FACILITY_IDENTIFIER"
+)
+
+type MatchConfidence string
+
+const (
+	HighConfidence   MatchConfidence = "HIGH"
+	MediumConfidence MatchConfidence = "MEDIUM"
+)
+
+// OutstandingItem is a membership period or a rental still to be settled, with the member who owes it
+type OutstandingItem struct {
+	Target             PaymentTarget
+	MembershipPeriodId *int64
+	RentedFacilityId   *int64
+	MemberId           int64
+	FirstName          string
+	LastName           string
+	TaxCode            string
+	SeasonId           int64
+	FacilityTypeId     *int64
+	FacilityName       string
+	FacilityIdentifier string
+	Due                money.Money
+	Balance            money.Money
+}
+
+// MatchCandidate is an outstanding item a bank transaction may pay, with the clues found
+type MatchCandidate struct {
+	Item    OutstandingItem
+	Score   int
+	Reasons []MatchReason
+}
+
+// MatchProposal is a bank transaction with its best candidates, the proposed one first
+// No candidate is proposed when none scores enough or the best one is proposed for another transaction
+type MatchProposal struct {
+	Transaction BankTransaction
+	Candidates  []MatchCandidate
+	Proposed    *MatchCandidate
+}
+
+// MatchConfirmation is a match confirmed by the treasurer, exactly one of the ids is set
+type MatchConfirmation struct {
+	BankTransactionId  domain.Id[BankTransaction]
+	MembershipPeriodId *int64
+	RentedFacilityId   *int64
+}
+
+// MatchOutcome is the result of a confirmation, the payment created or the reason it was not
+type MatchOutcome struct {
+	BankTransactionId domain.Id[BankTransaction]
+	PaymentId         *int64
+	Error             error
+}
+
+func (c MatchCandidate) Confidence() MatchConfidence {
+	if c.Score >= HighConfidenceScore {
+		return HighConfidence
+	}
+	return MediumConfidence
+}
+
+func (c MatchConfirmation) Validate() result.Result[MatchConfirmation] {
+	if (c.MembershipPeriodId == nil) == (c.RentedFacilityId == nil) {
+		return result.Err[MatchConfirmation](errors.PaymentError{Description: "exactly one of membershipPeriodId or rentedFacilityId must be provided"})
+	}
+	return result.Ok(c)
+}
+
+// ProposeMatches scores the outstanding items against the unmatched incoming transactions
+// Each item is proposed at most once, to the transaction it scores best with
+func ProposeMatches(transactions []BankTransaction, items []OutstandingItem) []MatchProposal {
+	proposals := make([]MatchProposal, 0, len(transactions))
+	type pairing struct{ proposal, candidate int }
+	pairings := []pairing{}
+
+	for _, transaction := range transactions {
+		proposal := MatchProposal{Transaction: transaction, Candidates: []MatchCandidate{}}
+		if transaction.IsCredit() && !transaction.IsMatched() {
+			proposal.Candidates = scoreCandidates(transaction, items)
+		}
+		for i := range proposal.Candidates {
+			pairings = append(pairings, pairing{proposal: len(proposals), candidate: i})
+		}
+		proposals = append(proposals, proposal)
+	}
+
+	// Best pairings first, ties go to the oldest transaction
+	sort.SliceStable(pairings, func(i, j int) bool {
+		return proposals[pairings[i].proposal].Candidates[pairings[i].candidate].Score >
+			proposals[pairings[j].proposal].Candidates[pairings[j].candidate].Score
+	})
+	taken := map[string]bool{}
+	for _, p := range pairings {
+		proposal := &proposals[p.proposal]
+		candidate := proposal.Candidates[p.candidate]
+		if proposal.Proposed != nil || taken[itemKey(candidate.Item)] {
+			continue
+		}
+		taken[itemKey(candidate.Item)] = true
+		proposal.Proposed = &candidate
+	}
+	return proposals
+}
+
+func scoreCandidates(transaction BankTransaction, items []OutstandingItem) []MatchCandidate {
+	counterparty := searchableWords(transaction.CounterpartyName)
+	remittance := searchableWords(transaction.RemittanceInfo)
+	compactRemittance := strings.ReplaceAll(remittance, " ", "")
+
+	candidates := []MatchCandidate{}
+	for _, item := range items {
+		candidate := MatchCandidate{Item: item, Reasons: []MatchReason{}}
+		add := func(reason MatchReason, score int) {
+			candidate.Score += score
+			candidate.Reasons = append(candidate.Reasons, reason)
+		}
+
+		if taxCode := searchableWords(item.TaxCode); len(taxCode) == 16 && strings.Contains(compactRemittance, taxCode) {
+			add(TaxCodeReason, taxCodeScore)
+		}
+		if item.Balance.Equal(transaction.Amount) {
+			add(ExactAmountReason, exactAmountScore)
+		}
+		lastName := containsWords(counterparty, item.LastName) || containsWords(remittance, item.LastName)
+		if lastName {
+			add(LastNameReason, lastNameScore)
+		}
+		if lastName && (containsWords(counterparty, item.FirstName) || containsWords(remittance, item.FirstName)) {
+			add(FirstNameReason, firstNameScore)
+		}
+		if item.Target == FacilityTarget && containsWords(remittance, item.FacilityIdentifier) {
+			add(FacilityIdentifierReason, facilityIdentifierScore)
+		}
+
+		if candidate.Score >= MinMatchScore {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+	if len(candidates) > maxMatchCandidates {
+		candidates = candidates[:maxMatchCandidates]
+	}
+	return candidates
+}
+
+// searchableWords upper-cases the text and keeps letters and digits only, words separated by single spaces
+func searchableWords(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToUpper(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// containsWords tells whether the words appear, as whole words, in the searchable text
+func containsWords(text string, words string) bool {
+	words = searchableWords(words)
+	if words == "" {
+		return false
+	}
+	return strings.Contains(" "+text+" ", " "+words+" ")
+}
+
+func itemKey(item OutstandingItem) string {
+	if item.MembershipPeriodId != nil {
+		return fmt.Sprintf("%s:%d", MembershipTarget, *item.MembershipPeriodId)
+	}
+	if item.RentedFacilityId != nil {
+		return fmt.Sprintf("%s:%d", FacilityTarget, *item.RentedFacilityId)
+	}
+	return ""
+}
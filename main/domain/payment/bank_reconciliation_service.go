@@ -0,0 +1,125 @@
+package payment
+
+import (
+	"strings"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+type BankReconciliationService struct {
+	repository BankStatementRepository
+	parser     BankStatementParser
+	payments   *PaymentManagementService
+}
+
+func NewBankReconciliationService(repository BankStatementRepository, parser BankStatementParser, payments *PaymentManagementService) *BankReconciliationService {
+	return &BankReconciliationService{repository: repository, parser: parser, payments: payments}
+}
+
+// ImportStatement parses the statement and stores its incoming transfers, skipping the ones already imported
+func (this BankReconciliationService) ImportStatement(fileName string, content []byte) result.Result[BankStatementImport] {
+	if len(content) > MaxBankStatementSize {
+		return result.Err[BankStatementImport](errors.PaymentError{Description: "bank statements cannot be larger than 5 MB"})
+	}
+
+	return result.Bind(DetectBankStatementFormat(fileName, content), func(format BankStatementFormat) result.Result[BankStatementImport] {
+		statement, err := this.parser.Parse(format, content)
+		if err != nil {
+			return result.Err[BankStatementImport](errors.PaymentError{Description: "invalid bank statement: " + err.Error()})
+		}
+		statement.FileName = strings.TrimSpace(fileName)
+		statement.Format = format
+
+		return result.Bind(statement.Validate(), func(statement BankStatement) result.Result[BankStatementImport] {
+			credits := statement.Credits()
+			skipped := len(statement.Transactions) - len(credits)
+			statement.Transactions = credits
+			return result.Map(this.repository.CreateStatement(statement), func(stored BankStatement) BankStatementImport {
+				return BankStatementImport{
+					Statement:     stored,
+					Imported:      len(stored.Transactions),
+					Duplicates:    len(credits) - len(stored.Transactions),
+					SkippedDebits: skipped,
+				}
+			})
+		})
+	})
+}
+
+func (this BankReconciliationService) GetStatements() result.Result[[]BankStatement] {
+	return this.repository.GetStatements()
+}
+
+func (this BankReconciliationService) GetStatement(id domain.Id[BankStatement]) result.Result[BankStatement] {
+	return this.repository.GetStatement(id)
+}
+
+// GetProposals returns the transactions of the statement with the outstanding items they may pay
+func (this BankReconciliationService) GetProposals(statementId domain.Id[BankStatement]) result.Result[[]MatchProposal] {
+	return result.Bind(this.repository.GetStatement(statementId), func(statement BankStatement) result.Result[[]MatchProposal] {
+		return result.Map(this.repository.GetOutstandingItems(), func(items []OutstandingItem) []MatchProposal {
+			return ProposeMatches(statement.Transactions, items)
+		})
+	})
+}
+
+// ConfirmMatches records a bank transfer payment for each confirmed match
+// Matches are confirmed one by one, the outcome of each tells whether its payment was created
+func (this BankReconciliationService) ConfirmMatches(confirmations []MatchConfirmation) result.Result[[]MatchOutcome] {
+	if len(confirmations) == 0 {
+		return result.Err[[]MatchOutcome](errors.PaymentError{Description: "no match to confirm"})
+	}
+	seen := map[int64]bool{}
+	for _, confirmation := range confirmations {
+		if validated := confirmation.Validate(); !validated.IsSuccess() {
+			return result.Err[[]MatchOutcome](validated.Error())
+		}
+		if seen[confirmation.BankTransactionId.Value] {
+			return result.Err[[]MatchOutcome](errors.PaymentError{Description: "a bank transaction can be confirmed only once"})
+		}
+		seen[confirmation.BankTransactionId.Value] = true
+	}
+
+	outcomes := make([]MatchOutcome, len(confirmations))
+	for i, confirmation := range confirmations {
+		outcome := MatchOutcome{BankTransactionId: confirmation.BankTransactionId}
+		created := this.confirmMatch(confirmation)
+		if created.IsSuccess() {
+			paymentId := created.Value()
+			outcome.PaymentId = &paymentId
+		} else {
+			outcome.Error = created.Error()
+		}
+		outcomes[i] = outcome
+	}
+	return result.Ok(outcomes)
+}
+
+func (this BankReconciliationService) confirmMatch(confirmation MatchConfirmation) result.Result[int64] {
+	return result.Bind(this.repository.GetTransaction(confirmation.BankTransactionId), func(transaction BankTransaction) result.Result[int64] {
+		if transaction.IsMatched() {
+			return result.Err[int64](errors.PaymentError{Description: "the bank transaction was already matched"})
+		}
+		if !transaction.IsCredit() {
+			return result.Err[int64](errors.PaymentError{Description: "only incoming transfers can be matched"})
+		}
+
+		var created result.Result[int64]
+		if confirmation.MembershipPeriodId != nil {
+			created = this.payments.CreatePaymentForMembershipPeriod(*confirmation.MembershipPeriodId, transaction.ToPayment())
+		} else {
+			created = this.payments.CreatePaymentForRentedFacility(*confirmation.RentedFacilityId, transaction.ToPayment())
+		}
+		// The payment is removed if it cannot be linked, so that the transaction can be confirmed again
+		return result.Bind(created, func(paymentId int64) result.Result[int64] {
+			linked := this.repository.LinkPayment(transaction.Id, paymentId)
+			if !linked.IsSuccess() {
+				_ = this.payments.DeletePayment(domain.NewId[Transaction](paymentId))
+				return result.Err[int64](linked.Error())
+			}
+			return result.Ok(paymentId)
+		})
+	})
+}
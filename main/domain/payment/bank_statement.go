@@ -0,0 +1,182 @@
+package payment
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// MaxBankStatementSize is the largest statement that can be imported, in bytes
+const MaxBankStatementSize = 5 << 20
+
+type BankStatementFormat string
+
+const (
+	CSVStatement     BankStatementFormat = "CSV"
+	CAMT053Statement BankStatementFormat = "CAMT053"
+)
+
+// BankStatement is a statement imported from the bank with its incoming transfers
+type BankStatement struct {
+	Id           domain.Id[BankStatement]
+	FileName     string
+	Format       BankStatementFormat
+	AccountIBAN  string
+	ImportedAt   time.Time
+	Transactions []BankTransaction
+}
+
+// BankTransaction is an entry of a bank statement, matched once a payment was recorded for it
+type BankTransaction struct {
+	Id               domain.Id[BankTransaction]
+	StatementId      domain.Id[BankStatement]
+	Reference        string // Reference given by the bank, empty when the statement has none
+	BookingDate      time.Time
+	Amount           money.Money // Negative for outgoing transfers
+	CounterpartyName string
+	CounterpartyIBAN string
+	RemittanceInfo   string // Free text of the transfer, usually the reason of the payment
+	PaymentId        *int64
+}
+
+// BankStatementImport is the outcome of an import, duplicates are transactions imported by an earlier statement
+type BankStatementImport struct {
+	Statement     BankStatement
+	Imported      int
+	Duplicates    int
+	SkippedDebits int
+}
+
+// BankStatementParser reads the transactions of a statement in one of the supported formats
+type BankStatementParser interface {
+	Parse(format BankStatementFormat, content []byte) (BankStatement, error)
+}
+
+type BankStatementRepository interface {
+	// CreateStatement stores the statement with the transactions not imported yet, returning the stored ones
+	CreateStatement(statement BankStatement) result.Result[BankStatement]
+	GetStatements() result.Result[[]BankStatement]
+	// GetStatement returns the statement with its transactions, NotFoundError when it does not exist
+	GetStatement(id domain.Id[BankStatement]) result.Result[BankStatement]
+	GetTransaction(id domain.Id[BankTransaction]) result.Result[BankTransaction]
+	// LinkPayment records the payment created for the transaction, PaymentError when it was already matched
+	LinkPayment(id domain.Id[BankTransaction], paymentId int64) result.Result[bool]
+	// GetOutstandingItems returns the memberships and rentals still to be settled
+	GetOutstandingItems() result.Result[[]OutstandingItem]
+}
+
+// DetectBankStatementFormat tells the format from the file extension, or from the content when the extension is unknown
+func DetectBankStatementFormat(fileName string, content []byte) result.Result[BankStatementFormat] {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".csv", ".txt":
+		return result.Ok(CSVStatement)
+	case ".xml":
+		return result.Ok(CAMT053Statement)
+	}
+	if strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(string(content), "\ufeff")), "<") {
+		return result.Ok(CAMT053Statement)
+	}
+	if len(content) > 0 {
+		return result.Ok(CSVStatement)
+	}
+	return result.Err[BankStatementFormat](errors.PaymentError{Description: "unsupported bank statement, expected CSV or CAMT.053 XML"})
+}
+
+func (s BankStatement) Validate() result.Result[BankStatement] {
+	if len(s.Transactions) == 0 {
+		return result.Err[BankStatement](errors.PaymentError{Description: "the bank statement has no transactions"})
+	}
+	for _, transaction := range s.Transactions {
+		if transaction.BookingDate.IsZero() {
+			return result.Err[BankStatement](errors.PaymentError{Description: "every bank transaction needs a booking date"})
+		}
+		if transaction.Amount.IsZero() {
+			return result.Err[BankStatement](errors.PaymentError{Description: "bank transactions cannot have a zero amount"})
+		}
+	}
+	return result.Ok(s)
+}
+
+// Credits returns the incoming transfers, the only ones that can pay a membership or a rental
+func (s BankStatement) Credits() []BankTransaction {
+	credits := []BankTransaction{}
+	for _, transaction := range s.Transactions {
+		if transaction.IsCredit() {
+			credits = append(credits, transaction)
+		}
+	}
+	return credits
+}
+
+func (t BankTransaction) IsCredit() bool {
+	return t.Amount.IsPositive()
+}
+
+func (t BankTransaction) IsMatched() bool {
+	return t.PaymentId != nil
+}
+
+// Fingerprints identify the transactions of the statement across statements, so that overlapping statements
+// of the same account do not import them twice
+// Identical transactions of the statement, such as two equal fees paid by the same payer on the same day,
+// are told apart by their occurrence, so that both are imported, and skipped only by a statement covering the same day
+func (s BankStatement) Fingerprints() []string {
+	occurrences := make(map[string]int)
+	fingerprints := make([]string, len(s.Transactions))
+	for i, transaction := range s.Transactions {
+		content := transaction.fingerprintContent()
+		fingerprints[i] = transaction.Fingerprint(s.AccountIBAN, occurrences[content])
+		occurrences[content]++
+	}
+	return fingerprints
+}
+
+// Fingerprint identifies the transaction among the ones of the account, occurrence is the number of identical
+// transactions preceding it in its statement
+// The bank reference is used when there is one, the content of the transaction otherwise
+func (t BankTransaction) Fingerprint(accountIBAN string, occurrence int) string {
+	content := strings.Join([]string{
+		strings.ToUpper(strings.ReplaceAll(accountIBAN, " ", "")),
+		t.fingerprintContent(),
+		fmt.Sprintf("%d", occurrence),
+	}, "|")
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func (t BankTransaction) fingerprintContent() string {
+	if t.Reference != "" {
+		return "REF|" + t.Reference
+	}
+	return strings.Join([]string{
+		t.BookingDate.Format("2006-01-02"),
+		fmt.Sprintf("%d", t.Amount.MinorUnits()),
+		string(t.Amount.Currency()),
+		strings.ToUpper(strings.TrimSpace(t.CounterpartyName)),
+		strings.ToUpper(strings.TrimSpace(t.CounterpartyIBAN)),
+		strings.ToUpper(strings.TrimSpace(t.RemittanceInfo)),
+	}, "|")
+}
+
+// ToPayment is the payment recorded when the transaction is matched
+func (t BankTransaction) ToPayment() Transaction {
+	amount := t.Amount
+	if amount.Currency() == "" {
+		amount = money.New(amount.MinorUnits(), money.DefaultCurrency)
+	}
+	return Transaction{
+		Type:          PaymentTransaction,
+		Amount:        amount,
+		Date:          t.BookingDate,
+		PaymentMethod: BankTransferPaymentMethod,
+		Notes:         strings.TrimSpace(t.RemittanceInfo),
+	}
+}
@@ -0,0 +1,285 @@
+package payment
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+const (
+	// CashPaymentMethod is the method of the money going through the cash box
+	CashPaymentMethod = "cash"
+	// BankTransferPaymentMethod is the method of the money reconciled against the bank statement
+	BankTransferPaymentMethod = "bank transfer"
+)
+
+// MaxCashRegisterPeriodDays bounds the periods that can be reported at once
+const MaxCashRegisterPeriodDays = 366
+
+type CashDayStatus string
+
+const (
+	CashDayOpen   CashDayStatus = "OPEN"
+	CashDayClosed CashDayStatus = "CLOSED"
+)
+
+type DiscrepancyType string
+
+const (
+	// CashCountDiscrepancy is a difference between the cash counted and the cash expected in the box
+	CashCountDiscrepancy DiscrepancyType = "CASH_COUNT"
+	// OpeningBalanceDiscrepancy is a difference between the opening balance and the cash counted when closing the previous day
+	OpeningBalanceDiscrepancy DiscrepancyType = "OPENING_BALANCE"
+	// BankTransfersDiscrepancy is a difference between the transfers on the bank statement and the ones recorded
+	BankTransfersDiscrepancy DiscrepancyType = "BANK_TRANSFERS"
+)
+
+// CashDay is a day of the cash register (prima nota) with the transactions paid on it
+// Once closed its transactions cannot be changed anymore
+// The cash register is kept in the default currency
+type CashDay struct {
+	Date                time.Time
+	OpeningBalance      money.Money  // Cash in the box when the day opens
+	CountedCash         *money.Money // Cash counted in the box when the day is closed
+	BankTransfers       *money.Money // Transfers of the day on the bank statement
+	Notes               string
+	ClosedAt            *time.Time
+	PreviousCountedCash *money.Money    // Cash counted when closing the previous closed day
+	Transactions        []PaymentRecord // Oldest first
+}
+
+// CashDayRecord holds the values recorded by the treasurer, nil values are left unchanged
+type CashDayRecord struct {
+	OpeningBalance *money.Money
+	CountedCash    *money.Money
+	BankTransfers  *money.Money
+	Notes          *string
+}
+
+// Discrepancy is a difference between what was recorded and what the transactions account for
+type Discrepancy struct {
+	Type     DiscrepancyType
+	Expected money.Money
+	Actual   money.Money
+}
+
+// CashRegisterPeriod is the cash register between two days, both included
+type CashRegisterPeriod struct {
+	From time.Time
+	To   time.Time
+	Days []CashDay // Days with transactions or recorded values, oldest first
+}
+
+type CashRegisterRepository interface {
+	// GetDay returns the day with its transactions, an open day with no values when nothing was recorded
+	GetDay(day time.Time) result.Result[CashDay]
+	// GetDays returns the days between from and to, both included, with transactions or recorded values
+	GetDays(from time.Time, to time.Time) result.Result[[]CashDay]
+	// SaveDay stores the recorded values and the closing time, PaymentError when the day was already closed
+	SaveDay(day CashDay) result.Result[CashDay]
+	IsDayClosed(day time.Time) result.Result[bool]
+}
+
+// Day returns the calendar day of a time, as read on its own clock
+func Day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (d CashDay) IsClosed() bool {
+	return d.ClosedAt != nil
+}
+
+func (d CashDay) GetStatus() CashDayStatus {
+	if d.IsClosed() {
+		return CashDayClosed
+	}
+	return CashDayOpen
+}
+
+// TotalsByMethod sums the transactions of the day by payment method,
+// CurrencyMismatchError when they are not all in the default currency
+func (d CashDay) TotalsByMethod() result.Result[[]MethodTotal] {
+	return totalsByMethod(d.Transactions)
+}
+
+// NetByMethod is what was received with the method once refunds are taken off,
+// CurrencyMismatchError when a transaction is not in the default currency
+func (d CashDay) NetByMethod(method string) result.Result[money.Money] {
+	amounts := []money.Money{}
+	for _, record := range d.Transactions {
+		if !isMethod(record.Transaction.PaymentMethod, method) {
+			continue
+		}
+		if record.Transaction.IsRefund() {
+			amounts = append(amounts, record.Transaction.Amount.Negate())
+		} else {
+			amounts = append(amounts, record.Transaction.Amount)
+		}
+	}
+	return money.Sum(money.DefaultCurrency, amounts...)
+}
+
+// ExpectedCash is the cash that should be in the box at the end of the day,
+// CurrencyMismatchError when the opening balance or a cash transaction is not in the default currency
+func (d CashDay) ExpectedCash() result.Result[money.Money] {
+	return result.Bind(d.NetByMethod(CashPaymentMethod), func(net money.Money) result.Result[money.Money] {
+		return money.Sum(money.DefaultCurrency, d.OpeningBalance, net)
+	})
+}
+
+// Discrepancies lists the recorded values that the transactions do not account for,
+// CurrencyMismatchError when the recorded values or the transactions are not all in the default currency
+func (d CashDay) Discrepancies() result.Result[[]Discrepancy] {
+	expectedCash := d.ExpectedCash()
+	if !expectedCash.IsSuccess() {
+		return result.Err[[]Discrepancy](expectedCash.Error())
+	}
+	transfers := d.NetByMethod(BankTransferPaymentMethod)
+	if !transfers.IsSuccess() {
+		return result.Err[[]Discrepancy](transfers.Error())
+	}
+
+	candidates := []Discrepancy{}
+	if d.PreviousCountedCash != nil {
+		candidates = append(candidates, Discrepancy{Type: OpeningBalanceDiscrepancy, Expected: *d.PreviousCountedCash, Actual: d.OpeningBalance})
+	}
+	if d.CountedCash != nil {
+		candidates = append(candidates, Discrepancy{Type: CashCountDiscrepancy, Expected: expectedCash.Value(), Actual: *d.CountedCash})
+	}
+	if d.BankTransfers != nil {
+		candidates = append(candidates, Discrepancy{Type: BankTransfersDiscrepancy, Expected: transfers.Value(), Actual: *d.BankTransfers})
+	}
+
+	discrepancies := []Discrepancy{}
+	for _, candidate := range candidates {
+		difference := candidate.Difference()
+		if !difference.IsSuccess() {
+			return result.Err[[]Discrepancy](difference.Error())
+		}
+		if !difference.Value().IsZero() {
+			discrepancies = append(discrepancies, candidate)
+		}
+	}
+	return result.Ok(discrepancies)
+}
+
+// Apply returns the day with the recorded values, a new day opens with the cash counted the day before
+func (d CashDay) Apply(record CashDayRecord) result.Result[CashDay] {
+	if d.IsClosed() {
+		return result.Err[CashDay](dayClosedError(d.Date))
+	}
+	for _, amount := range []*money.Money{record.OpeningBalance, record.CountedCash, record.BankTransfers} {
+		if amount != nil && amount.IsNegative() {
+			return result.Err[CashDay](errors.PaymentError{Description: "cash register amounts cannot be negative"})
+		}
+	}
+	if record.OpeningBalance != nil {
+		d.OpeningBalance = *record.OpeningBalance
+	}
+	if record.CountedCash != nil {
+		d.CountedCash = record.CountedCash
+	}
+	if record.BankTransfers != nil {
+		d.BankTransfers = record.BankTransfers
+	}
+	if record.Notes != nil {
+		d.Notes = strings.TrimSpace(*record.Notes)
+	}
+	return result.Ok(d)
+}
+
+// Close records the values and closes the day, which cannot be closed before it ends, without counting the cash
+// or when its amounts are not all in the default currency
+func (d CashDay) Close(record CashDayRecord, now time.Time) result.Result[CashDay] {
+	return result.Bind(d.Apply(record), func(day CashDay) result.Result[CashDay] {
+		if Day(now).Before(day.Date) {
+			return result.Err[CashDay](errors.PaymentError{Description: "a day cannot be closed before it starts"})
+		}
+		if day.CountedCash == nil {
+			return result.Err[CashDay](errors.PaymentError{Description: "counted cash is required to close the day"})
+		}
+		if discrepancies := day.Discrepancies(); !discrepancies.IsSuccess() {
+			return result.Err[CashDay](discrepancies.Error())
+		}
+		day.ClosedAt = &now
+		return result.Ok(day)
+	})
+}
+
+// Difference is how much more was recorded than expected, negative when something is missing,
+// CurrencyMismatchError when the amounts are in different currencies
+func (d Discrepancy) Difference() result.Result[money.Money] {
+	return d.Actual.Subtract(d.Expected)
+}
+
+// TotalsByMethod sums the transactions of every day of the period by payment method,
+// CurrencyMismatchError when they are not all in the default currency
+func (p CashRegisterPeriod) TotalsByMethod() result.Result[[]MethodTotal] {
+	transactions := []PaymentRecord{}
+	for _, day := range p.Days {
+		transactions = append(transactions, day.Transactions...)
+	}
+	return totalsByMethod(transactions)
+}
+
+func totalsByMethod(transactions []PaymentRecord) result.Result[[]MethodTotal] {
+	totals := map[string]*MethodTotal{}
+	for _, record := range transactions {
+		key := normalizeMethod(record.Transaction.PaymentMethod)
+		if _, ok := totals[key]; !ok {
+			totals[key] = &MethodTotal{
+				PaymentMethod: record.Transaction.PaymentMethod,
+				Paid:          money.Zero(money.DefaultCurrency),
+				Refunded:      money.Zero(money.DefaultCurrency),
+			}
+		}
+		total := totals[key]
+		total.Count++
+		sum := &total.Paid
+		if record.Transaction.IsRefund() {
+			sum = &total.Refunded
+		}
+		added := money.Sum(money.DefaultCurrency, *sum, record.Transaction.Amount)
+		if !added.IsSuccess() {
+			return result.Err[[]MethodTotal](added.Error())
+		}
+		*sum = added.Value()
+	}
+
+	methodTotals := make([]MethodTotal, 0, len(totals))
+	for _, total := range totals {
+		methodTotals = append(methodTotals, *total)
+	}
+	sort.Slice(methodTotals, func(i, j int) bool {
+		return normalizeMethod(methodTotals[i].PaymentMethod) < normalizeMethod(methodTotals[j].PaymentMethod)
+	})
+	return result.Ok(methodTotals)
+}
+
+func isMethod(method string, expected string) bool {
+	return normalizeMethod(method) == normalizeMethod(expected)
+}
+
+func normalizeMethod(method string) string {
+	return strings.ToLower(strings.TrimSpace(method))
+}
+
+// ValidateCashRegisterPeriod checks the period is ordered and not too long to be reported at once
+func ValidateCashRegisterPeriod(from time.Time, to time.Time) result.Result[CashRegisterPeriod] {
+	from, to = Day(from), Day(to)
+	if to.Before(from) {
+		return result.Err[CashRegisterPeriod](errors.PaymentError{Description: "from cannot be after to"})
+	}
+	if to.Sub(from).Hours()/24 >= MaxCashRegisterPeriodDays {
+		return result.Err[CashRegisterPeriod](errors.PaymentError{Description: "the period cannot be longer than 366 days"})
+	}
+	return result.Ok(CashRegisterPeriod{From: from, To: to, Days: []CashDay{}})
+}
+
+func dayClosedError(day time.Time) errors.PaymentError {
+	return errors.PaymentError{Description: "the cash register of " + day.Format("2006-01-02") + " is closed"}
+}
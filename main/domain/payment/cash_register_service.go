@@ -0,0 +1,43 @@
+package payment
+
+import (
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+type CashRegisterService struct {
+	repository CashRegisterRepository
+}
+
+func NewCashRegisterService(repository CashRegisterRepository) *CashRegisterService {
+	return &CashRegisterService{repository: repository}
+}
+
+func (this CashRegisterService) GetDay(day time.Time) result.Result[CashDay] {
+	return this.repository.GetDay(Day(day))
+}
+
+// GetPeriod returns the days of the period, both included, that have transactions or recorded values
+func (this CashRegisterService) GetPeriod(from time.Time, to time.Time) result.Result[CashRegisterPeriod] {
+	return result.Bind(ValidateCashRegisterPeriod(from, to), func(period CashRegisterPeriod) result.Result[CashRegisterPeriod] {
+		return result.Map(this.repository.GetDays(period.From, period.To), func(days []CashDay) CashRegisterPeriod {
+			period.Days = days
+			return period
+		})
+	})
+}
+
+// RecordDay stores the opening balance, the counted cash or the bank transfers of a day that is still open
+func (this CashRegisterService) RecordDay(day time.Time, record CashDayRecord) result.Result[CashDay] {
+	return result.Bind(this.repository.GetDay(Day(day)), func(cashDay CashDay) result.Result[CashDay] {
+		return result.Bind(cashDay.Apply(record), this.repository.SaveDay)
+	})
+}
+
+// CloseDay records the final values and closes the day, its transactions cannot be changed anymore
+func (this CashRegisterService) CloseDay(day time.Time, record CashDayRecord) result.Result[CashDay] {
+	return result.Bind(this.repository.GetDay(Day(day)), func(cashDay CashDay) result.Result[CashDay] {
+		return result.Bind(cashDay.Close(record, time.Now()), this.repository.SaveDay)
+	})
+}
@@ -0,0 +1,305 @@
+package payment
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// Documents out of the scope of VAT above the threshold need a revenue stamp (marca da bollo)
+var (
+	StampDutyThreshold = money.New(7747, money.EUR)
+	StampDutyAmount    = money.New(200, money.EUR)
+)
+
+type FiscalDocumentKind string
+
+const (
+	ReceiptDocument FiscalDocumentKind = "RECEIPT"
+	InvoiceDocument FiscalDocumentKind = "INVOICE"
+)
+
+// FiscalAddress is the address printed on a fiscal document
+type FiscalAddress struct {
+	Street       string
+	StreetNumber string
+	ZipCode      string
+	City         string
+	Province     string
+	Country      string
+}
+
+// Issuer is the club issuing the documents, taken from the club configuration
+type Issuer struct {
+	Name             string
+	TaxCode          string
+	VATNumber        string
+	Address          FiscalAddress
+	Email            string
+	VATExemptionNote string  // Printed on the documents with lines out of the scope of VAT, such as the fees of the members
+	RentalVATRate    float64 // Percentage charged on the rentals to members billed as a business, 0 when out of the scope of VAT
+}
+
+// Recipient is the member the document is issued to, as they were when it was issued
+// The business fields are empty unless the member is billed as a business
+type Recipient struct {
+	MemberId      int64
+	FirstName     string
+	LastName      string
+	TaxCode       string
+	CompanyName   string
+	VATNumber     string
+	RecipientCode string // SdI code the electronic invoice is delivered to
+	PEC           string // Certified email the electronic invoice is delivered to
+	Address       FiscalAddress
+}
+
+// IsBusiness tells whether the recipient is billed as a business
+func (r Recipient) IsBusiness() bool {
+	return strings.TrimSpace(r.VATNumber) != ""
+}
+
+// FiscalDocumentLine is a payment of a membership period or a rental
+type FiscalDocumentLine struct {
+	PaymentId     int64
+	Description   string
+	PaidOn        time.Time
+	PaymentMethod string
+	Amount        money.Money // VAT included
+	VATRate       float64     // Percentage, 0 for payments out of the scope of VAT
+}
+
+// FiscalDocument is a receipt or an invoice, numbered without gaps within its kind and fiscal year
+// Issuer and recipient are copied at issue time, so that the document can be generated again as it was
+type FiscalDocument struct {
+	Id         domain.Id[FiscalDocument]
+	Kind       FiscalDocumentKind
+	FiscalYear int
+	Number     int // Assigned when the document is stored
+	IssuedOn   time.Time
+	Issuer     Issuer
+	Recipient  Recipient
+	Currency   string
+	Lines      []FiscalDocumentLine
+	Notes      []string
+}
+
+// BillablePayment is a payment with what is needed to describe it on a fiscal document
+type BillablePayment struct {
+	Record           PaymentRecord
+	SeasonCode       string
+	FacilityName     string // Empty for memberships
+	TaxCode          string
+	Address          FiscalAddress
+	CompanyName      string // Billing details, empty unless the member is billed as a business
+	VATNumber        string
+	RecipientCode    string
+	PEC              string
+	FiscalDocumentId *int64 // Document the payment is already on, if any
+}
+
+// FiscalDocumentCriteria filters the documents, nil fields are ignored
+type FiscalDocumentCriteria struct {
+	MemberId   *int64
+	FiscalYear *int
+	Kind       *FiscalDocumentKind
+	IssuedFrom *time.Time // Day, included
+	IssuedTo   *time.Time // Day, included
+}
+
+// ElectronicInvoice is the FatturaPA file of an invoice, named as the SdI expects it
+type ElectronicInvoice struct {
+	FileName string
+	Content  []byte
+}
+
+// ElectronicInvoiceEncoder turns an invoice into a FatturaPA file, failing when the result would not be valid
+type ElectronicInvoiceEncoder interface {
+	Encode(document FiscalDocument) (ElectronicInvoice, error)
+}
+
+type FiscalDocumentRepository interface {
+	// GetBillablePayments returns the payments with the given ids, the missing ones are left out
+	GetBillablePayments(paymentIds []int64) result.Result[[]BillablePayment]
+	// CreateDocument numbers the document in its sequence and stores it, PaymentError when a payment was documented meanwhile
+	CreateDocument(document FiscalDocument) result.Result[FiscalDocument]
+	// GetDocument returns the document, NotFoundError when it does not exist
+	GetDocument(id domain.Id[FiscalDocument]) result.Result[FiscalDocument]
+	GetDocuments(criteria FiscalDocumentCriteria) result.Result[[]FiscalDocument]
+}
+
+func ValidateFiscalDocumentKind(kind FiscalDocumentKind) result.Result[FiscalDocumentKind] {
+	switch kind {
+	case ReceiptDocument, InvoiceDocument:
+		return result.Ok(kind)
+	}
+	return result.Err[FiscalDocumentKind](errors.PaymentError{Description: "unknown document kind " + string(kind) + ", expected RECEIPT or INVOICE"})
+}
+
+// NewFiscalDocument builds the document for payments of a single member, one line per payment
+// Memberships come first, then the rentals, each group in the order the payments were made
+func NewFiscalDocument(kind FiscalDocumentKind, issuer Issuer, payments []BillablePayment, issuedOn time.Time) result.Result[FiscalDocument] {
+	if kind := ValidateFiscalDocumentKind(kind); !kind.IsSuccess() {
+		return result.Err[FiscalDocument](kind.Error())
+	}
+	if strings.TrimSpace(issuer.Name) == "" || (strings.TrimSpace(issuer.TaxCode) == "" && strings.TrimSpace(issuer.VATNumber) == "") {
+		return result.Err[FiscalDocument](errors.PaymentError{Description: "the club configuration needs a name and a tax code or VAT number to issue documents"})
+	}
+	if len(payments) == 0 {
+		return result.Err[FiscalDocument](errors.PaymentError{Description: "at least one payment is required"})
+	}
+
+	first := payments[0]
+	for _, p := range payments {
+		if p.FiscalDocumentId != nil {
+			return result.Err[FiscalDocument](errors.PaymentError{Description: fmt.Sprintf("payment %d is already on document %d", p.Record.Transaction.Id.Value, *p.FiscalDocumentId)})
+		}
+		if p.Record.Transaction.IsRefund() {
+			return result.Err[FiscalDocument](errors.PaymentError{Description: fmt.Sprintf("transaction %d is a refund, only payments can be documented", p.Record.Transaction.Id.Value)})
+		}
+		if p.Record.MemberId != first.Record.MemberId {
+			return result.Err[FiscalDocument](errors.PaymentError{Description: "all the payments of a document must belong to the same member"})
+		}
+		if p.Record.Transaction.Amount.Currency() != first.Record.Transaction.Amount.Currency() {
+			return result.Err[FiscalDocument](errors.PaymentError{Description: "all the payments of a document must be in the same currency"})
+		}
+	}
+
+	sorted := append([]BillablePayment{}, payments...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Record, sorted[j].Record
+		if a.Target != b.Target {
+			return a.Target == MembershipTarget
+		}
+		return a.Transaction.Date.Before(b.Transaction.Date)
+	})
+
+	document := FiscalDocument{
+		Kind:       kind,
+		FiscalYear: issuedOn.Year(),
+		IssuedOn:   Day(issuedOn),
+		Issuer:     issuer,
+		Recipient: Recipient{
+			MemberId:      first.Record.MemberId,
+			FirstName:     first.Record.FirstName,
+			LastName:      first.Record.LastName,
+			TaxCode:       strings.ToUpper(strings.TrimSpace(first.TaxCode)),
+			CompanyName:   strings.TrimSpace(first.CompanyName),
+			VATNumber:     strings.ToUpper(strings.TrimSpace(first.VATNumber)),
+			RecipientCode: strings.ToUpper(strings.TrimSpace(first.RecipientCode)),
+			PEC:           strings.TrimSpace(first.PEC),
+			Address:       first.Address,
+		},
+		Currency: string(first.Record.Transaction.Amount.Currency()),
+		Lines:    make([]FiscalDocumentLine, 0, len(sorted)),
+	}
+	if document.Currency == "" {
+		document.Currency = string(money.DefaultCurrency)
+	}
+	for _, p := range sorted {
+		document.Lines = append(document.Lines, FiscalDocumentLine{
+			PaymentId:     p.Record.Transaction.Id.Value,
+			Description:   p.Description(),
+			PaidOn:        Day(p.Record.Transaction.Date),
+			PaymentMethod: p.Record.Transaction.PaymentMethod,
+			Amount:        p.Record.Transaction.Amount,
+			VATRate:       document.vatRateOf(p),
+		})
+	}
+
+	if !document.Total().IsPositive() {
+		return result.Err[FiscalDocument](errors.PaymentError{Description: "the total of a document must be greater than 0"})
+	}
+	document.Notes = document.legalNotes()
+	return result.Ok(document)
+}
+
+// Description is the text of the line of the payment, the membership or the facility with its season
+func (p BillablePayment) Description() string {
+	season := ""
+	if p.SeasonCode != "" {
+		season = " - stagione " + p.SeasonCode
+	}
+	if p.Record.Target == FacilityTarget {
+		facility := strings.TrimSpace(p.FacilityName + " " + p.Record.FacilityIdentifier)
+		if facility == "" {
+			facility = "Servizio"
+		}
+		return facility + season
+	}
+	return "Quota associativa" + season
+}
+
+// vatRateOf is the VAT rate of the line of a payment, only rentals to businesses are subject to VAT
+func (d FiscalDocument) vatRateOf(p BillablePayment) float64 {
+	if p.Record.Target == FacilityTarget && d.Recipient.IsBusiness() {
+		return d.Issuer.RentalVATRate
+	}
+	return 0
+}
+
+// Total is the sum of the lines
+func (d FiscalDocument) Total() money.Money {
+	var total int64
+	for _, line := range d.Lines {
+		total += line.Amount.MinorUnits()
+	}
+	return money.New(total, money.Currency(d.Currency))
+}
+
+// ExemptTotal is the sum of the lines out of the scope of VAT
+func (d FiscalDocument) ExemptTotal() money.Money {
+	var total int64
+	for _, line := range d.Lines {
+		if line.IsVATExempt() {
+			total += line.Amount.MinorUnits()
+		}
+	}
+	return money.New(total, money.Currency(d.Currency))
+}
+
+// IsVATExempt tells whether the line is out of the scope of VAT
+func (l FiscalDocumentLine) IsVATExempt() bool {
+	return l.VATRate == 0
+}
+
+// Code is the number of the document within its fiscal year, such as 12/2026
+func (d FiscalDocument) Code() string {
+	return fmt.Sprintf("%d/%d", d.Number, d.FiscalYear)
+}
+
+// IsVATExempt tells whether the document has lines out of the scope of VAT, as the issuer declares an exemption
+func (d FiscalDocument) IsVATExempt() bool {
+	if strings.TrimSpace(d.Issuer.VATExemptionNote) == "" {
+		return false
+	}
+	for _, line := range d.Lines {
+		if line.IsVATExempt() {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresStampDuty tells whether a revenue stamp is due, when the amount out of the scope of VAT is above the threshold
+func (d FiscalDocument) RequiresStampDuty() bool {
+	return d.IsVATExempt() && StampDutyThreshold.LessThan(d.ExemptTotal())
+}
+
+// legalNotes are the VAT exemption note of the issuer and, when due, the stamp duty one
+func (d FiscalDocument) legalNotes() []string {
+	notes := []string{}
+	if d.IsVATExempt() {
+		notes = append(notes, strings.TrimSpace(d.Issuer.VATExemptionNote))
+	}
+	if d.RequiresStampDuty() {
+		notes = append(notes, "Imposta di bollo di "+StampDutyAmount.String()+" assolta sull'originale")
+	}
+	return notes
+}
@@ -0,0 +1,108 @@
+package payment
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// MaxElectronicInvoicePeriodDays is the longest period whose electronic invoices can be exported at once
+const MaxElectronicInvoicePeriodDays = 366
+
+type FiscalDocumentService struct {
+	repository FiscalDocumentRepository
+	issuer     Issuer
+	encoder    ElectronicInvoiceEncoder
+}
+
+func NewFiscalDocumentService(repository FiscalDocumentRepository, issuer Issuer, encoder ElectronicInvoiceEncoder) *FiscalDocumentService {
+	return &FiscalDocumentService{repository: repository, issuer: issuer, encoder: encoder}
+}
+
+// IssueDocument issues a receipt or an invoice, dated today, for payments of a single member
+func (this FiscalDocumentService) IssueDocument(kind FiscalDocumentKind, paymentIds []int64) result.Result[FiscalDocument] {
+	if len(paymentIds) == 0 {
+		return result.Err[FiscalDocument](errors.PaymentError{Description: "at least one payment is required"})
+	}
+	seen := map[int64]bool{}
+	for _, id := range paymentIds {
+		if seen[id] {
+			return result.Err[FiscalDocument](errors.PaymentError{Description: fmt.Sprintf("payment %d is listed more than once", id)})
+		}
+		seen[id] = true
+	}
+
+	payments := result.Bind(this.repository.GetBillablePayments(paymentIds), func(payments []BillablePayment) result.Result[[]BillablePayment] {
+		for _, p := range payments {
+			delete(seen, p.Record.Transaction.Id.Value)
+		}
+		for id := range seen {
+			return result.Err[[]BillablePayment](errors.NotFoundError{Description: fmt.Sprintf("payment %d not found", id)})
+		}
+		return result.Ok(payments)
+	})
+	document := result.Bind(payments, func(payments []BillablePayment) result.Result[FiscalDocument] {
+		return NewFiscalDocument(kind, this.issuer, payments, time.Now())
+	})
+	return result.Bind(document, this.repository.CreateDocument)
+}
+
+func (this FiscalDocumentService) GetDocument(id domain.Id[FiscalDocument]) result.Result[FiscalDocument] {
+	return this.repository.GetDocument(id)
+}
+
+func (this FiscalDocumentService) GetDocuments(criteria FiscalDocumentCriteria) result.Result[[]FiscalDocument] {
+	if criteria.Kind != nil {
+		if kind := ValidateFiscalDocumentKind(*criteria.Kind); !kind.IsSuccess() {
+			return result.Err[[]FiscalDocument](kind.Error())
+		}
+	}
+	return this.repository.GetDocuments(criteria)
+}
+
+// ExportElectronicInvoice returns the FatturaPA file of an invoice, receipts have none
+func (this FiscalDocumentService) ExportElectronicInvoice(id domain.Id[FiscalDocument]) result.Result[ElectronicInvoice] {
+	return result.Bind(this.repository.GetDocument(id), func(document FiscalDocument) result.Result[ElectronicInvoice] {
+		if document.Kind != InvoiceDocument {
+			return result.Err[ElectronicInvoice](errors.PaymentError{Description: "only invoices can be exported as electronic invoices, document " + document.Code() + " is a receipt"})
+		}
+		return this.encode(document)
+	})
+}
+
+// ExportElectronicInvoices returns the FatturaPA files of the invoices issued in a period, days included
+// Nothing is returned unless every invoice of the period can be encoded
+func (this FiscalDocumentService) ExportElectronicInvoices(from time.Time, to time.Time) result.Result[[]ElectronicInvoice] {
+	from, to = Day(from), Day(to)
+	if to.Before(from) {
+		return result.Err[[]ElectronicInvoice](errors.PaymentError{Description: "the end of the period cannot be before its start"})
+	}
+	if to.Sub(from) >= MaxElectronicInvoicePeriodDays*24*time.Hour {
+		return result.Err[[]ElectronicInvoice](errors.PaymentError{Description: fmt.Sprintf("the period cannot be longer than %d days", MaxElectronicInvoicePeriodDays)})
+	}
+
+	kind := InvoiceDocument
+	criteria := FiscalDocumentCriteria{Kind: &kind, IssuedFrom: &from, IssuedTo: &to}
+	return result.Bind(this.repository.GetDocuments(criteria), func(documents []FiscalDocument) result.Result[[]ElectronicInvoice] {
+		invoices := make([]ElectronicInvoice, 0, len(documents))
+		for _, document := range documents {
+			invoice := this.encode(document)
+			if !invoice.IsSuccess() {
+				return result.Err[[]ElectronicInvoice](invoice.Error())
+			}
+			invoices = append(invoices, invoice.Value())
+		}
+		return result.Ok(invoices)
+	})
+}
+
+func (this FiscalDocumentService) encode(document FiscalDocument) result.Result[ElectronicInvoice] {
+	invoice, err := this.encoder.Encode(document)
+	if err != nil {
+		return result.Err[ElectronicInvoice](errors.PaymentError{Description: "invoice " + document.Code() + " cannot be exported: " + err.Error()})
+	}
+	return result.Ok(invoice)
+}
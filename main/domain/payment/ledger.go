@@ -0,0 +1,180 @@
+package payment
+
+import (
+	"fmt"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// Ledger is the amount due for a membership period or a rental with the transactions settling it
+// Transactions must be in the currency of the amount due
+type Ledger struct {
+	Due          money.Money
+	Transactions []Transaction // Oldest first
+}
+
+// Paid is the sum of the payments received, CurrencyMismatchError when one is not in the currency of the amount due
+func (l Ledger) Paid() result.Result[money.Money] {
+	return l.sum(PaymentTransaction)
+}
+
+// Refunded is the sum of the refunds given back, CurrencyMismatchError when one is not in the currency of the amount due
+func (l Ledger) Refunded() result.Result[money.Money] {
+	return l.sum(RefundTransaction)
+}
+
+// NetPaid is what was received once refunds are taken off, CurrencyMismatchError when a transaction
+// is not in the currency of the amount due
+func (l Ledger) NetPaid() result.Result[money.Money] {
+	return result.Bind(l.Paid(), func(paid money.Money) result.Result[money.Money] {
+		return result.Bind(l.Refunded(), paid.Subtract)
+	})
+}
+
+// Balance is what is still owed, negative when more than due was paid,
+// CurrencyMismatchError when a transaction is not in the currency of the amount due
+func (l Ledger) Balance() result.Result[money.Money] {
+	return result.Bind(l.NetPaid(), func(net money.Money) result.Result[money.Money] {
+		return money.Sum(l.Currency(), l.Due, net.Negate())
+	})
+}
+
+// Currency is the currency of the amount due, the default one when no amount is set
+func (l Ledger) Currency() money.Currency {
+	if l.Due.Currency() == "" {
+		return money.DefaultCurrency
+	}
+	return l.Due.Currency()
+}
+
+// GetStatus tells how far the amount due was paid, CurrencyMismatchError when a transaction
+// is not in the currency of the amount due
+func (l Ledger) GetStatus() result.Result[PaymentStatus] {
+	refunded := l.Refunded()
+	if !refunded.IsSuccess() {
+		return result.Err[PaymentStatus](refunded.Error())
+	}
+	net := l.NetPaid()
+	if !net.IsSuccess() {
+		return result.Err[PaymentStatus](net.Error())
+	}
+	due := l.Due.MinorUnits()
+	netPaid := net.Value().MinorUnits()
+	switch {
+	case refunded.Value().IsPositive() && netPaid <= 0 && due > 0:
+		return result.Ok(Refunded)
+	case netPaid > due:
+		return result.Ok(Overpaid)
+	case netPaid == due:
+		return result.Ok(Paid)
+	case netPaid > 0:
+		return result.Ok(PartiallyPaid)
+	default:
+		return result.Ok(Unpaid)
+	}
+}
+
+// IsSettled tells whether nothing is owed anymore, CurrencyMismatchError when a transaction
+// is not in the currency of the amount due
+func (l Ledger) IsSettled() result.Result[bool] {
+	return result.Map(l.Balance(), func(balance money.Money) bool {
+		return !balance.IsPositive()
+	})
+}
+
+// LastPayment returns the latest payment received, nil when there is none
+func (l Ledger) LastPayment() *Transaction {
+	for i := len(l.Transactions) - 1; i >= 0; i-- {
+		if !l.Transactions[i].IsRefund() {
+			return &l.Transactions[i]
+		}
+	}
+	return nil
+}
+
+// Find returns the transaction with the given id, nil when it is not in the ledger
+func (l Ledger) Find(id domain.Id[Transaction]) *Transaction {
+	for i := range l.Transactions {
+		if l.Transactions[i].Id == id {
+			return &l.Transactions[i]
+		}
+	}
+	return nil
+}
+
+// With returns the ledger with the transaction added, or replaced when one with the same id is there
+func (l Ledger) With(transaction Transaction) Ledger {
+	transactions := make([]Transaction, 0, len(l.Transactions)+1)
+	replaced := false
+	for _, existing := range l.Transactions {
+		if transaction.Id.Value != 0 && existing.Id == transaction.Id {
+			transactions = append(transactions, transaction)
+			replaced = true
+			continue
+		}
+		transactions = append(transactions, existing)
+	}
+	if !replaced {
+		transactions = append(transactions, transaction)
+	}
+	return Ledger{Due: l.Due, Transactions: transactions}
+}
+
+// Without returns the ledger with the transaction removed
+func (l Ledger) Without(id domain.Id[Transaction]) Ledger {
+	transactions := make([]Transaction, 0, len(l.Transactions))
+	for _, existing := range l.Transactions {
+		if existing.Id != id {
+			transactions = append(transactions, existing)
+		}
+	}
+	return Ledger{Due: l.Due, Transactions: transactions}
+}
+
+// Validate checks the transactions are in the currency of the amount due and no more was refunded than was paid
+func (l Ledger) Validate() result.Result[Ledger] {
+	for _, transaction := range l.Transactions {
+		if transaction.Amount.Currency() != "" && transaction.Amount.Currency() != l.Currency() {
+			return result.Err[Ledger](errors.CurrencyMismatchError{Description: fmt.Sprintf("a transaction in %s cannot settle an amount due in %s", transaction.Amount.Currency(), l.Currency())})
+		}
+	}
+	net := l.NetPaid()
+	if !net.IsSuccess() {
+		return result.Err[Ledger](net.Error())
+	}
+	if net.Value().IsNegative() {
+		return result.Err[Ledger](errors.PaymentError{Description: fmt.Sprintf("refunds of %s exceed the %s paid", l.Refunded().Value(), l.Paid().Value())})
+	}
+	return result.Ok(l)
+}
+
+// ValidateTransaction checks a single transaction, payments default to the PAYMENT type
+func ValidateTransaction(transaction Transaction) result.Result[Transaction] {
+	if transaction.Type == "" {
+		transaction.Type = PaymentTransaction
+	}
+	if transaction.Type != PaymentTransaction && transaction.Type != RefundTransaction {
+		return result.Err[Transaction](errors.PaymentError{Description: "unknown transaction type " + string(transaction.Type)})
+	}
+	if transaction.Amount.IsNegative() {
+		return result.Err[Transaction](errors.PaymentError{Description: "amount must be greater than or equal to 0"})
+	}
+	if transaction.IsRefund() && transaction.Amount.IsZero() {
+		return result.Err[Transaction](errors.PaymentError{Description: "refund amount must be greater than 0"})
+	}
+	return result.Ok(transaction)
+}
+
+// sum adds up the transactions of the given type in the currency of the amount due
+func (l Ledger) sum(transactionType TransactionType) result.Result[money.Money] {
+	amounts := []money.Money{}
+	for _, transaction := range l.Transactions {
+		if transaction.Type == transactionType {
+			amounts = append(amounts, transaction.Amount)
+		}
+	}
+	return money.Sum(l.Currency(), amounts...)
+}
@@ -2,28 +2,29 @@ package payment
 
 import (
 	"time"
-)
 
-type Payment interface {
-	GetStatus() PaymentStatus
-}
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+)
 
-type PaymentPaid struct {
-	ID             int64
-	AmountPaid     float64
-	Currency       string
-	PaymentDate    time.Time
-	PaymentMethod  string
-	TransactionRef string
-}
+type TransactionType string
 
-func (p PaymentPaid) GetStatus() PaymentStatus {
-	return Paid
-}
+const (
+	PaymentTransaction TransactionType = "PAYMENT"
+	RefundTransaction  TransactionType = "REFUND"
+)
 
-type PaymentUnpaid struct {
+// Transaction is money received for, or given back on, a membership period or a rental
+// Amounts are always positive, the type tells the direction
+type Transaction struct {
+	Id            domain.Id[Transaction]
+	Type          TransactionType
+	Amount        money.Money
+	Date          time.Time
+	PaymentMethod string
+	Notes         string
 }
 
-func (p PaymentUnpaid) GetStatus() PaymentStatus {
-	return Unpaid
+func (t Transaction) IsRefund() bool {
+	return t.Type == RefundTransaction
 }
@@ -0,0 +1,260 @@
+package payment
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// LateFeeKind tells how the fee charged on a late payment is computed
+type LateFeeKind string
+
+const (
+	FixedLateFee      LateFeeKind = "FIXED"      // A fixed amount
+	PercentageLateFee LateFeeKind = "PERCENTAGE" // A percentage of what is still owed
+)
+
+// LateFeeRule is the fee charged on what is still owed some days after the deadline
+type LateFeeRule struct {
+	Kind       LateFeeKind
+	Amount     money.Money // Charged by a fixed fee
+	Percentage float64     // Percentage points of the balance charged by a percentage fee
+	AfterDays  int         // Days of grace after the deadline before the fee applies
+}
+
+// PaymentDeadline is the day by which the memberships, or the rentals of a facility type, must be paid in a season
+type PaymentDeadline struct {
+	Id               int64
+	SeasonId         int64
+	Target           PaymentTarget
+	FacilityTypeId   *int64 // Set for rentals only
+	FacilityTypeName string
+	DueOn            time.Time
+	LateFee          *LateFeeRule // No fee when nil
+}
+
+// OverdueItem is a membership or a rental still owed after its deadline
+type OverdueItem struct {
+	Item             OutstandingItem
+	DueOn            time.Time
+	DaysLate         int
+	LateFee          money.Money
+	LateFeeChargedOn *time.Time  // Day the late fee was charged, nil when no fee is charged
+	Total            money.Money // What is owed for the item, late fee included
+}
+
+// ChargedLateFee is the late fee charged on a membership or a rental the day it first became liable to it
+// The fee is kept as charged, later payments or changes to the deadline do not change it
+type ChargedLateFee struct {
+	MembershipPeriodId *int64
+	RentedFacilityId   *int64
+	Amount             money.Money
+	ChargedOn          time.Time
+}
+
+// PaymentDeadlineRepository defines the interface for the payment deadlines of the seasons
+type PaymentDeadlineRepository interface {
+	// GetDeadlines returns the deadlines of a season, of every season when nil, memberships first
+	GetDeadlines(seasonId *int64) result.Result[[]PaymentDeadline]
+	// SetDeadlines replaces the deadlines of a season in a single transaction
+	SetDeadlines(seasonId int64, deadlines []PaymentDeadline) result.Result[[]PaymentDeadline]
+	// GetOutstandingItems returns the memberships and rentals still to be settled, of a single season when given
+	GetOutstandingItems(seasonId *int64) result.Result[[]OutstandingItem]
+	// GetChargedLateFees returns the late fees charged on the memberships and rentals, of a single season when given
+	GetChargedLateFees(seasonId *int64) result.Result[[]ChargedLateFee]
+	// ChargeLateFees stores the late fees, keeping the fees already charged on the same items
+	ChargeLateFees(fees []ChargedLateFee) result.Result[bool]
+}
+
+// NewPaymentDeadline validates a deadline, PaymentError when it is not consistent
+func NewPaymentDeadline(seasonId int64, target PaymentTarget, facilityTypeId *int64, dueOn time.Time, lateFee *LateFeeRule) result.Result[PaymentDeadline] {
+	switch target {
+	case MembershipTarget:
+		if facilityTypeId != nil {
+			return result.Err[PaymentDeadline](errors.PaymentError{Description: "a membership deadline cannot have a facility type"})
+		}
+	case FacilityTarget:
+		if facilityTypeId == nil {
+			return result.Err[PaymentDeadline](errors.PaymentError{Description: "a rental deadline needs a facility type"})
+		}
+	default:
+		return result.Err[PaymentDeadline](errors.PaymentError{Description: "unknown payment target " + string(target) + ", expected MEMBERSHIP or FACILITY"})
+	}
+	if dueOn.IsZero() {
+		return result.Err[PaymentDeadline](errors.PaymentError{Description: "the due date is required"})
+	}
+	if lateFee != nil {
+		if err := lateFee.validate(); err != nil {
+			return result.Err[PaymentDeadline](err)
+		}
+	}
+
+	return result.Ok(PaymentDeadline{
+		SeasonId:       seasonId,
+		Target:         target,
+		FacilityTypeId: facilityTypeId,
+		DueOn:          Day(dueOn),
+		LateFee:        lateFee,
+	})
+}
+
+func (r LateFeeRule) validate() error {
+	switch r.Kind {
+	case FixedLateFee:
+		if !r.Amount.IsPositive() {
+			return errors.PaymentError{Description: "the late fee must be greater than zero"}
+		}
+	case PercentageLateFee:
+		if r.Percentage <= 0 {
+			return errors.PaymentError{Description: "the late fee must be greater than zero"}
+		}
+		if r.Percentage > 100 {
+			return errors.PaymentError{Description: "a late fee cannot be more than 100% of what is owed"}
+		}
+	default:
+		return errors.PaymentError{Description: "unknown late fee kind " + string(r.Kind) + ", expected FIXED or PERCENTAGE"}
+	}
+	if r.AfterDays < 0 {
+		return errors.PaymentError{Description: "the days before the late fee applies cannot be negative"}
+	}
+	return nil
+}
+
+// Fee is what is charged on the balance once the payment is the given days late, zero during the grace days
+// A fixed fee in another currency than the balance is a CurrencyMismatchError
+func (r LateFeeRule) Fee(balance money.Money, daysLate int) result.Result[money.Money] {
+	if daysLate <= r.AfterDays || !balance.IsPositive() {
+		return result.Ok(money.Zero(balance.Currency()))
+	}
+	if r.Kind == PercentageLateFee {
+		return result.Ok(balance.Percentage(r.Percentage))
+	}
+	return money.Zero(balance.Currency()).Add(r.Amount)
+}
+
+// IsOf tells whether the fee was charged on the outstanding item
+func (f ChargedLateFee) IsOf(item OutstandingItem) bool {
+	if item.MembershipPeriodId != nil {
+		return f.MembershipPeriodId != nil && *f.MembershipPeriodId == *item.MembershipPeriodId
+	}
+	return item.RentedFacilityId != nil && f.RentedFacilityId != nil && *f.RentedFacilityId == *item.RentedFacilityId
+}
+
+// AppliesTo tells whether the deadline is the one of the outstanding item
+func (d PaymentDeadline) AppliesTo(item OutstandingItem) bool {
+	if d.SeasonId != item.SeasonId || d.Target != item.Target {
+		return false
+	}
+	if d.Target == FacilityTarget {
+		return d.FacilityTypeId != nil && item.FacilityTypeId != nil && *d.FacilityTypeId == *item.FacilityTypeId
+	}
+	return true
+}
+
+// DaysLate is how many days after the deadline the given day is, zero up to the due date included
+func (d PaymentDeadline) DaysLate(today time.Time) int {
+	days := int(Day(today).Sub(Day(d.DueOn)).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// Overdue checks the item against the deadline, nil when nothing is owed or the deadline has not passed yet
+// The fee already charged on the item is kept, otherwise the fee of the deadline is charged on the given day
+// CurrencyMismatchError when the late fee is in another currency than the balance
+func (d PaymentDeadline) Overdue(item OutstandingItem, charged *ChargedLateFee, today time.Time) result.Result[*OverdueItem] {
+	daysLate := d.DaysLate(today)
+	if daysLate == 0 || !item.Balance.IsPositive() {
+		return result.Ok[*OverdueItem](nil)
+	}
+
+	fee := result.Ok(money.Zero(item.Balance.Currency()))
+	var chargedOn *time.Time
+	switch {
+	case charged != nil:
+		fee = result.Ok(charged.Amount)
+		chargedOn = &charged.ChargedOn
+	case d.LateFee != nil:
+		fee = d.LateFee.Fee(item.Balance, daysLate)
+	}
+	return result.Bind(fee, func(fee money.Money) result.Result[*OverdueItem] {
+		if chargedOn == nil && fee.IsPositive() {
+			day := Day(today)
+			chargedOn = &day
+		}
+		return result.Map(item.Balance.Add(fee), func(total money.Money) *OverdueItem {
+			return &OverdueItem{Item: item, DueOn: d.DueOn, DaysLate: daysLate, LateFee: fee, LateFeeChargedOn: chargedOn, Total: total}
+		})
+	})
+}
+
+// NewOverdueItems returns the outstanding items whose deadline has passed on the given day, in the order of the items
+// Items without a deadline are never overdue, the fees already charged are kept
+func NewOverdueItems(items []OutstandingItem, deadlines []PaymentDeadline, charged []ChargedLateFee, today time.Time) result.Result[[]OverdueItem] {
+	overdue := []OverdueItem{}
+	for _, item := range items {
+		for _, deadline := range deadlines {
+			if !deadline.AppliesTo(item) {
+				continue
+			}
+			late := deadline.Overdue(item, chargedLateFeeOf(item, charged), today)
+			if !late.IsSuccess() {
+				return result.Err[[]OverdueItem](late.Error())
+			}
+			if late.Value() != nil {
+				overdue = append(overdue, *late.Value())
+			}
+			break
+		}
+	}
+	return result.Ok(overdue)
+}
+
+// NewChargedLateFees returns the late fees of the overdue items not charged yet, to be kept as charged
+func NewChargedLateFees(overdue []OverdueItem, charged []ChargedLateFee) []ChargedLateFee {
+	fees := []ChargedLateFee{}
+	for _, late := range overdue {
+		if !late.LateFee.IsPositive() || chargedLateFeeOf(late.Item, charged) != nil {
+			continue
+		}
+		fees = append(fees, ChargedLateFee{
+			MembershipPeriodId: late.Item.MembershipPeriodId,
+			RentedFacilityId:   late.Item.RentedFacilityId,
+			Amount:             late.LateFee,
+			ChargedOn:          *late.LateFeeChargedOn,
+		})
+	}
+	return fees
+}
+
+func chargedLateFeeOf(item OutstandingItem, charged []ChargedLateFee) *ChargedLateFee {
+	for i := range charged {
+		if charged[i].IsOf(item) {
+			return &charged[i]
+		}
+	}
+	return nil
+}
+
+// validateDeadlines checks that no membership or facility type has more than one deadline
+func validateDeadlines(deadlines []PaymentDeadline) error {
+	seen := map[string]bool{}
+	for _, deadline := range deadlines {
+		key := string(deadline.Target)
+		if deadline.FacilityTypeId != nil {
+			key = fmt.Sprintf("%s-%d", key, *deadline.FacilityTypeId)
+		}
+		if seen[key] {
+			if deadline.Target == MembershipTarget {
+				return errors.PaymentError{Description: "memberships can have a single deadline per season"}
+			}
+			return errors.PaymentError{Description: fmt.Sprintf("facility type %d has more than one deadline", *deadline.FacilityTypeId)}
+		}
+		seen[key] = true
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package payment
+
+import (
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// PaymentDeadlineService keeps the payment deadlines of the seasons and tells which payments are late
+type PaymentDeadlineService struct {
+	repository PaymentDeadlineRepository
+}
+
+func NewPaymentDeadlineService(repository PaymentDeadlineRepository) *PaymentDeadlineService {
+	return &PaymentDeadlineService{repository: repository}
+}
+
+func (this PaymentDeadlineService) GetDeadlines(seasonId int64) result.Result[[]PaymentDeadline] {
+	return this.repository.GetDeadlines(&seasonId)
+}
+
+// SetDeadlines replaces the deadlines of the season, PaymentError when one is invalid or repeated
+func (this PaymentDeadlineService) SetDeadlines(seasonId int64, deadlines []PaymentDeadline) result.Result[[]PaymentDeadline] {
+	validated := make([]PaymentDeadline, 0, len(deadlines))
+	for _, deadline := range deadlines {
+		valid := NewPaymentDeadline(seasonId, deadline.Target, deadline.FacilityTypeId, deadline.DueOn, deadline.LateFee)
+		if !valid.IsSuccess() {
+			return result.Err[[]PaymentDeadline](valid.Error())
+		}
+		validated = append(validated, valid.Value())
+	}
+	if err := validateDeadlines(validated); err != nil {
+		return result.Err[[]PaymentDeadline](err)
+	}
+	return this.repository.SetDeadlines(seasonId, validated)
+}
+
+// GetOverdueItems returns what is still owed after its deadline on the given day, of every season when nil
+// A late fee is computed on the balance of the day the item first becomes liable to it and is charged then,
+// the same fee is returned afterwards whatever is paid later
+func (this PaymentDeadlineService) GetOverdueItems(seasonId *int64, today time.Time) result.Result[[]OverdueItem] {
+	return result.Map(this.chargeLateFees(seasonId, today), func(charging lateFeeCharging) []OverdueItem {
+		return charging.overdue
+	})
+}
+
+// ChargeLateFees charges the late fees of every season that apply on the given day, so that each fee is computed
+// on the balance of its first day even when the overdue payments are not looked at, returning how many were charged
+func (this PaymentDeadlineService) ChargeLateFees(today time.Time) result.Result[int] {
+	return result.Map(this.chargeLateFees(nil, today), func(charging lateFeeCharging) int {
+		return len(charging.charged)
+	})
+}
+
+// lateFeeCharging is what is overdue on a day, with the late fees charged for the first time that day
+type lateFeeCharging struct {
+	overdue []OverdueItem
+	charged []ChargedLateFee
+}
+
+func (this PaymentDeadlineService) chargeLateFees(seasonId *int64, today time.Time) result.Result[lateFeeCharging] {
+	deadlines := this.repository.GetDeadlines(seasonId)
+	if !deadlines.IsSuccess() {
+		return result.Err[lateFeeCharging](deadlines.Error())
+	}
+	if len(deadlines.Value()) == 0 {
+		return result.Ok(lateFeeCharging{overdue: []OverdueItem{}, charged: []ChargedLateFee{}})
+	}
+	charged := this.repository.GetChargedLateFees(seasonId)
+	if !charged.IsSuccess() {
+		return result.Err[lateFeeCharging](charged.Error())
+	}
+	return result.Bind(this.repository.GetOutstandingItems(seasonId), func(items []OutstandingItem) result.Result[lateFeeCharging] {
+		return result.Bind(NewOverdueItems(items, deadlines.Value(), charged.Value(), today), func(overdue []OverdueItem) result.Result[lateFeeCharging] {
+			fees := NewChargedLateFees(overdue, charged.Value())
+			if len(fees) == 0 {
+				return result.Ok(lateFeeCharging{overdue: overdue, charged: fees})
+			}
+			return result.Map(this.repository.ChargeLateFees(fees), func(bool) lateFeeCharging {
+				return lateFeeCharging{overdue: overdue, charged: fees}
+			})
+		})
+	})
+}
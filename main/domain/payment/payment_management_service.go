@@ -1,30 +1,117 @@
 package payment
 
 import (
+	"time"
+
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
 )
 
 type PaymentManagementService struct {
-	repository PaymentRepository
+	repository   PaymentRepository
+	cashRegister CashRegisterRepository
+}
+
+func NewPaymentManagementService(repository PaymentRepository, cashRegister CashRegisterRepository) *PaymentManagementService {
+	return &PaymentManagementService{repository: repository, cashRegister: cashRegister}
+}
+
+func (this PaymentManagementService) CreatePaymentForMembershipPeriod(membershipPeriodId int64, transaction Transaction) result.Result[int64] {
+	validated := result.Bind(this.repository.GetMembershipPeriodLedger(membershipPeriodId), func(ledger Ledger) result.Result[Transaction] {
+		return this.addTo(ledger, transaction)
+	})
+	return result.Bind(validated, func(transaction Transaction) result.Result[int64] {
+		return this.repository.CreatePaymentForMembershipPeriod(membershipPeriodId, transaction)
+	})
+}
+
+func (this PaymentManagementService) CreatePaymentForRentedFacility(rentedFacilityId int64, transaction Transaction) result.Result[int64] {
+	validated := result.Bind(this.repository.GetRentedFacilityLedger(rentedFacilityId), func(ledger Ledger) result.Result[Transaction] {
+		return this.addTo(ledger, transaction)
+	})
+	return result.Bind(validated, func(transaction Transaction) result.Result[int64] {
+		return this.repository.CreatePaymentForRentedFacility(rentedFacilityId, transaction)
+	})
+}
+
+// UpdatePayment changes a transaction, keeping its type and date, the refunds of its ledger must still be covered by the payments
+// and a transaction on a receipt or invoice cannot change
+func (this PaymentManagementService) UpdatePayment(transaction Transaction) result.Result[bool] {
+	ledger := result.Bind(this.ensureNotDocumented(transaction.Id), func(bool) result.Result[Ledger] {
+		return this.repository.GetPaymentLedger(transaction.Id)
+	})
+	validated := result.Bind(ledger, func(ledger Ledger) result.Result[Transaction] {
+		if existing := ledger.Find(transaction.Id); existing != nil {
+			transaction.Type = existing.Type
+			transaction.Date = existing.Date
+		}
+		return this.addTo(ledger, transaction)
+	})
+	return result.Bind(validated, func(transaction Transaction) result.Result[bool] {
+		return this.repository.UpdatePayment(transaction)
+	})
 }
 
-func NewPaymentManagementService(repository PaymentRepository) *PaymentManagementService {
-	return &PaymentManagementService{repository: repository}
+// DeletePayment removes a transaction, a payment cannot be removed while refunds depend on it or once it is on a receipt or invoice
+func (this PaymentManagementService) DeletePayment(paymentId domain.Id[Transaction]) result.Result[bool] {
+	ledger := result.Bind(this.ensureNotDocumented(paymentId), func(bool) result.Result[Ledger] {
+		return this.repository.GetPaymentLedger(paymentId)
+	})
+	validated := result.Bind(ledger, func(ledger Ledger) result.Result[Ledger] {
+		if existing := ledger.Find(paymentId); existing != nil {
+			if open := this.ensureDayOpen(existing.Date); !open.IsSuccess() {
+				return result.Err[Ledger](open.Error())
+			}
+		}
+		return ledger.Without(paymentId).Validate()
+	})
+	return result.Bind(validated, func(Ledger) result.Result[bool] {
+		return this.repository.DeletePayment(paymentId)
+	})
 }
 
-func (this PaymentManagementService) CreatePaymentForMembershipPeriod(membershipPeriodId int64, amount float64, currency string, paymentMethod string, transactionRef *string) result.Result[int64] {
-	return this.repository.CreatePaymentForMembershipPeriod(membershipPeriodId, amount, currency, paymentMethod, transactionRef)
+// SearchPayments lists the transactions matching the criteria with the totals by payment method of all the matches
+func (this PaymentManagementService) SearchPayments(criteria PaymentSearchCriteria) result.Result[PaymentPage] {
+	return result.Bind(criteria.Validate(), func(criteria PaymentSearchCriteria) result.Result[PaymentPage] {
+		criteria = criteria.Normalized()
+		return result.Bind(this.repository.SearchPayments(criteria), func(page PaymentPage) result.Result[PaymentPage] {
+			return result.Map(this.repository.GetTotalsByMethod(criteria), func(totals []MethodTotal) PaymentPage {
+				page.TotalsByMethod = totals
+				return page
+			})
+		})
+	})
 }
 
-func (this PaymentManagementService) CreatePaymentForRentedFacility(rentedFacilityId int64, amount float64, currency string, paymentMethod string, transactionRef *string) result.Result[int64] {
-	return this.repository.CreatePaymentForRentedFacility(rentedFacilityId, amount, currency, paymentMethod, transactionRef)
+// addTo validates the transaction and the ledger it ends up in, the cash register of its day must still be open
+func (this PaymentManagementService) addTo(ledger Ledger, transaction Transaction) result.Result[Transaction] {
+	return result.Bind(ValidateTransaction(transaction), func(transaction Transaction) result.Result[Transaction] {
+		return result.Bind(this.ensureDayOpen(transaction.Date), func(bool) result.Result[Transaction] {
+			return result.Map(ledger.With(transaction).Validate(), func(Ledger) Transaction { return transaction })
+		})
+	})
 }
 
-func (this PaymentManagementService) UpdatePayment(paymentId domain.Id[Payment], amount float64, currency string, paymentMethod string, transactionRef *string) result.Result[bool] {
-	return this.repository.UpdatePayment(paymentId, amount, currency, paymentMethod, transactionRef)
+// ensureDayOpen fails when the cash register of the day of a transaction, today when it has no date, is closed
+func (this PaymentManagementService) ensureDayOpen(date time.Time) result.Result[bool] {
+	if date.IsZero() {
+		date = time.Now()
+	}
+	return result.Bind(this.cashRegister.IsDayClosed(Day(date)), func(closed bool) result.Result[bool] {
+		if closed {
+			return result.Err[bool](dayClosedError(Day(date)))
+		}
+		return result.Ok(true)
+	})
 }
 
-func (this PaymentManagementService) DeletePayment(paymentId domain.Id[Payment]) result.Result[bool] {
-	return this.repository.DeletePayment(paymentId)
+// ensureNotDocumented fails when the payment appears on an issued receipt or invoice, the document would no longer match it
+func (this PaymentManagementService) ensureNotDocumented(paymentId domain.Id[Transaction]) result.Result[bool] {
+	return result.Bind(this.repository.IsPaymentDocumented(paymentId), func(documented bool) result.Result[bool] {
+		if documented {
+			return result.Err[bool](errors.PaymentError{Description: "the payment is on an issued receipt or invoice and cannot be changed"})
+		}
+		return result.Ok(true)
+	})
 }
@@ -6,8 +6,20 @@ import (
 )
 
 type PaymentRepository interface {
-	CreatePaymentForMembershipPeriod(membershipPeriodId int64, amount float64, currency string, paymentMethod string, transactionRef *string) result.Result[int64]
-	CreatePaymentForRentedFacility(rentedFacilityId int64, amount float64, currency string, paymentMethod string, transactionRef *string) result.Result[int64]
-	UpdatePayment(paymentId domain.Id[Payment], amount float64, currency string, paymentMethod string, transactionRef *string) result.Result[bool]
-	DeletePayment(paymentId domain.Id[Payment]) result.Result[bool]
+	// GetMembershipPeriodLedger returns the price of the period with its transactions, NotFoundError when it does not exist
+	GetMembershipPeriodLedger(membershipPeriodId int64) result.Result[Ledger]
+	// GetRentedFacilityLedger returns the price of the rental with its transactions, NotFoundError when it does not exist
+	GetRentedFacilityLedger(rentedFacilityId int64) result.Result[Ledger]
+	// GetPaymentLedger returns the ledger the payment belongs to, NotFoundError when it does not exist
+	GetPaymentLedger(paymentId domain.Id[Transaction]) result.Result[Ledger]
+	// IsPaymentDocumented tells whether the payment appears on an issued receipt or invoice
+	IsPaymentDocumented(paymentId domain.Id[Transaction]) result.Result[bool]
+	CreatePaymentForMembershipPeriod(membershipPeriodId int64, transaction Transaction) result.Result[int64]
+	CreatePaymentForRentedFacility(rentedFacilityId int64, transaction Transaction) result.Result[int64]
+	UpdatePayment(transaction Transaction) result.Result[bool]
+	DeletePayment(paymentId domain.Id[Transaction]) result.Result[bool]
+	// SearchPayments returns the page of transactions matching the criteria and the total number of matches
+	SearchPayments(criteria PaymentSearchCriteria) result.Result[PaymentPage]
+	// GetTotalsByMethod sums the transactions matching the criteria by payment method, ignoring the pagination
+	GetTotalsByMethod(criteria PaymentSearchCriteria) result.Result[[]MethodTotal]
 }
@@ -0,0 +1,114 @@
+package payment
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+const (
+	DefaultPaymentSearchLimit = 50
+	MaxPaymentSearchLimit     = 200
+)
+
+// PaymentTarget is what a transaction was made for
+type PaymentTarget string
+
+const (
+	MembershipTarget PaymentTarget = "MEMBERSHIP"
+	FacilityTarget   PaymentTarget = "FACILITY"
+)
+
+// PaymentSearchCriteria filters and paginates the list of payments and refunds
+// Nil filters are not applied
+type PaymentSearchCriteria struct {
+	SeasonId      *int64
+	PaidFrom      *time.Time // Day, included
+	PaidTo        *time.Time // Day, included
+	PaymentMethod string     // Matched ignoring case
+	MemberId      *int64
+	Target        *PaymentTarget
+	MinAmount     *money.Money
+	MaxAmount     *money.Money
+	Limit         int
+	Offset        int
+}
+
+// PaymentRecord is a transaction with the member and the membership period or rental it was made for
+type PaymentRecord struct {
+	Transaction        Transaction
+	Target             PaymentTarget
+	MembershipPeriodId *int64
+	RentedFacilityId   *int64
+	MemberId           int64
+	FirstName          string
+	LastName           string
+	SeasonId           int64
+	FacilityIdentifier string // Empty for memberships
+}
+
+// MethodTotal sums the transactions made with a payment method
+type MethodTotal struct {
+	PaymentMethod string
+	Count         int
+	Paid          money.Money
+	Refunded      money.Money
+}
+
+// PaymentPage is a page of the search results with the number and the totals of all the matches
+type PaymentPage struct {
+	Payments       []PaymentRecord
+	Total          int
+	TotalsByMethod []MethodTotal
+	Limit          int
+	Offset         int
+}
+
+// Net is what was received with the method once refunds are taken off
+func (t MethodTotal) Net() money.Money {
+	return money.New(t.Paid.MinorUnits()-t.Refunded.MinorUnits(), money.DefaultCurrency)
+}
+
+// Validate rejects empty ranges and negative amounts
+func (c PaymentSearchCriteria) Validate() result.Result[PaymentSearchCriteria] {
+	if c.PaidFrom != nil && c.PaidTo != nil && c.PaidTo.Before(*c.PaidFrom) {
+		return result.Err[PaymentSearchCriteria](errors.PaymentError{Description: "the end of the date range is before its start"})
+	}
+	if (c.MinAmount != nil && c.MinAmount.IsNegative()) || (c.MaxAmount != nil && c.MaxAmount.IsNegative()) {
+		return result.Err[PaymentSearchCriteria](errors.PaymentError{Description: "amounts cannot be negative"})
+	}
+	if c.MinAmount != nil && c.MaxAmount != nil && c.MaxAmount.LessThan(*c.MinAmount) {
+		return result.Err[PaymentSearchCriteria](errors.PaymentError{Description: "the maximum amount is below the minimum amount"})
+	}
+	if c.Target != nil && *c.Target != MembershipTarget && *c.Target != FacilityTarget {
+		return result.Err[PaymentSearchCriteria](errors.PaymentError{Description: "unknown payment target " + string(*c.Target)})
+	}
+	return result.Ok(c)
+}
+
+// Normalized fills in the defaults and keeps the pagination within bounds
+func (c PaymentSearchCriteria) Normalized() PaymentSearchCriteria {
+	c.PaymentMethod = strings.TrimSpace(c.PaymentMethod)
+	if c.Limit <= 0 {
+		c.Limit = DefaultPaymentSearchLimit
+	}
+	if c.Limit > MaxPaymentSearchLimit {
+		c.Limit = MaxPaymentSearchLimit
+	}
+	if c.Offset < 0 {
+		c.Offset = 0
+	}
+	return c
+}
+
+// PaidBefore is the exclusive upper bound of the payment date, the day after PaidTo
+func (c PaymentSearchCriteria) PaidBefore() *time.Time {
+	if c.PaidTo == nil {
+		return nil
+	}
+	day := time.Date(c.PaidTo.Year(), c.PaidTo.Month(), c.PaidTo.Day(), 0, 0, 0, 0, c.PaidTo.Location()).AddDate(0, 0, 1)
+	return &day
+}
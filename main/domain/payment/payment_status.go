@@ -3,6 +3,9 @@ package payment
 type PaymentStatus string
 
 const (
-	Paid   PaymentStatus = "PAID"
-	Unpaid PaymentStatus = "UNPAID"
+	Unpaid        PaymentStatus = "UNPAID"
+	PartiallyPaid PaymentStatus = "PARTIALLY_PAID"
+	Paid          PaymentStatus = "PAID"
+	Overpaid      PaymentStatus = "OVERPAID"
+	Refunded      PaymentStatus = "REFUNDED"
 )
@@ -0,0 +1,11 @@
+package reports
+
+import (
+	"bytes"
+)
+
+// CSVGenerator defines the interface for exporting reports as CSV spreadsheets
+type CSVGenerator interface {
+	// GenerateCashRegisterCSV exports the payments and refunds of the cash register, one per row
+	GenerateCashRegisterCSV(register CashRegister) (*bytes.Buffer, error)
+}
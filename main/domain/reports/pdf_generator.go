@@ -2,6 +2,9 @@ package reports
 
 import (
 	"bytes"
+	"strconv"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 )
 
 // PDFGenerator defines the interface for generating PDF documents
@@ -11,19 +14,35 @@ type PDFGenerator interface {
 
 	// GenerateMemberDetailPDF generates a PDF with detailed information about a member
 	GenerateMemberDetailPDF(member MemberDetail, facilities []FacilityRental, seasonCode string) (*bytes.Buffer, error)
+
+	// GenerateMembershipCardsPDF generates A4 sheets of membership cards, fronts and backs on alternate pages
+	// so that the sheets can be printed double-sided
+	GenerateMembershipCardsPDF(cards []MembershipCard, seasonCode string) (*bytes.Buffer, error)
+
+	// GenerateCashRegisterPDF generates the cash register (prima nota) of a period, one section per day
+	GenerateCashRegisterPDF(register CashRegister) (*bytes.Buffer, error)
+
+	// GenerateFiscalDocumentPDF generates a receipt or an invoice issued for payments
+	GenerateFiscalDocumentPDF(document FiscalDocument) (*bytes.Buffer, error)
+
+	// GenerateReminderLettersPDF generates payment reminder letters, one per page, ready to be printed and mailed
+	GenerateReminderLettersPDF(letters []ReminderLetter) (*bytes.Buffer, error)
 }
 
 // MemberSummary represents a member in the list report
 type MemberSummary struct {
-	ID                  int64
-	FirstName           string
-	LastName            string
-	Email               string
-	BirthDate           string
-	MembershipNumber    int64
-	MembershipStatus    string
-	MembershipPaid      bool
-	HasUnpaidFacilities bool
+	ID                   int64
+	FirstName            string
+	LastName             string
+	Email                string
+	BirthDate            string
+	MembershipNumber     int64
+	MembershipStatus     string
+	MembershipPaid       bool
+	HasUnpaidFacilities  bool
+	MembershipOverdue    bool // Still owed after the payment deadline
+	HasOverdueFacilities bool
+	LateFees             money.Money
 }
 
 // MemberDetail represents detailed member information
@@ -35,9 +54,19 @@ type MemberDetail struct {
 	BirthDate    string
 	PhoneNumbers []PhoneNumber
 	Addresses    []Address
+	IsMinor      bool
+	Guardians    []Guardian
 	Memberships  []Membership
 }
 
+// Guardian represents the legal guardian of a minor member
+type Guardian struct {
+	Name         string
+	Relationship string
+	Email        string
+	Phone        string
+}
+
 // PhoneNumber represents a phone number
 type PhoneNumber struct {
 	Number string
@@ -54,13 +83,15 @@ type Address struct {
 
 // Membership represents a membership period
 type Membership struct {
-	ID        int64
-	Number    int64
-	Status    string
-	ValidFrom string
-	ExpiresAt string
-	Price     float64
-	Paid      bool
+	ID            int64
+	Number        int64
+	Status        string
+	ValidFrom     string
+	ExpiresAt     string
+	Price         money.Money
+	Paid          bool        // Settled or exempt from payment
+	PaymentStatus string      // Status of the ledger of the period
+	Balance       money.Money // Amount still owed
 }
 
 // FacilityRental represents a rented facility
@@ -71,7 +102,143 @@ type FacilityRental struct {
 	FacilityTypeDescription string
 	RentedAt                string
 	ExpiresAt               string
-	Price                   float64
+	Price                   money.Money
 	Paid                    bool
+	PaymentStatus           string
+	Balance                 money.Money
 	BoatName                string
 }
+
+// TotalPrice sums the prices of the rentals, CurrencyMismatchError when they are not all in the same currency
+func TotalPrice(facilities []FacilityRental) (money.Money, error) {
+	prices := make([]money.Money, len(facilities))
+	for i, facility := range facilities {
+		prices[i] = facility.Price
+	}
+	total := money.Sum(money.DefaultCurrency, prices...)
+	if !total.IsSuccess() {
+		return money.Money{}, total.Error()
+	}
+	return total.Value(), nil
+}
+
+// MembershipCard represents a printable membership card
+type MembershipCard struct {
+	MemberID         int64
+	FirstName        string
+	LastName         string
+	BirthDate        string
+	MembershipNumber int64
+	CardCode         string
+	SeasonCode       string
+	SeasonName       string
+	ValidFrom        string
+	ValidUntil       string
+	Category         string
+}
+
+// CashRegister represents the cash register (prima nota) of a period
+type CashRegister struct {
+	From          string
+	To            string
+	Days          []CashRegisterDay
+	Totals        []CashRegisterTotal // Totals of the period by payment method
+	Discrepancies int                 // Number of discrepancies over all the days
+}
+
+// CashRegisterDay represents a day of the cash register
+type CashRegisterDay struct {
+	Date           string
+	Closed         bool
+	OpeningBalance money.Money
+	ExpectedCash   money.Money
+	CountedCash    *money.Money
+	BankTransfers  *money.Money
+	Notes          string
+	Entries        []CashRegisterEntry
+	Totals         []CashRegisterTotal
+	Discrepancies  []CashRegisterDiscrepancy
+}
+
+// CashRegisterEntry represents a payment or a refund of the day
+type CashRegisterEntry struct {
+	Time          string
+	Member        string
+	Description   string // What was paid, the membership or the facility
+	PaymentMethod string
+	Refund        bool
+	Amount        money.Money // Negative for refunds
+	Notes         string
+}
+
+// CashRegisterTotal represents the total of a payment method
+type CashRegisterTotal struct {
+	PaymentMethod string
+	Count         int
+	Paid          money.Money
+	Refunded      money.Money
+	Net           money.Money
+}
+
+// CashRegisterDiscrepancy represents a recorded value the transactions do not account for
+type CashRegisterDiscrepancy struct {
+	Type       string
+	Expected   money.Money
+	Actual     money.Money
+	Difference money.Money
+}
+
+// FiscalDocument represents a receipt or an invoice
+type FiscalDocument struct {
+	Title     string // Ricevuta or Fattura
+	Number    string
+	IssuedOn  string
+	Issuer    FiscalParty
+	Recipient FiscalParty
+	Lines     []FiscalDocumentLine
+	Total     money.Money
+	Notes     []string
+}
+
+// FiscalParty represents the issuer or the recipient of a fiscal document
+type FiscalParty struct {
+	Name      string
+	TaxCode   string
+	VATNumber string
+	Address   []string // Lines of the address, empty when unknown
+	Email     string
+}
+
+// FiscalDocumentLine represents a payment on a fiscal document
+type FiscalDocumentLine struct {
+	Description   string
+	PaidOn        string
+	PaymentMethod string
+	Amount        money.Money
+}
+
+// ReminderLetter represents a payment reminder sent to a member
+type ReminderLetter struct {
+	Title      string // Primo sollecito, Secondo sollecito or Diffida
+	Place      string
+	Date       string
+	Sender     FiscalParty
+	Recipient  FiscalParty
+	Subject    string
+	Paragraphs []string // Before the items still to be paid
+	Closing    []string // After them
+	Lines      []ReminderLetterLine
+	Total      money.Money
+}
+
+// ReminderLetterLine represents a membership or a rental still to be paid
+type ReminderLetterLine struct {
+	Description string
+	Due         money.Money
+	Balance     money.Money
+}
+
+// QRCodeContent is the content of the QR code printed on the back of the card, the member ID
+func (c MembershipCard) QRCodeContent() string {
+	return strconv.FormatInt(c.MemberID, 10)
+}
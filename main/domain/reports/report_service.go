@@ -7,12 +7,14 @@ import (
 // ReportService orchestrates report generation
 type ReportService struct {
 	pdfGenerator PDFGenerator
+	csvGenerator CSVGenerator
 }
 
 // NewReportService creates a new report service
-func NewReportService(pdfGenerator PDFGenerator) *ReportService {
+func NewReportService(pdfGenerator PDFGenerator, csvGenerator CSVGenerator) *ReportService {
 	return &ReportService{
 		pdfGenerator: pdfGenerator,
+		csvGenerator: csvGenerator,
 	}
 }
 
@@ -25,3 +27,28 @@ func (s *ReportService) GenerateMemberListReport(members []MemberSummary, season
 func (s *ReportService) GenerateMemberDetailReport(member MemberDetail, facilities []FacilityRental, seasonCode string) (*bytes.Buffer, error) {
 	return s.pdfGenerator.GenerateMemberDetailPDF(member, facilities, seasonCode)
 }
+
+// GenerateMembershipCardsReport generates a PDF with the membership cards, ready to be printed and cut
+func (s *ReportService) GenerateMembershipCardsReport(cards []MembershipCard, seasonCode string) (*bytes.Buffer, error) {
+	return s.pdfGenerator.GenerateMembershipCardsPDF(cards, seasonCode)
+}
+
+// GenerateCashRegisterReport generates a PDF with the cash register of a period
+func (s *ReportService) GenerateCashRegisterReport(register CashRegister) (*bytes.Buffer, error) {
+	return s.pdfGenerator.GenerateCashRegisterPDF(register)
+}
+
+// GenerateCashRegisterExport exports the cash register of a period as CSV
+func (s *ReportService) GenerateCashRegisterExport(register CashRegister) (*bytes.Buffer, error) {
+	return s.csvGenerator.GenerateCashRegisterCSV(register)
+}
+
+// GenerateFiscalDocumentReport generates the PDF of a receipt or an invoice
+func (s *ReportService) GenerateFiscalDocumentReport(document FiscalDocument) (*bytes.Buffer, error) {
+	return s.pdfGenerator.GenerateFiscalDocumentPDF(document)
+}
+
+// GenerateReminderLettersReport generates the PDF of payment reminder letters, one per page
+func (s *ReportService) GenerateReminderLettersReport(letters []ReminderLetter) (*bytes.Buffer, error) {
+	return s.pdfGenerator.GenerateReminderLettersPDF(letters)
+}
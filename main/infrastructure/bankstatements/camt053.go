@@ -0,0 +1,225 @@
+package bankstatements
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+)
+
+// CAMT.053 elements are matched by local name, so that every version of the schema is read the same way
+
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	IBAN    string      `xml:"Acct>Id>IBAN"`
+	Entries []camtEntry `xml:"Ntry"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// camtStatus is a plain code up to version 2 of the schema, a Cd element later
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+type camtEntry struct {
+	Amount         camtAmount               `xml:"Amt"`
+	CreditDebit    string                   `xml:"CdtDbtInd"`
+	Status         camtStatus               `xml:"Sts"`
+	BookingDate    camtDate                 `xml:"BookgDt"`
+	ValueDate      camtDate                 `xml:"ValDt"`
+	Reference      string                   `xml:"AcctSvcrRef"`
+	AdditionalInfo string                   `xml:"AddtlNtryInf"`
+	Details        []camtTransactionDetails `xml:"NtryDtls>TxDtls"`
+}
+
+type camtTransactionDetails struct {
+	Reference         string      `xml:"Refs>AcctSvcrRef"`
+	EndToEndId        string      `xml:"Refs>EndToEndId"`
+	Amount            *camtAmount `xml:"Amt"`
+	TransactionAmount *camtAmount `xml:"AmtDtls>TxAmt>Amt"`
+	DebtorName        string      `xml:"RltdPties>Dbtr>Nm"`
+	DebtorPartyName   string      `xml:"RltdPties>Dbtr>Pty>Nm"`
+	DebtorIBAN        string      `xml:"RltdPties>DbtrAcct>Id>IBAN"`
+	CreditorName      string      `xml:"RltdPties>Cdtr>Nm"`
+	CreditorPartyName string      `xml:"RltdPties>Cdtr>Pty>Nm"`
+	CreditorIBAN      string      `xml:"RltdPties>CdtrAcct>Id>IBAN"`
+	Unstructured      []string    `xml:"RmtInf>Ustrd"`
+	CreditorReference []string    `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+	AdditionalInfo    string      `xml:"AddtlTxInf"`
+}
+
+func parseCAMT053(content []byte) (payment.BankStatement, error) {
+	var document camtDocument
+	if err := xml.Unmarshal(content, &document); err != nil {
+		return payment.BankStatement{}, fmt.Errorf("failed to read CAMT.053: %w", err)
+	}
+	if len(document.Statements) == 0 {
+		return payment.BankStatement{}, fmt.Errorf("no statement found, expected a CAMT.053 document")
+	}
+
+	statement := payment.BankStatement{AccountIBAN: document.Statements[0].IBAN, Transactions: []payment.BankTransaction{}}
+	for _, camtStatement := range document.Statements {
+		for _, entry := range camtStatement.Entries {
+			if !entry.isBooked() {
+				continue
+			}
+			transactions, err := entry.transactions()
+			if err != nil {
+				return payment.BankStatement{}, err
+			}
+			statement.Transactions = append(statement.Transactions, transactions...)
+		}
+	}
+	return statement, nil
+}
+
+// isBooked skips pending and informational entries, which may still change
+func (e camtEntry) isBooked() bool {
+	status := strings.TrimSpace(e.Status.Code)
+	if status == "" {
+		status = strings.TrimSpace(e.Status.Text)
+	}
+	return status == "" || status == "BOOK"
+}
+
+// transactions splits batch entries into their transactions when each one has its own amount
+func (e camtEntry) transactions() ([]payment.BankTransaction, error) {
+	bookingDate, err := e.BookingDate.parse()
+	if err != nil {
+		bookingDate, err = e.ValueDate.parse()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("entry %s: missing booking date", e.Reference)
+	}
+
+	if len(e.Details) <= 1 || !e.detailsHaveAmounts() {
+		details := camtTransactionDetails{}
+		if len(e.Details) > 0 {
+			details = e.Details[0]
+		}
+		transaction, err := e.transaction(details, e.Amount, bookingDate)
+		if err != nil {
+			return nil, err
+		}
+		if transaction.Reference == "" {
+			transaction.Reference = e.Reference
+		}
+		return []payment.BankTransaction{transaction}, nil
+	}
+
+	transactions := make([]payment.BankTransaction, len(e.Details))
+	for i, details := range e.Details {
+		transaction, err := e.transaction(details, *details.amount(), bookingDate)
+		if err != nil {
+			return nil, err
+		}
+		if transaction.Reference == "" && e.Reference != "" {
+			transaction.Reference = fmt.Sprintf("%s/%d", e.Reference, i+1)
+		}
+		transactions[i] = transaction
+	}
+	return transactions, nil
+}
+
+func (e camtEntry) detailsHaveAmounts() bool {
+	for _, details := range e.Details {
+		if details.amount() == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (e camtEntry) transaction(details camtTransactionDetails, amount camtAmount, bookingDate time.Time) (payment.BankTransaction, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(amount.Value), 64)
+	if err != nil {
+		return payment.BankTransaction{}, fmt.Errorf("entry %s: invalid amount %q", e.Reference, amount.Value)
+	}
+
+	currency := money.Currency(strings.ToUpper(strings.TrimSpace(amount.Currency)))
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	transaction := payment.BankTransaction{
+		Reference:   details.reference(),
+		BookingDate: bookingDate,
+		Amount:      money.FromFloat(value, currency),
+	}
+	// The counterparty is who sent the money for credits, who received it for debits
+	if strings.TrimSpace(e.CreditDebit) == "DBIT" {
+		transaction.Amount = transaction.Amount.Negate()
+		transaction.CounterpartyName = firstNonEmpty(details.CreditorName, details.CreditorPartyName)
+		transaction.CounterpartyIBAN = details.CreditorIBAN
+	} else {
+		transaction.CounterpartyName = firstNonEmpty(details.DebtorName, details.DebtorPartyName)
+		transaction.CounterpartyIBAN = details.DebtorIBAN
+	}
+
+	remittance := append(append([]string{}, details.Unstructured...), details.CreditorReference...)
+	if len(remittance) == 0 {
+		remittance = []string{firstNonEmpty(details.AdditionalInfo, e.AdditionalInfo)}
+	}
+	transaction.RemittanceInfo = strings.TrimSpace(strings.Join(remittance, " "))
+	return transaction, nil
+}
+
+func (d camtTransactionDetails) amount() *camtAmount {
+	if d.Amount != nil && strings.TrimSpace(d.Amount.Value) != "" {
+		return d.Amount
+	}
+	if d.TransactionAmount != nil && strings.TrimSpace(d.TransactionAmount.Value) != "" {
+		return d.TransactionAmount
+	}
+	return nil
+}
+
+// reference prefers the reference of the bank, the end to end id is often NOTPROVIDED
+func (d camtTransactionDetails) reference() string {
+	if reference := strings.TrimSpace(d.Reference); reference != "" {
+		return reference
+	}
+	if endToEndId := strings.TrimSpace(d.EndToEndId); endToEndId != "" && endToEndId != "NOTPROVIDED" {
+		return endToEndId
+	}
+	return ""
+}
+
+func (d camtDate) parse() (time.Time, error) {
+	if date := strings.TrimSpace(d.Date); date != "" {
+		return time.Parse("2006-01-02", date)
+	}
+	if dateTime := strings.TrimSpace(d.DateTime); dateTime != "" {
+		parsed, err := time.Parse(time.RFC3339, dateTime)
+		if err != nil {
+			parsed, err = time.Parse("2006-01-02T15:04:05", dateTime)
+		}
+		return payment.Day(parsed), err
+	}
+	return time.Time{}, fmt.Errorf("missing date")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
@@ -0,0 +1,194 @@
+package bankstatements
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+)
+
+// maxPreambleRows is how many rows can precede the header, banks often export the account details first
+const maxPreambleRows = 20
+
+// Column names used by the banks, compared lower-cased, Italian and English
+var (
+	dateColumns         = []string{"data contabile", "data operazione", "data registrazione", "data", "booking date", "date"}
+	amountColumns       = []string{"importo", "importo eur", "importo (eur)", "amount"}
+	creditColumns       = []string{"avere", "accrediti", "entrate", "credit"}
+	debitColumns        = []string{"dare", "addebiti", "uscite", "debit"}
+	currencyColumns     = []string{"divisa", "currency"}
+	descriptionColumns  = []string{"descrizione", "descrizione operazione", "causale", "dettagli", "description", "remittance information"}
+	counterpartyColumns = []string{"ordinante", "controparte", "beneficiario", "nome", "counterparty", "name"}
+	ibanColumns         = []string{"iban", "iban ordinante", "iban controparte", "counterparty iban"}
+	referenceColumns    = []string{"riferimento", "id operazione", "cro", "trn", "reference"}
+)
+
+var dateLayouts = []string{"02/01/2006", "2006-01-02", "02-01-2006", "02.01.2006", "02/01/06"}
+
+// csvColumns are the positions of the columns in a row, -1 when missing
+type csvColumns struct {
+	date, amount, credit, debit, currency, description, counterparty, iban, reference int
+}
+
+func parseCSV(content []byte) (payment.BankStatement, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = detectDelimiter(content)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return payment.BankStatement{}, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	headerRow, columns, ok := findHeader(rows)
+	if !ok {
+		return payment.BankStatement{}, fmt.Errorf("no header with a date and an amount column found")
+	}
+
+	statement := payment.BankStatement{Transactions: []payment.BankTransaction{}}
+	for i, row := range rows[headerRow+1:] {
+		dateValue := cell(row, columns.date)
+		if dateValue == "" {
+			// Blank lines and totals at the bottom
+			continue
+		}
+		bookingDate, err := parseDate(dateValue)
+		if err != nil {
+			return payment.BankStatement{}, fmt.Errorf("row %d: %w", headerRow+i+2, err)
+		}
+		amount, err := rowAmount(row, columns)
+		if err != nil {
+			return payment.BankStatement{}, fmt.Errorf("row %d: %w", headerRow+i+2, err)
+		}
+
+		currency := money.Currency(strings.ToUpper(cell(row, columns.currency)))
+		if currency == "" {
+			currency = money.DefaultCurrency
+		}
+		statement.Transactions = append(statement.Transactions, payment.BankTransaction{
+			Reference:        cell(row, columns.reference),
+			BookingDate:      bookingDate,
+			Amount:           money.FromFloat(amount, currency),
+			CounterpartyName: cell(row, columns.counterparty),
+			CounterpartyIBAN: strings.ReplaceAll(strings.ToUpper(cell(row, columns.iban)), " ", ""),
+			RemittanceInfo:   cell(row, columns.description),
+		})
+	}
+	return statement, nil
+}
+
+// detectDelimiter picks the most frequent delimiter of the first line, Italian banks mostly use semicolons
+func detectDelimiter(content []byte) rune {
+	firstLine, _, _ := bytes.Cut(content, []byte("\n"))
+	delimiter, count := ';', bytes.Count(firstLine, []byte(";"))
+	for _, candidate := range []rune{',', '\t'} {
+		if n := bytes.Count(firstLine, []byte(string(candidate))); n > count {
+			delimiter, count = candidate, n
+		}
+	}
+	return delimiter
+}
+
+func findHeader(rows [][]string) (int, csvColumns, bool) {
+	for i, row := range rows {
+		if i >= maxPreambleRows {
+			break
+		}
+		columns := csvColumns{
+			date:         columnIndex(row, dateColumns),
+			amount:       columnIndex(row, amountColumns),
+			credit:       columnIndex(row, creditColumns),
+			debit:        columnIndex(row, debitColumns),
+			currency:     columnIndex(row, currencyColumns),
+			description:  columnIndex(row, descriptionColumns),
+			counterparty: columnIndex(row, counterpartyColumns),
+			iban:         columnIndex(row, ibanColumns),
+			reference:    columnIndex(row, referenceColumns),
+		}
+		if columns.date >= 0 && (columns.amount >= 0 || columns.credit >= 0) {
+			return i, columns, true
+		}
+	}
+	return 0, csvColumns{}, false
+}
+
+// columnIndex returns the position of the first column named as one of the names, in their order of preference
+func columnIndex(header []string, names []string) int {
+	for _, name := range names {
+		for i, column := range header {
+			if strings.ToLower(strings.TrimSpace(column)) == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func cell(row []string, index int) string {
+	if index < 0 || index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+// rowAmount reads the signed amount, from a single column or from separate credit and debit columns
+func rowAmount(row []string, columns csvColumns) (float64, error) {
+	if value := cell(row, columns.amount); value != "" {
+		return parseAmount(value)
+	}
+	if value := cell(row, columns.credit); value != "" {
+		amount, err := parseAmount(value)
+		if amount < 0 {
+			amount = -amount
+		}
+		return amount, err
+	}
+	if value := cell(row, columns.debit); value != "" {
+		amount, err := parseAmount(value)
+		if amount > 0 {
+			amount = -amount
+		}
+		return amount, err
+	}
+	return 0, fmt.Errorf("missing amount")
+}
+
+// parseAmount reads amounts written either as 1.234,56 or as 1,234.56
+// A lone separator followed by three digits, as in 1.234, separates the thousands, bank amounts having two decimals at most
+func parseAmount(value string) (float64, error) {
+	cleaned := strings.NewReplacer("€", "", "EUR", "", " ", "", "\u00a0", "", "'", "").Replace(value)
+	lastComma, lastDot := strings.LastIndex(cleaned, ","), strings.LastIndex(cleaned, ".")
+	lastSeparator := max(lastComma, lastDot)
+	switch {
+	case lastComma > lastDot && lastDot >= 0:
+		cleaned = strings.ReplaceAll(cleaned, ".", "")
+		cleaned = strings.Replace(cleaned, ",", ".", 1)
+	case lastDot > lastComma && lastComma >= 0:
+		cleaned = strings.ReplaceAll(cleaned, ",", "")
+	case strings.Count(cleaned, ",") > 1 || strings.Count(cleaned, ".") > 1 || (lastSeparator >= 0 && len(cleaned)-lastSeparator-1 == 3):
+		cleaned = strings.NewReplacer(",", "", ".", "").Replace(cleaned)
+	default:
+		cleaned = strings.Replace(cleaned, ",", ".", 1)
+	}
+	amount, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
@@ -0,0 +1,28 @@
+package bankstatements
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+)
+
+// StatementParser implements BankStatementParser for CSV exports and CAMT.053 XML statements
+type StatementParser struct{}
+
+// NewStatementParser creates a new bank statement parser
+func NewStatementParser() *StatementParser {
+	return &StatementParser{}
+}
+
+func (p *StatementParser) Parse(format payment.BankStatementFormat, content []byte) (payment.BankStatement, error) {
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+	switch format {
+	case payment.CSVStatement:
+		return parseCSV(content)
+	case payment.CAMT053Statement:
+		return parseCAMT053(content)
+	default:
+		return payment.BankStatement{}, fmt.Errorf("unsupported format %s", format)
+	}
+}
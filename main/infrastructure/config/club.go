@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+)
+
+// DefaultVATExemptionNote is printed on the documents of a sports association to its members
+const DefaultVATExemptionNote = "Operazione fuori campo IVA ai sensi dell'art. 4, commi 4 e 6, D.P.R. 633/1972"
+
+// Defaults of the electronic invoices: ordinary tax regime, operations not subject to VAT
+const (
+	DefaultTaxRegime = "RF01"
+	DefaultVATNature = "N2.2"
+)
+
+// ClubConfig holds the data of the club printed on receipts and invoices
+type ClubConfig struct {
+	Name             string
+	TaxCode          string
+	VATNumber        string
+	Street           string
+	StreetNumber     string
+	ZipCode          string
+	City             string
+	Province         string
+	Country          string
+	Email            string
+	VATExemptionNote string
+	TaxRegime        string  // RegimeFiscale of the electronic invoices
+	VATNature        string  // Natura of the lines of the electronic invoices out of the scope of VAT
+	RentalVATRate    float64 // VAT rate of the rentals to members billed as a business, 0 when out of the scope of VAT
+	// DaysBetweenReminders is how long a member has to pay after a reminder letter before the next one
+	DaysBetweenReminders int
+}
+
+func NewClubConfig() *ClubConfig {
+	return &ClubConfig{
+		Name:                 getEnv("CLUB_NAME", "Circolo Nautico Cattolica"),
+		TaxCode:              getEnv("CLUB_TAX_CODE", ""),
+		VATNumber:            getEnv("CLUB_VAT_NUMBER", ""),
+		Street:               getEnv("CLUB_STREET", ""),
+		StreetNumber:         getEnv("CLUB_STREET_NUMBER", ""),
+		ZipCode:              getEnv("CLUB_ZIP_CODE", ""),
+		City:                 getEnv("CLUB_CITY", "Cattolica"),
+		Province:             getEnv("CLUB_PROVINCE", "RN"),
+		Country:              getEnv("CLUB_COUNTRY", "IT"),
+		Email:                getEnv("CLUB_EMAIL", ""),
+		VATExemptionNote:     getEnv("CLUB_VAT_EXEMPTION_NOTE", DefaultVATExemptionNote),
+		TaxRegime:            getEnv("CLUB_TAX_REGIME", DefaultTaxRegime),
+		VATNature:            getEnv("CLUB_VAT_NATURE", DefaultVATNature),
+		RentalVATRate:        getEnvFloat("CLUB_RENTAL_VAT_RATE", 0),
+		DaysBetweenReminders: getEnvInt("CLUB_DUNNING_INTERVAL_DAYS", club.DefaultDaysBetweenReminders),
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil && value >= 0 && value <= 100 {
+		return value
+	}
+	return defaultValue
+}
+
+// Issuer is the club as the issuer of fiscal documents
+func (c *ClubConfig) Issuer() payment.Issuer {
+	return payment.Issuer{
+		Name:      c.Name,
+		TaxCode:   c.TaxCode,
+		VATNumber: c.VATNumber,
+		Address: payment.FiscalAddress{
+			Street:       c.Street,
+			StreetNumber: c.StreetNumber,
+			ZipCode:      c.ZipCode,
+			City:         c.City,
+			Province:     c.Province,
+			Country:      c.Country,
+		},
+		Email:            c.Email,
+		VATExemptionNote: c.VATExemptionNote,
+		RentalVATRate:    c.RentalVATRate,
+	}
+}
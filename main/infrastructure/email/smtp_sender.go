@@ -0,0 +1,151 @@
+package email
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/notification"
+)
+
+// SMTPConfig is the mail server the emails are sent through
+// The defaults point to a local stand-in such as Mailpit or MailHog, which accepts everything on port 1025
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string // No authentication when empty
+	Password string
+	From     string
+	FromName string
+	Timeout  time.Duration
+}
+
+func NewSMTPConfig() *SMTPConfig {
+	return &SMTPConfig{
+		Host:     getEnv("SMTP_HOST", "localhost"),
+		Port:     getEnv("SMTP_PORT", "1025"),
+		Username: getEnv("SMTP_USERNAME", ""),
+		Password: getEnv("SMTP_PASSWORD", ""),
+		From:     getEnv("SMTP_FROM", getEnv("CLUB_EMAIL", "")),
+		FromName: getEnv("SMTP_FROM_NAME", getEnv("CLUB_NAME", "Circolo Nautico Cattolica")),
+		Timeout:  30 * time.Second,
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// SMTPSender sends the emails one connection each, upgrading it with STARTTLS when the server offers it
+// Port 465 uses implicit TLS instead
+type SMTPSender struct {
+	config SMTPConfig
+}
+
+func NewSMTPSender(config SMTPConfig) *SMTPSender {
+	return &SMTPSender{config: config}
+}
+
+func (s *SMTPSender) Send(message notification.EmailMessage) error {
+	if s.config.From == "" {
+		return fmt.Errorf("no sender address, set SMTP_FROM")
+	}
+	if _, err := mail.ParseAddress(message.To.Email); err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", message.To.Email, err)
+	}
+
+	content, err := s.compose(message, time.Now())
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(s.config.Host, s.config.Port), s.config.Timeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the mail server: %w", err)
+	}
+	if err := conn.SetDeadline(time.Now().Add(s.config.Timeout)); err != nil {
+		conn.Close()
+		return err
+	}
+	if s.config.Port == "465" {
+		conn = tls.Client(conn, &tls.Config{ServerName: s.config.Host})
+	}
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to open the SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && s.config.Port != "465" {
+		if err := client.StartTLS(&tls.Config{ServerName: s.config.Host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if s.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.config.From); err != nil {
+		return fmt.Errorf("sender rejected: %w", err)
+	}
+	if err := client.Rcpt(message.To.Email); err != nil {
+		return fmt.Errorf("recipient rejected: %w", err)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send the message: %w", err)
+	}
+	if _, err := writer.Write(content); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to send the message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("message rejected: %w", err)
+	}
+	return client.Quit()
+}
+
+// compose writes the message as a plain text UTF-8 email, quoted-printable so that accented letters survive any server
+func (s *SMTPSender) compose(message notification.EmailMessage, now time.Time) ([]byte, error) {
+	from := mail.Address{Name: s.config.FromName, Address: s.config.From}
+	to := mail.Address{Name: message.To.Name, Address: message.To.Email}
+
+	var buffer bytes.Buffer
+	headers := [][2]string{
+		{"From", from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", message.Subject)},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=UTF-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, header := range headers {
+		buffer.WriteString(header[0] + ": " + header[1] + "\r\n")
+	}
+	buffer.WriteString("\r\n")
+
+	// Line breaks of the body are written as CRLF by the encoder
+	body := quotedprintable.NewWriter(&buffer)
+	if _, err := body.Write([]byte(message.Body)); err != nil {
+		return nil, fmt.Errorf("failed to encode the message: %w", err)
+	}
+	if err := body.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode the message: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
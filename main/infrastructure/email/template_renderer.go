@@ -0,0 +1,103 @@
+package email
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/notification"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+)
+
+//go:embed templates/signature.tmpl
+var signatureTemplate string
+
+//go:embed templates/membership_created.tmpl
+var membershipCreatedTemplate string
+
+//go:embed templates/facility_rented.tmpl
+var facilityRentedTemplate string
+
+//go:embed templates/payment_received.tmpl
+var paymentReceivedTemplate string
+
+//go:embed templates/waiting_list_position_changed.tmpl
+var waitingListPositionChangedTemplate string
+
+// Each template defines a "subject" and a "body", the body can end with the shared "signature"
+var templates = map[notification.NotificationKind]string{
+	notification.MembershipCreated:          membershipCreatedTemplate,
+	notification.FacilityRented:             facilityRentedTemplate,
+	notification.PaymentReceived:            paymentReceivedTemplate,
+	notification.WaitingListPositionChanged: waitingListPositionChangedTemplate,
+}
+
+// TemplateData is what the templates are executed with
+type TemplateData struct {
+	Club      string
+	Recipient notification.Recipient
+	Data      any
+}
+
+// TemplateRenderer writes the emails in Italian from the embedded text templates
+type TemplateRenderer struct {
+	clubName string
+}
+
+func NewTemplateRenderer(clubName string) *TemplateRenderer {
+	return &TemplateRenderer{clubName: clubName}
+}
+
+func (r *TemplateRenderer) Render(n notification.Notification) (notification.EmailMessage, error) {
+	source, ok := templates[n.Kind]
+	if !ok {
+		return notification.EmailMessage{}, fmt.Errorf("no template for %s", n.Kind)
+	}
+
+	tmpl, err := template.New(string(n.Kind)).Funcs(template.FuncMap{
+		"amount": formatAmount,
+		"date":   formatDate,
+	}).Parse(signatureTemplate)
+	if err == nil {
+		tmpl, err = tmpl.Parse(source)
+	}
+	if err != nil {
+		return notification.EmailMessage{}, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	data := TemplateData{Club: r.clubName, Recipient: n.Recipient, Data: n.Data}
+	var subject, body strings.Builder
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return notification.EmailMessage{}, fmt.Errorf("failed to execute template: %w", err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return notification.EmailMessage{}, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return notification.EmailMessage{
+		To:      n.Recipient,
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Body:    strings.TrimSpace(body.String()) + "\n",
+	}, nil
+}
+
+// formatAmount writes an amount the Italian way, 1.234,56
+func formatAmount(amount money.Money) string {
+	units, decimals, _ := strings.Cut(strings.TrimPrefix(amount.Decimal(), "-"), ".")
+	for i := len(units) - 3; i > 0; i -= 3 {
+		units = units[:i] + "." + units[i:]
+	}
+	if amount.IsNegative() {
+		units = "-" + units
+	}
+	if decimals == "" {
+		return units
+	}
+	return units + "," + decimals
+}
+
+func formatDate(date time.Time) string {
+	return date.Format("02/01/2006")
+}
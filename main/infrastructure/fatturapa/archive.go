@@ -0,0 +1,28 @@
+package fatturapa
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+)
+
+// Archive zips the files of the invoices, as the SdI and most accounting software accept them
+func Archive(invoices []payment.ElectronicInvoice) (*bytes.Buffer, error) {
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+	for _, invoice := range invoices {
+		file, err := writer.Create(invoice.FileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s to the archive: %w", invoice.FileName, err)
+		}
+		if _, err := file.Write(invoice.Content); err != nil {
+			return nil, fmt.Errorf("failed to add %s to the archive: %w", invoice.FileName, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close the archive: %w", err)
+	}
+	return buf, nil
+}
@@ -0,0 +1,290 @@
+package fatturapa
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+)
+
+const (
+	ordinaryInvoice = "TD01"
+	// defaultRecipientCode lets the SdI deliver the invoice to the PEC or the tax drawer of the recipient
+	defaultRecipientCode = "0000000"
+	// foreignRecipientCode and foreignZipCode are used for members living abroad
+	foreignRecipientCode = "XXXXXXX"
+	foreignZipCode       = "00000"
+	foreignVATCode       = "99999999999"
+	italy                = "IT"
+	// immediateChargeability is the EsigibilitaIVA of the taxed summaries, VAT is due when the invoice is issued
+	immediateChargeability = "I"
+)
+
+// Encoder turns issued invoices into FatturaPA files, validating each one before returning it
+type Encoder struct {
+	taxRegime string
+	vatNature string
+	validator Validator
+}
+
+func NewEncoder(taxRegime string, vatNature string, validator Validator) *Encoder {
+	return &Encoder{
+		taxRegime: strings.ToUpper(strings.TrimSpace(taxRegime)),
+		vatNature: strings.ToUpper(strings.TrimSpace(vatNature)),
+		validator: validator,
+	}
+}
+
+func (e *Encoder) Encode(document payment.FiscalDocument) (payment.ElectronicInvoice, error) {
+	if document.Kind != payment.InvoiceDocument {
+		return payment.ElectronicInvoice{}, fmt.Errorf("only invoices can be encoded")
+	}
+	if len(document.Lines) == 0 {
+		return payment.ElectronicInvoice{}, fmt.Errorf("the invoice has no lines")
+	}
+	for _, documentLine := range document.Lines {
+		if documentLine.IsVATExempt() && !document.IsVATExempt() {
+			return payment.ElectronicInvoice{}, fmt.Errorf("the invoice has lines out of the scope of VAT, the club configuration has no VAT exemption note")
+		}
+	}
+
+	issuer := document.Issuer
+	vatNumber := strings.ToUpper(strings.TrimSpace(issuer.VATNumber))
+	if vatNumber == "" {
+		return payment.ElectronicInvoice{}, fmt.Errorf("the club configuration needs a VAT number to issue electronic invoices")
+	}
+	transmitterCode := strings.ToUpper(strings.TrimSpace(issuer.TaxCode))
+	if transmitterCode == "" {
+		transmitterCode = vatNumber
+	}
+	progressive := progressiveNumber(document.Id.Value)
+	recipient := document.Recipient
+	recipientCode := strings.ToUpper(strings.TrimSpace(recipient.RecipientCode))
+	if recipientCode == "" {
+		recipientCode = defaultRecipientCode
+	}
+
+	content := invoice{
+		XMLName:         xml.Name{Local: "p:" + rootElement},
+		Version:         TransmissionFormat,
+		NamespacePrefix: Namespace,
+		Header: header{
+			Transmission: transmissionData{
+				Transmitter:   fiscalId{Country: italy, Code: transmitterCode},
+				Progressive:   progressive,
+				Format:        TransmissionFormat,
+				RecipientCode: recipientCode,
+			},
+			Supplier: supplier{
+				Data: personalData{
+					VATId:     &fiscalId{Country: italy, Code: vatNumber},
+					TaxCode:   strings.ToUpper(strings.TrimSpace(issuer.TaxCode)),
+					Registry:  registry{Name: text(issuer.Name)},
+					TaxRegime: e.taxRegime,
+				},
+				Address: fiscalAddress(issuer.Address),
+			},
+			Customer: customer{
+				Data: personalData{
+					TaxCode: recipient.TaxCode,
+					Registry: registry{
+						FirstName: text(recipient.FirstName),
+						LastName:  text(recipient.LastName),
+					},
+				},
+				Address: fiscalAddress(recipient.Address),
+			},
+		},
+		Body: body{
+			General: generalData{Document: documentData{
+				Type:     ordinaryInvoice,
+				Currency: strings.ToUpper(document.Currency),
+				Date:     document.IssuedOn.Format("2006-01-02"),
+				Number:   document.Code(),
+			}},
+		},
+	}
+	if email := strings.TrimSpace(issuer.Email); email != "" {
+		content.Header.Supplier.Contacts = &contacts{Email: email}
+	}
+
+	// Businesses are identified by their VAT number and named after the company
+	if recipient.IsBusiness() {
+		content.Header.Customer.Data.VATId = vatId(recipient.VATNumber)
+		content.Header.Customer.Data.Registry = registry{Name: truncate(text(recipient.CompanyName), 80)}
+	}
+	// The PEC is only read by the SdI when there is no recipient code
+	if pec := strings.TrimSpace(recipient.PEC); pec != "" && recipientCode == defaultRecipientCode {
+		content.Header.Transmission.PEC = pec
+	}
+
+	// Members living abroad have no Italian tax code, the SdI wants a conventional VAT id instead
+	if country := content.Header.Customer.Address.Country; country != italy {
+		content.Header.Transmission.RecipientCode = foreignRecipientCode
+		content.Header.Transmission.PEC = ""
+		if content.Header.Customer.Data.VATId == nil {
+			content.Header.Customer.Data.VATId = &fiscalId{Country: country, Code: foreignVATCode}
+		}
+		content.Header.Customer.Data.TaxCode = ""
+		content.Header.Customer.Address.ZipCode = foreignZipCode
+		content.Header.Customer.Address.Province = ""
+	} else if content.Header.Customer.Data.TaxCode == "" && content.Header.Customer.Data.VATId == nil {
+		return payment.ElectronicInvoice{}, fmt.Errorf("the member has no tax code")
+	}
+
+	if document.RequiresStampDuty() {
+		content.Body.General.Document.StampDuty = &stampDuty{Virtual: "SI", Amount: payment.StampDutyAmount.Decimal()}
+	}
+
+	// Lines carry their amount net of VAT. The taxable amount of each rate is taken off the gross collected at the
+	// rate and its VAT is the rest of the gross, so that the invoice adds up to the total of the document
+	currency := money.Currency(content.Body.General.Document.Currency)
+	grossByRate := map[float64]int64{}
+	netByRate := map[float64]int64{}
+	lastLineByRate := map[float64]int{}
+	rates := []float64{}
+	for i, documentLine := range document.Lines {
+		rate := documentLine.VATRate
+		net := netOfVAT(documentLine.Amount, rate)
+		encodedLine := line{
+			Number:      i + 1,
+			Description: text(documentLine.Description),
+			UnitPrice:   net.Decimal(),
+			TotalPrice:  net.Decimal(),
+			VATRate:     formatRate(rate),
+		}
+		if rate == 0 {
+			encodedLine.Nature = e.vatNature
+		}
+		content.Body.Goods.Lines = append(content.Body.Goods.Lines, encodedLine)
+
+		if _, ok := grossByRate[rate]; !ok {
+			rates = append(rates, rate)
+		}
+		grossByRate[rate] += documentLine.Amount.MinorUnits()
+		netByRate[rate] += net.MinorUnits()
+		lastLineByRate[rate] = i
+	}
+
+	var total int64
+	for _, rate := range rates {
+		taxable := netOfVAT(money.New(grossByRate[rate], currency), rate)
+		tax := money.New(grossByRate[rate]-taxable.MinorUnits(), currency)
+
+		// The last line of the rate takes the rounding of the lines, so that they add up to the summary
+		if rounding := taxable.MinorUnits() - netByRate[rate]; rounding != 0 {
+			last := &content.Body.Goods.Lines[lastLineByRate[rate]]
+			net := netOfVAT(document.Lines[lastLineByRate[rate]].Amount, rate).MinorUnits() + rounding
+			last.UnitPrice = money.New(net, currency).Decimal()
+			last.TotalPrice = last.UnitPrice
+		}
+
+		encodedSummary := summary{
+			VATRate:       formatRate(rate),
+			TaxableAmount: taxable.Decimal(),
+			Tax:           tax.Decimal(),
+		}
+		if rate == 0 {
+			encodedSummary.Nature = e.vatNature
+			encodedSummary.LegalReference = truncate(text(issuer.VATExemptionNote), 100)
+		} else {
+			encodedSummary.Chargeability = immediateChargeability
+		}
+		content.Body.Goods.Summaries = append(content.Body.Goods.Summaries, encodedSummary)
+		total += grossByRate[rate]
+	}
+	content.Body.General.Document.Total = money.New(total, currency).Decimal()
+
+	encoded, err := xml.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return payment.ElectronicInvoice{}, fmt.Errorf("failed to encode invoice: %w", err)
+	}
+	encoded = append([]byte(xml.Header), encoded...)
+	if err := e.validator.Validate(encoded); err != nil {
+		return payment.ElectronicInvoice{}, err
+	}
+
+	return payment.ElectronicInvoice{
+		FileName: italy + transmitterCode + "_" + progressive + ".xml",
+		Content:  encoded,
+	}, nil
+}
+
+// progressiveNumber makes the part of the file name unique to the invoice, five alphanumeric characters
+func progressiveNumber(id int64) string {
+	progressive := strings.ToUpper(strconv.FormatInt(id, 36))
+	if len(progressive) < 5 {
+		progressive = strings.Repeat("0", 5-len(progressive)) + progressive
+	}
+	return progressive
+}
+
+// vatId splits a VAT number into its country, Italy when it has no prefix, and its code
+func vatId(vatNumber string) *fiscalId {
+	vatNumber = strings.ToUpper(strings.TrimSpace(vatNumber))
+	if len(vatNumber) > 2 && vatNumber[0] >= 'A' && vatNumber[0] <= 'Z' && vatNumber[1] >= 'A' && vatNumber[1] <= 'Z' {
+		return &fiscalId{Country: vatNumber[:2], Code: vatNumber[2:]}
+	}
+	return &fiscalId{Country: italy, Code: vatNumber}
+}
+
+func fiscalAddress(a payment.FiscalAddress) address {
+	return address{
+		Street:       text(a.Street),
+		StreetNumber: text(a.StreetNumber),
+		ZipCode:      strings.TrimSpace(a.ZipCode),
+		City:         text(a.City),
+		Province:     strings.ToUpper(strings.TrimSpace(a.Province)),
+		Country:      countryCode(a.Country),
+	}
+}
+
+// countryCode turns the country of an address, often typed by hand, into its ISO 3166-1 alpha-2 code
+func countryCode(country string) string {
+	switch strings.ToUpper(strings.TrimSpace(country)) {
+	case "", "IT", "ITA", "ITALIA", "ITALY":
+		return italy
+	case "SM", "SAN MARINO":
+		return "SM"
+	case "DE", "GERMANIA", "GERMANY":
+		return "DE"
+	case "FR", "FRANCIA", "FRANCE":
+		return "FR"
+	case "CH", "SVIZZERA", "SWITZERLAND":
+		return "CH"
+	case "AT", "AUSTRIA":
+		return "AT"
+	}
+	return strings.ToUpper(strings.TrimSpace(country))
+}
+
+// typographic are the characters often pasted in names and notes that the schema, Latin-1 only, does not accept
+var typographic = strings.NewReplacer(
+	"‘", "'", "’", "'", "“", "\"", "”", "\"",
+	"–", "-", "—", "-", "…", "...", "€", "EUR",
+)
+
+func text(value string) string {
+	return strings.TrimSpace(typographic.Replace(value))
+}
+
+func truncate(value string, length int) string {
+	runes := []rune(strings.TrimSpace(value))
+	if len(runes) > length {
+		return strings.TrimSpace(string(runes[:length]))
+	}
+	return string(runes)
+}
+
+// netOfVAT takes the VAT out of an amount, rate being a percentage
+func netOfVAT(gross money.Money, rate float64) money.Money {
+	return gross.Multiply(100 / (100 + rate))
+}
+
+// formatRate writes a VAT rate with the two decimals the schema wants
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', 2, 64)
+}
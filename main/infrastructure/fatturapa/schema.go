@@ -0,0 +1,122 @@
+package fatturapa
+
+import "encoding/xml"
+
+// Elements of the FatturaPA schema, version 1.2, in the order the schema requires them
+// Only what the club needs is modelled: invoices to members and businesses, out of the scope of VAT or taxed
+
+const (
+	Namespace          = "http://ivaservizi.agenziaentrate.gov.it/docs/xsd/fatture/v1.2"
+	TransmissionFormat = "FPR12"
+	rootElement        = "FatturaElettronica"
+)
+
+type invoice struct {
+	XMLName         xml.Name // Set to the prefixed root element when encoding, any name is read back
+	Version         string   `xml:"versione,attr"`
+	NamespacePrefix string   `xml:"xmlns:p,attr,omitempty"`
+	Header          header   `xml:"FatturaElettronicaHeader"`
+	Body            body     `xml:"FatturaElettronicaBody"`
+}
+
+type header struct {
+	Transmission transmissionData `xml:"DatiTrasmissione"`
+	Supplier     supplier         `xml:"CedentePrestatore"`
+	Customer     customer         `xml:"CessionarioCommittente"`
+}
+
+type fiscalId struct {
+	Country string `xml:"IdPaese"`
+	Code    string `xml:"IdCodice"`
+}
+
+type transmissionData struct {
+	Transmitter   fiscalId `xml:"IdTrasmittente"`
+	Progressive   string   `xml:"ProgressivoInvio"`
+	Format        string   `xml:"FormatoTrasmissione"`
+	RecipientCode string   `xml:"CodiceDestinatario"`
+	PEC           string   `xml:"PECDestinatario,omitempty"`
+}
+
+type registry struct {
+	Name      string `xml:"Denominazione,omitempty"`
+	FirstName string `xml:"Nome,omitempty"`
+	LastName  string `xml:"Cognome,omitempty"`
+}
+
+type personalData struct {
+	VATId     *fiscalId `xml:"IdFiscaleIVA"`
+	TaxCode   string    `xml:"CodiceFiscale,omitempty"`
+	Registry  registry  `xml:"Anagrafica"`
+	TaxRegime string    `xml:"RegimeFiscale,omitempty"` // Supplier only
+}
+
+type address struct {
+	Street       string `xml:"Indirizzo"`
+	StreetNumber string `xml:"NumeroCivico,omitempty"`
+	ZipCode      string `xml:"CAP"`
+	City         string `xml:"Comune"`
+	Province     string `xml:"Provincia,omitempty"`
+	Country      string `xml:"Nazione"`
+}
+
+type contacts struct {
+	Email string `xml:"Email,omitempty"`
+}
+
+type supplier struct {
+	Data     personalData `xml:"DatiAnagrafici"`
+	Address  address      `xml:"Sede"`
+	Contacts *contacts    `xml:"Contatti"`
+}
+
+type customer struct {
+	Data    personalData `xml:"DatiAnagrafici"`
+	Address address      `xml:"Sede"`
+}
+
+type body struct {
+	General generalData      `xml:"DatiGenerali"`
+	Goods   goodsAndServices `xml:"DatiBeniServizi"`
+}
+
+type generalData struct {
+	Document documentData `xml:"DatiGeneraliDocumento"`
+}
+
+type documentData struct {
+	Type      string     `xml:"TipoDocumento"`
+	Currency  string     `xml:"Divisa"`
+	Date      string     `xml:"Data"`
+	Number    string     `xml:"Numero"`
+	StampDuty *stampDuty `xml:"DatiBollo"`
+	Total     string     `xml:"ImportoTotaleDocumento,omitempty"`
+}
+
+type stampDuty struct {
+	Virtual string `xml:"BolloVirtuale"`
+	Amount  string `xml:"ImportoBollo"`
+}
+
+type goodsAndServices struct {
+	Lines     []line    `xml:"DettaglioLinee"`
+	Summaries []summary `xml:"DatiRiepilogo"`
+}
+
+type line struct {
+	Number      int    `xml:"NumeroLinea"`
+	Description string `xml:"Descrizione"`
+	UnitPrice   string `xml:"PrezzoUnitario"`
+	TotalPrice  string `xml:"PrezzoTotale"`
+	VATRate     string `xml:"AliquotaIVA"`
+	Nature      string `xml:"Natura,omitempty"`
+}
+
+type summary struct {
+	VATRate        string `xml:"AliquotaIVA"`
+	Nature         string `xml:"Natura,omitempty"`
+	TaxableAmount  string `xml:"ImponibileImporto"`
+	Tax            string `xml:"Imposta"`
+	Chargeability  string `xml:"EsigibilitaIVA,omitempty"`
+	LegalReference string `xml:"RiferimentoNormativo,omitempty"`
+}
@@ -0,0 +1,226 @@
+package fatturapa
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// DefaultSchemaPath is the official FPR12 schema in the schemas folder of the repository, relative to the working
+// directory, which is the root of the repository when run from source and /app in the Docker image
+const DefaultSchemaPath = "schemas/fatturapa/Schema_del_file_xml_FatturaPA_v1.2.2.xsd"
+
+// Exit codes of xmllint for a file that does not match the schema and for one that is not well-formed, which
+// newer versions report as a file they could not read
+const (
+	xmllintInvalid    = 3
+	xmllintMalformed  = 1
+	xmllintUnreadable = 4
+)
+
+// Validator checks an encoded FatturaPA file before it is handed out
+type Validator interface {
+	Validate(content []byte) error
+}
+
+// SchemaValidator checks a FatturaPA file against the official FPR12 schema with xmllint, then against the checks of the SdI
+type SchemaValidator struct {
+	xmllintPath string
+	schemaPath  string
+}
+
+// NewSchemaValidator validates with the xmllint at XMLLINT_PATH, or the one on the PATH, against the schema at
+// FATTURAPA_SCHEMA_PATH, or the one in the schemas folder
+func NewSchemaValidator() *SchemaValidator {
+	xmllintPath := os.Getenv("XMLLINT_PATH")
+	if xmllintPath == "" {
+		xmllintPath = "xmllint"
+	}
+	schemaPath := os.Getenv("FATTURAPA_SCHEMA_PATH")
+	if schemaPath == "" {
+		schemaPath = DefaultSchemaPath
+	}
+	return NewSchemaValidatorAt(xmllintPath, schemaPath)
+}
+
+func NewSchemaValidatorAt(xmllintPath string, schemaPath string) *SchemaValidator {
+	return &SchemaValidator{xmllintPath: xmllintPath, schemaPath: schemaPath}
+}
+
+func (v *SchemaValidator) Validate(content []byte) error {
+	if !fileExists(v.schemaPath) {
+		return fmt.Errorf("FatturaPA schema not found at %s", v.schemaPath)
+	}
+
+	cmd := exec.Command(v.xmllintPath, "--noout", "--nonet", "--schema", v.schemaPath, "-")
+	// The schema imports the one of XML signatures from the W3C, the catalog next to it points to a local copy
+	if catalog := filepath.Join(filepath.Dir(v.schemaPath), "catalog.xml"); fileExists(catalog) {
+		cmd.Env = append(os.Environ(), "XML_CATALOG_FILES="+catalog)
+	}
+	cmd.Stdin = bytes.NewReader(content)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && slices.Contains([]int{xmllintInvalid, xmllintMalformed, xmllintUnreadable}, exitErr.ExitCode()) {
+			return fmt.Errorf("invalid FatturaPA: %s", xmllintProblems(stderr.String()))
+		}
+		return fmt.Errorf("xmllint execution failed: %w, stderr: %s", err, stderr.String())
+	}
+	return RulesValidator{}.Validate(content)
+}
+
+// xmllintProblems keeps the errors xmllint reports on the file, which it reads from the standard input as "-"
+func xmllintProblems(output string) string {
+	problems := []string{}
+	for _, l := range strings.Split(output, "\n") {
+		if strings.HasPrefix(l, "-:") {
+			problems = append(problems, strings.TrimSpace(strings.TrimPrefix(l, "-:")))
+		}
+	}
+	if len(problems) == 0 {
+		return strings.TrimSpace(output)
+	}
+	return strings.Join(problems, "; ")
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// RulesValidator applies the checks of the SdI on what the schema cannot express: the consistency of the codes and
+// of the amounts, which would get the file rejected
+type RulesValidator struct{}
+
+func (RulesValidator) Validate(content []byte) error {
+	var document invoice
+	if err := xml.Unmarshal(content, &document); err != nil {
+		return fmt.Errorf("invalid FatturaPA: %w", err)
+	}
+	v := &sdiValidator{}
+	v.document(document)
+	if len(v.problems) > 0 {
+		return fmt.Errorf("invalid FatturaPA: %s", strings.Join(v.problems, "; "))
+	}
+	return nil
+}
+
+// sdiValidator applies the checks the SdI runs on top of the schema, each named after its error code
+type sdiValidator struct {
+	problems []string
+}
+
+func (v *sdiValidator) fail(path string, format string, args ...any) {
+	v.problems = append(v.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *sdiValidator) document(document invoice) {
+	transmission := document.Header.Transmission
+	if transmission.Format != document.Version {
+		v.fail("DatiTrasmissione/FormatoTrasmissione", "%q must match the version of the document (00428)", transmission.Format)
+	}
+	if document.Version == TransmissionFormat && len(transmission.RecipientCode) != 7 {
+		v.fail("DatiTrasmissione/CodiceDestinatario", "%q must be 7 characters long for invoices between private parties (00427)", transmission.RecipientCode)
+	}
+	if transmission.PEC != "" && transmission.RecipientCode != defaultRecipientCode {
+		v.fail("DatiTrasmissione/PECDestinatario", "is only allowed when CodiceDestinatario is %s (00426)", defaultRecipientCode)
+	}
+
+	customer := document.Header.Customer.Data
+	if customer.VATId == nil && customer.TaxCode == "" {
+		v.fail("CessionarioCommittente/DatiAnagrafici", "IdFiscaleIVA or CodiceFiscale is required (00417)")
+	}
+
+	general := document.Body.General.Document
+	if !strings.ContainsAny(general.Number, "0123456789") {
+		v.fail("DatiGeneraliDocumento/Numero", "%q must contain at least a digit (00425)", general.Number)
+	}
+	v.goodsAndServices(general, document.Body.Goods)
+}
+
+func (v *sdiValidator) goodsAndServices(document documentData, goods goodsAndServices) {
+	// Taxable amounts by rate and nature, the summaries must match the lines
+	taxable := map[string]*big.Rat{}
+	for i, l := range goods.Lines {
+		path := fmt.Sprintf("DettaglioLinee[%d]", i+1)
+		if l.Number != i+1 {
+			v.fail(path+"/NumeroLinea", "%d must be %d, lines are numbered in sequence", l.Number, i+1)
+		}
+		v.nature(path, l.VATRate, l.Nature)
+		key := l.VATRate + "|" + l.Nature
+		if taxable[key] == nil {
+			taxable[key] = new(big.Rat)
+		}
+		taxable[key].Add(taxable[key], decimal(l.TotalPrice))
+	}
+
+	total := new(big.Rat)
+	for i, s := range goods.Summaries {
+		path := fmt.Sprintf("DatiRiepilogo[%d]", i+1)
+		v.nature(path, s.VATRate, s.Nature)
+		expected, ok := taxable[s.VATRate+"|"+s.Nature]
+		if !ok {
+			expected = new(big.Rat)
+		}
+		if !withinACent(decimal(s.TaxableAmount), expected) {
+			v.fail(path+"/ImponibileImporto", "%s does not match the lines, which add up to %s (00422)", s.TaxableAmount, expected.FloatString(2))
+		}
+		delete(taxable, s.VATRate+"|"+s.Nature)
+
+		tax := new(big.Rat).Mul(decimal(s.TaxableAmount), decimal(s.VATRate))
+		tax.Quo(tax, big.NewRat(100, 1))
+		if !withinACent(decimal(s.Tax), tax) {
+			v.fail(path+"/Imposta", "%s must be ImponibileImporto times AliquotaIVA, %s (00421)", s.Tax, tax.FloatString(2))
+		}
+		total.Add(total, decimal(s.TaxableAmount))
+		total.Add(total, decimal(s.Tax))
+	}
+	missing := []string{}
+	for key := range taxable {
+		missing = append(missing, key)
+	}
+	sort.Strings(missing)
+	for _, key := range missing {
+		rate, nature, _ := strings.Cut(key, "|")
+		v.fail("DatiRiepilogo", "no summary for the lines at rate %s %s (00419)", rate, nature)
+	}
+
+	if document.Total != "" && decimal(document.Total).Cmp(total) != 0 {
+		v.fail("DatiGeneraliDocumento/ImportoTotaleDocumento", "%s does not match the summaries, which add up to %s", document.Total, total.FloatString(2))
+	}
+}
+
+// nature checks that operations without VAT tell why with their nature, and only them (00400, 00401)
+func (v *sdiValidator) nature(path string, rate string, nature string) {
+	zero := decimal(rate).Sign() == 0
+	switch {
+	case zero && nature == "":
+		v.fail(path+"/Natura", "is required when AliquotaIVA is 0.00")
+	case !zero && nature != "":
+		v.fail(path+"/Natura", "is only allowed when AliquotaIVA is 0.00")
+	}
+}
+
+// decimal reads an amount, the schema checks its format
+func decimal(value string) *big.Rat {
+	parsed, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return new(big.Rat)
+	}
+	return parsed
+}
+
+func withinACent(a *big.Rat, b *big.Rat) bool {
+	difference := new(big.Rat).Sub(a, b)
+	return difference.Abs(difference).Cmp(big.NewRat(1, 100)) <= 0
+}
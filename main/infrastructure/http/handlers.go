@@ -1,47 +1,128 @@
 package http
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/json"
+	"io"
+	"log"
+	"mime"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
 	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/notification"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/reports"
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/bankstatements"
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/config"
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/email"
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/fatturapa"
 	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/persistence"
 	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/presentation"
 	infrareports "github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/reports"
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/storage"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
 )
 
 var (
-	memberService      *membership.MemberManagementService
-	rentalService      *facilityrental.RentalManagementService
-	paymentService     *payment.PaymentManagementService
-	waitingListService *facilityrental.WaitingListManagementService
-	reportService      *reports.ReportService
-	facilityRepo       facilityrental.FacilityRepository
-	seasonRepo         club.SeasonRepository
+	memberService             *membership.MemberManagementService
+	rentalService             *facilityrental.RentalManagementService
+	paymentService            *payment.PaymentManagementService
+	cashRegisterService       *payment.CashRegisterService
+	bankReconciliationService *payment.BankReconciliationService
+	fiscalDocumentService     *payment.FiscalDocumentService
+	waitingListService        *facilityrental.WaitingListManagementService
+	reportService             *reports.ReportService
+	facilityRepo              facilityrental.FacilityRepository
+	seasonRepo                club.SeasonRepository
+	seasonService             *club.SeasonManagementService
+	seasonRolloverService     *club.SeasonRolloverService
+	expiryService             *club.MembershipExpiryService
+	memberExportService       *club.MemberDataExportService
+	householdService          *membership.HouseholdManagementService
+	householdOverviewService  *club.HouseholdOverviewService
+	membershipCardService     *membership.MembershipCardService
+	documentService           *membership.MemberDocumentService
+	consentService            *membership.ConsentManagementService
+	dunningService            *club.DunningService
+	paymentDeadlineService    *payment.PaymentDeadlineService
+	notificationService       *notification.NotificationService
+	memberNotificationService *club.MemberNotificationService
+	clubConfig                *config.ClubConfig
 )
 
-func InitializeServices(database *sql.DB) {
+// BackgroundServices are the services the scheduled jobs share with the handlers
+type BackgroundServices struct {
+	Expiry           *club.MembershipExpiryService
+	Notifications    *notification.NotificationService
+	PaymentDeadlines *payment.PaymentDeadlineService
+}
+
+func InitializeServices(database *sql.DB) BackgroundServices {
 	var memberRepository = persistence.NewSQLMemberRepository(database)
-	memberService = membership.NewMemberManagementService(memberRepository)
+	consentRepository := persistence.NewSQLConsentRepository(database)
+	householdRepository := persistence.NewSQLHouseholdRepository(database)
+	var documentStorage membership.DocumentStorage
+	if localStorage, err := storage.NewLocalDocumentStorage(storage.DocumentsPath()); err != nil {
+		log.Printf("⚠️ Document storage unavailable: %v", err)
+	} else {
+		documentStorage = localStorage
+	}
+	memberService = membership.NewMemberManagementService(memberRepository, persistence.NewSQLMembershipCategoryRepository(database), consentRepository, householdRepository, documentStorage)
+	consentService = membership.NewConsentManagementService(consentRepository)
 	facilityRepo = persistence.NewSQLFacilityRepository(database)
 	waitingListRepo := persistence.NewSQLWaitingListRepository(database)
 	rentalService = facilityrental.NewRentalManagementService(facilityRepo, waitingListRepo)
 	paymentRepo := persistence.NewSQLPaymentRepository(database)
-	paymentService = payment.NewPaymentManagementService(paymentRepo)
+	cashRegisterRepository := persistence.NewSQLCashRegisterRepository(database)
+	paymentService = payment.NewPaymentManagementService(paymentRepo, cashRegisterRepository)
+	cashRegisterService = payment.NewCashRegisterService(cashRegisterRepository)
+	bankReconciliationService = payment.NewBankReconciliationService(persistence.NewSQLBankStatementRepository(database), bankstatements.NewStatementParser(), paymentService)
+	clubConfig = config.NewClubConfig()
+	fiscalDocumentRepository := persistence.NewSQLFiscalDocumentRepository(database)
+	fiscalDocumentService = payment.NewFiscalDocumentService(fiscalDocumentRepository, clubConfig.Issuer(), fatturapa.NewEncoder(clubConfig.TaxRegime, clubConfig.VATNature, fatturapa.NewSchemaValidator()))
 	waitingListService = facilityrental.NewWaitingListManagementService(waitingListRepo)
 	seasonRepo = persistence.NewSQLSeasonRepository(database)
+	seasonService = club.NewSeasonManagementService(seasonRepo)
+	paymentDeadlineService = payment.NewPaymentDeadlineService(persistence.NewSQLPaymentDeadlineRepository(database))
+	dunningRepository := persistence.NewSQLDunningRepository(database)
+	dunningService = club.NewDunningService(dunningRepository, memberRepository, seasonRepo, clubConfig.DaysBetweenReminders)
+	outboxRepository := persistence.NewSQLOutboxRepository(database)
+	notificationService = notification.NewNotificationService(outboxRepository, email.NewTemplateRenderer(clubConfig.Name), email.NewSMTPSender(*email.NewSMTPConfig()))
+	memberNotificationService = club.NewMemberNotificationService(notificationService, memberRepository, seasonRepo, paymentRepo, fiscalDocumentRepository)
+	expiryService = club.NewMembershipExpiryService(persistence.NewSQLMembershipExpiryRepository(database))
+	seasonRolloverService = club.NewSeasonRolloverService(persistence.NewSQLSeasonRolloverRepository(database), seasonRepo, rentalService, householdRepository)
+	memberExportService = club.NewMemberDataExportService(
+		memberRepository,
+		seasonRepo,
+		facilityRepo,
+		waitingListRepo,
+		persistence.NewSQLMemberDocumentRepository(database),
+		consentRepository,
+		paymentRepo,
+		fiscalDocumentRepository,
+		dunningRepository,
+		outboxRepository,
+	)
+	householdService = membership.NewHouseholdManagementService(householdRepository, memberRepository)
+	householdOverviewService = club.NewHouseholdOverviewService(householdRepository, memberRepository, facilityRepo)
+	membershipCardService = membership.NewMembershipCardService(persistence.NewSQLMembershipCardRepository(database))
+	if documentStorage != nil {
+		documentService = membership.NewMemberDocumentService(persistence.NewSQLMemberDocumentRepository(database), documentStorage, memberRepository)
+	}
 	pdfGenerator := infrareports.NewWkhtmltopdfGenerator()
-	reportService = reports.NewReportService(pdfGenerator)
+	reportService = reports.NewReportService(pdfGenerator, infrareports.NewEncodingCSVGenerator())
+
+	return BackgroundServices{Expiry: expiryService, Notifications: notificationService, PaymentDeadlines: paymentDeadlineService}
 }
 
 func HealthHandler(w http.ResponseWriter, r *http.Request) {
@@ -56,25 +137,42 @@ func MembersHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		// Any search parameter switches to the paginated search
+		for _, parameter := range presentation.MemberSearchParameters {
+			if r.URL.Query().Has(parameter) {
+				handleMemberSearch(w, r)
+				return
+			}
+		}
+
 		var result result.Result[[]membership.Member]
+		var seasonId *int64
 		switch {
 		case r.URL.Query().Get("season") != "":
-			seasonId, err := strconv.ParseInt(r.URL.Query().Get("season"), 10, 64)
+			id, err := strconv.ParseInt(r.URL.Query().Get("season"), 10, 64)
 			if err != nil {
 				presentation.WriteError(w, http.StatusBadRequest, "invalid season ID format")
 				return
 			}
-			result = memberService.GetListOfMembersBySeason(seasonId)
+			seasonId = &id
+			result = memberService.GetListOfMembersBySeason(id)
 		default:
 			result = memberService.GetListOfAllMembers()
 		}
+		if result.IsSuccess() {
+			result = flagOverdueMembers(result.Value(), seasonId)
+		}
 
 		if !result.IsSuccess() {
 			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
 			return
 		}
 
-		members := presentation.ConvertMembersToPresentation(result.Value())
+		members, err := presentation.ConvertMembersToPresentation(result.Value())
+		if err != nil {
+			presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 		presentation.WriteJSON(w, http.StatusOK, members)
 
 	case http.MethodPost:
@@ -97,14 +195,29 @@ func MembersHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Create the member
-		result := memberService.CreateMember(data.User, data.CreateMembership, data.SeasonId, data.Price)
+		result := memberService.CreateMember(data.User, data.CreateMembership, data.SeasonId, data.Price, data.Category)
 		if !result.IsSuccess() {
-			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			switch result.Error().(type) {
+			case errors.GuardianError, errors.MembershipCategoryError, errors.ConsentError:
+				presentation.WriteError(w, http.StatusBadRequest, result.Error().Error())
+			case errors.NotFoundError:
+				presentation.WriteError(w, http.StatusNotFound, result.Error().Error())
+			default:
+				presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			}
 			return
 		}
 
+		if data.CreateMembership && data.SeasonId != nil && memberNotificationService != nil {
+			notifyMembers("membership created", memberNotificationService.MembershipCreated(result.Value(), *data.SeasonId))
+		}
+
 		// Convert to presentation and return
-		memberDetails := presentation.ConvertMemberDetailsToPresentation(result.Value())
+		memberDetails, err := presentation.ConvertMemberDetailsToPresentation(result.Value())
+		if err != nil {
+			presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 		presentation.WriteJSON(w, http.StatusCreated, memberDetails)
 
 	default:
@@ -112,19 +225,125 @@ func MembersHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleMemberSearch searches, filters, sorts and paginates the members
+func handleMemberSearch(w http.ResponseWriter, r *http.Request) {
+	criteria, err := presentation.ConvertMemberSearchQueryToDomain(r.URL.Query())
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result := memberService.SearchMembers(criteria)
+	if !result.IsSuccess() {
+		presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+		return
+	}
+
+	page := result.Value()
+	flagged := flagOverdueMembers(page.Members, criteria.SeasonId)
+	if !flagged.IsSuccess() {
+		presentation.WriteError(w, http.StatusInternalServerError, flagged.Error().Error())
+		return
+	}
+	page.Members = flagged.Value()
+
+	memberPage, err := presentation.ConvertMemberPageToPresentation(page)
+	if err != nil {
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	presentation.WriteJSON(w, http.StatusOK, memberPage)
+}
+
+// flagOverdueMembers marks the members late with their payments in the season, in the current one when nil,
+// so the late fees of a member are never added up across seasons
+func flagOverdueMembers(members []membership.Member, seasonId *int64) result.Result[[]membership.Member] {
+	if paymentDeadlineService == nil || seasonService == nil {
+		return result.Ok(members)
+	}
+	if seasonId == nil {
+		current := seasonService.GetCurrentSeason(time.Now())
+		if !current.IsSuccess() {
+			// Between two seasons nothing is overdue
+			if _, ok := current.Error().(errors.NotFoundError); ok {
+				return result.Ok(members)
+			}
+			return result.Err[[]membership.Member](current.Error())
+		}
+		currentId := current.Value().ID
+		seasonId = &currentId
+	}
+	return result.Bind(paymentDeadlineService.GetOverdueItems(seasonId, time.Now()), func(overdue []payment.OverdueItem) result.Result[[]membership.Member] {
+		return membership.FlagOverdue(members, overdue)
+	})
+}
+
+// TaxCodeSuggestionHandler computes the tax code from the personal data of a member
+func TaxCodeSuggestionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req presentation.TaxCodeSuggestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	taxCode, err := presentation.ConvertTaxCodeSuggestionRequestToDomain(req)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	presentation.WriteJSON(w, http.StatusOK, presentation.TaxCodeSuggestion{TaxCode: taxCode.Value})
+}
+
 func MemberByIDHandler(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1.0/members/")
-	if idStr == "" {
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1.0/members/")
+	if path == "" {
 		presentation.WriteError(w, http.StatusBadRequest, "missing id")
 		return
 	}
 
+	idStr, subresource, _ := strings.Cut(path, "/")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
 		presentation.WriteError(w, http.StatusBadRequest, "invalid id format")
 		return
 	}
 
+	if subresource == "membership" {
+		handleMembershipStatus(w, r, id)
+		return
+	}
+
+	if subresource == "export" {
+		handleMemberDataExport(w, r, id)
+		return
+	}
+
+	if subresource == "merge" {
+		handleMemberMerge(w, r, id)
+		return
+	}
+
+	if subresource == "consents" {
+		handleMemberConsents(w, r, id)
+		return
+	}
+
+	if resource, documentId, _ := strings.Cut(subresource, "/"); resource == "documents" {
+		handleMemberDocuments(w, r, id, documentId)
+		return
+	}
+
+	if subresource != "" {
+		presentation.WriteError(w, http.StatusNotFound, "unknown member resource")
+		return
+	}
+
 	switch r.Method {
 	case http.MethodGet:
 		if memberService == nil {
@@ -148,7 +367,11 @@ func MemberByIDHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		member := presentation.ConvertMemberDetailsToPresentation(result.Value())
+		member, err := presentation.ConvertMemberDetailsToPresentation(result.Value())
+		if err != nil {
+			presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 		presentation.WriteJSON(w, http.StatusOK, member)
 
 	case http.MethodPut:
@@ -171,32 +394,256 @@ func MemberByIDHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		// A stored tax code no longer passing the checks is kept when sent back unchanged
+		memberId := domain.Id[membership.Member]{Value: id}
+		var storedTaxCode *membership.TaxCode
+		if current := memberService.GetMemberById(memberId, seasonId); current.IsSuccess() {
+			taxCode := current.Value().TaxCode
+			if taxCode != nil && !taxCode.IsValid() && strings.EqualFold(strings.TrimSpace(req.TaxCode), taxCode.Value) {
+				storedTaxCode = taxCode
+				req.TaxCode = ""
+			}
+		}
+
 		// Convert presentation request to domain data
 		user, err := presentation.ConvertUpdateMemberRequestToDomain(req)
 		if err != nil {
 			presentation.WriteError(w, http.StatusBadRequest, err.Error())
 			return
 		}
+		if storedTaxCode != nil {
+			user.TaxCode = storedTaxCode
+		}
 
 		// Update the member
-		memberId := domain.Id[membership.Member]{Value: id}
 		result := memberService.UpdateMember(memberId, user, seasonId)
 		if !result.IsSuccess() {
-			if _, ok := result.Error().(errors.NotFoundError); ok {
+			switch result.Error().(type) {
+			case errors.NotFoundError:
 				presentation.WriteError(w, http.StatusNotFound, result.Error().Error())
-				return
+			case errors.GuardianError:
+				presentation.WriteError(w, http.StatusBadRequest, result.Error().Error())
+			default:
+				presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
 			}
-			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
 			return
 		}
 
 		// Convert to presentation and return
-		memberDetails := presentation.ConvertMemberDetailsToPresentation(result.Value())
+		memberDetails, err := presentation.ConvertMemberDetailsToPresentation(result.Value())
+		if err != nil {
+			presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 		presentation.WriteJSON(w, http.StatusOK, memberDetails)
 
+	case http.MethodDelete:
+		if memberService == nil {
+			presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+			return
+		}
+
+		// The exclusion must have been deliberated in this season
+		season := r.URL.Query().Get("season")
+		seasonId, err := strconv.ParseInt(season, 10, 64)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "missing season query parameter")
+			return
+		}
+
+		memberId := domain.Id[membership.Member]{Value: id}
+		waitingLists := waitingListsOf(memberId)
+		result := memberService.RemoveMember(memberId, seasonId)
+		if !result.IsSuccess() {
+			switch result.Error().(type) {
+			case errors.NotFoundError:
+				presentation.WriteError(w, http.StatusNotFound, result.Error().Error())
+			case errors.MembershipStatusError:
+				presentation.WriteError(w, http.StatusConflict, result.Error().Error())
+			default:
+				presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			}
+			return
+		}
+
+		notifyAllWaitingListChanges(waitingLists)
+
+		writeDocumentFilesDeletion(w, result.Value())
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleMembershipStatus changes the status of the member's membership in the given season
+// Setting ACTIVE reinstates a suspended membership
+func handleMembershipStatus(w http.ResponseWriter, r *http.Request, id int64) {
+	if r.Method != http.MethodPatch {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if memberService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	season := r.URL.Query().Get("season")
+	seasonId, err := strconv.ParseInt(season, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "missing season query parameter")
+		return
+	}
+
+	var req presentation.UpdateMembershipStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	memberId := domain.Id[membership.Member]{Value: id}
+	var result result.Result[membership.MemberDetails]
+	switch membership.MembershipStatus(req.Status) {
+	case membership.MembershipStatusSuspended:
+		result = memberService.SuspendMembership(memberId, seasonId)
+	case membership.MembershipStatusActive:
+		result = memberService.ReinstateMembership(memberId, seasonId)
+	case membership.MembershipStatusExpired:
+		result = memberService.ExpireMembership(memberId, seasonId)
+	case membership.MembershipStatusExcluded:
+		decisionDate, err := presentation.ConvertExclusionDecisionDate(req)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		result = memberService.ExcludeMember(memberId, seasonId, decisionDate, req.Reason)
 	default:
+		presentation.WriteError(w, http.StatusBadRequest, "invalid membership status: "+req.Status)
+		return
+	}
+
+	if !result.IsSuccess() {
+		switch result.Error().(type) {
+		case errors.NotFoundError:
+			presentation.WriteError(w, http.StatusNotFound, result.Error().Error())
+		case errors.MembershipStatusError:
+			presentation.WriteError(w, http.StatusConflict, result.Error().Error())
+		default:
+			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+		}
+		return
+	}
+
+	memberDetails, err := presentation.ConvertMemberDetailsToPresentation(result.Value())
+	if err != nil {
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	presentation.WriteJSON(w, http.StatusOK, memberDetails)
+}
+
+// MemberDuplicatesHandler lists the pairs of members that are likely the same person
+func MemberDuplicatesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if memberService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	threshold := membership.DefaultDuplicateThreshold
+	if minScore := r.URL.Query().Get("minScore"); minScore != "" {
+		value, err := strconv.Atoi(minScore)
+		if err != nil || value <= 0 || value > 100 {
+			presentation.WriteError(w, http.StatusBadRequest, "minScore must be between 1 and 100")
+			return
+		}
+		threshold = value
+	}
+
+	result := memberService.FindDuplicates(threshold)
+	if !result.IsSuccess() {
+		presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+		return
+	}
+
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertDuplicateCandidatesToPresentation(result.Value()))
+}
+
+// handleMemberMerge merges the member given in the body into the member of the path
+func handleMemberMerge(w http.ResponseWriter, r *http.Request, id int64) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if memberService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	var req presentation.MergeMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	if req.SourceMemberId <= 0 {
+		presentation.WriteError(w, http.StatusBadRequest, "sourceMemberId is required")
+		return
+	}
+
+	sourceId := domain.Id[membership.Member]{Value: req.SourceMemberId}
+	targetId := domain.Id[membership.Member]{Value: id}
+	waitingLists := waitingListsOf(sourceId, targetId)
+	result := memberService.MergeMembers(sourceId, targetId)
+	if !result.IsSuccess() {
+		switch result.Error().(type) {
+		case errors.NotFoundError:
+			presentation.WriteError(w, http.StatusNotFound, result.Error().Error())
+		case errors.MergeError:
+			presentation.WriteError(w, http.StatusBadRequest, result.Error().Error())
+		default:
+			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+		}
+		return
+	}
+
+	notifyAllWaitingListChanges(waitingLists)
+
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertMergeResultToPresentation(result.Value()))
+}
+
+// handleMemberDataExport returns everything held on the member across all seasons
+func handleMemberDataExport(w http.ResponseWriter, r *http.Request, id int64) {
+	if r.Method != http.MethodGet {
 		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if memberExportService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	result := memberExportService.ExportMemberData(domain.Id[membership.Member]{Value: id})
+	if !result.IsSuccess() {
+		if _, ok := result.Error().(errors.NotFoundError); ok {
+			presentation.WriteError(w, http.StatusNotFound, result.Error().Error())
+			return
+		}
+		presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+		return
+	}
+
+	export, err := presentation.ConvertMemberDataExportToPresentation(result.Value())
+	if err != nil {
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
 	}
+	presentation.WriteJSON(w, http.StatusOK, export)
 }
 
 func RentedFacilitiesHandler(w http.ResponseWriter, r *http.Request) {
@@ -233,9 +680,10 @@ func RentedFacilitiesHandler(w http.ResponseWriter, r *http.Request) {
 		rentedFacilities := rentalService.GetFacilitiesRentedByMember(memberId, seasonId)
 
 		// Convert DTOs to presentation models
-		rentedFacilitiesDTOs := make([]presentation.RentedFacility, len(rentedFacilities))
-		for i, rentedFacility := range rentedFacilities {
-			rentedFacilitiesDTOs[i] = presentation.ConvertRentedFacilityToPresentation(rentedFacility)
+		rentedFacilitiesDTOs, err := presentation.ConvertRentedFacilitiesToPresentation(rentedFacilities)
+		if err != nil {
+			presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+			return
 		}
 
 		presentation.WriteJSON(w, http.StatusOK, rentedFacilitiesDTOs)
@@ -269,6 +717,11 @@ func RentedFacilitiesHandler(w http.ResponseWriter, r *http.Request) {
 			presentation.WriteError(w, http.StatusBadRequest, "price must be greater than 0")
 			return
 		}
+		price, err := presentation.ConvertPriceToDomain(req.Price)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 
 		memberId := domain.Id[membership.User]{Value: req.MemberId}
 		facilityId := domain.Id[facilityrental.Facility]{Value: req.FacilityId}
@@ -356,23 +809,40 @@ func RentedFacilitiesHandler(w http.ResponseWriter, r *http.Request) {
 		// Determine if discount was applied based on price calculation
 		discountApplied := priceResult.DiscountApplied
 
+		// Renting removes the member from the waiting list, the others move up
+		waitingList := waitingListService.GetWaitingList(facility.FacilityTypeId)
+
 		// Rent facility
 		result := rentalService.RentService(
 			facilityId,
 			memberId,
 			req.SeasonId,
-			req.Price,
+			price,
 			discountApplied,
 			boatInfo,
 			leerboardInfo,
+			time.Now(),
 		)
 		if !result.IsSuccess() {
+			if _, isRentError := result.Error().(errors.RentError); isRentError {
+				presentation.WriteError(w, http.StatusConflict, result.Error().Error())
+				return
+			}
 			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
 			return
 		}
 
+		if memberNotificationService != nil {
+			notifyMembers("facility rented", memberNotificationService.FacilityRented(result.Value(), req.SeasonId))
+			notifyWaitingListChanges(waitingList, facility.FacilityTypeId)
+		}
+
 		// Convert to presentation and return
-		rentedFacility := presentation.ConvertRentedFacilityToPresentation(result.Value())
+		rentedFacility, err := presentation.ConvertRentedFacilityToPresentation(result.Value())
+		if err != nil {
+			presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 		presentation.WriteJSON(w, http.StatusCreated, rentedFacility)
 
 	default:
@@ -446,6 +916,7 @@ func RentedFacilityByIDHandler(w http.ResponseWriter, r *http.Request) {
 			newFacilityId,
 			memberId,
 			req.SeasonId,
+			time.Now(),
 		)
 		if !result.IsSuccess() {
 			presentation.WriteError(w, http.StatusBadRequest, result.Error().Error())
@@ -453,7 +924,11 @@ func RentedFacilityByIDHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Convert to presentation and return
-		updatedFacility := presentation.ConvertRentedFacilityToPresentation(result.Value())
+		updatedFacility, err := presentation.ConvertRentedFacilityToPresentation(result.Value())
+		if err != nil {
+			presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 		presentation.WriteJSON(w, http.StatusOK, updatedFacility)
 
 	case http.MethodPut:
@@ -532,7 +1007,11 @@ func RentedFacilityByIDHandler(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// Convert to presentation and return
-			updatedFacility := presentation.ConvertRentedFacilityToPresentation(result.Value())
+			updatedFacility, err := presentation.ConvertRentedFacilityToPresentation(result.Value())
+			if err != nil {
+				presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
 			presentation.WriteJSON(w, http.StatusOK, updatedFacility)
 			return
 		}
@@ -579,7 +1058,11 @@ func RentedFacilityByIDHandler(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// Convert to presentation and return
-			updatedFacility := presentation.ConvertRentedFacilityToPresentation(result.Value())
+			updatedFacility, err := presentation.ConvertRentedFacilityToPresentation(result.Value())
+			if err != nil {
+				presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
 			presentation.WriteJSON(w, http.StatusOK, updatedFacility)
 			return
 		}
@@ -597,14 +1080,23 @@ func RentedFacilityByIDHandler(w http.ResponseWriter, r *http.Request) {
 				presentation.WriteError(w, http.StatusBadRequest, "price cannot be negative")
 				return
 			}
+			price, err := presentation.ConvertPriceToDomain(req.Price)
+			if err != nil {
+				presentation.WriteError(w, http.StatusBadRequest, err.Error())
+				return
+			}
 
-			result := rentalService.UpdatePrice(rentedFacilityId, req.Price)
+			result := rentalService.UpdatePrice(rentedFacilityId, price)
 			if !result.IsSuccess() {
 				presentation.WriteError(w, http.StatusBadRequest, result.Error().Error())
 				return
 			}
 
-			updatedFacility := presentation.ConvertRentedFacilityToPresentation(result.Value())
+			updatedFacility, err := presentation.ConvertRentedFacilityToPresentation(result.Value())
+			if err != nil {
+				presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
 			presentation.WriteJSON(w, http.StatusOK, updatedFacility)
 			return
 		}
@@ -675,16 +1167,53 @@ func FacilitiesByTypeHandler(w http.ResponseWriter, r *http.Request) {
 	presentation.WriteJSON(w, http.StatusOK, presentationFacilities)
 }
 
+// MembershipsHandler adds a membership to a member on POST
+// GET returns the membership categories of the season (?season=) with their suggested price,
+// flagging the category fitting the member when memberId is given
 func MembershipsHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
+	if memberService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		handleMembershipCategorySuggestions(w, r)
+	case http.MethodPost:
+		handleAddMembership(w, r)
+	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func handleMembershipCategorySuggestions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	seasonId, err := strconv.ParseInt(query.Get("season"), 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "missing season query parameter")
 		return
 	}
 
-	if memberService == nil {
-		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+	var memberId *domain.Id[membership.Member]
+	if memberIdStr := query.Get("memberId"); memberIdStr != "" {
+		id, err := strconv.ParseInt(memberIdStr, 10, 64)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid memberId")
+			return
+		}
+		parsed := domain.NewId[membership.Member](id)
+		memberId = &parsed
+	}
+
+	result := memberService.SuggestMembershipCategories(seasonId, memberId)
+	if !result.IsSuccess() {
+		writeMembershipCategoryError(w, result.Error())
 		return
 	}
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertMembershipCategorySuggestionsToPresentation(result.Value()))
+}
+
+func handleAddMembership(w http.ResponseWriter, r *http.Request) {
 
 	var req presentation.AddMembershipRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -701,26 +1230,66 @@ func MembershipsHandler(w http.ResponseWriter, r *http.Request) {
 		presentation.WriteError(w, http.StatusBadRequest, "seasonId is required")
 		return
 	}
-	if req.Price < 0 {
+	if req.Price != nil && *req.Price < 0 {
 		presentation.WriteError(w, http.StatusBadRequest, "price must be greater than 0")
 		return
 	}
+	price, err := presentation.ConvertOptionalPriceToDomain(req.Price)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	// Add membership period
+	// Add membership period, the price defaults to the price of the category
 	memberId := domain.Id[membership.Member]{Value: req.MemberId}
-	result := memberService.AddMembership(memberId, req.SeasonId, req.Price)
+	result := memberService.AddMembership(memberId, req.SeasonId, price, membership.MembershipCategoryCode(req.Category))
 	if !result.IsSuccess() {
-		presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+		switch result.Error().(type) {
+		case errors.NotFoundError:
+			presentation.WriteError(w, http.StatusNotFound, result.Error().Error())
+		case errors.GuardianError, errors.MembershipCategoryError:
+			presentation.WriteError(w, http.StatusBadRequest, result.Error().Error())
+		default:
+			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+		}
 		return
 	}
 
+	if memberNotificationService != nil {
+		notifyMembers("membership created", memberNotificationService.MembershipCreated(result.Value(), req.SeasonId))
+	}
+
 	// Convert to presentation and return
-	memberDetails := presentation.ConvertMemberDetailsToPresentation(result.Value())
+	memberDetails, err := presentation.ConvertMemberDetailsToPresentation(result.Value())
+	if err != nil {
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
 	presentation.WriteJSON(w, http.StatusCreated, memberDetails)
 }
 
 func PaymentsHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
+	case http.MethodGet:
+		if paymentService == nil {
+			presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+			return
+		}
+
+		criteria, err := presentation.ConvertPaymentSearchQueryToDomain(r.URL.Query())
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		result := paymentService.SearchPayments(criteria)
+		if !result.IsSuccess() {
+			writePaymentError(w, result.Error())
+			return
+		}
+
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertPaymentPageToPresentation(result.Value()))
+
 	case http.MethodPost:
 		if paymentService == nil {
 			presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
@@ -740,11 +1309,7 @@ func PaymentsHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Validate required fields
-		if req.Amount < 0 {
-			presentation.WriteError(w, http.StatusBadRequest, "amount must be greater than or equal to 0")
-			return
-		}
+		// Validate required fields, amounts and refunds are checked against the ledger
 		if req.Currency == "" {
 			presentation.WriteError(w, http.StatusBadRequest, "currency is required")
 			return
@@ -754,30 +1319,27 @@ func PaymentsHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		transaction, err := presentation.ConvertCreatePaymentRequestToDomain(req)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		var result result.Result[int64]
 		if req.MembershipPeriodId != nil {
-			result = paymentService.CreatePaymentForMembershipPeriod(
-				*req.MembershipPeriodId,
-				req.Amount,
-				req.Currency,
-				req.PaymentMethod,
-				req.TransactionRef,
-			)
+			result = paymentService.CreatePaymentForMembershipPeriod(*req.MembershipPeriodId, transaction)
 		} else {
-			result = paymentService.CreatePaymentForRentedFacility(
-				*req.RentedFacilityId,
-				req.Amount,
-				req.Currency,
-				req.PaymentMethod,
-				req.TransactionRef,
-			)
+			result = paymentService.CreatePaymentForRentedFacility(*req.RentedFacilityId, transaction)
 		}
 
 		if !result.IsSuccess() {
-			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			writePaymentError(w, result.Error())
 			return
 		}
 
+		if memberNotificationService != nil {
+			notifyMembers("payment received", memberNotificationService.PaymentReceived(result.Value()))
+		}
+
 		response := map[string]int64{"id": result.Value()}
 		presentation.WriteJSON(w, http.StatusCreated, response)
 
@@ -799,7 +1361,7 @@ func PaymentByIDHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	paymentId := domain.Id[payment.Payment]{Value: id}
+	paymentId := domain.Id[payment.Transaction]{Value: id}
 
 	switch r.Method {
 	case http.MethodPut:
@@ -814,11 +1376,7 @@ func PaymentByIDHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Validate required fields
-		if req.Amount < 0 {
-			presentation.WriteError(w, http.StatusBadRequest, "amount must be greater than or equal to 0")
-			return
-		}
+		// Validate required fields, amounts and refunds are checked against the ledger
 		if req.Currency == "" {
 			presentation.WriteError(w, http.StatusBadRequest, "currency is required")
 			return
@@ -828,16 +1386,15 @@ func PaymentByIDHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		result := paymentService.UpdatePayment(
-			paymentId,
-			req.Amount,
-			req.Currency,
-			req.PaymentMethod,
-			req.TransactionRef,
-		)
+		transaction, err := presentation.ConvertUpdatePaymentRequestToDomain(paymentId, req)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		result := paymentService.UpdatePayment(transaction)
 
 		if !result.IsSuccess() {
-			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			writePaymentError(w, result.Error())
 			return
 		}
 
@@ -852,7 +1409,7 @@ func PaymentByIDHandler(w http.ResponseWriter, r *http.Request) {
 		result := paymentService.DeletePayment(paymentId)
 
 		if !result.IsSuccess() {
-			presentation.WriteError(w, http.StatusNotFound, result.Error().Error())
+			writePaymentError(w, result.Error())
 			return
 		}
 
@@ -863,485 +1420,2839 @@ func PaymentByIDHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func WaitingListHandler(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		if waitingListService == nil {
-			presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
-			return
-		}
-
-		// Get facility_type_id from query parameter
-		facilityTypeIDStr := r.URL.Query().Get("facility_type_id")
-		if facilityTypeIDStr == "" {
-			presentation.WriteError(w, http.StatusBadRequest, "facility_type_id is required")
-			return
-		}
-
-		facilityTypeID, err := strconv.ParseInt(facilityTypeIDStr, 10, 64)
-		if err != nil {
-			presentation.WriteError(w, http.StatusBadRequest, "invalid facility_type_id")
-			return
-		}
-
-		facilityTypeId := domain.Id[facilityrental.FacilityType]{Value: facilityTypeID}
-		result := waitingListService.GetWaitingList(facilityTypeId)
+func writePaymentError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case errors.PaymentError, errors.CurrencyMismatchError:
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
+	case errors.NotFoundError:
+		presentation.WriteError(w, http.StatusNotFound, err.Error())
+	default:
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
 
-		if !result.IsSuccess() {
-			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
-			return
-		}
+// CashRegisterHandler returns the cash register (prima nota) of a period
+// GET /api/v1.0/cash-register?from=YYYY-MM-DD&to=YYYY-MM-DD
+func CashRegisterHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
 
-		waitingList := presentation.ConvertWaitingListToPresentation(result.Value())
-		presentation.WriteJSON(w, http.StatusOK, waitingList)
+	if cashRegisterService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
 
-	case http.MethodPost:
-		if waitingListService == nil {
-			presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
-			return
-		}
+	from, to, err := parseCashRegisterPeriod(r)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-		var req presentation.AddToWaitingListRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
+	result := cashRegisterService.GetPeriod(from, to)
+	if !result.IsSuccess() {
+		writePaymentError(w, result.Error())
+		return
+	}
 
-		// Validate required fields
-		if req.MemberId == 0 {
-			presentation.WriteError(w, http.StatusBadRequest, "memberId is required")
-			return
-		}
-		if req.FacilityTypeId == 0 {
-			presentation.WriteError(w, http.StatusBadRequest, "facilityTypeId is required")
-			return
-		}
+	cashRegister, err := presentation.ConvertCashRegisterPeriodToPresentation(result.Value())
+	if err != nil {
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	presentation.WriteJSON(w, http.StatusOK, cashRegister)
+}
 
-		memberId := domain.Id[membership.Member]{Value: req.MemberId}
-		facilityTypeId := domain.Id[facilityrental.FacilityType]{Value: req.FacilityTypeId}
+// CashRegisterDayHandler handles a day of the cash register
+// GET /api/v1.0/cash-register/{date} returns the day with its payments and discrepancies
+// PUT /api/v1.0/cash-register/{date} records the opening balance, the counted cash or the bank transfers
+// POST /api/v1.0/cash-register/{date}/close records the final values and closes the day
+func CashRegisterDayHandler(w http.ResponseWriter, r *http.Request) {
+	if cashRegisterService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
 
-		result := waitingListService.AddToWaitingList(memberId, facilityTypeId, req.Notes)
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1.0/cash-register/")
+	dateStr, action, _ := strings.Cut(path, "/")
+	day, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid date, expected YYYY-MM-DD: "+dateStr)
+		return
+	}
 
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		result := cashRegisterService.GetDay(day)
 		if !result.IsSuccess() {
-			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			writePaymentError(w, result.Error())
 			return
 		}
-
-		entry := presentation.ConvertWaitingListEntryToPresentation(result.Value())
-		presentation.WriteJSON(w, http.StatusCreated, entry)
-
-	case http.MethodDelete:
-		if waitingListService == nil {
-			presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		cashDay, err := presentation.ConvertCashDayToPresentation(result.Value())
+		if err != nil {
+			presentation.WriteError(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+		presentation.WriteJSON(w, http.StatusOK, cashDay)
 
-		// Get member_id and facility_type_id from query parameters
-		memberIDStr := r.URL.Query().Get("member_id")
-		facilityTypeIDStr := r.URL.Query().Get("facility_type_id")
-
-		if memberIDStr == "" || facilityTypeIDStr == "" {
-			presentation.WriteError(w, http.StatusBadRequest, "member_id and facility_type_id are required")
+	case action == "" && r.Method == http.MethodPut, action == "close" && r.Method == http.MethodPost:
+		var req presentation.CashDayRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
 			return
 		}
 
-		memberID, err := strconv.ParseInt(memberIDStr, 10, 64)
+		record, err := presentation.ConvertCashDayRequestToDomain(req)
 		if err != nil {
-			presentation.WriteError(w, http.StatusBadRequest, "invalid member_id")
+			presentation.WriteError(w, http.StatusBadRequest, err.Error())
 			return
 		}
-
-		facilityTypeID, err := strconv.ParseInt(facilityTypeIDStr, 10, 64)
-		if err != nil {
-			presentation.WriteError(w, http.StatusBadRequest, "invalid facility_type_id")
-			return
+		var result result.Result[payment.CashDay]
+		if action == "close" {
+			result = cashRegisterService.CloseDay(day, record)
+		} else {
+			result = cashRegisterService.RecordDay(day, record)
 		}
-
-		memberId := domain.Id[membership.Member]{Value: memberID}
-		facilityTypeId := domain.Id[facilityrental.FacilityType]{Value: facilityTypeID}
-
-		result := waitingListService.RemoveFromWaitingListByMemberAndType(memberId, facilityTypeId)
-
 		if !result.IsSuccess() {
-			presentation.WriteError(w, http.StatusNotFound, result.Error().Error())
+			writePaymentError(w, result.Error())
+			return
+		}
+		cashDay, err := presentation.ConvertCashDayToPresentation(result.Value())
+		if err != nil {
+			presentation.WriteError(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+		presentation.WriteJSON(w, http.StatusOK, cashDay)
 
-		entry := presentation.ConvertWaitingListEntryToPresentation(result.Value())
-		presentation.WriteJSON(w, http.StatusOK, entry)
+	case action == "" || action == "close":
+		w.WriteHeader(http.StatusMethodNotAllowed)
 
 	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		presentation.WriteError(w, http.StatusNotFound, "not found")
 	}
 }
 
-func SuggestedPriceHandler(w http.ResponseWriter, r *http.Request) {
+// CashRegisterPDFHandler exports the cash register of a period as PDF
+// GET /api/v1.0/reports/cash-register/pdf?from=YYYY-MM-DD&to=YYYY-MM-DD
+func CashRegisterPDFHandler(w http.ResponseWriter, r *http.Request) {
+	writeCashRegisterExport(w, r, "application/pdf", "pdf", reportService.GenerateCashRegisterReport)
+}
+
+// CashRegisterCSVHandler exports the payments of the cash register of a period as CSV
+// GET /api/v1.0/reports/cash-register/csv?from=YYYY-MM-DD&to=YYYY-MM-DD
+func CashRegisterCSVHandler(w http.ResponseWriter, r *http.Request) {
+	writeCashRegisterExport(w, r, "text/csv; charset=utf-8", "csv", reportService.GenerateCashRegisterExport)
+}
+
+func writeCashRegisterExport(w http.ResponseWriter, r *http.Request, contentType string, extension string, generate func(reports.CashRegister) (*bytes.Buffer, error)) {
 	if r.Method != http.MethodGet {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
 
-	if rentalService == nil {
-		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+	if reportService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "report service not initialized")
 		return
 	}
 
-	// Get facility_type_id from query parameter
-	facilityTypeIDStr := r.URL.Query().Get("facility_type_id")
-	if facilityTypeIDStr == "" {
-		presentation.WriteError(w, http.StatusBadRequest, "facility_type_id is required")
+	if cashRegisterService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
 		return
 	}
 
-	facilityTypeID, err := strconv.ParseInt(facilityTypeIDStr, 10, 64)
+	from, to, err := parseCashRegisterPeriod(r)
 	if err != nil {
-		presentation.WriteError(w, http.StatusBadRequest, "invalid facility_type_id")
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Get member_id from query parameter
-	memberIDStr := r.URL.Query().Get("member_id")
-	if memberIDStr == "" {
-		presentation.WriteError(w, http.StatusBadRequest, "member_id is required")
+	period := cashRegisterService.GetPeriod(from, to)
+	if !period.IsSuccess() {
+		writePaymentError(w, period.Error())
 		return
 	}
 
-	memberID, err := strconv.ParseInt(memberIDStr, 10, 64)
+	register, err := convertCashRegisterToReport(period.Value())
 	if err != nil {
-		presentation.WriteError(w, http.StatusBadRequest, "invalid member_id")
+		presentation.WriteError(w, http.StatusInternalServerError, "failed to generate report: "+err.Error())
 		return
 	}
 
-	// Get season from query parameter (optional, defaults to 0)
-	seasonID := int64(0)
-	seasonStr := r.URL.Query().Get("season")
-	if seasonStr != "" {
-		parsedSeason, err := strconv.ParseInt(seasonStr, 10, 64)
-		if err != nil {
-			presentation.WriteError(w, http.StatusBadRequest, "invalid season")
-			return
-		}
-		seasonID = parsedSeason
+	buffer, err := generate(register)
+	if err != nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "failed to generate report: "+err.Error())
+		return
 	}
 
-	// Get boat_length from query parameter (optional, only for boat facilities)
-	var boatLengthMeters *float64
-	boatLengthStr := r.URL.Query().Get("boat_length")
-	if boatLengthStr != "" {
-		boatLength, err := strconv.ParseFloat(boatLengthStr, 64)
-		if err != nil {
-			presentation.WriteError(w, http.StatusBadRequest, "invalid boat_length")
-			return
-		}
-		if boatLength <= 0 {
-			presentation.WriteError(w, http.StatusBadRequest, "boat_length must be greater than 0")
-			return
-		}
-		boatLengthMeters = &boatLength
+	filename := "prima_nota_" + period.Value().From.Format("2006-01-02") + "_" + period.Value().To.Format("2006-01-02") + "." + extension
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.Header().Set("Content-Length", strconv.Itoa(buffer.Len()))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buffer.Bytes())
+}
+
+// parseCashRegisterPeriod reads the from and to query parameters, a single day when to is missing
+func parseCashRegisterPeriod(r *http.Request) (time.Time, time.Time, error) {
+	from, err := parseOptionalDate(r.URL.Query().Get("from"))
+	if err != nil || from == nil {
+		return time.Time{}, time.Time{}, errors.PaymentError{Description: "invalid from date, expected YYYY-MM-DD"}
+	}
+	to, err := parseOptionalDate(r.URL.Query().Get("to"))
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.PaymentError{Description: "invalid to date, expected YYYY-MM-DD"}
+	}
+	if to == nil {
+		to = from
 	}
+	return *from, *to, nil
+}
 
-	// Fetch facility type from catalog to get base price
-	catalog := facilityRepo.GetFacilitiesCatalog()
-	var facilityType *facilityrental.FacilityType
-	for _, ft := range catalog {
-		if ft.Id.Value == facilityTypeID {
-			facilityType = &ft
-			break
+// convertCashRegisterToReport fails with a CurrencyMismatchError when the amounts of the period
+// are not all in the default currency
+func convertCashRegisterToReport(period payment.CashRegisterPeriod) (reports.CashRegister, error) {
+	totals := period.TotalsByMethod()
+	if !totals.IsSuccess() {
+		return reports.CashRegister{}, totals.Error()
+	}
+	register := reports.CashRegister{
+		From:   period.From.Format("02/01/2006"),
+		To:     period.To.Format("02/01/2006"),
+		Days:   make([]reports.CashRegisterDay, len(period.Days)),
+		Totals: convertMethodTotalsToReport(totals.Value()),
+	}
+
+	for i, day := range period.Days {
+		expectedCash := day.ExpectedCash()
+		if !expectedCash.IsSuccess() {
+			return reports.CashRegister{}, expectedCash.Error()
+		}
+		dayTotals := day.TotalsByMethod()
+		if !dayTotals.IsSuccess() {
+			return reports.CashRegister{}, dayTotals.Error()
+		}
+		discrepancies := day.Discrepancies()
+		if !discrepancies.IsSuccess() {
+			return reports.CashRegister{}, discrepancies.Error()
+		}
+		reportDay := reports.CashRegisterDay{
+			Date:           day.Date.Format("02/01/2006"),
+			Closed:         day.IsClosed(),
+			OpeningBalance: day.OpeningBalance,
+			ExpectedCash:   expectedCash.Value(),
+			CountedCash:    day.CountedCash,
+			BankTransfers:  day.BankTransfers,
+			Notes:          day.Notes,
+			Entries:        make([]reports.CashRegisterEntry, len(day.Transactions)),
+			Totals:         convertMethodTotalsToReport(dayTotals.Value()),
+		}
+
+		for j, record := range day.Transactions {
+			description := "Tessera"
+			if record.Target == payment.FacilityTarget {
+				description = "Servizio " + record.FacilityIdentifier
+			}
+			amount := record.Transaction.Amount
+			if record.Transaction.IsRefund() {
+				amount = amount.Negate()
+			}
+			reportDay.Entries[j] = reports.CashRegisterEntry{
+				Time:          record.Transaction.Date.Format("15:04"),
+				Member:        record.LastName + " " + record.FirstName,
+				Description:   description,
+				PaymentMethod: record.Transaction.PaymentMethod,
+				Refund:        record.Transaction.IsRefund(),
+				Amount:        amount,
+				Notes:         record.Transaction.Notes,
+			}
+		}
+
+		for _, discrepancy := range discrepancies.Value() {
+			reportDay.Discrepancies = append(reportDay.Discrepancies, reports.CashRegisterDiscrepancy{
+				Type:       string(discrepancy.Type),
+				Expected:   discrepancy.Expected,
+				Actual:     discrepancy.Actual,
+				Difference: discrepancy.Difference().Value(),
+			})
 		}
+		register.Discrepancies += len(reportDay.Discrepancies)
+		register.Days[i] = reportDay
 	}
 
-	if facilityType == nil {
-		presentation.WriteError(w, http.StatusNotFound, "facility type not found")
+	return register, nil
+}
+
+func convertMethodTotalsToReport(methodTotals []payment.MethodTotal) []reports.CashRegisterTotal {
+	totals := make([]reports.CashRegisterTotal, len(methodTotals))
+	for i, total := range methodTotals {
+		totals[i] = reports.CashRegisterTotal{
+			PaymentMethod: total.PaymentMethod,
+			Count:         total.Count,
+			Paid:          total.Paid,
+			Refunded:      total.Refunded,
+			Net:           total.Net(),
+		}
+	}
+	return totals
+}
+
+// BankStatementsHandler lists the imported bank statements or imports a new one
+// GET /api/v1.0/bank-statements lists the statements, the latest first
+// POST /api/v1.0/bank-statements imports a CSV or CAMT.053 statement uploaded as multipart form (file)
+// and returns its incoming transfers with the proposed matches
+func BankStatementsHandler(w http.ResponseWriter, r *http.Request) {
+	if bankReconciliationService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
 		return
 	}
 
-	basePrice := facilityType.SuggestedPrice
+	switch r.Method {
+	case http.MethodGet:
+		result := bankReconciliationService.GetStatements()
+		if !result.IsSuccess() {
+			writePaymentError(w, result.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertBankStatementsToPresentation(result.Value()))
 
-	facilityTypeId := domain.Id[facilityrental.FacilityType]{Value: facilityTypeID}
-	memberId := domain.Id[membership.User]{Value: memberID}
+	case http.MethodPost:
+		// Room for the form fields on top of the file
+		r.Body = http.MaxBytesReader(w, r.Body, payment.MaxBankStatementSize+1<<20)
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid upload, statements cannot be larger than 5 MB: "+err.Error())
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
 
-	// Calculate suggested price with boat length support
-	priceResult := rentalService.GetSuggestedPriceWithBoatLength(
-		facilityTypeId,
-		basePrice,
-		memberId,
-		seasonID,
-		boatLengthMeters,
-	)
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "file is required")
+			return
+		}
+		defer file.Close()
 
-	// Get applicable discount rules for informational purposes
-	applicableDiscounts := rentalService.GetApplicableDiscountsForMember(
-		facilityTypeId,
-		memberId,
-		seasonID,
-	)
+		content, err := io.ReadAll(io.LimitReader(file, payment.MaxBankStatementSize+1))
+		if err != nil {
+			presentation.WriteError(w, http.StatusInternalServerError, "failed to read file: "+err.Error())
+			return
+		}
 
-	// Get boat length tiers if this is a boat facility
-	var boatLengthTiers []map[string]any
-	if facilityType.HasBoat {
-		if tiers, hasTiers := rentalService.GetBoatLengthTiers(facilityTypeId); hasTiers {
-			boatLengthTiers = make([]map[string]any, len(tiers))
-			for i, tier := range tiers {
-				boatLengthTiers[i] = map[string]any{
-					"minLengthMeters": tier.MinLengthMeters,
-					"maxLengthMeters": tier.MaxLengthMeters,
-					"price":           tier.Price,
-				}
-			}
+		imported := bankReconciliationService.ImportStatement(header.Filename, content)
+		if !imported.IsSuccess() {
+			writePaymentError(w, imported.Error())
+			return
 		}
-	}
 
-	response := map[string]any{
-		"suggestedPrice":        priceResult.FinalPrice,
-		"basePrice":             priceResult.BasePrice,
-		"pricingMethod":         string(priceResult.PricingMethod),
-		"discountApplied":       priceResult.DiscountApplied,
-		"discountAmount":        priceResult.DiscountAmount,
-		"boatLengthTierApplied": priceResult.BoatLengthTierApplied,
-		"boatLengthTierPrice":   priceResult.BoatLengthTierPrice,
-		"applicableRules":       len(applicableDiscounts),
-		"boatLengthTiers":       boatLengthTiers,
-		"hasBoatLengthPricing":  facilityType.HasBoat && len(boatLengthTiers) > 0,
-	}
+		proposals := bankReconciliationService.GetProposals(imported.Value().Statement.Id)
+		if !proposals.IsSuccess() {
+			writePaymentError(w, proposals.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusCreated, presentation.ConvertBankStatementImportToPresentation(imported.Value(), proposals.Value()))
 
-	presentation.WriteJSON(w, http.StatusOK, response)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
 }
 
-// MemberListPDFHandler generates a PDF report with all members
-func MemberListPDFHandler(w http.ResponseWriter, r *http.Request) {
+// BankStatementByIDHandler returns a statement with its transactions and the proposed matches of the unmatched ones
+// GET /api/v1.0/bank-statements/{id}
+func BankStatementByIDHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
 
-	if reportService == nil {
-		presentation.WriteError(w, http.StatusInternalServerError, "report service not initialized")
+	if bankReconciliationService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
 		return
 	}
 
-	if memberService == nil {
-		presentation.WriteError(w, http.StatusInternalServerError, "member service not initialized")
+	id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/api/v1.0/bank-statements/"), 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid bank statement id format")
 		return
 	}
+	statementId := domain.NewId[payment.BankStatement](id)
 
-	// Get season from query parameter
-	seasonStr := r.URL.Query().Get("season")
-	if seasonStr == "" {
-		presentation.WriteError(w, http.StatusBadRequest, "missing season query parameter")
+	statement := bankReconciliationService.GetStatement(statementId)
+	if !statement.IsSuccess() {
+		writePaymentError(w, statement.Error())
+		return
+	}
+	proposals := bankReconciliationService.GetProposals(statementId)
+	if !proposals.IsSuccess() {
+		writePaymentError(w, proposals.Error())
 		return
 	}
 
-	seasonId, err := strconv.ParseInt(seasonStr, 10, 64)
-	if err != nil {
-		presentation.WriteError(w, http.StatusBadRequest, "invalid season format")
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertBankStatementDetailToPresentation(statement.Value(), proposals.Value()))
+}
+
+// BankMatchesHandler confirms matches in bulk, creating a bank transfer payment for each
+// POST /api/v1.0/bank-statements/matches
+func BankMatchesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Get all members for the season
-	membersResult := memberService.GetListOfMembersBySeason(seasonId)
-	if !membersResult.IsSuccess() {
-		presentation.WriteError(w, http.StatusInternalServerError, "failed to get members: "+membersResult.Error().Error())
+	if bankReconciliationService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
 		return
 	}
 
-	members := membersResult.Value()
+	var req presentation.ConfirmMatchesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
 
-	// Convert to report format
-	memberSummaries := make([]reports.MemberSummary, len(members))
-	for i, member := range members {
-		email := ""
-		if member.User.Email != nil {
-			email = member.User.Email.Value
-		}
+	result := bankReconciliationService.ConfirmMatches(presentation.ConvertConfirmMatchesRequestToDomain(req))
+	if !result.IsSuccess() {
+		writePaymentError(w, result.Error())
+		return
+	}
 
-		memberSummaries[i] = reports.MemberSummary{
-			ID:                  member.User.Id.Value,
-			FirstName:           member.User.FirstName,
-			LastName:            member.User.LastName,
-			Email:               email,
-			BirthDate:           member.User.BirthDate.Format("02/01/2006"),
-			MembershipNumber:    member.Membership.Number,
-			MembershipStatus:    string(member.Membership.Status.GetStatus()),
-			MembershipPaid:      member.Membership.Payment.GetStatus() == payment.Paid,
-			HasUnpaidFacilities: member.HasUnpaidFacilities,
+	if memberNotificationService != nil {
+		for _, outcome := range result.Value() {
+			if outcome.PaymentId != nil {
+				notifyMembers("payment received", memberNotificationService.PaymentReceived(*outcome.PaymentId))
+			}
 		}
 	}
 
-	// Get season code
-	seasonResult := seasonRepo.GetSeasonById(seasonId)
-	if !seasonResult.IsSuccess() {
-		presentation.WriteError(w, http.StatusInternalServerError, "failed to get season: "+seasonResult.Error().Error())
-		return
-	}
-	seasonCode := seasonResult.Value().GetCode()
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertMatchOutcomesToPresentation(result.Value()))
+}
 
-	// Generate PDF
-	pdfBuffer, err := reportService.GenerateMemberListReport(memberSummaries, seasonCode)
-	if err != nil {
-		presentation.WriteError(w, http.StatusInternalServerError, "failed to generate PDF: "+err.Error())
+// FiscalDocumentsHandler lists or issues receipts and invoices
+// GET /api/v1.0/fiscal-documents?memberId=&year=&kind= lists the documents, the latest first
+// POST /api/v1.0/fiscal-documents issues a document, dated today, for payments of a single member
+func FiscalDocumentsHandler(w http.ResponseWriter, r *http.Request) {
+	if fiscalDocumentService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
 		return
 	}
 
-	// Set headers for PDF download
-	w.Header().Set("Content-Type", "application/pdf")
-	w.Header().Set("Content-Disposition", "attachment; filename=lista_soci.pdf")
-	w.Header().Set("Content-Length", strconv.Itoa(pdfBuffer.Len()))
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		criteria := payment.FiscalDocumentCriteria{}
+		if memberIdStr := query.Get("memberId"); memberIdStr != "" {
+			memberId, err := strconv.ParseInt(memberIdStr, 10, 64)
+			if err != nil {
+				presentation.WriteError(w, http.StatusBadRequest, "invalid memberId")
+				return
+			}
+			criteria.MemberId = &memberId
+		}
+		if yearStr := query.Get("year"); yearStr != "" {
+			year, err := strconv.Atoi(yearStr)
+			if err != nil {
+				presentation.WriteError(w, http.StatusBadRequest, "invalid year")
+				return
+			}
+			criteria.FiscalYear = &year
+		}
+		if kindStr := query.Get("kind"); kindStr != "" {
+			kind := payment.FiscalDocumentKind(strings.ToUpper(kindStr))
+			criteria.Kind = &kind
+		}
 
-	// Write PDF to response
-	w.WriteHeader(http.StatusOK)
-	w.Write(pdfBuffer.Bytes())
+		result := fiscalDocumentService.GetDocuments(criteria)
+		if !result.IsSuccess() {
+			writePaymentError(w, result.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertFiscalDocumentsToPresentation(result.Value()))
+
+	case http.MethodPost:
+		var req presentation.IssueFiscalDocumentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+		kind := payment.ReceiptDocument
+		if req.Kind != "" {
+			kind = payment.FiscalDocumentKind(strings.ToUpper(req.Kind))
+		}
+
+		result := fiscalDocumentService.IssueDocument(kind, req.PaymentIds)
+		if !result.IsSuccess() {
+			writePaymentError(w, result.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusCreated, presentation.ConvertFiscalDocumentToPresentation(result.Value()))
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
 }
 
-// MemberDetailPDFHandler generates a PDF report with member details and facilities
-func MemberDetailPDFHandler(w http.ResponseWriter, r *http.Request) {
+// FiscalDocumentByIDHandler returns a receipt or an invoice
+// GET /api/v1.0/fiscal-documents/{id} returns the document
+// GET /api/v1.0/fiscal-documents/{id}/pdf downloads it, generated again from the data stored when it was issued
+// GET /api/v1.0/fiscal-documents/{id}/xml downloads the FatturaPA file of an invoice, to be sent to the SdI
+func FiscalDocumentByIDHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
 
-	if reportService == nil {
-		presentation.WriteError(w, http.StatusInternalServerError, "report service not initialized")
+	if fiscalDocumentService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
 		return
 	}
 
-	if memberService == nil || rentalService == nil {
-		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+	idStr, subresource, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/v1.0/fiscal-documents/"), "/")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid fiscal document id format")
+		return
+	}
+	if subresource != "" && subresource != "pdf" && subresource != "xml" {
+		presentation.WriteError(w, http.StatusNotFound, "unknown fiscal document resource")
 		return
 	}
 
-	// Get member ID from path
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1.0/reports/members/")
-	idStr = strings.TrimSuffix(idStr, "/pdf")
-	if idStr == "" {
-		presentation.WriteError(w, http.StatusBadRequest, "missing member id")
+	if subresource == "xml" {
+		invoice := fiscalDocumentService.ExportElectronicInvoice(domain.NewId[payment.FiscalDocument](id))
+		if !invoice.IsSuccess() {
+			writePaymentError(w, invoice.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.Header().Set("Content-Disposition", "attachment; filename="+invoice.Value().FileName)
+		w.Header().Set("Content-Length", strconv.Itoa(len(invoice.Value().Content)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(invoice.Value().Content)
 		return
 	}
 
-	memberId64, err := strconv.ParseInt(idStr, 10, 64)
-	if err != nil {
-		presentation.WriteError(w, http.StatusBadRequest, "invalid member id format")
+	result := fiscalDocumentService.GetDocument(domain.NewId[payment.FiscalDocument](id))
+	if !result.IsSuccess() {
+		writePaymentError(w, result.Error())
 		return
 	}
+	document := result.Value()
 
-	memberId := domain.Id[membership.Member]{Value: memberId64}
+	if subresource == "" {
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertFiscalDocumentToPresentation(document))
+		return
+	}
 
-	// Get season from query parameter
-	seasonStr := r.URL.Query().Get("season")
-	if seasonStr == "" {
-		presentation.WriteError(w, http.StatusBadRequest, "missing season query parameter")
+	if reportService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "report service not initialized")
 		return
 	}
 
-	seasonId, err := strconv.ParseInt(seasonStr, 10, 64)
+	report := convertFiscalDocumentToReport(document)
+	pdfBuffer, err := reportService.GenerateFiscalDocumentReport(report)
 	if err != nil {
-		presentation.WriteError(w, http.StatusBadRequest, "invalid season format")
+		presentation.WriteError(w, http.StatusInternalServerError, "failed to generate PDF: "+err.Error())
 		return
 	}
 
-	// Get member details
-	memberResult := memberService.GetMemberById(memberId, seasonId)
-	if !memberResult.IsSuccess() {
-		presentation.WriteError(w, http.StatusNotFound, "member not found")
+	filename := strings.ToLower(report.Title) + "_" + strconv.Itoa(document.Number) + "_" + strconv.Itoa(document.FiscalYear) + ".pdf"
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.Header().Set("Content-Length", strconv.Itoa(pdfBuffer.Len()))
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdfBuffer.Bytes())
+}
+
+// ElectronicInvoicesHandler downloads the FatturaPA files of the invoices issued in a period, zipped
+// GET /api/v1.0/fiscal-documents/electronic-invoices?from=YYYY-MM-DD&to=YYYY-MM-DD
+func ElectronicInvoicesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
 
-	memberDetails := memberResult.Value()
-
-	// Convert member to report format
-	email := ""
-	if memberDetails.User.Email != nil {
-		email = memberDetails.User.Email.Value
+	if fiscalDocumentService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
 	}
 
-	memberDetail := reports.MemberDetail{
-		ID:        memberDetails.User.Id.Value,
-		FirstName: memberDetails.User.FirstName,
-		LastName:  memberDetails.User.LastName,
-		Email:     email,
-		BirthDate: memberDetails.User.BirthDate.Format("02/01/2006"),
+	from, err := parseOptionalDate(r.URL.Query().Get("from"))
+	if err != nil || from == nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid from date, expected YYYY-MM-DD")
+		return
+	}
+	to, err := parseOptionalDate(r.URL.Query().Get("to"))
+	if err != nil || to == nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid to date, expected YYYY-MM-DD")
+		return
 	}
 
-	// Add phone numbers
-	for _, phone := range memberDetails.User.PhoneNumbers {
-		memberDetail.PhoneNumbers = append(memberDetail.PhoneNumbers, reports.PhoneNumber{
-			Number: phone.Number,
-		})
+	invoices := fiscalDocumentService.ExportElectronicInvoices(*from, *to)
+	if !invoices.IsSuccess() {
+		writePaymentError(w, invoices.Error())
+		return
 	}
 
-	// Add addresses
-	for _, addr := range memberDetails.User.Addresses {
-		memberDetail.Addresses = append(memberDetail.Addresses, reports.Address{
-			Country:      addr.Country,
-			City:         addr.City,
-			Street:       addr.Street,
-			StreetNumber: addr.Number,
-			ZipCode:      addr.ZipCode,
-		})
+	archive, err := fatturapa.Archive(invoices.Value())
+	if err != nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "failed to generate archive: "+err.Error())
+		return
+	}
+
+	filename := "fatture_" + from.Format("2006-01-02") + "_" + to.Format("2006-01-02") + ".zip"
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.Header().Set("Content-Length", strconv.Itoa(archive.Len()))
+	w.WriteHeader(http.StatusOK)
+	w.Write(archive.Bytes())
+}
+
+func convertFiscalDocumentToReport(document payment.FiscalDocument) reports.FiscalDocument {
+	title := "Ricevuta"
+	if document.Kind == payment.InvoiceDocument {
+		title = "Fattura"
+	}
+
+	report := reports.FiscalDocument{
+		Title:    title,
+		Number:   document.Code(),
+		IssuedOn: document.IssuedOn.Format("02/01/2006"),
+		Issuer: reports.FiscalParty{
+			Name:      document.Issuer.Name,
+			TaxCode:   document.Issuer.TaxCode,
+			VATNumber: document.Issuer.VATNumber,
+			Address:   fiscalAddressLines(document.Issuer.Address),
+			Email:     document.Issuer.Email,
+		},
+		Recipient: reports.FiscalParty{
+			Name:    document.Recipient.LastName + " " + document.Recipient.FirstName,
+			TaxCode: document.Recipient.TaxCode,
+			Address: fiscalAddressLines(document.Recipient.Address),
+		},
+		Lines: make([]reports.FiscalDocumentLine, len(document.Lines)),
+		Total: document.Total(),
+		Notes: document.Notes,
+	}
+	if document.Recipient.IsBusiness() {
+		report.Recipient.Name = document.Recipient.CompanyName
+		report.Recipient.VATNumber = document.Recipient.VATNumber
+	}
+	for i, line := range document.Lines {
+		description := line.Description
+		if !line.IsVATExempt() {
+			description += " (IVA " + strconv.FormatFloat(line.VATRate, 'f', -1, 64) + "% inclusa)"
+		}
+		report.Lines[i] = reports.FiscalDocumentLine{
+			Description:   description,
+			PaidOn:        line.PaidOn.Format("02/01/2006"),
+			PaymentMethod: line.PaymentMethod,
+			Amount:        line.Amount,
+		}
+	}
+	return report
+}
+
+// fiscalAddressLines formats an address as street and city lines, leaving out the missing parts
+func fiscalAddressLines(address payment.FiscalAddress) []string {
+	lines := []string{}
+	if street := strings.TrimSpace(address.Street + " " + address.StreetNumber); street != "" {
+		lines = append(lines, street)
+	}
+	city := strings.TrimSpace(address.ZipCode + " " + address.City)
+	if address.Province != "" {
+		city = strings.TrimSpace(city + " (" + address.Province + ")")
+	}
+	if address.Country != "" && !strings.EqualFold(address.Country, "IT") && !strings.EqualFold(address.Country, "Italia") {
+		city = strings.TrimSpace(city + " - " + address.Country)
+	}
+	if city != "" {
+		lines = append(lines, city)
+	}
+	return lines
+}
+
+func WaitingListHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if waitingListService == nil {
+			presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+			return
+		}
+
+		// Get facility_type_id from query parameter
+		facilityTypeIDStr := r.URL.Query().Get("facility_type_id")
+		if facilityTypeIDStr == "" {
+			presentation.WriteError(w, http.StatusBadRequest, "facility_type_id is required")
+			return
+		}
+
+		facilityTypeID, err := strconv.ParseInt(facilityTypeIDStr, 10, 64)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid facility_type_id")
+			return
+		}
+
+		facilityTypeId := domain.Id[facilityrental.FacilityType]{Value: facilityTypeID}
+		result := waitingListService.GetWaitingList(facilityTypeId)
+
+		if !result.IsSuccess() {
+			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			return
+		}
+
+		waitingList := presentation.ConvertWaitingListToPresentation(result.Value())
+		presentation.WriteJSON(w, http.StatusOK, waitingList)
+
+	case http.MethodPost:
+		if waitingListService == nil {
+			presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+			return
+		}
+
+		var req presentation.AddToWaitingListRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+
+		// Validate required fields
+		if req.MemberId == 0 {
+			presentation.WriteError(w, http.StatusBadRequest, "memberId is required")
+			return
+		}
+		if req.FacilityTypeId == 0 {
+			presentation.WriteError(w, http.StatusBadRequest, "facilityTypeId is required")
+			return
+		}
+
+		memberId := domain.Id[membership.Member]{Value: req.MemberId}
+		facilityTypeId := domain.Id[facilityrental.FacilityType]{Value: req.FacilityTypeId}
+
+		result := waitingListService.AddToWaitingList(memberId, facilityTypeId, req.Notes)
+
+		if !result.IsSuccess() {
+			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			return
+		}
+
+		entry := presentation.ConvertWaitingListEntryToPresentation(result.Value())
+		presentation.WriteJSON(w, http.StatusCreated, entry)
+
+	case http.MethodDelete:
+		if waitingListService == nil {
+			presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+			return
+		}
+
+		// Get member_id and facility_type_id from query parameters
+		memberIDStr := r.URL.Query().Get("member_id")
+		facilityTypeIDStr := r.URL.Query().Get("facility_type_id")
+
+		if memberIDStr == "" || facilityTypeIDStr == "" {
+			presentation.WriteError(w, http.StatusBadRequest, "member_id and facility_type_id are required")
+			return
+		}
+
+		memberID, err := strconv.ParseInt(memberIDStr, 10, 64)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid member_id")
+			return
+		}
+
+		facilityTypeID, err := strconv.ParseInt(facilityTypeIDStr, 10, 64)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid facility_type_id")
+			return
+		}
+
+		memberId := domain.Id[membership.Member]{Value: memberID}
+		facilityTypeId := domain.Id[facilityrental.FacilityType]{Value: facilityTypeID}
+
+		waitingList := waitingListService.GetWaitingList(facilityTypeId)
+		result := waitingListService.RemoveFromWaitingListByMemberAndType(memberId, facilityTypeId)
+
+		if !result.IsSuccess() {
+			presentation.WriteError(w, http.StatusNotFound, result.Error().Error())
+			return
+		}
+
+		if memberNotificationService != nil {
+			notifyWaitingListChanges(waitingList, facilityTypeId)
+		}
+
+		entry := presentation.ConvertWaitingListEntryToPresentation(result.Value())
+		presentation.WriteJSON(w, http.StatusOK, entry)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func SuggestedPriceHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if rentalService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	// Get facility_type_id from query parameter
+	facilityTypeIDStr := r.URL.Query().Get("facility_type_id")
+	if facilityTypeIDStr == "" {
+		presentation.WriteError(w, http.StatusBadRequest, "facility_type_id is required")
+		return
+	}
+
+	facilityTypeID, err := strconv.ParseInt(facilityTypeIDStr, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid facility_type_id")
+		return
+	}
+
+	// Get member_id from query parameter
+	memberIDStr := r.URL.Query().Get("member_id")
+	if memberIDStr == "" {
+		presentation.WriteError(w, http.StatusBadRequest, "member_id is required")
+		return
+	}
+
+	memberID, err := strconv.ParseInt(memberIDStr, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid member_id")
+		return
+	}
+
+	// Get season from query parameter (optional, defaults to 0)
+	seasonID := int64(0)
+	seasonStr := r.URL.Query().Get("season")
+	if seasonStr != "" {
+		parsedSeason, err := strconv.ParseInt(seasonStr, 10, 64)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid season")
+			return
+		}
+		seasonID = parsedSeason
+	}
+
+	// Get boat_length from query parameter (optional, only for boat facilities)
+	var boatLengthMeters *float64
+	boatLengthStr := r.URL.Query().Get("boat_length")
+	if boatLengthStr != "" {
+		boatLength, err := strconv.ParseFloat(boatLengthStr, 64)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid boat_length")
+			return
+		}
+		if boatLength <= 0 {
+			presentation.WriteError(w, http.StatusBadRequest, "boat_length must be greater than 0")
+			return
+		}
+		boatLengthMeters = &boatLength
+	}
+
+	// Fetch facility type from catalog to get base price
+	catalog := facilityRepo.GetFacilitiesCatalog()
+	var facilityType *facilityrental.FacilityType
+	for _, ft := range catalog {
+		if ft.Id.Value == facilityTypeID {
+			facilityType = &ft
+			break
+		}
+	}
+
+	if facilityType == nil {
+		presentation.WriteError(w, http.StatusNotFound, "facility type not found")
+		return
+	}
+
+	basePrice := facilityType.SuggestedPrice
+
+	facilityTypeId := domain.Id[facilityrental.FacilityType]{Value: facilityTypeID}
+	memberId := domain.Id[membership.User]{Value: memberID}
+
+	// Calculate suggested price with boat length support
+	priceResult := rentalService.GetSuggestedPriceWithBoatLength(
+		facilityTypeId,
+		basePrice,
+		memberId,
+		seasonID,
+		boatLengthMeters,
+	)
+
+	// Get applicable discount rules for informational purposes
+	applicableDiscounts := rentalService.GetApplicableDiscountsForMember(
+		facilityTypeId,
+		memberId,
+		seasonID,
+	)
+
+	// Get boat length tiers if this is a boat facility
+	var boatLengthTiers []map[string]any
+	if facilityType.HasBoat {
+		if tiers, hasTiers := rentalService.GetBoatLengthTiers(facilityTypeId); hasTiers {
+			boatLengthTiers = make([]map[string]any, len(tiers))
+			for i, tier := range tiers {
+				boatLengthTiers[i] = map[string]any{
+					"minLengthMeters": tier.MinLengthMeters,
+					"maxLengthMeters": tier.MaxLengthMeters,
+					"price":           tier.Price.Float64(),
+				}
+			}
+		}
+	}
+
+	response := map[string]any{
+		"suggestedPrice":        priceResult.FinalPrice.Float64(),
+		"basePrice":             priceResult.BasePrice.Float64(),
+		"pricingMethod":         string(priceResult.PricingMethod),
+		"discountApplied":       priceResult.DiscountApplied,
+		"discountAmount":        priceResult.DiscountAmount.Float64(),
+		"boatLengthTierApplied": priceResult.BoatLengthTierApplied,
+		"boatLengthTierPrice":   priceResult.BoatLengthTierPrice.Float64(),
+		"applicableRules":       len(applicableDiscounts),
+		"boatLengthTiers":       boatLengthTiers,
+		"hasBoatLengthPricing":  facilityType.HasBoat && len(boatLengthTiers) > 0,
+	}
+
+	presentation.WriteJSON(w, http.StatusOK, response)
+}
+
+// MemberListPDFHandler generates a PDF report with all members
+func MemberListPDFHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if reportService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "report service not initialized")
+		return
+	}
+
+	if memberService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "member service not initialized")
+		return
+	}
+
+	// Get season from query parameter
+	seasonStr := r.URL.Query().Get("season")
+	if seasonStr == "" {
+		presentation.WriteError(w, http.StatusBadRequest, "missing season query parameter")
+		return
+	}
+
+	seasonId, err := strconv.ParseInt(seasonStr, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid season format")
+		return
+	}
+
+	// Get all members for the season
+	membersResult := memberService.GetListOfMembersBySeason(seasonId)
+	if !membersResult.IsSuccess() {
+		presentation.WriteError(w, http.StatusInternalServerError, "failed to get members: "+membersResult.Error().Error())
+		return
+	}
+
+	flagged := flagOverdueMembers(membersResult.Value(), &seasonId)
+	if !flagged.IsSuccess() {
+		presentation.WriteError(w, http.StatusInternalServerError, "failed to get overdue payments: "+flagged.Error().Error())
+		return
+	}
+	members := flagged.Value()
+
+	// Convert to report format
+	memberSummaries := make([]reports.MemberSummary, len(members))
+	for i, member := range members {
+		email := ""
+		if member.User.Email != nil {
+			email = member.User.Email.Value
+		}
+		paid := member.Membership.IsPaid()
+		if !paid.IsSuccess() {
+			presentation.WriteError(w, http.StatusInternalServerError, "failed to generate PDF: "+paid.Error().Error())
+			return
+		}
+
+		memberSummaries[i] = reports.MemberSummary{
+			ID:                   member.User.Id.Value,
+			FirstName:            member.User.FirstName,
+			LastName:             member.User.LastName,
+			Email:                email,
+			BirthDate:            member.User.BirthDate.Format("02/01/2006"),
+			MembershipNumber:     member.Membership.Number,
+			MembershipStatus:     string(member.Membership.Status.GetStatus()),
+			MembershipPaid:       paid.Value(),
+			HasUnpaidFacilities:  member.HasUnpaidFacilities,
+			MembershipOverdue:    member.MembershipOverdue,
+			HasOverdueFacilities: member.HasOverdueFacilities,
+			LateFees:             member.LateFees,
+		}
+	}
+
+	// Get season code
+	seasonResult := seasonRepo.GetSeasonById(seasonId)
+	if !seasonResult.IsSuccess() {
+		presentation.WriteError(w, http.StatusInternalServerError, "failed to get season: "+seasonResult.Error().Error())
+		return
+	}
+	seasonCode := seasonResult.Value().GetCode()
+
+	// Generate PDF
+	pdfBuffer, err := reportService.GenerateMemberListReport(memberSummaries, seasonCode)
+	if err != nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "failed to generate PDF: "+err.Error())
+		return
+	}
+
+	// Set headers for PDF download
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", "attachment; filename=lista_soci.pdf")
+	w.Header().Set("Content-Length", strconv.Itoa(pdfBuffer.Len()))
+
+	// Write PDF to response
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdfBuffer.Bytes())
+}
+
+// MemberDetailPDFHandler generates a PDF report with member details and facilities
+func MemberDetailPDFHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if reportService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "report service not initialized")
+		return
+	}
+
+	// The membership card shares the path prefix of the member report
+	if strings.HasSuffix(r.URL.Path, "/card/pdf") {
+		handleMembershipCardPDF(w, r)
+		return
+	}
+
+	if memberService == nil || rentalService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	// Get member ID from path
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1.0/reports/members/")
+	idStr = strings.TrimSuffix(idStr, "/pdf")
+	if idStr == "" {
+		presentation.WriteError(w, http.StatusBadRequest, "missing member id")
+		return
+	}
+
+	memberId64, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid member id format")
+		return
+	}
+
+	memberId := domain.Id[membership.Member]{Value: memberId64}
+
+	// Get season from query parameter
+	seasonStr := r.URL.Query().Get("season")
+	if seasonStr == "" {
+		presentation.WriteError(w, http.StatusBadRequest, "missing season query parameter")
+		return
+	}
+
+	seasonId, err := strconv.ParseInt(seasonStr, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid season format")
+		return
+	}
+
+	// Get member details
+	memberResult := memberService.GetMemberById(memberId, seasonId)
+	if !memberResult.IsSuccess() {
+		presentation.WriteError(w, http.StatusNotFound, "member not found")
+		return
+	}
+
+	memberDetails := memberResult.Value()
+
+	// Convert member to report format
+	email := ""
+	if memberDetails.User.Email != nil {
+		email = memberDetails.User.Email.Value
+	}
+
+	memberDetail := reports.MemberDetail{
+		ID:        memberDetails.User.Id.Value,
+		FirstName: memberDetails.User.FirstName,
+		LastName:  memberDetails.User.LastName,
+		Email:     email,
+		BirthDate: memberDetails.User.BirthDate.Format("02/01/2006"),
+	}
+
+	// Add phone numbers
+	for _, phone := range memberDetails.User.PhoneNumbers {
+		memberDetail.PhoneNumbers = append(memberDetail.PhoneNumbers, reports.PhoneNumber{
+			Number: phone.Number,
+		})
+	}
+
+	// Add addresses
+	for _, addr := range memberDetails.User.Addresses {
+		memberDetail.Addresses = append(memberDetail.Addresses, reports.Address{
+			Country:      addr.Country,
+			City:         addr.City,
+			Street:       addr.Street,
+			StreetNumber: addr.Number,
+			ZipCode:      addr.ZipCode,
+		})
+	}
+
+	// Add guardians
+	memberDetail.IsMinor = memberDetails.IsMinor()
+	for _, guardian := range memberDetails.User.Guardians {
+		reportGuardian := reports.Guardian{
+			Name:         strings.TrimSpace(guardian.FirstName + " " + guardian.LastName),
+			Relationship: guardian.Relationship,
+		}
+		if guardian.Email != nil {
+			reportGuardian.Email = guardian.Email.Value
+		}
+		if guardian.PhoneNumber != nil {
+			reportGuardian.Phone = guardian.PhoneNumber.Number
+		}
+		memberDetail.Guardians = append(memberDetail.Guardians, reportGuardian)
 	}
 
 	// Add memberships
 	for _, ms := range memberDetails.Memberships {
+		paid := ms.IsPaid()
+		if !paid.IsSuccess() {
+			presentation.WriteError(w, http.StatusInternalServerError, "failed to generate PDF: "+paid.Error().Error())
+			return
+		}
+		status, balance, err := ledgerSummary(ms.Ledger())
+		if err != nil {
+			presentation.WriteError(w, http.StatusInternalServerError, "failed to generate PDF: "+err.Error())
+			return
+		}
 		memberDetail.Memberships = append(memberDetail.Memberships, reports.Membership{
-			ID:        ms.Id.Value,
-			Number:    ms.Number,
-			Status:    string(ms.Status.GetStatus()),
-			ValidFrom: ms.Status.GetValidFromDate().Format("02/01/2006"),
-			ExpiresAt: ms.Status.GetValidUntilDate().Format("02/01/2006"),
-			Price:     ms.Price,
-			Paid:      ms.Payment.GetStatus() == payment.Paid,
+			ID:            ms.Id.Value,
+			Number:        ms.Number,
+			Status:        string(ms.Status.GetStatus()),
+			ValidFrom:     ms.Status.GetValidFromDate().Format("02/01/2006"),
+			ExpiresAt:     ms.Status.GetValidUntilDate().Format("02/01/2006"),
+			Price:         ms.Price,
+			Paid:          paid.Value(),
+			PaymentStatus: string(status),
+			Balance:       balance,
 		})
 	}
 
-	// Get rented facilities
-	userIdForFacilities := domain.Id[membership.User]{Value: memberId64}
-	rentedFacilities := rentalService.GetFacilitiesRentedByMember(userIdForFacilities, seasonId)
+	// Get rented facilities
+	userIdForFacilities := domain.Id[membership.User]{Value: memberId64}
+	rentedFacilities := rentalService.GetFacilitiesRentedByMember(userIdForFacilities, seasonId)
+
+	// Convert to report format
+	facilityRentals := make([]reports.FacilityRental, len(rentedFacilities))
+	for i, rf := range rentedFacilities {
+		status, balance, err := ledgerSummary(rf.GetLedger())
+		if err != nil {
+			presentation.WriteError(w, http.StatusInternalServerError, "failed to generate PDF: "+err.Error())
+			return
+		}
+		facility := rf.GetFacility()
+		boatName := ""
+		if rf.GetType() == facilityrental.BoatFacility {
+			if boatFacility, ok := rf.(facilityrental.RentedFacilityWithBoat); ok {
+				boatName = boatFacility.BoatInfo.Name
+			}
+		}
+
+		facilityRentals[i] = reports.FacilityRental{
+			ID:                      rf.GetId().Value,
+			FacilityIdentifier:      facility.Identifier,
+			FacilityName:            string(facility.FacilityType.FacilityName),
+			FacilityTypeDescription: facility.FacilityType.Description,
+			RentedAt:                rf.GetValidity().FromDate.Format("02/01/2006"),
+			ExpiresAt:               rf.GetValidity().ToDate.Format("02/01/2006"),
+			Price:                   rf.GetPrice(),
+			Paid:                    !balance.IsPositive(),
+			PaymentStatus:           string(status),
+			Balance:                 balance,
+			BoatName:                boatName,
+		}
+	}
+
+	// Get season code
+	seasonResult := seasonRepo.GetSeasonById(seasonId)
+	if !seasonResult.IsSuccess() {
+		presentation.WriteError(w, http.StatusInternalServerError, "failed to get season: "+seasonResult.Error().Error())
+		return
+	}
+	seasonCode := seasonResult.Value().GetCode()
+
+	// Generate PDF
+	pdfBuffer, err := reportService.GenerateMemberDetailReport(memberDetail, facilityRentals, seasonCode)
+	if err != nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "failed to generate PDF: "+err.Error())
+		return
+	}
+
+	// Set headers for PDF download
+	filename := "dettaglio_socio_" + memberDetails.User.LastName + "_" + memberDetails.User.FirstName + ".pdf"
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.Header().Set("Content-Length", strconv.Itoa(pdfBuffer.Len()))
+
+	// Write PDF to response
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdfBuffer.Bytes())
+}
+
+// ledgerSummary returns the payment status and the balance of a ledger,
+// CurrencyMismatchError when its transactions are not in the currency of the amount due
+func ledgerSummary(ledger payment.Ledger) (payment.PaymentStatus, money.Money, error) {
+	status := ledger.GetStatus()
+	if !status.IsSuccess() {
+		return "", money.Money{}, status.Error()
+	}
+	balance := ledger.Balance()
+	if !balance.IsSuccess() {
+		return "", money.Money{}, balance.Error()
+	}
+	return status.Value(), balance.Value(), nil
+}
+
+// PreemptionsHandler lists the facilities of a season still reserved to their previous holder
+func PreemptionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if rentalService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	seasonIDStr := r.URL.Query().Get("season")
+	if seasonIDStr == "" {
+		presentation.WriteError(w, http.StatusBadRequest, "season is required")
+		return
+	}
+
+	seasonId, err := strconv.ParseInt(seasonIDStr, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid season")
+		return
+	}
+
+	result := rentalService.GetPendingPreemptions(seasonId, time.Now())
+	if !result.IsSuccess() {
+		presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+		return
+	}
+
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertPreemptionRightsToPresentation(result.Value()))
+}
+
+func SeasonsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if seasonService == nil {
+			presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+			return
+		}
+
+		result := seasonService.GetAllSeasons()
+		if !result.IsSuccess() {
+			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			return
+		}
+
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertSeasonsToPresentation(result.Value()))
+
+	case http.MethodPost:
+		if seasonService == nil {
+			presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+			return
+		}
+
+		var req presentation.CreateSeasonRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+
+		data, err := presentation.ConvertCreateSeasonRequestToDomain(req)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		result := seasonService.CreateSeason(data.Code, data.Name, data.StartsAt, data.EndsAt)
+		if !result.IsSuccess() {
+			presentation.WriteError(w, seasonErrorStatus(result.Error()), result.Error().Error())
+			return
+		}
+
+		presentation.WriteJSON(w, http.StatusCreated, presentation.ConvertSeasonToPresentation(result.Value()))
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// SeasonByIDHandler handles /seasons/current, /seasons/{id} and /seasons/{id}/close
+func SeasonByIDHandler(w http.ResponseWriter, r *http.Request) {
+	if seasonService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1.0/seasons/")
+	if path == "" {
+		presentation.WriteError(w, http.StatusBadRequest, "missing season id")
+		return
+	}
+
+	if path == "current" {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		result := seasonService.GetCurrentSeason(time.Now())
+		if !result.IsSuccess() {
+			presentation.WriteError(w, seasonErrorStatus(result.Error()), result.Error().Error())
+			return
+		}
+
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertSeasonToPresentation(result.Value()))
+		return
+	}
+
+	idStr, action, _ := strings.Cut(path, "/")
+	seasonId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid season id format")
+		return
+	}
+
+	if action == "close" {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		result := seasonService.CloseSeason(seasonId)
+		if !result.IsSuccess() {
+			presentation.WriteError(w, seasonErrorStatus(result.Error()), result.Error().Error())
+			return
+		}
+
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertSeasonToPresentation(result.Value()))
+		return
+	}
+
+	if action == "preemption-window" {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req presentation.SetPreemptionWindowRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+
+		preemptionEndsAt, err := presentation.ConvertSetPreemptionWindowRequestToDomain(req)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		result := seasonService.SetPreemptionWindow(seasonId, preemptionEndsAt)
+		if !result.IsSuccess() {
+			presentation.WriteError(w, seasonErrorStatus(result.Error()), result.Error().Error())
+			return
+		}
+
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertSeasonToPresentation(result.Value()))
+		return
+	}
+
+	if action == "rollover" {
+		handleSeasonRollover(w, r, seasonId)
+		return
+	}
+
+	if action == "payment-deadlines" {
+		handleSeasonPaymentDeadlines(w, r, seasonId)
+		return
+	}
+
+	if action == "overdue-payments" {
+		handleSeasonOverduePayments(w, r, seasonId)
+		return
+	}
+
+	if action != "" {
+		presentation.WriteError(w, http.StatusNotFound, "unknown season action")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		result := seasonService.GetSeasonById(seasonId)
+		if !result.IsSuccess() {
+			presentation.WriteError(w, seasonErrorStatus(result.Error()), result.Error().Error())
+			return
+		}
+
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertSeasonToPresentation(result.Value()))
+
+	case http.MethodPut:
+		var req presentation.UpdateSeasonRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+
+		data, err := presentation.ConvertUpdateSeasonRequestToDomain(req)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		result := seasonService.UpdateSeason(seasonId, data.Code, data.Name, data.StartsAt, data.EndsAt)
+		if !result.IsSuccess() {
+			presentation.WriteError(w, seasonErrorStatus(result.Error()), result.Error().Error())
+			return
+		}
+
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertSeasonToPresentation(result.Value()))
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleSeasonRollover renews the memberships and rentals of a season into the target season
+// With dryRun set, the plan is returned without storing anything
+func handleSeasonRollover(w http.ResponseWriter, r *http.Request, sourceSeasonId int64) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if seasonRolloverService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	var req presentation.SeasonRolloverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	if req.TargetSeasonID == 0 {
+		presentation.WriteError(w, http.StatusBadRequest, "targetSeasonId is required")
+		return
+	}
+
+	if req.DryRun {
+		result := seasonRolloverService.PreviewRollover(sourceSeasonId, req.TargetSeasonID)
+		if !result.IsSuccess() {
+			presentation.WriteError(w, seasonErrorStatus(result.Error()), result.Error().Error())
+			return
+		}
+
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertRolloverPlanToPresentation(result.Value(), true))
+		return
+	}
+
+	result := seasonRolloverService.ExecuteRollover(sourceSeasonId, req.TargetSeasonID)
+	if !result.IsSuccess() {
+		presentation.WriteError(w, seasonErrorStatus(result.Error()), result.Error().Error())
+		return
+	}
+
+	presentation.WriteJSON(w, http.StatusCreated, presentation.ConvertRolloverPlanToPresentation(result.Value().Plan, false))
+}
+
+// handleSeasonPaymentDeadlines reads or replaces the payment deadlines of a season
+func handleSeasonPaymentDeadlines(w http.ResponseWriter, r *http.Request, seasonId int64) {
+	if paymentDeadlineService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	if season := seasonService.GetSeasonById(seasonId); !season.IsSuccess() {
+		presentation.WriteError(w, seasonErrorStatus(season.Error()), season.Error().Error())
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		result := paymentDeadlineService.GetDeadlines(seasonId)
+		if !result.IsSuccess() {
+			writePaymentDeadlineError(w, result.Error())
+			return
+		}
+
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertPaymentDeadlinesToPresentation(result.Value()))
+
+	case http.MethodPut:
+		var req presentation.SetPaymentDeadlinesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+
+		deadlines, err := presentation.ConvertSetPaymentDeadlinesRequestToDomain(req)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		result := paymentDeadlineService.SetDeadlines(seasonId, deadlines)
+		if !result.IsSuccess() {
+			writePaymentDeadlineError(w, result.Error())
+			return
+		}
+
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertPaymentDeadlinesToPresentation(result.Value()))
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleSeasonOverduePayments lists what is still owed in a season after its deadline, with the late fees accrued today
+func handleSeasonOverduePayments(w http.ResponseWriter, r *http.Request, seasonId int64) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if paymentDeadlineService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	if season := seasonService.GetSeasonById(seasonId); !season.IsSuccess() {
+		presentation.WriteError(w, seasonErrorStatus(season.Error()), season.Error().Error())
+		return
+	}
+
+	result := paymentDeadlineService.GetOverdueItems(&seasonId, time.Now())
+	if !result.IsSuccess() {
+		writePaymentDeadlineError(w, result.Error())
+		return
+	}
+
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertOverdueItemsToPresentation(result.Value()))
+}
+
+func writePaymentDeadlineError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case errors.NotFoundError:
+		presentation.WriteError(w, http.StatusNotFound, err.Error())
+	case errors.PaymentError, errors.CurrencyMismatchError:
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// seasonErrorStatus maps season management errors to HTTP status codes
+func seasonErrorStatus(err error) int {
+	switch err.(type) {
+	case errors.NotFoundError:
+		return http.StatusNotFound
+	case errors.SeasonError:
+		return http.StatusConflict
+	case errors.DateError:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// MembershipExpiryHandler lists the runs of the membership expiry job and triggers a run on demand
+func MembershipExpiryHandler(w http.ResponseWriter, r *http.Request) {
+	if expiryService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		limit := 0
+		if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+			parsed, err := strconv.Atoi(limitStr)
+			if err != nil {
+				presentation.WriteError(w, http.StatusBadRequest, "invalid limit")
+				return
+			}
+			limit = parsed
+		}
+
+		result := expiryService.GetExpiryRuns(limit)
+		if !result.IsSuccess() {
+			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			return
+		}
+
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertExpiryRunsToPresentation(result.Value()))
+
+	case http.MethodPost:
+		result := expiryService.RunExpiry(time.Now(), club.ManualExpiry)
+		if !result.IsSuccess() {
+			if _, ok := result.Error().(errors.MembershipStatusError); ok {
+				presentation.WriteError(w, http.StatusConflict, result.Error().Error())
+				return
+			}
+			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			return
+		}
+
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertExpiryRunToPresentation(result.Value()))
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func HouseholdsHandler(w http.ResponseWriter, r *http.Request) {
+	if householdService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		result := householdService.GetAllHouseholds()
+		if !result.IsSuccess() {
+			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertHouseholdsToPresentation(result.Value()))
+
+	case http.MethodPost:
+		var req presentation.CreateHouseholdRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+
+		name, memberIds := presentation.ConvertCreateHouseholdRequestToDomain(req)
+		result := householdService.CreateHousehold(name, memberIds)
+		if !result.IsSuccess() {
+			writeHouseholdError(w, result.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusCreated, presentation.ConvertHouseholdToPresentation(result.Value()))
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// HouseholdByIDHandler handles a household and its members
+// GET returns the household overview of the season, with memberships, rentals and outstanding balance
+func HouseholdByIDHandler(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1.0/households/")
+	if path == "" {
+		presentation.WriteError(w, http.StatusBadRequest, "missing id")
+		return
+	}
+
+	idStr, subresource, _ := strings.Cut(path, "/")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid id format")
+		return
+	}
+	householdId := domain.NewId[membership.Household](id)
+
+	if householdService == nil || householdOverviewService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	if subresource == "members" || strings.HasPrefix(subresource, "members/") {
+		handleHouseholdMembers(w, r, householdId, strings.TrimPrefix(strings.TrimPrefix(subresource, "members"), "/"))
+		return
+	}
+
+	if subresource != "" {
+		presentation.WriteError(w, http.StatusNotFound, "unknown household resource")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		seasonId, err := strconv.ParseInt(r.URL.Query().Get("season"), 10, 64)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "missing season query parameter")
+			return
+		}
+
+		result := householdOverviewService.GetHouseholdOverview(householdId, seasonId)
+		if !result.IsSuccess() {
+			writeHouseholdError(w, result.Error())
+			return
+		}
+		overview, err := presentation.ConvertHouseholdOverviewToPresentation(result.Value())
+		if err != nil {
+			presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusOK, overview)
+
+	case http.MethodDelete:
+		result := householdService.DeleteHousehold(householdId)
+		if !result.IsSuccess() {
+			writeHouseholdError(w, result.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleHouseholdMembers adds a member to the household (POST) or removes one (DELETE /members/{memberId})
+func handleHouseholdMembers(w http.ResponseWriter, r *http.Request, householdId domain.Id[membership.Household], memberIdStr string) {
+	switch r.Method {
+	case http.MethodPost:
+		if memberIdStr != "" {
+			presentation.WriteError(w, http.StatusNotFound, "unknown household resource")
+			return
+		}
+
+		var req presentation.AddHouseholdMemberRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+		if req.MemberId <= 0 {
+			presentation.WriteError(w, http.StatusBadRequest, "memberId is required")
+			return
+		}
+
+		result := householdService.AddMember(householdId, domain.NewId[membership.Member](req.MemberId))
+		if !result.IsSuccess() {
+			writeHouseholdError(w, result.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertHouseholdToPresentation(result.Value()))
+
+	case http.MethodDelete:
+		memberId, err := strconv.ParseInt(memberIdStr, 10, 64)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid member id format")
+			return
+		}
+
+		result := householdService.RemoveMember(householdId, domain.NewId[membership.Member](memberId))
+		if !result.IsSuccess() {
+			writeHouseholdError(w, result.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func FamilyPricingHandler(w http.ResponseWriter, r *http.Request) {
+	if householdService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		result := householdService.GetFamilyPricingScheme()
+		if !result.IsSuccess() {
+			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertFamilyPricingSchemeToPresentation(result.Value()))
+
+	case http.MethodPut:
+		var req presentation.FamilyPricingScheme
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+
+		scheme, err := presentation.ConvertFamilyPricingSchemeToDomain(req)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		result := householdService.UpdateFamilyPricingScheme(scheme)
+		if !result.IsSuccess() {
+			writeHouseholdError(w, result.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertFamilyPricingSchemeToPresentation(result.Value()))
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// MembershipSuggestedPriceHandler suggests the membership price of the season taking the household into account
+// Either memberId, for an existing member, or birthDate and optionally householdId, for a new member, are required
+func MembershipSuggestedPriceHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if householdService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	query := r.URL.Query()
+	seasonId, err := strconv.ParseInt(query.Get("season"), 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "missing season query parameter")
+		return
+	}
+
+	var result result.Result[membership.MembershipPriceSuggestion]
+	if memberIdStr := query.Get("memberId"); memberIdStr != "" {
+		memberId, err := strconv.ParseInt(memberIdStr, 10, 64)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid memberId")
+			return
+		}
+		result = householdService.SuggestMembershipPrice(domain.NewId[membership.Member](memberId), seasonId)
+	} else {
+		var householdId *domain.Id[membership.Household]
+		if householdIdStr := query.Get("householdId"); householdIdStr != "" {
+			id, err := strconv.ParseInt(householdIdStr, 10, 64)
+			if err != nil {
+				presentation.WriteError(w, http.StatusBadRequest, "invalid householdId")
+				return
+			}
+			parsed := domain.NewId[membership.Household](id)
+			householdId = &parsed
+		}
+
+		var birthDate time.Time
+		if birthDateStr := query.Get("birthDate"); birthDateStr != "" {
+			birthDate, err = time.Parse("2006-01-02", birthDateStr)
+			if err != nil {
+				presentation.WriteError(w, http.StatusBadRequest, "invalid birthDate, expected YYYY-MM-DD")
+				return
+			}
+		}
+		result = householdService.SuggestNewMemberPrice(householdId, birthDate, seasonId)
+	}
+
+	if !result.IsSuccess() {
+		writeHouseholdError(w, result.Error())
+		return
+	}
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertMembershipPriceSuggestionToPresentation(result.Value()))
+}
+
+func writeHouseholdError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case errors.NotFoundError:
+		presentation.WriteError(w, http.StatusNotFound, err.Error())
+	case errors.HouseholdError, errors.CurrencyMismatchError:
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// MembershipCategoriesHandler handles the membership categories and their prices
+// GET /api/v1.0/membership-categories?season= lists the categories with their price in the season
+// PUT /api/v1.0/membership-categories/{code}/prices sets the price of the category in a season
+func MembershipCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	if memberService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1.0/membership-categories"), "/")
+	if path == "" {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		seasonId, err := strconv.ParseInt(r.URL.Query().Get("season"), 10, 64)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "missing season query parameter")
+			return
+		}
+
+		result := memberService.GetMembershipCategories(seasonId)
+		if !result.IsSuccess() {
+			writeMembershipCategoryError(w, result.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertMembershipCategoryPricesToPresentation(result.Value()))
+		return
+	}
+
+	code, subResource, _ := strings.Cut(path, "/")
+	if subResource != "prices" {
+		presentation.WriteError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req presentation.SetMembershipCategoryPriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	if req.SeasonId == 0 {
+		presentation.WriteError(w, http.StatusBadRequest, "seasonId is required")
+		return
+	}
+	price, err := presentation.ConvertPriceToDomain(req.Price)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result := memberService.SetMembershipCategoryPrice(membership.MembershipCategoryCode(strings.ToUpper(code)), req.SeasonId, price)
+	if !result.IsSuccess() {
+		writeMembershipCategoryError(w, result.Error())
+		return
+	}
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertMembershipCategoryPriceToPresentation(result.Value()))
+}
+
+func writeMembershipCategoryError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case errors.NotFoundError:
+		presentation.WriteError(w, http.StatusNotFound, err.Error())
+	case errors.MembershipCategoryError:
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// MembershipNumberingHandler reads and replaces the numbering policy of the membership cards
+func MembershipNumberingHandler(w http.ResponseWriter, r *http.Request) {
+	if membershipCardService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		result := membershipCardService.GetNumberingPolicy()
+		if !result.IsSuccess() {
+			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertMembershipNumberingPolicyToPresentation(result.Value()))
+
+	case http.MethodPut:
+		var req presentation.MembershipNumberingPolicy
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+
+		result := membershipCardService.UpdateNumberingPolicy(presentation.ConvertMembershipNumberingPolicyToDomain(req))
+		if !result.IsSuccess() {
+			switch result.Error().(type) {
+			case errors.MembershipNumberingError:
+				presentation.WriteError(w, http.StatusBadRequest, result.Error().Error())
+			default:
+				presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+			}
+			return
+		}
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertMembershipNumberingPolicyToPresentation(result.Value()))
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleMembershipCardPDF prints the membership card of a member
+// GET /api/v1.0/reports/members/{id}/card/pdf?season=
+func handleMembershipCardPDF(w http.ResponseWriter, r *http.Request) {
+	if membershipCardService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	idStr := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1.0/reports/members/"), "/card/pdf")
+	memberId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid member id format")
+		return
+	}
+
+	seasonId, err := strconv.ParseInt(r.URL.Query().Get("season"), 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "missing season query parameter")
+		return
+	}
+
+	result := membershipCardService.GetMembershipCard(domain.NewId[membership.Member](memberId), seasonId)
+	if !result.IsSuccess() {
+		switch result.Error().(type) {
+		case errors.NotFoundError:
+			presentation.WriteError(w, http.StatusNotFound, result.Error().Error())
+		default:
+			presentation.WriteError(w, http.StatusInternalServerError, result.Error().Error())
+		}
+		return
+	}
+
+	card := result.Value()
+	filename := "tessera_" + card.LastName + "_" + card.FirstName + ".pdf"
+	writeMembershipCardsPDF(w, []membership.MembershipCard{card}, card.SeasonCode, filename)
+}
+
+// MembershipCardsPDFHandler prints the cards of every active membership of the season on A4 sheets
+// GET /api/v1.0/reports/membership-cards/pdf?season=
+func MembershipCardsPDFHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if reportService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "report service not initialized")
+		return
+	}
+
+	if membershipCardService == nil || seasonRepo == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	seasonId, err := strconv.ParseInt(r.URL.Query().Get("season"), 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "missing season query parameter")
+		return
+	}
+
+	season := seasonRepo.GetSeasonById(seasonId)
+	if !season.IsSuccess() {
+		switch season.Error().(type) {
+		case errors.NotFoundError:
+			presentation.WriteError(w, http.StatusNotFound, season.Error().Error())
+		default:
+			presentation.WriteError(w, http.StatusInternalServerError, "failed to get season: "+season.Error().Error())
+		}
+		return
+	}
+
+	cards := membershipCardService.GetMembershipCards(seasonId)
+	if !cards.IsSuccess() {
+		presentation.WriteError(w, http.StatusInternalServerError, cards.Error().Error())
+		return
+	}
+
+	seasonCode := season.Value().GetCode()
+	writeMembershipCardsPDF(w, cards.Value(), seasonCode, "tessere_"+seasonCode+".pdf")
+}
+
+func writeMembershipCardsPDF(w http.ResponseWriter, cards []membership.MembershipCard, seasonCode string, filename string) {
+	reportCards := make([]reports.MembershipCard, len(cards))
+	for i, card := range cards {
+		reportCards[i] = reports.MembershipCard{
+			MemberID:         card.MemberId.Value,
+			FirstName:        card.FirstName,
+			LastName:         card.LastName,
+			BirthDate:        card.BirthDate.Format("02/01/2006"),
+			MembershipNumber: card.MembershipNumber,
+			CardCode:         card.CardCode,
+			SeasonCode:       card.SeasonCode,
+			SeasonName:       card.SeasonName,
+			ValidFrom:        card.ValidFrom.Format("02/01/2006"),
+			ValidUntil:       card.ValidUntil.Format("02/01/2006"),
+		}
+		if card.Category != nil {
+			reportCards[i].Category = card.Category.Name
+		}
+	}
+
+	pdfBuffer, err := reportService.GenerateMembershipCardsReport(reportCards, seasonCode)
+	if err != nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "failed to generate PDF: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.Header().Set("Content-Length", strconv.Itoa(pdfBuffer.Len()))
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdfBuffer.Bytes())
+}
+
+// handleMemberDocuments handles the documents of a member
+// GET /api/v1.0/members/{id}/documents lists the documents
+// POST /api/v1.0/members/{id}/documents uploads a document as multipart form (file, type, issuedAt, expiresAt, notes)
+// GET /api/v1.0/members/{id}/documents/{documentId} downloads a document
+// DELETE /api/v1.0/members/{id}/documents/{documentId} deletes a document
+func handleMemberDocuments(w http.ResponseWriter, r *http.Request, id int64, documentIdStr string) {
+	if documentService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	userId := domain.NewId[membership.User](id)
+
+	if documentIdStr == "" {
+		switch r.Method {
+		case http.MethodGet:
+			result := documentService.GetDocuments(userId)
+			if !result.IsSuccess() {
+				writeDocumentError(w, result.Error())
+				return
+			}
+			presentation.WriteJSON(w, http.StatusOK, presentation.ConvertMemberDocumentsToPresentation(result.Value()))
+		case http.MethodPost:
+			handleDocumentUpload(w, r, userId)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	documentId, err := strconv.ParseInt(documentIdStr, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid document id format")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		result := documentService.OpenDocument(userId, domain.NewId[membership.MemberDocument](documentId))
+		if !result.IsSuccess() {
+			writeDocumentError(w, result.Error())
+			return
+		}
+		document := result.Value()
+		defer document.Content.Close()
+
+		w.Header().Set("Content-Type", document.Document.ContentType)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": document.Document.FileName}))
+		w.Header().Set("Content-Length", strconv.FormatInt(document.Document.Size, 10))
+		w.WriteHeader(http.StatusOK)
+		io.Copy(w, document.Content)
+	case http.MethodDelete:
+		result := documentService.DeleteDocument(userId, domain.NewId[membership.MemberDocument](documentId))
+		if !result.IsSuccess() {
+			writeDocumentError(w, result.Error())
+			return
+		}
+		writeDocumentFilesDeletion(w, result.Value())
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func handleDocumentUpload(w http.ResponseWriter, r *http.Request, userId domain.Id[membership.User]) {
+	// Room for the form fields on top of the file
+	r.Body = http.MaxBytesReader(w, r.Body, membership.MaxDocumentSize+1<<20)
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid upload, files cannot be larger than 10 MB: "+err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	issuedAt, err := parseOptionalDate(r.FormValue("issuedAt"))
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid issuedAt, expected YYYY-MM-DD")
+		return
+	}
+	expiresAt, err := parseOptionalDate(r.FormValue("expiresAt"))
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid expiresAt, expected YYYY-MM-DD")
+		return
+	}
+
+	// The content type is sniffed from the content rather than trusted from the client
+	sniff := make([]byte, 512)
+	n, _ := io.ReadFull(file, sniff)
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "failed to read file: "+err.Error())
+		return
+	}
+
+	upload := membership.DocumentUpload{
+		Type:        membership.DocumentType(strings.ToUpper(r.FormValue("type"))),
+		FileName:    header.Filename,
+		ContentType: http.DetectContentType(sniff[:n]),
+		Size:        header.Size,
+		IssuedAt:    issuedAt,
+		ExpiresAt:   expiresAt,
+		Notes:       r.FormValue("notes"),
+	}
+
+	result := documentService.UploadDocument(userId, upload, file)
+	if !result.IsSuccess() {
+		writeDocumentError(w, result.Error())
+		return
+	}
+	presentation.WriteJSON(w, http.StatusCreated, presentation.ConvertMemberDocumentToPresentation(result.Value()))
+}
+
+// MissingCertificatesHandler lists the members of the season whose medical certificate is missing or expired
+// GET /api/v1.0/members/missing-certificates?season=
+func MissingCertificatesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if documentService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	seasonId, err := strconv.ParseInt(r.URL.Query().Get("season"), 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "missing season query parameter")
+		return
+	}
+
+	result := documentService.GetMissingCertificates(seasonId, time.Now())
+	if !result.IsSuccess() {
+		writeDocumentError(w, result.Error())
+		return
+	}
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertCertificateChecksToPresentation(result.Value()))
+}
+
+// writeDocumentFilesDeletion answers with no content when every file was deleted, otherwise the files left
+// behind are logged and listed so that they can be deleted by hand
+func writeDocumentFilesDeletion(w http.ResponseWriter, deletion membership.DocumentFilesDeletion) {
+	if len(deletion.UndeletedFiles) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	for _, file := range deletion.UndeletedFiles {
+		log.Printf("⚠️ Failed to delete document file %s: %s", file.StorageKey, file.Reason)
+	}
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertDocumentFilesDeletionToPresentation(deletion))
+}
+
+func writeDocumentError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case errors.NotFoundError:
+		presentation.WriteError(w, http.StatusNotFound, err.Error())
+	case errors.DocumentError:
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func parseOptionalDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+// handleMemberConsents returns the consents of a member, or records new ones granting or revoking them
+func handleMemberConsents(w http.ResponseWriter, r *http.Request, id int64) {
+	if consentService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	userId := domain.NewId[membership.User](id)
+
+	switch r.Method {
+	case http.MethodGet:
+		result := consentService.GetConsents(userId)
+		if !result.IsSuccess() {
+			writeConsentError(w, result.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertMemberConsentsToPresentation(result.Value()))
+	case http.MethodPost:
+		var req presentation.RecordConsentsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+
+		result := consentService.RecordConsents(userId, presentation.ConvertConsentsToDomain(req.Consents))
+		if !result.IsSuccess() {
+			writeConsentError(w, result.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusCreated, presentation.ConvertMemberConsentsToPresentation(result.Value()))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// PrivacyPoliciesHandler lists the published privacy policies, or publishes a new version
+func PrivacyPoliciesHandler(w http.ResponseWriter, r *http.Request) {
+	if consentService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		result := consentService.GetPolicies()
+		if !result.IsSuccess() {
+			writeConsentError(w, result.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertPrivacyPoliciesToPresentation(result.Value()))
+	case http.MethodPost:
+		var req presentation.PrivacyPolicy
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+
+		result := consentService.PublishPolicy(membership.PrivacyPolicy{Version: req.Version, Text: req.Text})
+		if !result.IsSuccess() {
+			writeConsentError(w, result.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusCreated, presentation.ConvertPrivacyPolicyToPresentation(result.Value()))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// CurrentPrivacyPolicyHandler returns the policy new consents must refer to
+func CurrentPrivacyPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if consentService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	result := consentService.GetCurrentPolicy()
+	if !result.IsSuccess() {
+		writeConsentError(w, result.Error())
+		return
+	}
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertPrivacyPolicyToPresentation(result.Value()))
+}
 
-	// Convert to report format
-	facilityRentals := make([]reports.FacilityRental, len(rentedFacilities))
-	for i, rf := range rentedFacilities {
-		facility := rf.GetFacility()
-		boatName := ""
-		if rf.GetType() == facilityrental.BoatFacility {
-			if boatFacility, ok := rf.(facilityrental.RentedFacilityWithBoat); ok {
-				boatName = boatFacility.BoatInfo.Name
-			}
+func writeConsentError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case errors.NotFoundError:
+		presentation.WriteError(w, http.StatusNotFound, err.Error())
+	case errors.ConsentError:
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// DunningHandler returns what each member owes in a season and where their reminders stand today
+// GET /api/v1.0/dunning?season={id}
+func DunningHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if dunningService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	seasonId, err := strconv.ParseInt(r.URL.Query().Get("season"), 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid season ID format")
+		return
+	}
+
+	result := dunningService.GetDashboard(seasonId, time.Now())
+	if !result.IsSuccess() {
+		writeDunningError(w, result.Error())
+		return
+	}
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertDunningDashboardToPresentation(result.Value()))
+}
+
+// UnpaidMembersHandler returns the members who have not paid their membership or their services
+// GET /api/v1.0/dunning/unpaid-members?for=membership|services
+func UnpaidMembersHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if dunningService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	var target payment.PaymentTarget
+	switch strings.ToLower(r.URL.Query().Get("for")) {
+	case "", "membership":
+		target = payment.MembershipTarget
+	case "services":
+		target = payment.FacilityTarget
+	default:
+		presentation.WriteError(w, http.StatusBadRequest, "invalid for parameter, expected membership or services")
+		return
+	}
+
+	result := dunningService.GetUnpaidMembers(target)
+	if !result.IsSuccess() {
+		writeDunningError(w, result.Error())
+		return
+	}
+	members, err := presentation.ConvertMembersToPresentation(result.Value())
+	if err != nil {
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	presentation.WriteJSON(w, http.StatusOK, members)
+}
+
+// RemindersHandler lists the reminder letters sent, or sends the next ones
+// GET /api/v1.0/dunning/reminders?season={id}&memberId={id}&sentOn=YYYY-MM-DD
+// POST /api/v1.0/dunning/reminders records the letters dated today, see SendRemindersRequest
+func RemindersHandler(w http.ResponseWriter, r *http.Request) {
+	if dunningService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		criteria, err := parseReminderCriteria(r)
+		if err != nil {
+			writeDunningError(w, err)
+			return
 		}
 
-		facilityRentals[i] = reports.FacilityRental{
-			ID:                      rf.GetId().Value,
-			FacilityIdentifier:      facility.Identifier,
-			FacilityName:            string(facility.FacilityType.FacilityName),
-			FacilityTypeDescription: facility.FacilityType.Description,
-			RentedAt:                rf.GetValidity().FromDate.Format("02/01/2006"),
-			ExpiresAt:               rf.GetValidity().ToDate.Format("02/01/2006"),
-			Price:                   rf.GetPrice(),
-			Paid:                    rf.GetPayment().GetStatus() == payment.Paid,
-			BoatName:                boatName,
+		result := dunningService.GetReminders(criteria)
+		if !result.IsSuccess() {
+			writeDunningError(w, result.Error())
+			return
 		}
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertReminderLettersToPresentation(result.Value()))
+
+	case http.MethodPost:
+		var req presentation.SendRemindersRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return
+		}
+
+		result := dunningService.SendReminders(req.SeasonId, req.MemberIds, time.Now())
+		if !result.IsSuccess() {
+			writeDunningError(w, result.Error())
+			return
+		}
+		presentation.WriteJSON(w, http.StatusCreated, presentation.ConvertReminderLettersToPresentation(result.Value()))
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
 	}
+}
 
-	// Get season code
-	seasonResult := seasonRepo.GetSeasonById(seasonId)
-	if !seasonResult.IsSuccess() {
-		presentation.WriteError(w, http.StatusInternalServerError, "failed to get season: "+seasonResult.Error().Error())
+// ReminderByIDHandler returns a reminder letter
+// GET /api/v1.0/dunning/reminders/{id} returns the letter
+// GET /api/v1.0/dunning/reminders/{id}/pdf downloads it, printed as it was sent
+func ReminderByIDHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
-	seasonCode := seasonResult.Value().GetCode()
 
-	// Generate PDF
-	pdfBuffer, err := reportService.GenerateMemberDetailReport(memberDetail, facilityRentals, seasonCode)
+	if dunningService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	idStr, subresource, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/v1.0/dunning/reminders/"), "/")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid reminder letter id format")
+		return
+	}
+	if subresource != "" && subresource != "pdf" {
+		presentation.WriteError(w, http.StatusNotFound, "unknown reminder letter resource")
+		return
+	}
+
+	result := dunningService.GetReminder(id)
+	if !result.IsSuccess() {
+		writeDunningError(w, result.Error())
+		return
+	}
+	letter := result.Value()
+
+	if subresource == "" {
+		presentation.WriteJSON(w, http.StatusOK, presentation.ConvertReminderLetterToPresentation(letter))
+		return
+	}
+
+	filename := "sollecito_" + strings.ToLower(string(letter.Level)) + "_" + strconv.FormatInt(letter.MemberId.Value, 10) + "_" + letter.SentOn.Format("2006-01-02") + ".pdf"
+	writeReminderLettersPDF(w, []club.ReminderLetter{letter}, filename)
+}
+
+// ReminderLettersPDFHandler downloads the letters sent in a season, one per page, to be printed and mailed together
+// GET /api/v1.0/reports/reminder-letters/pdf?season={id}&sentOn=YYYY-MM-DD
+func ReminderLettersPDFHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if dunningService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	criteria, err := parseReminderCriteria(r)
+	if err != nil {
+		writeDunningError(w, err)
+		return
+	}
+	if criteria.SeasonId == 0 {
+		presentation.WriteError(w, http.StatusBadRequest, "season is required")
+		return
+	}
+
+	result := dunningService.GetReminders(criteria)
+	if !result.IsSuccess() {
+		writeDunningError(w, result.Error())
+		return
+	}
+	if len(result.Value()) == 0 {
+		presentation.WriteError(w, http.StatusNotFound, "no reminder letters found")
+		return
+	}
+
+	filename := "solleciti_" + strconv.FormatInt(criteria.SeasonId, 10)
+	if criteria.SentOn != nil {
+		filename += "_" + criteria.SentOn.Format("2006-01-02")
+	}
+	writeReminderLettersPDF(w, result.Value(), filename+".pdf")
+}
+
+func writeReminderLettersPDF(w http.ResponseWriter, letters []club.ReminderLetter, filename string) {
+	if reportService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "report service not initialized")
+		return
+	}
+
+	report := make([]reports.ReminderLetter, len(letters))
+	for i, letter := range letters {
+		report[i] = convertReminderLetterToReport(letter)
+	}
+	pdfBuffer, err := reportService.GenerateReminderLettersReport(report)
 	if err != nil {
 		presentation.WriteError(w, http.StatusInternalServerError, "failed to generate PDF: "+err.Error())
 		return
 	}
 
-	// Set headers for PDF download
-	filename := "dettaglio_socio_" + memberDetails.User.LastName + "_" + memberDetails.User.FirstName + ".pdf"
 	w.Header().Set("Content-Type", "application/pdf")
 	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
 	w.Header().Set("Content-Length", strconv.Itoa(pdfBuffer.Len()))
-
-	// Write PDF to response
 	w.WriteHeader(http.StatusOK)
 	w.Write(pdfBuffer.Bytes())
 }
+
+// parseReminderCriteria reads the season, memberId and sentOn query parameters, all optional
+func parseReminderCriteria(r *http.Request) (club.ReminderCriteria, error) {
+	query := r.URL.Query()
+	criteria := club.ReminderCriteria{}
+	if seasonStr := query.Get("season"); seasonStr != "" {
+		seasonId, err := strconv.ParseInt(seasonStr, 10, 64)
+		if err != nil {
+			return criteria, errors.DunningError{Description: "invalid season ID format"}
+		}
+		criteria.SeasonId = seasonId
+	}
+	if memberIdStr := query.Get("memberId"); memberIdStr != "" {
+		memberId, err := strconv.ParseInt(memberIdStr, 10, 64)
+		if err != nil {
+			return criteria, errors.DunningError{Description: "invalid memberId"}
+		}
+		criteria.MemberId = &memberId
+	}
+	sentOn, err := parseOptionalDate(query.Get("sentOn"))
+	if err != nil {
+		return criteria, errors.DunningError{Description: "invalid sentOn date, expected YYYY-MM-DD"}
+	}
+	criteria.SentOn = sentOn
+	return criteria, nil
+}
+
+// convertReminderLetterToReport writes the letter in Italian, in a tone that gets firmer at each level
+func convertReminderLetterToReport(letter club.ReminderLetter) reports.ReminderLetter {
+	issuer := clubConfig.Issuer()
+	deadline := strconv.Itoa(clubConfig.DaysBetweenReminders)
+	season := letter.SeasonCode
+
+	report := reports.ReminderLetter{
+		Place: clubConfig.City,
+		Date:  letter.SentOn.Format("02/01/2006"),
+		Sender: reports.FiscalParty{
+			Name:    issuer.Name,
+			TaxCode: issuer.TaxCode,
+			Address: fiscalAddressLines(issuer.Address),
+			Email:   issuer.Email,
+		},
+		Recipient: reports.FiscalParty{
+			Name:  strings.TrimSpace(letter.Recipient.FirstName + " " + letter.Recipient.LastName),
+			Email: letter.Recipient.Email,
+		},
+		Lines: make([]reports.ReminderLetterLine, len(letter.Items)),
+		Total: letter.Total(),
+	}
+	if address := letter.Recipient.Address; address != nil {
+		report.Recipient.Address = fiscalAddressLines(payment.FiscalAddress{
+			Street:       address.Street,
+			StreetNumber: address.Number,
+			ZipCode:      address.ZipCode,
+			City:         address.City,
+			Country:      address.Country,
+		})
+	}
+	for i, item := range letter.Items {
+		report.Lines[i] = reports.ReminderLetterLine{
+			Description: item.Description,
+			Due:         item.Due,
+			Balance:     item.Balance,
+		}
+	}
+
+	switch letter.Level {
+	case club.FirstReminder:
+		report.Title = "Primo sollecito"
+		report.Subject = "Sollecito di pagamento - stagione " + season
+		report.Paragraphs = []string{
+			"Gentile socio,",
+			"da un controllo della nostra contabilità risulta che i seguenti importi relativi alla stagione " + season + " non sono ancora stati saldati.",
+		}
+		report.Closing = []string{
+			"La invitiamo a provvedere al pagamento entro " + deadline + " giorni dalla data della presente, presso la segreteria del circolo o tramite bonifico bancario.",
+			"Se ha già provveduto al pagamento, La preghiamo di non tenere conto di questa comunicazione.",
+		}
+	case club.SecondReminder:
+		report.Title = "Secondo sollecito"
+		report.Subject = "Secondo sollecito di pagamento - stagione " + season
+		report.Paragraphs = []string{
+			"Gentile socio,",
+			"nonostante il nostro precedente sollecito, risultano ancora da saldare i seguenti importi relativi alla stagione " + season + ".",
+		}
+		report.Closing = []string{
+			"La invitiamo a regolarizzare la Sua posizione entro " + deadline + " giorni dalla data della presente.",
+			"In mancanza di pagamento il circolo procederà con una diffida formale.",
+		}
+	case club.FormalNotice:
+		report.Title = "Diffida ad adempiere"
+		report.Subject = "Diffida ad adempiere - stagione " + season
+		report.Paragraphs = []string{
+			"Gentile socio,",
+			"nonostante i precedenti solleciti, risultano ancora da saldare i seguenti importi relativi alla stagione " + season + ".",
+		}
+		report.Closing = []string{
+			"Con la presente La diffidiamo formalmente a provvedere al pagamento entro " + deadline + " giorni dalla data della presente.",
+			"Decorso inutilmente tale termine, il Consiglio Direttivo potrà deliberare la Sua esclusione dal circolo ai sensi dello statuto, fatto salvo il recupero delle somme dovute.",
+		}
+	}
+	return report
+}
+
+func writeDunningError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case errors.NotFoundError:
+		presentation.WriteError(w, http.StatusNotFound, err.Error())
+	case errors.DunningError:
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// notifyMembers logs the notifications that could not be queued, the operation that triggered them succeeded anyway
+func notifyMembers(event string, queued result.Result[[]notification.OutboxMessage]) {
+	if !queued.IsSuccess() {
+		log.Printf("⚠️ Failed to notify %s: %v", event, queued.Error())
+	}
+}
+
+// notifyWaitingListChanges notifies the members who moved in the waiting list since the previous state was read
+func notifyWaitingListChanges(previous result.Result[facilityrental.WaitingList], facilityTypeId domain.Id[facilityrental.FacilityType]) {
+	if !previous.IsSuccess() {
+		log.Printf("⚠️ Failed to notify waiting list changes: %v", previous.Error())
+		return
+	}
+	current := waitingListService.GetWaitingList(facilityTypeId)
+	if !current.IsSuccess() {
+		log.Printf("⚠️ Failed to notify waiting list changes: %v", current.Error())
+		return
+	}
+	notifyMembers("waiting list changes", memberNotificationService.WaitingListChanged(previous.Value(), current.Value()))
+}
+
+// waitingListsOf reads the waiting lists the members are queued in, before they leave or move in them
+func waitingListsOf(memberIds ...domain.Id[membership.Member]) map[domain.Id[facilityrental.FacilityType]]result.Result[facilityrental.WaitingList] {
+	waitingLists := map[domain.Id[facilityrental.FacilityType]]result.Result[facilityrental.WaitingList]{}
+	if memberNotificationService == nil || waitingListService == nil || rentalService == nil {
+		return waitingLists
+	}
+	for _, facilityType := range rentalService.GetFacilitiesCatalog() {
+		waitingList := waitingListService.GetWaitingList(facilityType.Id)
+		if !waitingList.IsSuccess() {
+			waitingLists[facilityType.Id] = waitingList
+			continue
+		}
+		for _, entry := range waitingList.Value().Entries {
+			if slices.Contains(memberIds, entry.MemberId) {
+				waitingLists[facilityType.Id] = waitingList
+				break
+			}
+		}
+	}
+	return waitingLists
+}
+
+// notifyAllWaitingListChanges notifies the members who moved in any of the waiting lists read before a change
+func notifyAllWaitingListChanges(previous map[domain.Id[facilityrental.FacilityType]]result.Result[facilityrental.WaitingList]) {
+	for facilityTypeId, waitingList := range previous {
+		notifyWaitingListChanges(waitingList, facilityTypeId)
+	}
+}
+
+// OutboxHandler lists the emails sent or waiting to be sent to the members
+// GET /api/v1.0/notifications/outbox?status=PENDING|SENT|FAILED&memberId={id}&limit={n}
+func OutboxHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if notificationService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	query := r.URL.Query()
+	criteria := notification.OutboxCriteria{}
+	if statusStr := query.Get("status"); statusStr != "" {
+		status := notification.OutboxStatus(strings.ToUpper(statusStr))
+		if status != notification.Pending && status != notification.Sent && status != notification.Failed && status != notification.Cancelled {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid status, expected PENDING, SENT, FAILED or CANCELLED")
+			return
+		}
+		criteria.Status = &status
+	}
+	if memberIdStr := query.Get("memberId"); memberIdStr != "" {
+		memberId, err := strconv.ParseInt(memberIdStr, 10, 64)
+		if err != nil {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid memberId")
+			return
+		}
+		criteria.MemberId = &memberId
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			presentation.WriteError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		criteria.Limit = limit
+	}
+
+	result := notificationService.GetMessages(criteria)
+	if !result.IsSuccess() {
+		writeNotificationError(w, result.Error())
+		return
+	}
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertOutboxMessagesToPresentation(result.Value()))
+}
+
+// OutboxMessageHandler queues again an email whose attempts all failed
+// POST /api/v1.0/notifications/outbox/{id}/retry
+func OutboxMessageHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if notificationService == nil {
+		presentation.WriteError(w, http.StatusInternalServerError, "service not initialized")
+		return
+	}
+
+	idStr, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/v1.0/notifications/outbox/"), "/")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		presentation.WriteError(w, http.StatusBadRequest, "invalid email id format")
+		return
+	}
+	if action != "retry" {
+		presentation.WriteError(w, http.StatusNotFound, "unknown email action")
+		return
+	}
+
+	result := notificationService.RetryMessage(id, time.Now())
+	if !result.IsSuccess() {
+		writeNotificationError(w, result.Error())
+		return
+	}
+	presentation.WriteJSON(w, http.StatusOK, presentation.ConvertOutboxMessageToPresentation(result.Value()))
+}
+
+func writeNotificationError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case errors.NotFoundError:
+		presentation.WriteError(w, http.StatusNotFound, err.Error())
+	case errors.NotificationError:
+		presentation.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		presentation.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -14,17 +14,51 @@ func NewRouter() http.Handler {
 	// Protected routes - auth required
 	mux.HandleFunc("/api/v1.0/members", MembersHandler)
 	mux.HandleFunc("/api/v1.0/members/", MemberByIDHandler)
+	mux.HandleFunc("/api/v1.0/members/duplicates", MemberDuplicatesHandler)
+	mux.HandleFunc("/api/v1.0/members/missing-certificates", MissingCertificatesHandler)
 	mux.HandleFunc("/api/v1.0/memberships", MembershipsHandler)
+	mux.HandleFunc("/api/v1.0/memberships/suggested-price", MembershipSuggestedPriceHandler)
+	mux.HandleFunc("/api/v1.0/membership-categories", MembershipCategoriesHandler)
+	mux.HandleFunc("/api/v1.0/membership-categories/", MembershipCategoriesHandler)
+	mux.HandleFunc("/api/v1.0/households", HouseholdsHandler)
+	mux.HandleFunc("/api/v1.0/households/", HouseholdByIDHandler)
+	mux.HandleFunc("/api/v1.0/family-pricing", FamilyPricingHandler)
+	mux.HandleFunc("/api/v1.0/membership-numbering", MembershipNumberingHandler)
+	mux.HandleFunc("/api/v1.0/privacy-policies", PrivacyPoliciesHandler)
+	mux.HandleFunc("/api/v1.0/privacy-policies/current", CurrentPrivacyPolicyHandler)
+	mux.HandleFunc("/api/v1.0/tax-codes/suggestion", TaxCodeSuggestionHandler)
+	mux.HandleFunc("/api/v1.0/seasons", SeasonsHandler)
+	mux.HandleFunc("/api/v1.0/seasons/", SeasonByIDHandler)
 	mux.HandleFunc("/api/v1.0/facilities/catalog", FacilitiesCatalogHandler)
 	mux.HandleFunc("/api/v1.0/facilities", FacilitiesByTypeHandler)
 	mux.HandleFunc("/api/v1.0/facilities/rented/", RentedFacilityByIDHandler)
 	mux.HandleFunc("/api/v1.0/facilities/rented", RentedFacilitiesHandler)
 	mux.HandleFunc("/api/v1.0/facilities/waiting-list", WaitingListHandler)
+	mux.HandleFunc("/api/v1.0/facilities/preemptions", PreemptionsHandler)
 	mux.HandleFunc("/api/v1.0/facilities/suggested-price", SuggestedPriceHandler)
 	mux.HandleFunc("/api/v1.0/payments", PaymentsHandler)
 	mux.HandleFunc("/api/v1.0/payments/", PaymentByIDHandler)
+	mux.HandleFunc("/api/v1.0/cash-register", CashRegisterHandler)
+	mux.HandleFunc("/api/v1.0/cash-register/", CashRegisterDayHandler)
+	mux.HandleFunc("/api/v1.0/bank-statements", BankStatementsHandler)
+	mux.HandleFunc("/api/v1.0/bank-statements/", BankStatementByIDHandler)
+	mux.HandleFunc("/api/v1.0/bank-statements/matches", BankMatchesHandler)
+	mux.HandleFunc("/api/v1.0/fiscal-documents", FiscalDocumentsHandler)
+	mux.HandleFunc("/api/v1.0/fiscal-documents/", FiscalDocumentByIDHandler)
+	mux.HandleFunc("/api/v1.0/fiscal-documents/electronic-invoices", ElectronicInvoicesHandler)
+	mux.HandleFunc("/api/v1.0/dunning", DunningHandler)
+	mux.HandleFunc("/api/v1.0/dunning/unpaid-members", UnpaidMembersHandler)
+	mux.HandleFunc("/api/v1.0/dunning/reminders", RemindersHandler)
+	mux.HandleFunc("/api/v1.0/dunning/reminders/", ReminderByIDHandler)
+	mux.HandleFunc("/api/v1.0/notifications/outbox", OutboxHandler)
+	mux.HandleFunc("/api/v1.0/notifications/outbox/", OutboxMessageHandler)
+	mux.HandleFunc("/api/v1.0/admin/membership-expiry", MembershipExpiryHandler)
 	mux.HandleFunc("/api/v1.0/reports/members/list/pdf", MemberListPDFHandler)
 	mux.HandleFunc("/api/v1.0/reports/members/", MemberDetailPDFHandler)
+	mux.HandleFunc("/api/v1.0/reports/membership-cards/pdf", MembershipCardsPDFHandler)
+	mux.HandleFunc("/api/v1.0/reports/cash-register/pdf", CashRegisterPDFHandler)
+	mux.HandleFunc("/api/v1.0/reports/cash-register/csv", CashRegisterCSVHandler)
+	mux.HandleFunc("/api/v1.0/reports/reminder-letters/pdf", ReminderLettersPDFHandler)
 
 	router := cors(mux)
 	// router = conditionalAuthMiddleware(router)
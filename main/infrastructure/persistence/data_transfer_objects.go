@@ -13,6 +13,7 @@ type GetMemberByIdQueryResult struct {
 	DateOfBirth         time.Time       `json:"date_of_birth"`
 	Email               sql.NullString  `json:"email"`
 	TaxCode             sql.NullString  `json:"tax_code"`
+	Billing             BillingColumns  `json:"billing"`
 	PhoneNumbers        json.RawMessage `json:"phone_numbers"`
 	Addresses           json.RawMessage `json:"addresses"`
 	Memberships         json.RawMessage `json:"memberships"`
@@ -21,6 +22,36 @@ type GetMemberByIdQueryResult struct {
 	BirthPlaceStreet    sql.NullString  `json:"birth_place_street"`
 	BirthPlaceStreetNum sql.NullString  `json:"birth_place_street_number"`
 	BirthPlaceZipCode   sql.NullString  `json:"birth_place_zip_code"`
+	Guardians           json.RawMessage `json:"guardians"`
+	HouseholdID         sql.NullInt64   `json:"household_id"`
+	Consents            json.RawMessage `json:"consents"`
+}
+
+// BillingColumns are the billing details of the members table, all NULL unless the member is billed as a business
+type BillingColumns struct {
+	CompanyName   sql.NullString
+	VATNumber     sql.NullString
+	RecipientCode sql.NullString
+	PEC           sql.NullString
+}
+
+// MembershipCategoryDetail is the category of a membership period, stored as JSON in the member queries
+type MembershipCategoryDetail struct {
+	ID              int64  `json:"id"`
+	Code            string `json:"code"`
+	Name            string `json:"name"`
+	PaymentRequired bool   `json:"payment_required"`
+}
+
+// TransactionDetail is an element of the transactions column of the ledger views
+type TransactionDetail struct {
+	ID            int64       `json:"id"`
+	Type          string      `json:"type"`
+	Amount        float64     `json:"amount"`
+	Currency      string      `json:"currency"`
+	PaidAt        PgTimestamp `json:"paid_at"`
+	PaymentMethod string      `json:"payment_method"`
+	Notes         *string     `json:"notes"`
 }
 
 type GetAllMembersQueryResult struct {
@@ -35,9 +66,9 @@ type GetAllMembersQueryResult struct {
 	SeasonEndsAt           *time.Time `json:"season_ends_at"`
 	ExclusionDeliberatedAt *time.Time `json:"exclusion_deliberated_at"`
 	Price                  *float64   `json:"price"`
-	AmountPaid             *float64   `json:"amount_paid"`
-	PaidAt                 *time.Time `json:"paid_at"`
 	Currency               *string    `json:"currency"`
+	Transactions           []byte     `json:"transactions"`
+	Category               []byte     `json:"category"`
 }
 
 type GetMembersBySeasonQueryResult struct {
@@ -53,11 +84,46 @@ type GetMembersBySeasonQueryResult struct {
 	SeasonEndsAt           time.Time      `json:"season_ends_at"`
 	ExclusionDeliberatedAt *time.Time     `json:"exclusion_deliberated_at"`
 	Price                  *float64       `json:"price"`
-	AmountPaid             *float64       `json:"amount_paid"`
-	PaidAt                 *time.Time     `json:"paid_at"`
-	Currency               *string        `json:"currency"`
+	Currency               sql.NullString `json:"currency"`
+	Transactions           []byte         `json:"transactions"`
 	HasRentedFacilities    bool           `json:"has_rented_facilities"`
 	HasUnpaidFacilities    bool           `json:"has_unpaid_facilities"`
+	HasFlaggedRentals      bool           `json:"has_flagged_rentals"`
+	Category               []byte         `json:"category"`
+}
+
+// SearchMembersQueryResult holds a row of the search query
+// Member columns are NULL when the requested page is empty, leaving only the total
+type SearchMembersQueryResult struct {
+	Total                  int            `json:"total"`
+	MemberID               sql.NullInt64  `json:"member_id"`
+	FirstName              sql.NullString `json:"first_name"`
+	LastName               sql.NullString `json:"last_name"`
+	Email                  sql.NullString `json:"email"`
+	DateOfBirth            sql.NullTime   `json:"date_of_birth"`
+	TaxCode                sql.NullString `json:"tax_code"`
+	MembershipNumber       *int64         `json:"membership_number"`
+	SeasonStartsAt         sql.NullTime   `json:"season_starts_at"`
+	SeasonEndsAt           sql.NullTime   `json:"season_ends_at"`
+	ExclusionDeliberatedAt *time.Time     `json:"exclusion_deliberated_at"`
+	Price                  *float64       `json:"price"`
+	Currency               sql.NullString `json:"currency"`
+	MembershipStatus       sql.NullString `json:"membership_status"`
+	Transactions           []byte         `json:"transactions"`
+	HasRentedFacilities    sql.NullBool   `json:"has_rented_facilities"`
+	HasUnpaidFacilities    sql.NullBool   `json:"has_unpaid_facilities"`
+	HasFlaggedRentals      sql.NullBool   `json:"has_flagged_rentals"`
+	Category               []byte         `json:"category"`
+}
+
+type GetUsersForDuplicateCheckQueryResult struct {
+	MemberID     int64          `json:"member_id"`
+	FirstName    string         `json:"first_name"`
+	LastName     string         `json:"last_name"`
+	DateOfBirth  time.Time      `json:"date_of_birth"`
+	Email        sql.NullString `json:"email"`
+	TaxCode      sql.NullString `json:"tax_code"`
+	PhoneNumbers []string       `json:"phone_numbers"`
 }
 
 type GetRentedFacilitiesByMemberQueryResult struct {
@@ -65,6 +131,7 @@ type GetRentedFacilitiesByMemberQueryResult struct {
 	RentedAt           time.Time  `json:"rented_at"`
 	ExpiresAt          time.Time  `json:"expires_at"`
 	Price              float64    `json:"price"`
+	Currency           string     `json:"currency"`
 	DiscountApplied    bool       `json:"discount_applied"`
 	FacilityID         int64      `json:"facility_id"`
 	FacilityIdentifier string     `json:"facility_identifier"`
@@ -86,10 +153,120 @@ type GetRentedFacilitiesByMemberQueryResult struct {
 	LeerboardColor     *string    `json:"leerboard_color"`
 	LeerboardType      *string    `json:"leerboard_type"`
 	LeerboardLength    *float64   `json:"leerboard_length_meters"`
-	PaymentID          *int64     `json:"payment_id"`
-	PaymentAmount      *float64   `json:"payment_amount"`
-	PaymentCurrency    *string    `json:"payment_currency"`
-	PaymentPaidAt      *time.Time `json:"payment_paid_at"`
-	PaymentMethod      *string    `json:"payment_method"`
-	PaymentNotes       *string    `json:"payment_notes"`
+	FlaggedAt          *time.Time `json:"flagged_at"`
+	FlagReason         *string    `json:"flag_reason"`
+	Transactions       []byte     `json:"transactions"`
+}
+
+type GetRenewableRentedFacilitiesQueryResult struct {
+	MemberID               int64                                  `json:"member_id"`
+	FirstName              string                                 `json:"first_name"`
+	LastName               string                                 `json:"last_name"`
+	FacilityRentedInTarget bool                                   `json:"facility_rented_in_target"`
+	Rental                 GetRentedFacilitiesByMemberQueryResult `json:"rental"`
+}
+
+// MembershipExpiryRunDetails is stored as JSON in membership_expiry_runs.details
+type MembershipExpiryRunDetails struct {
+	ExpiredMemberships []ExpiredMembershipDetail `json:"expired_memberships"`
+	FlaggedRentals     []FlaggedRentalDetail     `json:"flagged_rentals"`
+}
+
+type ExpiredMembershipDetail struct {
+	PeriodID         int64  `json:"period_id"`
+	MemberID         int64  `json:"member_id"`
+	FirstName        string `json:"first_name"`
+	LastName         string `json:"last_name"`
+	MembershipNumber int64  `json:"membership_number"`
+	SeasonID         int64  `json:"season_id"`
+}
+
+type FlaggedRentalDetail struct {
+	RentedFacilityID   int64  `json:"rented_facility_id"`
+	MemberID           int64  `json:"member_id"`
+	FacilityIdentifier string `json:"facility_identifier"`
+	SeasonID           int64  `json:"season_id"`
+	Reason             string `json:"reason"`
+}
+
+type GetHouseholdQueryResult struct {
+	HouseholdID int64           `json:"id"`
+	Name        string          `json:"name"`
+	Members     json.RawMessage `json:"members"`
+}
+
+type HouseholdMemberDetail struct {
+	MemberID    int64   `json:"member_id"`
+	FirstName   string  `json:"first_name"`
+	LastName    string  `json:"last_name"`
+	DateOfBirth string  `json:"date_of_birth"`
+	Email       *string `json:"email"`
+}
+
+// FiscalAddressDetail is the address of the issuer or the recipient of a fiscal document
+type FiscalAddressDetail struct {
+	Street       string `json:"street"`
+	StreetNumber string `json:"street_number"`
+	ZipCode      string `json:"zip_code"`
+	City         string `json:"city"`
+	Province     string `json:"province"`
+	Country      string `json:"country"`
+}
+
+// FiscalIssuerDetail is stored as JSON in fiscal_documents.issuer
+type FiscalIssuerDetail struct {
+	Name             string              `json:"name"`
+	TaxCode          string              `json:"tax_code"`
+	VATNumber        string              `json:"vat_number"`
+	Address          FiscalAddressDetail `json:"address"`
+	Email            string              `json:"email"`
+	VATExemptionNote string              `json:"vat_exemption_note"`
+	RentalVATRate    float64             `json:"rental_vat_rate"`
+}
+
+// FiscalRecipientDetail is stored as JSON in fiscal_documents.recipient
+type FiscalRecipientDetail struct {
+	MemberID      int64               `json:"member_id"`
+	FirstName     string              `json:"first_name"`
+	LastName      string              `json:"last_name"`
+	TaxCode       string              `json:"tax_code"`
+	CompanyName   string              `json:"company_name,omitempty"`
+	VATNumber     string              `json:"vat_number,omitempty"`
+	RecipientCode string              `json:"recipient_code,omitempty"`
+	PEC           string              `json:"pec,omitempty"`
+	Address       FiscalAddressDetail `json:"address"`
+}
+
+// FiscalDocumentLineDetail is an element of the lines column of the fiscal documents query
+type FiscalDocumentLineDetail struct {
+	PaymentID     *int64  `json:"payment_id"`
+	Description   string  `json:"description"`
+	PaidOn        string  `json:"paid_on"`
+	PaymentMethod string  `json:"payment_method"`
+	Amount        float64 `json:"amount"`
+	VATRate       float64 `json:"vat_rate"`
+}
+
+// ReminderAddressDetail is the address a reminder letter was sent to
+type ReminderAddressDetail struct {
+	Country string `json:"country"`
+	City    string `json:"city"`
+	ZipCode string `json:"zip_code"`
+	Street  string `json:"street"`
+	Number  string `json:"number"`
+}
+
+// ReminderRecipientDetail is stored as JSON in reminder_letters.recipient
+type ReminderRecipientDetail struct {
+	FirstName string                 `json:"first_name"`
+	LastName  string                 `json:"last_name"`
+	Email     string                 `json:"email"`
+	Address   *ReminderAddressDetail `json:"address"`
+}
+
+// ReminderItemDetail is an element of reminder_letters.items
+type ReminderItemDetail struct {
+	Description string  `json:"description"`
+	Due         float64 `json:"due"`
+	Balance     float64 `json:"balance"`
 }
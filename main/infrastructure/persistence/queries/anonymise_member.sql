@@ -0,0 +1,16 @@
+UPDATE members
+SET
+    first_name = $2,
+    last_name = $3,
+    date_of_birth = $4,
+    email = NULL,
+    tax_code = NULL,
+    company_name = NULL,
+    vat_number = NULL,
+    recipient_code = NULL,
+    pec = NULL,
+    household_id = NULL,
+    removed_at = $5
+WHERE id = $1
+AND removed_at IS NULL
+RETURNING id;
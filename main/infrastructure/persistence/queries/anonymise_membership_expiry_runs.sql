@@ -0,0 +1,16 @@
+-- Replace the names of the member in the details of past expiry runs
+UPDATE membership_expiry_runs
+SET details = jsonb_set(
+    details,
+    '{expired_memberships}',
+    (
+        SELECT COALESCE(jsonb_agg(
+            CASE WHEN (e->>'member_id')::bigint = $1
+                THEN e || jsonb_build_object('first_name', $2::text, 'last_name', $3::text)
+                ELSE e
+            END
+        ), '[]'::jsonb)
+        FROM jsonb_array_elements(details->'expired_memberships') e
+    )
+)
+WHERE details->'expired_memberships' @> jsonb_build_array(jsonb_build_object('member_id', $1::bigint));
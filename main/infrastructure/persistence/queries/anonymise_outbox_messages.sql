@@ -0,0 +1,8 @@
+-- Cancel the emails to the member not sent yet and scrub the recipient and the body of all of them
+UPDATE email_outbox
+SET
+    status = CASE WHEN status = 'PENDING' THEN 'CANCELLED' ELSE status END,
+    recipient_email = '',
+    recipient_name = '',
+    body = ''
+WHERE member_id = $1;
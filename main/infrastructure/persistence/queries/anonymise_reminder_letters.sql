@@ -0,0 +1,9 @@
+-- Replace the recipient copied into the letters sent to the member, the items are kept for the accounts
+UPDATE reminder_letters
+SET recipient = jsonb_build_object(
+    'first_name', $2::text,
+    'last_name', $3::text,
+    'email', '',
+    'address', NULL
+)
+WHERE member_id = $1;
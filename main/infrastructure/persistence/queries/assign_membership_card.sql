@@ -0,0 +1,5 @@
+-- Assign the card to a membership period
+UPDATE membership_periods
+SET card_number = $2,
+    card_code = $3
+WHERE id = $1;
@@ -0,0 +1,33 @@
+-- Pending emails whose next attempt is due, the oldest first
+-- Their next attempt is postponed to $3 while they are being sent, rows locked by a concurrent run are skipped
+WITH due AS (
+    SELECT id
+    FROM email_outbox
+    WHERE status = 'PENDING'
+    AND next_attempt_at <= $1
+    ORDER BY next_attempt_at, id
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+), claimed AS (
+    UPDATE email_outbox
+    SET next_attempt_at = $3
+    FROM due
+    WHERE email_outbox.id = due.id
+    RETURNING email_outbox.*
+)
+SELECT
+    id,
+    kind,
+    member_id,
+    recipient_email,
+    recipient_name,
+    subject,
+    body,
+    status,
+    attempts,
+    COALESCE(last_error, '') AS last_error,
+    next_attempt_at,
+    sent_at,
+    created_at
+FROM claimed
+ORDER BY created_at, id;
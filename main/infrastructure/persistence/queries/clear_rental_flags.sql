@@ -0,0 +1,13 @@
+-- Clear the flags of the rentals in the season of a membership period once the period is active again
+UPDATE rented_facilities rf
+SET
+    flagged_at = NULL,
+    flag_reason = NULL
+FROM membership_periods mp
+JOIN memberships mem ON mem.id = mp.membership_id
+JOIN membership_statuses ms ON ms.id = mp.status_id
+WHERE mp.id = $1
+AND ms.status = 'ACTIVE'
+AND rf.member_id = mem.member_id
+AND rf.season_id = mp.season_id
+AND rf.flagged_at IS NOT NULL;
@@ -0,0 +1,4 @@
+UPDATE seasons
+SET closed_at = $2
+WHERE id = $1 AND closed_at IS NULL
+RETURNING id, code, name, starts_at, ends_at, closed_at, preemption_ends_at;
@@ -0,0 +1,12 @@
+-- Fill the personal data the target is missing with the data of the source
+-- The billing details are taken as a whole, only when the target has none
+UPDATE members
+SET
+    email = COALESCE(email, $2),
+    tax_code = COALESCE(tax_code, $3),
+    household_id = COALESCE(household_id, $4),
+    company_name = CASE WHEN vat_number IS NULL THEN $5 ELSE company_name END,
+    vat_number = CASE WHEN vat_number IS NULL THEN $6 ELSE vat_number END,
+    recipient_code = CASE WHEN vat_number IS NULL THEN $7 ELSE recipient_code END,
+    pec = CASE WHEN vat_number IS NULL THEN $8 ELSE pec END
+WHERE id = $1
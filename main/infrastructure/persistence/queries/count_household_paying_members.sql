@@ -0,0 +1,11 @@
+-- Members of the household holding a membership in the season, excluded members do not count
+SELECT COUNT(DISTINCT m.id)
+FROM members m
+JOIN memberships mem ON mem.member_id = m.id
+JOIN membership_periods mp ON mp.membership_id = mem.id
+JOIN membership_statuses ms ON ms.id = mp.status_id
+WHERE m.household_id = $1
+AND m.removed_at IS NULL
+AND mp.season_id = $2
+AND ms.status <> 'EXCLUDED'
+AND ($3::bigint IS NULL OR m.id <> $3)
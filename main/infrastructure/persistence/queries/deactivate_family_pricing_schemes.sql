@@ -0,0 +1,3 @@
+UPDATE family_pricing_schemes
+SET active = FALSE
+WHERE active = TRUE
@@ -0,0 +1,3 @@
+UPDATE membership_numbering_policies
+SET active = FALSE
+WHERE active;
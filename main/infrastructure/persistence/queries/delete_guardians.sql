@@ -0,0 +1,2 @@
+DELETE FROM member_guardians
+WHERE member_id = $1;
@@ -0,0 +1,3 @@
+-- Members are detached by the foreign key
+DELETE FROM households
+WHERE id = $1
@@ -0,0 +1,2 @@
+DELETE FROM members
+WHERE id = $1
@@ -0,0 +1,3 @@
+DELETE FROM member_documents
+WHERE member_id = $1
+AND id = $2;
@@ -0,0 +1,4 @@
+-- Delete every document of a member, returning the storage keys of the files to delete
+DELETE FROM member_documents
+WHERE member_id = $1
+RETURNING storage_key;
@@ -0,0 +1,2 @@
+DELETE FROM memberships
+WHERE member_id = $1;
@@ -0,0 +1,2 @@
+DELETE FROM members_waiting
+WHERE member_id = $1;
@@ -0,0 +1,2 @@
+DELETE FROM payment_deadlines
+WHERE season_id = $1;
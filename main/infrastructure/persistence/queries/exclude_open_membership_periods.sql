@@ -0,0 +1,11 @@
+-- Exclude the active and suspended periods of a member with the decision that led to the removal
+UPDATE membership_periods mp
+SET
+    status_id = (SELECT id FROM membership_statuses WHERE status = 'EXCLUDED'),
+    exclusion_deliberated_at = $2,
+    exclusion_reason = $3
+FROM memberships mem, membership_statuses ms
+WHERE mem.id = mp.membership_id
+AND ms.id = mp.status_id
+AND mem.member_id = $1
+AND ms.status IN ('ACTIVE', 'SUSPENDED');
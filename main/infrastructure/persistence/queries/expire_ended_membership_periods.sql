@@ -0,0 +1,12 @@
+-- Move to EXPIRED the active and suspended periods of seasons ended before the given date
+-- The season end date is inclusive, periods expire the day after it
+UPDATE membership_periods mp
+SET status_id = (SELECT id FROM membership_statuses WHERE status = 'EXPIRED')
+FROM seasons s, memberships mem, members m, membership_statuses ms
+WHERE s.id = mp.season_id
+AND mem.id = mp.membership_id
+AND m.id = mem.member_id
+AND ms.id = mp.status_id
+AND ms.status IN ('ACTIVE', 'SUSPENDED')
+AND s.ends_at < $1::date
+RETURNING mp.id, m.id, m.first_name, m.last_name, mem.number, mp.season_id;
@@ -0,0 +1,23 @@
+-- Flag the rentals of the given members in seasons not yet ended
+-- when the member has no active membership in the rental season
+UPDATE rented_facilities rf
+SET
+    flagged_at = $2,
+    flag_reason = 'holder has no active membership in the season'
+FROM seasons s, facilities f
+WHERE s.id = rf.season_id
+AND f.id = rf.facility_id
+AND rf.member_id = ANY($3)
+AND rf.deleted_at IS NULL
+AND rf.flagged_at IS NULL
+AND s.ends_at >= $1::date
+AND NOT EXISTS (
+    SELECT 1
+    FROM membership_periods mp
+    JOIN memberships mem ON mem.id = mp.membership_id
+    JOIN membership_statuses ms ON ms.id = mp.status_id
+    WHERE mem.member_id = rf.member_id
+    AND mp.season_id = rf.season_id
+    AND ms.status = 'ACTIVE'
+)
+RETURNING rf.id, rf.member_id, f.identifier, rf.season_id, rf.flag_reason;
@@ -0,0 +1,14 @@
+SELECT
+    h.id,
+    h.name,
+    COALESCE(json_agg(jsonb_build_object(
+        'member_id', m.id,
+        'first_name', m.first_name,
+        'last_name', m.last_name,
+        'date_of_birth', m.date_of_birth,
+        'email', m.email
+    ) ORDER BY m.date_of_birth, m.id) FILTER (WHERE m.id IS NOT NULL), '[]'::json) AS members
+FROM households h
+LEFT JOIN members m ON m.household_id = h.id AND m.removed_at IS NULL
+GROUP BY h.id
+ORDER BY h.name, h.id
@@ -5,9 +5,9 @@ SELECT
     m.date_of_birth,
     mem.number          AS membership_number,
     CASE WHEN mp.id IS NOT NULL THEN
-        CASE WHEN CURRENT_DATE >= s.starts_at AND CURRENT_DATE < s.ends_at THEN 'CURRENT'
+        CASE WHEN CURRENT_DATE >= s.starts_at AND CURRENT_DATE <= s.ends_at THEN 'CURRENT'
          WHEN CURRENT_DATE < s.starts_at THEN 'FUTURE'
-         WHEN CURRENT_DATE >= s.ends_at THEN 'PAST'
+         WHEN CURRENT_DATE > s.ends_at THEN 'PAST'
         END
     END AS season,
     s.starts_at AS season_starts_at,
@@ -15,9 +15,11 @@ SELECT
     ms.status AS membership_status,
     mp.exclusion_deliberated_at,
     mp.price   AS price,
-    p.amount   AS amount_paid,
-    p.paid_at  AS paid_at,
-    p.currency AS currency
+    mp.currency AS currency,
+    mpl.transactions,
+    CASE WHEN mc.id IS NOT NULL THEN
+        jsonb_build_object('id', mc.id, 'code', mc.code, 'name', mc.name, 'payment_required', mc.payment_required)
+    END AS category
 FROM members m
 LEFT JOIN (
     SELECT
@@ -44,8 +46,11 @@ LEFT JOIN membership_periods mp
     ON mp.id = latest_membership_period.period_id
 LEFT JOIN membership_statuses ms
     ON ms.id = mp.status_id
-LEFT JOIN payments p
-    ON p.membership_period_id = mp.id
+LEFT JOIN membership_period_ledgers mpl
+    ON mpl.membership_period_id = mp.id
 LEFT JOIN seasons s
     ON s.id = mp.season_id
+LEFT JOIN membership_categories mc
+    ON mc.id = mp.category_id
+WHERE m.removed_at IS NULL
 ORDER BY m.last_name, m.first_name
@@ -0,0 +1,20 @@
+-- Statements with their transactions, the latest first
+SELECT
+    bs.id,
+    bs.file_name,
+    bs.format,
+    bs.account_iban,
+    bs.imported_at,
+    bt.id,
+    bt.reference,
+    bt.booking_date,
+    bt.amount,
+    bt.currency,
+    bt.counterparty_name,
+    bt.counterparty_iban,
+    bt.remittance_info,
+    bt.payment_id
+FROM bank_statements bs
+LEFT JOIN bank_transactions bt ON bt.bank_statement_id = bs.id
+WHERE ($1::bigint IS NULL OR bs.id = $1)
+ORDER BY bs.imported_at DESC, bs.id DESC, bt.booking_date, bt.id;
@@ -0,0 +1,13 @@
+SELECT
+    id,
+    bank_statement_id,
+    reference,
+    booking_date,
+    amount,
+    currency,
+    counterparty_name,
+    counterparty_iban,
+    remittance_info,
+    payment_id
+FROM bank_transactions
+WHERE id = $1;
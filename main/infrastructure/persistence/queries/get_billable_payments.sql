@@ -0,0 +1,47 @@
+-- Payments with the member and what they paid for, to describe them on a fiscal document
+-- The address of the member is the first one recorded, the billing details are set for members billed as a business
+SELECT
+    pr.id,
+    pr.type,
+    pr.amount,
+    pr.currency,
+    pr.paid_at,
+    pr.payment_method,
+    pr.notes,
+    pr.target,
+    pr.membership_period_id,
+    pr.rented_facility_id,
+    pr.member_id,
+    pr.first_name,
+    pr.last_name,
+    pr.season_id,
+    pr.facility_identifier,
+    s.code AS season_code,
+    fc.name AS facility_name,
+    m.tax_code,
+    m.company_name,
+    m.vat_number,
+    m.recipient_code,
+    m.pec,
+    a.street,
+    a.street_number,
+    a.zip_code,
+    a.city,
+    a.country,
+    fdl.fiscal_document_id
+FROM payment_records pr
+JOIN members m ON m.id = pr.member_id
+LEFT JOIN seasons s ON s.id = pr.season_id
+LEFT JOIN rented_facilities rf ON rf.id = pr.rented_facility_id
+LEFT JOIN facilities f ON f.id = rf.facility_id
+LEFT JOIN facilities_catalog fc ON fc.id = f.facility_type_id
+LEFT JOIN LATERAL (
+    SELECT street, street_number, zip_code, city, country
+    FROM addresses
+    WHERE member_id = m.id
+    ORDER BY id
+    LIMIT 1
+) a ON TRUE
+LEFT JOIN fiscal_document_lines fdl ON fdl.payment_id = pr.id
+WHERE pr.id = ANY($1)
+ORDER BY pr.paid_at, pr.id;
@@ -0,0 +1,11 @@
+-- Days recorded between $1 and $2, both included, oldest first
+SELECT
+    day,
+    opening_balance,
+    counted_cash,
+    bank_transfers,
+    notes,
+    closed_at
+FROM cash_register_days
+WHERE day BETWEEN $1::date AND $2::date
+ORDER BY day;
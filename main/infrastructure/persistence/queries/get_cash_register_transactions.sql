@@ -0,0 +1,21 @@
+-- Payments and refunds made between $1 and $2, both included, oldest first
+SELECT
+    pr.id,
+    pr.type,
+    pr.amount,
+    pr.currency,
+    pr.paid_at,
+    pr.payment_method,
+    pr.notes,
+    pr.target,
+    pr.membership_period_id,
+    pr.rented_facility_id,
+    pr.member_id,
+    pr.first_name,
+    pr.last_name,
+    pr.season_id,
+    pr.facility_identifier
+FROM payment_records pr
+WHERE pr.paid_at >= $1::date
+AND pr.paid_at < $2::date + 1
+ORDER BY pr.paid_at, pr.id;
@@ -0,0 +1,11 @@
+-- Late fees charged on the memberships and rentals of a season, of every season when $1 is NULL
+SELECT
+    lf.membership_period_id,
+    lf.rented_facility_id,
+    lf.amount,
+    lf.currency,
+    lf.charged_on
+FROM late_fees lf
+LEFT JOIN membership_periods mp ON mp.id = lf.membership_period_id
+LEFT JOIN rented_facilities rf ON rf.id = lf.rented_facility_id
+WHERE ($1::bigint IS NULL OR COALESCE(mp.season_id, rf.season_id) = $1);
@@ -0,0 +1,4 @@
+SELECT version, text, published_at
+FROM privacy_policies
+ORDER BY published_at DESC, id DESC
+LIMIT 1;
@@ -0,0 +1,9 @@
+SELECT
+    full_price,
+    additional_member_price,
+    child_price,
+    child_max_age
+FROM family_pricing_schemes
+WHERE active = TRUE
+ORDER BY created_at DESC, id DESC
+LIMIT 1
@@ -0,0 +1,33 @@
+-- Fiscal documents with their lines, the latest first
+-- $1 is an optional document id, $2 to $6 optional filters on member, fiscal year, kind and issue days (included)
+SELECT
+    fd.id,
+    fd.kind,
+    fd.fiscal_year,
+    fd.number,
+    fd.issued_on,
+    fd.issuer,
+    fd.recipient,
+    fd.currency,
+    fd.notes,
+    COALESCE(
+        json_agg(json_build_object(
+            'payment_id', fdl.payment_id,
+            'description', fdl.description,
+            'paid_on', fdl.paid_on,
+            'payment_method', fdl.payment_method,
+            'amount', fdl.amount,
+            'vat_rate', fdl.vat_rate
+        ) ORDER BY fdl.position) FILTER (WHERE fdl.id IS NOT NULL),
+        '[]'::json
+    ) AS lines
+FROM fiscal_documents fd
+LEFT JOIN fiscal_document_lines fdl ON fdl.fiscal_document_id = fd.id
+WHERE ($1::bigint IS NULL OR fd.id = $1)
+AND ($2::bigint IS NULL OR fd.member_id = $2)
+AND ($3::int IS NULL OR fd.fiscal_year = $3)
+AND ($4::text IS NULL OR fd.kind = $4)
+AND ($5::date IS NULL OR fd.issued_on >= $5)
+AND ($6::date IS NULL OR fd.issued_on <= $6)
+GROUP BY fd.id
+ORDER BY fd.issued_on DESC, fd.kind, fd.number DESC;
@@ -0,0 +1,12 @@
+-- Members a member is the legal guardian of
+SELECT
+    m.id,
+    m.first_name,
+    m.last_name,
+    m.date_of_birth
+FROM member_guardians g
+JOIN members m
+    ON m.id = g.member_id
+WHERE g.guardian_member_id = $1
+AND m.removed_at IS NULL
+ORDER BY m.last_name, m.first_name;
@@ -0,0 +1,9 @@
+-- Members chosen as legal guardians, the removed ones are left out
+SELECT
+    m.id,
+    m.first_name,
+    m.last_name,
+    m.date_of_birth
+FROM members m
+WHERE m.id = ANY($1)
+AND m.removed_at IS NULL;
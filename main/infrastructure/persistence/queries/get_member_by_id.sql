@@ -7,18 +7,16 @@ WITH membership_details AS (
         s.starts_at AS valid_from,
         s.ends_at AS expires_at,
         mp.exclusion_deliberated_at,
+        mp.exclusion_reason,
         mp.status_id,
         mp.price,
-        p.id AS payment_id,
-        p.amount AS payment_amount,
-        p.currency AS payment_currency,
-        p.paid_at AS payment_date,
-        p.payment_method,
-        p.notes
+        mp.currency,
+        mp.category_id,
+        mpl.transactions
     FROM memberships m
     JOIN membership_periods mp ON m.id = mp.membership_id
     LEFT JOIN seasons s ON s.id = mp.season_id
-    LEFT JOIN payments p ON mp.id = p.membership_period_id
+    JOIN membership_period_ledgers mpl ON mpl.membership_period_id = mp.id
     WHERE m.member_id = $1
     AND s.id = $2
 )
@@ -29,6 +27,10 @@ SELECT
     m.date_of_birth,
     m.email,
     m.tax_code,
+    m.company_name,
+    m.vat_number,
+    m.recipient_code,
+    m.pec,
     COALESCE(
         json_agg(DISTINCT jsonb_build_object(
             'number', pn.number
@@ -53,20 +55,21 @@ SELECT
             'valid_from', md.valid_from,
             'expires_at', md.expires_at,
             'exclusion_deliberated_at', md.exclusion_deliberated_at,
+            'exclusion_reason', md.exclusion_reason,
             'status', ms.status,
             'price', md.price,
-            'payment', CASE
-                WHEN md.payment_amount IS NOT NULL THEN
+            'currency', md.currency,
+            'category', CASE
+                WHEN mc.id IS NOT NULL THEN
                     jsonb_build_object(
-                        'id', md.payment_id,
-                        'amount', md.payment_amount,
-                        'currency', md.payment_currency,
-                        'paid_at', md.payment_date,
-                        'payment_method', md.payment_method,
-                        'payment_notes', md.notes
+                        'id', mc.id,
+                        'code', mc.code,
+                        'name', mc.name,
+                        'payment_required', mc.payment_required
                     )
                 ELSE NULL
-            END
+            END,
+            'transactions', md.transactions
         )) FILTER (WHERE md.membership_id IS NOT NULL),
         '[]'::json
     ) AS memberships,
@@ -74,12 +77,43 @@ SELECT
     bp.city AS birth_place_city,
     bp.street AS birth_place_street,
     bp.street_number AS birth_place_street_number,
-    bp.zip_code AS birth_place_zip_code
+    bp.zip_code AS birth_place_zip_code,
+    (
+        SELECT COALESCE(json_agg(jsonb_build_object(
+            'guardian_member_id', g.guardian_member_id,
+            'first_name', COALESCE(gm.first_name, g.first_name),
+            'last_name', COALESCE(gm.last_name, g.last_name),
+            'email', COALESCE(g.email, gm.email),
+            'phone_number', COALESCE(g.phone_number, (
+                SELECT gpn.number FROM phone_numbers gpn WHERE gpn.member_id = gm.id ORDER BY gpn.id LIMIT 1
+            )),
+            'relationship', g.relationship
+        ) ORDER BY g.id), '[]'::json)
+        FROM member_guardians g
+        LEFT JOIN members gm ON gm.id = g.guardian_member_id
+        WHERE g.member_id = m.id
+    ) AS guardians,
+    m.household_id,
+    (
+        SELECT COALESCE(json_agg(jsonb_build_object(
+            'type', c.consent_type,
+            'granted', c.granted,
+            'policy_version', c.policy_version,
+            'recorded_at', c.recorded_at
+        )), '[]'::json)
+        FROM (
+            SELECT DISTINCT ON (mc.consent_type) mc.*
+            FROM member_consents mc
+            WHERE mc.member_id = m.id
+            ORDER BY mc.consent_type, mc.recorded_at DESC, mc.id DESC
+        ) c
+    ) AS consents
 FROM members m
 LEFT JOIN phone_numbers pn ON m.id = pn.member_id
 LEFT JOIN addresses a ON m.id = a.member_id
 LEFT JOIN membership_details md ON m.id = md.member_id
 LEFT JOIN membership_statuses ms ON ms.id = md.status_id
+LEFT JOIN membership_categories mc ON mc.id = md.category_id
 LEFT JOIN birth_places bp ON m.id = bp.member_id
 WHERE m.id = $1
 GROUP BY m.id, bp.country, bp.city, bp.street, bp.street_number, bp.zip_code;
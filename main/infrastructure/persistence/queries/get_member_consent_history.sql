@@ -0,0 +1,4 @@
+SELECT consent_type, granted, policy_version, recorded_at
+FROM member_consents
+WHERE member_id = $1
+ORDER BY recorded_at, id;
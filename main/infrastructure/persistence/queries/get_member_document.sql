@@ -0,0 +1,15 @@
+SELECT
+    id,
+    member_id,
+    type,
+    file_name,
+    content_type,
+    size_bytes,
+    storage_key,
+    issued_at,
+    expires_at,
+    notes,
+    uploaded_at
+FROM member_documents
+WHERE member_id = $1
+AND id = $2;
@@ -0,0 +1,16 @@
+-- Get the documents of a member, the latest first
+SELECT
+    id,
+    member_id,
+    type,
+    file_name,
+    content_type,
+    size_bytes,
+    storage_key,
+    issued_at,
+    expires_at,
+    notes,
+    uploaded_at
+FROM member_documents
+WHERE member_id = $1
+ORDER BY uploaded_at DESC, id DESC;
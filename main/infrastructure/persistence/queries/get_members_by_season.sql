@@ -10,10 +10,9 @@ SELECT
     s.ends_at AS season_ends_at,
     mp.exclusion_deliberated_at,
     mp.price AS price,
+    mp.currency AS currency,
     ms.status AS membership_status,
-    p.amount AS amount_paid,
-    p.paid_at AS paid_at,
-    p.currency AS currency,
+    mpl.transactions,
     CASE
         WHEN EXISTS (
             SELECT 1
@@ -28,19 +27,35 @@ SELECT
         WHEN EXISTS (
             SELECT 1
             FROM rented_facilities rf
-            LEFT JOIN payments fp ON fp.rented_facility_id = rf.id
+            JOIN rented_facility_ledgers rfl ON rfl.rented_facility_id = rf.id
             WHERE rf.member_id = m.id
             AND rf.season_id = s.id
             AND rf.deleted_at IS NULL
-            AND fp.id IS NULL
+            AND NOT rfl.settled
         ) THEN true
         ELSE false
-    END AS has_unpaid_facilities
+    END AS has_unpaid_facilities,
+    CASE
+        WHEN EXISTS (
+            SELECT 1
+            FROM rented_facilities rf
+            WHERE rf.member_id = m.id
+            AND rf.season_id = s.id
+            AND rf.deleted_at IS NULL
+            AND rf.flagged_at IS NOT NULL
+        ) THEN true
+        ELSE false
+    END AS has_flagged_rentals,
+    CASE WHEN mc.id IS NOT NULL THEN
+        jsonb_build_object('id', mc.id, 'code', mc.code, 'name', mc.name, 'payment_required', mc.payment_required)
+    END AS category
 FROM members m
 LEFT JOIN memberships mem ON m.id = mem.member_id
 LEFT JOIN membership_periods mp ON mem.id = mp.membership_id
 LEFT JOIN membership_statuses ms ON mp.status_id = ms.id
-LEFT JOIN payments p ON mp.id = p.membership_period_id
+LEFT JOIN membership_period_ledgers mpl ON mpl.membership_period_id = mp.id
 LEFT JOIN seasons s ON mp.season_id = s.id
+LEFT JOIN membership_categories mc ON mc.id = mp.category_id
 WHERE s.id = $1
+AND m.removed_at IS NULL
 ORDER BY m.last_name, m.first_name
@@ -0,0 +1,32 @@
+-- Get the cards of the active memberships of a season, of a single member when $2 is given
+SELECT
+    m.id          AS member_id,
+    m.first_name,
+    m.last_name,
+    m.date_of_birth,
+    mem.number    AS membership_number,
+    COALESCE(mp.card_code, mem.number::TEXT) AS card_code,
+    s.id          AS season_id,
+    s.code        AS season_code,
+    s.name        AS season_name,
+    s.starts_at,
+    s.ends_at,
+    CASE WHEN mc.id IS NOT NULL THEN
+        jsonb_build_object('id', mc.id, 'code', mc.code, 'name', mc.name, 'payment_required', mc.payment_required)
+    END AS category
+FROM membership_periods mp
+JOIN memberships mem
+    ON mem.id = mp.membership_id
+JOIN members m
+    ON m.id = mem.member_id
+JOIN seasons s
+    ON s.id = mp.season_id
+JOIN membership_statuses ms
+    ON ms.id = mp.status_id
+LEFT JOIN membership_categories mc
+    ON mc.id = mp.category_id
+WHERE mp.season_id = $1
+AND ($2::BIGINT IS NULL OR m.id = $2)
+AND ms.status = 'ACTIVE'
+AND m.removed_at IS NULL
+ORDER BY COALESCE(mp.card_number, mem.number), m.last_name, m.first_name;
@@ -0,0 +1,14 @@
+-- Membership category by code with its price in the season, NULL when not configured
+SELECT
+    mc.id,
+    mc.code,
+    mc.name,
+    mc.payment_required,
+    mcp.price,
+    mcp.currency
+FROM membership_categories mc
+LEFT JOIN membership_category_prices mcp
+    ON mcp.category_id = mc.id
+   AND mcp.season_id = $2
+WHERE mc.code = $1
+  AND mc.active;
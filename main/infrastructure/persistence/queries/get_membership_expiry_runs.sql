@@ -0,0 +1,4 @@
+SELECT id, trigger, reference_date, started_at, finished_at, details
+FROM membership_expiry_runs
+ORDER BY started_at DESC
+LIMIT $1;
@@ -0,0 +1,5 @@
+SELECT scope, prefix, digits, reuse_freed_numbers
+FROM membership_numbering_policies
+WHERE active
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
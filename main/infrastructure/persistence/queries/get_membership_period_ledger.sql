@@ -0,0 +1,4 @@
+SELECT mpl.due, mp.currency, mpl.transactions
+FROM membership_period_ledgers mpl
+JOIN membership_periods mp ON mp.id = mpl.membership_period_id
+WHERE mpl.membership_period_id = $1
@@ -0,0 +1,19 @@
+-- Get the next card number of the season (max + 1), or the lowest number no longer in use when $2 is true
+SELECT CASE
+    WHEN $2 THEN (
+        SELECT MIN(candidate.number)
+        FROM (
+            SELECT 1 AS number
+            UNION ALL
+            SELECT card_number + 1 FROM membership_periods WHERE season_id = $1 AND card_number IS NOT NULL
+        ) candidate
+        WHERE NOT EXISTS (
+            SELECT 1 FROM membership_periods taken
+            WHERE taken.season_id = $1
+            AND taken.card_number = candidate.number
+        )
+    )
+    ELSE COALESCE(MAX(card_number), 0) + 1
+END AS next_number
+FROM membership_periods
+WHERE season_id = $1;
@@ -1,3 +1,14 @@
--- Get the next membership number (max + 1)
-SELECT COALESCE(MAX(number), 0) + 1 AS next_number
+-- Get the next membership number (max + 1), or the lowest number no longer in use when $1 is true
+SELECT CASE
+    WHEN $1 THEN (
+        SELECT MIN(candidate.number)
+        FROM (
+            SELECT 1 AS number
+            UNION ALL
+            SELECT number + 1 FROM memberships
+        ) candidate
+        WHERE NOT EXISTS (SELECT 1 FROM memberships taken WHERE taken.number = candidate.number)
+    )
+    ELSE COALESCE(MAX(number), 0) + 1
+END AS next_number
 FROM memberships;
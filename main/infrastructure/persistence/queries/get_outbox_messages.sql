@@ -0,0 +1,22 @@
+-- Emails of the outbox, the latest first
+-- $1 selects a single email, $2 and $3 filter by status and member, $4 limits the results
+SELECT
+    id,
+    kind,
+    member_id,
+    recipient_email,
+    recipient_name,
+    subject,
+    body,
+    status,
+    attempts,
+    COALESCE(last_error, '') AS last_error,
+    next_attempt_at,
+    sent_at,
+    created_at
+FROM email_outbox
+WHERE ($1::bigint IS NULL OR id = $1)
+AND ($2::text IS NULL OR status = $2)
+AND ($3::bigint IS NULL OR member_id = $3)
+ORDER BY created_at DESC, id DESC
+LIMIT $4;
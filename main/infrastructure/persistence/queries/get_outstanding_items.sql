@@ -0,0 +1,55 @@
+-- Memberships and rentals still to be settled by members not removed, exempt memberships aside
+-- $1 is an optional season
+SELECT
+    'MEMBERSHIP' AS target,
+    mp.id AS membership_period_id,
+    NULL::bigint AS rented_facility_id,
+    m.id AS member_id,
+    m.first_name,
+    m.last_name,
+    m.tax_code,
+    mp.season_id,
+    NULL::bigint AS facility_type_id,
+    NULL::varchar AS facility_name,
+    NULL::varchar AS facility_identifier,
+    mpl.due,
+    mpl.due - mpl.paid + mpl.refunded AS balance
+FROM membership_periods mp
+JOIN membership_period_ledgers mpl ON mpl.membership_period_id = mp.id
+JOIN membership_categories mc ON mc.id = mp.category_id
+JOIN memberships mem ON mem.id = mp.membership_id
+JOIN members m ON m.id = mem.member_id
+WHERE NOT mpl.settled
+AND mpl.due > 0
+AND mc.payment_required
+AND m.removed_at IS NULL
+AND ($1::bigint IS NULL OR mp.season_id = $1)
+
+UNION ALL
+
+SELECT
+    'FACILITY' AS target,
+    NULL::bigint AS membership_period_id,
+    rf.id AS rented_facility_id,
+    m.id AS member_id,
+    m.first_name,
+    m.last_name,
+    m.tax_code,
+    rf.season_id,
+    f.facility_type_id,
+    fc.name AS facility_name,
+    f.identifier AS facility_identifier,
+    rfl.due,
+    rfl.due - rfl.paid + rfl.refunded AS balance
+FROM rented_facilities rf
+JOIN rented_facility_ledgers rfl ON rfl.rented_facility_id = rf.id
+JOIN facilities f ON f.id = rf.facility_id
+LEFT JOIN facilities_catalog fc ON fc.id = f.facility_type_id
+JOIN members m ON m.id = rf.member_id
+WHERE NOT rfl.settled
+AND rfl.due > 0
+AND rf.deleted_at IS NULL
+AND m.removed_at IS NULL
+AND ($1::bigint IS NULL OR rf.season_id = $1)
+
+ORDER BY last_name, first_name;
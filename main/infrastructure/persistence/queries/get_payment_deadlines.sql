@@ -0,0 +1,15 @@
+-- Deadlines of a season, of every season when $1 is NULL, memberships first
+SELECT
+    pd.id,
+    pd.season_id,
+    pd.target,
+    pd.facility_type_id,
+    fc.name AS facility_type_name,
+    pd.due_on,
+    pd.late_fee_kind,
+    pd.late_fee_amount,
+    pd.late_fee_after_days
+FROM payment_deadlines pd
+LEFT JOIN facilities_catalog fc ON fc.id = pd.facility_type_id
+WHERE ($1::bigint IS NULL OR pd.season_id = $1)
+ORDER BY pd.season_id, pd.target DESC, fc.name;
@@ -0,0 +1,11 @@
+-- Ledger of the membership period or the rental the payment belongs to
+SELECT
+    COALESCE(mpl.due, rfl.due),
+    COALESCE(mp.currency, rf.currency),
+    COALESCE(mpl.transactions, rfl.transactions)
+FROM payments p
+LEFT JOIN membership_period_ledgers mpl ON mpl.membership_period_id = p.membership_period_id
+LEFT JOIN membership_periods mp ON mp.id = p.membership_period_id
+LEFT JOIN rented_facility_ledgers rfl ON rfl.rented_facility_id = p.rented_facility_id
+LEFT JOIN rented_facilities rf ON rf.id = p.rented_facility_id
+WHERE p.id = $1
@@ -0,0 +1,18 @@
+-- Totals of the payments matching the search filters, by payment method
+-- $3 is the exclusive upper bound of the payment date
+SELECT
+    pr.payment_method,
+    COUNT(*) AS count,
+    COALESCE(SUM(pr.amount) FILTER (WHERE pr.type = 'PAYMENT'), 0) AS paid,
+    COALESCE(SUM(pr.amount) FILTER (WHERE pr.type = 'REFUND'), 0) AS refunded
+FROM payment_records pr
+WHERE ($1::bigint IS NULL OR pr.season_id = $1)
+AND ($2::timestamp IS NULL OR pr.paid_at >= $2)
+AND ($3::timestamp IS NULL OR pr.paid_at < $3)
+AND ($4::text IS NULL OR LOWER(pr.payment_method) = LOWER($4))
+AND ($5::bigint IS NULL OR pr.member_id = $5)
+AND ($6::text IS NULL OR pr.target = $6)
+AND ($7::numeric IS NULL OR pr.amount >= $7)
+AND ($8::numeric IS NULL OR pr.amount <= $8)
+GROUP BY pr.payment_method
+ORDER BY pr.payment_method
@@ -0,0 +1,53 @@
+-- Get the facilities rented in the previous season that are still reserved to their holder
+-- A preemption is pending while the season's window is open ($2 <= preemption_ends_at)
+-- and the facility has not been rented yet in the season
+-- Holders removed from the club or excluded in either season are returned as left, their right has lapsed
+SELECT
+    f.id                  AS facility_id,
+    f.identifier          AS facility_identifier,
+    fc.id                 AS facility_type_id,
+    fc.name               AS facility_type,
+    fc.description        AS facility_type_description,
+    fc.suggested_price,
+    m.id                  AS holder_id,
+    m.first_name          AS holder_first_name,
+    m.last_name           AS holder_last_name,
+    ps.id                 AS previous_season_id,
+    s.preemption_ends_at,
+    m.removed_at IS NOT NULL OR EXISTS (
+        SELECT 1
+        FROM membership_periods mp
+        JOIN memberships mem ON mem.id = mp.membership_id
+        JOIN membership_statuses ms ON ms.id = mp.status_id
+        WHERE mem.member_id = m.id
+        AND mp.season_id IN (ps.id, s.id)
+        AND ms.status = 'EXCLUDED'
+    )                     AS holder_left
+FROM seasons s
+JOIN LATERAL (
+    SELECT p.id
+    FROM seasons p
+    WHERE p.starts_at < s.starts_at
+    ORDER BY p.starts_at DESC
+    LIMIT 1
+) ps ON true
+JOIN rented_facilities prf
+    ON prf.season_id = ps.id
+    AND prf.deleted_at IS NULL
+JOIN facilities f
+    ON f.id = prf.facility_id
+JOIN facilities_catalog fc
+    ON fc.id = f.facility_type_id
+JOIN members m
+    ON m.id = prf.member_id
+WHERE s.id = $1
+AND s.preemption_ends_at IS NOT NULL
+AND $2::date <= s.preemption_ends_at
+AND NOT EXISTS (
+    SELECT 1
+    FROM rented_facilities rf
+    WHERE rf.facility_id = prf.facility_id
+    AND rf.season_id = s.id
+    AND rf.deleted_at IS NULL
+)
+ORDER BY fc.name, f.identifier;
@@ -0,0 +1,7 @@
+-- Cash counted when closing the last closed day before $1
+SELECT counted_cash
+FROM cash_register_days
+WHERE day < $1::date
+AND closed_at IS NOT NULL
+ORDER BY day DESC
+LIMIT 1;
@@ -0,0 +1,18 @@
+-- Reminder letters in the order they were sent
+-- $1 is an optional letter id, $2 and $3 optional filters on season and member, $4 an optional day they were sent
+SELECT
+    rl.id,
+    rl.member_id,
+    rl.season_id,
+    s.code AS season_code,
+    rl.level,
+    rl.sent_on,
+    rl.recipient,
+    rl.items
+FROM reminder_letters rl
+JOIN seasons s ON s.id = rl.season_id
+WHERE ($1::bigint IS NULL OR rl.id = $1)
+AND ($2::bigint IS NULL OR rl.season_id = $2)
+AND ($3::bigint IS NULL OR rl.member_id = $3)
+AND ($4::date IS NULL OR rl.sent_on = $4)
+ORDER BY rl.sent_on, rl.id;
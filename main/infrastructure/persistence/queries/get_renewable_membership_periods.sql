@@ -0,0 +1,37 @@
+-- Get the membership periods of a season with one of the given renewable statuses, flagging members already enrolled in the target season
+-- The category of the period comes with its price in the target season
+SELECT
+    m.id          AS member_id,
+    m.first_name,
+    m.last_name,
+    m.date_of_birth,
+    m.household_id,
+    mem.id        AS membership_id,
+    mem.number    AS membership_number,
+    mp.price,
+    mp.currency,
+    EXISTS (
+        SELECT 1
+        FROM membership_periods tmp
+        WHERE tmp.membership_id = mem.id
+        AND tmp.season_id = $2
+    ) AS already_in_target,
+    jsonb_build_object('id', mc.id, 'code', mc.code, 'name', mc.name, 'payment_required', mc.payment_required) AS category,
+    mcp.price     AS target_price,
+    mcp.currency  AS target_currency
+FROM membership_periods mp
+JOIN memberships mem
+    ON mem.id = mp.membership_id
+JOIN members m
+    ON m.id = mem.member_id
+JOIN membership_statuses ms
+    ON ms.id = mp.status_id
+JOIN membership_categories mc
+    ON mc.id = mp.category_id
+LEFT JOIN membership_category_prices mcp
+    ON mcp.category_id = mc.id
+   AND mcp.season_id = $2
+WHERE mp.season_id = $1
+AND ms.status = ANY($3::text[])
+AND m.removed_at IS NULL
+ORDER BY m.last_name, m.first_name;
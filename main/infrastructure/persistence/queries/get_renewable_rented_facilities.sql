@@ -0,0 +1,79 @@
+-- Get the rentals of a season held by members with a renewable membership period in it, flagging facilities already rented in the target season
+-- Periods expired at the end of the season are renewable, rentals of suspended, excluded or removed members are not renewed
+SELECT
+    m.id                  AS member_id,
+    m.first_name,
+    m.last_name,
+    EXISTS (
+        SELECT 1
+        FROM rented_facilities trf
+        WHERE trf.facility_id = rf.facility_id
+        AND trf.season_id = $2
+        AND trf.deleted_at IS NULL
+    )                     AS facility_rented_in_target,
+
+    rf.id                 AS rented_facility_id,
+    s.starts_at           AS rented_at,
+    s.ends_at             AS expires_at,
+    rf.price,
+    rf.currency,
+    rf.discount_applied,
+
+    f.id                  AS facility_id,
+    f.identifier          AS facility_identifier,
+
+    fc.id                 AS facility_type_id,
+    fc.name               AS facility_type,
+    fc.description        AS facility_type_description,
+    fc.suggested_price,
+
+    b.id                  AS boat_id,
+    b.name                AS boat_name,
+    b.length_meters       AS boat_length_meters,
+    b.width_meters        AS boat_width_meters,
+    b.engine_info         AS boat_engine_info,
+    b.type                AS boat_type,
+
+    i.id                  AS insurance_id,
+    i.provider            AS insurance_provider,
+    i.number              AS insurance_number,
+    i.expires_at          AS insurance_expires_at,
+
+    l.id                  AS leerboard_id,
+    l.color               AS leerboard_color,
+    l.type                AS leerboard_type,
+    l.length_meters       AS leerboard_length_meters,
+
+    rfl.transactions
+FROM rented_facilities rf
+JOIN members m
+    ON m.id = rf.member_id
+JOIN facilities f
+    ON f.id = rf.facility_id
+JOIN facilities_catalog fc
+    ON fc.id = f.facility_type_id
+JOIN seasons s
+    ON s.id = rf.season_id
+LEFT JOIN boats b
+    ON b.rented_facility_id = rf.id
+LEFT JOIN insurances i
+    ON i.boat_id = b.id
+LEFT JOIN leeboards l
+    ON l.rented_facility_id = rf.id
+JOIN rented_facility_ledgers rfl
+    ON rfl.rented_facility_id = rf.id
+WHERE rf.season_id = $1
+AND rf.deleted_at IS NULL
+AND m.removed_at IS NULL
+AND EXISTS (
+    SELECT 1
+    FROM memberships mem
+    JOIN membership_periods mp
+        ON mp.membership_id = mem.id
+    JOIN membership_statuses ms
+        ON ms.id = mp.status_id
+    WHERE mem.member_id = m.id
+    AND mp.season_id = $1
+    AND ms.status = ANY($3::text[])
+)
+ORDER BY m.last_name, m.first_name, rf.id;
@@ -3,6 +3,7 @@ SELECT
     s.starts_at           AS rented_at,
     s.ends_at             AS expires_at,
     rf.price,
+    rf.currency,
     rf.discount_applied,
 
     f.id                  AS facility_id,
@@ -30,12 +31,9 @@ SELECT
     l.type                AS leerboard_type,
     l.length_meters       AS leerboard_length_meters,
 
-    p.id                  AS payment_id,
-    p.amount              AS payment_amount,
-    p.currency            AS payment_currency,
-    p.paid_at             AS payment_paid_at,
-    p.payment_method,
-    p.notes               AS payment_notes
+    rf.flagged_at,
+    rf.flag_reason,
+    rfl.transactions
 FROM rented_facilities rf
 JOIN facilities f
     ON f.id = rf.facility_id
@@ -49,8 +47,8 @@ LEFT JOIN insurances i
     ON i.boat_id = b.id
 LEFT JOIN leeboards l
     ON l.rented_facility_id = rf.id
-LEFT JOIN payments p
-    ON p.rented_facility_id = rf.id
+JOIN rented_facility_ledgers rfl
+    ON rfl.rented_facility_id = rf.id
 WHERE rf.member_id = $1
 AND s.id = $2
 AND rf.deleted_at IS NULL
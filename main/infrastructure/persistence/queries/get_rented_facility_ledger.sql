@@ -0,0 +1,5 @@
+SELECT rfl.due, rf.currency, rfl.transactions
+FROM rented_facility_ledgers rfl
+JOIN rented_facilities rf ON rf.id = rfl.rented_facility_id
+WHERE rfl.rented_facility_id = $1
+AND rf.deleted_at IS NULL
@@ -0,0 +1,6 @@
+-- Get the season containing the given date (end date is inclusive)
+SELECT id, code, name, starts_at, ends_at, closed_at, preemption_ends_at
+FROM seasons
+WHERE $1::date >= starts_at
+  AND $1::date <= ends_at
+LIMIT 1;
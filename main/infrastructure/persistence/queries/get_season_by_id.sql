@@ -0,0 +1,3 @@
+SELECT id, code, name, starts_at, ends_at, closed_at, preemption_ends_at
+FROM seasons
+WHERE id = $1;
@@ -0,0 +1,24 @@
+-- Get the members holding a membership in the season, excluded ones aside,
+-- with the expiry of their latest medical certificate
+SELECT
+    m.id,
+    m.first_name,
+    m.last_name,
+    m.date_of_birth,
+    m.email,
+    MAX(md.expires_at) AS expires_at
+FROM membership_periods mp
+JOIN memberships mem
+    ON mem.id = mp.membership_id
+JOIN members m
+    ON m.id = mem.member_id
+JOIN membership_statuses ms
+    ON ms.id = mp.status_id
+LEFT JOIN member_documents md
+    ON md.member_id = m.id
+    AND md.type = 'MEDICAL_CERTIFICATE'
+WHERE mp.season_id = $1
+AND ms.status <> 'EXCLUDED'
+AND m.removed_at IS NULL
+GROUP BY m.id, m.first_name, m.last_name, m.date_of_birth, m.email
+ORDER BY m.last_name, m.first_name;
@@ -0,0 +1,3 @@
+SELECT starts_at
+FROM seasons
+WHERE id = $1;
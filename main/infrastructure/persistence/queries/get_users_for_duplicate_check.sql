@@ -0,0 +1,16 @@
+SELECT
+    m.id AS member_id,
+    m.first_name,
+    m.last_name,
+    m.date_of_birth,
+    m.email,
+    m.tax_code,
+    COALESCE(
+        array_agg(pn.number) FILTER (WHERE pn.id IS NOT NULL),
+        '{}'
+    ) AS phone_numbers
+FROM members m
+LEFT JOIN phone_numbers pn ON pn.member_id = m.id
+WHERE m.removed_at IS NULL
+GROUP BY m.id
+ORDER BY m.id
@@ -0,0 +1,3 @@
+INSERT INTO bank_statements (file_name, format, account_iban)
+VALUES ($1, $2, $3)
+RETURNING id, imported_at;
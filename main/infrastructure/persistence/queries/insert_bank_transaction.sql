@@ -0,0 +1,14 @@
+-- Returns no row when the transaction was imported by an earlier statement
+INSERT INTO bank_transactions (
+    bank_statement_id,
+    fingerprint,
+    reference,
+    booking_date,
+    amount,
+    currency,
+    counterparty_name,
+    counterparty_iban,
+    remittance_info
+) VALUES ($1, $2, $3, $4::date, $5, $6, $7, $8, $9)
+ON CONFLICT (fingerprint) DO NOTHING
+RETURNING id;
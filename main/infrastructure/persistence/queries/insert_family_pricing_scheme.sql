@@ -0,0 +1,2 @@
+INSERT INTO family_pricing_schemes (full_price, additional_member_price, child_price, child_max_age)
+VALUES ($1, $2, $3, $4)
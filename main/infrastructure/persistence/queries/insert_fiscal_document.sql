@@ -0,0 +1,3 @@
+INSERT INTO fiscal_documents (kind, fiscal_year, number, issued_on, member_id, issuer, recipient, currency, notes)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id;
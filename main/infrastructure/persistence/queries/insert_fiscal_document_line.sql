@@ -0,0 +1,5 @@
+-- No row is returned when the payment is already on another document
+INSERT INTO fiscal_document_lines (fiscal_document_id, position, payment_id, description, paid_on, payment_method, amount, vat_rate)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (payment_id) DO NOTHING
+RETURNING id;
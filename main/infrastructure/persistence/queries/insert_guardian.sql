@@ -0,0 +1,2 @@
+INSERT INTO member_guardians (member_id, guardian_member_id, first_name, last_name, email, phone_number, relationship)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
@@ -0,0 +1,3 @@
+INSERT INTO households (name)
+VALUES ($1)
+RETURNING id
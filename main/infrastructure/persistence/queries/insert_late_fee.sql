@@ -0,0 +1,4 @@
+-- A fee already charged on the item is kept as it is
+INSERT INTO late_fees (membership_period_id, rented_facility_id, amount, currency, charged_on)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT DO NOTHING;
@@ -1,4 +1,4 @@
 -- Insert a new member and return the generated ID
-INSERT INTO members (first_name, last_name, date_of_birth, email, tax_code)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO members (first_name, last_name, date_of_birth, email, tax_code, company_name, vat_number, recipient_code, pec)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING id;
@@ -0,0 +1,7 @@
+-- Insert a consent, only for members not removed
+INSERT INTO member_consents (member_id, consent_type, granted, policy_version)
+SELECT $1, $2, $3, $4
+FROM members
+WHERE id = $1
+AND removed_at IS NULL
+RETURNING recorded_at;
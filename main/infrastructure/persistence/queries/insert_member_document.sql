@@ -0,0 +1,17 @@
+-- Insert a document, only for members not removed
+INSERT INTO member_documents (
+    member_id,
+    type,
+    file_name,
+    content_type,
+    size_bytes,
+    storage_key,
+    issued_at,
+    expires_at,
+    notes
+)
+SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9
+FROM members
+WHERE id = $1
+AND removed_at IS NULL
+RETURNING id, uploaded_at;
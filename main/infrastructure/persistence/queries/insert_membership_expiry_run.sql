@@ -0,0 +1,4 @@
+-- Record an execution of the membership expiry job
+INSERT INTO membership_expiry_runs (trigger, reference_date, started_at, expired_memberships, flagged_rentals, details)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, finished_at;
@@ -0,0 +1,2 @@
+INSERT INTO membership_numbering_policies (scope, prefix, digits, reuse_freed_numbers)
+VALUES ($1, $2, $3, $4);
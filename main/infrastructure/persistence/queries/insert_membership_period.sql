@@ -1,4 +1,11 @@
--- Insert a membership period for a membership
-INSERT INTO membership_periods (membership_id, status_id, season_id, price)
-VALUES ($1, $2, $3, $4)
+-- Insert a membership period for a membership, ordinary when no category is given
+INSERT INTO membership_periods (membership_id, status_id, season_id, price, category_id, currency)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    COALESCE($5, (SELECT id FROM membership_categories WHERE code = 'ORDINARY')),
+    $6
+)
 RETURNING id;
@@ -0,0 +1,14 @@
+INSERT INTO email_outbox (
+    kind,
+    member_id,
+    recipient_email,
+    recipient_name,
+    subject,
+    body,
+    status,
+    attempts,
+    next_attempt_at,
+    created_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id;
@@ -5,6 +5,7 @@ INSERT INTO payments (
     currency,
     paid_at,
     payment_method,
-    notes
-) VALUES ($1, $2, $3, $4, $5, $6, $7)
+    notes,
+    type
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING id;
@@ -0,0 +1,3 @@
+INSERT INTO payment_deadlines (season_id, target, facility_type_id, due_on, late_fee_kind, late_fee_amount, late_fee_after_days)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id;
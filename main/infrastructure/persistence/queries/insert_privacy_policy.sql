@@ -0,0 +1,5 @@
+-- Returns no row when the version was already published
+INSERT INTO privacy_policies (version, text)
+VALUES ($1, $2)
+ON CONFLICT (version) DO NOTHING
+RETURNING published_at;
@@ -0,0 +1,5 @@
+-- Nothing is returned when the member was already sent a letter of the same level in the season
+INSERT INTO reminder_letters (member_id, season_id, level, sent_on, recipient, items)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (member_id, season_id, level) DO NOTHING
+RETURNING id;
@@ -1,4 +1,4 @@
 -- Insert a rental for a facility
-INSERT INTO rented_facilities (facility_id, member_id, season_id, price, discount_applied)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO rented_facilities (facility_id, member_id, season_id, price, discount_applied, currency)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING id;
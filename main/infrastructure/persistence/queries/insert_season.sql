@@ -0,0 +1,4 @@
+-- Insert a new season
+INSERT INTO seasons (code, name, starts_at, ends_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, code, name, starts_at, ends_at, closed_at, preemption_ends_at;
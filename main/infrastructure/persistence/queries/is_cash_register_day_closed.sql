@@ -0,0 +1,6 @@
+SELECT EXISTS (
+    SELECT 1
+    FROM cash_register_days
+    WHERE day = $1::date
+    AND closed_at IS NOT NULL
+);
@@ -0,0 +1,5 @@
+SELECT EXISTS (
+    SELECT 1
+    FROM fiscal_document_lines
+    WHERE payment_id = $1
+);
@@ -0,0 +1,5 @@
+-- Updates nothing when the transaction was already matched
+UPDATE bank_transactions
+SET payment_id = $2
+WHERE id = $1
+AND payment_id IS NULL;
@@ -0,0 +1,5 @@
+-- Serialize the rentals of a facility until the end of the transaction
+SELECT id
+FROM facilities
+WHERE id = $1
+FOR UPDATE
@@ -0,0 +1,6 @@
+SELECT id, email, tax_code, household_id, company_name, vat_number, recipient_code, pec
+FROM members
+WHERE id IN ($1, $2)
+AND removed_at IS NULL
+ORDER BY id
+FOR UPDATE
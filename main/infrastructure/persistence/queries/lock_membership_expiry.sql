@@ -0,0 +1,2 @@
+-- Prevent concurrent expiry runs, the lock is released at the end of the transaction
+SELECT pg_try_advisory_xact_lock(hashtext('membership_expiry'));
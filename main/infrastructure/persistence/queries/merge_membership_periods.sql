@@ -0,0 +1,21 @@
+-- Move the payments of the source periods to the target period of the same season,
+-- then drop the source periods, which are now empty
+WITH overlapping AS (
+    SELECT source_mp.id AS source_period_id, target_mp.id AS target_period_id
+    FROM membership_periods source_mp
+    JOIN memberships source_mem ON source_mem.id = source_mp.membership_id
+    JOIN membership_periods target_mp ON target_mp.season_id = source_mp.season_id
+    JOIN memberships target_mem ON target_mem.id = target_mp.membership_id
+    WHERE source_mem.member_id = $1
+    AND target_mem.member_id = $2
+),
+moved_payments AS (
+    UPDATE payments p
+    SET membership_period_id = o.target_period_id
+    FROM overlapping o
+    WHERE p.membership_period_id = o.source_period_id
+    RETURNING p.id
+)
+DELETE FROM membership_periods
+WHERE id IN (SELECT source_period_id FROM overlapping)
+RETURNING id
@@ -0,0 +1,8 @@
+-- Keep the earliest date when both members were sent the same letter in a season, the next level is due from it
+UPDATE reminder_letters target
+SET sent_on = LEAST(target.sent_on, source.sent_on)
+FROM reminder_letters source
+WHERE source.member_id = $1
+AND target.member_id = $2
+AND target.season_id = source.season_id
+AND target.level = source.level
@@ -0,0 +1,7 @@
+-- Keep the earliest position when both members are queued for the same facility type
+UPDATE members_waiting target
+SET queued_at = LEAST(target.queued_at, source.queued_at)
+FROM members_waiting source
+WHERE source.member_id = $1
+AND target.member_id = $2
+AND target.facility_type_id = source.facility_type_id
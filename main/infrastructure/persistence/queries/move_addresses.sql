@@ -0,0 +1,14 @@
+UPDATE addresses source
+SET member_id = $2
+WHERE source.member_id = $1
+AND NOT EXISTS (
+    SELECT 1
+    FROM addresses target
+    WHERE target.member_id = $2
+    AND target.country = source.country
+    AND target.city = source.city
+    AND target.street = source.street
+    AND target.street_number = source.street_number
+    AND target.zip_code = source.zip_code
+)
+RETURNING id
@@ -0,0 +1,4 @@
+UPDATE birth_places
+SET member_id = $2
+WHERE member_id = $1
+AND NOT EXISTS (SELECT 1 FROM birth_places WHERE member_id = $2)
@@ -0,0 +1,5 @@
+-- The recipient of the documents is kept as it was at issue time
+UPDATE fiscal_documents
+SET member_id = $2
+WHERE member_id = $1
+RETURNING id
@@ -0,0 +1,17 @@
+-- The guardians of the source become guardians of the target, and the minors the source
+-- was guardian of get the target as guardian. Links between the two members are dropped.
+WITH dropped_links AS (
+    DELETE FROM member_guardians
+    WHERE (member_id = $2 AND guardian_member_id = $1)
+    OR (member_id = $1 AND guardian_member_id = $2)
+),
+moved_wards AS (
+    UPDATE member_guardians
+    SET guardian_member_id = $2
+    WHERE guardian_member_id = $1
+    AND member_id <> $2
+)
+UPDATE member_guardians
+SET member_id = $2
+WHERE member_id = $1
+AND (guardian_member_id IS NULL OR guardian_member_id <> $2)
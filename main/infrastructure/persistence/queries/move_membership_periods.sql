@@ -0,0 +1,10 @@
+-- Move the remaining source periods to the target membership
+UPDATE membership_periods mp
+SET membership_id = (
+    SELECT id FROM memberships WHERE member_id = $2 ORDER BY id LIMIT 1
+)
+FROM memberships source_mem
+WHERE source_mem.id = mp.membership_id
+AND source_mem.member_id = $1
+AND EXISTS (SELECT 1 FROM memberships WHERE member_id = $2)
+RETURNING mp.id
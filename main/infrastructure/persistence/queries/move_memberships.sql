@@ -0,0 +1,6 @@
+-- A target without membership takes over the membership of the source, with its number
+UPDATE memberships
+SET member_id = $2
+WHERE member_id = $1
+AND NOT EXISTS (SELECT 1 FROM memberships WHERE member_id = $2)
+RETURNING (SELECT COUNT(*) FROM membership_periods mp WHERE mp.membership_id = memberships.id)
@@ -0,0 +1,5 @@
+UPDATE phone_numbers
+SET member_id = $2
+WHERE member_id = $1
+AND number NOT IN (SELECT number FROM phone_numbers WHERE member_id = $2)
+RETURNING id
@@ -0,0 +1,11 @@
+-- The letters the target was also sent are left to the deletion of the source
+UPDATE reminder_letters source
+SET member_id = $2
+WHERE source.member_id = $1
+AND NOT EXISTS (
+    SELECT 1 FROM reminder_letters target
+    WHERE target.member_id = $2
+    AND target.season_id = source.season_id
+    AND target.level = source.level
+)
+RETURNING id
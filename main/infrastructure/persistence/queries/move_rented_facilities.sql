@@ -0,0 +1,4 @@
+UPDATE rented_facilities
+SET member_id = $2
+WHERE member_id = $1
+RETURNING id
@@ -0,0 +1,5 @@
+UPDATE members_waiting
+SET member_id = $2
+WHERE member_id = $1
+AND facility_type_id NOT IN (SELECT facility_type_id FROM members_waiting WHERE member_id = $2)
+RETURNING id
@@ -0,0 +1,7 @@
+-- Next number of the sequence of the kind and fiscal year
+-- The row stays locked until the document is stored or the transaction rolled back, so numbers have no gaps
+INSERT INTO fiscal_document_sequences (kind, fiscal_year, last_number)
+VALUES ($1, $2, 1)
+ON CONFLICT (kind, fiscal_year) DO UPDATE
+SET last_number = fiscal_document_sequences.last_number + 1
+RETURNING last_number;
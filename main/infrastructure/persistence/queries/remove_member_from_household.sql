@@ -0,0 +1,4 @@
+UPDATE members
+SET household_id = NULL
+WHERE household_id = $1
+AND id = $2
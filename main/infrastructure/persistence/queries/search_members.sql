@@ -0,0 +1,157 @@
+-- Search members by season, or by their latest membership period when no season is given
+-- $2 is a LIKE pattern fragment, already escaped
+WITH member_periods AS (
+    SELECT
+        m.id AS member_id,
+        m.first_name,
+        m.last_name,
+        m.email,
+        m.date_of_birth,
+        m.tax_code,
+        mem.number AS membership_number,
+        s.id AS season_id,
+        s.starts_at AS season_starts_at,
+        s.ends_at AS season_ends_at,
+        mp.exclusion_deliberated_at,
+        mp.price AS price,
+        mp.currency AS currency,
+        ms.status AS membership_status,
+        mpl.transactions,
+        mpl.settled,
+        mc.payment_required AS category_payment_required,
+        CASE WHEN mc.id IS NOT NULL THEN
+            jsonb_build_object('id', mc.id, 'code', mc.code, 'name', mc.name, 'payment_required', mc.payment_required)
+        END AS category,
+        ROW_NUMBER() OVER (
+            PARTITION BY m.id
+            ORDER BY s.starts_at DESC NULLS LAST
+        ) AS rn
+    -- Members without a membership are kept when no season is given, with NULL membership columns
+    FROM members m
+    LEFT JOIN memberships mem ON mem.member_id = m.id
+    LEFT JOIN membership_periods mp ON mp.membership_id = mem.id
+    LEFT JOIN membership_statuses ms ON ms.id = mp.status_id
+    LEFT JOIN seasons s ON s.id = mp.season_id
+    LEFT JOIN membership_period_ledgers mpl ON mpl.membership_period_id = mp.id
+    LEFT JOIN membership_categories mc ON mc.id = mp.category_id
+    WHERE m.removed_at IS NULL
+    AND ($1::bigint IS NULL OR s.id = $1)
+),
+candidates AS (
+    SELECT
+        mp.*,
+        EXISTS (
+            SELECT 1
+            FROM rented_facilities rf
+            WHERE rf.member_id = mp.member_id
+            AND rf.season_id = mp.season_id
+            AND rf.deleted_at IS NULL
+        ) AS has_rented_facilities,
+        EXISTS (
+            SELECT 1
+            FROM rented_facilities rf
+            JOIN rented_facility_ledgers rfl ON rfl.rented_facility_id = rf.id
+            WHERE rf.member_id = mp.member_id
+            AND rf.season_id = mp.season_id
+            AND rf.deleted_at IS NULL
+            AND NOT rfl.settled
+        ) AS has_unpaid_facilities,
+        EXISTS (
+            SELECT 1
+            FROM rented_facilities rf
+            WHERE rf.member_id = mp.member_id
+            AND rf.season_id = mp.season_id
+            AND rf.deleted_at IS NULL
+            AND rf.flagged_at IS NOT NULL
+        ) AS has_flagged_rentals
+    FROM member_periods mp
+    WHERE mp.rn = 1
+),
+filtered AS (
+    SELECT c.*
+    FROM candidates c
+    WHERE (
+        $2::text IS NULL
+        OR (c.first_name || ' ' || c.last_name) ILIKE '%' || $2 || '%'
+        OR (c.last_name || ' ' || c.first_name) ILIKE '%' || $2 || '%'
+        OR c.tax_code ILIKE '%' || $2 || '%'
+        OR c.email ILIKE '%' || $2 || '%'
+        OR c.membership_number::text = $2
+    )
+    AND ($3::text IS NULL OR c.membership_status = $3)
+    -- Paid means settled, categories exempt from the fee count as paid
+    AND ($4::boolean IS NULL OR (c.settled OR c.category_payment_required IS FALSE) = $4)
+    AND ($5::boolean IS NULL OR c.has_unpaid_facilities = $5)
+    AND (
+        $6::bigint IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM rented_facilities rf
+            JOIN facilities f ON f.id = rf.facility_id
+            WHERE rf.member_id = c.member_id
+            AND rf.season_id = c.season_id
+            AND rf.deleted_at IS NULL
+            AND f.facility_type_id = $6
+        )
+    )
+    -- The privacy policy consent only counts when given for the current policy
+    AND (
+        $11::text IS NULL
+        OR NOT EXISTS (
+            SELECT 1
+            FROM (
+                SELECT DISTINCT ON (mc.consent_type) mc.consent_type, mc.granted, mc.policy_version
+                FROM member_consents mc
+                WHERE mc.member_id = c.member_id
+                AND mc.consent_type = $11
+                ORDER BY mc.consent_type, mc.recorded_at DESC, mc.id DESC
+            ) current_consent
+            WHERE current_consent.granted
+            AND (
+                current_consent.consent_type <> 'PRIVACY_POLICY'
+                OR current_consent.policy_version IS NOT DISTINCT FROM (
+                    SELECT pp.version FROM privacy_policies pp ORDER BY pp.published_at DESC, pp.id DESC LIMIT 1
+                )
+            )
+        )
+    )
+)
+SELECT
+    total.count AS total,
+    page.member_id,
+    page.first_name,
+    page.last_name,
+    page.email,
+    page.date_of_birth,
+    page.tax_code,
+    page.membership_number,
+    page.season_starts_at,
+    page.season_ends_at,
+    page.exclusion_deliberated_at,
+    page.price,
+    page.currency,
+    page.membership_status,
+    page.transactions,
+    page.has_rented_facilities,
+    page.has_unpaid_facilities,
+    page.has_flagged_rentals,
+    page.category
+FROM (SELECT COUNT(*) AS count FROM filtered) total
+-- Keeps the total when the page is empty
+LEFT JOIN LATERAL (
+    SELECT *
+    FROM filtered f
+    ORDER BY
+        CASE WHEN $7 = 'firstName' AND NOT $8 THEN f.first_name END ASC,
+        CASE WHEN $7 = 'firstName' AND $8 THEN f.first_name END DESC,
+        CASE WHEN $7 = 'membershipNumber' AND NOT $8 THEN f.membership_number END ASC,
+        CASE WHEN $7 = 'membershipNumber' AND $8 THEN f.membership_number END DESC,
+        CASE WHEN $7 = 'birthDate' AND NOT $8 THEN f.date_of_birth END ASC,
+        CASE WHEN $7 = 'birthDate' AND $8 THEN f.date_of_birth END DESC,
+        CASE WHEN $7 = 'lastName' AND $8 THEN f.last_name END DESC,
+        CASE WHEN $7 = 'lastName' AND $8 THEN f.first_name END DESC,
+        f.last_name,
+        f.first_name,
+        f.member_id
+    LIMIT $9 OFFSET $10
+) page ON true
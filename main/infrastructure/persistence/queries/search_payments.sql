@@ -0,0 +1,39 @@
+-- Search the payments and refunds, the latest first
+-- $3 is the exclusive upper bound of the payment date
+WITH filtered AS (
+    SELECT pr.*
+    FROM payment_records pr
+    WHERE ($1::bigint IS NULL OR pr.season_id = $1)
+    AND ($2::timestamp IS NULL OR pr.paid_at >= $2)
+    AND ($3::timestamp IS NULL OR pr.paid_at < $3)
+    AND ($4::text IS NULL OR LOWER(pr.payment_method) = LOWER($4))
+    AND ($5::bigint IS NULL OR pr.member_id = $5)
+    AND ($6::text IS NULL OR pr.target = $6)
+    AND ($7::numeric IS NULL OR pr.amount >= $7)
+    AND ($8::numeric IS NULL OR pr.amount <= $8)
+)
+SELECT
+    total.count AS total,
+    page.id,
+    page.type,
+    page.amount,
+    page.currency,
+    page.paid_at,
+    page.payment_method,
+    page.notes,
+    page.target,
+    page.membership_period_id,
+    page.rented_facility_id,
+    page.member_id,
+    page.first_name,
+    page.last_name,
+    page.season_id,
+    page.facility_identifier
+FROM (SELECT COUNT(*) AS count FROM filtered) total
+-- Keeps the total when the page is empty
+LEFT JOIN LATERAL (
+    SELECT *
+    FROM filtered f
+    ORDER BY f.paid_at DESC, f.id DESC
+    LIMIT $9 OFFSET $10
+) page ON true
@@ -0,0 +1,4 @@
+UPDATE members
+SET household_id = $1
+WHERE id = $2
+AND removed_at IS NULL
@@ -0,0 +1,5 @@
+-- Set (or clear, with NULL) the end of the preemption window of a season
+UPDATE seasons
+SET preemption_ends_at = $2
+WHERE id = $1
+RETURNING id, code, name, starts_at, ends_at, closed_at, preemption_ends_at;
@@ -4,6 +4,11 @@ SET
     last_name = $2,
     date_of_birth = $3,
     email = $4,
-    tax_code = $5
+    tax_code = $5,
+    company_name = $7,
+    vat_number = $8,
+    recipient_code = $9,
+    pec = $10
 WHERE id = $6
+AND removed_at IS NULL
 RETURNING id;
@@ -0,0 +1,9 @@
+-- Change the status of a membership period
+-- Exclusion decision date and reason are only kept for excluded memberships
+UPDATE membership_periods
+SET
+    status_id = (SELECT id FROM membership_statuses WHERE status = $2),
+    exclusion_deliberated_at = $3,
+    exclusion_reason = $4
+WHERE id = $1
+RETURNING id;
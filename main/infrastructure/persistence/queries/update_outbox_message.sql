@@ -0,0 +1,8 @@
+UPDATE email_outbox
+SET
+    status = $2,
+    attempts = $3,
+    last_error = NULLIF($4, ''),
+    next_attempt_at = $5,
+    sent_at = $6
+WHERE id = $1;
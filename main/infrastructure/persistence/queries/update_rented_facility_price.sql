@@ -1,3 +1,3 @@
 UPDATE rented_facilities
-SET price = $2
+SET price = $2, currency = $3
 WHERE id = $1 AND deleted_at IS NULL;
@@ -0,0 +1,8 @@
+UPDATE seasons
+SET
+    code = $1,
+    name = $2,
+    starts_at = $3,
+    ends_at = $4
+WHERE id = $5
+RETURNING id, code, name, starts_at, ends_at, closed_at, preemption_ends_at;
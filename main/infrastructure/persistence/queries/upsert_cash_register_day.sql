@@ -0,0 +1,11 @@
+-- Returns no row when the day was already closed
+INSERT INTO cash_register_days (day, opening_balance, counted_cash, bank_transfers, notes, closed_at)
+VALUES ($1::date, $2, $3, $4, $5, $6)
+ON CONFLICT (day) DO UPDATE SET
+    opening_balance = EXCLUDED.opening_balance,
+    counted_cash = EXCLUDED.counted_cash,
+    bank_transfers = EXCLUDED.bank_transfers,
+    notes = EXCLUDED.notes,
+    closed_at = EXCLUDED.closed_at
+WHERE cash_register_days.closed_at IS NULL
+RETURNING day;
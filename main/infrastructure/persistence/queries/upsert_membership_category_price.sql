@@ -0,0 +1,7 @@
+-- Set the price of a membership category in a season
+INSERT INTO membership_category_prices (category_id, season_id, price, currency)
+SELECT id, $2, $3, $4
+FROM membership_categories
+WHERE code = $1
+  AND active
+ON CONFLICT (category_id, season_id) DO UPDATE SET price = EXCLUDED.price, currency = EXCLUDED.currency;
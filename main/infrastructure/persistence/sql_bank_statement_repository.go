@@ -0,0 +1,299 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+//go:embed queries/insert_bank_statement.sql
+var insertBankStatementQuery string
+
+//go:embed queries/insert_bank_transaction.sql
+var insertBankTransactionQuery string
+
+//go:embed queries/get_bank_statements.sql
+var getBankStatementsQuery string
+
+//go:embed queries/get_bank_transaction.sql
+var getBankTransactionQuery string
+
+//go:embed queries/link_bank_transaction_payment.sql
+var linkBankTransactionPaymentQuery string
+
+//go:embed queries/get_outstanding_items.sql
+var getOutstandingItemsQuery string
+
+type SQLBankStatementRepository struct {
+	db *sql.DB
+}
+
+func NewSQLBankStatementRepository(db *sql.DB) *SQLBankStatementRepository {
+	return &SQLBankStatementRepository{db: db}
+}
+
+func (r *SQLBankStatementRepository) CreateStatement(statement payment.BankStatement) result.Result[payment.BankStatement] {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[payment.BankStatement](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	var statementId int64
+	accountIBAN := sql.NullString{String: statement.AccountIBAN, Valid: statement.AccountIBAN != ""}
+	err = tx.QueryRowContext(ctx, insertBankStatementQuery, statement.FileName, string(statement.Format), accountIBAN).Scan(&statementId, &statement.ImportedAt)
+	if err != nil {
+		return result.Err[payment.BankStatement](errors.RepositoryError{Description: "failed to insert bank statement: " + err.Error()})
+	}
+	statement.Id = domain.NewId[payment.BankStatement](statementId)
+
+	stored := []payment.BankTransaction{}
+	fingerprints := statement.Fingerprints()
+	for i, transaction := range statement.Transactions {
+		var id int64
+		err := tx.QueryRowContext(ctx, insertBankTransactionQuery,
+			statementId,
+			fingerprints[i],
+			sql.NullString{String: transaction.Reference, Valid: transaction.Reference != ""},
+			pgDate(transaction.BookingDate),
+			transaction.Amount,
+			currencyOf(transaction.Amount),
+			sql.NullString{String: transaction.CounterpartyName, Valid: transaction.CounterpartyName != ""},
+			sql.NullString{String: transaction.CounterpartyIBAN, Valid: transaction.CounterpartyIBAN != ""},
+			sql.NullString{String: transaction.RemittanceInfo, Valid: transaction.RemittanceInfo != ""},
+		).Scan(&id)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return result.Err[payment.BankStatement](errors.RepositoryError{Description: "failed to insert bank transaction: " + err.Error()})
+		}
+		transaction.Id = domain.NewId[payment.BankTransaction](id)
+		transaction.StatementId = statement.Id
+		stored = append(stored, transaction)
+	}
+	statement.Transactions = stored
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[payment.BankStatement](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return result.Ok(statement)
+}
+
+func (r *SQLBankStatementRepository) GetStatements() result.Result[[]payment.BankStatement] {
+	statements, err := r.queryStatements(nil)
+	if err != nil {
+		return result.Err[[]payment.BankStatement](err)
+	}
+	return result.Ok(statements)
+}
+
+func (r *SQLBankStatementRepository) GetStatement(id domain.Id[payment.BankStatement]) result.Result[payment.BankStatement] {
+	statements, err := r.queryStatements(&id.Value)
+	if err != nil {
+		return result.Err[payment.BankStatement](err)
+	}
+	if len(statements) == 0 {
+		return result.Err[payment.BankStatement](errors.NotFoundError{Description: "bank statement not found"})
+	}
+	return result.Ok(statements[0])
+}
+
+func (r *SQLBankStatementRepository) GetTransaction(id domain.Id[payment.BankTransaction]) result.Result[payment.BankTransaction] {
+	var statementId int64
+	var reference, counterpartyName, counterpartyIBAN, remittanceInfo sql.NullString
+	var paymentId sql.NullInt64
+	var amount float64
+	var currency string
+	var transaction payment.BankTransaction
+	err := r.db.QueryRowContext(context.Background(), getBankTransactionQuery, id.Value).Scan(
+		&transaction.Id.Value,
+		&statementId,
+		&reference,
+		&transaction.BookingDate,
+		&amount,
+		&currency,
+		&counterpartyName,
+		&counterpartyIBAN,
+		&remittanceInfo,
+		&paymentId,
+	)
+	if err == sql.ErrNoRows {
+		return result.Err[payment.BankTransaction](errors.NotFoundError{Description: "bank transaction not found"})
+	}
+	if err != nil {
+		return result.Err[payment.BankTransaction](errors.RepositoryError{Description: "failed to get bank transaction: " + err.Error()})
+	}
+	transaction.StatementId = domain.NewId[payment.BankStatement](statementId)
+	transaction.Amount = amountOf(amount, currency)
+	transaction.Reference = reference.String
+	transaction.CounterpartyName = counterpartyName.String
+	transaction.CounterpartyIBAN = counterpartyIBAN.String
+	transaction.RemittanceInfo = remittanceInfo.String
+	if paymentId.Valid {
+		transaction.PaymentId = &paymentId.Int64
+	}
+	return result.Ok(transaction)
+}
+
+func (r *SQLBankStatementRepository) LinkPayment(id domain.Id[payment.BankTransaction], paymentId int64) result.Result[bool] {
+	res, err := r.db.ExecContext(context.Background(), linkBankTransactionPaymentQuery, id.Value, paymentId)
+	if err != nil {
+		return result.Err[bool](errors.RepositoryError{Description: "failed to link payment: " + err.Error()})
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return result.Err[bool](errors.RepositoryError{Description: "failed to link payment: " + err.Error()})
+	}
+	if affected == 0 {
+		return result.Err[bool](errors.PaymentError{Description: "the bank transaction was already matched"})
+	}
+	return result.Ok(true)
+}
+
+func (r *SQLBankStatementRepository) GetOutstandingItems() result.Result[[]payment.OutstandingItem] {
+	return queryOutstandingItems(r.db, nil)
+}
+
+// queryOutstandingItems returns the memberships and rentals still to be settled, of a single season when given
+func queryOutstandingItems(db *sql.DB, seasonId *int64) result.Result[[]payment.OutstandingItem] {
+	rows, err := db.QueryContext(context.Background(), getOutstandingItemsQuery, seasonId)
+	if err != nil {
+		return result.Err[[]payment.OutstandingItem](errors.RepositoryError{Description: "failed to get outstanding items: " + err.Error()})
+	}
+	defer rows.Close()
+
+	items := []payment.OutstandingItem{}
+	for rows.Next() {
+		var target string
+		var membershipPeriodId, rentedFacilityId, facilityTypeId sql.NullInt64
+		var taxCode, facilityName, facilityIdentifier sql.NullString
+		var due, balance float64
+		var item payment.OutstandingItem
+		err := rows.Scan(
+			&target,
+			&membershipPeriodId,
+			&rentedFacilityId,
+			&item.MemberId,
+			&item.FirstName,
+			&item.LastName,
+			&taxCode,
+			&item.SeasonId,
+			&facilityTypeId,
+			&facilityName,
+			&facilityIdentifier,
+			&due,
+			&balance,
+		)
+		if err != nil {
+			return result.Err[[]payment.OutstandingItem](errors.RepositoryError{Description: "failed to scan outstanding item: " + err.Error()})
+		}
+		item.Target = payment.PaymentTarget(target)
+		item.Due = priceOf(due)
+		item.Balance = priceOf(balance)
+		if membershipPeriodId.Valid {
+			item.MembershipPeriodId = &membershipPeriodId.Int64
+		}
+		if rentedFacilityId.Valid {
+			item.RentedFacilityId = &rentedFacilityId.Int64
+		}
+		if facilityTypeId.Valid {
+			item.FacilityTypeId = &facilityTypeId.Int64
+		}
+		item.TaxCode = taxCode.String
+		item.FacilityName = facilityName.String
+		item.FacilityIdentifier = facilityIdentifier.String
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]payment.OutstandingItem](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(items)
+}
+
+// queryStatements returns the statements with their transactions, only the given one when an id is passed
+func (r *SQLBankStatementRepository) queryStatements(id *int64) ([]payment.BankStatement, error) {
+	rows, err := r.db.QueryContext(context.Background(), getBankStatementsQuery, id)
+	if err != nil {
+		return nil, errors.RepositoryError{Description: "failed to get bank statements: " + err.Error()}
+	}
+	defer rows.Close()
+
+	statements := []payment.BankStatement{}
+	for rows.Next() {
+		var statementId int64
+		var fileName, format string
+		var accountIBAN sql.NullString
+		var importedAt time.Time
+		var transactionId, paymentId sql.NullInt64
+		var reference, currency, counterpartyName, counterpartyIBAN, remittanceInfo sql.NullString
+		var bookingDate sql.NullTime
+		var amount sql.NullFloat64
+		err := rows.Scan(
+			&statementId,
+			&fileName,
+			&format,
+			&accountIBAN,
+			&importedAt,
+			&transactionId,
+			&reference,
+			&bookingDate,
+			&amount,
+			&currency,
+			&counterpartyName,
+			&counterpartyIBAN,
+			&remittanceInfo,
+			&paymentId,
+		)
+		if err != nil {
+			return nil, errors.RepositoryError{Description: "failed to scan bank statement: " + err.Error()}
+		}
+
+		if len(statements) == 0 || statements[len(statements)-1].Id.Value != statementId {
+			statements = append(statements, payment.BankStatement{
+				Id:           domain.NewId[payment.BankStatement](statementId),
+				FileName:     fileName,
+				Format:       payment.BankStatementFormat(format),
+				AccountIBAN:  accountIBAN.String,
+				ImportedAt:   importedAt,
+				Transactions: []payment.BankTransaction{},
+			})
+		}
+		if !transactionId.Valid {
+			continue
+		}
+
+		statement := &statements[len(statements)-1]
+		transaction := payment.BankTransaction{
+			Id:               domain.NewId[payment.BankTransaction](transactionId.Int64),
+			StatementId:      statement.Id,
+			Reference:        reference.String,
+			BookingDate:      bookingDate.Time,
+			Amount:           amountOf(amount.Float64, currency.String),
+			CounterpartyName: counterpartyName.String,
+			CounterpartyIBAN: counterpartyIBAN.String,
+			RemittanceInfo:   remittanceInfo.String,
+		}
+		if paymentId.Valid {
+			transaction.PaymentId = &paymentId.Int64
+		}
+		statement.Transactions = append(statement.Transactions, transaction)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.RepositoryError{Description: err.Error()}
+	}
+
+	return statements, nil
+}
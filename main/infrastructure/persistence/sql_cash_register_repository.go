@@ -0,0 +1,203 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+//go:embed queries/get_cash_register_days.sql
+var getCashRegisterDaysQuery string
+
+//go:embed queries/get_previous_counted_cash.sql
+var getPreviousCountedCashQuery string
+
+//go:embed queries/get_cash_register_transactions.sql
+var getCashRegisterTransactionsQuery string
+
+//go:embed queries/upsert_cash_register_day.sql
+var upsertCashRegisterDayQuery string
+
+//go:embed queries/is_cash_register_day_closed.sql
+var isCashRegisterDayClosedQuery string
+
+type SQLCashRegisterRepository struct {
+	db *sql.DB
+}
+
+func NewSQLCashRegisterRepository(db *sql.DB) *SQLCashRegisterRepository {
+	return &SQLCashRegisterRepository{db: db}
+}
+
+func (r *SQLCashRegisterRepository) GetDay(day time.Time) result.Result[payment.CashDay] {
+	days, previousCountedCash, err := r.loadDays(day, day)
+	if err != nil {
+		return result.Err[payment.CashDay](err)
+	}
+	if len(days) > 0 {
+		return result.Ok(days[0])
+	}
+	return result.Ok(openDay(day, previousCountedCash))
+}
+
+func (r *SQLCashRegisterRepository) GetDays(from time.Time, to time.Time) result.Result[[]payment.CashDay] {
+	days, _, err := r.loadDays(from, to)
+	if err != nil {
+		return result.Err[[]payment.CashDay](err)
+	}
+	return result.Ok(days)
+}
+
+func (r *SQLCashRegisterRepository) SaveDay(day payment.CashDay) result.Result[payment.CashDay] {
+	var saved time.Time
+	err := r.db.QueryRowContext(context.Background(), upsertCashRegisterDayQuery,
+		pgDate(day.Date),
+		day.OpeningBalance,
+		day.CountedCash,
+		day.BankTransfers,
+		nullableNotes(day.Notes),
+		day.ClosedAt,
+	).Scan(&saved)
+	if err == sql.ErrNoRows {
+		return result.Err[payment.CashDay](errors.PaymentError{Description: "the cash register of " + day.Date.Format("2006-01-02") + " is closed"})
+	}
+	if err != nil {
+		return result.Err[payment.CashDay](errors.RepositoryError{Description: "failed to save cash register day: " + err.Error()})
+	}
+	return result.Ok(day)
+}
+
+func (r *SQLCashRegisterRepository) IsDayClosed(day time.Time) result.Result[bool] {
+	var closed bool
+	if err := r.db.QueryRowContext(context.Background(), isCashRegisterDayClosedQuery, pgDate(day)).Scan(&closed); err != nil {
+		return result.Err[bool](errors.RepositoryError{Description: "failed to check cash register day: " + err.Error()})
+	}
+	return result.Ok(closed)
+}
+
+// loadDays returns the days between from and to with transactions or recorded values, oldest first,
+// and the cash counted when closing the last closed day before to
+func (r *SQLCashRegisterRepository) loadDays(from time.Time, to time.Time) ([]payment.CashDay, *money.Money, error) {
+	ctx := context.Background()
+
+	var previous sql.NullFloat64
+	err := r.db.QueryRowContext(ctx, getPreviousCountedCashQuery, pgDate(from)).Scan(&previous)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, nil, errors.RepositoryError{Description: "failed to get previous cash register day: " + err.Error()}
+	}
+	previousCountedCash := nullPricePtr(previous)
+
+	recorded, err := r.getRecordedDays(ctx, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	transactions, err := r.getTransactions(ctx, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	days := []payment.CashDay{}
+	for date := payment.Day(from); !date.After(payment.Day(to)); date = date.AddDate(0, 0, 1) {
+		key := pgDate(date)
+		day, ok := recorded[key]
+		if !ok && len(transactions[key]) == 0 {
+			continue
+		}
+		if !ok {
+			day = openDay(date, previousCountedCash)
+		}
+		day.PreviousCountedCash = previousCountedCash
+		day.Transactions = append([]payment.PaymentRecord{}, transactions[key]...)
+		if day.IsClosed() {
+			previousCountedCash = day.CountedCash
+		}
+		days = append(days, day)
+	}
+	return days, previousCountedCash, nil
+}
+
+func (r *SQLCashRegisterRepository) getRecordedDays(ctx context.Context, from time.Time, to time.Time) (map[string]payment.CashDay, error) {
+	rows, err := r.db.QueryContext(ctx, getCashRegisterDaysQuery, pgDate(from), pgDate(to))
+	if err != nil {
+		return nil, errors.RepositoryError{Description: "failed to get cash register days: " + err.Error()}
+	}
+	defer rows.Close()
+
+	days := map[string]payment.CashDay{}
+	for rows.Next() {
+		var day payment.CashDay
+		var openingBalance float64
+		var countedCash, bankTransfers sql.NullFloat64
+		var notes sql.NullString
+		var closedAt sql.NullTime
+		if err := rows.Scan(&day.Date, &openingBalance, &countedCash, &bankTransfers, &notes, &closedAt); err != nil {
+			return nil, errors.RepositoryError{Description: "failed to scan cash register day: " + err.Error()}
+		}
+		day.Date = payment.Day(day.Date)
+		day.OpeningBalance = priceOf(openingBalance)
+		day.CountedCash = nullPricePtr(countedCash)
+		day.BankTransfers = nullPricePtr(bankTransfers)
+		day.Notes = notes.String
+		day.ClosedAt = nullTimePtr(closedAt)
+		days[pgDate(day.Date)] = day
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.RepositoryError{Description: err.Error()}
+	}
+
+	return days, nil
+}
+
+// getTransactions returns the transactions between from and to by the day they were paid
+func (r *SQLCashRegisterRepository) getTransactions(ctx context.Context, from time.Time, to time.Time) (map[string][]payment.PaymentRecord, error) {
+	rows, err := r.db.QueryContext(ctx, getCashRegisterTransactionsQuery, pgDate(from), pgDate(to))
+	if err != nil {
+		return nil, errors.RepositoryError{Description: "failed to get cash register transactions: " + err.Error()}
+	}
+	defer rows.Close()
+
+	transactions := map[string][]payment.PaymentRecord{}
+	for rows.Next() {
+		var record paymentRecordRow
+		if err := rows.Scan(record.targets()...); err != nil {
+			return nil, errors.RepositoryError{Description: "failed to scan payment: " + err.Error()}
+		}
+		key := pgDate(record.paidAt.Time)
+		transactions[key] = append(transactions[key], record.toDomain())
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.RepositoryError{Description: err.Error()}
+	}
+
+	return transactions, nil
+}
+
+// openDay is a day nothing was recorded for, which opens with the cash counted the day before
+func openDay(date time.Time, previousCountedCash *money.Money) payment.CashDay {
+	day := payment.CashDay{Date: payment.Day(date), PreviousCountedCash: previousCountedCash, Transactions: []payment.PaymentRecord{}}
+	if previousCountedCash != nil {
+		day.OpeningBalance = *previousCountedCash
+	}
+	return day
+}
+
+// pgDate formats the calendar day of a time, so that it is not shifted by the time zone of the connection
+func pgDate(t time.Time) string {
+	return payment.Day(t).Format("2006-01-02")
+}
+
+func nullPricePtr(value sql.NullFloat64) *money.Money {
+	if !value.Valid {
+		return nil
+	}
+	price := priceOf(value.Float64)
+	return &price
+}
@@ -0,0 +1,146 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+//go:embed queries/get_current_privacy_policy.sql
+var getCurrentPrivacyPolicyQuery string
+
+//go:embed queries/get_privacy_policies.sql
+var getPrivacyPoliciesQuery string
+
+//go:embed queries/insert_privacy_policy.sql
+var insertPrivacyPolicyQuery string
+
+//go:embed queries/get_member_consent_history.sql
+var getMemberConsentHistoryQuery string
+
+//go:embed queries/insert_member_consent.sql
+var insertMemberConsentQuery string
+
+type SQLConsentRepository struct {
+	db *sql.DB
+}
+
+func NewSQLConsentRepository(db *sql.DB) *SQLConsentRepository {
+	return &SQLConsentRepository{db: db}
+}
+
+func (r *SQLConsentRepository) GetCurrentPolicy() result.Result[membership.PrivacyPolicy] {
+	var policy membership.PrivacyPolicy
+	err := r.db.QueryRowContext(context.Background(), getCurrentPrivacyPolicyQuery).Scan(&policy.Version, &policy.Text, &policy.PublishedAt)
+	if err == sql.ErrNoRows {
+		return result.Err[membership.PrivacyPolicy](errors.NotFoundError{Description: "no privacy policy has been published"})
+	}
+	if err != nil {
+		return result.Err[membership.PrivacyPolicy](errors.RepositoryError{Description: "failed to get privacy policy: " + err.Error()})
+	}
+	return result.Ok(policy)
+}
+
+func (r *SQLConsentRepository) GetPolicies() result.Result[[]membership.PrivacyPolicy] {
+	rows, err := r.db.QueryContext(context.Background(), getPrivacyPoliciesQuery)
+	if err != nil {
+		return result.Err[[]membership.PrivacyPolicy](errors.RepositoryError{Description: "failed to get privacy policies: " + err.Error()})
+	}
+	defer rows.Close()
+
+	policies := []membership.PrivacyPolicy{}
+	for rows.Next() {
+		var policy membership.PrivacyPolicy
+		if err := rows.Scan(&policy.Version, &policy.Text, &policy.PublishedAt); err != nil {
+			return result.Err[[]membership.PrivacyPolicy](errors.RepositoryError{Description: "failed to scan privacy policy: " + err.Error()})
+		}
+		policies = append(policies, policy)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]membership.PrivacyPolicy](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(policies)
+}
+
+func (r *SQLConsentRepository) PublishPolicy(policy membership.PrivacyPolicy) result.Result[membership.PrivacyPolicy] {
+	err := r.db.QueryRowContext(context.Background(), insertPrivacyPolicyQuery, policy.Version, policy.Text).Scan(&policy.PublishedAt)
+	if err == sql.ErrNoRows {
+		return result.Err[membership.PrivacyPolicy](errors.ConsentError{Description: "privacy policy " + policy.Version + " was already published"})
+	}
+	if err != nil {
+		return result.Err[membership.PrivacyPolicy](errors.RepositoryError{Description: "failed to insert privacy policy: " + err.Error()})
+	}
+	return result.Ok(policy)
+}
+
+func (r *SQLConsentRepository) GetConsentHistory(userId domain.Id[membership.User]) result.Result[[]membership.Consent] {
+	rows, err := r.db.QueryContext(context.Background(), getMemberConsentHistoryQuery, userId.Value)
+	if err != nil {
+		return result.Err[[]membership.Consent](errors.RepositoryError{Description: "failed to get consents: " + err.Error()})
+	}
+	defer rows.Close()
+
+	consents := []membership.Consent{}
+	for rows.Next() {
+		var consentType string
+		var policyVersion sql.NullString
+		var consent membership.Consent
+		if err := rows.Scan(&consentType, &consent.Granted, &policyVersion, &consent.RecordedAt); err != nil {
+			return result.Err[[]membership.Consent](errors.RepositoryError{Description: "failed to scan consent: " + err.Error()})
+		}
+		consent.Type = membership.ConsentType(consentType)
+		consent.PolicyVersion = policyVersion.String
+		consents = append(consents, consent)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]membership.Consent](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(consents)
+}
+
+func (r *SQLConsentRepository) RecordConsents(userId domain.Id[membership.User], consents []membership.Consent) result.Result[[]membership.Consent] {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[[]membership.Consent](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	recorded, err := insertConsents(ctx, tx, userId.Value, consents)
+	if err != nil {
+		return result.Err[[]membership.Consent](err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[[]membership.Consent](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return result.Ok(recorded)
+}
+
+// insertConsents appends the consents to the history of the member, returning them with their recording time
+func insertConsents(ctx context.Context, db queryRower, memberId int64, consents []membership.Consent) ([]membership.Consent, error) {
+	recorded := make([]membership.Consent, len(consents))
+	for i, consent := range consents {
+		policyVersion := sql.NullString{String: consent.PolicyVersion, Valid: consent.PolicyVersion != ""}
+		err := db.QueryRowContext(ctx, insertMemberConsentQuery, memberId, string(consent.Type), consent.Granted, policyVersion).Scan(&consent.RecordedAt)
+		if err == sql.ErrNoRows {
+			return nil, errors.NotFoundError{Description: "Member not found or removed"}
+		}
+		if err != nil {
+			return nil, errors.RepositoryError{Description: "failed to insert consent: " + err.Error()}
+		}
+		recorded[i] = consent
+	}
+	return recorded, nil
+}
@@ -0,0 +1,160 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+//go:embed queries/insert_reminder_letter.sql
+var insertReminderLetterQuery string
+
+//go:embed queries/get_reminder_letters.sql
+var getReminderLettersQuery string
+
+type SQLDunningRepository struct {
+	db *sql.DB
+}
+
+func NewSQLDunningRepository(db *sql.DB) *SQLDunningRepository {
+	return &SQLDunningRepository{db: db}
+}
+
+func (r *SQLDunningRepository) GetOutstandingItems(seasonId int64) result.Result[[]payment.OutstandingItem] {
+	return queryOutstandingItems(r.db, &seasonId)
+}
+
+func (r *SQLDunningRepository) GetReminders(criteria club.ReminderCriteria) result.Result[[]club.ReminderLetter] {
+	letters, err := r.queryReminders(nil, criteria)
+	if err != nil {
+		return result.Err[[]club.ReminderLetter](err)
+	}
+	return result.Ok(letters)
+}
+
+func (r *SQLDunningRepository) GetReminder(id int64) result.Result[club.ReminderLetter] {
+	letters, err := r.queryReminders(&id, club.ReminderCriteria{})
+	if err != nil {
+		return result.Err[club.ReminderLetter](err)
+	}
+	if len(letters) == 0 {
+		return result.Err[club.ReminderLetter](errors.NotFoundError{Description: "reminder letter not found"})
+	}
+	return result.Ok(letters[0])
+}
+
+func (r *SQLDunningRepository) CreateReminders(letters []club.ReminderLetter) result.Result[[]club.ReminderLetter] {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[[]club.ReminderLetter](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	created := make([]club.ReminderLetter, 0, len(letters))
+	for _, letter := range letters {
+		recipient, err := json.Marshal(ConvertReminderRecipientToDetail(letter.Recipient))
+		if err != nil {
+			return result.Err[[]club.ReminderLetter](errors.RepositoryError{Description: "failed to encode recipient: " + err.Error()})
+		}
+		items, err := json.Marshal(ConvertReminderItemsToDetails(letter.Items))
+		if err != nil {
+			return result.Err[[]club.ReminderLetter](errors.RepositoryError{Description: "failed to encode items: " + err.Error()})
+		}
+
+		err = tx.QueryRowContext(ctx, insertReminderLetterQuery,
+			letter.MemberId.Value,
+			letter.SeasonId,
+			string(letter.Level),
+			pgDate(letter.SentOn),
+			recipient,
+			items,
+		).Scan(&letter.Id)
+		if err == sql.ErrNoRows {
+			return result.Err[[]club.ReminderLetter](errors.DunningError{Description: "the letter " + string(letter.Level) + " was already sent to " + letter.Recipient.FirstName + " " + letter.Recipient.LastName})
+		}
+		if err != nil {
+			return result.Err[[]club.ReminderLetter](errors.RepositoryError{Description: "failed to insert reminder letter: " + err.Error()})
+		}
+		created = append(created, letter)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[[]club.ReminderLetter](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return result.Ok(created)
+}
+
+// queryReminders returns the letters matching the criteria, only the given one when an id is passed
+func (r *SQLDunningRepository) queryReminders(id *int64, criteria club.ReminderCriteria) ([]club.ReminderLetter, error) {
+	var seasonId *int64
+	if criteria.SeasonId != 0 {
+		seasonId = &criteria.SeasonId
+	}
+	var sentOn *string
+	if criteria.SentOn != nil {
+		value := pgDate(*criteria.SentOn)
+		sentOn = &value
+	}
+
+	rows, err := r.db.QueryContext(context.Background(), getReminderLettersQuery, id, seasonId, criteria.MemberId, sentOn)
+	if err != nil {
+		return nil, errors.RepositoryError{Description: "failed to get reminder letters: " + err.Error()}
+	}
+	defer rows.Close()
+
+	letters := []club.ReminderLetter{}
+	for rows.Next() {
+		var letter club.ReminderLetter
+		var memberId int64
+		var level string
+		var sentOn time.Time
+		var recipient, items []byte
+		err := rows.Scan(
+			&letter.Id,
+			&memberId,
+			&letter.SeasonId,
+			&letter.SeasonCode,
+			&level,
+			&sentOn,
+			&recipient,
+			&items,
+		)
+		if err != nil {
+			return nil, errors.RepositoryError{Description: "failed to scan reminder letter: " + err.Error()}
+		}
+
+		var recipientDetail ReminderRecipientDetail
+		var itemDetails []ReminderItemDetail
+		if err := json.Unmarshal(recipient, &recipientDetail); err != nil {
+			return nil, errors.RepositoryError{Description: "failed to parse recipient: " + err.Error()}
+		}
+		if err := json.Unmarshal(items, &itemDetails); err != nil {
+			return nil, errors.RepositoryError{Description: "failed to parse reminder items: " + err.Error()}
+		}
+
+		letter.MemberId = domain.NewId[membership.Member](memberId)
+		letter.Level = club.DunningLevel(level)
+		letter.SentOn = payment.Day(sentOn)
+		letter.Recipient = ConvertDetailToReminderRecipient(recipientDetail)
+		letter.Items = ConvertDetailsToReminderItems(itemDetails)
+		letters = append(letters, letter)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.RepositoryError{Description: err.Error()}
+	}
+
+	return letters, nil
+}
@@ -10,6 +10,7 @@ import (
 	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
 )
 
@@ -61,6 +62,12 @@ var deleteRentedFacilityQuery string
 //go:embed queries/update_rented_facility_price.sql
 var updateRentedFacilityPriceQuery string
 
+//go:embed queries/get_pending_preemptions.sql
+var getPendingPreemptionsQuery string
+
+//go:embed queries/lock_facility.sql
+var lockFacilityQuery string
+
 type SQLFacilityRepository struct {
 	db *sql.DB
 }
@@ -95,7 +102,7 @@ func (r *SQLFacilityRepository) GetFacilitiesCatalog() []facilityrental.Facility
 			Id:             domain.Id[facilityrental.FacilityType]{Value: id},
 			FacilityName:   facilityrental.ToFacilityName(name),
 			Description:    description.String,
-			SuggestedPrice: suggestedPrice,
+			SuggestedPrice: priceOf(suggestedPrice),
 			HasBoat:        hasBoat,
 			HasLeerboard:   hasLeerboard,
 		}
@@ -170,7 +177,7 @@ func (r *SQLFacilityRepository) GetFacilitiesByType(facilityTypeId domain.Id[fac
 			Identifier:              identifier,
 			FacilityTypeName:        facilityrental.ToFacilityName(facilityTypeName),
 			FacilityTypeDescription: facilityTypeDescription.String,
-			SuggestedPrice:          suggestedPrice,
+			SuggestedPrice:          priceOf(suggestedPrice),
 			IsRented:                isRented,
 			ExpiresAt:               expiresAtPtr,
 			RentedByMemberId:        memberIdPtr,
@@ -240,7 +247,7 @@ func (r *SQLFacilityRepository) GetFacilityById(facilityId domain.Id[facilityren
 		Identifier:              identifier,
 		FacilityTypeName:        facilityrental.ToFacilityName(facilityTypeName),
 		FacilityTypeDescription: facilityTypeDescription.String,
-		SuggestedPrice:          suggestedPrice,
+		SuggestedPrice:          priceOf(suggestedPrice),
 		IsRented:                isRented,
 		ExpiresAt:               expiresAtPtr,
 		RentedByMemberId:        memberIdPtr,
@@ -272,6 +279,7 @@ func (r *SQLFacilityRepository) GetFacilitiesRentedByMember(memberId domain.Id[m
 			&dto.RentedAt,
 			&dto.ExpiresAt,
 			&dto.Price,
+			&dto.Currency,
 			&dto.DiscountApplied,
 			&dto.FacilityID,
 			&dto.FacilityIdentifier,
@@ -293,19 +301,19 @@ func (r *SQLFacilityRepository) GetFacilitiesRentedByMember(memberId domain.Id[m
 			&dto.LeerboardColor,
 			&dto.LeerboardType,
 			&dto.LeerboardLength,
-			&dto.PaymentID,
-			&dto.PaymentAmount,
-			&dto.PaymentCurrency,
-			&dto.PaymentPaidAt,
-			&dto.PaymentMethod,
-			&dto.PaymentNotes,
+			&dto.FlaggedAt,
+			&dto.FlagReason,
+			&dto.Transactions,
 		)
 		if err != nil {
 			continue
 		}
 
 		rentedFacility := ConvertDTOToRentedFacility(dto)
-		rentedFacilities = append(rentedFacilities, rentedFacility)
+		if !rentedFacility.IsSuccess() {
+			continue
+		}
+		rentedFacilities = append(rentedFacilities, rentedFacility.Value())
 	}
 
 	return rentedFacilities
@@ -315,10 +323,11 @@ func (r *SQLFacilityRepository) RentFacility(
 	memberId domain.Id[membership.User],
 	facilityId domain.Id[facilityrental.Facility],
 	season int64,
-	price float64,
+	price money.Money,
 	discountApplied bool,
 	boatInfo *facilityrental.BoatInfo,
 	leerboardInfo *facilityrental.LeerboardInfo,
+	date time.Time,
 ) result.Result[facilityrental.RentedFacility] {
 	ctx := context.Background()
 
@@ -329,6 +338,28 @@ func (r *SQLFacilityRepository) RentFacility(
 	}
 	defer tx.Rollback()
 
+	// Lock the facility, so that the right of preemption is checked against the rentals committed before
+	var lockedFacilityId int64
+	if err = tx.QueryRowContext(ctx, lockFacilityQuery, facilityId.Value).Scan(&lockedFacilityId); err != nil {
+		if err == sql.ErrNoRows {
+			return result.Err[facilityrental.RentedFacility](errors.NotFoundError{Description: "facility not found"})
+		}
+		return result.Err[facilityrental.RentedFacility](errors.RepositoryError{Description: "failed to lock facility: " + err.Error()})
+	}
+
+	// Facilities under a pending right of preemption are reserved to their previous holder
+	rows, err := tx.QueryContext(ctx, getPendingPreemptionsQuery, season, date)
+	if err != nil {
+		return result.Err[facilityrental.RentedFacility](errors.RepositoryError{Description: "failed to query pending preemptions: " + err.Error()})
+	}
+	preemptions, err := scanPreemptions(rows)
+	if err != nil {
+		return result.Err[facilityrental.RentedFacility](err)
+	}
+	if err = facilityrental.CheckPreemption(preemptions, facilityId, memberId); err != nil {
+		return result.Err[facilityrental.RentedFacility](err)
+	}
+
 	// Insert facility rental
 	var rentedFacilityId int64
 	err = tx.QueryRowContext(ctx, insertRentedFacilityQuery,
@@ -337,6 +368,7 @@ func (r *SQLFacilityRepository) RentFacility(
 		season,
 		price,
 		discountApplied,
+		currencyOf(price),
 	).Scan(&rentedFacilityId)
 	if err != nil {
 		return result.Err[facilityrental.RentedFacility](errors.RepositoryError{Description: "failed to insert facility rental: " + err.Error()})
@@ -344,69 +376,15 @@ func (r *SQLFacilityRepository) RentFacility(
 
 	// Insert boat info if provided
 	if boatInfo != nil {
-		engineInfo := sql.NullString{Valid: false}
-		if boatInfo.EngineInfo != "" {
-			engineInfo = sql.NullString{String: boatInfo.EngineInfo, Valid: true}
-		}
-
-		widthMeters := sql.NullFloat64{Valid: false}
-		if boatInfo.WidthMeters != nil {
-			widthMeters = sql.NullFloat64{Float64: *boatInfo.WidthMeters, Valid: true}
-		}
-
-		boatType := sql.NullString{Valid: false}
-		if boatInfo.Type != "" {
-			boatType = sql.NullString{String: boatInfo.Type, Valid: true}
-		}
-
-		var boatId int64
-		err = tx.QueryRowContext(ctx, insertBoatQuery,
-			rentedFacilityId,
-			boatInfo.Name,
-			boatInfo.LengthMeters,
-			widthMeters,
-			engineInfo,
-			boatType,
-		).Scan(&boatId)
-		if err != nil {
-			return result.Err[facilityrental.RentedFacility](errors.RepositoryError{Description: "failed to insert boat info: " + err.Error()})
-		}
-
-		// Insert insurance info if boat has insurance
-		if boatInfo.HasInsurance() {
-			if insurance, ok := boatInfo.InsuranceInfo.(facilityrental.BoatInsurance); ok {
-				_, err = tx.ExecContext(ctx, insertInsuranceQuery,
-					boatId,
-					insurance.ProviderName,
-					insurance.PolicyNumber,
-					insurance.ExpirationDate,
-				)
-				if err != nil {
-					return result.Err[facilityrental.RentedFacility](errors.RepositoryError{Description: "failed to insert insurance info: " + err.Error()})
-				}
-			}
+		if err = insertBoatInfo(ctx, tx, rentedFacilityId, *boatInfo); err != nil {
+			return result.Err[facilityrental.RentedFacility](err)
 		}
 	}
 
 	// Insert leerboard info if provided
 	if leerboardInfo != nil {
-		color := sql.NullString{Valid: false}
-		if leerboardInfo.Color != "" {
-			color = sql.NullString{String: leerboardInfo.Color, Valid: true}
-		}
-		leerboardType := sql.NullString{Valid: false}
-		if leerboardInfo.Type != "" {
-			leerboardType = sql.NullString{String: leerboardInfo.Type, Valid: true}
-		}
-
-		_, err = tx.ExecContext(ctx, insertLeerboardQuery,
-			rentedFacilityId,
-			color,
-			leerboardType,
-			leerboardInfo.LengthMeters,
-		)
-		if err != nil {
-			return result.Err[facilityrental.RentedFacility](errors.RepositoryError{Description: "failed to insert leerboard info: " + err.Error()})
+		if err = insertLeerboardInfo(ctx, tx, rentedFacilityId, *leerboardInfo); err != nil {
+			return result.Err[facilityrental.RentedFacility](err)
 		}
 	}
 
@@ -425,6 +403,78 @@ func (r *SQLFacilityRepository) RentFacility(
 	return result.Err[facilityrental.RentedFacility](errors.RepositoryError{Description: "failed to retrieve inserted facility id"})
 }
 
+// insertBoatInfo stores the boat of a rented facility, together with its insurance if any
+func insertBoatInfo(ctx context.Context, tx *sql.Tx, rentedFacilityId int64, boatInfo facilityrental.BoatInfo) error {
+	engineInfo := sql.NullString{Valid: false}
+	if boatInfo.EngineInfo != "" {
+		engineInfo = sql.NullString{String: boatInfo.EngineInfo, Valid: true}
+	}
+
+	widthMeters := sql.NullFloat64{Valid: false}
+	if boatInfo.WidthMeters != nil {
+		widthMeters = sql.NullFloat64{Float64: *boatInfo.WidthMeters, Valid: true}
+	}
+
+	boatType := sql.NullString{Valid: false}
+	if boatInfo.Type != "" {
+		boatType = sql.NullString{String: boatInfo.Type, Valid: true}
+	}
+
+	var boatId int64
+	err := tx.QueryRowContext(ctx, insertBoatQuery,
+		rentedFacilityId,
+		boatInfo.Name,
+		boatInfo.LengthMeters,
+		widthMeters,
+		engineInfo,
+		boatType,
+	).Scan(&boatId)
+	if err != nil {
+		return errors.RepositoryError{Description: "failed to insert boat info: " + err.Error()}
+	}
+
+	// Insert insurance info if boat has insurance
+	if boatInfo.HasInsurance() {
+		if insurance, ok := boatInfo.InsuranceInfo.(facilityrental.BoatInsurance); ok {
+			_, err = tx.ExecContext(ctx, insertInsuranceQuery,
+				boatId,
+				insurance.ProviderName,
+				insurance.PolicyNumber,
+				insurance.ExpirationDate,
+			)
+			if err != nil {
+				return errors.RepositoryError{Description: "failed to insert insurance info: " + err.Error()}
+			}
+		}
+	}
+
+	return nil
+}
+
+// insertLeerboardInfo stores the leerboard of a rented facility
+func insertLeerboardInfo(ctx context.Context, tx *sql.Tx, rentedFacilityId int64, leerboardInfo facilityrental.LeerboardInfo) error {
+	color := sql.NullString{Valid: false}
+	if leerboardInfo.Color != "" {
+		color = sql.NullString{String: leerboardInfo.Color, Valid: true}
+	}
+	leerboardType := sql.NullString{Valid: false}
+	if leerboardInfo.Type != "" {
+		leerboardType = sql.NullString{String: leerboardInfo.Type, Valid: true}
+	}
+
+	_, err := tx.ExecContext(ctx, insertLeerboardQuery,
+		rentedFacilityId,
+		color,
+		leerboardType,
+		leerboardInfo.LengthMeters,
+	)
+	if err != nil {
+		return errors.RepositoryError{Description: "failed to insert leerboard info: " + err.Error()}
+	}
+
+	return nil
+}
+
 func (r *SQLFacilityRepository) GetPricingRules() []facilityrental.PricingRule {
 	rows, err := r.db.Query(getFacilityPricingRulesQuery)
 	if err != nil {
@@ -464,7 +514,7 @@ func (r *SQLFacilityRepository) GetPricingRules() []facilityrental.PricingRule {
 			Id:                     domain.Id[facilityrental.PricingRule]{Value: id},
 			FacilityTypeId:         domain.Id[facilityrental.FacilityType]{Value: facilityTypeId},
 			RequiredFacilityTypeId: domain.Id[facilityrental.FacilityType]{Value: requiredFacilityTypeId},
-			SpecialPrice:           specialPrice,
+			SpecialPrice:           money.FromFloat(specialPrice, money.Currency(currency)),
 			Description:            description.String,
 			Active:                 active,
 		}
@@ -519,8 +569,7 @@ func (r *SQLFacilityRepository) GetBoatLengthPricingTiers() []facilityrental.Boa
 			FacilityTypeId:  domain.Id[facilityrental.FacilityType]{Value: facilityTypeId},
 			MinLengthMeters: minLengthMeters,
 			MaxLengthMeters: maxLengthPtr,
-			Price:           price,
-			Currency:        currency,
+			Price:           money.FromFloat(price, money.Currency(currency)),
 			Active:          active,
 		}
 		tiers = append(tiers, tier)
@@ -736,7 +785,7 @@ func (r *SQLFacilityRepository) UpdateLeerboardInfo(
 
 func (r *SQLFacilityRepository) UpdatePrice(
 	rentedFacilityId domain.Id[facilityrental.RentedFacility],
-	price float64,
+	price money.Money,
 ) result.Result[facilityrental.RentedFacility] {
 	ctx := context.Background()
 
@@ -744,6 +793,7 @@ func (r *SQLFacilityRepository) UpdatePrice(
 	_, err := r.db.ExecContext(ctx, updateRentedFacilityPriceQuery,
 		rentedFacilityId.Value,
 		price,
+		currencyOf(price),
 	)
 	if err != nil {
 		return result.Err[facilityrental.RentedFacility](
@@ -780,3 +830,58 @@ func (r *SQLFacilityRepository) UpdatePrice(
 		errors.RepositoryError{Description: "failed to find updated rental"},
 	)
 }
+
+func (r *SQLFacilityRepository) GetPendingPreemptions(season int64, date time.Time) result.Result[[]facilityrental.PreemptionRight] {
+	rows, err := r.db.QueryContext(context.Background(), getPendingPreemptionsQuery, season, date)
+	if err != nil {
+		return result.Err[[]facilityrental.PreemptionRight](errors.RepositoryError{Description: "failed to query pending preemptions: " + err.Error()})
+	}
+
+	preemptions, err := scanPreemptions(rows)
+	if err != nil {
+		return result.Err[[]facilityrental.PreemptionRight](err)
+	}
+	return result.Ok(preemptions)
+}
+
+// scanPreemptions reads the rows of the pending preemptions query and closes them
+func scanPreemptions(rows *sql.Rows) ([]facilityrental.PreemptionRight, error) {
+	defer rows.Close()
+
+	preemptions := []facilityrental.PreemptionRight{}
+	for rows.Next() {
+		var facilityId, facilityTypeId, holderId int64
+		var facilityType string
+		var suggestedPrice float64
+		var preemption facilityrental.PreemptionRight
+		err := rows.Scan(
+			&facilityId,
+			&preemption.Facility.Identifier,
+			&facilityTypeId,
+			&facilityType,
+			&preemption.Facility.FacilityType.Description,
+			&suggestedPrice,
+			&holderId,
+			&preemption.HolderFirstName,
+			&preemption.HolderLastName,
+			&preemption.PreviousSeasonId,
+			&preemption.ExpiresAt,
+			&preemption.HolderLeft,
+		)
+		if err != nil {
+			return nil, errors.RepositoryError{Description: "failed to scan pending preemption: " + err.Error()}
+		}
+		preemption.Facility.Id = domain.NewId[facilityrental.Facility](facilityId)
+		preemption.Facility.FacilityType.Id = domain.NewId[facilityrental.FacilityType](facilityTypeId)
+		preemption.Facility.FacilityType.FacilityName = facilityrental.FacilityName(facilityType)
+		preemption.Facility.FacilityType.SuggestedPrice = priceOf(suggestedPrice)
+		preemption.HolderId = domain.NewId[membership.Member](holderId)
+		preemptions = append(preemptions, preemption)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.RepositoryError{Description: err.Error()}
+	}
+
+	return preemptions, nil
+}
@@ -0,0 +1,266 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/lib/pq"
+)
+
+//go:embed queries/get_billable_payments.sql
+var getBillablePaymentsQuery string
+
+//go:embed queries/next_fiscal_document_number.sql
+var nextFiscalDocumentNumberQuery string
+
+//go:embed queries/insert_fiscal_document.sql
+var insertFiscalDocumentQuery string
+
+//go:embed queries/insert_fiscal_document_line.sql
+var insertFiscalDocumentLineQuery string
+
+//go:embed queries/get_fiscal_documents.sql
+var getFiscalDocumentsQuery string
+
+type SQLFiscalDocumentRepository struct {
+	db *sql.DB
+}
+
+func NewSQLFiscalDocumentRepository(db *sql.DB) *SQLFiscalDocumentRepository {
+	return &SQLFiscalDocumentRepository{db: db}
+}
+
+func (r *SQLFiscalDocumentRepository) GetBillablePayments(paymentIds []int64) result.Result[[]payment.BillablePayment] {
+	rows, err := r.db.QueryContext(context.Background(), getBillablePaymentsQuery, pq.Array(paymentIds))
+	if err != nil {
+		return result.Err[[]payment.BillablePayment](errors.RepositoryError{Description: "failed to get payments: " + err.Error()})
+	}
+	defer rows.Close()
+
+	payments := []payment.BillablePayment{}
+	for rows.Next() {
+		var record paymentRecordRow
+		var seasonCode, facilityName, taxCode, street, streetNumber, zipCode, city, country sql.NullString
+		var billing BillingColumns
+		var fiscalDocumentId sql.NullInt64
+		targets := append(record.targets(),
+			&seasonCode, &facilityName, &taxCode,
+			&billing.CompanyName, &billing.VATNumber, &billing.RecipientCode, &billing.PEC,
+			&street, &streetNumber, &zipCode, &city, &country,
+			&fiscalDocumentId,
+		)
+		if err := rows.Scan(targets...); err != nil {
+			return result.Err[[]payment.BillablePayment](errors.RepositoryError{Description: "failed to scan payment: " + err.Error()})
+		}
+
+		billable := payment.BillablePayment{
+			Record:       record.toDomain(),
+			SeasonCode:   seasonCode.String,
+			FacilityName: facilityName.String,
+			TaxCode:      taxCode.String,
+			Address: payment.FiscalAddress{
+				Street:       street.String,
+				StreetNumber: streetNumber.String,
+				ZipCode:      zipCode.String,
+				City:         city.String,
+				Country:      country.String,
+			},
+			CompanyName:   billing.CompanyName.String,
+			VATNumber:     billing.VATNumber.String,
+			RecipientCode: billing.RecipientCode.String,
+			PEC:           billing.PEC.String,
+		}
+		if fiscalDocumentId.Valid {
+			billable.FiscalDocumentId = &fiscalDocumentId.Int64
+		}
+		payments = append(payments, billable)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]payment.BillablePayment](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(payments)
+}
+
+func (r *SQLFiscalDocumentRepository) CreateDocument(document payment.FiscalDocument) result.Result[payment.FiscalDocument] {
+	ctx := context.Background()
+
+	issuer, err := json.Marshal(ConvertIssuerToDetail(document.Issuer))
+	if err != nil {
+		return result.Err[payment.FiscalDocument](errors.RepositoryError{Description: "failed to encode issuer: " + err.Error()})
+	}
+	recipient, err := json.Marshal(ConvertRecipientToDetail(document.Recipient))
+	if err != nil {
+		return result.Err[payment.FiscalDocument](errors.RepositoryError{Description: "failed to encode recipient: " + err.Error()})
+	}
+	notes, err := json.Marshal(document.Notes)
+	if err != nil {
+		return result.Err[payment.FiscalDocument](errors.RepositoryError{Description: "failed to encode notes: " + err.Error()})
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[payment.FiscalDocument](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	if err = tx.QueryRowContext(ctx, nextFiscalDocumentNumberQuery, string(document.Kind), document.FiscalYear).Scan(&document.Number); err != nil {
+		return result.Err[payment.FiscalDocument](errors.RepositoryError{Description: "failed to number fiscal document: " + err.Error()})
+	}
+
+	var documentId int64
+	err = tx.QueryRowContext(ctx, insertFiscalDocumentQuery,
+		string(document.Kind),
+		document.FiscalYear,
+		document.Number,
+		pgDate(document.IssuedOn),
+		document.Recipient.MemberId,
+		issuer,
+		recipient,
+		document.Currency,
+		notes,
+	).Scan(&documentId)
+	if err != nil {
+		return result.Err[payment.FiscalDocument](errors.RepositoryError{Description: "failed to insert fiscal document: " + err.Error()})
+	}
+	document.Id = domain.NewId[payment.FiscalDocument](documentId)
+
+	for i, line := range document.Lines {
+		var lineId int64
+		err := tx.QueryRowContext(ctx, insertFiscalDocumentLineQuery, documentId, i+1, line.PaymentId, line.Description, pgDate(line.PaidOn), line.PaymentMethod, line.Amount, line.VATRate).Scan(&lineId)
+		if err == sql.ErrNoRows {
+			return result.Err[payment.FiscalDocument](errors.PaymentError{Description: fmt.Sprintf("payment %d is already on another document", line.PaymentId)})
+		}
+		if err != nil {
+			return result.Err[payment.FiscalDocument](errors.RepositoryError{Description: "failed to insert fiscal document line: " + err.Error()})
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[payment.FiscalDocument](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return result.Ok(document)
+}
+
+func (r *SQLFiscalDocumentRepository) GetDocument(id domain.Id[payment.FiscalDocument]) result.Result[payment.FiscalDocument] {
+	documents, err := r.queryDocuments(&id.Value, payment.FiscalDocumentCriteria{})
+	if err != nil {
+		return result.Err[payment.FiscalDocument](err)
+	}
+	if len(documents) == 0 {
+		return result.Err[payment.FiscalDocument](errors.NotFoundError{Description: "fiscal document not found"})
+	}
+	return result.Ok(documents[0])
+}
+
+func (r *SQLFiscalDocumentRepository) GetDocuments(criteria payment.FiscalDocumentCriteria) result.Result[[]payment.FiscalDocument] {
+	documents, err := r.queryDocuments(nil, criteria)
+	if err != nil {
+		return result.Err[[]payment.FiscalDocument](err)
+	}
+	return result.Ok(documents)
+}
+
+// queryDocuments returns the documents matching the criteria, only the given one when an id is passed
+func (r *SQLFiscalDocumentRepository) queryDocuments(id *int64, criteria payment.FiscalDocumentCriteria) ([]payment.FiscalDocument, error) {
+	var kind *string
+	if criteria.Kind != nil {
+		value := string(*criteria.Kind)
+		kind = &value
+	}
+	var issuedFrom, issuedTo *string
+	if criteria.IssuedFrom != nil {
+		value := pgDate(*criteria.IssuedFrom)
+		issuedFrom = &value
+	}
+	if criteria.IssuedTo != nil {
+		value := pgDate(*criteria.IssuedTo)
+		issuedTo = &value
+	}
+
+	rows, err := r.db.QueryContext(context.Background(), getFiscalDocumentsQuery, id, criteria.MemberId, criteria.FiscalYear, kind, issuedFrom, issuedTo)
+	if err != nil {
+		return nil, errors.RepositoryError{Description: "failed to get fiscal documents: " + err.Error()}
+	}
+	defer rows.Close()
+
+	documents := []payment.FiscalDocument{}
+	for rows.Next() {
+		var documentId int64
+		var kind string
+		var issuedOn time.Time
+		var issuer, recipient, notes, lines []byte
+		var document payment.FiscalDocument
+		err := rows.Scan(
+			&documentId,
+			&kind,
+			&document.FiscalYear,
+			&document.Number,
+			&issuedOn,
+			&issuer,
+			&recipient,
+			&document.Currency,
+			&notes,
+			&lines,
+		)
+		if err != nil {
+			return nil, errors.RepositoryError{Description: "failed to scan fiscal document: " + err.Error()}
+		}
+
+		var issuerDetail FiscalIssuerDetail
+		var recipientDetail FiscalRecipientDetail
+		var lineDetails []FiscalDocumentLineDetail
+		if err := json.Unmarshal(issuer, &issuerDetail); err != nil {
+			return nil, errors.RepositoryError{Description: "failed to parse issuer: " + err.Error()}
+		}
+		if err := json.Unmarshal(recipient, &recipientDetail); err != nil {
+			return nil, errors.RepositoryError{Description: "failed to parse recipient: " + err.Error()}
+		}
+		if err := json.Unmarshal(notes, &document.Notes); err != nil {
+			return nil, errors.RepositoryError{Description: "failed to parse notes: " + err.Error()}
+		}
+		if err := json.Unmarshal(lines, &lineDetails); err != nil {
+			return nil, errors.RepositoryError{Description: "failed to parse fiscal document lines: " + err.Error()}
+		}
+
+		document.Id = domain.NewId[payment.FiscalDocument](documentId)
+		document.Kind = payment.FiscalDocumentKind(kind)
+		document.IssuedOn = payment.Day(issuedOn)
+		document.Issuer = ConvertDetailToIssuer(issuerDetail)
+		document.Recipient = ConvertDetailToRecipient(recipientDetail)
+		document.Lines = make([]payment.FiscalDocumentLine, len(lineDetails))
+		for i, line := range lineDetails {
+			paidOn, err := time.Parse("2006-01-02", line.PaidOn)
+			if err != nil {
+				return nil, errors.RepositoryError{Description: "failed to parse payment date: " + err.Error()}
+			}
+			document.Lines[i] = payment.FiscalDocumentLine{
+				Description:   line.Description,
+				PaidOn:        paidOn,
+				PaymentMethod: line.PaymentMethod,
+				Amount:        amountOf(line.Amount, document.Currency),
+				VATRate:       line.VATRate,
+			}
+			if line.PaymentID != nil {
+				document.Lines[i].PaymentId = *line.PaymentID
+			}
+		}
+		documents = append(documents, document)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.RepositoryError{Description: err.Error()}
+	}
+
+	return documents, nil
+}
@@ -0,0 +1,257 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+//go:embed queries/get_all_households.sql
+var getAllHouseholdsQuery string
+
+//go:embed queries/get_household_by_id.sql
+var getHouseholdByIdQuery string
+
+//go:embed queries/insert_household.sql
+var insertHouseholdQuery string
+
+//go:embed queries/delete_household.sql
+var deleteHouseholdQuery string
+
+//go:embed queries/set_member_household.sql
+var setMemberHouseholdQuery string
+
+//go:embed queries/remove_member_from_household.sql
+var removeMemberFromHouseholdQuery string
+
+//go:embed queries/count_household_paying_members.sql
+var countHouseholdPayingMembersQuery string
+
+//go:embed queries/get_family_pricing_scheme.sql
+var getFamilyPricingSchemeQuery string
+
+//go:embed queries/deactivate_family_pricing_schemes.sql
+var deactivateFamilyPricingSchemesQuery string
+
+//go:embed queries/insert_family_pricing_scheme.sql
+var insertFamilyPricingSchemeQuery string
+
+type SQLHouseholdRepository struct {
+	db *sql.DB
+}
+
+func NewSQLHouseholdRepository(db *sql.DB) *SQLHouseholdRepository {
+	return &SQLHouseholdRepository{db: db}
+}
+
+func (r *SQLHouseholdRepository) GetAllHouseholds() result.Result[[]membership.Household] {
+	rows, err := r.db.QueryContext(context.Background(), getAllHouseholdsQuery)
+	if err != nil {
+		return result.Err[[]membership.Household](errors.RepositoryError{Description: "failed to get households: " + err.Error()})
+	}
+	defer rows.Close()
+
+	households := []membership.Household{}
+	for rows.Next() {
+		var resultRow GetHouseholdQueryResult
+		if err := rows.Scan(&resultRow.HouseholdID, &resultRow.Name, &resultRow.Members); err != nil {
+			return result.Err[[]membership.Household](errors.RepositoryError{Description: "failed to scan household: " + err.Error()})
+		}
+		household := MapToHouseholdFromQuery(resultRow)
+		if !household.IsSuccess() {
+			return result.Err[[]membership.Household](household.Error())
+		}
+		households = append(households, household.Value())
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]membership.Household](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(households)
+}
+
+func (r *SQLHouseholdRepository) GetHouseholdById(id domain.Id[membership.Household]) result.Result[membership.Household] {
+	return r.getHouseholdById(context.Background(), r.db, id.Value)
+}
+
+func (r *SQLHouseholdRepository) CreateHousehold(name string, memberIds []domain.Id[membership.Member]) result.Result[membership.Household] {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[membership.Household](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	// 1. Insert the household
+	var householdId int64
+	if err = tx.QueryRowContext(ctx, insertHouseholdQuery, name).Scan(&householdId); err != nil {
+		return result.Err[membership.Household](errors.RepositoryError{Description: "failed to insert household: " + err.Error()})
+	}
+
+	// 2. Move the members to the household
+	for _, memberId := range memberIds {
+		if err = setMemberHousehold(ctx, tx, householdId, memberId.Value); err != nil {
+			return result.Err[membership.Household](err)
+		}
+	}
+
+	household := r.getHouseholdById(ctx, tx, householdId)
+	if !household.IsSuccess() {
+		return household
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[membership.Household](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return household
+}
+
+func (r *SQLHouseholdRepository) DeleteHousehold(id domain.Id[membership.Household]) result.Result[bool] {
+	res, err := r.db.ExecContext(context.Background(), deleteHouseholdQuery, id.Value)
+	if err != nil {
+		return result.Err[bool](errors.RepositoryError{Description: "failed to delete household: " + err.Error()})
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return result.Err[bool](errors.NotFoundError{Description: "household not found"})
+	}
+	return result.Ok(true)
+}
+
+func (r *SQLHouseholdRepository) AddMember(id domain.Id[membership.Household], memberId domain.Id[membership.Member]) result.Result[membership.Household] {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[membership.Household](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	if household := r.getHouseholdById(ctx, tx, id.Value); !household.IsSuccess() {
+		return household
+	}
+	if err = setMemberHousehold(ctx, tx, id.Value, memberId.Value); err != nil {
+		return result.Err[membership.Household](err)
+	}
+
+	household := r.getHouseholdById(ctx, tx, id.Value)
+	if !household.IsSuccess() {
+		return household
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[membership.Household](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return household
+}
+
+func (r *SQLHouseholdRepository) RemoveMember(id domain.Id[membership.Household], memberId domain.Id[membership.Member]) result.Result[bool] {
+	res, err := r.db.ExecContext(context.Background(), removeMemberFromHouseholdQuery, id.Value, memberId.Value)
+	if err != nil {
+		return result.Err[bool](errors.RepositoryError{Description: "failed to remove member from household: " + err.Error()})
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return result.Err[bool](errors.NotFoundError{Description: "member not found in household"})
+	}
+	return result.Ok(true)
+}
+
+func (r *SQLHouseholdRepository) CountPayingMembers(id domain.Id[membership.Household], seasonId int64, excluded *domain.Id[membership.Member]) result.Result[int] {
+	excludedId := sql.NullInt64{}
+	if excluded != nil {
+		excludedId = sql.NullInt64{Int64: excluded.Value, Valid: true}
+	}
+
+	var count int
+	err := r.db.QueryRowContext(context.Background(), countHouseholdPayingMembersQuery, id.Value, seasonId, excludedId).Scan(&count)
+	if err != nil {
+		return result.Err[int](errors.RepositoryError{Description: "failed to count household members: " + err.Error()})
+	}
+	return result.Ok(count)
+}
+
+func (r *SQLHouseholdRepository) GetFamilyPricingScheme() result.Result[membership.FamilyPricingScheme] {
+	var scheme membership.FamilyPricingScheme
+	var fullPrice, additionalMemberPrice, childPrice float64
+	err := r.db.QueryRowContext(context.Background(), getFamilyPricingSchemeQuery).Scan(
+		&fullPrice,
+		&additionalMemberPrice,
+		&childPrice,
+		&scheme.ChildMaxAge,
+	)
+	if err == sql.ErrNoRows {
+		return result.Ok(membership.DefaultFamilyPricingScheme)
+	}
+	if err != nil {
+		return result.Err[membership.FamilyPricingScheme](errors.RepositoryError{Description: "failed to get family pricing scheme: " + err.Error()})
+	}
+	scheme.FullPrice = priceOf(fullPrice)
+	scheme.AdditionalMemberPrice = priceOf(additionalMemberPrice)
+	scheme.ChildPrice = priceOf(childPrice)
+	return result.Ok(scheme)
+}
+
+func (r *SQLHouseholdRepository) SaveFamilyPricingScheme(scheme membership.FamilyPricingScheme) result.Result[membership.FamilyPricingScheme] {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[membership.FamilyPricingScheme](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, deactivateFamilyPricingSchemesQuery); err != nil {
+		return result.Err[membership.FamilyPricingScheme](errors.RepositoryError{Description: "failed to deactivate family pricing schemes: " + err.Error()})
+	}
+	_, err = tx.ExecContext(ctx, insertFamilyPricingSchemeQuery,
+		scheme.FullPrice,
+		scheme.AdditionalMemberPrice,
+		scheme.ChildPrice,
+		scheme.ChildMaxAge,
+	)
+	if err != nil {
+		return result.Err[membership.FamilyPricingScheme](errors.RepositoryError{Description: "failed to insert family pricing scheme: " + err.Error()})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[membership.FamilyPricingScheme](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return result.Ok(scheme)
+}
+
+// queryRower is implemented by both *sql.DB and *sql.Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func (r *SQLHouseholdRepository) getHouseholdById(ctx context.Context, db queryRower, id int64) result.Result[membership.Household] {
+	var resultRow GetHouseholdQueryResult
+	err := db.QueryRowContext(ctx, getHouseholdByIdQuery, id).Scan(&resultRow.HouseholdID, &resultRow.Name, &resultRow.Members)
+	if err == sql.ErrNoRows {
+		return result.Err[membership.Household](errors.NotFoundError{Description: "household not found"})
+	}
+	if err != nil {
+		return result.Err[membership.Household](errors.RepositoryError{Description: "failed to get household: " + err.Error()})
+	}
+	return MapToHouseholdFromQuery(resultRow)
+}
+
+func setMemberHousehold(ctx context.Context, tx *sql.Tx, householdId int64, memberId int64) error {
+	res, err := tx.ExecContext(ctx, setMemberHouseholdQuery, householdId, memberId)
+	if err != nil {
+		return errors.RepositoryError{Description: "failed to add member to household: " + err.Error()}
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return errors.NotFoundError{Description: "member not found"}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+//go:embed queries/get_member_documents.sql
+var getMemberDocumentsQuery string
+
+//go:embed queries/get_member_document.sql
+var getMemberDocumentQuery string
+
+//go:embed queries/insert_member_document.sql
+var insertMemberDocumentQuery string
+
+//go:embed queries/delete_member_document.sql
+var deleteMemberDocumentQuery string
+
+//go:embed queries/get_season_certificate_expiries.sql
+var getSeasonCertificateExpiriesQuery string
+
+type SQLMemberDocumentRepository struct {
+	db *sql.DB
+}
+
+func NewSQLMemberDocumentRepository(db *sql.DB) *SQLMemberDocumentRepository {
+	return &SQLMemberDocumentRepository{db: db}
+}
+
+func (r *SQLMemberDocumentRepository) GetDocuments(userId domain.Id[membership.User]) result.Result[[]membership.MemberDocument] {
+	rows, err := r.db.QueryContext(context.Background(), getMemberDocumentsQuery, userId.Value)
+	if err != nil {
+		return result.Err[[]membership.MemberDocument](errors.RepositoryError{Description: "failed to get documents: " + err.Error()})
+	}
+	defer rows.Close()
+
+	documents := []membership.MemberDocument{}
+	for rows.Next() {
+		document, err := scanMemberDocument(rows)
+		if err != nil {
+			return result.Err[[]membership.MemberDocument](err)
+		}
+		documents = append(documents, document)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]membership.MemberDocument](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(documents)
+}
+
+func (r *SQLMemberDocumentRepository) GetDocument(userId domain.Id[membership.User], id domain.Id[membership.MemberDocument]) result.Result[membership.MemberDocument] {
+	row := r.db.QueryRowContext(context.Background(), getMemberDocumentQuery, userId.Value, id.Value)
+	document, err := scanMemberDocument(row)
+	if err != nil {
+		return result.Err[membership.MemberDocument](err)
+	}
+	return result.Ok(document)
+}
+
+func (r *SQLMemberDocumentRepository) CreateDocument(document membership.MemberDocument) result.Result[membership.MemberDocument] {
+	var id int64
+	err := r.db.QueryRowContext(context.Background(), insertMemberDocumentQuery,
+		document.UserId.Value,
+		string(document.Type),
+		document.FileName,
+		document.ContentType,
+		document.Size,
+		document.StorageKey,
+		document.IssuedAt,
+		document.ExpiresAt,
+		sql.NullString{String: document.Notes, Valid: document.Notes != ""},
+	).Scan(&id, &document.UploadedAt)
+	if err == sql.ErrNoRows {
+		return result.Err[membership.MemberDocument](errors.NotFoundError{Description: "Member not found or removed"})
+	}
+	if err != nil {
+		return result.Err[membership.MemberDocument](errors.RepositoryError{Description: "failed to insert document: " + err.Error()})
+	}
+	document.Id = domain.NewId[membership.MemberDocument](id)
+	return result.Ok(document)
+}
+
+func (r *SQLMemberDocumentRepository) DeleteDocument(userId domain.Id[membership.User], id domain.Id[membership.MemberDocument]) result.Result[bool] {
+	res, err := r.db.ExecContext(context.Background(), deleteMemberDocumentQuery, userId.Value, id.Value)
+	if err != nil {
+		return result.Err[bool](errors.RepositoryError{Description: "failed to delete document: " + err.Error()})
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return result.Err[bool](errors.RepositoryError{Description: "failed to delete document: " + err.Error()})
+	}
+	if affected == 0 {
+		return result.Err[bool](errors.NotFoundError{Description: "Document not found"})
+	}
+	return result.Ok(true)
+}
+
+func (r *SQLMemberDocumentRepository) GetCertificateExpiries(seasonId int64) result.Result[[]membership.CertificateCheck] {
+	rows, err := r.db.QueryContext(context.Background(), getSeasonCertificateExpiriesQuery, seasonId)
+	if err != nil {
+		return result.Err[[]membership.CertificateCheck](errors.RepositoryError{Description: "failed to get certificate expiries: " + err.Error()})
+	}
+	defer rows.Close()
+
+	checks := []membership.CertificateCheck{}
+	for rows.Next() {
+		var id int64
+		var email sql.NullString
+		var expiresAt sql.NullTime
+		var check membership.CertificateCheck
+		if err := rows.Scan(&id, &check.User.FirstName, &check.User.LastName, &check.User.BirthDate, &email, &expiresAt); err != nil {
+			return result.Err[[]membership.CertificateCheck](errors.RepositoryError{Description: "failed to scan certificate expiry: " + err.Error()})
+		}
+		check.User.Id = domain.NewId[membership.User](id)
+		if email.Valid && email.String != "" {
+			check.User.Email = &membership.EmailAddress{Value: email.String}
+		}
+		check.ExpiresAt = nullTimePtr(expiresAt)
+		checks = append(checks, check)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]membership.CertificateCheck](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(checks)
+}
+
+func scanMemberDocument(row rowScanner) (membership.MemberDocument, error) {
+	var id, memberId int64
+	var documentType string
+	var issuedAt, expiresAt sql.NullTime
+	var notes sql.NullString
+	var document membership.MemberDocument
+	err := row.Scan(
+		&id,
+		&memberId,
+		&documentType,
+		&document.FileName,
+		&document.ContentType,
+		&document.Size,
+		&document.StorageKey,
+		&issuedAt,
+		&expiresAt,
+		&notes,
+		&document.UploadedAt,
+	)
+	if err == sql.ErrNoRows {
+		return membership.MemberDocument{}, errors.NotFoundError{Description: "Document not found"}
+	}
+	if err != nil {
+		return membership.MemberDocument{}, errors.RepositoryError{Description: "failed to scan document: " + err.Error()}
+	}
+	document.Id = domain.NewId[membership.MemberDocument](id)
+	document.UserId = domain.NewId[membership.User](memberId)
+	document.Type = membership.DocumentType(documentType)
+	document.IssuedAt = nullTimePtr(issuedAt)
+	document.ExpiresAt = nullTimePtr(expiresAt)
+	document.Notes = notes.String
+	return document, nil
+}
+
+func nullTimePtr(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
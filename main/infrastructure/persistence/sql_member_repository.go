@@ -4,11 +4,15 @@ import (
 	"context"
 	"database/sql"
 	_ "embed"
+	"strings"
+	"time"
 
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
 	m "github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/lib/pq"
 )
 
 //go:embed queries/get_member_by_id.sql
@@ -53,6 +57,111 @@ var insertBirthPlaceQuery string
 //go:embed queries/delete_birth_place.sql
 var deleteBirthPlaceQuery string
 
+//go:embed queries/update_membership_period_status.sql
+var updateMembershipPeriodStatusQuery string
+
+//go:embed queries/clear_rental_flags.sql
+var clearRentalFlagsQuery string
+
+//go:embed queries/search_members.sql
+var searchMembersQuery string
+
+//go:embed queries/get_users_for_duplicate_check.sql
+var getUsersForDuplicateCheckQuery string
+
+//go:embed queries/lock_members_for_merge.sql
+var lockMembersForMergeQuery string
+
+//go:embed queries/merge_membership_periods.sql
+var mergeMembershipPeriodsQuery string
+
+//go:embed queries/move_membership_periods.sql
+var moveMembershipPeriodsQuery string
+
+//go:embed queries/move_memberships.sql
+var moveMembershipsQuery string
+
+//go:embed queries/delete_member_memberships.sql
+var deleteMemberMembershipsQuery string
+
+//go:embed queries/move_rented_facilities.sql
+var moveRentedFacilitiesQuery string
+
+//go:embed queries/merge_waiting_entries.sql
+var mergeWaitingEntriesQuery string
+
+//go:embed queries/move_waiting_entries.sql
+var moveWaitingEntriesQuery string
+
+//go:embed queries/move_phone_numbers.sql
+var movePhoneNumbersQuery string
+
+//go:embed queries/move_addresses.sql
+var moveAddressesQuery string
+
+//go:embed queries/move_birth_place.sql
+var moveBirthPlaceQuery string
+
+//go:embed queries/complete_merged_member.sql
+var completeMergedMemberQuery string
+
+//go:embed queries/delete_member.sql
+var deleteMemberQuery string
+
+//go:embed queries/insert_guardian.sql
+var insertGuardianQuery string
+
+//go:embed queries/delete_guardians.sql
+var deleteGuardiansQuery string
+
+//go:embed queries/get_season_start_date.sql
+var getSeasonStartDateQuery string
+
+//go:embed queries/move_guardians.sql
+var moveGuardiansQuery string
+
+//go:embed queries/move_member_documents.sql
+var moveMemberDocumentsQuery string
+
+//go:embed queries/move_member_consents.sql
+var moveMemberConsentsQuery string
+
+//go:embed queries/move_fiscal_documents.sql
+var moveFiscalDocumentsQuery string
+
+//go:embed queries/merge_reminder_letters.sql
+var mergeReminderLettersQuery string
+
+//go:embed queries/move_reminder_letters.sql
+var moveReminderLettersQuery string
+
+//go:embed queries/anonymise_member.sql
+var anonymiseMemberQuery string
+
+//go:embed queries/delete_member_documents.sql
+var deleteMemberDocumentsQuery string
+
+//go:embed queries/get_guarded_members.sql
+var getGuardedMembersQuery string
+
+//go:embed queries/get_guardian_members.sql
+var getGuardianMembersQuery string
+
+//go:embed queries/exclude_open_membership_periods.sql
+var excludeOpenMembershipPeriodsQuery string
+
+//go:embed queries/delete_member_waiting_entries.sql
+var deleteMemberWaitingEntriesQuery string
+
+//go:embed queries/anonymise_membership_expiry_runs.sql
+var anonymiseMembershipExpiryRunsQuery string
+
+//go:embed queries/anonymise_reminder_letters.sql
+var anonymiseReminderLettersQuery string
+
+//go:embed queries/anonymise_outbox_messages.sql
+var anonymiseOutboxMessagesQuery string
+
 type SQLMemberRepository struct {
 	db *sql.DB
 }
@@ -83,9 +192,9 @@ func (r *SQLMemberRepository) GetAllMembers() result.Result[[]m.Member] {
 			&resultRow.MembershipStatus,
 			&resultRow.ExclusionDeliberatedAt,
 			&resultRow.Price,
-			&resultRow.AmountPaid,
-			&resultRow.PaidAt,
 			&resultRow.Currency,
+			&resultRow.Transactions,
+			&resultRow.Category,
 		)
 		if err != nil {
 			return result.Err[[]m.Member](errors.RepositoryError{Description: err.Error()})
@@ -128,12 +237,13 @@ func (r *SQLMemberRepository) GetMembersBySeason(seasonId int64) result.Result[[
 			&resultRow.SeasonEndsAt,
 			&resultRow.ExclusionDeliberatedAt,
 			&resultRow.Price,
-			&resultRow.MembershipStatus,
-			&resultRow.AmountPaid,
-			&resultRow.PaidAt,
 			&resultRow.Currency,
+			&resultRow.MembershipStatus,
+			&resultRow.Transactions,
 			&resultRow.HasRentedFacilities,
 			&resultRow.HasUnpaidFacilities,
+			&resultRow.HasFlaggedRentals,
+			&resultRow.Category,
 		)
 		if err != nil {
 			return result.Err[[]m.Member](errors.RepositoryError{Description: err.Error()})
@@ -154,6 +264,92 @@ func (r *SQLMemberRepository) GetMembersBySeason(seasonId int64) result.Result[[
 	return result.Ok(members)
 }
 
+// likeEscaper escapes the LIKE wildcards in user supplied search text
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *SQLMemberRepository) SearchMembers(criteria m.MemberSearchCriteria) result.Result[m.MemberPage] {
+	query := sql.NullString{Valid: criteria.Query != ""}
+	if query.Valid {
+		query.String = likeEscaper.Replace(criteria.Query)
+	}
+	status := sql.NullString{Valid: criteria.Status != nil}
+	if criteria.Status != nil {
+		status.String = string(*criteria.Status)
+	}
+	withoutConsent := sql.NullString{Valid: criteria.WithoutConsent != nil}
+	if criteria.WithoutConsent != nil {
+		withoutConsent.String = string(*criteria.WithoutConsent)
+	}
+
+	rows, err := r.db.QueryContext(context.Background(), searchMembersQuery,
+		criteria.SeasonId,
+		query,
+		status,
+		criteria.MembershipPaid,
+		criteria.HasUnpaidFacilities,
+		criteria.FacilityTypeId,
+		string(criteria.SortBy),
+		criteria.Descending,
+		criteria.Limit,
+		criteria.Offset,
+		withoutConsent,
+	)
+	if err != nil {
+		return result.Err[m.MemberPage](errors.RepositoryError{Description: "failed to search members: " + err.Error()})
+	}
+	defer rows.Close()
+
+	page := m.MemberPage{
+		Members: []m.Member{},
+		Limit:   criteria.Limit,
+		Offset:  criteria.Offset,
+	}
+	for rows.Next() {
+		var resultRow SearchMembersQueryResult
+		err := rows.Scan(
+			&resultRow.Total,
+			&resultRow.MemberID,
+			&resultRow.FirstName,
+			&resultRow.LastName,
+			&resultRow.Email,
+			&resultRow.DateOfBirth,
+			&resultRow.TaxCode,
+			&resultRow.MembershipNumber,
+			&resultRow.SeasonStartsAt,
+			&resultRow.SeasonEndsAt,
+			&resultRow.ExclusionDeliberatedAt,
+			&resultRow.Price,
+			&resultRow.Currency,
+			&resultRow.MembershipStatus,
+			&resultRow.Transactions,
+			&resultRow.HasRentedFacilities,
+			&resultRow.HasUnpaidFacilities,
+			&resultRow.HasFlaggedRentals,
+			&resultRow.Category,
+		)
+		if err != nil {
+			return result.Err[m.MemberPage](errors.RepositoryError{Description: err.Error()})
+		}
+
+		page.Total = resultRow.Total
+		if !resultRow.MemberID.Valid {
+			continue
+		}
+
+		memberResult := MapToMemberFromSearchQuery(resultRow)
+		if !memberResult.IsSuccess() {
+			return result.Err[m.MemberPage](memberResult.Error())
+		}
+		page.Members = append(page.Members, memberResult.Value())
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[m.MemberPage](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(page)
+}
+
 func (r *SQLMemberRepository) GetMemberById(id domain.Id[m.Member], season int64) result.Result[m.MemberDetails] {
 	var resultRow GetMemberByIdQueryResult
 	err := r.db.QueryRowContext(context.Background(), getMemberByIdQuery, id.Value, season).Scan(
@@ -163,6 +359,10 @@ func (r *SQLMemberRepository) GetMemberById(id domain.Id[m.Member], season int64
 		&resultRow.DateOfBirth,
 		&resultRow.Email,
 		&resultRow.TaxCode,
+		&resultRow.Billing.CompanyName,
+		&resultRow.Billing.VATNumber,
+		&resultRow.Billing.RecipientCode,
+		&resultRow.Billing.PEC,
 		&resultRow.PhoneNumbers,
 		&resultRow.Addresses,
 		&resultRow.Memberships,
@@ -171,6 +371,9 @@ func (r *SQLMemberRepository) GetMemberById(id domain.Id[m.Member], season int64
 		&resultRow.BirthPlaceStreet,
 		&resultRow.BirthPlaceStreetNum,
 		&resultRow.BirthPlaceZipCode,
+		&resultRow.Guardians,
+		&resultRow.HouseholdID,
+		&resultRow.Consents,
 	)
 
 	return result.MapErr(result.Bind(result.From(true, err), func(_ bool) result.Result[m.MemberDetails] {
@@ -183,17 +386,49 @@ func (r *SQLMemberRepository) GetMemberById(id domain.Id[m.Member], season int64
 	})
 }
 
+// GetMembersWhoDidNotPayForServices returns the members with at least a rental still unpaid,
+// excluded members are left out as their rentals are released
 func (r *SQLMemberRepository) GetMembersWhoDidNotPayForServices() []m.Member {
-	// TODO: Implement query for members with unpaid service rentals
-	return []m.Member{}
+	members := r.GetAllMembers()
+	if !members.IsSuccess() {
+		return []m.Member{}
+	}
+
+	unpaid := []m.Member{}
+	for _, member := range members.Value() {
+		if member.Membership.Status.GetStatus() == m.MembershipStatusExcluded {
+			continue
+		}
+		if member.HasUnpaidFacilities {
+			unpaid = append(unpaid, member)
+		}
+	}
+	return unpaid
 }
 
+// GetMembersWhoDidNotPayForMembership returns the members whose latest membership is unpaid,
+// excluded members and categories exempt from the fee are left out
 func (r *SQLMemberRepository) GetMembersWhoDidNotPayForMembership() []m.Member {
-	// TODO: Implement query for members with unpaid memberships
-	return []m.Member{}
+	members := r.GetAllMembers()
+	if !members.IsSuccess() {
+		return []m.Member{}
+	}
+
+	unpaid := []m.Member{}
+	for _, member := range members.Value() {
+		status := member.Membership.Status.GetStatus()
+		if status == m.MembershipStatusNone || status == m.MembershipStatusExcluded {
+			continue
+		}
+		// A ledger mixing currencies cannot be settled, so it is listed for the treasurer to fix
+		if paid := member.Membership.IsPaid(); !paid.IsSuccess() || !paid.Value() {
+			unpaid = append(unpaid, member)
+		}
+	}
+	return unpaid
 }
 
-func (r *SQLMemberRepository) CreateMember(user m.User, createMembership bool, seasonId *int64, price *float64) result.Result[m.MemberDetails] {
+func (r *SQLMemberRepository) CreateMember(user m.User, createMembership bool, seasonId *int64, price *money.Money, category m.MembershipCategory) result.Result[m.MemberDetails] {
 	ctx := context.Background()
 
 	// Begin transaction
@@ -205,10 +440,12 @@ func (r *SQLMemberRepository) CreateMember(user m.User, createMembership bool, s
 
 	// 1. Insert member
 	var memberId int64
-	// Convert TaxCode to sql.NullString to handle empty strings as NULL
+	// Convert TaxCode to sql.NullString to handle optional tax code
 	taxCode := sql.NullString{
-		String: user.TaxCode,
-		Valid:  user.TaxCode != "",
+		Valid: user.TaxCode != nil,
+	}
+	if user.TaxCode != nil {
+		taxCode.String = user.TaxCode.Value
 	}
 	// Convert Email to sql.NullString to handle optional email
 	email := sql.NullString{
@@ -217,12 +454,17 @@ func (r *SQLMemberRepository) CreateMember(user m.User, createMembership bool, s
 	if user.Email != nil {
 		email.String = user.Email.Value
 	}
+	billing := ConvertBillingDetailsToColumns(user.Billing)
 	err = tx.QueryRowContext(ctx, insertMemberQuery,
 		user.FirstName,
 		user.LastName,
 		user.BirthDate,
 		email,
 		taxCode,
+		billing.CompanyName,
+		billing.VATNumber,
+		billing.RecipientCode,
+		billing.PEC,
 	).Scan(&memberId)
 	if err != nil {
 		return result.Err[m.MemberDetails](errors.RepositoryError{Description: "failed to insert member: " + err.Error()})
@@ -265,11 +507,24 @@ func (r *SQLMemberRepository) CreateMember(user m.User, createMembership bool, s
 		}
 	}
 
-	// Get next membership number
-	var nextMembershipNumber int64
-	err = tx.QueryRowContext(ctx, getNextMembershipNumberQuery).Scan(&nextMembershipNumber)
+	// 5. Insert guardians
+	if err = insertGuardians(ctx, tx, memberId, user.Guardians); err != nil {
+		return result.Err[m.MemberDetails](errors.RepositoryError{Description: "failed to insert guardian: " + err.Error()})
+	}
+
+	// 6. Insert consents
+	if _, err = insertConsents(ctx, tx, memberId, user.Consents); err != nil {
+		return result.Err[m.MemberDetails](err)
+	}
+
+	// Get next membership number following the numbering policy
+	policy, err := getNumberingPolicy(ctx, tx)
+	if err != nil {
+		return result.Err[m.MemberDetails](err)
+	}
+	nextMembershipNumber, err := nextMembershipNumber(ctx, tx, policy)
 	if err != nil {
-		return result.Err[m.MemberDetails](errors.RepositoryError{Description: "failed to get next membership number: " + err.Error()})
+		return result.Err[m.MemberDetails](err)
 	}
 
 	// Insert membership
@@ -304,15 +559,23 @@ func (r *SQLMemberRepository) CreateMember(user m.User, createMembership bool, s
 		}
 
 		// Insert membership period with status_id = 1 (ACTIVE)
-		_, err = tx.ExecContext(ctx, insertMembershipPeriodQuery,
+		var periodId int64
+		err = tx.QueryRowContext(ctx, insertMembershipPeriodQuery,
 			membershipId,
 			1, // status_id for ACTIVE
 			*seasonId,
 			membershipPrice,
-		)
+			categoryId(category),
+			currencyOf(membershipPrice),
+		).Scan(&periodId)
 		if err != nil {
 			return result.Err[m.MemberDetails](errors.RepositoryError{Description: "failed to insert membership period: " + err.Error()})
 		}
+
+		// Number the membership card
+		if err = assignMembershipCard(ctx, tx, policy, periodId, *seasonId, nextMembershipNumber); err != nil {
+			return result.Err[m.MemberDetails](err)
+		}
 	}
 
 	// Commit transaction
@@ -334,14 +597,16 @@ func (r *SQLMemberRepository) CreateMember(user m.User, createMembership bool, s
 			BirthDate:    user.BirthDate,
 			Email:        user.Email,
 			TaxCode:      user.TaxCode,
+			BirthPlace:   user.BirthPlace,
 			Addresses:    user.Addresses,
 			PhoneNumbers: user.PhoneNumbers,
+			Guardians:    user.Guardians,
 		},
 		Memberships: []m.Membership{},
 	})
 }
 
-func (r *SQLMemberRepository) AddMembership(memberId domain.Id[m.Member], seasonId int64, price float64) result.Result[m.MemberDetails] {
+func (r *SQLMemberRepository) AddMembership(memberId domain.Id[m.Member], seasonId int64, price money.Money, category m.MembershipCategory) result.Result[m.MemberDetails] {
 	ctx := context.Background()
 
 	// Begin transaction
@@ -351,24 +616,41 @@ func (r *SQLMemberRepository) AddMembership(memberId domain.Id[m.Member], season
 	}
 	defer tx.Rollback()
 
-	// Get the membership_id for this member
-	var membershipId int64
-	err = tx.QueryRowContext(ctx, "SELECT id FROM memberships WHERE member_id = $1", memberId.Value).Scan(&membershipId)
+	// Get the membership_id and number for this member
+	var membershipId, membershipNumber int64
+	err = tx.QueryRowContext(ctx, "SELECT id, number FROM memberships WHERE member_id = $1", memberId.Value).Scan(&membershipId, &membershipNumber)
 	if err != nil {
 		return result.Err[m.MemberDetails](errors.RepositoryError{Description: "failed to get membership id: " + err.Error()})
 	}
 
 	// Insert membership period with status_id = 1 (ACTIVE)
-	_, err = tx.ExecContext(ctx, insertMembershipPeriodQuery,
+	var periodId int64
+	err = tx.QueryRowContext(ctx, insertMembershipPeriodQuery,
 		membershipId,
 		1, // status_id for ACTIVE
 		seasonId,
 		price,
-	)
+		categoryId(category),
+		currencyOf(price),
+	).Scan(&periodId)
 	if err != nil {
 		return result.Err[m.MemberDetails](errors.RepositoryError{Description: "failed to insert membership period: " + err.Error()})
 	}
 
+	// Number the membership card
+	policy, err := getNumberingPolicy(ctx, tx)
+	if err != nil {
+		return result.Err[m.MemberDetails](err)
+	}
+	if err = assignMembershipCard(ctx, tx, policy, periodId, seasonId, membershipNumber); err != nil {
+		return result.Err[m.MemberDetails](err)
+	}
+
+	// Rentals flagged while the member had no membership in the season are fine again
+	if err = clearRentalFlags(ctx, tx, periodId); err != nil {
+		return result.Err[m.MemberDetails](err)
+	}
+
 	// Commit transaction
 	if err = tx.Commit(); err != nil {
 		return result.Err[m.MemberDetails](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
@@ -389,10 +671,12 @@ func (r *SQLMemberRepository) UpdateMember(id domain.Id[m.Member], user m.User,
 	defer tx.Rollback()
 
 	// 1. Update member basic info
-	// Convert TaxCode to sql.NullString to handle empty strings as NULL
+	// Convert TaxCode to sql.NullString to handle optional tax code
 	taxCode := sql.NullString{
-		String: user.TaxCode,
-		Valid:  user.TaxCode != "",
+		Valid: user.TaxCode != nil,
+	}
+	if user.TaxCode != nil {
+		taxCode.String = user.TaxCode.Value
 	}
 	// Convert Email to sql.NullString to handle optional email
 	email := sql.NullString{
@@ -402,6 +686,8 @@ func (r *SQLMemberRepository) UpdateMember(id domain.Id[m.Member], user m.User,
 		email.String = user.Email.Value
 	}
 
+	billing := ConvertBillingDetailsToColumns(user.Billing)
+
 	var memberId int64
 	err = tx.QueryRowContext(ctx, updateMemberQuery,
 		user.FirstName,
@@ -410,6 +696,10 @@ func (r *SQLMemberRepository) UpdateMember(id domain.Id[m.Member], user m.User,
 		email,
 		taxCode,
 		id.Value,
+		billing.CompanyName,
+		billing.VATNumber,
+		billing.RecipientCode,
+		billing.PEC,
 	).Scan(&memberId)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -471,6 +761,15 @@ func (r *SQLMemberRepository) UpdateMember(id domain.Id[m.Member], user m.User,
 		}
 	}
 
+	// 7. Replace guardians
+	_, err = tx.ExecContext(ctx, deleteGuardiansQuery, id.Value)
+	if err != nil {
+		return result.Err[m.MemberDetails](errors.RepositoryError{Description: "failed to delete guardians: " + err.Error()})
+	}
+	if err = insertGuardians(ctx, tx, id.Value, user.Guardians); err != nil {
+		return result.Err[m.MemberDetails](errors.RepositoryError{Description: "failed to insert guardian: " + err.Error()})
+	}
+
 	// Commit transaction
 	if err = tx.Commit(); err != nil {
 		return result.Err[m.MemberDetails](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
@@ -479,3 +778,449 @@ func (r *SQLMemberRepository) UpdateMember(id domain.Id[m.Member], user m.User,
 	// Fetch and return the updated member details
 	return r.GetMemberById(id, season)
 }
+
+func (r *SQLMemberRepository) GetSeasonStartDate(seasonId int64) result.Result[time.Time] {
+	var startsAt time.Time
+	err := r.db.QueryRowContext(context.Background(), getSeasonStartDateQuery, seasonId).Scan(&startsAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return result.Err[time.Time](errors.NotFoundError{Description: "season not found"})
+		}
+		return result.Err[time.Time](errors.RepositoryError{Description: "failed to get season start date: " + err.Error()})
+	}
+	return result.Ok(startsAt)
+}
+
+// insertGuardians stores the guardians of a member, member guardians only keep the link and the relationship
+func insertGuardians(ctx context.Context, tx *sql.Tx, memberId int64, guardians []m.Guardian) error {
+	for _, guardian := range guardians {
+		guardianMemberId := sql.NullInt64{}
+		firstName, lastName := sql.NullString{}, sql.NullString{}
+		if guardian.IsMember() {
+			guardianMemberId = sql.NullInt64{Int64: guardian.MemberId.Value, Valid: true}
+		} else {
+			firstName = sql.NullString{String: guardian.FirstName, Valid: true}
+			lastName = sql.NullString{String: guardian.LastName, Valid: true}
+		}
+		email := sql.NullString{Valid: guardian.Email != nil}
+		if guardian.Email != nil {
+			email.String = guardian.Email.Value
+		}
+		phoneNumber := sql.NullString{Valid: guardian.PhoneNumber != nil}
+		if guardian.PhoneNumber != nil {
+			phoneNumber.String = guardian.PhoneNumber.Number
+		}
+
+		_, err := tx.ExecContext(ctx, insertGuardianQuery,
+			memberId,
+			guardianMemberId,
+			firstName,
+			lastName,
+			email,
+			phoneNumber,
+			guardian.Relationship,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SQLMemberRepository) UpdateMembershipStatus(membership m.Membership) result.Result[m.Membership] {
+	periodId := membership.Status.GetPeriodId()
+	if periodId == nil {
+		return result.Err[m.Membership](errors.RepositoryError{Description: "membership has no period to update"})
+	}
+
+	exclusionDeliberatedAt := sql.NullTime{Valid: false}
+	exclusionReason := sql.NullString{Valid: false}
+	if excluded, ok := membership.Status.(m.Excluded); ok {
+		exclusionDeliberatedAt = sql.NullTime{Time: excluded.ExcludedAt, Valid: true}
+		exclusionReason = sql.NullString{String: excluded.Reason, Valid: true}
+	}
+
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[m.Membership](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	var updatedPeriodId int64
+	err = tx.QueryRowContext(ctx, updateMembershipPeriodStatusQuery,
+		*periodId,
+		string(membership.Status.GetStatus()),
+		exclusionDeliberatedAt,
+		exclusionReason,
+	).Scan(&updatedPeriodId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return result.Err[m.Membership](errors.NotFoundError{Description: "membership period not found"})
+		}
+		return result.Err[m.Membership](errors.RepositoryError{Description: "failed to update membership status: " + err.Error()})
+	}
+
+	// Reinstating the member clears the flags the membership expiry set on the rentals of the season
+	if err = clearRentalFlags(ctx, tx, updatedPeriodId); err != nil {
+		return result.Err[m.Membership](err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[m.Membership](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return result.Ok(membership)
+}
+
+// clearRentalFlags clears the flags of the rentals in the season of the period, when the period is active
+func clearRentalFlags(ctx context.Context, tx *sql.Tx, periodId int64) error {
+	if _, err := tx.ExecContext(ctx, clearRentalFlagsQuery, periodId); err != nil {
+		return errors.RepositoryError{Description: "failed to clear rental flags: " + err.Error()}
+	}
+	return nil
+}
+
+func (r *SQLMemberRepository) RemoveMember(id domain.Id[m.Member], exclusion m.Excluded) result.Result[[]string] {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	// 1. Anonymise member basic info
+	var memberId int64
+	err = tx.QueryRowContext(ctx, anonymiseMemberQuery,
+		id.Value,
+		m.RemovedMemberFirstName,
+		m.RemovedMemberLastName,
+		m.RemovedMemberBirthDate,
+		time.Now(),
+	).Scan(&memberId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return result.Err[[]string](errors.NotFoundError{Description: "Member not found or already removed"})
+		}
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to anonymise member: " + err.Error()})
+	}
+
+	// 2. End the periods of other seasons still open with the same decision
+	_, err = tx.ExecContext(ctx, excludeOpenMembershipPeriodsQuery,
+		id.Value,
+		exclusion.ExcludedAt,
+		exclusion.Reason,
+	)
+	if err != nil {
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to exclude open memberships: " + err.Error()})
+	}
+
+	// 3. Delete contacts, guardians and birth place
+	if _, err = tx.ExecContext(ctx, deletePhoneNumbersQuery, id.Value); err != nil {
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to delete phone numbers: " + err.Error()})
+	}
+	if _, err = tx.ExecContext(ctx, deleteAddressesQuery, id.Value); err != nil {
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to delete addresses: " + err.Error()})
+	}
+	if _, err = tx.ExecContext(ctx, deleteGuardiansQuery, id.Value); err != nil {
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to delete guardians: " + err.Error()})
+	}
+	if _, err = tx.ExecContext(ctx, deleteBirthPlaceQuery, id.Value); err != nil {
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to delete birth place: " + err.Error()})
+	}
+
+	// 4. Leave the waiting lists
+	if _, err = tx.ExecContext(ctx, deleteMemberWaitingEntriesQuery, id.Value); err != nil {
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to delete waiting list entries: " + err.Error()})
+	}
+
+	// 5. Scrub the names recorded by past expiry runs
+	_, err = tx.ExecContext(ctx, anonymiseMembershipExpiryRunsQuery,
+		id.Value,
+		m.RemovedMemberFirstName,
+		m.RemovedMemberLastName,
+	)
+	if err != nil {
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to anonymise expiry runs: " + err.Error()})
+	}
+
+	// 6. Scrub the recipient copied into the reminder letters
+	_, err = tx.ExecContext(ctx, anonymiseReminderLettersQuery,
+		id.Value,
+		m.RemovedMemberFirstName,
+		m.RemovedMemberLastName,
+	)
+	if err != nil {
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to anonymise reminder letters: " + err.Error()})
+	}
+
+	// 7. Cancel the emails not sent yet and scrub the ones in the outbox
+	if _, err = tx.ExecContext(ctx, anonymiseOutboxMessagesQuery, id.Value); err != nil {
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to anonymise emails: " + err.Error()})
+	}
+
+	// 8. Delete the documents, the files are deleted by the caller once committed
+	rows, err := tx.QueryContext(ctx, deleteMemberDocumentsQuery, id.Value)
+	if err != nil {
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to delete documents: " + err.Error()})
+	}
+	storageKeys := []string{}
+	for rows.Next() {
+		var storageKey string
+		if err = rows.Scan(&storageKey); err != nil {
+			rows.Close()
+			return result.Err[[]string](errors.RepositoryError{Description: "failed to scan document: " + err.Error()})
+		}
+		storageKeys = append(storageKeys, storageKey)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to delete documents: " + err.Error()})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[[]string](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return result.Ok(storageKeys)
+}
+
+func (r *SQLMemberRepository) GetGuardedMembers(id domain.Id[m.Member]) result.Result[[]m.User] {
+	return r.queryUsers("guarded members", getGuardedMembersQuery, id.Value)
+}
+
+func (r *SQLMemberRepository) GetGuardianMembers(ids []domain.Id[m.User]) result.Result[[]m.User] {
+	values := make([]int64, len(ids))
+	for i, id := range ids {
+		values[i] = id.Value
+	}
+	return r.queryUsers("guardian members", getGuardianMembersQuery, pq.Array(values))
+}
+
+// queryUsers reads the id, name and birth date of the members returned by the query
+func (r *SQLMemberRepository) queryUsers(what string, query string, args ...any) result.Result[[]m.User] {
+	rows, err := r.db.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		return result.Err[[]m.User](errors.RepositoryError{Description: "failed to query " + what + ": " + err.Error()})
+	}
+	defer rows.Close()
+
+	users := []m.User{}
+	for rows.Next() {
+		var userId int64
+		var user m.User
+		if err := rows.Scan(&userId, &user.FirstName, &user.LastName, &user.BirthDate); err != nil {
+			return result.Err[[]m.User](errors.RepositoryError{Description: "failed to scan " + what + ": " + err.Error()})
+		}
+		user.Id = domain.NewId[m.User](userId)
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]m.User](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(users)
+}
+
+func (r *SQLMemberRepository) GetUsersForDuplicateCheck() result.Result[[]m.User] {
+	rows, err := r.db.QueryContext(context.Background(), getUsersForDuplicateCheckQuery)
+	if err != nil {
+		return result.Err[[]m.User](errors.RepositoryError{Description: "failed to query members: " + err.Error()})
+	}
+	defer rows.Close()
+
+	users := []m.User{}
+	for rows.Next() {
+		var resultRow GetUsersForDuplicateCheckQueryResult
+		if err := rows.Scan(
+			&resultRow.MemberID,
+			&resultRow.FirstName,
+			&resultRow.LastName,
+			&resultRow.DateOfBirth,
+			&resultRow.Email,
+			&resultRow.TaxCode,
+			pq.Array(&resultRow.PhoneNumbers),
+		); err != nil {
+			return result.Err[[]m.User](errors.RepositoryError{Description: "failed to scan member: " + err.Error()})
+		}
+		users = append(users, MapToUserFromDuplicateCheckQuery(resultRow))
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]m.User](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(users)
+}
+
+func (r *SQLMemberRepository) MergeMembers(sourceId domain.Id[m.Member], targetId domain.Id[m.Member]) result.Result[m.MergeResult] {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	// 1. Lock both members and keep the personal data of the source
+	rows, err := tx.QueryContext(ctx, lockMembersForMergeQuery, sourceId.Value, targetId.Value)
+	if err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to lock members: " + err.Error()})
+	}
+	locked := 0
+	var sourceEmail, sourceTaxCode sql.NullString
+	var sourceHouseholdId sql.NullInt64
+	var sourceBilling BillingColumns
+	for rows.Next() {
+		var id int64
+		var email, taxCode sql.NullString
+		var householdId sql.NullInt64
+		var billing BillingColumns
+		if err := rows.Scan(&id, &email, &taxCode, &householdId, &billing.CompanyName, &billing.VATNumber, &billing.RecipientCode, &billing.PEC); err != nil {
+			rows.Close()
+			return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to scan member: " + err.Error()})
+		}
+		if id == sourceId.Value {
+			sourceEmail, sourceTaxCode, sourceHouseholdId, sourceBilling = email, taxCode, householdId, billing
+		}
+		locked++
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: err.Error()})
+	}
+	if locked != 2 {
+		return result.Err[m.MergeResult](errors.NotFoundError{Description: "Member not found or removed"})
+	}
+
+	mergeResult := m.MergeResult{SourceId: sourceId, TargetId: targetId}
+
+	// 2. Memberships: periods of the same season are merged, the others are moved
+	if mergeResult.MergedMembershipPeriods, err = countRows(ctx, tx, mergeMembershipPeriodsQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to merge membership periods: " + err.Error()})
+	}
+	takenOverPeriods := 0
+	if err = sumRows(ctx, tx, &takenOverPeriods, moveMembershipsQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to move memberships: " + err.Error()})
+	}
+	if mergeResult.MovedMembershipPeriods, err = countRows(ctx, tx, moveMembershipPeriodsQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to move membership periods: " + err.Error()})
+	}
+	mergeResult.MovedMembershipPeriods += takenOverPeriods
+	if _, err = tx.ExecContext(ctx, deleteMemberMembershipsQuery, sourceId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to delete memberships: " + err.Error()})
+	}
+
+	// 3. Rentals, with their payments
+	if mergeResult.MovedRentals, err = countRows(ctx, tx, moveRentedFacilitiesQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to move rentals: " + err.Error()})
+	}
+
+	// 4. Waiting list entries, keeping the earliest position
+	if _, err = tx.ExecContext(ctx, mergeWaitingEntriesQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to merge waiting list entries: " + err.Error()})
+	}
+	if mergeResult.MovedWaitingListEntries, err = countRows(ctx, tx, moveWaitingEntriesQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to move waiting list entries: " + err.Error()})
+	}
+	if _, err = tx.ExecContext(ctx, deleteMemberWaitingEntriesQuery, sourceId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to delete waiting list entries: " + err.Error()})
+	}
+
+	// 5. Contacts, birth place, guardians, documents and consents, skipping the ones the target already has
+	if mergeResult.MovedPhoneNumbers, err = countRows(ctx, tx, movePhoneNumbersQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to move phone numbers: " + err.Error()})
+	}
+	if mergeResult.MovedAddresses, err = countRows(ctx, tx, moveAddressesQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to move addresses: " + err.Error()})
+	}
+	if _, err = tx.ExecContext(ctx, moveBirthPlaceQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to move birth place: " + err.Error()})
+	}
+	if _, err = tx.ExecContext(ctx, moveGuardiansQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to move guardians: " + err.Error()})
+	}
+	if mergeResult.MovedDocuments, err = countRows(ctx, tx, moveMemberDocumentsQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to move documents: " + err.Error()})
+	}
+	if _, err = tx.ExecContext(ctx, moveMemberConsentsQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to move consents: " + err.Error()})
+	}
+
+	// 6. Fiscal documents, which the deletion of the source would otherwise detach
+	if mergeResult.MovedFiscalDocuments, err = countRows(ctx, tx, moveFiscalDocumentsQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to move fiscal documents: " + err.Error()})
+	}
+
+	// 7. Reminder letters, keeping the earliest date of the letters both members were sent
+	if _, err = tx.ExecContext(ctx, mergeReminderLettersQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to merge reminder letters: " + err.Error()})
+	}
+	if mergeResult.MovedReminderLetters, err = countRows(ctx, tx, moveReminderLettersQuery, sourceId.Value, targetId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to move reminder letters: " + err.Error()})
+	}
+
+	// 8. Delete the source, then fill in the email, tax code, household and billing details the target is missing
+	if _, err = tx.ExecContext(ctx, deleteMemberQuery, sourceId.Value); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to delete merged member: " + err.Error()})
+	}
+	_, err = tx.ExecContext(ctx, completeMergedMemberQuery,
+		targetId.Value,
+		sourceEmail,
+		sourceTaxCode,
+		sourceHouseholdId,
+		sourceBilling.CompanyName,
+		sourceBilling.VATNumber,
+		sourceBilling.RecipientCode,
+		sourceBilling.PEC,
+	)
+	if err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to complete merged member: " + err.Error()})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[m.MergeResult](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return result.Ok(mergeResult)
+}
+
+// countRows runs a statement returning one row per affected record and counts them
+func countRows(ctx context.Context, tx *sql.Tx, query string, args ...any) (int, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	return count, rows.Err()
+}
+
+// sumRows runs a statement returning a single count column and adds up the values
+func sumRows(ctx context.Context, tx *sql.Tx, total *int, query string, args ...any) error {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var value int
+		if err := rows.Scan(&value); err != nil {
+			return err
+		}
+		*total += value
+	}
+	return rows.Err()
+}
+
+// categoryId is NULL for an unset category, the membership period is then ordinary
+func categoryId(category m.MembershipCategory) sql.NullInt64 {
+	return sql.NullInt64{Int64: category.Id.Value, Valid: category.Id.Value != 0}
+}
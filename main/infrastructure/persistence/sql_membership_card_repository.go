@@ -0,0 +1,177 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+//go:embed queries/get_membership_numbering_policy.sql
+var getMembershipNumberingPolicyQuery string
+
+//go:embed queries/deactivate_membership_numbering_policies.sql
+var deactivateMembershipNumberingPoliciesQuery string
+
+//go:embed queries/insert_membership_numbering_policy.sql
+var insertMembershipNumberingPolicyQuery string
+
+//go:embed queries/get_next_card_number.sql
+var getNextCardNumberQuery string
+
+//go:embed queries/assign_membership_card.sql
+var assignMembershipCardQuery string
+
+//go:embed queries/get_membership_cards.sql
+var getMembershipCardsQuery string
+
+type SQLMembershipCardRepository struct {
+	db *sql.DB
+}
+
+func NewSQLMembershipCardRepository(db *sql.DB) *SQLMembershipCardRepository {
+	return &SQLMembershipCardRepository{db: db}
+}
+
+func (r *SQLMembershipCardRepository) GetNumberingPolicy() result.Result[membership.MembershipNumberingPolicy] {
+	policy, err := getNumberingPolicy(context.Background(), r.db)
+	if err != nil {
+		return result.Err[membership.MembershipNumberingPolicy](err)
+	}
+	return result.Ok(policy)
+}
+
+func (r *SQLMembershipCardRepository) SaveNumberingPolicy(policy membership.MembershipNumberingPolicy) result.Result[membership.MembershipNumberingPolicy] {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[membership.MembershipNumberingPolicy](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, deactivateMembershipNumberingPoliciesQuery); err != nil {
+		return result.Err[membership.MembershipNumberingPolicy](errors.RepositoryError{Description: "failed to deactivate numbering policies: " + err.Error()})
+	}
+	_, err = tx.ExecContext(ctx, insertMembershipNumberingPolicyQuery,
+		string(policy.Scope),
+		policy.Prefix,
+		policy.Digits,
+		policy.ReuseFreedNumbers,
+	)
+	if err != nil {
+		return result.Err[membership.MembershipNumberingPolicy](errors.RepositoryError{Description: "failed to insert numbering policy: " + err.Error()})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[membership.MembershipNumberingPolicy](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return result.Ok(policy)
+}
+
+func (r *SQLMembershipCardRepository) GetMembershipCards(seasonId int64) result.Result[[]membership.MembershipCard] {
+	return r.getMembershipCards(seasonId, sql.NullInt64{})
+}
+
+func (r *SQLMembershipCardRepository) GetMembershipCard(memberId domain.Id[membership.Member], seasonId int64) result.Result[membership.MembershipCard] {
+	cards := r.getMembershipCards(seasonId, sql.NullInt64{Int64: memberId.Value, Valid: true})
+	if !cards.IsSuccess() {
+		return result.Err[membership.MembershipCard](cards.Error())
+	}
+	if len(cards.Value()) == 0 {
+		return result.Err[membership.MembershipCard](errors.NotFoundError{Description: "no active membership for the member in the season"})
+	}
+	return result.Ok(cards.Value()[0])
+}
+
+func (r *SQLMembershipCardRepository) getMembershipCards(seasonId int64, memberId sql.NullInt64) result.Result[[]membership.MembershipCard] {
+	rows, err := r.db.QueryContext(context.Background(), getMembershipCardsQuery, seasonId, memberId)
+	if err != nil {
+		return result.Err[[]membership.MembershipCard](errors.RepositoryError{Description: "failed to get membership cards: " + err.Error()})
+	}
+	defer rows.Close()
+
+	cards := []membership.MembershipCard{}
+	for rows.Next() {
+		var memberId int64
+		var category []byte
+		var card membership.MembershipCard
+		err := rows.Scan(
+			&memberId,
+			&card.FirstName,
+			&card.LastName,
+			&card.BirthDate,
+			&card.MembershipNumber,
+			&card.CardCode,
+			&card.SeasonId,
+			&card.SeasonCode,
+			&card.SeasonName,
+			&card.ValidFrom,
+			&card.ValidUntil,
+			&category,
+		)
+		if err != nil {
+			return result.Err[[]membership.MembershipCard](errors.RepositoryError{Description: "failed to scan membership card: " + err.Error()})
+		}
+		card.MemberId = domain.NewId[membership.Member](memberId)
+		if card.Category, err = parseMembershipCategory(category); err != nil {
+			return result.Err[[]membership.MembershipCard](err)
+		}
+		cards = append(cards, card)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]membership.MembershipCard](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(cards)
+}
+
+func getNumberingPolicy(ctx context.Context, db queryRower) (membership.MembershipNumberingPolicy, error) {
+	var scope string
+	var policy membership.MembershipNumberingPolicy
+	err := db.QueryRowContext(ctx, getMembershipNumberingPolicyQuery).Scan(
+		&scope,
+		&policy.Prefix,
+		&policy.Digits,
+		&policy.ReuseFreedNumbers,
+	)
+	if err == sql.ErrNoRows {
+		return membership.DefaultMembershipNumberingPolicy, nil
+	}
+	if err != nil {
+		return membership.MembershipNumberingPolicy{}, errors.RepositoryError{Description: "failed to get numbering policy: " + err.Error()}
+	}
+	policy.Scope = membership.NumberingScope(scope)
+	return policy, nil
+}
+
+// nextMembershipNumber allocates the number of a new membership,
+// freed numbers are reused only when the cards carry the membership number
+func nextMembershipNumber(ctx context.Context, tx *sql.Tx, policy membership.MembershipNumberingPolicy) (int64, error) {
+	reuse := policy.Scope == membership.GlobalNumbering && policy.ReuseFreedNumbers
+	var number int64
+	if err := tx.QueryRowContext(ctx, getNextMembershipNumberQuery, reuse).Scan(&number); err != nil {
+		return 0, errors.RepositoryError{Description: "failed to get next membership number: " + err.Error()}
+	}
+	return number, nil
+}
+
+// assignMembershipCard numbers the card of a new membership period following the policy
+func assignMembershipCard(ctx context.Context, tx *sql.Tx, policy membership.MembershipNumberingPolicy, periodId int64, seasonId int64, membershipNumber int64) error {
+	number := membershipNumber
+	if policy.Scope == membership.SeasonNumbering {
+		if err := tx.QueryRowContext(ctx, getNextCardNumberQuery, seasonId, policy.ReuseFreedNumbers).Scan(&number); err != nil {
+			return errors.RepositoryError{Description: "failed to get next card number: " + err.Error()}
+		}
+	}
+	if _, err := tx.ExecContext(ctx, assignMembershipCardQuery, periodId, number, policy.Format(number)); err != nil {
+		return errors.RepositoryError{Description: "failed to assign membership card: " + err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+//go:embed queries/get_membership_category_prices.sql
+var getMembershipCategoryPricesQuery string
+
+//go:embed queries/get_membership_category_price.sql
+var getMembershipCategoryPriceQuery string
+
+//go:embed queries/upsert_membership_category_price.sql
+var upsertMembershipCategoryPriceQuery string
+
+type SQLMembershipCategoryRepository struct {
+	db *sql.DB
+}
+
+func NewSQLMembershipCategoryRepository(db *sql.DB) *SQLMembershipCategoryRepository {
+	return &SQLMembershipCategoryRepository{db: db}
+}
+
+func (r *SQLMembershipCategoryRepository) GetCategoryPrices(seasonId int64) result.Result[[]membership.MembershipCategoryPrice] {
+	rows, err := r.db.QueryContext(context.Background(), getMembershipCategoryPricesQuery, seasonId)
+	if err != nil {
+		return result.Err[[]membership.MembershipCategoryPrice](errors.RepositoryError{Description: "failed to get membership categories: " + err.Error()})
+	}
+	defer rows.Close()
+
+	prices := []membership.MembershipCategoryPrice{}
+	for rows.Next() {
+		price, err := scanMembershipCategoryPrice(rows, seasonId)
+		if err != nil {
+			return result.Err[[]membership.MembershipCategoryPrice](errors.RepositoryError{Description: "failed to scan membership category: " + err.Error()})
+		}
+		prices = append(prices, price)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]membership.MembershipCategoryPrice](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(prices)
+}
+
+func (r *SQLMembershipCategoryRepository) GetCategoryPrice(code membership.MembershipCategoryCode, seasonId int64) result.Result[membership.MembershipCategoryPrice] {
+	row := r.db.QueryRowContext(context.Background(), getMembershipCategoryPriceQuery, string(code), seasonId)
+	price, err := scanMembershipCategoryPrice(row, seasonId)
+	if err == sql.ErrNoRows {
+		return result.Err[membership.MembershipCategoryPrice](errors.MembershipCategoryError{Description: "unknown membership category " + string(code)})
+	}
+	if err != nil {
+		return result.Err[membership.MembershipCategoryPrice](errors.RepositoryError{Description: "failed to get membership category: " + err.Error()})
+	}
+	return result.Ok(price)
+}
+
+func (r *SQLMembershipCategoryRepository) SetCategoryPrice(code membership.MembershipCategoryCode, seasonId int64, price money.Money) result.Result[membership.MembershipCategoryPrice] {
+	res, err := r.db.ExecContext(context.Background(), upsertMembershipCategoryPriceQuery, string(code), seasonId, price, currencyOf(price))
+	if err != nil {
+		return result.Err[membership.MembershipCategoryPrice](errors.RepositoryError{Description: "failed to set membership category price: " + err.Error()})
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return result.Err[membership.MembershipCategoryPrice](errors.MembershipCategoryError{Description: "unknown membership category " + string(code)})
+	}
+	return r.GetCategoryPrice(code, seasonId)
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMembershipCategoryPrice(row rowScanner, seasonId int64) (membership.MembershipCategoryPrice, error) {
+	var id int64
+	var code, name string
+	var paymentRequired bool
+	var price sql.NullFloat64
+	var currency sql.NullString
+	if err := row.Scan(&id, &code, &name, &paymentRequired, &price, &currency); err != nil {
+		return membership.MembershipCategoryPrice{}, err
+	}
+
+	categoryPrice := membership.MembershipCategoryPrice{
+		Category: membership.MembershipCategory{
+			Id:              domain.NewId[membership.MembershipCategory](id),
+			Code:            membership.MembershipCategoryCode(code),
+			Name:            name,
+			PaymentRequired: paymentRequired,
+		},
+		SeasonId: seasonId,
+	}
+	if price.Valid {
+		stored := amountOf(price.Float64, currency.String)
+		categoryPrice.Price = &stored
+	}
+	return categoryPrice, nil
+}
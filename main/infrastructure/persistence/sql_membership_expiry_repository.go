@@ -0,0 +1,177 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/lib/pq"
+)
+
+//go:embed queries/lock_membership_expiry.sql
+var lockMembershipExpiryQuery string
+
+//go:embed queries/expire_ended_membership_periods.sql
+var expireEndedMembershipPeriodsQuery string
+
+//go:embed queries/flag_rentals_without_active_membership.sql
+var flagRentalsWithoutActiveMembershipQuery string
+
+//go:embed queries/insert_membership_expiry_run.sql
+var insertMembershipExpiryRunQuery string
+
+//go:embed queries/get_membership_expiry_runs.sql
+var getMembershipExpiryRunsQuery string
+
+type SQLMembershipExpiryRepository struct {
+	db *sql.DB
+}
+
+func NewSQLMembershipExpiryRepository(db *sql.DB) *SQLMembershipExpiryRepository {
+	return &SQLMembershipExpiryRepository{db: db}
+}
+
+func (r *SQLMembershipExpiryRepository) ExpireMemberships(date time.Time, trigger club.ExpiryTrigger, startedAt time.Time) result.Result[club.ExpiryRun] {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[club.ExpiryRun](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	// Only one run at a time, whether scheduled or manual
+	var locked bool
+	if err = tx.QueryRowContext(ctx, lockMembershipExpiryQuery).Scan(&locked); err != nil {
+		return result.Err[club.ExpiryRun](errors.RepositoryError{Description: "failed to acquire expiry lock: " + err.Error()})
+	}
+	if !locked {
+		return result.Err[club.ExpiryRun](errors.MembershipStatusError{Description: "membership expiry is already running"})
+	}
+
+	run := club.ExpiryRun{
+		Trigger:            trigger,
+		ReferenceDate:      date,
+		StartedAt:          startedAt,
+		ExpiredMemberships: []club.ExpiredMembership{},
+		FlaggedRentals:     []club.FlaggedRental{},
+	}
+
+	// Expire periods of ended seasons
+	rows, err := tx.QueryContext(ctx, expireEndedMembershipPeriodsQuery, date)
+	if err != nil {
+		return result.Err[club.ExpiryRun](errors.RepositoryError{Description: "failed to expire memberships: " + err.Error()})
+	}
+	expiredMemberIds := []int64{}
+	for rows.Next() {
+		var memberId int64
+		var expired club.ExpiredMembership
+		if err := rows.Scan(
+			&expired.PeriodId,
+			&memberId,
+			&expired.FirstName,
+			&expired.LastName,
+			&expired.MembershipNumber,
+			&expired.SeasonId,
+		); err != nil {
+			rows.Close()
+			return result.Err[club.ExpiryRun](errors.RepositoryError{Description: "failed to scan expired membership: " + err.Error()})
+		}
+		expired.MemberId = domain.NewId[membership.Member](memberId)
+		run.ExpiredMemberships = append(run.ExpiredMemberships, expired)
+		expiredMemberIds = append(expiredMemberIds, memberId)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return result.Err[club.ExpiryRun](errors.RepositoryError{Description: err.Error()})
+	}
+
+	// Flag rentals of members left without an active membership
+	if len(expiredMemberIds) > 0 {
+		rows, err = tx.QueryContext(ctx, flagRentalsWithoutActiveMembershipQuery, date, startedAt, pq.Array(expiredMemberIds))
+		if err != nil {
+			return result.Err[club.ExpiryRun](errors.RepositoryError{Description: "failed to flag rentals: " + err.Error()})
+		}
+		for rows.Next() {
+			var memberId int64
+			var flagged club.FlaggedRental
+			if err := rows.Scan(
+				&flagged.RentedFacilityId,
+				&memberId,
+				&flagged.FacilityIdentifier,
+				&flagged.SeasonId,
+				&flagged.Reason,
+			); err != nil {
+				rows.Close()
+				return result.Err[club.ExpiryRun](errors.RepositoryError{Description: "failed to scan flagged rental: " + err.Error()})
+			}
+			flagged.MemberId = domain.NewId[membership.Member](memberId)
+			run.FlaggedRentals = append(run.FlaggedRentals, flagged)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return result.Err[club.ExpiryRun](errors.RepositoryError{Description: err.Error()})
+		}
+	}
+
+	// Record the run
+	details, err := json.Marshal(ConvertExpiryRunToDetails(run))
+	if err != nil {
+		return result.Err[club.ExpiryRun](errors.RepositoryError{Description: "failed to encode run details: " + err.Error()})
+	}
+	err = tx.QueryRowContext(ctx, insertMembershipExpiryRunQuery,
+		string(trigger),
+		date,
+		startedAt,
+		len(run.ExpiredMemberships),
+		len(run.FlaggedRentals),
+		details,
+	).Scan(&run.Id, &run.FinishedAt)
+	if err != nil {
+		return result.Err[club.ExpiryRun](errors.RepositoryError{Description: "failed to record expiry run: " + err.Error()})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[club.ExpiryRun](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return result.Ok(run)
+}
+
+func (r *SQLMembershipExpiryRepository) GetExpiryRuns(limit int) result.Result[[]club.ExpiryRun] {
+	rows, err := r.db.QueryContext(context.Background(), getMembershipExpiryRunsQuery, limit)
+	if err != nil {
+		return result.Err[[]club.ExpiryRun](errors.RepositoryError{Description: "failed to query expiry runs: " + err.Error()})
+	}
+	defer rows.Close()
+
+	runs := []club.ExpiryRun{}
+	for rows.Next() {
+		var run club.ExpiryRun
+		var trigger string
+		var rawDetails []byte
+		if err := rows.Scan(&run.Id, &trigger, &run.ReferenceDate, &run.StartedAt, &run.FinishedAt, &rawDetails); err != nil {
+			return result.Err[[]club.ExpiryRun](errors.RepositoryError{Description: "failed to scan expiry run: " + err.Error()})
+		}
+		run.Trigger = club.ExpiryTrigger(trigger)
+
+		var details MembershipExpiryRunDetails
+		if err := json.Unmarshal(rawDetails, &details); err != nil {
+			return result.Err[[]club.ExpiryRun](errors.RepositoryError{Description: "failed to decode run details: " + err.Error()})
+		}
+		runs = append(runs, ConvertDetailsToExpiryRun(run, details))
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]club.ExpiryRun](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(runs)
+}
@@ -0,0 +1,162 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/notification"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+//go:embed queries/insert_outbox_message.sql
+var insertOutboxMessageQuery string
+
+//go:embed queries/get_outbox_messages.sql
+var getOutboxMessagesQuery string
+
+//go:embed queries/claim_due_outbox_messages.sql
+var claimDueOutboxMessagesQuery string
+
+//go:embed queries/update_outbox_message.sql
+var updateOutboxMessageQuery string
+
+// defaultOutboxLimit caps the emails listed when the criteria set no limit
+const defaultOutboxLimit = 100
+
+type SQLOutboxRepository struct {
+	db *sql.DB
+}
+
+func NewSQLOutboxRepository(db *sql.DB) *SQLOutboxRepository {
+	return &SQLOutboxRepository{db: db}
+}
+
+func (r *SQLOutboxRepository) Enqueue(message notification.OutboxMessage) result.Result[notification.OutboxMessage] {
+	err := r.db.QueryRowContext(context.Background(), insertOutboxMessageQuery,
+		string(message.Kind),
+		message.MemberId,
+		message.Message.To.Email,
+		message.Message.To.Name,
+		message.Message.Subject,
+		message.Message.Body,
+		string(message.Status),
+		message.Attempts,
+		message.NextAttemptAt,
+		message.CreatedAt,
+	).Scan(&message.Id)
+	if err != nil {
+		return result.Err[notification.OutboxMessage](errors.RepositoryError{Description: "failed to enqueue email: " + err.Error()})
+	}
+	return result.Ok(message)
+}
+
+func (r *SQLOutboxRepository) ClaimDueMessages(now time.Time, claimedUntil time.Time, limit int) result.Result[[]notification.OutboxMessage] {
+	messages, err := r.queryMessages(claimDueOutboxMessagesQuery, now, limit, claimedUntil)
+	if err != nil {
+		return result.Err[[]notification.OutboxMessage](err)
+	}
+	return result.Ok(messages)
+}
+
+func (r *SQLOutboxRepository) GetMessages(criteria notification.OutboxCriteria) result.Result[[]notification.OutboxMessage] {
+	status := sql.NullString{Valid: criteria.Status != nil}
+	if criteria.Status != nil {
+		status.String = string(*criteria.Status)
+	}
+	limit := criteria.Limit
+	if limit <= 0 {
+		limit = defaultOutboxLimit
+	}
+
+	messages, err := r.queryMessages(getOutboxMessagesQuery, nil, status, criteria.MemberId, limit)
+	if err != nil {
+		return result.Err[[]notification.OutboxMessage](err)
+	}
+	return result.Ok(messages)
+}
+
+func (r *SQLOutboxRepository) GetMessage(id int64) result.Result[notification.OutboxMessage] {
+	messages, err := r.queryMessages(getOutboxMessagesQuery, id, nil, nil, 1)
+	if err != nil {
+		return result.Err[notification.OutboxMessage](err)
+	}
+	if len(messages) == 0 {
+		return result.Err[notification.OutboxMessage](errors.NotFoundError{Description: "email not found"})
+	}
+	return result.Ok(messages[0])
+}
+
+func (r *SQLOutboxRepository) UpdateMessage(message notification.OutboxMessage) result.Result[notification.OutboxMessage] {
+	res, err := r.db.ExecContext(context.Background(), updateOutboxMessageQuery,
+		message.Id,
+		string(message.Status),
+		message.Attempts,
+		message.LastError,
+		message.NextAttemptAt,
+		message.SentAt,
+	)
+	if err != nil {
+		return result.Err[notification.OutboxMessage](errors.RepositoryError{Description: "failed to update email: " + err.Error()})
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return result.Err[notification.OutboxMessage](errors.RepositoryError{Description: "failed to update email: " + err.Error()})
+	}
+	if affected == 0 {
+		return result.Err[notification.OutboxMessage](errors.NotFoundError{Description: "email not found"})
+	}
+	return result.Ok(message)
+}
+
+func (r *SQLOutboxRepository) queryMessages(query string, arguments ...any) ([]notification.OutboxMessage, error) {
+	rows, err := r.db.QueryContext(context.Background(), query, arguments...)
+	if err != nil {
+		return nil, errors.RepositoryError{Description: "failed to get emails: " + err.Error()}
+	}
+	defer rows.Close()
+
+	messages := []notification.OutboxMessage{}
+	for rows.Next() {
+		var message notification.OutboxMessage
+		var kind, status string
+		var memberId sql.NullInt64
+		var sentAt sql.NullTime
+		err := rows.Scan(
+			&message.Id,
+			&kind,
+			&memberId,
+			&message.Message.To.Email,
+			&message.Message.To.Name,
+			&message.Message.Subject,
+			&message.Message.Body,
+			&status,
+			&message.Attempts,
+			&message.LastError,
+			&message.NextAttemptAt,
+			&sentAt,
+			&message.CreatedAt,
+		)
+		if err != nil {
+			return nil, errors.RepositoryError{Description: "failed to scan email: " + err.Error()}
+		}
+
+		message.Kind = notification.NotificationKind(kind)
+		message.Status = notification.OutboxStatus(status)
+		if memberId.Valid {
+			message.MemberId = &memberId.Int64
+		}
+		if sentAt.Valid {
+			message.SentAt = &sentAt.Time
+		}
+		messages = append(messages, message)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.RepositoryError{Description: err.Error()}
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,211 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+//go:embed queries/get_payment_deadlines.sql
+var getPaymentDeadlinesQuery string
+
+//go:embed queries/delete_payment_deadlines.sql
+var deletePaymentDeadlinesQuery string
+
+//go:embed queries/insert_payment_deadline.sql
+var insertPaymentDeadlineQuery string
+
+//go:embed queries/get_charged_late_fees.sql
+var getChargedLateFeesQuery string
+
+//go:embed queries/insert_late_fee.sql
+var insertLateFeeQuery string
+
+type SQLPaymentDeadlineRepository struct {
+	db *sql.DB
+}
+
+func NewSQLPaymentDeadlineRepository(db *sql.DB) *SQLPaymentDeadlineRepository {
+	return &SQLPaymentDeadlineRepository{db: db}
+}
+
+func (r *SQLPaymentDeadlineRepository) GetDeadlines(seasonId *int64) result.Result[[]payment.PaymentDeadline] {
+	rows, err := r.db.QueryContext(context.Background(), getPaymentDeadlinesQuery, seasonId)
+	if err != nil {
+		return result.Err[[]payment.PaymentDeadline](errors.RepositoryError{Description: "failed to get payment deadlines: " + err.Error()})
+	}
+	defer rows.Close()
+
+	deadlines := []payment.PaymentDeadline{}
+	for rows.Next() {
+		var deadline payment.PaymentDeadline
+		var target string
+		var facilityTypeId sql.NullInt64
+		var facilityTypeName, lateFeeKind sql.NullString
+		var lateFeeAmount sql.NullFloat64
+		var lateFeeAfterDays int
+		var dueOn time.Time
+		err := rows.Scan(
+			&deadline.Id,
+			&deadline.SeasonId,
+			&target,
+			&facilityTypeId,
+			&facilityTypeName,
+			&dueOn,
+			&lateFeeKind,
+			&lateFeeAmount,
+			&lateFeeAfterDays,
+		)
+		if err != nil {
+			return result.Err[[]payment.PaymentDeadline](errors.RepositoryError{Description: "failed to scan payment deadline: " + err.Error()})
+		}
+
+		deadline.Target = payment.PaymentTarget(target)
+		if facilityTypeId.Valid {
+			deadline.FacilityTypeId = &facilityTypeId.Int64
+		}
+		deadline.FacilityTypeName = facilityTypeName.String
+		deadline.DueOn = payment.Day(dueOn)
+		if lateFeeKind.Valid {
+			deadline.LateFee = &payment.LateFeeRule{
+				Kind:      payment.LateFeeKind(lateFeeKind.String),
+				AfterDays: lateFeeAfterDays,
+			}
+			// The amount column keeps the euros of a fixed fee or the percentage points of a percentage one
+			if deadline.LateFee.Kind == payment.PercentageLateFee {
+				deadline.LateFee.Percentage = lateFeeAmount.Float64
+			} else {
+				deadline.LateFee.Amount = priceOf(lateFeeAmount.Float64)
+			}
+		}
+		deadlines = append(deadlines, deadline)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]payment.PaymentDeadline](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(deadlines)
+}
+
+func (r *SQLPaymentDeadlineRepository) SetDeadlines(seasonId int64, deadlines []payment.PaymentDeadline) result.Result[[]payment.PaymentDeadline] {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[[]payment.PaymentDeadline](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, deletePaymentDeadlinesQuery, seasonId); err != nil {
+		return result.Err[[]payment.PaymentDeadline](errors.RepositoryError{Description: "failed to delete payment deadlines: " + err.Error()})
+	}
+
+	for _, deadline := range deadlines {
+		var lateFeeKind sql.NullString
+		var lateFeeAmount sql.NullFloat64
+		var lateFeeAfterDays int
+		if deadline.LateFee != nil {
+			lateFeeKind = sql.NullString{String: string(deadline.LateFee.Kind), Valid: true}
+			lateFeeAmount = sql.NullFloat64{Float64: deadline.LateFee.Amount.Float64(), Valid: true}
+			if deadline.LateFee.Kind == payment.PercentageLateFee {
+				lateFeeAmount.Float64 = deadline.LateFee.Percentage
+			}
+			lateFeeAfterDays = deadline.LateFee.AfterDays
+		}
+
+		var id int64
+		err := tx.QueryRowContext(ctx, insertPaymentDeadlineQuery,
+			seasonId,
+			string(deadline.Target),
+			deadline.FacilityTypeId,
+			pgDate(deadline.DueOn),
+			lateFeeKind,
+			lateFeeAmount,
+			lateFeeAfterDays,
+		).Scan(&id)
+		if err != nil {
+			return result.Err[[]payment.PaymentDeadline](errors.RepositoryError{Description: "failed to insert payment deadline: " + err.Error()})
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[[]payment.PaymentDeadline](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	// Read them back for the names of the facility types
+	return r.GetDeadlines(&seasonId)
+}
+
+func (r *SQLPaymentDeadlineRepository) GetOutstandingItems(seasonId *int64) result.Result[[]payment.OutstandingItem] {
+	return queryOutstandingItems(r.db, seasonId)
+}
+
+func (r *SQLPaymentDeadlineRepository) GetChargedLateFees(seasonId *int64) result.Result[[]payment.ChargedLateFee] {
+	rows, err := r.db.QueryContext(context.Background(), getChargedLateFeesQuery, seasonId)
+	if err != nil {
+		return result.Err[[]payment.ChargedLateFee](errors.RepositoryError{Description: "failed to get late fees: " + err.Error()})
+	}
+	defer rows.Close()
+
+	fees := []payment.ChargedLateFee{}
+	for rows.Next() {
+		var fee payment.ChargedLateFee
+		var membershipPeriodId, rentedFacilityId sql.NullInt64
+		var amount float64
+		var currency string
+		var chargedOn time.Time
+		if err := rows.Scan(&membershipPeriodId, &rentedFacilityId, &amount, &currency, &chargedOn); err != nil {
+			return result.Err[[]payment.ChargedLateFee](errors.RepositoryError{Description: "failed to scan late fee: " + err.Error()})
+		}
+		if membershipPeriodId.Valid {
+			fee.MembershipPeriodId = &membershipPeriodId.Int64
+		}
+		if rentedFacilityId.Valid {
+			fee.RentedFacilityId = &rentedFacilityId.Int64
+		}
+		fee.Amount = amountOf(amount, currency)
+		fee.ChargedOn = payment.Day(chargedOn)
+		fees = append(fees, fee)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]payment.ChargedLateFee](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(fees)
+}
+
+func (r *SQLPaymentDeadlineRepository) ChargeLateFees(fees []payment.ChargedLateFee) result.Result[bool] {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[bool](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	for _, fee := range fees {
+		_, err := tx.ExecContext(ctx, insertLateFeeQuery,
+			fee.MembershipPeriodId,
+			fee.RentedFacilityId,
+			fee.Amount.Float64(),
+			currencyOf(fee.Amount),
+			pgDate(fee.ChargedOn),
+		)
+		if err != nil {
+			return result.Err[bool](errors.RepositoryError{Description: "failed to insert late fee: " + err.Error()})
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[bool](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return result.Ok(true)
+}
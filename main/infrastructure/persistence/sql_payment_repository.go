@@ -21,6 +21,24 @@ var updatePaymentQuery string
 //go:embed queries/delete_payment.sql
 var deletePaymentQuery string
 
+//go:embed queries/get_membership_period_ledger.sql
+var getMembershipPeriodLedgerQuery string
+
+//go:embed queries/get_rented_facility_ledger.sql
+var getRentedFacilityLedgerQuery string
+
+//go:embed queries/get_payment_ledger.sql
+var getPaymentLedgerQuery string
+
+//go:embed queries/is_payment_documented.sql
+var isPaymentDocumentedQuery string
+
+//go:embed queries/search_payments.sql
+var searchPaymentsQuery string
+
+//go:embed queries/get_payment_totals_by_method.sql
+var getPaymentTotalsByMethodQuery string
+
 type SQLPaymentRepository struct {
 	db *sql.DB
 }
@@ -29,20 +47,44 @@ func NewSQLPaymentRepository(db *sql.DB) *SQLPaymentRepository {
 	return &SQLPaymentRepository{db: db}
 }
 
-func (r *SQLPaymentRepository) CreatePaymentForMembershipPeriod(membershipPeriodId int64, amount float64, currency string, paymentMethod string, transactionRef *string) result.Result[int64] {
+func (r *SQLPaymentRepository) GetMembershipPeriodLedger(membershipPeriodId int64) result.Result[payment.Ledger] {
+	return scanLedger(r.db.QueryRowContext(context.Background(), getMembershipPeriodLedgerQuery, membershipPeriodId), "Membership period not found")
+}
+
+func (r *SQLPaymentRepository) GetRentedFacilityLedger(rentedFacilityId int64) result.Result[payment.Ledger] {
+	return scanLedger(r.db.QueryRowContext(context.Background(), getRentedFacilityLedgerQuery, rentedFacilityId), "Rented facility not found")
+}
+
+func (r *SQLPaymentRepository) GetPaymentLedger(paymentId domain.Id[payment.Transaction]) result.Result[payment.Ledger] {
+	return scanLedger(r.db.QueryRowContext(context.Background(), getPaymentLedgerQuery, paymentId.Value), "payment not found")
+}
+
+func (r *SQLPaymentRepository) IsPaymentDocumented(paymentId domain.Id[payment.Transaction]) result.Result[bool] {
+	var documented bool
+	if err := r.db.QueryRowContext(context.Background(), isPaymentDocumentedQuery, paymentId.Value).Scan(&documented); err != nil {
+		return result.Err[bool](errors.RepositoryError{Description: "failed to check fiscal documents of payment: " + err.Error()})
+	}
+	return result.Ok(documented)
+}
+
+func (r *SQLPaymentRepository) CreatePaymentForMembershipPeriod(membershipPeriodId int64, transaction payment.Transaction) result.Result[int64] {
 	var paymentId int64
-	paidAt := time.Now()
+	paidAt := transaction.Date
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
 
 	err := r.db.QueryRowContext(
 		context.Background(),
 		insertPaymentQuery,
 		nil, // rented_facility_id
 		membershipPeriodId,
-		amount,
-		currency,
+		transaction.Amount,
+		currencyOf(transaction.Amount),
 		paidAt,
-		paymentMethod,
-		transactionRef,
+		transaction.PaymentMethod,
+		nullableNotes(transaction.Notes),
+		string(transaction.Type),
 	).Scan(&paymentId)
 
 	if err != nil {
@@ -52,20 +94,24 @@ func (r *SQLPaymentRepository) CreatePaymentForMembershipPeriod(membershipPeriod
 	return result.Ok(paymentId)
 }
 
-func (r *SQLPaymentRepository) CreatePaymentForRentedFacility(rentedFacilityId int64, amount float64, currency string, paymentMethod string, transactionRef *string) result.Result[int64] {
+func (r *SQLPaymentRepository) CreatePaymentForRentedFacility(rentedFacilityId int64, transaction payment.Transaction) result.Result[int64] {
 	var paymentId int64
-	paidAt := time.Now()
+	paidAt := transaction.Date
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
 
 	err := r.db.QueryRowContext(
 		context.Background(),
 		insertPaymentQuery,
 		rentedFacilityId,
 		nil, // membership_period_id
-		amount,
-		currency,
+		transaction.Amount,
+		currencyOf(transaction.Amount),
 		paidAt,
-		paymentMethod,
-		transactionRef,
+		transaction.PaymentMethod,
+		nullableNotes(transaction.Notes),
+		string(transaction.Type),
 	).Scan(&paymentId)
 
 	if err != nil {
@@ -75,15 +121,15 @@ func (r *SQLPaymentRepository) CreatePaymentForRentedFacility(rentedFacilityId i
 	return result.Ok(paymentId)
 }
 
-func (r *SQLPaymentRepository) UpdatePayment(paymentId domain.Id[payment.Payment], amount float64, currency string, paymentMethod string, transactionRef *string) result.Result[bool] {
+func (r *SQLPaymentRepository) UpdatePayment(transaction payment.Transaction) result.Result[bool] {
 	execResult, err := r.db.ExecContext(
 		context.Background(),
 		updatePaymentQuery,
-		amount,
-		currency,
-		paymentMethod,
-		transactionRef,
-		paymentId.Value,
+		transaction.Amount,
+		currencyOf(transaction.Amount),
+		transaction.PaymentMethod,
+		nullableNotes(transaction.Notes),
+		transaction.Id.Value,
 	)
 
 	if err != nil {
@@ -96,13 +142,13 @@ func (r *SQLPaymentRepository) UpdatePayment(paymentId domain.Id[payment.Payment
 	}
 
 	if rowsAffected == 0 {
-		return result.Err[bool](errors.RepositoryError{Description: "payment not found"})
+		return result.Err[bool](errors.NotFoundError{Description: "payment not found"})
 	}
 
 	return result.Ok(true)
 }
 
-func (r *SQLPaymentRepository) DeletePayment(paymentId domain.Id[payment.Payment]) result.Result[bool] {
+func (r *SQLPaymentRepository) DeletePayment(paymentId domain.Id[payment.Transaction]) result.Result[bool] {
 	execResult, err := r.db.ExecContext(
 		context.Background(),
 		deletePaymentQuery,
@@ -119,8 +165,162 @@ func (r *SQLPaymentRepository) DeletePayment(paymentId domain.Id[payment.Payment
 	}
 
 	if rowsAffected == 0 {
-		return result.Err[bool](errors.RepositoryError{Description: "payment not found"})
+		return result.Err[bool](errors.NotFoundError{Description: "payment not found"})
 	}
 
 	return result.Ok(true)
 }
+
+func (r *SQLPaymentRepository) SearchPayments(criteria payment.PaymentSearchCriteria) result.Result[payment.PaymentPage] {
+	arguments := append(paymentSearchArguments(criteria), criteria.Limit, criteria.Offset)
+	rows, err := r.db.QueryContext(context.Background(), searchPaymentsQuery, arguments...)
+	if err != nil {
+		return result.Err[payment.PaymentPage](errors.RepositoryError{Description: "failed to search payments: " + err.Error()})
+	}
+	defer rows.Close()
+
+	page := payment.PaymentPage{
+		Payments: []payment.PaymentRecord{},
+		Limit:    criteria.Limit,
+		Offset:   criteria.Offset,
+	}
+	for rows.Next() {
+		var record paymentRecordRow
+		if err := rows.Scan(append([]any{&page.Total}, record.targets()...)...); err != nil {
+			return result.Err[payment.PaymentPage](errors.RepositoryError{Description: "failed to scan payment: " + err.Error()})
+		}
+		if !record.id.Valid {
+			continue
+		}
+		page.Payments = append(page.Payments, record.toDomain())
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[payment.PaymentPage](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(page)
+}
+
+func (r *SQLPaymentRepository) GetTotalsByMethod(criteria payment.PaymentSearchCriteria) result.Result[[]payment.MethodTotal] {
+	rows, err := r.db.QueryContext(context.Background(), getPaymentTotalsByMethodQuery, paymentSearchArguments(criteria)...)
+	if err != nil {
+		return result.Err[[]payment.MethodTotal](errors.RepositoryError{Description: "failed to get payment totals: " + err.Error()})
+	}
+	defer rows.Close()
+
+	totals := []payment.MethodTotal{}
+	for rows.Next() {
+		var total payment.MethodTotal
+		var paid, refunded float64
+		if err := rows.Scan(&total.PaymentMethod, &total.Count, &paid, &refunded); err != nil {
+			return result.Err[[]payment.MethodTotal](errors.RepositoryError{Description: "failed to scan payment total: " + err.Error()})
+		}
+		total.Paid = priceOf(paid)
+		total.Refunded = priceOf(refunded)
+		totals = append(totals, total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]payment.MethodTotal](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(totals)
+}
+
+// paymentSearchArguments are the filters shared by the search and the totals queries, $1 to $8
+func paymentSearchArguments(criteria payment.PaymentSearchCriteria) []any {
+	target := sql.NullString{Valid: criteria.Target != nil}
+	if criteria.Target != nil {
+		target.String = string(*criteria.Target)
+	}
+	return []any{
+		criteria.SeasonId,
+		criteria.PaidFrom,
+		criteria.PaidBefore(),
+		sql.NullString{String: criteria.PaymentMethod, Valid: criteria.PaymentMethod != ""},
+		criteria.MemberId,
+		target,
+		criteria.MinAmount,
+		criteria.MaxAmount,
+	}
+}
+
+// paymentRecordRow scans the columns of the payment_records view, null when a page is empty
+type paymentRecordRow struct {
+	id, membershipPeriodId, rentedFacilityId, memberId, seasonId                                     sql.NullInt64
+	transactionType, currency, paymentMethod, notes, target, firstName, lastName, facilityIdentifier sql.NullString
+	amount                                                                                           sql.NullFloat64
+	paidAt                                                                                           sql.NullTime
+}
+
+func (r *paymentRecordRow) targets() []any {
+	return []any{
+		&r.id,
+		&r.transactionType,
+		&r.amount,
+		&r.currency,
+		&r.paidAt,
+		&r.paymentMethod,
+		&r.notes,
+		&r.target,
+		&r.membershipPeriodId,
+		&r.rentedFacilityId,
+		&r.memberId,
+		&r.firstName,
+		&r.lastName,
+		&r.seasonId,
+		&r.facilityIdentifier,
+	}
+}
+
+func (r *paymentRecordRow) toDomain() payment.PaymentRecord {
+	record := payment.PaymentRecord{
+		Transaction: payment.Transaction{
+			Id:            domain.NewId[payment.Transaction](r.id.Int64),
+			Type:          payment.TransactionType(r.transactionType.String),
+			Amount:        amountOf(r.amount.Float64, r.currency.String),
+			Date:          r.paidAt.Time,
+			PaymentMethod: r.paymentMethod.String,
+			Notes:         r.notes.String,
+		},
+		Target:             payment.PaymentTarget(r.target.String),
+		MemberId:           r.memberId.Int64,
+		FirstName:          r.firstName.String,
+		LastName:           r.lastName.String,
+		SeasonId:           r.seasonId.Int64,
+		FacilityIdentifier: r.facilityIdentifier.String,
+	}
+	if r.membershipPeriodId.Valid {
+		record.MembershipPeriodId = &r.membershipPeriodId.Int64
+	}
+	if r.rentedFacilityId.Valid {
+		record.RentedFacilityId = &r.rentedFacilityId.Int64
+	}
+	return record
+}
+
+func scanLedger(row rowScanner, notFound string) result.Result[payment.Ledger] {
+	var ledger payment.Ledger
+	var due float64
+	var currency sql.NullString
+	var transactions []byte
+	err := row.Scan(&due, &currency, &transactions)
+	if err == sql.ErrNoRows {
+		return result.Err[payment.Ledger](errors.NotFoundError{Description: notFound})
+	}
+	if err != nil {
+		return result.Err[payment.Ledger](errors.RepositoryError{Description: "failed to get ledger: " + err.Error()})
+	}
+	ledger.Due = amountOf(due, currency.String)
+	parsed, err := parseTransactions(transactions)
+	if err != nil {
+		return result.Err[payment.Ledger](err)
+	}
+	ledger.Transactions = parsed
+	return result.Ok(ledger)
+}
+
+func nullableNotes(notes string) sql.NullString {
+	return sql.NullString{String: notes, Valid: notes != ""}
+}
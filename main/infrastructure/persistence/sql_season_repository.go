@@ -3,12 +3,36 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	_ "embed"
+	"time"
 
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/lib/pq"
 )
 
+//go:embed queries/get_all_seasons.sql
+var getAllSeasonsQuery string
+
+//go:embed queries/get_season_by_id.sql
+var getSeasonByIdQuery string
+
+//go:embed queries/get_season_by_date.sql
+var getSeasonByDateQuery string
+
+//go:embed queries/insert_season.sql
+var insertSeasonQuery string
+
+//go:embed queries/update_season.sql
+var updateSeasonQuery string
+
+//go:embed queries/close_season.sql
+var closeSeasonQuery string
+
+//go:embed queries/set_season_preemption_window.sql
+var setSeasonPreemptionWindowQuery string
+
 type SQLSeasonRepository struct {
 	db *sql.DB
 }
@@ -17,28 +41,154 @@ func NewSQLSeasonRepository(db *sql.DB) *SQLSeasonRepository {
 	return &SQLSeasonRepository{db: db}
 }
 
-func (r *SQLSeasonRepository) GetSeasonById(seasonId int64) result.Result[club.Season] {
-	query := `
-		SELECT id, code, name, starts_at, ends_at
-		FROM seasons
-		WHERE id = $1
-	`
+// seasonScanner abstracts *sql.Row and *sql.Rows so both can be scanned into a season
+type seasonScanner interface {
+	Scan(dest ...any) error
+}
 
+func scanSeason(row seasonScanner) (club.Season, error) {
 	var season club.Season
-	err := r.db.QueryRowContext(context.Background(), query, seasonId).Scan(
+	var closedAt sql.NullTime
+	var preemptionEndsAt sql.NullTime
+	err := row.Scan(
 		&season.ID,
 		&season.Code,
 		&season.Name,
 		&season.StartsAt,
 		&season.EndsAt,
+		&closedAt,
+		&preemptionEndsAt,
 	)
+	if closedAt.Valid {
+		season.ClosedAt = &closedAt.Time
+	}
+	if preemptionEndsAt.Valid {
+		season.PreemptionEndsAt = &preemptionEndsAt.Time
+	}
+	return season, err
+}
+
+func (r *SQLSeasonRepository) GetSeasonById(seasonId int64) result.Result[club.Season] {
+	season, err := scanSeason(r.db.QueryRowContext(context.Background(), getSeasonByIdQuery, seasonId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return result.Err[club.Season](errors.NotFoundError{Description: "season not found"})
+		}
+		return result.Err[club.Season](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(season)
+}
+
+func (r *SQLSeasonRepository) GetAllSeasons() result.Result[[]club.Season] {
+	rows, err := r.db.QueryContext(context.Background(), getAllSeasonsQuery)
+	if err != nil {
+		return result.Err[[]club.Season](errors.RepositoryError{Description: err.Error()})
+	}
+	defer rows.Close()
+
+	seasons := []club.Season{}
+	for rows.Next() {
+		season, err := scanSeason(rows)
+		if err != nil {
+			return result.Err[[]club.Season](errors.RepositoryError{Description: err.Error()})
+		}
+		seasons = append(seasons, season)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]club.Season](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(seasons)
+}
 
+func (r *SQLSeasonRepository) GetSeasonByDate(date time.Time) result.Result[club.Season] {
+	season, err := scanSeason(r.db.QueryRowContext(context.Background(), getSeasonByDateQuery, date))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return result.Err[club.Season](errors.RepositoryError{Description: "season not found"})
+			return result.Err[club.Season](errors.NotFoundError{Description: "no season found for date " + date.Format("2006-01-02")})
 		}
 		return result.Err[club.Season](errors.RepositoryError{Description: err.Error()})
 	}
 
 	return result.Ok(season)
 }
+
+func (r *SQLSeasonRepository) CreateSeason(season club.Season) result.Result[club.Season] {
+	created, err := scanSeason(r.db.QueryRowContext(
+		context.Background(),
+		insertSeasonQuery,
+		season.Code,
+		season.Name,
+		season.StartsAt,
+		season.EndsAt,
+	))
+	if isSeasonOverlap(err) {
+		return result.Err[club.Season](errors.SeasonError{Description: "season overlaps with another season"})
+	}
+	if err != nil {
+		return result.Err[club.Season](errors.RepositoryError{Description: "failed to insert season: " + err.Error()})
+	}
+
+	return result.Ok(created)
+}
+
+func (r *SQLSeasonRepository) UpdateSeason(season club.Season) result.Result[club.Season] {
+	updated, err := scanSeason(r.db.QueryRowContext(
+		context.Background(),
+		updateSeasonQuery,
+		season.Code,
+		season.Name,
+		season.StartsAt,
+		season.EndsAt,
+		season.ID,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return result.Err[club.Season](errors.NotFoundError{Description: "season not found"})
+		}
+		if isSeasonOverlap(err) {
+			return result.Err[club.Season](errors.SeasonError{Description: "season overlaps with another season"})
+		}
+		return result.Err[club.Season](errors.RepositoryError{Description: "failed to update season: " + err.Error()})
+	}
+
+	return result.Ok(updated)
+}
+
+func (r *SQLSeasonRepository) CloseSeason(seasonId int64, closedAt time.Time) result.Result[club.Season] {
+	closed, err := scanSeason(r.db.QueryRowContext(context.Background(), closeSeasonQuery, seasonId, closedAt))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return result.Err[club.Season](errors.NotFoundError{Description: "season not found or already closed"})
+		}
+		return result.Err[club.Season](errors.RepositoryError{Description: "failed to close season: " + err.Error()})
+	}
+
+	return result.Ok(closed)
+}
+
+func (r *SQLSeasonRepository) SetPreemptionWindow(seasonId int64, preemptionEndsAt *time.Time) result.Result[club.Season] {
+	endsAt := sql.NullTime{Valid: false}
+	if preemptionEndsAt != nil {
+		endsAt = sql.NullTime{Time: *preemptionEndsAt, Valid: true}
+	}
+
+	updated, err := scanSeason(r.db.QueryRowContext(context.Background(), setSeasonPreemptionWindowQuery, seasonId, endsAt))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return result.Err[club.Season](errors.NotFoundError{Description: "season not found"})
+		}
+		return result.Err[club.Season](errors.RepositoryError{Description: "failed to set preemption window: " + err.Error()})
+	}
+
+	return result.Ok(updated)
+}
+
+// isSeasonOverlap tells whether the error is the violation of the constraint keeping seasons apart,
+// raised when a concurrent create or update got past the check of the service
+func isSeasonOverlap(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == "23P01" && pqErr.Constraint == "seasons_no_overlap"
+}
@@ -0,0 +1,236 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
+	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/lib/pq"
+)
+
+//go:embed queries/get_renewable_membership_periods.sql
+var getRenewableMembershipPeriodsQuery string
+
+//go:embed queries/get_renewable_rented_facilities.sql
+var getRenewableRentedFacilitiesQuery string
+
+type SQLSeasonRolloverRepository struct {
+	db *sql.DB
+}
+
+func NewSQLSeasonRolloverRepository(db *sql.DB) *SQLSeasonRolloverRepository {
+	return &SQLSeasonRolloverRepository{db: db}
+}
+
+// renewableStatuses returns the membership statuses renewed by a rollover as query parameters
+func renewableStatuses() []string {
+	statuses := make([]string, len(club.RenewableMembershipStatuses))
+	for i, status := range club.RenewableMembershipStatuses {
+		statuses[i] = string(status)
+	}
+	return statuses
+}
+
+func (r *SQLSeasonRolloverRepository) GetRenewableMemberships(sourceSeasonId int64, targetSeasonId int64) result.Result[[]club.RenewableMembership] {
+	rows, err := r.db.QueryContext(context.Background(), getRenewableMembershipPeriodsQuery, sourceSeasonId, targetSeasonId, pq.Array(renewableStatuses()))
+	if err != nil {
+		return result.Err[[]club.RenewableMembership](errors.RepositoryError{Description: "failed to query renewable memberships: " + err.Error()})
+	}
+	defer rows.Close()
+
+	memberships := []club.RenewableMembership{}
+	for rows.Next() {
+		var memberId int64
+		var householdId sql.NullInt64
+		var category []byte
+		var sourcePrice float64
+		var sourceCurrency string
+		var targetPrice sql.NullFloat64
+		var targetCurrency sql.NullString
+		var renewable club.RenewableMembership
+		err := rows.Scan(
+			&memberId,
+			&renewable.FirstName,
+			&renewable.LastName,
+			&renewable.BirthDate,
+			&householdId,
+			&renewable.MembershipId,
+			&renewable.MembershipNumber,
+			&sourcePrice,
+			&sourceCurrency,
+			&renewable.AlreadyInTarget,
+			&category,
+			&targetPrice,
+			&targetCurrency,
+		)
+		if err != nil {
+			return result.Err[[]club.RenewableMembership](errors.RepositoryError{Description: "failed to scan renewable membership: " + err.Error()})
+		}
+		renewable.MemberId = domain.NewId[membership.Member](memberId)
+		renewable.SourcePrice = amountOf(sourcePrice, sourceCurrency)
+		if householdId.Valid {
+			id := domain.NewId[membership.Household](householdId.Int64)
+			renewable.HouseholdId = &id
+		}
+		if renewable.Category, err = parseMembershipCategory(category); err != nil {
+			return result.Err[[]club.RenewableMembership](err)
+		}
+		if targetPrice.Valid {
+			price := amountOf(targetPrice.Float64, targetCurrency.String)
+			renewable.TargetPrice = &price
+		}
+		memberships = append(memberships, renewable)
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]club.RenewableMembership](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(memberships)
+}
+
+func (r *SQLSeasonRolloverRepository) GetRenewableRentals(sourceSeasonId int64, targetSeasonId int64) result.Result[[]club.RenewableRental] {
+	rows, err := r.db.QueryContext(context.Background(), getRenewableRentedFacilitiesQuery, sourceSeasonId, targetSeasonId, pq.Array(renewableStatuses()))
+	if err != nil {
+		return result.Err[[]club.RenewableRental](errors.RepositoryError{Description: "failed to query renewable rentals: " + err.Error()})
+	}
+	defer rows.Close()
+
+	rentals := []club.RenewableRental{}
+	for rows.Next() {
+		var dto GetRenewableRentedFacilitiesQueryResult
+		err := rows.Scan(
+			&dto.MemberID,
+			&dto.FirstName,
+			&dto.LastName,
+			&dto.FacilityRentedInTarget,
+			&dto.Rental.RentedFacilityID,
+			&dto.Rental.RentedAt,
+			&dto.Rental.ExpiresAt,
+			&dto.Rental.Price,
+			&dto.Rental.Currency,
+			&dto.Rental.DiscountApplied,
+			&dto.Rental.FacilityID,
+			&dto.Rental.FacilityIdentifier,
+			&dto.Rental.FacilityTypeID,
+			&dto.Rental.FacilityType,
+			&dto.Rental.FacilityTypeDesc,
+			&dto.Rental.SuggestedPrice,
+			&dto.Rental.BoatID,
+			&dto.Rental.BoatName,
+			&dto.Rental.BoatLengthMeters,
+			&dto.Rental.BoatWidthMeters,
+			&dto.Rental.BoatEngineInfo,
+			&dto.Rental.BoatType,
+			&dto.Rental.InsuranceID,
+			&dto.Rental.InsuranceProvider,
+			&dto.Rental.InsuranceNumber,
+			&dto.Rental.InsuranceExpiresAt,
+			&dto.Rental.LeerboardID,
+			&dto.Rental.LeerboardColor,
+			&dto.Rental.LeerboardType,
+			&dto.Rental.LeerboardLength,
+			&dto.Rental.Transactions,
+		)
+		if err != nil {
+			return result.Err[[]club.RenewableRental](errors.RepositoryError{Description: "failed to scan renewable rental: " + err.Error()})
+		}
+		rental := ConvertDTOToRenewableRental(dto)
+		if !rental.IsSuccess() {
+			return result.Err[[]club.RenewableRental](rental.Error())
+		}
+		rentals = append(rentals, rental.Value())
+	}
+
+	if err = rows.Err(); err != nil {
+		return result.Err[[]club.RenewableRental](errors.RepositoryError{Description: err.Error()})
+	}
+
+	return result.Ok(rentals)
+}
+
+// ApplyRollover creates the membership periods and the rentals of the plan in the target season
+// Everything is created unpaid, and nothing is stored if any insert fails
+func (r *SQLSeasonRolloverRepository) ApplyRollover(plan club.RolloverPlan) result.Result[club.RolloverResult] {
+	ctx := context.Background()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.Err[club.RolloverResult](errors.RepositoryError{Description: "failed to begin transaction: " + err.Error()})
+	}
+	defer tx.Rollback()
+
+	targetSeasonId := plan.TargetSeason.ID
+
+	policy, err := getNumberingPolicy(ctx, tx)
+	if err != nil {
+		return result.Err[club.RolloverResult](err)
+	}
+
+	for _, renewal := range plan.Memberships {
+		var periodId int64
+		err = tx.QueryRowContext(ctx, insertMembershipPeriodQuery,
+			renewal.MembershipId,
+			1, // status_id for ACTIVE
+			targetSeasonId,
+			renewal.Price,
+			renewalCategoryId(renewal.Category),
+			currencyOf(renewal.Price),
+		).Scan(&periodId)
+		if err != nil {
+			return result.Err[club.RolloverResult](errors.RepositoryError{Description: "failed to insert membership period: " + err.Error()})
+		}
+		if err = assignMembershipCard(ctx, tx, policy, periodId, targetSeasonId, renewal.MembershipNumber); err != nil {
+			return result.Err[club.RolloverResult](err)
+		}
+	}
+
+	for _, renewal := range plan.Rentals {
+		var rentedFacilityId int64
+		err = tx.QueryRowContext(ctx, insertRentedFacilityQuery,
+			renewal.SourceRental.GetFacility().Id.Value,
+			renewal.MemberId.Value,
+			targetSeasonId,
+			renewal.Price,
+			renewal.DiscountApplied,
+			currencyOf(renewal.Price),
+		).Scan(&rentedFacilityId)
+		if err != nil {
+			return result.Err[club.RolloverResult](errors.RepositoryError{Description: "failed to insert facility rental: " + err.Error()})
+		}
+
+		switch rental := renewal.SourceRental.(type) {
+		case facilityrental.RentedFacilityWithBoat:
+			if err = insertBoatInfo(ctx, tx, rentedFacilityId, rental.BoatInfo); err != nil {
+				return result.Err[club.RolloverResult](err)
+			}
+		case facilityrental.RentedFacilityWithLeerboard:
+			if err = insertLeerboardInfo(ctx, tx, rentedFacilityId, rental.LeerboardInfo); err != nil {
+				return result.Err[club.RolloverResult](err)
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result.Err[club.RolloverResult](errors.RepositoryError{Description: "failed to commit transaction: " + err.Error()})
+	}
+
+	return result.Ok(club.RolloverResult{
+		Plan:                     plan,
+		CreatedMembershipPeriods: len(plan.Memberships),
+		CreatedRentals:           len(plan.Rentals),
+	})
+}
+
+func renewalCategoryId(category *membership.MembershipCategory) sql.NullInt64 {
+	if category == nil {
+		return sql.NullInt64{}
+	}
+	return categoryId(*category)
+}
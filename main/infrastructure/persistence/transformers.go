@@ -1,18 +1,42 @@
 package persistence
 
 import (
+	"database/sql"
 	"encoding/json"
 	"strings"
 	"time"
 
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
 	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
 )
 
+// priceOf reads a NUMERIC price of a table without a currency column, in the default currency
+func priceOf(amount float64) money.Money {
+	return money.FromFloat(amount, money.DefaultCurrency)
+}
+
+// amountOf reads a NUMERIC amount stored with its currency column, the default currency when it has none
+func amountOf(amount float64, currency string) money.Money {
+	if strings.TrimSpace(currency) == "" {
+		return priceOf(amount)
+	}
+	return money.FromFloat(amount, money.Currency(strings.ToUpper(strings.TrimSpace(currency))))
+}
+
+// currencyOf is the value of the currency column of an amount, the default currency when it has none
+func currencyOf(amount money.Money) string {
+	if amount.Currency() == "" {
+		return string(money.DefaultCurrency)
+	}
+	return string(amount.Currency())
+}
+
 // PgTimestamp handles PostgreSQL timestamp parsing
 type PgTimestamp struct {
 	time.Time
@@ -59,24 +83,80 @@ func MapToMemberFromMemberByIdQuery(queryResult GetMemberByIdQueryResult) result
 		return result.Err[membership.MemberDetails](err)
 	}
 
+	var guardianRows []struct {
+		GuardianMemberID *int64  `json:"guardian_member_id"`
+		FirstName        string  `json:"first_name"`
+		LastName         string  `json:"last_name"`
+		Email            *string `json:"email"`
+		PhoneNumber      *string `json:"phone_number"`
+		Relationship     string  `json:"relationship"`
+	}
+	if len(queryResult.Guardians) > 0 {
+		if err := json.Unmarshal(queryResult.Guardians, &guardianRows); err != nil {
+			return result.Err[membership.MemberDetails](err)
+		}
+	}
+	guardians := make([]membership.Guardian, 0, len(guardianRows))
+	for _, g := range guardianRows {
+		guardian := membership.Guardian{
+			FirstName:    g.FirstName,
+			LastName:     g.LastName,
+			Relationship: g.Relationship,
+		}
+		if g.GuardianMemberID != nil {
+			guardian.MemberId = &domain.Id[membership.User]{Value: *g.GuardianMemberID}
+		}
+		if g.Email != nil && *g.Email != "" {
+			guardian.Email = &membership.EmailAddress{Value: *g.Email}
+		}
+		if g.PhoneNumber != nil && *g.PhoneNumber != "" {
+			guardian.PhoneNumber = &membership.PhoneNumber{Number: *g.PhoneNumber}
+		}
+		guardians = append(guardians, guardian)
+	}
+
+	var consentRows []struct {
+		Type          string      `json:"type"`
+		Granted       bool        `json:"granted"`
+		PolicyVersion *string     `json:"policy_version"`
+		RecordedAt    PgTimestamp `json:"recorded_at"`
+	}
+	if len(queryResult.Consents) > 0 {
+		if err := json.Unmarshal(queryResult.Consents, &consentRows); err != nil {
+			return result.Err[membership.MemberDetails](err)
+		}
+	}
+	consents := make([]membership.Consent, 0, len(consentRows))
+	for _, c := range consentRows {
+		consent := membership.Consent{
+			Type:       membership.ConsentType(c.Type),
+			Granted:    c.Granted,
+			RecordedAt: c.RecordedAt.Time,
+		}
+		if c.PolicyVersion != nil {
+			consent.PolicyVersion = *c.PolicyVersion
+		}
+		consents = append(consents, consent)
+	}
+
+	var householdId *domain.Id[membership.Household]
+	if queryResult.HouseholdID.Valid {
+		householdId = &domain.Id[membership.Household]{Value: queryResult.HouseholdID.Int64}
+	}
+
 	var memberships []struct {
-		MembershipID           int64        `json:"membership_id"`
-		MembershipNumber       int64        `json:"membership_number"`
-		ValidFrom              PgTimestamp  `json:"valid_from"`
-		ExpiresAt              PgTimestamp  `json:"expires_at"`
-		Status                 string       `json:"status"`
-		ExclusionDeliberatedAt *PgTimestamp `json:"exclusion_deliberated_at"`
-		ExcludedAt             *PgTimestamp `json:"excluded_at"`
-		PeriodID               int64        `json:"membership_period_id"`
-		Price                  float64      `json:"price"`
-		Payment                *struct {
-			ID            int64       `json:"id"`
-			Amount        float64     `json:"amount"`
-			Currency      string      `json:"currency"`
-			PaidAt        PgTimestamp `json:"paid_at"`
-			PaymentMethod string      `json:"payment_method"`
-			Notes         string      `json:"payment_notes"`
-		} `json:"payment"`
+		MembershipID           int64                     `json:"membership_id"`
+		MembershipNumber       int64                     `json:"membership_number"`
+		ValidFrom              PgTimestamp               `json:"valid_from"`
+		ExpiresAt              PgTimestamp               `json:"expires_at"`
+		Status                 string                    `json:"status"`
+		ExclusionDeliberatedAt *PgTimestamp              `json:"exclusion_deliberated_at"`
+		ExclusionReason        *string                   `json:"exclusion_reason"`
+		PeriodID               int64                     `json:"membership_period_id"`
+		Price                  float64                   `json:"price"`
+		Currency               string                    `json:"currency"`
+		Category               *MembershipCategoryDetail `json:"category"`
+		Transactions           []TransactionDetail       `json:"transactions"`
 	}
 	if err := json.Unmarshal(queryResult.Memberships, &memberships); err != nil {
 		return result.Err[membership.MemberDetails](err)
@@ -85,29 +165,23 @@ func MapToMemberFromMemberByIdQuery(queryResult GetMemberByIdQueryResult) result
 	// Map memberships and rented services to domain structs
 	var domainMemberships []membership.Membership
 	for _, m := range memberships {
-		var paymentInfo payment.Payment
-		if m.Payment != nil {
-			paymentInfo = payment.PaymentPaid{
-				ID:             m.Payment.ID,
-				AmountPaid:     m.Payment.Amount,
-				PaymentDate:    m.Payment.PaidAt.Time,
-				Currency:       m.Payment.Currency,
-				PaymentMethod:  m.Payment.PaymentMethod,
-				TransactionRef: m.Payment.Notes,
-			}
-		} else {
-			paymentInfo = payment.PaymentUnpaid{}
-		}
-
 		var membershipStatus membership.MembershipInfo
+		// The season end date is inclusive, the membership lasts until the end of that day
+		seasonEnded := !m.ExpiresAt.AddDate(0, 0, 1).After(time.Now())
 		switch {
-		case m.Status == "ACTIVE" && m.ExpiresAt.After(time.Now()):
+		case m.Status == "ACTIVE" && !seasonEnded:
 			membershipStatus = membership.Active{
 				PeriodId:       m.PeriodID,
 				ValidFromDate:  m.ValidFrom.Time,
 				ValidUntilDate: m.ExpiresAt.Time,
 			}
-		case m.Status == "ACTIVE" && m.ExpiresAt.Before(time.Now()):
+		case m.Status == "ACTIVE" && seasonEnded:
+			membershipStatus = membership.Expired{
+				PeriodId:       m.PeriodID,
+				ValidFromDate:  m.ValidFrom.Time,
+				ValidUntilDate: m.ExpiresAt.Time,
+			}
+		case m.Status == "EXPIRED":
 			membershipStatus = membership.Expired{
 				PeriodId:       m.PeriodID,
 				ValidFromDate:  m.ValidFrom.Time,
@@ -115,14 +189,19 @@ func MapToMemberFromMemberByIdQuery(queryResult GetMemberByIdQueryResult) result
 			}
 		case m.Status == "EXCLUDED":
 			excludedAt := time.Time{}
-			if m.ExcludedAt != nil {
-				excludedAt = m.ExcludedAt.Time
+			if m.ExclusionDeliberatedAt != nil {
+				excludedAt = m.ExclusionDeliberatedAt.Time
+			}
+			reason := ""
+			if m.ExclusionReason != nil {
+				reason = *m.ExclusionReason
 			}
 			membershipStatus = membership.Excluded{
 				PeriodId:       m.PeriodID,
 				ValidFromDate:  m.ValidFrom.Time,
 				ValidUntilDate: m.ExpiresAt.Time,
 				ExcludedAt:     excludedAt,
+				Reason:         reason,
 			}
 		case m.Status == "SUSPENDED":
 			membershipStatus = membership.Suspended{
@@ -140,18 +219,16 @@ func MapToMemberFromMemberByIdQuery(queryResult GetMemberByIdQueryResult) result
 		}
 
 		domainMemberships = append(domainMemberships, membership.Membership{
-			Id:      domain.Id[membership.Membership]{Value: m.MembershipID},
-			Number:  m.MembershipNumber,
-			Status:  membershipStatus,
-			Price:   m.Price,
-			Payment: paymentInfo,
+			Id:           domain.Id[membership.Membership]{Value: m.MembershipID},
+			Number:       m.MembershipNumber,
+			Status:       membershipStatus,
+			Category:     mapToMembershipCategory(m.Category),
+			Price:        amountOf(m.Price, m.Currency),
+			Transactions: mapToTransactions(m.Transactions),
 		})
 	}
 
-	taxCode := ""
-	if queryResult.TaxCode.Valid {
-		taxCode = queryResult.TaxCode.String
-	}
+	taxCode := mapToTaxCode(queryResult.TaxCode)
 
 	var email *membership.EmailAddress = nil
 	if queryResult.Email.Valid && queryResult.Email.String != "" {
@@ -181,9 +258,13 @@ func MapToMemberFromMemberByIdQuery(queryResult GetMemberByIdQueryResult) result
 			BirthDate:    queryResult.DateOfBirth,
 			Email:        email,
 			TaxCode:      taxCode,
+			Billing:      queryResult.Billing.toDomain(),
 			BirthPlace:   birthPlace,
 			Addresses:    addresses,
 			PhoneNumbers: phoneNumbers,
+			Guardians:    guardians,
+			HouseholdId:  householdId,
+			Consents:     membership.CurrentConsents(consents),
 		},
 		Memberships: domainMemberships,
 	})
@@ -200,6 +281,11 @@ func MapToMemberFromAllMembersQuery(queryResult GetAllMembersQueryResult) result
 	switch {
 	case queryResult.Season == nil:
 		membershipStatus = membership.None{}
+	case *queryResult.MembershipStatus == "EXPIRED":
+		membershipStatus = membership.Expired{
+			ValidFromDate:  *queryResult.SeasonStartsAt,
+			ValidUntilDate: *queryResult.SeasonEndsAt,
+		}
 	case *queryResult.Season == "PAST" && *queryResult.MembershipStatus == "ACTIVE":
 		membershipStatus = membership.Expired{
 			ValidFromDate:  *queryResult.SeasonStartsAt,
@@ -228,32 +314,38 @@ func MapToMemberFromAllMembersQuery(queryResult GetAllMembersQueryResult) result
 		}
 	}
 
-	var paymentInfo payment.Payment
-	switch {
-	case queryResult.AmountPaid != nil && queryResult.PaidAt != nil:
-		paymentInfo = payment.PaymentPaid{
-			ID:             0, // ID not available in this query
-			AmountPaid:     *queryResult.AmountPaid,
-			PaymentDate:    *queryResult.PaidAt,
-			Currency:       *queryResult.Currency,
-			PaymentMethod:  "", // Not available in this query
-			TransactionRef: "", // Not available in this query
-		}
-	default:
-		paymentInfo = payment.PaymentUnpaid{}
+	transactions, err := parseTransactions(queryResult.Transactions)
+	if err != nil {
+		return result.Err[membership.Member](err)
 	}
 
-	var price float64
+	var price money.Money
 	if queryResult.Price != nil {
-		price = *queryResult.Price
+		currency := ""
+		if queryResult.Currency != nil {
+			currency = *queryResult.Currency
+		}
+		price = amountOf(*queryResult.Price, currency)
+	}
+
+	category, err := parseMembershipCategory(queryResult.Category)
+	if err != nil {
+		return result.Err[membership.Member](err)
+	}
+
+	// Members without a membership have no number
+	var membershipNumber int64
+	if queryResult.MembershipNumber != nil {
+		membershipNumber = *queryResult.MembershipNumber
 	}
 
 	domainMembership := membership.Membership{
-		Id:      domain.Id[membership.Membership]{Value: queryResult.MemberID},
-		Number:  *queryResult.MembershipNumber,
-		Status:  membershipStatus,
-		Price:   price,
-		Payment: paymentInfo,
+		Id:           domain.Id[membership.Membership]{Value: queryResult.MemberID},
+		Number:       membershipNumber,
+		Status:       membershipStatus,
+		Category:     category,
+		Price:        price,
+		Transactions: transactions,
 	}
 
 	return result.Ok(membership.Member{
@@ -263,7 +355,7 @@ func MapToMemberFromAllMembersQuery(queryResult GetAllMembersQueryResult) result
 			LastName:     queryResult.LastName,
 			BirthDate:    queryResult.DateOfBirth,
 			Email:        nil,
-			TaxCode:      "",
+			TaxCode:      nil,
 			Addresses:    []membership.Address{},
 			PhoneNumbers: []membership.PhoneNumber{},
 		},
@@ -271,6 +363,98 @@ func MapToMemberFromAllMembersQuery(queryResult GetAllMembersQueryResult) result
 	})
 }
 
+// mapToTaxCode returns the stored tax code, nil when missing
+// Codes no longer passing the checks are kept as they are, only the codes coming from the API are validated
+func mapToTaxCode(stored sql.NullString) *membership.TaxCode {
+	if !stored.Valid || strings.TrimSpace(stored.String) == "" {
+		return nil
+	}
+	taxCode := membership.StoredTaxCode(stored.String)
+	return &taxCode
+}
+
+// ConvertBillingDetailsToColumns returns the billing details as stored, all NULL when the member has none
+func ConvertBillingDetailsToColumns(billing *membership.BillingDetails) BillingColumns {
+	if billing == nil {
+		return BillingColumns{}
+	}
+	columns := BillingColumns{
+		CompanyName:   sql.NullString{String: billing.CompanyName, Valid: true},
+		VATNumber:     sql.NullString{String: billing.VATNumber, Valid: true},
+		RecipientCode: sql.NullString{String: billing.RecipientCode, Valid: true},
+	}
+	if billing.PEC != nil {
+		columns.PEC = sql.NullString{String: billing.PEC.Value, Valid: true}
+	}
+	return columns
+}
+
+// toDomain returns the stored billing details, nil when the member is not billed as a business
+func (c BillingColumns) toDomain() *membership.BillingDetails {
+	if !c.VATNumber.Valid {
+		return nil
+	}
+	billing := &membership.BillingDetails{
+		CompanyName:   c.CompanyName.String,
+		VATNumber:     c.VATNumber.String,
+		RecipientCode: c.RecipientCode.String,
+	}
+	if c.PEC.Valid {
+		billing.PEC = &membership.EmailAddress{Value: c.PEC.String}
+	}
+	return billing
+}
+
+func MapToUserFromDuplicateCheckQuery(queryResult GetUsersForDuplicateCheckQueryResult) membership.User {
+	var email *membership.EmailAddress = nil
+	if queryResult.Email.Valid && queryResult.Email.String != "" {
+		emailResult := membership.NewEmailAddress(queryResult.Email.String)
+		if emailResult.IsSuccess() {
+			emailVal := emailResult.Value()
+			email = &emailVal
+		}
+	}
+
+	phoneNumbers := make([]membership.PhoneNumber, len(queryResult.PhoneNumbers))
+	for i, number := range queryResult.PhoneNumbers {
+		phoneNumbers[i] = membership.PhoneNumber{Number: number}
+	}
+
+	return membership.User{
+		Id:           domain.Id[membership.User]{Value: queryResult.MemberID},
+		FirstName:    queryResult.FirstName,
+		LastName:     queryResult.LastName,
+		BirthDate:    queryResult.DateOfBirth,
+		Email:        email,
+		TaxCode:      mapToTaxCode(queryResult.TaxCode),
+		Addresses:    []membership.Address{},
+		PhoneNumbers: phoneNumbers,
+	}
+}
+
+// MapToMemberFromSearchQuery maps a non-empty row of the search query, which has the same columns as the query by season
+func MapToMemberFromSearchQuery(queryResult SearchMembersQueryResult) result.Result[membership.Member] {
+	return MapToMemberFromQueryBySeason(GetMembersBySeasonQueryResult{
+		MemberID:               queryResult.MemberID.Int64,
+		FirstName:              queryResult.FirstName.String,
+		LastName:               queryResult.LastName.String,
+		Email:                  queryResult.Email,
+		DateOfBirth:            queryResult.DateOfBirth.Time,
+		TaxCode:                queryResult.TaxCode,
+		MembershipNumber:       queryResult.MembershipNumber,
+		MembershipStatus:       queryResult.MembershipStatus.String,
+		SeasonStartsAt:         queryResult.SeasonStartsAt.Time,
+		SeasonEndsAt:           queryResult.SeasonEndsAt.Time,
+		ExclusionDeliberatedAt: queryResult.ExclusionDeliberatedAt,
+		Price:                  queryResult.Price,
+		Transactions:           queryResult.Transactions,
+		HasRentedFacilities:    queryResult.HasRentedFacilities.Bool,
+		HasUnpaidFacilities:    queryResult.HasUnpaidFacilities.Bool,
+		HasFlaggedRentals:      queryResult.HasFlaggedRentals.Bool,
+		Category:               queryResult.Category,
+	})
+}
+
 func MapToMemberFromQueryBySeason(queryResult GetMembersBySeasonQueryResult) result.Result[membership.Member] {
 	var membershipStatus membership.MembershipInfo
 	switch {
@@ -290,35 +474,47 @@ func MapToMemberFromQueryBySeason(queryResult GetMembersBySeasonQueryResult) res
 			ValidFromDate:  queryResult.SeasonStartsAt,
 			ValidUntilDate: queryResult.SeasonEndsAt,
 		}
-	}
-
-	var paymentInfo payment.Payment
-	switch {
-	case queryResult.AmountPaid != nil && queryResult.PaidAt != nil:
-		paymentInfo = payment.PaymentPaid{
-			ID:             0, // ID not available in this query
-			AmountPaid:     *queryResult.AmountPaid,
-			PaymentDate:    *queryResult.PaidAt,
-			Currency:       *queryResult.Currency,
-			PaymentMethod:  "", // Not available in this query
-			TransactionRef: "", // Not available in this query
+	case queryResult.MembershipStatus == "EXPIRED":
+		membershipStatus = membership.Expired{
+			ValidFromDate:  queryResult.SeasonStartsAt,
+			ValidUntilDate: queryResult.SeasonEndsAt,
 		}
 	default:
-		paymentInfo = payment.PaymentUnpaid{}
+		membershipStatus = membership.None{}
 	}
 
-	domainMembership := membership.Membership{
-		Id:      domain.Id[membership.Membership]{Value: queryResult.MemberID},
-		Number:  *queryResult.MembershipNumber,
-		Status:  membershipStatus,
-		Payment: paymentInfo,
+	transactions, err := parseTransactions(queryResult.Transactions)
+	if err != nil {
+		return result.Err[membership.Member](err)
 	}
 
-	taxCode := ""
-	if queryResult.TaxCode.Valid {
-		taxCode = queryResult.TaxCode.String
+	category, err := parseMembershipCategory(queryResult.Category)
+	if err != nil {
+		return result.Err[membership.Member](err)
 	}
 
+	var price money.Money
+	if queryResult.Price != nil {
+		price = amountOf(*queryResult.Price, queryResult.Currency.String)
+	}
+
+	// Members without a membership have no number
+	var membershipNumber int64
+	if queryResult.MembershipNumber != nil {
+		membershipNumber = *queryResult.MembershipNumber
+	}
+
+	domainMembership := membership.Membership{
+		Id:           domain.Id[membership.Membership]{Value: queryResult.MemberID},
+		Number:       membershipNumber,
+		Status:       membershipStatus,
+		Category:     category,
+		Price:        price,
+		Transactions: transactions,
+	}
+
+	taxCode := mapToTaxCode(queryResult.TaxCode)
+
 	var email *membership.EmailAddress = nil
 	if queryResult.Email.Valid && queryResult.Email.String != "" {
 		emailResult := membership.NewEmailAddress(queryResult.Email.String)
@@ -352,15 +548,16 @@ func MapToMemberFromQueryBySeason(queryResult GetMembersBySeasonQueryResult) res
 		// 3) add the corresponding field to the domain Member struct (membership.Member)
 		// For now we include the field here so later changes are minimal.
 		HasRentedFacilities: hasRentedFacilities,
+		HasFlaggedRentals:   queryResult.HasFlaggedRentals,
 	})
 }
 
-func ConvertDTOToRentedFacility(dto GetRentedFacilitiesByMemberQueryResult) facilityrental.RentedFacility {
+func ConvertDTOToRentedFacility(dto GetRentedFacilitiesByMemberQueryResult) result.Result[facilityrental.RentedFacility] {
 	facilityType := facilityrental.FacilityType{
 		Id:             domain.NewId[facilityrental.FacilityType](dto.FacilityTypeID),
 		FacilityName:   facilityrental.FacilityName(dto.FacilityType),
 		Description:    dto.FacilityTypeDesc,
-		SuggestedPrice: dto.SuggestedPrice,
+		SuggestedPrice: priceOf(dto.SuggestedPrice),
 	}
 
 	facility := facilityrental.Facility{
@@ -374,31 +571,17 @@ func ConvertDTOToRentedFacility(dto GetRentedFacilitiesByMemberQueryResult) faci
 		FromDate: dto.RentedAt,
 	}
 
-	// Map payment information
-	var paymentInfo payment.Payment
-	if dto.PaymentID != nil && dto.PaymentAmount != nil && dto.PaymentPaidAt != nil {
-		method := ""
-		if dto.PaymentMethod != nil {
-			method = *dto.PaymentMethod
-		}
-		transactionRef := ""
-		if dto.PaymentNotes != nil {
-			transactionRef = *dto.PaymentNotes
-		}
-		currency := "EUR"
-		if dto.PaymentCurrency != nil {
-			currency = *dto.PaymentCurrency
-		}
-		paymentInfo = payment.PaymentPaid{
-			ID:             *dto.PaymentID,
-			AmountPaid:     *dto.PaymentAmount,
-			PaymentDate:    *dto.PaymentPaidAt,
-			Currency:       currency,
-			PaymentMethod:  method,
-			TransactionRef: transactionRef,
+	transactions, err := parseTransactions(dto.Transactions)
+	if err != nil {
+		return result.Err[facilityrental.RentedFacility](err)
+	}
+
+	var flag *facilityrental.RentalFlag
+	if dto.FlaggedAt != nil {
+		flag = &facilityrental.RentalFlag{FlaggedAt: *dto.FlaggedAt}
+		if dto.FlagReason != nil {
+			flag.Reason = *dto.FlagReason
 		}
-	} else {
-		paymentInfo = payment.PaymentUnpaid{}
 	}
 
 	// Check if this is a boat facility (has boat info)
@@ -435,16 +618,17 @@ func ConvertDTOToRentedFacility(dto GetRentedFacilitiesByMemberQueryResult) faci
 			InsuranceInfo: insuranceInfo,
 		}
 
-		return facilityrental.RentedFacilityWithBoat{
+		return result.Ok[facilityrental.RentedFacility](facilityrental.RentedFacilityWithBoat{
 			Id:              domain.NewId[facilityrental.RentedFacility](dto.RentedFacilityID),
 			MemberId:        domain.NewId[membership.Member](0), // Will be filled from query param
 			Facility:        facility,
 			Validity:        validity,
-			Price:           dto.Price,
-			Payment:         paymentInfo,
+			Price:           amountOf(dto.Price, dto.Currency),
+			Transactions:    transactions,
 			BoatInfo:        boatInfo,
 			DiscountApplied: dto.DiscountApplied,
-		}
+			Flag:            flag,
+		})
 	}
 
 	// Check if this is a leerboard facility (has leerboard info)
@@ -464,26 +648,271 @@ func ConvertDTOToRentedFacility(dto GetRentedFacilitiesByMemberQueryResult) faci
 			LengthMeters: *dto.LeerboardLength,
 		}
 
-		return facilityrental.RentedFacilityWithLeerboard{
+		return result.Ok[facilityrental.RentedFacility](facilityrental.RentedFacilityWithLeerboard{
 			Id:              domain.NewId[facilityrental.RentedFacility](dto.RentedFacilityID),
 			MemberId:        domain.NewId[membership.Member](0), // Will be filled from query param
 			Facility:        facility,
 			Validity:        validity,
-			Price:           dto.Price,
-			Payment:         paymentInfo,
+			Price:           amountOf(dto.Price, dto.Currency),
+			Transactions:    transactions,
 			LeerboardInfo:   leerboardInfo,
 			DiscountApplied: dto.DiscountApplied,
-		}
+			Flag:            flag,
+		})
 	}
 
 	// Simple facility without boat or leerboard
-	return facilityrental.SimpleRentedFacility{
+	return result.Ok[facilityrental.RentedFacility](facilityrental.SimpleRentedFacility{
 		Id:              domain.NewId[facilityrental.RentedFacility](dto.RentedFacilityID),
 		MemberId:        domain.NewId[membership.Member](0), // Will be filled from query param
 		Facility:        facility,
 		Validity:        validity,
-		Price:           dto.Price,
-		Payment:         paymentInfo,
+		Price:           amountOf(dto.Price, dto.Currency),
+		Transactions:    transactions,
 		DiscountApplied: dto.DiscountApplied,
+		Flag:            flag,
+	})
+}
+
+func ConvertDTOToRenewableRental(dto GetRenewableRentedFacilitiesQueryResult) result.Result[club.RenewableRental] {
+	return result.Map(ConvertDTOToRentedFacility(dto.Rental), func(rental facilityrental.RentedFacility) club.RenewableRental {
+		return club.RenewableRental{
+			MemberId:               domain.NewId[membership.Member](dto.MemberID),
+			FirstName:              dto.FirstName,
+			LastName:               dto.LastName,
+			Rental:                 rental,
+			FacilityRentedInTarget: dto.FacilityRentedInTarget,
+		}
+	})
+}
+
+func ConvertExpiryRunToDetails(run club.ExpiryRun) MembershipExpiryRunDetails {
+	details := MembershipExpiryRunDetails{
+		ExpiredMemberships: make([]ExpiredMembershipDetail, len(run.ExpiredMemberships)),
+		FlaggedRentals:     make([]FlaggedRentalDetail, len(run.FlaggedRentals)),
+	}
+	for i, expired := range run.ExpiredMemberships {
+		details.ExpiredMemberships[i] = ExpiredMembershipDetail{
+			PeriodID:         expired.PeriodId,
+			MemberID:         expired.MemberId.Value,
+			FirstName:        expired.FirstName,
+			LastName:         expired.LastName,
+			MembershipNumber: expired.MembershipNumber,
+			SeasonID:         expired.SeasonId,
+		}
+	}
+	for i, flagged := range run.FlaggedRentals {
+		details.FlaggedRentals[i] = FlaggedRentalDetail{
+			RentedFacilityID:   flagged.RentedFacilityId,
+			MemberID:           flagged.MemberId.Value,
+			FacilityIdentifier: flagged.FacilityIdentifier,
+			SeasonID:           flagged.SeasonId,
+			Reason:             flagged.Reason,
+		}
+	}
+	return details
+}
+
+func ConvertDetailsToExpiryRun(run club.ExpiryRun, details MembershipExpiryRunDetails) club.ExpiryRun {
+	run.ExpiredMemberships = make([]club.ExpiredMembership, len(details.ExpiredMemberships))
+	for i, expired := range details.ExpiredMemberships {
+		run.ExpiredMemberships[i] = club.ExpiredMembership{
+			PeriodId:         expired.PeriodID,
+			MemberId:         domain.NewId[membership.Member](expired.MemberID),
+			FirstName:        expired.FirstName,
+			LastName:         expired.LastName,
+			MembershipNumber: expired.MembershipNumber,
+			SeasonId:         expired.SeasonID,
+		}
+	}
+	run.FlaggedRentals = make([]club.FlaggedRental, len(details.FlaggedRentals))
+	for i, flagged := range details.FlaggedRentals {
+		run.FlaggedRentals[i] = club.FlaggedRental{
+			RentedFacilityId:   flagged.RentedFacilityID,
+			MemberId:           domain.NewId[membership.Member](flagged.MemberID),
+			FacilityIdentifier: flagged.FacilityIdentifier,
+			SeasonId:           flagged.SeasonID,
+			Reason:             flagged.Reason,
+		}
+	}
+	return run
+}
+
+func MapToHouseholdFromQuery(queryResult GetHouseholdQueryResult) result.Result[membership.Household] {
+	var members []HouseholdMemberDetail
+	if err := json.Unmarshal(queryResult.Members, &members); err != nil {
+		return result.Err[membership.Household](errors.RepositoryError{Description: "failed to parse household members: " + err.Error()})
+	}
+
+	householdId := domain.NewId[membership.Household](queryResult.HouseholdID)
+	household := membership.Household{
+		Id:      householdId,
+		Name:    queryResult.Name,
+		Members: make([]membership.User, 0, len(members)),
+	}
+	for _, member := range members {
+		birthDate, err := time.Parse("2006-01-02", member.DateOfBirth)
+		if err != nil {
+			return result.Err[membership.Household](errors.RepositoryError{Description: "failed to parse birth date: " + err.Error()})
+		}
+		user := membership.User{
+			Id:          domain.NewId[membership.User](member.MemberID),
+			FirstName:   member.FirstName,
+			LastName:    member.LastName,
+			BirthDate:   birthDate,
+			HouseholdId: &householdId,
+		}
+		if member.Email != nil && *member.Email != "" {
+			user.Email = &membership.EmailAddress{Value: *member.Email}
+		}
+		household.Members = append(household.Members, user)
+	}
+	return result.Ok(household)
+}
+
+// parseMembershipCategory parses the category column of the member lists, NULL when the member has no membership
+func parseMembershipCategory(category []byte) (*membership.MembershipCategory, error) {
+	if len(category) == 0 {
+		return nil, nil
+	}
+	var detail MembershipCategoryDetail
+	if err := json.Unmarshal(category, &detail); err != nil {
+		return nil, errors.RepositoryError{Description: "failed to parse membership category: " + err.Error()}
+	}
+	return mapToMembershipCategory(&detail), nil
+}
+
+func mapToMembershipCategory(detail *MembershipCategoryDetail) *membership.MembershipCategory {
+	if detail == nil {
+		return nil
+	}
+	return &membership.MembershipCategory{
+		Id:              domain.NewId[membership.MembershipCategory](detail.ID),
+		Code:            membership.MembershipCategoryCode(detail.Code),
+		Name:            detail.Name,
+		PaymentRequired: detail.PaymentRequired,
+	}
+}
+
+// parseTransactions parses the transactions column of a ledger, a JSON array oldest first
+func parseTransactions(transactions []byte) ([]payment.Transaction, error) {
+	if len(transactions) == 0 {
+		return []payment.Transaction{}, nil
+	}
+	var details []TransactionDetail
+	if err := json.Unmarshal(transactions, &details); err != nil {
+		return nil, errors.RepositoryError{Description: "failed to parse transactions: " + err.Error()}
+	}
+	return mapToTransactions(details), nil
+}
+
+func mapToTransactions(details []TransactionDetail) []payment.Transaction {
+	transactions := make([]payment.Transaction, len(details))
+	for i, detail := range details {
+		transactions[i] = payment.Transaction{
+			Id:            domain.NewId[payment.Transaction](detail.ID),
+			Type:          payment.TransactionType(detail.Type),
+			Amount:        amountOf(detail.Amount, detail.Currency),
+			Date:          detail.PaidAt.Time,
+			PaymentMethod: detail.PaymentMethod,
+		}
+		if detail.Notes != nil {
+			transactions[i].Notes = *detail.Notes
+		}
+	}
+	return transactions
+}
+
+func ConvertIssuerToDetail(issuer payment.Issuer) FiscalIssuerDetail {
+	return FiscalIssuerDetail{
+		Name:             issuer.Name,
+		TaxCode:          issuer.TaxCode,
+		VATNumber:        issuer.VATNumber,
+		Address:          FiscalAddressDetail(issuer.Address),
+		Email:            issuer.Email,
+		VATExemptionNote: issuer.VATExemptionNote,
+		RentalVATRate:    issuer.RentalVATRate,
+	}
+}
+
+func ConvertDetailToIssuer(detail FiscalIssuerDetail) payment.Issuer {
+	return payment.Issuer{
+		Name:             detail.Name,
+		TaxCode:          detail.TaxCode,
+		VATNumber:        detail.VATNumber,
+		Address:          payment.FiscalAddress(detail.Address),
+		Email:            detail.Email,
+		VATExemptionNote: detail.VATExemptionNote,
+		RentalVATRate:    detail.RentalVATRate,
+	}
+}
+
+func ConvertRecipientToDetail(recipient payment.Recipient) FiscalRecipientDetail {
+	return FiscalRecipientDetail{
+		MemberID:      recipient.MemberId,
+		FirstName:     recipient.FirstName,
+		LastName:      recipient.LastName,
+		TaxCode:       recipient.TaxCode,
+		CompanyName:   recipient.CompanyName,
+		VATNumber:     recipient.VATNumber,
+		RecipientCode: recipient.RecipientCode,
+		PEC:           recipient.PEC,
+		Address:       FiscalAddressDetail(recipient.Address),
+	}
+}
+
+func ConvertDetailToRecipient(detail FiscalRecipientDetail) payment.Recipient {
+	return payment.Recipient{
+		MemberId:      detail.MemberID,
+		FirstName:     detail.FirstName,
+		LastName:      detail.LastName,
+		TaxCode:       detail.TaxCode,
+		CompanyName:   detail.CompanyName,
+		VATNumber:     detail.VATNumber,
+		RecipientCode: detail.RecipientCode,
+		PEC:           detail.PEC,
+		Address:       payment.FiscalAddress(detail.Address),
+	}
+}
+
+func ConvertReminderRecipientToDetail(recipient club.ReminderRecipient) ReminderRecipientDetail {
+	detail := ReminderRecipientDetail{
+		FirstName: recipient.FirstName,
+		LastName:  recipient.LastName,
+		Email:     recipient.Email,
+	}
+	if recipient.Address != nil {
+		address := ReminderAddressDetail(*recipient.Address)
+		detail.Address = &address
+	}
+	return detail
+}
+
+func ConvertDetailToReminderRecipient(detail ReminderRecipientDetail) club.ReminderRecipient {
+	recipient := club.ReminderRecipient{
+		FirstName: detail.FirstName,
+		LastName:  detail.LastName,
+		Email:     detail.Email,
+	}
+	if detail.Address != nil {
+		address := membership.Address(*detail.Address)
+		recipient.Address = &address
+	}
+	return recipient
+}
+
+func ConvertReminderItemsToDetails(items []club.ReminderItem) []ReminderItemDetail {
+	details := make([]ReminderItemDetail, len(items))
+	for i, item := range items {
+		details[i] = ReminderItemDetail{Description: item.Description, Due: item.Due.Float64(), Balance: item.Balance.Float64()}
+	}
+	return details
+}
+
+func ConvertDetailsToReminderItems(details []ReminderItemDetail) []club.ReminderItem {
+	items := make([]club.ReminderItem, len(details))
+	for i, detail := range details {
+		items[i] = club.ReminderItem{Description: detail.Description, Due: priceOf(detail.Due), Balance: priceOf(detail.Balance)}
 	}
+	return items
 }
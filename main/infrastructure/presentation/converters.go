@@ -4,11 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
 	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/notification"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 )
 
 func WriteJSON(w http.ResponseWriter, status int, v any) {
@@ -45,73 +52,213 @@ func convertAddressesToPresentation(a []membership.Address) []Address {
 	return addresses
 }
 
-func convertMembershipToPresentation(m membership.Membership) Membership {
-	var p *Payment
+func convertGuardiansToPresentation(g []membership.Guardian) []Guardian {
+	guardians := make([]Guardian, len(g))
+	for i, guardian := range g {
+		guardians[i] = Guardian{
+			FirstName:    guardian.FirstName,
+			LastName:     guardian.LastName,
+			Relationship: guardian.Relationship,
+		}
+		if guardian.IsMember() {
+			memberId := guardian.MemberId.Value
+			guardians[i].MemberId = &memberId
+		}
+		if guardian.Email != nil {
+			guardians[i].Email = guardian.Email.Value
+		}
+		if guardian.PhoneNumber != nil {
+			guardians[i].PhoneNumber = guardian.PhoneNumber.Number
+		}
+	}
+	return guardians
+}
 
-	switch casted := m.Payment.(type) {
-	case payment.PaymentPaid:
-		p = &Payment{
-			ID:             casted.ID,
-			Amount:         casted.AmountPaid,
-			PaidAt:         casted.PaymentDate.Format("2006-01-02T15:04:05Z07:00"),
-			Currency:       casted.Currency,
-			PaymentMethod:  casted.PaymentMethod,
-			TransactionRef: casted.TransactionRef,
+func convertGuardiansToDomain(g []Guardian) ([]membership.Guardian, error) {
+	guardians := make([]membership.Guardian, 0, len(g))
+	for _, guardian := range g {
+		domainGuardian := membership.Guardian{
+			FirstName:    strings.TrimSpace(guardian.FirstName),
+			LastName:     strings.TrimSpace(guardian.LastName),
+			Relationship: strings.TrimSpace(guardian.Relationship),
 		}
-	default:
-		p = nil
+		if guardian.MemberId != nil {
+			domainGuardian.MemberId = &domain.Id[membership.User]{Value: *guardian.MemberId}
+		}
+		if guardian.Email != "" {
+			emailResult := membership.NewEmailAddress(guardian.Email)
+			if !emailResult.IsSuccess() {
+				return nil, fmt.Errorf("invalid guardian email: %s", emailResult.Error().Error())
+			}
+			email := emailResult.Value()
+			domainGuardian.Email = &email
+		}
+		if guardian.PhoneNumber != "" {
+			phoneResult := membership.NewPhoneNumber(guardian.PhoneNumber)
+			if !phoneResult.IsSuccess() {
+				return nil, fmt.Errorf("invalid guardian phone number: %s", phoneResult.Error().Error())
+			}
+			phoneNumber := phoneResult.Value()
+			domainGuardian.PhoneNumber = &phoneNumber
+		}
+		guardians = append(guardians, domainGuardian)
 	}
+	return guardians, nil
+}
 
-	membership := Membership{
+func convertMembershipToPresentation(m membership.Membership) (Membership, error) {
+	ledger, err := ConvertLedgerToPresentation(m.Ledger())
+	if err != nil {
+		return Membership{}, err
+	}
+	paid := m.IsPaid()
+	if !paid.IsSuccess() {
+		return Membership{}, paid.Error()
+	}
+	presentationMembership := Membership{
 		ID:        m.Id.Value,
 		Number:    m.Number,
 		Status:    string(m.Status.GetStatus()),
 		ValidFrom: m.Status.GetValidFromDate().Format("2006-01-02"),
 		ExpiresAt: m.Status.GetValidUntilDate().Format("2006-01-02"),
 		PeriodId:  m.Status.GetPeriodId(),
-		Price:     m.Price,
-		Payment:   p,
+		Price:     m.Price.Float64(),
+		Payment:   convertLastPaymentToPresentation(m.Ledger()),
+		Ledger:    ledger,
+		Paid:      paid.Value(),
+	}
+
+	if m.Category != nil {
+		category := convertMembershipCategoryToPresentation(*m.Category)
+		presentationMembership.Category = &category
+	}
+
+	if excluded, ok := m.Status.(membership.Excluded); ok {
+		excludedAt := excluded.ExcludedAt.Format("2006-01-02")
+		presentationMembership.ExcludedAt = &excludedAt
+		presentationMembership.ExclusionReason = excluded.Reason
+	}
+
+	return presentationMembership, nil
+}
+
+// ConvertLedgerToPresentation fails with a CurrencyMismatchError when the transactions
+// are not in the currency of the amount due
+func ConvertLedgerToPresentation(ledger payment.Ledger) (Ledger, error) {
+	transactions := make([]Payment, len(ledger.Transactions))
+	for i, transaction := range ledger.Transactions {
+		transactions[i] = convertTransactionToPresentation(transaction)
+	}
+	paid := ledger.Paid()
+	if !paid.IsSuccess() {
+		return Ledger{}, paid.Error()
+	}
+	refunded := ledger.Refunded()
+	if !refunded.IsSuccess() {
+		return Ledger{}, refunded.Error()
+	}
+	balance := ledger.Balance()
+	if !balance.IsSuccess() {
+		return Ledger{}, balance.Error()
+	}
+	status := ledger.GetStatus()
+	if !status.IsSuccess() {
+		return Ledger{}, status.Error()
+	}
+	return Ledger{
+		Due:          ledger.Due.Float64(),
+		Paid:         paid.Value().Float64(),
+		Refunded:     refunded.Value().Float64(),
+		Balance:      balance.Value().Float64(),
+		Status:       string(status.Value()),
+		Transactions: transactions,
+	}, nil
+}
+
+func convertLastPaymentToPresentation(ledger payment.Ledger) *Payment {
+	last := ledger.LastPayment()
+	if last == nil {
+		return nil
 	}
+	p := convertTransactionToPresentation(*last)
+	return &p
+}
 
-	return membership
+func convertTransactionToPresentation(transaction payment.Transaction) Payment {
+	return Payment{
+		ID:             transaction.Id.Value,
+		Type:           string(transaction.Type),
+		Amount:         transaction.Amount.Float64(),
+		Currency:       string(transaction.Amount.Currency()),
+		PaidAt:         transaction.Date.Format(time.RFC3339),
+		PaymentMethod:  transaction.PaymentMethod,
+		TransactionRef: transaction.Notes,
+	}
 }
 
-func convertMembershipsToPresentation(memberships []membership.Membership) []Membership {
+func convertMembershipsToPresentation(memberships []membership.Membership) ([]Membership, error) {
 	presentationMemberships := make([]Membership, len(memberships))
 	for i, m := range memberships {
-		presentationMemberships[i] = convertMembershipToPresentation(m)
+		presentationMembership, err := convertMembershipToPresentation(m)
+		if err != nil {
+			return nil, err
+		}
+		presentationMemberships[i] = presentationMembership
 	}
-	return presentationMemberships
+	return presentationMemberships, nil
 }
 
-func ConvertMemberToPresentation(domainMember membership.Member) Member {
+func ConvertMemberToPresentation(domainMember membership.Member) (Member, error) {
 	birthDate := ""
 	if !domainMember.User.BirthDate.IsZero() {
 		birthDate = domainMember.User.BirthDate.Format("2006-01-02")
 	}
 
-	return Member{
-		ID:                  domainMember.Id.Value,
-		FirstName:           domainMember.User.FirstName,
-		LastName:            domainMember.User.LastName,
-		BirthDate:           birthDate,
-		MembershipNumber:    domainMember.Membership.Number,
-		MembershipStatus:    string(domainMember.Membership.Status.GetStatus()),
-		MembershipPaid:      domainMember.Membership.Payment.GetStatus() == payment.Paid,
-		HasUnpaidFacilities: domainMember.HasUnpaidFacilities,
-		HasRentedFacilities: domainMember.HasRentedFacilities,
+	paymentStatus := ""
+	if domainMember.Membership.Status.GetStatus() != membership.MembershipStatusNone {
+		status := domainMember.Membership.Ledger().GetStatus()
+		if !status.IsSuccess() {
+			return Member{}, status.Error()
+		}
+		paymentStatus = string(status.Value())
+	}
+	paid := domainMember.Membership.IsPaid()
+	if !paid.IsSuccess() {
+		return Member{}, paid.Error()
 	}
+
+	return Member{
+		ID:                      domainMember.Id.Value,
+		FirstName:               domainMember.User.FirstName,
+		LastName:                domainMember.User.LastName,
+		BirthDate:               birthDate,
+		MembershipNumber:        domainMember.Membership.Number,
+		MembershipStatus:        string(domainMember.Membership.Status.GetStatus()),
+		MembershipCategory:      membershipCategoryCode(domainMember.Membership.Category),
+		MembershipPaid:          paid.Value(),
+		MembershipPaymentStatus: paymentStatus,
+		HasUnpaidFacilities:     domainMember.HasUnpaidFacilities,
+		HasRentedFacilities:     domainMember.HasRentedFacilities,
+		HasFlaggedRentals:       domainMember.HasFlaggedRentals,
+		MembershipOverdue:       domainMember.MembershipOverdue,
+		HasOverdueFacilities:    domainMember.HasOverdueFacilities,
+		LateFees:                domainMember.LateFees.Float64(),
+	}, nil
 }
 
-func ConvertMembersToPresentation(domainMembers []membership.Member) []Member {
+func ConvertMembersToPresentation(domainMembers []membership.Member) ([]Member, error) {
 	presentationMembers := make([]Member, len(domainMembers))
 	for i, dm := range domainMembers {
-		presentationMembers[i] = ConvertMemberToPresentation(dm)
+		presentationMember, err := ConvertMemberToPresentation(dm)
+		if err != nil {
+			return nil, err
+		}
+		presentationMembers[i] = presentationMember
 	}
-	return presentationMembers
+	return presentationMembers, nil
 }
 
-func ConvertMemberDetailsToPresentation(domainMember membership.MemberDetails) MemberDetails {
+func ConvertMemberDetailsToPresentation(domainMember membership.MemberDetails) (MemberDetails, error) {
 	birthDate := ""
 	if !domainMember.User.BirthDate.IsZero() {
 		birthDate = domainMember.User.BirthDate.Format("2006-01-02")
@@ -133,18 +280,34 @@ func ConvertMemberDetailsToPresentation(domainMember membership.MemberDetails) M
 		}
 	}
 
+	var householdId *int64
+	if domainMember.User.HouseholdId != nil {
+		id := domainMember.User.HouseholdId.Value
+		householdId = &id
+	}
+
+	memberships, err := convertMembershipsToPresentation(domainMember.Memberships)
+	if err != nil {
+		return MemberDetails{}, err
+	}
+
 	return MemberDetails{
 		ID:           domainMember.User.Id.Value,
 		FirstName:    domainMember.User.FirstName,
 		LastName:     domainMember.User.LastName,
 		Email:        email,
 		BirthDate:    birthDate,
-		TaxCode:      domainMember.User.TaxCode,
+		TaxCode:      taxCodeValue(domainMember.User.TaxCode),
+		Billing:      convertBillingDetailsToPresentation(domainMember.User.Billing),
 		BirthPlace:   birthPlace,
 		PhoneNumbers: convertPhoneNumbersToPresentation(domainMember.PhoneNumbers),
 		Addresses:    convertAddressesToPresentation(domainMember.Addresses),
-		Memberships:  convertMembershipsToPresentation(domainMember.Memberships),
-	}
+		Guardians:    convertGuardiansToPresentation(domainMember.Guardians),
+		Consents:     ConvertConsentsToPresentation(domainMember.Consents),
+		IsMinor:      domainMember.IsMinor(),
+		HouseholdId:  householdId,
+		Memberships:  memberships,
+	}, nil
 }
 
 func ConvertMemberToSummary(domainMember membership.Member) MemberSummary {
@@ -160,7 +323,11 @@ func ConvertMemberToSummary(domainMember membership.Member) MemberSummary {
 	}
 }
 
-func ConvertRentedFacilityToPresentation(rf facilityrental.RentedFacility) RentedFacility {
+func ConvertRentedFacilityToPresentation(rf facilityrental.RentedFacility) (RentedFacility, error) {
+	ledger, err := ConvertLedgerToPresentation(rf.GetLedger())
+	if err != nil {
+		return RentedFacility{}, err
+	}
 	rentedFacility := RentedFacility{
 		ID:                      rf.GetId().Value,
 		FacilityID:              rf.GetFacility().Id.Value,
@@ -168,24 +335,17 @@ func ConvertRentedFacilityToPresentation(rf facilityrental.RentedFacility) Rente
 		FacilityName:            rf.GetFacility().FacilityType.FacilityName.String(),
 		FacilityTypeDescription: rf.GetFacility().FacilityType.Description,
 		RentedAt:                rf.GetValidity().FromDate.Format("2006-01-02"),
-		Price:                   rf.GetPrice(),
+		Price:                   rf.GetPrice().Float64(),
 		ExpiresAt:               rf.GetValidity().ToDate.Format("2006-01-02"),
 		BoatInfo:                nil,
 		LeerboardInfo:           nil,
-		Payment:                 nil,
-	}
-
-	// Convert payment information
-	if rf.GetPayment().GetStatus() == payment.Paid {
-		if paymentPaid, ok := rf.GetPayment().(payment.PaymentPaid); ok {
-			rentedFacility.Payment = &Payment{
-				ID:             paymentPaid.ID,
-				Amount:         paymentPaid.AmountPaid,
-				Currency:       paymentPaid.Currency,
-				PaidAt:         paymentPaid.PaymentDate.Format(time.RFC3339),
-				PaymentMethod:  paymentPaid.PaymentMethod,
-				TransactionRef: paymentPaid.TransactionRef,
-			}
+		Payment:                 convertLastPaymentToPresentation(rf.GetLedger()),
+		Ledger:                  ledger,
+	}
+	if flag := rf.GetFlag(); flag != nil {
+		rentedFacility.Flag = &RentalFlag{
+			FlaggedAt: flag.FlaggedAt.Format("2006-01-02T15:04:05Z07:00"),
+			Reason:    flag.Reason,
 		}
 	}
 
@@ -230,7 +390,19 @@ func ConvertRentedFacilityToPresentation(rf facilityrental.RentedFacility) Rente
 		}
 	}
 
-	return rentedFacility
+	return rentedFacility, nil
+}
+
+func ConvertRentedFacilitiesToPresentation(rentedFacilities []facilityrental.RentedFacility) ([]RentedFacility, error) {
+	presentationFacilities := make([]RentedFacility, len(rentedFacilities))
+	for i, rf := range rentedFacilities {
+		presentationFacility, err := ConvertRentedFacilityToPresentation(rf)
+		if err != nil {
+			return nil, err
+		}
+		presentationFacilities[i] = presentationFacility
+	}
+	return presentationFacilities, nil
 }
 
 func ConvertFacilityTypesToPresentation(domainFacilityTypes []facilityrental.FacilityType) []FacilityType {
@@ -240,7 +412,7 @@ func ConvertFacilityTypesToPresentation(domainFacilityTypes []facilityrental.Fac
 			ID:             ft.Id.Value,
 			Name:           string(ft.FacilityName),
 			Description:    ft.Description,
-			SuggestedPrice: ft.SuggestedPrice,
+			SuggestedPrice: ft.SuggestedPrice.Float64(),
 			HasBoat:        ft.HasBoat,
 			HasLeerboard:   ft.HasLeerboard,
 		}
@@ -263,7 +435,7 @@ func ConvertFacilitiesWithStatusToPresentation(domainFacilities []facilityrental
 			Identifier:              f.Identifier,
 			FacilityTypeName:        string(f.FacilityTypeName),
 			FacilityTypeDescription: f.FacilityTypeDescription,
-			SuggestedPrice:          f.SuggestedPrice,
+			SuggestedPrice:          f.SuggestedPrice.Float64(),
 			IsRented:                f.IsRented,
 			ExpiresAt:               expiresAt,
 			RentedByMemberId:        f.RentedByMemberId,
@@ -278,7 +450,50 @@ type CreateMemberData struct {
 	User             membership.User
 	CreateMembership bool
 	SeasonId         *int64
-	Price            *float64
+	Price            *money.Money
+	Category         membership.MembershipCategoryCode
+}
+
+// ConvertPriceToDomain reads a price received in euros, MoneyError when it has more than two decimals
+func ConvertPriceToDomain(price float64) (money.Money, error) {
+	parsed := money.ExactFromFloat(price, money.DefaultCurrency)
+	if !parsed.IsSuccess() {
+		return money.Money{}, parsed.Error()
+	}
+	return parsed.Value(), nil
+}
+
+// ConvertAmountToDomain reads an amount in the given currency, MoneyError when the currency is unknown or the amount is finer than its minor unit
+func ConvertAmountToDomain(amount float64, currency string) (money.Money, error) {
+	parsedCurrency := money.ParseCurrency(currency)
+	if !parsedCurrency.IsSuccess() {
+		return money.Money{}, parsedCurrency.Error()
+	}
+	parsed := money.ExactFromFloat(amount, parsedCurrency.Value())
+	if !parsed.IsSuccess() {
+		return money.Money{}, parsed.Error()
+	}
+	return parsed.Value(), nil
+}
+
+func ConvertOptionalPriceToDomain(price *float64) (*money.Money, error) {
+	if price == nil {
+		return nil, nil
+	}
+	parsed, err := ConvertPriceToDomain(*price)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// convertOptionalPriceToPresentation writes an amount that may be missing, null when it is
+func convertOptionalPriceToPresentation(price *money.Money) *float64 {
+	if price == nil {
+		return nil
+	}
+	amount := price.Float64()
+	return &amount
 }
 
 func ConvertCreateMemberRequestToDomain(req CreateMemberRequest) (CreateMemberData, error) {
@@ -321,6 +536,11 @@ func ConvertCreateMemberRequestToDomain(req CreateMemberRequest) (CreateMemberDa
 		})
 	}
 
+	guardians, err := convertGuardiansToDomain(req.Guardians)
+	if err != nil {
+		return CreateMemberData{}, err
+	}
+
 	var birthPlace *membership.Address
 	if req.BirthPlace != nil {
 		birthPlace = &membership.Address{
@@ -337,17 +557,39 @@ func ConvertCreateMemberRequestToDomain(req CreateMemberRequest) (CreateMemberDa
 		LastName:     req.LastName,
 		BirthDate:    birthDate,
 		Email:        email,
-		TaxCode:      req.TaxCode,
 		BirthPlace:   birthPlace,
 		Addresses:    addresses,
 		PhoneNumbers: phoneNumbers,
+		Guardians:    guardians,
+		Consents:     ConvertConsentsToDomain(req.Consents),
+	}
+
+	// Validate tax code (optional) against the personal data
+	if req.TaxCode != "" {
+		taxCode, err := convertTaxCodeToDomain(req.TaxCode, user)
+		if err != nil {
+			return CreateMemberData{}, err
+		}
+		user.TaxCode = &taxCode
+	}
+
+	billing, err := convertBillingDetailsToDomain(req.Billing)
+	if err != nil {
+		return CreateMemberData{}, err
+	}
+	user.Billing = billing
+
+	price, err := ConvertOptionalPriceToDomain(req.Price)
+	if err != nil {
+		return CreateMemberData{}, err
 	}
 
 	return CreateMemberData{
 		User:             user,
 		CreateMembership: req.CreateMembership,
 		SeasonId:         req.SeasonId,
-		Price:            req.Price,
+		Price:            price,
+		Category:         membership.MembershipCategoryCode(strings.ToUpper(req.Category)),
 	}, nil
 }
 
@@ -422,6 +664,11 @@ func ConvertUpdateMemberRequestToDomain(req UpdateMemberRequest) (membership.Use
 		})
 	}
 
+	guardians, err := convertGuardiansToDomain(req.Guardians)
+	if err != nil {
+		return membership.User{}, err
+	}
+
 	var birthPlace *membership.Address
 	if req.BirthPlace != nil {
 		birthPlace = &membership.Address{
@@ -438,11 +685,1413 @@ func ConvertUpdateMemberRequestToDomain(req UpdateMemberRequest) (membership.Use
 		LastName:     req.LastName,
 		BirthDate:    birthDate,
 		Email:        email,
-		TaxCode:      req.TaxCode,
 		BirthPlace:   birthPlace,
 		Addresses:    addresses,
 		PhoneNumbers: phoneNumbers,
+		Guardians:    guardians,
+	}
+
+	// Validate tax code (optional) against the personal data
+	if req.TaxCode != "" {
+		taxCode, err := convertTaxCodeToDomain(req.TaxCode, user)
+		if err != nil {
+			return membership.User{}, err
+		}
+		user.TaxCode = &taxCode
+	}
+
+	billing, err := convertBillingDetailsToDomain(req.Billing)
+	if err != nil {
+		return membership.User{}, err
 	}
+	user.Billing = billing
 
 	return user, nil
 }
+
+func convertTaxCodeToDomain(code string, user membership.User) (membership.TaxCode, error) {
+	taxCodeResult := membership.NewTaxCode(code)
+	if !taxCodeResult.IsSuccess() {
+		return membership.TaxCode{}, fmt.Errorf("invalid tax code: %s", taxCodeResult.Error().Error())
+	}
+	checkResult := taxCodeResult.Value().CheckAgainst(user)
+	if !checkResult.IsSuccess() {
+		return membership.TaxCode{}, fmt.Errorf("invalid tax code: %s", checkResult.Error().Error())
+	}
+	return checkResult.Value(), nil
+}
+
+// convertBillingDetailsToDomain validates the billing details, nil when the member is not billed as a business
+func convertBillingDetailsToDomain(billing *BillingDetails) (*membership.BillingDetails, error) {
+	if billing == nil {
+		return nil, nil
+	}
+	detailsResult := membership.NewBillingDetails(billing.CompanyName, billing.VATNumber, billing.RecipientCode, billing.PEC)
+	if !detailsResult.IsSuccess() {
+		return nil, fmt.Errorf("invalid billing details: %s", detailsResult.Error().Error())
+	}
+	details := detailsResult.Value()
+	return &details, nil
+}
+
+func convertBillingDetailsToPresentation(billing *membership.BillingDetails) *BillingDetails {
+	if billing == nil {
+		return nil
+	}
+	details := &BillingDetails{
+		CompanyName:   billing.CompanyName,
+		VATNumber:     billing.VATNumber,
+		RecipientCode: billing.RecipientCode,
+	}
+	if billing.PEC != nil {
+		details.PEC = billing.PEC.Value
+	}
+	return details
+}
+
+// taxCodeValue returns the tax code, empty when the member has none
+func taxCodeValue(taxCode *membership.TaxCode) string {
+	if taxCode == nil {
+		return ""
+	}
+	return taxCode.Value
+}
+
+func ConvertSeasonToPresentation(season club.Season) Season {
+	var closedAt *string
+	if season.ClosedAt != nil {
+		formatted := season.ClosedAt.Format("2006-01-02T15:04:05Z07:00")
+		closedAt = &formatted
+	}
+
+	var preemptionEndsAt *string
+	if season.PreemptionEndsAt != nil {
+		formatted := season.PreemptionEndsAt.Format("2006-01-02")
+		preemptionEndsAt = &formatted
+	}
+
+	return Season{
+		ID:               season.ID,
+		Code:             season.Code,
+		Name:             season.Name,
+		StartsAt:         season.StartsAt.Format("2006-01-02"),
+		EndsAt:           season.EndsAt.Format("2006-01-02"),
+		ClosedAt:         closedAt,
+		IsClosed:         season.IsClosed(),
+		PreemptionEndsAt: preemptionEndsAt,
+	}
+}
+
+func ConvertSeasonsToPresentation(seasons []club.Season) []Season {
+	presentationSeasons := make([]Season, len(seasons))
+	for i, season := range seasons {
+		presentationSeasons[i] = ConvertSeasonToPresentation(season)
+	}
+	return presentationSeasons
+}
+
+type SeasonData struct {
+	Code     string
+	Name     string
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+func ConvertCreateSeasonRequestToDomain(req CreateSeasonRequest) (SeasonData, error) {
+	return convertSeasonFields(req.Code, req.Name, req.StartsAt, req.EndsAt)
+}
+
+func ConvertUpdateSeasonRequestToDomain(req UpdateSeasonRequest) (SeasonData, error) {
+	return convertSeasonFields(req.Code, req.Name, req.StartsAt, req.EndsAt)
+}
+
+func convertSeasonFields(code string, name string, startsAt string, endsAt string) (SeasonData, error) {
+	startDate, err := parseDate(startsAt)
+	if err != nil {
+		return SeasonData{}, fmt.Errorf("invalid start date: %w", err)
+	}
+
+	endDate, err := parseDate(endsAt)
+	if err != nil {
+		return SeasonData{}, fmt.Errorf("invalid end date: %w", err)
+	}
+
+	return SeasonData{
+		Code:     code,
+		Name:     name,
+		StartsAt: startDate,
+		EndsAt:   endDate,
+	}, nil
+}
+
+func ConvertRolloverPlanToPresentation(plan club.RolloverPlan, dryRun bool) SeasonRollover {
+	memberships := make([]MembershipRenewal, len(plan.Memberships))
+	for i, renewal := range plan.Memberships {
+		memberships[i] = MembershipRenewal{
+			MemberID:         renewal.MemberId.Value,
+			FirstName:        renewal.FirstName,
+			LastName:         renewal.LastName,
+			MembershipNumber: renewal.MembershipNumber,
+			PreviousPrice:    renewal.PreviousPrice.Float64(),
+			Price:            renewal.Price.Float64(),
+			Category:         membershipCategoryCode(renewal.Category),
+		}
+	}
+
+	rentals := make([]RentalRenewal, len(plan.Rentals))
+	for i, renewal := range plan.Rentals {
+		facility := renewal.SourceRental.GetFacility()
+		rentals[i] = RentalRenewal{
+			MemberID:           renewal.MemberId.Value,
+			FirstName:          renewal.FirstName,
+			LastName:           renewal.LastName,
+			FacilityID:         facility.Id.Value,
+			FacilityIdentifier: facility.Identifier,
+			FacilityName:       facility.FacilityType.FacilityName.String(),
+			PreviousPrice:      renewal.PreviousPrice.Float64(),
+			Price:              renewal.Price.Float64(),
+			DiscountApplied:    renewal.DiscountApplied,
+			PricingMethod:      string(renewal.PricingMethod),
+		}
+	}
+
+	skipped := make([]SkippedRenewal, len(plan.Skipped))
+	for i, entry := range plan.Skipped {
+		skipped[i] = SkippedRenewal{
+			MemberID:  entry.MemberId.Value,
+			FirstName: entry.FirstName,
+			LastName:  entry.LastName,
+			Kind:      string(entry.Kind),
+			Reason:    entry.Reason,
+		}
+	}
+
+	return SeasonRollover{
+		SourceSeason: ConvertSeasonToPresentation(plan.SourceSeason),
+		TargetSeason: ConvertSeasonToPresentation(plan.TargetSeason),
+		DryRun:       dryRun,
+		Memberships:  memberships,
+		Rentals:      rentals,
+		Skipped:      skipped,
+	}
+}
+
+func ConvertSetPreemptionWindowRequestToDomain(req SetPreemptionWindowRequest) (*time.Time, error) {
+	if req.PreemptionEndsAt == nil || *req.PreemptionEndsAt == "" {
+		return nil, nil
+	}
+
+	preemptionEndsAt, err := parseDate(*req.PreemptionEndsAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid preemption end date: %w", err)
+	}
+
+	return &preemptionEndsAt, nil
+}
+
+func ConvertPreemptionRightsToPresentation(preemptions []facilityrental.PreemptionRight) []PreemptionRight {
+	presentationPreemptions := make([]PreemptionRight, len(preemptions))
+	for i, preemption := range preemptions {
+		presentationPreemptions[i] = PreemptionRight{
+			FacilityID:         preemption.Facility.Id.Value,
+			FacilityIdentifier: preemption.Facility.Identifier,
+			FacilityTypeID:     preemption.Facility.FacilityType.Id.Value,
+			FacilityName:       preemption.Facility.FacilityType.FacilityName.String(),
+			HolderID:           preemption.HolderId.Value,
+			HolderFirstName:    preemption.HolderFirstName,
+			HolderLastName:     preemption.HolderLastName,
+			PreviousSeasonID:   preemption.PreviousSeasonId,
+			ExpiresAt:          preemption.ExpiresAt.Format("2006-01-02"),
+		}
+	}
+	return presentationPreemptions
+}
+
+func ConvertExclusionDecisionDate(req UpdateMembershipStatusRequest) (time.Time, error) {
+	if req.DecisionDate == "" {
+		return time.Time{}, fmt.Errorf("decisionDate is required to exclude a member")
+	}
+
+	decisionDate, err := parseDate(req.DecisionDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid decision date: %w", err)
+	}
+
+	return decisionDate, nil
+}
+
+func ConvertExpiryRunToPresentation(run club.ExpiryRun) ExpiryRun {
+	expiredMemberships := make([]ExpiredMembership, len(run.ExpiredMemberships))
+	for i, expired := range run.ExpiredMemberships {
+		expiredMemberships[i] = ExpiredMembership{
+			PeriodID:         expired.PeriodId,
+			MemberID:         expired.MemberId.Value,
+			FirstName:        expired.FirstName,
+			LastName:         expired.LastName,
+			MembershipNumber: expired.MembershipNumber,
+			SeasonID:         expired.SeasonId,
+		}
+	}
+
+	flaggedRentals := make([]FlaggedRental, len(run.FlaggedRentals))
+	for i, flagged := range run.FlaggedRentals {
+		flaggedRentals[i] = FlaggedRental{
+			RentedFacilityID:   flagged.RentedFacilityId,
+			MemberID:           flagged.MemberId.Value,
+			FacilityIdentifier: flagged.FacilityIdentifier,
+			SeasonID:           flagged.SeasonId,
+			Reason:             flagged.Reason,
+		}
+	}
+
+	return ExpiryRun{
+		ID:                 run.Id,
+		Trigger:            string(run.Trigger),
+		ReferenceDate:      run.ReferenceDate.Format("2006-01-02"),
+		StartedAt:          run.StartedAt.Format("2006-01-02T15:04:05Z07:00"),
+		FinishedAt:         run.FinishedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ExpiredMemberships: expiredMemberships,
+		FlaggedRentals:     flaggedRentals,
+	}
+}
+
+func ConvertExpiryRunsToPresentation(runs []club.ExpiryRun) []ExpiryRun {
+	presentationRuns := make([]ExpiryRun, len(runs))
+	for i, run := range runs {
+		presentationRuns[i] = ConvertExpiryRunToPresentation(run)
+	}
+	return presentationRuns
+}
+
+func ConvertMemberDataExportToPresentation(export club.MemberDataExport) (MemberDataExport, error) {
+	member, err := ConvertMemberDetailsToPresentation(export.Member)
+	if err != nil {
+		return MemberDataExport{}, err
+	}
+
+	rentals := make([]SeasonRentals, len(export.Rentals))
+	for i, seasonRentals := range export.Rentals {
+		rentedFacilities, err := ConvertRentedFacilitiesToPresentation(seasonRentals.Rentals)
+		if err != nil {
+			return MemberDataExport{}, err
+		}
+		rentals[i] = SeasonRentals{
+			Season:  ConvertSeasonToPresentation(seasonRentals.Season),
+			Rentals: rentedFacilities,
+		}
+	}
+
+	waitingListEntries := make([]WaitingListEntry, len(export.WaitingListEntries))
+	for i, entry := range export.WaitingListEntries {
+		waitingListEntries[i] = ConvertWaitingListEntryToPresentation(entry)
+	}
+
+	guardianOf := make([]GuardedMember, len(export.GuardianOf))
+	for i, user := range export.GuardianOf {
+		guardianOf[i] = GuardedMember{
+			ID:        user.Id.Value,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			BirthDate: user.BirthDate.Format("2006-01-02"),
+		}
+	}
+
+	return MemberDataExport{
+		ExportedAt:         export.ExportedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Member:             member,
+		Rentals:            rentals,
+		WaitingListEntries: waitingListEntries,
+		Documents:          ConvertMemberDocumentsToPresentation(export.Documents),
+		ConsentHistory:     ConvertConsentsToPresentation(export.ConsentHistory),
+		GuardianOf:         guardianOf,
+		Payments:           convertPaymentRecordsToPresentation(export.Payments),
+		FiscalDocuments:    ConvertFiscalDocumentsToPresentation(export.FiscalDocuments),
+		ReminderLetters:    ConvertReminderLettersToPresentation(export.ReminderLetters),
+		Emails:             ConvertOutboxMessagesToPresentation(export.Emails),
+	}, nil
+}
+
+// MemberSearchParameters are the query parameters that turn the member list into a paginated search
+var MemberSearchParameters = []string{"q", "status", "paid", "unpaidFacilities", "facilityType", "withoutConsent", "sort", "order", "limit", "offset"}
+
+func ConvertMemberSearchQueryToDomain(query url.Values) (membership.MemberSearchCriteria, error) {
+	criteria := membership.MemberSearchCriteria{
+		Query: query.Get("q"),
+	}
+
+	if season := query.Get("season"); season != "" {
+		seasonId, err := strconv.ParseInt(season, 10, 64)
+		if err != nil {
+			return criteria, fmt.Errorf("invalid season ID format")
+		}
+		criteria.SeasonId = &seasonId
+	}
+
+	if status := query.Get("status"); status != "" {
+		membershipStatus := membership.MembershipStatus(strings.ToUpper(status))
+		switch membershipStatus {
+		case membership.MembershipStatusActive, membership.MembershipStatusSuspended,
+			membership.MembershipStatusExcluded, membership.MembershipStatusExpired:
+			criteria.Status = &membershipStatus
+		default:
+			return criteria, fmt.Errorf("invalid membership status: %s", status)
+		}
+	}
+
+	if paid := query.Get("paid"); paid != "" {
+		membershipPaid, err := strconv.ParseBool(paid)
+		if err != nil {
+			return criteria, fmt.Errorf("invalid paid filter: %s", paid)
+		}
+		criteria.MembershipPaid = &membershipPaid
+	}
+
+	if unpaidFacilities := query.Get("unpaidFacilities"); unpaidFacilities != "" {
+		hasUnpaidFacilities, err := strconv.ParseBool(unpaidFacilities)
+		if err != nil {
+			return criteria, fmt.Errorf("invalid unpaidFacilities filter: %s", unpaidFacilities)
+		}
+		criteria.HasUnpaidFacilities = &hasUnpaidFacilities
+	}
+
+	if facilityType := query.Get("facilityType"); facilityType != "" {
+		facilityTypeId, err := strconv.ParseInt(facilityType, 10, 64)
+		if err != nil {
+			return criteria, fmt.Errorf("invalid facility type ID format")
+		}
+		criteria.FacilityTypeId = &facilityTypeId
+	}
+
+	if withoutConsent := query.Get("withoutConsent"); withoutConsent != "" {
+		consentType := membership.ConsentType(strings.ToUpper(withoutConsent))
+		switch consentType {
+		case membership.PrivacyPolicyConsent, membership.PhotoConsent, membership.MarketingConsent:
+			criteria.WithoutConsent = &consentType
+		default:
+			return criteria, fmt.Errorf("invalid consent type: %s", withoutConsent)
+		}
+	}
+
+	if sort := query.Get("sort"); sort != "" {
+		criteria.SortBy = membership.MemberSortField(sort)
+		if !criteria.SortBy.IsValid() {
+			return criteria, fmt.Errorf("invalid sort field: %s", sort)
+		}
+	}
+
+	switch query.Get("order") {
+	case "", "asc":
+	case "desc":
+		criteria.Descending = true
+	default:
+		return criteria, fmt.Errorf("invalid order: %s", query.Get("order"))
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value <= 0 {
+			return criteria, fmt.Errorf("invalid limit: %s", limit)
+		}
+		criteria.Limit = value
+	}
+
+	if offset := query.Get("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return criteria, fmt.Errorf("invalid offset: %s", offset)
+		}
+		criteria.Offset = value
+	}
+
+	return criteria, nil
+}
+
+func ConvertMemberPageToPresentation(page membership.MemberPage) (MemberPage, error) {
+	members, err := ConvertMembersToPresentation(page.Members)
+	if err != nil {
+		return MemberPage{}, err
+	}
+	return MemberPage{
+		Members: members,
+		Total:   page.Total,
+		Limit:   page.Limit,
+		Offset:  page.Offset,
+	}, nil
+}
+
+func ConvertTaxCodeSuggestionRequestToDomain(req TaxCodeSuggestionRequest) (membership.TaxCode, error) {
+	birthDate, err := parseDate(req.BirthDate)
+	if err != nil {
+		return membership.TaxCode{}, fmt.Errorf("invalid birth date: %w", err)
+	}
+
+	taxCodeResult := membership.SuggestTaxCode(
+		req.FirstName,
+		req.LastName,
+		birthDate,
+		membership.Sex(strings.ToUpper(req.Sex)),
+		req.BirthPlaceCode,
+	)
+	if !taxCodeResult.IsSuccess() {
+		return membership.TaxCode{}, taxCodeResult.Error()
+	}
+	return taxCodeResult.Value(), nil
+}
+
+func convertDuplicateMemberToPresentation(user membership.User) DuplicateMember {
+	email := ""
+	if user.Email != nil {
+		email = user.Email.Value
+	}
+
+	phoneNumbers := make([]string, len(user.PhoneNumbers))
+	for i, phone := range user.PhoneNumbers {
+		phoneNumbers[i] = phone.Number
+	}
+
+	return DuplicateMember{
+		ID:           user.Id.Value,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		BirthDate:    user.BirthDate.Format("2006-01-02"),
+		Email:        email,
+		TaxCode:      taxCodeValue(user.TaxCode),
+		PhoneNumbers: phoneNumbers,
+	}
+}
+
+func ConvertDuplicateCandidatesToPresentation(candidates []membership.DuplicateCandidate) []DuplicateCandidate {
+	presentationCandidates := make([]DuplicateCandidate, len(candidates))
+	for i, candidate := range candidates {
+		presentationCandidates[i] = DuplicateCandidate{
+			First:   convertDuplicateMemberToPresentation(candidate.First),
+			Second:  convertDuplicateMemberToPresentation(candidate.Second),
+			Score:   candidate.Score,
+			Reasons: candidate.Reasons,
+		}
+	}
+	return presentationCandidates
+}
+
+func ConvertMergeResultToPresentation(mergeResult membership.MergeResult) MergeResult {
+	return MergeResult{
+		SourceMemberID:          mergeResult.SourceId.Value,
+		TargetMemberID:          mergeResult.TargetId.Value,
+		MovedMembershipPeriods:  mergeResult.MovedMembershipPeriods,
+		MergedMembershipPeriods: mergeResult.MergedMembershipPeriods,
+		MovedRentals:            mergeResult.MovedRentals,
+		MovedWaitingListEntries: mergeResult.MovedWaitingListEntries,
+		MovedPhoneNumbers:       mergeResult.MovedPhoneNumbers,
+		MovedAddresses:          mergeResult.MovedAddresses,
+		MovedDocuments:          mergeResult.MovedDocuments,
+		MovedFiscalDocuments:    mergeResult.MovedFiscalDocuments,
+		MovedReminderLetters:    mergeResult.MovedReminderLetters,
+	}
+}
+
+func ConvertHouseholdToPresentation(household membership.Household) Household {
+	members := make([]HouseholdMember, len(household.Members))
+	for i, member := range household.Members {
+		members[i] = HouseholdMember{
+			ID:        member.Id.Value,
+			FirstName: member.FirstName,
+			LastName:  member.LastName,
+			BirthDate: member.BirthDate.Format("2006-01-02"),
+		}
+		if member.Email != nil {
+			members[i].Email = member.Email.Value
+		}
+	}
+
+	return Household{
+		ID:      household.Id.Value,
+		Name:    household.Name,
+		Members: members,
+	}
+}
+
+func ConvertHouseholdsToPresentation(households []membership.Household) []Household {
+	presentationHouseholds := make([]Household, len(households))
+	for i, household := range households {
+		presentationHouseholds[i] = ConvertHouseholdToPresentation(household)
+	}
+	return presentationHouseholds
+}
+
+func ConvertCreateHouseholdRequestToDomain(req CreateHouseholdRequest) (string, []domain.Id[membership.Member]) {
+	memberIds := make([]domain.Id[membership.Member], len(req.MemberIds))
+	for i, id := range req.MemberIds {
+		memberIds[i] = domain.NewId[membership.Member](id)
+	}
+	return req.Name, memberIds
+}
+
+func ConvertHouseholdOverviewToPresentation(overview club.HouseholdOverview) (HouseholdOverview, error) {
+	members := make([]HouseholdMemberOverview, len(overview.Members))
+	for i, member := range overview.Members {
+		details, err := ConvertMemberDetailsToPresentation(member.Member)
+		if err != nil {
+			return HouseholdOverview{}, err
+		}
+		rentals, err := ConvertRentedFacilitiesToPresentation(member.Rentals)
+		if err != nil {
+			return HouseholdOverview{}, err
+		}
+		members[i] = HouseholdMemberOverview{
+			Member:             details,
+			Rentals:            rentals,
+			OutstandingBalance: member.OutstandingBalance.Float64(),
+		}
+	}
+
+	return HouseholdOverview{
+		ID:                 overview.Household.Id.Value,
+		Name:               overview.Household.Name,
+		SeasonId:           overview.SeasonId,
+		Members:            members,
+		OutstandingBalance: overview.OutstandingBalance.Float64(),
+	}, nil
+}
+
+func ConvertFamilyPricingSchemeToPresentation(scheme membership.FamilyPricingScheme) FamilyPricingScheme {
+	return FamilyPricingScheme{
+		FullPrice:             scheme.FullPrice.Float64(),
+		AdditionalMemberPrice: scheme.AdditionalMemberPrice.Float64(),
+		ChildPrice:            scheme.ChildPrice.Float64(),
+		ChildMaxAge:           scheme.ChildMaxAge,
+	}
+}
+
+func ConvertFamilyPricingSchemeToDomain(scheme FamilyPricingScheme) (membership.FamilyPricingScheme, error) {
+	prices := make([]money.Money, 3)
+	for i, price := range []float64{scheme.FullPrice, scheme.AdditionalMemberPrice, scheme.ChildPrice} {
+		parsed, err := ConvertPriceToDomain(price)
+		if err != nil {
+			return membership.FamilyPricingScheme{}, err
+		}
+		prices[i] = parsed
+	}
+	return membership.FamilyPricingScheme{
+		FullPrice:             prices[0],
+		AdditionalMemberPrice: prices[1],
+		ChildPrice:            prices[2],
+		ChildMaxAge:           scheme.ChildMaxAge,
+	}, nil
+}
+
+func ConvertMembershipPriceSuggestionToPresentation(suggestion membership.MembershipPriceSuggestion) MembershipPriceSuggestion {
+	var householdId *int64
+	if suggestion.HouseholdId != nil {
+		id := suggestion.HouseholdId.Value
+		householdId = &id
+	}
+
+	return MembershipPriceSuggestion{
+		SuggestedPrice: suggestion.Price.Float64(),
+		FullPrice:      suggestion.FullPrice.Float64(),
+		PricingRule:    string(suggestion.Rule),
+		HouseholdId:    householdId,
+		PayingMembers:  suggestion.PayingMembers,
+	}
+}
+
+func convertMembershipCategoryToPresentation(category membership.MembershipCategory) MembershipCategory {
+	return MembershipCategory{
+		ID:              category.Id.Value,
+		Code:            string(category.Code),
+		Name:            category.Name,
+		PaymentRequired: category.PaymentRequired,
+	}
+}
+
+func membershipCategoryCode(category *membership.MembershipCategory) string {
+	if category == nil {
+		return ""
+	}
+	return string(category.Code)
+}
+
+func ConvertMembershipCategoryPriceToPresentation(price membership.MembershipCategoryPrice) MembershipCategoryPrice {
+	presentationPrice := MembershipCategoryPrice{
+		Category: convertMembershipCategoryToPresentation(price.Category),
+		SeasonId: price.SeasonId,
+	}
+	if price.Price != nil {
+		amount := price.Price.Float64()
+		presentationPrice.Price = &amount
+	}
+	return presentationPrice
+}
+
+func ConvertMembershipCategoryPricesToPresentation(prices []membership.MembershipCategoryPrice) []MembershipCategoryPrice {
+	presentationPrices := make([]MembershipCategoryPrice, len(prices))
+	for i, price := range prices {
+		presentationPrices[i] = ConvertMembershipCategoryPriceToPresentation(price)
+	}
+	return presentationPrices
+}
+
+func ConvertMembershipCategorySuggestionsToPresentation(suggestions []membership.MembershipCategorySuggestion) []MembershipCategorySuggestion {
+	presentationSuggestions := make([]MembershipCategorySuggestion, len(suggestions))
+	for i, suggestion := range suggestions {
+		presentationSuggestions[i] = MembershipCategorySuggestion{
+			MembershipCategoryPrice: ConvertMembershipCategoryPriceToPresentation(suggestion.MembershipCategoryPrice),
+			SuggestedPrice:          suggestion.SuggestedPrice.Float64(),
+			Suggested:               suggestion.Suggested,
+		}
+	}
+	return presentationSuggestions
+}
+
+func ConvertMembershipNumberingPolicyToPresentation(policy membership.MembershipNumberingPolicy) MembershipNumberingPolicy {
+	return MembershipNumberingPolicy{
+		Scope:             string(policy.Scope),
+		Prefix:            policy.Prefix,
+		Digits:            policy.Digits,
+		ReuseFreedNumbers: policy.ReuseFreedNumbers,
+	}
+}
+
+func ConvertMembershipNumberingPolicyToDomain(policy MembershipNumberingPolicy) membership.MembershipNumberingPolicy {
+	return membership.MembershipNumberingPolicy{
+		Scope:             membership.NumberingScope(strings.ToUpper(policy.Scope)),
+		Prefix:            policy.Prefix,
+		Digits:            policy.Digits,
+		ReuseFreedNumbers: policy.ReuseFreedNumbers,
+	}
+}
+
+func ConvertMemberDocumentToPresentation(document membership.MemberDocument) MemberDocument {
+	return MemberDocument{
+		ID:          document.Id.Value,
+		MemberID:    document.UserId.Value,
+		Type:        string(document.Type),
+		FileName:    document.FileName,
+		ContentType: document.ContentType,
+		Size:        document.Size,
+		IssuedAt:    formatOptionalDate(document.IssuedAt),
+		ExpiresAt:   formatOptionalDate(document.ExpiresAt),
+		Notes:       document.Notes,
+		UploadedAt:  document.UploadedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func ConvertMemberDocumentsToPresentation(documents []membership.MemberDocument) []MemberDocument {
+	presentationDocuments := make([]MemberDocument, len(documents))
+	for i, document := range documents {
+		presentationDocuments[i] = ConvertMemberDocumentToPresentation(document)
+	}
+	return presentationDocuments
+}
+
+func ConvertDocumentFilesDeletionToPresentation(deletion membership.DocumentFilesDeletion) DocumentFilesDeletion {
+	undeletedFiles := make([]UndeletedFile, len(deletion.UndeletedFiles))
+	for i, file := range deletion.UndeletedFiles {
+		undeletedFiles[i] = UndeletedFile{StorageKey: file.StorageKey, Reason: file.Reason}
+	}
+	return DocumentFilesDeletion{
+		DeletedFiles:   deletion.DeletedFiles,
+		UndeletedFiles: undeletedFiles,
+	}
+}
+
+func ConvertCertificateChecksToPresentation(checks []membership.CertificateCheck) []CertificateCheck {
+	presentationChecks := make([]CertificateCheck, len(checks))
+	for i, check := range checks {
+		presentationChecks[i] = CertificateCheck{
+			MemberID:  check.User.Id.Value,
+			FirstName: check.User.FirstName,
+			LastName:  check.User.LastName,
+			ExpiresAt: formatOptionalDate(check.ExpiresAt),
+			Status:    string(check.Status),
+		}
+		if check.User.Email != nil {
+			email := check.User.Email.Value
+			presentationChecks[i].Email = &email
+		}
+	}
+	return presentationChecks
+}
+
+func formatOptionalDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format("2006-01-02")
+	return &formatted
+}
+
+func ConvertConsentsToPresentation(consents []membership.Consent) []Consent {
+	presentationConsents := make([]Consent, len(consents))
+	for i, consent := range consents {
+		presentationConsents[i] = Consent{
+			Type:          string(consent.Type),
+			Granted:       consent.Granted,
+			PolicyVersion: consent.PolicyVersion,
+			RecordedAt:    consent.RecordedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+	return presentationConsents
+}
+
+func ConvertConsentsToDomain(consents []Consent) []membership.Consent {
+	domainConsents := make([]membership.Consent, len(consents))
+	for i, consent := range consents {
+		domainConsents[i] = membership.Consent{
+			Type:          membership.ConsentType(strings.ToUpper(consent.Type)),
+			Granted:       consent.Granted,
+			PolicyVersion: consent.PolicyVersion,
+		}
+	}
+	return domainConsents
+}
+
+func ConvertMemberConsentsToPresentation(consents membership.MemberConsents) MemberConsents {
+	return MemberConsents{
+		Current:  ConvertConsentsToPresentation(consents.Current),
+		History:  ConvertConsentsToPresentation(consents.History),
+		UpToDate: consents.UpToDate,
+	}
+}
+
+func ConvertPrivacyPolicyToPresentation(policy membership.PrivacyPolicy) PrivacyPolicy {
+	return PrivacyPolicy{
+		Version:     policy.Version,
+		Text:        policy.Text,
+		PublishedAt: policy.PublishedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func ConvertPrivacyPoliciesToPresentation(policies []membership.PrivacyPolicy) []PrivacyPolicy {
+	presentationPolicies := make([]PrivacyPolicy, len(policies))
+	for i, policy := range policies {
+		presentationPolicies[i] = ConvertPrivacyPolicyToPresentation(policy)
+	}
+	return presentationPolicies
+}
+
+func ConvertCreatePaymentRequestToDomain(req CreatePaymentRequest) (payment.Transaction, error) {
+	amount, err := ConvertAmountToDomain(req.Amount, req.Currency)
+	if err != nil {
+		return payment.Transaction{}, err
+	}
+	transaction := payment.Transaction{
+		Type:          payment.TransactionType(strings.ToUpper(strings.TrimSpace(req.Type))),
+		Amount:        amount,
+		PaymentMethod: req.PaymentMethod,
+	}
+	if req.TransactionRef != nil {
+		transaction.Notes = *req.TransactionRef
+	}
+	return transaction, nil
+}
+
+func ConvertUpdatePaymentRequestToDomain(id domain.Id[payment.Transaction], req UpdatePaymentRequest) (payment.Transaction, error) {
+	amount, err := ConvertAmountToDomain(req.Amount, req.Currency)
+	if err != nil {
+		return payment.Transaction{}, err
+	}
+	transaction := payment.Transaction{
+		Id:            id,
+		Amount:        amount,
+		PaymentMethod: req.PaymentMethod,
+	}
+	if req.TransactionRef != nil {
+		transaction.Notes = *req.TransactionRef
+	}
+	return transaction, nil
+}
+
+func ConvertPaymentSearchQueryToDomain(query url.Values) (payment.PaymentSearchCriteria, error) {
+	criteria := payment.PaymentSearchCriteria{
+		PaymentMethod: query.Get("method"),
+	}
+
+	if season := query.Get("season"); season != "" {
+		seasonId, err := strconv.ParseInt(season, 10, 64)
+		if err != nil {
+			return criteria, fmt.Errorf("invalid season ID format")
+		}
+		criteria.SeasonId = &seasonId
+	}
+
+	if from := query.Get("from"); from != "" {
+		paidFrom, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return criteria, fmt.Errorf("invalid from date, expected YYYY-MM-DD: %s", from)
+		}
+		criteria.PaidFrom = &paidFrom
+	}
+
+	if to := query.Get("to"); to != "" {
+		paidTo, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return criteria, fmt.Errorf("invalid to date, expected YYYY-MM-DD: %s", to)
+		}
+		criteria.PaidTo = &paidTo
+	}
+
+	if member := query.Get("member"); member != "" {
+		memberId, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			return criteria, fmt.Errorf("invalid member ID format")
+		}
+		criteria.MemberId = &memberId
+	}
+
+	if target := query.Get("target"); target != "" {
+		paymentTarget := payment.PaymentTarget(strings.ToUpper(target))
+		switch paymentTarget {
+		case payment.MembershipTarget, payment.FacilityTarget:
+			criteria.Target = &paymentTarget
+		default:
+			return criteria, fmt.Errorf("invalid target: %s", target)
+		}
+	}
+
+	if minAmount := query.Get("minAmount"); minAmount != "" {
+		value, err := strconv.ParseFloat(minAmount, 64)
+		if err != nil {
+			return criteria, fmt.Errorf("invalid minAmount: %s", minAmount)
+		}
+		amount := money.FromFloat(value, money.DefaultCurrency)
+		criteria.MinAmount = &amount
+	}
+
+	if maxAmount := query.Get("maxAmount"); maxAmount != "" {
+		value, err := strconv.ParseFloat(maxAmount, 64)
+		if err != nil {
+			return criteria, fmt.Errorf("invalid maxAmount: %s", maxAmount)
+		}
+		amount := money.FromFloat(value, money.DefaultCurrency)
+		criteria.MaxAmount = &amount
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value <= 0 {
+			return criteria, fmt.Errorf("invalid limit: %s", limit)
+		}
+		criteria.Limit = value
+	}
+
+	if offset := query.Get("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return criteria, fmt.Errorf("invalid offset: %s", offset)
+		}
+		criteria.Offset = value
+	}
+
+	return criteria, nil
+}
+
+func ConvertPaymentPageToPresentation(page payment.PaymentPage) PaymentPage {
+	return PaymentPage{
+		Payments:       convertPaymentRecordsToPresentation(page.Payments),
+		Total:          page.Total,
+		TotalsByMethod: convertMethodTotalsToPresentation(page.TotalsByMethod),
+		Limit:          page.Limit,
+		Offset:         page.Offset,
+	}
+}
+
+func convertPaymentRecordsToPresentation(records []payment.PaymentRecord) []PaymentRecord {
+	payments := make([]PaymentRecord, len(records))
+	for i, record := range records {
+		payments[i] = PaymentRecord{
+			Payment:            convertTransactionToPresentation(record.Transaction),
+			Target:             string(record.Target),
+			MembershipPeriodId: record.MembershipPeriodId,
+			RentedFacilityId:   record.RentedFacilityId,
+			FacilityIdentifier: record.FacilityIdentifier,
+			MemberId:           record.MemberId,
+			MemberName:         fmt.Sprintf("%s %s", record.FirstName, record.LastName),
+			SeasonId:           record.SeasonId,
+		}
+	}
+	return payments
+}
+
+func convertMethodTotalsToPresentation(methodTotals []payment.MethodTotal) []PaymentMethodTotal {
+	totals := make([]PaymentMethodTotal, len(methodTotals))
+	for i, total := range methodTotals {
+		totals[i] = PaymentMethodTotal{
+			PaymentMethod: total.PaymentMethod,
+			Count:         total.Count,
+			Paid:          total.Paid.Float64(),
+			Refunded:      total.Refunded.Float64(),
+			Net:           total.Net().Float64(),
+		}
+	}
+	return totals
+}
+
+// ConvertCashDayToPresentation fails with a CurrencyMismatchError when the amounts of the day
+// are not all in the default currency
+func ConvertCashDayToPresentation(day payment.CashDay) (CashDay, error) {
+	dayDiscrepancies := day.Discrepancies()
+	if !dayDiscrepancies.IsSuccess() {
+		return CashDay{}, dayDiscrepancies.Error()
+	}
+	discrepancies := make([]CashRegisterDiscrepancy, 0)
+	for _, discrepancy := range dayDiscrepancies.Value() {
+		discrepancies = append(discrepancies, CashRegisterDiscrepancy{
+			Type:       string(discrepancy.Type),
+			Expected:   discrepancy.Expected.Float64(),
+			Actual:     discrepancy.Actual.Float64(),
+			Difference: discrepancy.Difference().Value().Float64(),
+		})
+	}
+	expectedCash := day.ExpectedCash()
+	if !expectedCash.IsSuccess() {
+		return CashDay{}, expectedCash.Error()
+	}
+	totals := day.TotalsByMethod()
+	if !totals.IsSuccess() {
+		return CashDay{}, totals.Error()
+	}
+
+	var closedAt *string
+	if day.ClosedAt != nil {
+		formatted := day.ClosedAt.Format(time.RFC3339)
+		closedAt = &formatted
+	}
+
+	return CashDay{
+		Date:           day.Date.Format("2006-01-02"),
+		Status:         string(day.GetStatus()),
+		OpeningBalance: day.OpeningBalance.Float64(),
+		ExpectedCash:   expectedCash.Value().Float64(),
+		CountedCash:    convertOptionalPriceToPresentation(day.CountedCash),
+		BankTransfers:  convertOptionalPriceToPresentation(day.BankTransfers),
+		Notes:          day.Notes,
+		ClosedAt:       closedAt,
+		TotalsByMethod: convertMethodTotalsToPresentation(totals.Value()),
+		Discrepancies:  discrepancies,
+		Transactions:   convertPaymentRecordsToPresentation(day.Transactions),
+	}, nil
+}
+
+func ConvertCashRegisterPeriodToPresentation(period payment.CashRegisterPeriod) (CashRegisterPeriod, error) {
+	days := make([]CashDay, len(period.Days))
+	discrepancies := 0
+	for i, day := range period.Days {
+		presentationDay, err := ConvertCashDayToPresentation(day)
+		if err != nil {
+			return CashRegisterPeriod{}, err
+		}
+		days[i] = presentationDay
+		discrepancies += len(days[i].Discrepancies)
+	}
+	totals := period.TotalsByMethod()
+	if !totals.IsSuccess() {
+		return CashRegisterPeriod{}, totals.Error()
+	}
+	return CashRegisterPeriod{
+		From:           period.From.Format("2006-01-02"),
+		To:             period.To.Format("2006-01-02"),
+		Days:           days,
+		TotalsByMethod: convertMethodTotalsToPresentation(totals.Value()),
+		Discrepancies:  discrepancies,
+	}, nil
+}
+
+func ConvertCashDayRequestToDomain(req CashDayRequest) (payment.CashDayRecord, error) {
+	record := payment.CashDayRecord{Notes: req.Notes}
+	var err error
+	if record.OpeningBalance, err = ConvertOptionalPriceToDomain(req.OpeningBalance); err != nil {
+		return record, fmt.Errorf("invalid openingBalance: %w", err)
+	}
+	if record.CountedCash, err = ConvertOptionalPriceToDomain(req.CountedCash); err != nil {
+		return record, fmt.Errorf("invalid countedCash: %w", err)
+	}
+	if record.BankTransfers, err = ConvertOptionalPriceToDomain(req.BankTransfers); err != nil {
+		return record, fmt.Errorf("invalid bankTransfers: %w", err)
+	}
+	return record, nil
+}
+
+func ConvertBankStatementToPresentation(statement payment.BankStatement) BankStatement {
+	matched := 0
+	for _, transaction := range statement.Transactions {
+		if transaction.IsMatched() {
+			matched++
+		}
+	}
+	return BankStatement{
+		ID:               statement.Id.Value,
+		FileName:         statement.FileName,
+		Format:           string(statement.Format),
+		AccountIBAN:      statement.AccountIBAN,
+		ImportedAt:       statement.ImportedAt.Format(time.RFC3339),
+		TransactionCount: len(statement.Transactions),
+		MatchedCount:     matched,
+	}
+}
+
+func ConvertBankStatementsToPresentation(statements []payment.BankStatement) []BankStatement {
+	converted := make([]BankStatement, len(statements))
+	for i, statement := range statements {
+		converted[i] = ConvertBankStatementToPresentation(statement)
+	}
+	return converted
+}
+
+func ConvertBankStatementDetailToPresentation(statement payment.BankStatement, proposals []payment.MatchProposal) BankStatementDetail {
+	converted := make([]MatchProposal, len(proposals))
+	for i, proposal := range proposals {
+		candidates := make([]MatchCandidate, len(proposal.Candidates))
+		for j, candidate := range proposal.Candidates {
+			candidates[j] = convertMatchCandidateToPresentation(candidate)
+		}
+		converted[i] = MatchProposal{
+			Transaction: convertBankTransactionToPresentation(proposal.Transaction),
+			Candidates:  candidates,
+		}
+		if proposal.Proposed != nil {
+			proposed := convertMatchCandidateToPresentation(*proposal.Proposed)
+			converted[i].Proposed = &proposed
+		}
+	}
+	return BankStatementDetail{
+		BankStatement: ConvertBankStatementToPresentation(statement),
+		Proposals:     converted,
+	}
+}
+
+func ConvertBankStatementImportToPresentation(imported payment.BankStatementImport, proposals []payment.MatchProposal) BankStatementImport {
+	return BankStatementImport{
+		Statement:     ConvertBankStatementDetailToPresentation(imported.Statement, proposals),
+		Imported:      imported.Imported,
+		Duplicates:    imported.Duplicates,
+		SkippedDebits: imported.SkippedDebits,
+	}
+}
+
+func convertBankTransactionToPresentation(transaction payment.BankTransaction) BankTransaction {
+	return BankTransaction{
+		ID:               transaction.Id.Value,
+		StatementID:      transaction.StatementId.Value,
+		Reference:        transaction.Reference,
+		BookingDate:      transaction.BookingDate.Format("2006-01-02"),
+		Amount:           transaction.Amount.Float64(),
+		Currency:         string(transaction.Amount.Currency()),
+		CounterpartyName: transaction.CounterpartyName,
+		CounterpartyIBAN: transaction.CounterpartyIBAN,
+		RemittanceInfo:   transaction.RemittanceInfo,
+		PaymentID:        transaction.PaymentId,
+		Matched:          transaction.IsMatched(),
+	}
+}
+
+func convertMatchCandidateToPresentation(candidate payment.MatchCandidate) MatchCandidate {
+	reasons := make([]string, len(candidate.Reasons))
+	for i, reason := range candidate.Reasons {
+		reasons[i] = string(reason)
+	}
+	return MatchCandidate{
+		Target:             string(candidate.Item.Target),
+		MembershipPeriodId: candidate.Item.MembershipPeriodId,
+		RentedFacilityId:   candidate.Item.RentedFacilityId,
+		FacilityIdentifier: candidate.Item.FacilityIdentifier,
+		MemberId:           candidate.Item.MemberId,
+		MemberName:         fmt.Sprintf("%s %s", candidate.Item.FirstName, candidate.Item.LastName),
+		SeasonId:           candidate.Item.SeasonId,
+		Due:                candidate.Item.Due.Float64(),
+		Balance:            candidate.Item.Balance.Float64(),
+		Score:              candidate.Score,
+		Confidence:         string(candidate.Confidence()),
+		Reasons:            reasons,
+	}
+}
+
+func ConvertConfirmMatchesRequestToDomain(req ConfirmMatchesRequest) []payment.MatchConfirmation {
+	confirmations := make([]payment.MatchConfirmation, len(req.Matches))
+	for i, match := range req.Matches {
+		confirmations[i] = payment.MatchConfirmation{
+			BankTransactionId:  domain.NewId[payment.BankTransaction](match.BankTransactionId),
+			MembershipPeriodId: match.MembershipPeriodId,
+			RentedFacilityId:   match.RentedFacilityId,
+		}
+	}
+	return confirmations
+}
+
+func ConvertMatchOutcomesToPresentation(outcomes []payment.MatchOutcome) ConfirmMatchesResponse {
+	response := ConfirmMatchesResponse{Outcomes: make([]MatchOutcome, len(outcomes))}
+	for i, outcome := range outcomes {
+		response.Outcomes[i] = MatchOutcome{BankTransactionId: outcome.BankTransactionId.Value, PaymentId: outcome.PaymentId}
+		if outcome.Error != nil {
+			response.Outcomes[i].Error = outcome.Error.Error()
+			response.Failed++
+		} else {
+			response.Confirmed++
+		}
+	}
+	return response
+}
+
+func ConvertFiscalDocumentToPresentation(document payment.FiscalDocument) FiscalDocument {
+	lines := make([]FiscalDocumentLine, len(document.Lines))
+	for i, line := range document.Lines {
+		lines[i] = FiscalDocumentLine{
+			Description:   line.Description,
+			PaidOn:        line.PaidOn.Format("2006-01-02"),
+			PaymentMethod: line.PaymentMethod,
+			Amount:        line.Amount.Float64(),
+			VATRate:       line.VATRate,
+		}
+		if line.PaymentId != 0 {
+			paymentId := line.PaymentId
+			lines[i].PaymentId = &paymentId
+		}
+	}
+
+	notes := document.Notes
+	if notes == nil {
+		notes = []string{}
+	}
+
+	return FiscalDocument{
+		ID:         document.Id.Value,
+		Kind:       string(document.Kind),
+		FiscalYear: document.FiscalYear,
+		Number:     document.Number,
+		Code:       document.Code(),
+		IssuedOn:   document.IssuedOn.Format("2006-01-02"),
+		Issuer: FiscalIssuer{
+			Name:      document.Issuer.Name,
+			TaxCode:   document.Issuer.TaxCode,
+			VATNumber: document.Issuer.VATNumber,
+			Address:   FiscalAddress(document.Issuer.Address),
+			Email:     document.Issuer.Email,
+		},
+		Recipient: FiscalRecipient{
+			MemberId:      document.Recipient.MemberId,
+			FirstName:     document.Recipient.FirstName,
+			LastName:      document.Recipient.LastName,
+			TaxCode:       document.Recipient.TaxCode,
+			CompanyName:   document.Recipient.CompanyName,
+			VATNumber:     document.Recipient.VATNumber,
+			RecipientCode: document.Recipient.RecipientCode,
+			PEC:           document.Recipient.PEC,
+			Address:       FiscalAddress(document.Recipient.Address),
+		},
+		Currency: document.Currency,
+		Lines:    lines,
+		Total:    document.Total().Float64(),
+		Notes:    notes,
+	}
+}
+
+func ConvertFiscalDocumentsToPresentation(documents []payment.FiscalDocument) []FiscalDocument {
+	converted := make([]FiscalDocument, len(documents))
+	for i, document := range documents {
+		converted[i] = ConvertFiscalDocumentToPresentation(document)
+	}
+	return converted
+}
+
+func ConvertReminderLetterToPresentation(letter club.ReminderLetter) ReminderLetter {
+	items := make([]ReminderItem, len(letter.Items))
+	for i, item := range letter.Items {
+		items[i] = ReminderItem{Description: item.Description, Due: item.Due.Float64(), Balance: item.Balance.Float64()}
+	}
+
+	recipient := ReminderRecipient{
+		FirstName: letter.Recipient.FirstName,
+		LastName:  letter.Recipient.LastName,
+		Email:     letter.Recipient.Email,
+	}
+	if letter.Recipient.Address != nil {
+		recipient.Address = &convertAddressesToPresentation([]membership.Address{*letter.Recipient.Address})[0]
+	}
+
+	return ReminderLetter{
+		ID:         letter.Id,
+		MemberId:   letter.MemberId.Value,
+		SeasonId:   letter.SeasonId,
+		SeasonCode: letter.SeasonCode,
+		Level:      string(letter.Level),
+		SentOn:     letter.SentOn.Format("2006-01-02"),
+		Recipient:  recipient,
+		Items:      items,
+		Total:      letter.Total().Float64(),
+	}
+}
+
+func ConvertReminderLettersToPresentation(letters []club.ReminderLetter) []ReminderLetter {
+	converted := make([]ReminderLetter, len(letters))
+	for i, letter := range letters {
+		converted[i] = ConvertReminderLetterToPresentation(letter)
+	}
+	return converted
+}
+
+func ConvertDunningDashboardToPresentation(dashboard club.DunningDashboard) DunningDashboard {
+	counts := dashboard.CountByLevel()
+	byLevel := []DunningLevelCount{{Level: string(club.NoReminder), Members: counts[club.NoReminder]}}
+	for _, level := range club.DunningLevels {
+		byLevel = append(byLevel, DunningLevelCount{Level: string(level), Members: counts[level]})
+	}
+
+	debtors := make([]MemberDebt, len(dashboard.Debtors))
+	for i, debt := range dashboard.Debtors {
+		items := make([]OutstandingItem, len(debt.Items))
+		for j, item := range debt.Items {
+			items[j] = convertOutstandingItemToPresentation(item)
+		}
+
+		debtors[i] = MemberDebt{
+			MemberId:          debt.MemberId.Value,
+			FirstName:         debt.FirstName,
+			LastName:          debt.LastName,
+			TaxCode:           debt.TaxCode,
+			Items:             items,
+			Total:             debt.Total().Float64(),
+			LastLevel:         string(debt.LastLevel()),
+			ReminderDue:       debt.IsReminderDue(dashboard.Today, dashboard.DaysBetweenReminders),
+			LiableToExclusion: debt.IsLiableToExclusion(dashboard.Today, dashboard.DaysBetweenReminders),
+			Reminders:         ConvertReminderLettersToPresentation(debt.Reminders),
+		}
+		if len(debt.Reminders) > 0 {
+			sentOn := debt.Reminders[len(debt.Reminders)-1].SentOn.Format("2006-01-02")
+			debtors[i].LastReminderSentOn = &sentOn
+		}
+		if next := debt.NextLevel(); next != nil {
+			level := string(*next)
+			debtors[i].NextLevel = &level
+		}
+		if next := debt.NextReminderOn(dashboard.DaysBetweenReminders); next != nil {
+			day := next.Format("2006-01-02")
+			debtors[i].NextReminderOn = &day
+		}
+	}
+
+	return DunningDashboard{
+		SeasonId:             dashboard.Season.ID,
+		SeasonCode:           dashboard.Season.Code,
+		Date:                 dashboard.Today.Format("2006-01-02"),
+		DaysBetweenReminders: dashboard.DaysBetweenReminders,
+		TotalOutstanding:     dashboard.TotalOutstanding().Float64(),
+		DebtorCount:          len(dashboard.Debtors),
+		DueReminderCount:     len(dashboard.DueReminders()),
+		ByLevel:              byLevel,
+		Debtors:              debtors,
+	}
+}
+
+func convertOutstandingItemToPresentation(item payment.OutstandingItem) OutstandingItem {
+	return OutstandingItem{
+		Target:             string(item.Target),
+		MembershipPeriodId: item.MembershipPeriodId,
+		RentedFacilityId:   item.RentedFacilityId,
+		FacilityName:       item.FacilityName,
+		FacilityIdentifier: item.FacilityIdentifier,
+		Due:                item.Due.Float64(),
+		Balance:            item.Balance.Float64(),
+	}
+}
+
+func ConvertPaymentDeadlinesToPresentation(deadlines []payment.PaymentDeadline) []PaymentDeadline {
+	converted := make([]PaymentDeadline, len(deadlines))
+	for i, deadline := range deadlines {
+		converted[i] = PaymentDeadline{
+			ID:               deadline.Id,
+			SeasonId:         deadline.SeasonId,
+			Target:           string(deadline.Target),
+			FacilityTypeId:   deadline.FacilityTypeId,
+			FacilityTypeName: deadline.FacilityTypeName,
+			DueOn:            deadline.DueOn.Format("2006-01-02"),
+		}
+		if deadline.LateFee != nil {
+			converted[i].LateFee = &LateFeeRule{
+				Kind:      string(deadline.LateFee.Kind),
+				Amount:    deadline.LateFee.Amount.Float64(),
+				AfterDays: deadline.LateFee.AfterDays,
+			}
+			if deadline.LateFee.Kind == payment.PercentageLateFee {
+				converted[i].LateFee.Amount = deadline.LateFee.Percentage
+			}
+		}
+	}
+	return converted
+}
+
+// ConvertSetPaymentDeadlinesRequestToDomain reads the deadlines, leaving their validation to the domain
+func ConvertSetPaymentDeadlinesRequestToDomain(req SetPaymentDeadlinesRequest) ([]payment.PaymentDeadline, error) {
+	deadlines := make([]payment.PaymentDeadline, len(req.Deadlines))
+	for i, deadline := range req.Deadlines {
+		dueOn, err := parseDate(deadline.DueOn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due date of deadline %d: %w", i+1, err)
+		}
+		deadlines[i] = payment.PaymentDeadline{
+			Target:         payment.PaymentTarget(strings.ToUpper(deadline.Target)),
+			FacilityTypeId: deadline.FacilityTypeId,
+			DueOn:          dueOn,
+		}
+		if deadline.LateFee != nil {
+			rule := payment.LateFeeRule{
+				Kind:      payment.LateFeeKind(strings.ToUpper(deadline.LateFee.Kind)),
+				AfterDays: deadline.LateFee.AfterDays,
+			}
+			if rule.Kind == payment.PercentageLateFee {
+				rule.Percentage = deadline.LateFee.Amount
+			} else if rule.Amount, err = ConvertPriceToDomain(deadline.LateFee.Amount); err != nil {
+				return nil, fmt.Errorf("invalid late fee of deadline %d: %w", i+1, err)
+			}
+			deadlines[i].LateFee = &rule
+		}
+	}
+	return deadlines, nil
+}
+
+func ConvertOverdueItemsToPresentation(items []payment.OverdueItem) []OverdueItem {
+	converted := make([]OverdueItem, len(items))
+	for i, item := range items {
+		converted[i] = OverdueItem{
+			MemberId:         item.Item.MemberId,
+			FirstName:        item.Item.FirstName,
+			LastName:         item.Item.LastName,
+			Item:             convertOutstandingItemToPresentation(item.Item),
+			DueOn:            item.DueOn.Format("2006-01-02"),
+			DaysLate:         item.DaysLate,
+			LateFee:          item.LateFee.Float64(),
+			LateFeeChargedOn: formatOptionalDate(item.LateFeeChargedOn),
+			Total:            item.Total.Float64(),
+		}
+	}
+	return converted
+}
+
+func ConvertOutboxMessageToPresentation(message notification.OutboxMessage) OutboxMessage {
+	converted := OutboxMessage{
+		ID:             message.Id,
+		Kind:           string(message.Kind),
+		MemberId:       message.MemberId,
+		RecipientEmail: message.Message.To.Email,
+		RecipientName:  message.Message.To.Name,
+		Subject:        message.Message.Subject,
+		Body:           message.Message.Body,
+		Status:         string(message.Status),
+		Attempts:       message.Attempts,
+		LastError:      message.LastError,
+		NextAttemptAt:  message.NextAttemptAt.Format(time.RFC3339),
+		CreatedAt:      message.CreatedAt.Format(time.RFC3339),
+	}
+	if message.SentAt != nil {
+		sentAt := message.SentAt.Format(time.RFC3339)
+		converted.SentAt = &sentAt
+	}
+	return converted
+}
+
+func ConvertOutboxMessagesToPresentation(messages []notification.OutboxMessage) []OutboxMessage {
+	converted := make([]OutboxMessage, len(messages))
+	for i, message := range messages {
+		converted[i] = ConvertOutboxMessageToPresentation(message)
+	}
+	return converted
+}
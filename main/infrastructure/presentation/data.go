@@ -4,6 +4,15 @@ type PhoneNumber struct {
 	Number string `json:"number"`
 }
 
+type Guardian struct {
+	MemberId     *int64 `json:"memberId,omitempty"`
+	FirstName    string `json:"firstName"`
+	LastName     string `json:"lastName"`
+	Email        string `json:"email,omitempty"`
+	PhoneNumber  string `json:"phoneNumber,omitempty"`
+	Relationship string `json:"relationship"`
+}
+
 type Address struct {
 	Country      string `json:"country"`
 	City         string `json:"city"`
@@ -12,8 +21,17 @@ type Address struct {
 	ZipCode      string `json:"zipCode"`
 }
 
+// BillingDetails are the data of a member billed as a business, used on their invoices
+type BillingDetails struct {
+	CompanyName   string `json:"companyName"`
+	VATNumber     string `json:"vatNumber"`
+	RecipientCode string `json:"recipientCode,omitempty"` // SdI code, defaults to 0000000
+	PEC           string `json:"pec,omitempty"`
+}
+
 type Payment struct {
 	ID             int64   `json:"id"`
+	Type           string  `json:"type"`
 	Amount         float64 `json:"amount"`
 	Currency       string  `json:"currency"`
 	PaidAt         string  `json:"paidAt"`
@@ -21,15 +39,30 @@ type Payment struct {
 	TransactionRef string  `json:"transactionRef,omitempty"`
 }
 
+// Ledger is the amount due with the payments and refunds settling it
+type Ledger struct {
+	Due          float64   `json:"due"`
+	Paid         float64   `json:"paid"`
+	Refunded     float64   `json:"refunded"`
+	Balance      float64   `json:"balance"`
+	Status       string    `json:"status"`
+	Transactions []Payment `json:"transactions"`
+}
+
 type Membership struct {
-	ID        int64    `json:"id"`
-	Number    int64    `json:"number"`
-	Status    string   `json:"status"`
-	ValidFrom string   `json:"validFrom"`
-	ExpiresAt string   `json:"expiresAt"`
-	PeriodId  *int64   `json:"periodId"`
-	Payment   *Payment `json:"payment"`
-	Price     float64  `json:"price"`
+	ID              int64               `json:"id"`
+	Number          int64               `json:"number"`
+	Status          string              `json:"status"`
+	ValidFrom       string              `json:"validFrom"`
+	ExpiresAt       string              `json:"expiresAt"`
+	PeriodId        *int64              `json:"periodId"`
+	Payment         *Payment            `json:"payment"` // Latest payment received
+	Ledger          Ledger              `json:"ledger"`
+	Price           float64             `json:"price"`
+	Category        *MembershipCategory `json:"category,omitempty"`
+	Paid            bool                `json:"paid"`
+	ExcludedAt      *string             `json:"excludedAt,omitempty"`
+	ExclusionReason string              `json:"exclusionReason,omitempty"`
 }
 
 type BoatInfo struct {
@@ -62,34 +95,52 @@ type RentedFacility struct {
 	RentedAt                string         `json:"rentedAt"`
 	ExpiresAt               string         `json:"expiresAt"`
 	Price                   float64        `json:"price"`
-	Payment                 *Payment       `json:"payment"`
+	Payment                 *Payment       `json:"payment"` // Latest payment received
+	Ledger                  Ledger         `json:"ledger"`
 	BoatInfo                *BoatInfo      `json:"boatInfo"`
 	LeerboardInfo           *LeerboardInfo `json:"leerboardInfo"`
+	Flag                    *RentalFlag    `json:"flag,omitempty"` // Set while the rental is flagged for review
+}
+
+type RentalFlag struct {
+	FlaggedAt string `json:"flaggedAt"`
+	Reason    string `json:"reason"`
 }
 
 type MemberDetails struct {
-	ID           int64         `json:"id"`
-	FirstName    string        `json:"firstName"`
-	LastName     string        `json:"lastName"`
-	Email        string        `json:"email"`
-	BirthDate    string        `json:"birthDate"`
-	TaxCode      string        `json:"taxCode,omitempty"`
-	BirthPlace   *Address      `json:"birthPlace,omitempty"`
-	PhoneNumbers []PhoneNumber `json:"phoneNumbers"`
-	Addresses    []Address     `json:"addresses"`
-	Memberships  []Membership  `json:"memberships"`
+	ID           int64           `json:"id"`
+	FirstName    string          `json:"firstName"`
+	LastName     string          `json:"lastName"`
+	Email        string          `json:"email"`
+	BirthDate    string          `json:"birthDate"`
+	TaxCode      string          `json:"taxCode,omitempty"`
+	Billing      *BillingDetails `json:"billing,omitempty"`
+	BirthPlace   *Address        `json:"birthPlace,omitempty"`
+	PhoneNumbers []PhoneNumber   `json:"phoneNumbers"`
+	Addresses    []Address       `json:"addresses"`
+	Guardians    []Guardian      `json:"guardians"`
+	Consents     []Consent       `json:"consents"`
+	IsMinor      bool            `json:"isMinor"`
+	HouseholdId  *int64          `json:"householdId,omitempty"`
+	Memberships  []Membership    `json:"memberships"`
 }
 
 type Member struct {
-	ID                  int64  `json:"id"`
-	FirstName           string `json:"firstName"`
-	LastName            string `json:"lastName"`
-	BirthDate           string `json:"birthDate"`
-	MembershipNumber    int64  `json:"membershipNumber"`
-	MembershipStatus    string `json:"membershipStatus"`
-	MembershipPaid      bool   `json:"membershipPaid"`
-	HasUnpaidFacilities bool   `json:"hasUnpaidFacilities"`
-	HasRentedFacilities bool   `json:"hasRentedFacilities"`
+	ID                      int64   `json:"id"`
+	FirstName               string  `json:"firstName"`
+	LastName                string  `json:"lastName"`
+	BirthDate               string  `json:"birthDate"`
+	MembershipNumber        int64   `json:"membershipNumber"`
+	MembershipStatus        string  `json:"membershipStatus"`
+	MembershipCategory      string  `json:"membershipCategory,omitempty"`
+	MembershipPaid          bool    `json:"membershipPaid"`
+	MembershipPaymentStatus string  `json:"membershipPaymentStatus,omitempty"`
+	HasUnpaidFacilities     bool    `json:"hasUnpaidFacilities"`
+	HasRentedFacilities     bool    `json:"hasRentedFacilities"`
+	HasFlaggedRentals       bool    `json:"hasFlaggedRentals"`
+	MembershipOverdue       bool    `json:"membershipOverdue"`
+	HasOverdueFacilities    bool    `json:"hasOverdueFacilities"`
+	LateFees                float64 `json:"lateFees"`
 }
 
 type MemberSummary struct {
@@ -122,25 +173,30 @@ type FacilityWithStatus struct {
 }
 
 type CreateMemberRequest struct {
-	FirstName        string        `json:"firstName"`
-	LastName         string        `json:"lastName"`
-	BirthDate        string        `json:"birthDate"`
-	Email            string        `json:"email"`
-	TaxCode          string        `json:"taxCode,omitempty"`
-	BirthPlace       *Address      `json:"birthPlace,omitempty"`
-	PhoneNumbers     []PhoneNumber `json:"phoneNumbers"`
-	Addresses        []Address     `json:"addresses"`
-	CreateMembership bool          `json:"createMembership"`
-	SeasonId         *int64        `json:"seasonId"`
-	Price            *float64      `json:"price"`
+	FirstName        string          `json:"firstName"`
+	LastName         string          `json:"lastName"`
+	BirthDate        string          `json:"birthDate"`
+	Email            string          `json:"email"`
+	TaxCode          string          `json:"taxCode,omitempty"`
+	Billing          *BillingDetails `json:"billing,omitempty"`
+	BirthPlace       *Address        `json:"birthPlace,omitempty"`
+	PhoneNumbers     []PhoneNumber   `json:"phoneNumbers"`
+	Addresses        []Address       `json:"addresses"`
+	Guardians        []Guardian      `json:"guardians"`
+	CreateMembership bool            `json:"createMembership"`
+	SeasonId         *int64          `json:"seasonId"`
+	Price            *float64        `json:"price"`
+	Category         string          `json:"category,omitempty"` // Defaults to ORDINARY
+	Consents         []Consent       `json:"consents"`
 }
 
 type AddMembershipRequest struct {
-	SeasonId       int64   `json:"seasonId"`
-	SeasonStartsAt string  `json:"seasonStartsAt"`
-	SeasonEndsAt   string  `json:"seasonEndsAt"`
-	Price          float64 `json:"price"`
-	MemberId       int64   `json:"memberId"`
+	SeasonId       int64    `json:"seasonId"`
+	SeasonStartsAt string   `json:"seasonStartsAt"`
+	SeasonEndsAt   string   `json:"seasonEndsAt"`
+	Price          *float64 `json:"price"`              // Defaults to the price of the category
+	Category       string   `json:"category,omitempty"` // Defaults to ORDINARY
+	MemberId       int64    `json:"memberId"`
 }
 
 type RentFacilityRequest struct {
@@ -184,12 +240,351 @@ type UpdateLeerboardInfoRequest struct {
 type CreatePaymentRequest struct {
 	MembershipPeriodId *int64  `json:"membershipPeriodId"`
 	RentedFacilityId   *int64  `json:"rentedFacilityId"`
+	Type               string  `json:"type"` // PAYMENT or REFUND, PAYMENT when omitted
 	Amount             float64 `json:"amount"`
 	Currency           string  `json:"currency"`
 	PaymentMethod      string  `json:"paymentMethod"`
 	TransactionRef     *string `json:"transactionRef"`
 }
 
+// PaymentRecord is a payment or refund with the member and what it was made for
+type PaymentRecord struct {
+	Payment
+	Target             string `json:"target"`
+	MembershipPeriodId *int64 `json:"membershipPeriodId,omitempty"`
+	RentedFacilityId   *int64 `json:"rentedFacilityId,omitempty"`
+	FacilityIdentifier string `json:"facilityIdentifier,omitempty"`
+	MemberId           int64  `json:"memberId"`
+	MemberName         string `json:"memberName"`
+	SeasonId           int64  `json:"seasonId"`
+}
+
+type PaymentMethodTotal struct {
+	PaymentMethod string  `json:"paymentMethod"`
+	Count         int     `json:"count"`
+	Paid          float64 `json:"paid"`
+	Refunded      float64 `json:"refunded"`
+	Net           float64 `json:"net"`
+}
+
+type PaymentPage struct {
+	Payments       []PaymentRecord      `json:"payments"`
+	Total          int                  `json:"total"`
+	TotalsByMethod []PaymentMethodTotal `json:"totalsByMethod"`
+	Limit          int                  `json:"limit"`
+	Offset         int                  `json:"offset"`
+}
+
+// CashDay is a day of the cash register (prima nota) with its payments and refunds
+type CashDay struct {
+	Date           string                    `json:"date"`
+	Status         string                    `json:"status"`
+	OpeningBalance float64                   `json:"openingBalance"`
+	ExpectedCash   float64                   `json:"expectedCash"`
+	CountedCash    *float64                  `json:"countedCash"`
+	BankTransfers  *float64                  `json:"bankTransfers"`
+	Notes          string                    `json:"notes,omitempty"`
+	ClosedAt       *string                   `json:"closedAt,omitempty"`
+	TotalsByMethod []PaymentMethodTotal      `json:"totalsByMethod"`
+	Discrepancies  []CashRegisterDiscrepancy `json:"discrepancies"`
+	Transactions   []PaymentRecord           `json:"transactions"`
+}
+
+type CashRegisterDiscrepancy struct {
+	Type       string  `json:"type"`
+	Expected   float64 `json:"expected"`
+	Actual     float64 `json:"actual"`
+	Difference float64 `json:"difference"`
+}
+
+type CashRegisterPeriod struct {
+	From           string               `json:"from"`
+	To             string               `json:"to"`
+	Days           []CashDay            `json:"days"`
+	TotalsByMethod []PaymentMethodTotal `json:"totalsByMethod"`
+	Discrepancies  int                  `json:"discrepancies"`
+}
+
+// CashDayRequest records the values of a day of the cash register, missing values are left unchanged
+type CashDayRequest struct {
+	OpeningBalance *float64 `json:"openingBalance"`
+	CountedCash    *float64 `json:"countedCash"`
+	BankTransfers  *float64 `json:"bankTransfers"`
+	Notes          *string  `json:"notes"`
+}
+
+type BankStatement struct {
+	ID               int64  `json:"id"`
+	FileName         string `json:"fileName"`
+	Format           string `json:"format"`
+	AccountIBAN      string `json:"accountIban,omitempty"`
+	ImportedAt       string `json:"importedAt"`
+	TransactionCount int    `json:"transactionCount"`
+	MatchedCount     int    `json:"matchedCount"`
+}
+
+type BankTransaction struct {
+	ID               int64   `json:"id"`
+	StatementID      int64   `json:"statementId"`
+	Reference        string  `json:"reference,omitempty"`
+	BookingDate      string  `json:"bookingDate"`
+	Amount           float64 `json:"amount"`
+	Currency         string  `json:"currency"`
+	CounterpartyName string  `json:"counterpartyName,omitempty"`
+	CounterpartyIBAN string  `json:"counterpartyIban,omitempty"`
+	RemittanceInfo   string  `json:"remittanceInfo,omitempty"`
+	PaymentID        *int64  `json:"paymentId,omitempty"`
+	Matched          bool    `json:"matched"`
+}
+
+// MatchCandidate is an outstanding membership or rental a bank transaction may pay
+type MatchCandidate struct {
+	Target             string   `json:"target"`
+	MembershipPeriodId *int64   `json:"membershipPeriodId,omitempty"`
+	RentedFacilityId   *int64   `json:"rentedFacilityId,omitempty"`
+	FacilityIdentifier string   `json:"facilityIdentifier,omitempty"`
+	MemberId           int64    `json:"memberId"`
+	MemberName         string   `json:"memberName"`
+	SeasonId           int64    `json:"seasonId"`
+	Due                float64  `json:"due"`
+	Balance            float64  `json:"balance"`
+	Score              int      `json:"score"`
+	Confidence         string   `json:"confidence"`
+	Reasons            []string `json:"reasons"`
+}
+
+type MatchProposal struct {
+	Transaction BankTransaction  `json:"transaction"`
+	Proposed    *MatchCandidate  `json:"proposed,omitempty"`
+	Candidates  []MatchCandidate `json:"candidates"`
+}
+
+type BankStatementDetail struct {
+	BankStatement
+	Proposals []MatchProposal `json:"proposals"`
+}
+
+type BankStatementImport struct {
+	Statement     BankStatementDetail `json:"statement"`
+	Imported      int                 `json:"imported"`
+	Duplicates    int                 `json:"duplicates"`
+	SkippedDebits int                 `json:"skippedDebits"`
+}
+
+type MatchConfirmationRequest struct {
+	BankTransactionId  int64  `json:"bankTransactionId"`
+	MembershipPeriodId *int64 `json:"membershipPeriodId"`
+	RentedFacilityId   *int64 `json:"rentedFacilityId"`
+}
+
+type ConfirmMatchesRequest struct {
+	Matches []MatchConfirmationRequest `json:"matches"`
+}
+
+type MatchOutcome struct {
+	BankTransactionId int64  `json:"bankTransactionId"`
+	PaymentId         *int64 `json:"paymentId,omitempty"`
+	Error             string `json:"error,omitempty"`
+}
+
+type ConfirmMatchesResponse struct {
+	Confirmed int            `json:"confirmed"`
+	Failed    int            `json:"failed"`
+	Outcomes  []MatchOutcome `json:"outcomes"`
+}
+
+type FiscalAddress struct {
+	Street       string `json:"street"`
+	StreetNumber string `json:"streetNumber"`
+	ZipCode      string `json:"zipCode"`
+	City         string `json:"city"`
+	Province     string `json:"province,omitempty"`
+	Country      string `json:"country"`
+}
+
+type FiscalIssuer struct {
+	Name      string        `json:"name"`
+	TaxCode   string        `json:"taxCode,omitempty"`
+	VATNumber string        `json:"vatNumber,omitempty"`
+	Address   FiscalAddress `json:"address"`
+	Email     string        `json:"email,omitempty"`
+}
+
+type FiscalRecipient struct {
+	MemberId      int64         `json:"memberId"`
+	FirstName     string        `json:"firstName"`
+	LastName      string        `json:"lastName"`
+	TaxCode       string        `json:"taxCode,omitempty"`
+	CompanyName   string        `json:"companyName,omitempty"`
+	VATNumber     string        `json:"vatNumber,omitempty"`
+	RecipientCode string        `json:"recipientCode,omitempty"`
+	PEC           string        `json:"pec,omitempty"`
+	Address       FiscalAddress `json:"address"`
+}
+
+type FiscalDocumentLine struct {
+	PaymentId     *int64  `json:"paymentId"` // Null when the payment was removed after the document was issued
+	Description   string  `json:"description"`
+	PaidOn        string  `json:"paidOn"`
+	PaymentMethod string  `json:"paymentMethod"`
+	Amount        float64 `json:"amount"`  // VAT included
+	VATRate       float64 `json:"vatRate"` // Percentage, 0 when out of the scope of VAT
+}
+
+type FiscalDocument struct {
+	ID         int64                `json:"id"`
+	Kind       string               `json:"kind"` // RECEIPT or INVOICE
+	FiscalYear int                  `json:"fiscalYear"`
+	Number     int                  `json:"number"`
+	Code       string               `json:"code"` // Number and fiscal year, such as 12/2026
+	IssuedOn   string               `json:"issuedOn"`
+	Issuer     FiscalIssuer         `json:"issuer"`
+	Recipient  FiscalRecipient      `json:"recipient"`
+	Currency   string               `json:"currency"`
+	Lines      []FiscalDocumentLine `json:"lines"`
+	Total      float64              `json:"total"`
+	Notes      []string             `json:"notes"`
+}
+
+type IssueFiscalDocumentRequest struct {
+	Kind       string  `json:"kind"` // Defaults to RECEIPT
+	PaymentIds []int64 `json:"paymentIds"`
+}
+
+// OutstandingItem is a membership or a rental still to be settled
+type OutstandingItem struct {
+	Target             string  `json:"target"` // MEMBERSHIP or FACILITY
+	MembershipPeriodId *int64  `json:"membershipPeriodId,omitempty"`
+	RentedFacilityId   *int64  `json:"rentedFacilityId,omitempty"`
+	FacilityName       string  `json:"facilityName,omitempty"`
+	FacilityIdentifier string  `json:"facilityIdentifier,omitempty"`
+	Due                float64 `json:"due"`
+	Balance            float64 `json:"balance"`
+}
+
+type ReminderRecipient struct {
+	FirstName string   `json:"firstName"`
+	LastName  string   `json:"lastName"`
+	Email     string   `json:"email,omitempty"`
+	Address   *Address `json:"address"` // Null when the member had no address
+}
+
+type ReminderItem struct {
+	Description string  `json:"description"`
+	Due         float64 `json:"due"`
+	Balance     float64 `json:"balance"`
+}
+
+type ReminderLetter struct {
+	ID         int64             `json:"id"`
+	MemberId   int64             `json:"memberId"`
+	SeasonId   int64             `json:"seasonId"`
+	SeasonCode string            `json:"seasonCode"`
+	Level      string            `json:"level"` // FIRST_REMINDER, SECOND_REMINDER or FORMAL_NOTICE
+	SentOn     string            `json:"sentOn"`
+	Recipient  ReminderRecipient `json:"recipient"`
+	Items      []ReminderItem    `json:"items"`
+	Total      float64           `json:"total"`
+}
+
+// MemberDebt is what a member owes in the season and where their reminders stand
+type MemberDebt struct {
+	MemberId           int64             `json:"memberId"`
+	FirstName          string            `json:"firstName"`
+	LastName           string            `json:"lastName"`
+	TaxCode            string            `json:"taxCode,omitempty"`
+	Items              []OutstandingItem `json:"items"`
+	Total              float64           `json:"total"`
+	LastLevel          string            `json:"lastLevel"` // NONE when no letter was sent yet
+	LastReminderSentOn *string           `json:"lastReminderSentOn"`
+	NextLevel          *string           `json:"nextLevel"`      // Null once the formal notice was sent
+	NextReminderOn     *string           `json:"nextReminderOn"` // Null before the first letter, which can be sent any time
+	ReminderDue        bool              `json:"reminderDue"`
+	LiableToExclusion  bool              `json:"liableToExclusion"`
+	Reminders          []ReminderLetter  `json:"reminders"`
+}
+
+type DunningLevelCount struct {
+	Level   string `json:"level"`
+	Members int    `json:"members"`
+}
+
+type DunningDashboard struct {
+	SeasonId             int64               `json:"seasonId"`
+	SeasonCode           string              `json:"seasonCode"`
+	Date                 string              `json:"date"`
+	DaysBetweenReminders int                 `json:"daysBetweenReminders"`
+	TotalOutstanding     float64             `json:"totalOutstanding"`
+	DebtorCount          int                 `json:"debtorCount"`
+	DueReminderCount     int                 `json:"dueReminderCount"`
+	ByLevel              []DunningLevelCount `json:"byLevel"`
+	Debtors              []MemberDebt        `json:"debtors"`
+}
+
+type LateFeeRule struct {
+	Kind      string  `json:"kind"`   // FIXED or PERCENTAGE
+	Amount    float64 `json:"amount"` // Euros, or percentage points of what is still owed
+	AfterDays int     `json:"afterDays"`
+}
+
+// PaymentDeadline is the day by which the memberships, or the rentals of a facility type, must be paid in a season
+type PaymentDeadline struct {
+	ID               int64        `json:"id"`
+	SeasonId         int64        `json:"seasonId"`
+	Target           string       `json:"target"` // MEMBERSHIP or FACILITY
+	FacilityTypeId   *int64       `json:"facilityTypeId,omitempty"`
+	FacilityTypeName string       `json:"facilityTypeName,omitempty"`
+	DueOn            string       `json:"dueOn"`
+	LateFee          *LateFeeRule `json:"lateFee"` // Null when no fee is charged
+}
+
+type PaymentDeadlineRequest struct {
+	Target         string       `json:"target"`
+	FacilityTypeId *int64       `json:"facilityTypeId"` // Required for FACILITY
+	DueOn          string       `json:"dueOn"`
+	LateFee        *LateFeeRule `json:"lateFee"`
+}
+
+// SetPaymentDeadlinesRequest replaces every deadline of the season, an empty list removes them
+type SetPaymentDeadlinesRequest struct {
+	Deadlines []PaymentDeadlineRequest `json:"deadlines"`
+}
+
+// OverdueItem is a membership or a rental still owed after its deadline
+type OverdueItem struct {
+	MemberId         int64           `json:"memberId"`
+	FirstName        string          `json:"firstName"`
+	LastName         string          `json:"lastName"`
+	Item             OutstandingItem `json:"item"`
+	DueOn            string          `json:"dueOn"`
+	DaysLate         int             `json:"daysLate"`
+	LateFee          float64         `json:"lateFee"`
+	LateFeeChargedOn *string         `json:"lateFeeChargedOn,omitempty"`
+	Total            float64         `json:"total"` // Balance and late fee
+}
+
+// OutboxMessage is an email to a member with where its delivery stands
+type OutboxMessage struct {
+	ID             int64   `json:"id"`
+	Kind           string  `json:"kind"`
+	MemberId       *int64  `json:"memberId"`
+	RecipientEmail string  `json:"recipientEmail"`
+	RecipientName  string  `json:"recipientName"`
+	Subject        string  `json:"subject"`
+	Body           string  `json:"body"`
+	Status         string  `json:"status"` // PENDING, SENT or FAILED
+	Attempts       int     `json:"attempts"`
+	LastError      string  `json:"lastError,omitempty"`
+	NextAttemptAt  string  `json:"nextAttemptAt"`
+	SentAt         *string `json:"sentAt"`
+	CreatedAt      string  `json:"createdAt"`
+}
+
+type SendRemindersRequest struct {
+	SeasonId  int64   `json:"seasonId"`
+	MemberIds []int64 `json:"memberIds"` // Every member whose next letter is due when empty
+}
+
 type UpdatePaymentRequest struct {
 	Amount         float64 `json:"amount"`
 	Currency       string  `json:"currency"`
@@ -226,16 +621,356 @@ type AddToWaitingListRequest struct {
 }
 
 type UpdateMemberRequest struct {
-	FirstName    string        `json:"firstName"`
-	LastName     string        `json:"lastName"`
-	BirthDate    string        `json:"birthDate"`
-	Email        string        `json:"email"`
-	TaxCode      string        `json:"taxCode,omitempty"`
-	BirthPlace   *Address      `json:"birthPlace,omitempty"`
-	PhoneNumbers []PhoneNumber `json:"phoneNumbers"`
-	Addresses    []Address     `json:"addresses"`
+	FirstName    string          `json:"firstName"`
+	LastName     string          `json:"lastName"`
+	BirthDate    string          `json:"birthDate"`
+	Email        string          `json:"email"`
+	TaxCode      string          `json:"taxCode,omitempty"`
+	Billing      *BillingDetails `json:"billing,omitempty"`
+	BirthPlace   *Address        `json:"birthPlace,omitempty"`
+	PhoneNumbers []PhoneNumber   `json:"phoneNumbers"`
+	Addresses    []Address       `json:"addresses"`
+	Guardians    []Guardian      `json:"guardians"`
 }
 
 type UpdatePriceRequest struct {
 	Price float64 `json:"price"`
 }
+
+type Season struct {
+	ID               int64   `json:"id"`
+	Code             string  `json:"code"`
+	Name             string  `json:"name"`
+	StartsAt         string  `json:"startsAt"`
+	EndsAt           string  `json:"endsAt"`
+	ClosedAt         *string `json:"closedAt,omitempty"`
+	IsClosed         bool    `json:"isClosed"`
+	PreemptionEndsAt *string `json:"preemptionEndsAt,omitempty"`
+}
+
+type CreateSeasonRequest struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	StartsAt string `json:"startsAt"`
+	EndsAt   string `json:"endsAt"`
+}
+
+type UpdateSeasonRequest struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	StartsAt string `json:"startsAt"`
+	EndsAt   string `json:"endsAt"`
+}
+
+type SeasonRolloverRequest struct {
+	TargetSeasonID int64 `json:"targetSeasonId"`
+	DryRun         bool  `json:"dryRun"`
+}
+
+type MembershipRenewal struct {
+	MemberID         int64   `json:"memberId"`
+	FirstName        string  `json:"firstName"`
+	LastName         string  `json:"lastName"`
+	MembershipNumber int64   `json:"membershipNumber"`
+	PreviousPrice    float64 `json:"previousPrice"`
+	Price            float64 `json:"price"`
+	Category         string  `json:"category,omitempty"`
+}
+
+type RentalRenewal struct {
+	MemberID           int64   `json:"memberId"`
+	FirstName          string  `json:"firstName"`
+	LastName           string  `json:"lastName"`
+	FacilityID         int64   `json:"facilityId"`
+	FacilityIdentifier string  `json:"facilityIdentifier"`
+	FacilityName       string  `json:"facilityName"`
+	PreviousPrice      float64 `json:"previousPrice"`
+	Price              float64 `json:"price"`
+	DiscountApplied    bool    `json:"discountApplied"`
+	PricingMethod      string  `json:"pricingMethod"`
+}
+
+type SkippedRenewal struct {
+	MemberID  int64  `json:"memberId"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Kind      string `json:"kind"`
+	Reason    string `json:"reason"`
+}
+
+type SeasonRollover struct {
+	SourceSeason Season              `json:"sourceSeason"`
+	TargetSeason Season              `json:"targetSeason"`
+	DryRun       bool                `json:"dryRun"`
+	Memberships  []MembershipRenewal `json:"memberships"`
+	Rentals      []RentalRenewal     `json:"rentals"`
+	Skipped      []SkippedRenewal    `json:"skipped"`
+}
+
+type SetPreemptionWindowRequest struct {
+	PreemptionEndsAt *string `json:"preemptionEndsAt"` // null removes the window
+}
+
+type PreemptionRight struct {
+	FacilityID         int64  `json:"facilityId"`
+	FacilityIdentifier string `json:"facilityIdentifier"`
+	FacilityTypeID     int64  `json:"facilityTypeId"`
+	FacilityName       string `json:"facilityName"`
+	HolderID           int64  `json:"holderId"`
+	HolderFirstName    string `json:"holderFirstName"`
+	HolderLastName     string `json:"holderLastName"`
+	PreviousSeasonID   int64  `json:"previousSeasonId"`
+	ExpiresAt          string `json:"expiresAt"`
+}
+
+type UpdateMembershipStatusRequest struct {
+	Status       string `json:"status"`                 // ACTIVE, SUSPENDED, EXCLUDED or EXPIRED
+	DecisionDate string `json:"decisionDate,omitempty"` // Required when excluding
+	Reason       string `json:"reason,omitempty"`       // Required when excluding
+}
+
+type ExpiredMembership struct {
+	PeriodID         int64  `json:"periodId"`
+	MemberID         int64  `json:"memberId"`
+	FirstName        string `json:"firstName"`
+	LastName         string `json:"lastName"`
+	MembershipNumber int64  `json:"membershipNumber"`
+	SeasonID         int64  `json:"seasonId"`
+}
+
+type FlaggedRental struct {
+	RentedFacilityID   int64  `json:"rentedFacilityId"`
+	MemberID           int64  `json:"memberId"`
+	FacilityIdentifier string `json:"facilityIdentifier"`
+	SeasonID           int64  `json:"seasonId"`
+	Reason             string `json:"reason"`
+}
+
+type ExpiryRun struct {
+	ID                 int64               `json:"id"`
+	Trigger            string              `json:"trigger"`
+	ReferenceDate      string              `json:"referenceDate"`
+	StartedAt          string              `json:"startedAt"`
+	FinishedAt         string              `json:"finishedAt"`
+	ExpiredMemberships []ExpiredMembership `json:"expiredMemberships"`
+	FlaggedRentals     []FlaggedRental     `json:"flaggedRentals"`
+}
+
+type SeasonRentals struct {
+	Season  Season           `json:"season"`
+	Rentals []RentedFacility `json:"rentals"`
+}
+
+// GuardedMember is a member somebody is the legal guardian of
+type GuardedMember struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	BirthDate string `json:"birthDate"`
+}
+
+type MemberDataExport struct {
+	ExportedAt         string             `json:"exportedAt"`
+	Member             MemberDetails      `json:"member"`
+	Rentals            []SeasonRentals    `json:"rentals"`
+	WaitingListEntries []WaitingListEntry `json:"waitingListEntries"`
+	Documents          []MemberDocument   `json:"documents"`
+	ConsentHistory     []Consent          `json:"consentHistory"`
+	GuardianOf         []GuardedMember    `json:"guardianOf"`
+	Payments           []PaymentRecord    `json:"payments"`
+	FiscalDocuments    []FiscalDocument   `json:"fiscalDocuments"`
+	ReminderLetters    []ReminderLetter   `json:"reminderLetters"`
+	Emails             []OutboxMessage    `json:"emails"`
+}
+
+type MemberPage struct {
+	Members []Member `json:"members"`
+	Total   int      `json:"total"`
+	Limit   int      `json:"limit"`
+	Offset  int      `json:"offset"`
+}
+
+type TaxCodeSuggestionRequest struct {
+	FirstName      string `json:"firstName"`
+	LastName       string `json:"lastName"`
+	BirthDate      string `json:"birthDate"`
+	Sex            string `json:"sex"`            // M or F
+	BirthPlaceCode string `json:"birthPlaceCode"` // Cadastral code of the municipality, or Z code of the country
+}
+
+type TaxCodeSuggestion struct {
+	TaxCode string `json:"taxCode"`
+}
+
+type DuplicateMember struct {
+	ID           int64    `json:"id"`
+	FirstName    string   `json:"firstName"`
+	LastName     string   `json:"lastName"`
+	BirthDate    string   `json:"birthDate"`
+	Email        string   `json:"email,omitempty"`
+	TaxCode      string   `json:"taxCode,omitempty"`
+	PhoneNumbers []string `json:"phoneNumbers"`
+}
+
+type DuplicateCandidate struct {
+	First   DuplicateMember `json:"first"`
+	Second  DuplicateMember `json:"second"`
+	Score   int             `json:"score"`
+	Reasons []string        `json:"reasons"`
+}
+
+type MergeMembersRequest struct {
+	SourceMemberId int64 `json:"sourceMemberId"`
+}
+
+type MergeResult struct {
+	SourceMemberID          int64 `json:"sourceMemberId"`
+	TargetMemberID          int64 `json:"targetMemberId"`
+	MovedMembershipPeriods  int   `json:"movedMembershipPeriods"`
+	MergedMembershipPeriods int   `json:"mergedMembershipPeriods"`
+	MovedRentals            int   `json:"movedRentals"`
+	MovedWaitingListEntries int   `json:"movedWaitingListEntries"`
+	MovedPhoneNumbers       int   `json:"movedPhoneNumbers"`
+	MovedAddresses          int   `json:"movedAddresses"`
+	MovedDocuments          int   `json:"movedDocuments"`
+	MovedFiscalDocuments    int   `json:"movedFiscalDocuments"`
+	MovedReminderLetters    int   `json:"movedReminderLetters"`
+}
+
+type HouseholdMember struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	BirthDate string `json:"birthDate"`
+	Email     string `json:"email,omitempty"`
+}
+
+type Household struct {
+	ID      int64             `json:"id"`
+	Name    string            `json:"name"`
+	Members []HouseholdMember `json:"members"`
+}
+
+type CreateHouseholdRequest struct {
+	Name      string  `json:"name"`
+	MemberIds []int64 `json:"memberIds"`
+}
+
+type AddHouseholdMemberRequest struct {
+	MemberId int64 `json:"memberId"`
+}
+
+type HouseholdMemberOverview struct {
+	Member             MemberDetails    `json:"member"`
+	Rentals            []RentedFacility `json:"rentals"`
+	OutstandingBalance float64          `json:"outstandingBalance"`
+}
+
+type HouseholdOverview struct {
+	ID                 int64                     `json:"id"`
+	Name               string                    `json:"name"`
+	SeasonId           int64                     `json:"seasonId"`
+	Members            []HouseholdMemberOverview `json:"members"`
+	OutstandingBalance float64                   `json:"outstandingBalance"`
+}
+
+type FamilyPricingScheme struct {
+	FullPrice             float64 `json:"fullPrice"`
+	AdditionalMemberPrice float64 `json:"additionalMemberPrice"`
+	ChildPrice            float64 `json:"childPrice"`
+	ChildMaxAge           int     `json:"childMaxAge"`
+}
+
+type MembershipPriceSuggestion struct {
+	SuggestedPrice float64 `json:"suggestedPrice"`
+	FullPrice      float64 `json:"fullPrice"`
+	PricingRule    string  `json:"pricingRule"`
+	HouseholdId    *int64  `json:"householdId,omitempty"`
+	PayingMembers  int     `json:"payingMembers"`
+}
+
+type MembershipCategory struct {
+	ID              int64  `json:"id"`
+	Code            string `json:"code"`
+	Name            string `json:"name"`
+	PaymentRequired bool   `json:"paymentRequired"`
+}
+
+type MembershipCategoryPrice struct {
+	Category MembershipCategory `json:"category"`
+	SeasonId int64              `json:"seasonId"`
+	Price    *float64           `json:"price"` // Null when no price is configured for the season
+}
+
+type MembershipCategorySuggestion struct {
+	MembershipCategoryPrice
+	SuggestedPrice float64 `json:"suggestedPrice"`
+	Suggested      bool    `json:"suggested"`
+}
+
+type SetMembershipCategoryPriceRequest struct {
+	SeasonId int64   `json:"seasonId"`
+	Price    float64 `json:"price"`
+}
+
+type MembershipNumberingPolicy struct {
+	Scope             string `json:"scope"` // GLOBAL or SEASON
+	Prefix            string `json:"prefix"`
+	Digits            int    `json:"digits"`
+	ReuseFreedNumbers bool   `json:"reuseFreedNumbers"`
+}
+
+type MemberDocument struct {
+	ID          int64   `json:"id"`
+	MemberID    int64   `json:"memberId"`
+	Type        string  `json:"type"`
+	FileName    string  `json:"fileName"`
+	ContentType string  `json:"contentType"`
+	Size        int64   `json:"size"`
+	IssuedAt    *string `json:"issuedAt,omitempty"`
+	ExpiresAt   *string `json:"expiresAt,omitempty"`
+	Notes       string  `json:"notes,omitempty"`
+	UploadedAt  string  `json:"uploadedAt"`
+}
+
+type UndeletedFile struct {
+	StorageKey string `json:"storageKey"`
+	Reason     string `json:"reason"`
+}
+
+type DocumentFilesDeletion struct {
+	DeletedFiles   int             `json:"deletedFiles"`
+	UndeletedFiles []UndeletedFile `json:"undeletedFiles"`
+}
+
+type CertificateCheck struct {
+	MemberID  int64   `json:"memberId"`
+	FirstName string  `json:"firstName"`
+	LastName  string  `json:"lastName"`
+	Email     *string `json:"email,omitempty"`
+	ExpiresAt *string `json:"expiresAt"` // Null when the member has no certificate
+	Status    string  `json:"status"`    // MISSING or EXPIRED
+}
+
+type Consent struct {
+	Type          string `json:"type"` // PRIVACY_POLICY, PHOTO or MARKETING
+	Granted       bool   `json:"granted"`
+	PolicyVersion string `json:"policyVersion,omitempty"` // Defaults to the current privacy policy
+	RecordedAt    string `json:"recordedAt,omitempty"`
+}
+
+type MemberConsents struct {
+	Current  []Consent `json:"current"`
+	History  []Consent `json:"history"`
+	UpToDate bool      `json:"upToDate"`
+}
+
+type RecordConsentsRequest struct {
+	Consents []Consent `json:"consents"`
+}
+
+type PrivacyPolicy struct {
+	Version     string `json:"version"`
+	Text        string `json:"text"`
+	PublishedAt string `json:"publishedAt,omitempty"`
+}
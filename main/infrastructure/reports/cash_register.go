@@ -0,0 +1,35 @@
+package reports
+
+import (
+	"fmt"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/reports"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+)
+
+// discrepancyText describes a discrepancy of the cash register
+func discrepancyText(discrepancy reports.CashRegisterDiscrepancy) string {
+	label := discrepancy.Type
+	switch payment.DiscrepancyType(discrepancy.Type) {
+	case payment.CashCountDiscrepancy:
+		label = "Contante contato diverso dal previsto"
+	case payment.OpeningBalanceDiscrepancy:
+		label = "Saldo di apertura diverso dalla chiusura precedente"
+	case payment.BankTransfersDiscrepancy:
+		label = "Bonifici in banca diversi da quelli registrati"
+	}
+	difference := discrepancy.Difference.String()
+	if discrepancy.Difference.IsPositive() {
+		difference = "+" + difference
+	}
+	return fmt.Sprintf("%s: previsti %s, registrati %s (%s)", label, discrepancy.Expected, discrepancy.Actual, difference)
+}
+
+// optionalAmount formats an amount that may not have been recorded yet
+func optionalAmount(amount *money.Money) string {
+	if amount == nil {
+		return "-"
+	}
+	return amount.String()
+}
@@ -0,0 +1,67 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/reports"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+)
+
+// utf8BOM lets spreadsheet applications detect the encoding of the accented characters
+const utf8BOM = "\ufeff"
+
+// EncodingCSVGenerator implements CSVGenerator with the standard library
+// Files use semicolons and decimal commas, as expected by spreadsheets with Italian settings
+type EncodingCSVGenerator struct{}
+
+// NewEncodingCSVGenerator creates a new CSV generator
+func NewEncodingCSVGenerator() *EncodingCSVGenerator {
+	return &EncodingCSVGenerator{}
+}
+
+// GenerateCashRegisterCSV exports the payments and refunds of the cash register, one per row
+func (g *EncodingCSVGenerator) GenerateCashRegisterCSV(register reports.CashRegister) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	buf.WriteString(utf8BOM)
+
+	writer := csv.NewWriter(&buf)
+	writer.Comma = ';'
+
+	rows := [][]string{{"Data", "Ora", "Socio", "Causale", "Metodo", "Tipo", "Importo", "Note", "Giornata"}}
+	for _, day := range register.Days {
+		status := "Aperta"
+		if day.Closed {
+			status = "Chiusa"
+		}
+		for _, entry := range day.Entries {
+			entryType := "Pagamento"
+			if entry.Refund {
+				entryType = "Rimborso"
+			}
+			rows = append(rows, []string{
+				day.Date,
+				entry.Time,
+				entry.Member,
+				entry.Description,
+				entry.PaymentMethod,
+				entryType,
+				csvAmount(entry.Amount),
+				entry.Notes,
+				status,
+			})
+		}
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to generate CSV: %w", err)
+	}
+
+	return &buf, nil
+}
+
+func csvAmount(amount money.Money) string {
+	return strings.Replace(amount.Decimal(), ".", ",", 1)
+}
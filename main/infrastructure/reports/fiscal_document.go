@@ -0,0 +1,26 @@
+package reports
+
+import (
+	"strings"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/reports"
+)
+
+// fiscalPartyLines are the lines printed under the name of the issuer or the recipient
+func fiscalPartyLines(party reports.FiscalParty) []string {
+	lines := append([]string{}, party.Address...)
+	identifiers := []string{}
+	if party.TaxCode != "" {
+		identifiers = append(identifiers, "C.F. "+party.TaxCode)
+	}
+	if party.VATNumber != "" {
+		identifiers = append(identifiers, "P.IVA "+party.VATNumber)
+	}
+	if len(identifiers) > 0 {
+		lines = append(lines, strings.Join(identifiers, " - "))
+	}
+	if party.Email != "" {
+		lines = append(lines, party.Email)
+	}
+	return lines
+}
@@ -3,9 +3,12 @@ package reports
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/reports"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 	"github.com/jung-kurt/gofpdf"
 )
 
@@ -41,8 +44,8 @@ func (g *GoPDFGenerator) GenerateMemberListPDF(members []reports.MemberSummary,
 	pdf.SetFillColor(200, 200, 200)
 
 	// Column widths (total should be ~277mm for A4 landscape)
-	colWidths := []float64{30, 70, 35, 80, 30, 32}
-	headers := []string{"N. Tessera", "Nome", "Data di Nascita", "Email", "Pag. Tessera", "Pag. Servizi"}
+	colWidths := []float64{25, 60, 30, 65, 25, 25, 47}
+	headers := []string{"N. Tessera", "Nome", "Data di Nascita", "Email", "Pag. Tessera", "Pag. Servizi", "In Ritardo"}
 
 	for i, header := range headers {
 		pdf.CellFormat(colWidths[i], 8, header, "1", 0, "C", true, 0, "")
@@ -95,6 +98,8 @@ func (g *GoPDFGenerator) GenerateMemberListPDF(members []reports.MemberSummary,
 		}
 		pdf.CellFormat(colWidths[5], 7, facilitiesPaidText, "1", 0, "C", fill, 0, "")
 
+		pdf.CellFormat(colWidths[6], 7, overdueText(member), "1", 0, "C", fill, 0, "")
+
 		pdf.Ln(-1)
 		fill = !fill
 	}
@@ -103,6 +108,7 @@ func (g *GoPDFGenerator) GenerateMemberListPDF(members []reports.MemberSummary,
 	pdf.Ln(5)
 	pdf.SetFont("Arial", "B", 10)
 	pdf.CellFormat(0, 8, fmt.Sprintf("Totale Soci: %d", len(members)), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 8, fmt.Sprintf("Soci in ritardo con i pagamenti: %d", countOverdue(members)), "", 1, "L", false, 0, "")
 
 	// Write to buffer
 	var buf bytes.Buffer
@@ -116,6 +122,11 @@ func (g *GoPDFGenerator) GenerateMemberListPDF(members []reports.MemberSummary,
 
 // GenerateMemberDetailPDF generates a PDF with detailed information about a member
 func (g *GoPDFGenerator) GenerateMemberDetailPDF(member reports.MemberDetail, facilities []reports.FacilityRental, seasonCode string) (*bytes.Buffer, error) {
+	totalPrice, err := reports.TotalPrice(facilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total the facilities: %w", err)
+	}
+
 	pdf := gofpdf.New("P", "mm", "A4", "") // Portrait for detail view
 	pdf.SetFont("Arial", "", 10)
 
@@ -171,6 +182,34 @@ func (g *GoPDFGenerator) GenerateMemberDetailPDF(member reports.MemberDetail, fa
 
 	pdf.Ln(5)
 
+	// Guardians Section
+	if member.IsMinor || len(member.Guardians) > 0 {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.SetFillColor(220, 220, 220)
+		pdf.CellFormat(0, 8, "Tutori", "1", 1, "L", true, 0, "")
+
+		if len(member.Guardians) == 0 {
+			pdf.SetFont("Arial", "I", 9)
+			pdf.CellFormat(0, 7, "Socio minorenne senza tutore registrato", "1", 1, "L", false, 0, "")
+		} else {
+			pdf.SetFont("Arial", "B", 9)
+			pdf.SetFillColor(200, 200, 200)
+			pdf.CellFormat(55, 7, "Nome", "1", 0, "C", true, 0, "")
+			pdf.CellFormat(35, 7, "Relazione", "1", 0, "C", true, 0, "")
+			pdf.CellFormat(60, 7, "Email", "1", 0, "C", true, 0, "")
+			pdf.CellFormat(40, 7, "Telefono", "1", 1, "C", true, 0, "")
+
+			pdf.SetFont("Arial", "", 9)
+			for _, guardian := range member.Guardians {
+				pdf.CellFormat(55, 6, guardian.Name, "1", 0, "L", false, 0, "")
+				pdf.CellFormat(35, 6, guardian.Relationship, "1", 0, "L", false, 0, "")
+				pdf.CellFormat(60, 6, guardian.Email, "1", 0, "L", false, 0, "")
+				pdf.CellFormat(40, 6, guardian.Phone, "1", 1, "L", false, 0, "")
+			}
+		}
+		pdf.Ln(5)
+	}
+
 	// Memberships Section
 	if len(member.Memberships) > 0 {
 		pdf.SetFont("Arial", "B", 12)
@@ -192,13 +231,9 @@ func (g *GoPDFGenerator) GenerateMemberDetailPDF(member reports.MemberDetail, fa
 			pdf.CellFormat(25, 6, membership.Status, "1", 0, "C", false, 0, "")
 			pdf.CellFormat(35, 6, membership.ValidFrom, "1", 0, "C", false, 0, "")
 			pdf.CellFormat(35, 6, membership.ExpiresAt, "1", 0, "C", false, 0, "")
-			pdf.CellFormat(30, 6, fmt.Sprintf("%.2f EUR", membership.Price), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(30, 6, membership.Price.String(), "1", 0, "R", false, 0, "")
 
-			paidText := "Non Pagato"
-			if membership.Paid {
-				paidText = "Pagato"
-			}
-			pdf.CellFormat(35, 6, paidText, "1", 1, "C", false, 0, "")
+			pdf.CellFormat(35, 6, paymentText(membership.Paid, membership.PaymentStatus, membership.Balance), "1", 1, "C", false, 0, "")
 		}
 		pdf.Ln(5)
 	}
@@ -218,31 +253,24 @@ func (g *GoPDFGenerator) GenerateMemberDetailPDF(member reports.MemberDetail, fa
 		pdf.CellFormat(60, 7, "Barca", "1", 1, "C", true, 0, "")
 
 		pdf.SetFont("Arial", "", 9)
-		totalPrice := 0.0
 		for _, facility := range facilities {
 			pdf.CellFormat(30, 6, facility.FacilityIdentifier, "1", 0, "C", false, 0, "")
 			pdf.CellFormat(40, 6, facility.FacilityName, "1", 0, "L", false, 0, "")
-			pdf.CellFormat(30, 6, fmt.Sprintf("%.2f EUR", facility.Price), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(30, 6, facility.Price.String(), "1", 0, "R", false, 0, "")
 
-			paidText := "Non Pagato"
-			if facility.Paid {
-				paidText = "Pagato"
-			}
-			pdf.CellFormat(30, 6, paidText, "1", 0, "C", false, 0, "")
+			pdf.CellFormat(30, 6, paymentText(facility.Paid, facility.PaymentStatus, facility.Balance), "1", 0, "C", false, 0, "")
 
 			boatText := "-"
 			if facility.BoatName != "" {
 				boatText = facility.BoatName
 			}
 			pdf.CellFormat(60, 6, boatText, "1", 1, "L", false, 0, "")
-
-			totalPrice += facility.Price
 		}
 
 		// Total
 		pdf.SetFont("Arial", "B", 9)
 		pdf.CellFormat(70, 7, "Totale:", "1", 0, "R", false, 0, "")
-		pdf.CellFormat(30, 7, fmt.Sprintf("%.2f EUR", totalPrice), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 7, totalPrice.String(), "1", 0, "R", false, 0, "")
 		pdf.CellFormat(90, 7, "", "1", 1, "L", false, 0, "")
 	} else {
 		pdf.SetFont("Arial", "I", 10)
@@ -255,6 +283,483 @@ func (g *GoPDFGenerator) GenerateMemberDetailPDF(member reports.MemberDetail, fa
 	pdf.CellFormat(95, 6, "Data: ___________________________", "", 0, "L", false, 0, "")
 	pdf.CellFormat(95, 6, "Firma: ___________________________", "", 1, "L", false, 0, "")
 
+	// Write to buffer
+	var buf bytes.Buffer
+	err = pdf.Output(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return &buf, nil
+}
+
+// paymentText describes the payment of a membership or a rental, memberships exempt from payment are paid
+// without being settled
+func paymentText(paid bool, status string, balance money.Money) string {
+	switch payment.PaymentStatus(status) {
+	case payment.Paid:
+		return "Pagato"
+	case payment.Overpaid:
+		return "Pagato in eccesso"
+	case payment.Refunded:
+		return "Rimborsato"
+	}
+	if paid {
+		return "Esente"
+	}
+	if payment.PaymentStatus(status) == payment.PartiallyPaid {
+		return "Resta " + balance.String()
+	}
+	return "Non Pagato"
+}
+
+// overdueText tells what the member has not paid by its deadline, with the late fees accrued
+func overdueText(member reports.MemberSummary) string {
+	var overdue []string
+	if member.MembershipOverdue {
+		overdue = append(overdue, "Tessera")
+	}
+	if member.HasOverdueFacilities {
+		overdue = append(overdue, "Servizi")
+	}
+	if len(overdue) == 0 {
+		return "-"
+	}
+	text := strings.Join(overdue, ", ")
+	if member.LateFees.IsPositive() {
+		text += " (mora " + member.LateFees.String() + ")"
+	}
+	return text
+}
+
+func countOverdue(members []reports.MemberSummary) int {
+	count := 0
+	for _, member := range members {
+		if member.MembershipOverdue || member.HasOverdueFacilities {
+			count++
+		}
+	}
+	return count
+}
+
+// GenerateMembershipCardsPDF generates A4 sheets of membership cards, fronts and backs on alternate pages
+func (g *GoPDFGenerator) GenerateMembershipCardsPDF(cards []reports.MembershipCard, seasonCode string) (*bytes.Buffer, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pageWidth, pageHeight := pdf.GetPageSize()
+	marginX := (pageWidth - cardsPerRow*cardWidthMm) / 2
+	marginY := (pageHeight - cardRowsPerSheet*cardHeightMm) / 2
+	position := func(slot cardSlot) (float64, float64) {
+		return marginX + float64(slot.Column)*cardWidthMm, marginY + float64(slot.Row)*cardHeightMm
+	}
+
+	if len(cards) == 0 {
+		pdf.AddPage()
+		pdf.SetFont("Arial", "I", 12)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Nessuna tessera da stampare per la stagione %s", seasonCode), "", 1, "C", false, 0, "")
+	}
+
+	for _, sheet := range cardSheets(cards) {
+		// Fronts
+		pdf.AddPage()
+		for _, slot := range frontSlots(sheet) {
+			x, y := position(slot)
+			drawCardFront(pdf, tr, *slot.Card, x, y)
+		}
+
+		// Backs
+		pdf.AddPage()
+		for _, slot := range backSlots(sheet) {
+			x, y := position(slot)
+			if err := drawCardBack(pdf, tr, *slot.Card, x, y); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Write to buffer
+	var buf bytes.Buffer
+	err := pdf.Output(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return &buf, nil
+}
+
+func drawCardFront(pdf *gofpdf.Fpdf, tr func(string) string, card reports.MembershipCard, x, y float64) {
+	// Cut line
+	pdf.SetDrawColor(180, 180, 180)
+	pdf.Rect(x, y, cardWidthMm, cardHeightMm, "D")
+
+	// Header band
+	pdf.SetFillColor(41, 128, 185)
+	pdf.Rect(x, y, cardWidthMm, 12, "F")
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Arial", "B", 11)
+	pdf.SetXY(x, y+1.5)
+	pdf.CellFormat(cardWidthMm, 5, "CIRCOLO NAUTICO", "", 2, "C", false, 0, "")
+	pdf.SetFont("Arial", "", 8)
+	pdf.CellFormat(cardWidthMm, 4, tr("Tessera Socio - "+card.SeasonName), "", 0, "C", false, 0, "")
+
+	// Member
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Arial", "B", 12)
+	pdf.SetXY(x+4, y+16)
+	pdf.CellFormat(cardWidthMm-8, 6, tr(card.LastName+" "+card.FirstName), "", 2, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 8)
+	pdf.CellFormat(cardWidthMm-8, 4.5, "Nato/a il "+card.BirthDate, "", 2, "L", false, 0, "")
+	if card.Category != "" {
+		pdf.CellFormat(cardWidthMm-8, 4.5, tr("Categoria: "+card.Category), "", 2, "L", false, 0, "")
+	}
+
+	// Card number and validity
+	pdf.SetXY(x+4, y+cardHeightMm-14)
+	pdf.SetFont("Arial", "B", 11)
+	pdf.CellFormat(cardWidthMm-8, 6, tr("N. "+card.CardCode), "", 2, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 7)
+	pdf.CellFormat(cardWidthMm-8, 4, fmt.Sprintf("Valida dal %s al %s", card.ValidFrom, card.ValidUntil), "", 0, "L", false, 0, "")
+}
+
+func drawCardBack(pdf *gofpdf.Fpdf, tr func(string) string, card reports.MembershipCard, x, y float64) error {
+	// Cut line
+	pdf.SetDrawColor(180, 180, 180)
+	pdf.Rect(x, y, cardWidthMm, cardHeightMm, "D")
+
+	// QR code of the member ID
+	qrCode, err := qrCodePNG(card.QRCodeContent())
+	if err != nil {
+		return err
+	}
+	imageName := "qr-" + card.QRCodeContent()
+	options := gofpdf.ImageOptions{ImageType: "PNG"}
+	pdf.RegisterImageOptionsReader(imageName, options, bytes.NewReader(qrCode))
+	pdf.ImageOptions(imageName, x+4, y+(cardHeightMm-34)/2, 34, 34, false, options, 0, "")
+
+	// Details
+	textX := x + 42
+	textWidth := cardWidthMm - 46
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetXY(textX, y+8)
+	pdf.SetFont("Arial", "B", 9)
+	pdf.CellFormat(textWidth, 5, fmt.Sprintf("Socio N. %d", card.MembershipNumber), "", 2, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 7)
+	pdf.CellFormat(textWidth, 4, "Stagione "+card.SeasonCode, "", 2, "L", false, 0, "")
+	pdf.Ln(2)
+	pdf.SetX(textX)
+	pdf.MultiCell(textWidth, 3.5, tr("La tessera è personale e va esibita su richiesta del personale del circolo."), "", "L", false)
+
+	// Signature
+	pdf.SetXY(textX, y+cardHeightMm-12)
+	pdf.CellFormat(textWidth, 4, "_______________________", "", 2, "L", false, 0, "")
+	pdf.CellFormat(textWidth, 3, "Firma del socio", "", 0, "L", false, 0, "")
+
+	return pdf.Error()
+}
+
+// GenerateCashRegisterPDF generates the cash register (prima nota) of a period, one section per day
+func (g *GoPDFGenerator) GenerateCashRegisterPDF(register reports.CashRegister) (*bytes.Buffer, error) {
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	// Header
+	pdf.SetFont("Arial", "B", 16)
+	pdf.CellFormat(0, 10, "Prima Nota - Circolo Nautico", "", 1, "C", false, 0, "")
+
+	pdf.SetFont("Arial", "", 10)
+	subtitle := fmt.Sprintf("Dal %s al %s - Generato il %s", register.From, register.To, time.Now().Format("02/01/2006"))
+	pdf.CellFormat(0, 8, subtitle, "", 1, "C", false, 0, "")
+	pdf.Ln(5)
+
+	if len(register.Days) == 0 {
+		pdf.SetFont("Arial", "I", 10)
+		pdf.CellFormat(0, 8, "Nessun movimento nel periodo", "", 1, "L", false, 0, "")
+	}
+
+	colWidths := []float64{20, 60, 70, 35, 25, 30, 37}
+	headers := []string{"Ora", "Socio", "Causale", "Metodo", "Tipo", "Importo", "Note"}
+
+	for _, day := range register.Days {
+		if pdf.GetY() > 150 {
+			pdf.AddPage()
+		}
+
+		// Day header
+		status := "Aperta"
+		if day.Closed {
+			status = "Chiusa"
+		}
+		pdf.SetFont("Arial", "B", 12)
+		pdf.SetFillColor(220, 220, 220)
+		pdf.CellFormat(0, 8, fmt.Sprintf("Giornata del %s - %s", day.Date, status), "1", 1, "L", true, 0, "")
+
+		pdf.SetFont("Arial", "", 9)
+		pdf.CellFormat(69, 7, "Saldo di apertura: "+day.OpeningBalance.String(), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(69, 7, "Contante previsto: "+day.ExpectedCash.String(), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(69, 7, "Contante contato: "+optionalAmount(day.CountedCash), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(70, 7, "Bonifici in banca: "+optionalAmount(day.BankTransfers), "1", 1, "L", false, 0, "")
+
+		// Entries
+		pdf.SetFont("Arial", "B", 9)
+		pdf.SetFillColor(200, 200, 200)
+		for i, header := range headers {
+			pdf.CellFormat(colWidths[i], 7, header, "1", 0, "C", true, 0, "")
+		}
+		pdf.Ln(-1)
+
+		pdf.SetFont("Arial", "", 8)
+		for _, entry := range day.Entries {
+			if pdf.GetY() > 185 {
+				pdf.AddPage()
+			}
+			entryType := "Pagamento"
+			if entry.Refund {
+				entryType = "Rimborso"
+			}
+			pdf.CellFormat(colWidths[0], 6, entry.Time, "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colWidths[1], 6, tr(entry.Member), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(colWidths[2], 6, tr(entry.Description), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(colWidths[3], 6, tr(entry.PaymentMethod), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(colWidths[4], 6, entryType, "1", 0, "C", false, 0, "")
+			pdf.CellFormat(colWidths[5], 6, entry.Amount.String(), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(colWidths[6], 6, tr(entry.Notes), "1", 1, "L", false, 0, "")
+		}
+
+		// Totals by method
+		pdf.SetFont("Arial", "B", 8)
+		for _, total := range day.Totals {
+			pdf.CellFormat(185, 6, tr(fmt.Sprintf("Totale %s (%d movimenti)", total.PaymentMethod, total.Count)), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(30, 6, total.Net.String(), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(62, 6, "", "1", 1, "L", false, 0, "")
+		}
+
+		// Discrepancies
+		if len(day.Discrepancies) > 0 {
+			pdf.SetTextColor(192, 57, 43)
+			pdf.SetFont("Arial", "B", 9)
+			for _, discrepancy := range day.Discrepancies {
+				pdf.CellFormat(0, 6, discrepancyText(discrepancy), "", 1, "L", false, 0, "")
+			}
+			pdf.SetTextColor(0, 0, 0)
+		}
+
+		if day.Notes != "" {
+			pdf.SetFont("Arial", "I", 9)
+			pdf.MultiCell(0, 6, tr("Note: "+day.Notes), "", "L", false)
+		}
+		pdf.Ln(5)
+	}
+
+	// Totals of the period
+	if len(register.Totals) > 0 {
+		pdf.SetFont("Arial", "B", 12)
+		pdf.SetFillColor(220, 220, 220)
+		pdf.CellFormat(0, 8, "Totali del periodo", "1", 1, "L", true, 0, "")
+
+		pdf.SetFont("Arial", "B", 9)
+		pdf.SetFillColor(200, 200, 200)
+		pdf.CellFormat(70, 7, "Metodo", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(30, 7, "Movimenti", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(40, 7, "Incassato", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(40, 7, "Rimborsato", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(40, 7, "Netto", "1", 1, "C", true, 0, "")
+
+		pdf.SetFont("Arial", "", 9)
+		for _, total := range register.Totals {
+			pdf.CellFormat(70, 6, tr(total.PaymentMethod), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(30, 6, fmt.Sprintf("%d", total.Count), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(40, 6, total.Paid.String(), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(40, 6, total.Refunded.String(), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(40, 6, total.Net.String(), "1", 1, "R", false, 0, "")
+		}
+	}
+
+	pdf.Ln(5)
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(0, 8, fmt.Sprintf("Discrepanze: %d", register.Discrepancies), "", 1, "L", false, 0, "")
+
+	// Write to buffer
+	var buf bytes.Buffer
+	err := pdf.Output(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return &buf, nil
+}
+
+// GenerateFiscalDocumentPDF generates a receipt or an invoice issued for payments
+func (g *GoPDFGenerator) GenerateFiscalDocumentPDF(document reports.FiscalDocument) (*bytes.Buffer, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	// Issuer on the left, number and date on the right
+	top := pdf.GetY()
+	pdf.SetFont("Arial", "B", 13)
+	pdf.CellFormat(110, 7, tr(document.Issuer.Name), "", 2, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 9)
+	for _, line := range fiscalPartyLines(document.Issuer) {
+		pdf.CellFormat(110, 5, tr(line), "", 2, "L", false, 0, "")
+	}
+	bottom := pdf.GetY()
+
+	pdf.SetXY(120, top)
+	pdf.SetFont("Arial", "B", 16)
+	pdf.CellFormat(80, 9, tr(document.Title), "", 2, "R", false, 0, "")
+	pdf.SetFont("Arial", "", 11)
+	pdf.CellFormat(80, 6, "N. "+document.Number, "", 2, "R", false, 0, "")
+	pdf.CellFormat(80, 6, "Data: "+document.IssuedOn, "", 2, "R", false, 0, "")
+	left, _, _, _ := pdf.GetMargins()
+	pdf.SetXY(left, max(bottom, pdf.GetY())+8)
+
+	// Recipient
+	pdf.SetFont("Arial", "B", 11)
+	pdf.SetFillColor(220, 220, 220)
+	pdf.CellFormat(0, 7, "Intestata a", "1", 1, "L", true, 0, "")
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(0, 6, tr(document.Recipient.Name), "LR", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 9)
+	for _, line := range fiscalPartyLines(document.Recipient) {
+		pdf.CellFormat(0, 5, tr(line), "LR", 1, "L", false, 0, "")
+	}
+	pdf.CellFormat(0, 1, "", "LRB", 1, "L", false, 0, "")
+	pdf.Ln(6)
+
+	// Lines
+	colWidths := []float64{95, 30, 35, 30}
+	headers := []string{"Descrizione", "Pagato il", "Metodo", "Importo"}
+	pdf.SetFont("Arial", "B", 9)
+	pdf.SetFillColor(200, 200, 200)
+	for i, header := range headers {
+		pdf.CellFormat(colWidths[i], 7, header, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Arial", "", 9)
+	for _, line := range document.Lines {
+		pdf.CellFormat(colWidths[0], 6, tr(line.Description), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[1], 6, line.PaidOn, "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths[2], 6, tr(line.PaymentMethod), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths[3], 6, line.Amount.String(), "1", 1, "R", false, 0, "")
+	}
+
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(colWidths[0]+colWidths[1]+colWidths[2], 7, "Totale", "1", 0, "R", false, 0, "")
+	pdf.CellFormat(colWidths[3], 7, document.Total.String(), "1", 1, "R", false, 0, "")
+	pdf.Ln(6)
+
+	// Legal notes
+	pdf.SetFont("Arial", "I", 9)
+	for _, note := range document.Notes {
+		pdf.MultiCell(0, 5, tr(note), "", "L", false)
+	}
+
+	pdf.Ln(15)
+	pdf.SetFont("Arial", "", 9)
+	pdf.CellFormat(120, 5, "", "", 0, "L", false, 0, "")
+	pdf.CellFormat(70, 5, "_______________________", "", 2, "C", false, 0, "")
+	pdf.CellFormat(70, 5, tr("Per "+document.Issuer.Name), "", 1, "C", false, 0, "")
+
+	// Write to buffer
+	var buf bytes.Buffer
+	err := pdf.Output(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return &buf, nil
+}
+
+// GenerateReminderLettersPDF generates payment reminder letters, one per page, ready to be printed and mailed
+func (g *GoPDFGenerator) GenerateReminderLettersPDF(letters []reports.ReminderLetter) (*bytes.Buffer, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	left, _, _, _ := pdf.GetMargins()
+
+	for _, letter := range letters {
+		pdf.AddPage()
+
+		// Sender
+		pdf.SetFont("Arial", "B", 13)
+		pdf.CellFormat(0, 7, tr(letter.Sender.Name), "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 9)
+		for _, line := range fiscalPartyLines(letter.Sender) {
+			pdf.CellFormat(0, 5, tr(line), "", 1, "L", false, 0, "")
+		}
+		pdf.Ln(10)
+
+		// Recipient on the right, where the window of the envelope is
+		pdf.SetX(120)
+		pdf.SetFont("Arial", "", 9)
+		pdf.CellFormat(80, 5, "Spett.le", "", 2, "L", false, 0, "")
+		pdf.SetFont("Arial", "B", 11)
+		pdf.CellFormat(80, 6, tr(letter.Recipient.Name), "", 2, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 10)
+		for _, line := range fiscalPartyLines(letter.Recipient) {
+			pdf.CellFormat(80, 5, tr(line), "", 2, "L", false, 0, "")
+		}
+		pdf.SetX(left)
+		pdf.Ln(12)
+
+		date := letter.Date
+		if letter.Place != "" {
+			date = letter.Place + ", " + letter.Date
+		}
+		pdf.SetFont("Arial", "", 10)
+		pdf.CellFormat(0, 6, tr(date), "", 1, "L", false, 0, "")
+		pdf.Ln(4)
+
+		pdf.SetFont("Arial", "B", 14)
+		pdf.CellFormat(0, 8, tr(strings.ToUpper(letter.Title)), "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "B", 10)
+		pdf.MultiCell(0, 6, tr("Oggetto: "+letter.Subject), "", "L", false)
+		pdf.Ln(4)
+
+		pdf.SetFont("Arial", "", 10)
+		for _, paragraph := range letter.Paragraphs {
+			pdf.MultiCell(0, 5.5, tr(paragraph), "", "J", false)
+			pdf.Ln(3)
+		}
+
+		// Items still to be paid
+		colWidths := []float64{110, 40, 40}
+		headers := []string{"Descrizione", "Dovuto", "Da pagare"}
+		pdf.SetFont("Arial", "B", 9)
+		pdf.SetFillColor(200, 200, 200)
+		for i, header := range headers {
+			pdf.CellFormat(colWidths[i], 7, header, "1", 0, "C", true, 0, "")
+		}
+		pdf.Ln(-1)
+
+		pdf.SetFont("Arial", "", 9)
+		for _, line := range letter.Lines {
+			pdf.CellFormat(colWidths[0], 6, tr(line.Description), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(colWidths[1], 6, line.Due.String(), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(colWidths[2], 6, line.Balance.String(), "1", 1, "R", false, 0, "")
+		}
+
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(colWidths[0]+colWidths[1], 7, "Totale da pagare", "1", 0, "R", false, 0, "")
+		pdf.CellFormat(colWidths[2], 7, letter.Total.String(), "1", 1, "R", false, 0, "")
+		pdf.Ln(6)
+
+		pdf.SetFont("Arial", "", 10)
+		for _, paragraph := range letter.Closing {
+			pdf.MultiCell(0, 5.5, tr(paragraph), "", "J", false)
+			pdf.Ln(3)
+		}
+
+		pdf.Ln(12)
+		pdf.SetFont("Arial", "", 9)
+		pdf.CellFormat(120, 5, "", "", 0, "L", false, 0, "")
+		pdf.CellFormat(70, 5, "_______________________", "", 2, "C", false, 0, "")
+		pdf.CellFormat(70, 5, tr("Per "+letter.Sender.Name), "", 1, "C", false, 0, "")
+	}
+
 	// Write to buffer
 	var buf bytes.Buffer
 	err := pdf.Output(&buf)
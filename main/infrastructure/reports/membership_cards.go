@@ -0,0 +1,80 @@
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/reports"
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+)
+
+// Membership cards are ISO ID-1 sized (credit card), ten per A4 sheet
+const (
+	cardWidthMm      = 85.6
+	cardHeightMm     = 54.0
+	cardsPerRow      = 2
+	cardRowsPerSheet = 5
+	cardsPerSheet    = cardsPerRow * cardRowsPerSheet
+	qrCodePixels     = 300
+)
+
+// cardSlot is the position of a card in a sheet, nil cards leave the slot empty
+type cardSlot struct {
+	Card   *reports.MembershipCard
+	Row    int
+	Column int
+}
+
+// cardSheets splits the cards in A4 sheets
+func cardSheets(cards []reports.MembershipCard) [][]reports.MembershipCard {
+	sheets := [][]reports.MembershipCard{}
+	for start := 0; start < len(cards); start += cardsPerSheet {
+		sheets = append(sheets, cards[start:min(start+cardsPerSheet, len(cards))])
+	}
+	return sheets
+}
+
+// frontSlots places the cards of a sheet row by row, left to right
+func frontSlots(sheet []reports.MembershipCard) []cardSlot {
+	slots := make([]cardSlot, len(sheet))
+	for i := range sheet {
+		slots[i] = cardSlot{Card: &sheet[i], Row: i / cardsPerRow, Column: i % cardsPerRow}
+	}
+	return slots
+}
+
+// backSlots mirrors the columns of the fronts, so that each back lands behind its front
+// when the sheet is printed double-sided flipping on the long edge
+func backSlots(sheet []reports.MembershipCard) []cardSlot {
+	slots := frontSlots(sheet)
+	for i := range slots {
+		slots[i].Column = cardsPerRow - 1 - slots[i].Column
+	}
+	return slots
+}
+
+// qrCodePNG encodes the content in a QR code, returned as a PNG image
+func qrCodePNG(content string) ([]byte, error) {
+	code, err := qr.Encode(content, qr.M, qr.Auto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	scaled, err := barcode.Scale(code, qrCodePixels, qrCodePixels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale QR code: %w", err)
+	}
+
+	// Re-encoded as 8-bit grayscale, 16-bit PNGs are not supported by gofpdf
+	gray := image.NewGray(scaled.Bounds())
+	draw.Draw(gray, gray.Bounds(), scaled, scaled.Bounds().Min, draw.Src)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, gray); err != nil {
+		return nil, fmt.Errorf("failed to encode QR code image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -3,6 +3,7 @@ package reports
 import (
 	"bytes"
 	_ "embed"
+	"encoding/base64"
 	"fmt"
 	"html/template"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/reports"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 )
 
 //go:embed templates/member_list.html
@@ -18,6 +20,18 @@ var memberListTemplate string
 //go:embed templates/member_detail.html
 var memberDetailTemplate string
 
+//go:embed templates/membership_cards.html
+var membershipCardsTemplate string
+
+//go:embed templates/cash_register.html
+var cashRegisterTemplate string
+
+//go:embed templates/fiscal_document.html
+var fiscalDocumentTemplate string
+
+//go:embed templates/reminder_letters.html
+var reminderLettersTemplate string
+
 // WkhtmltopdfGenerator implements PDFGenerator using wkhtmltopdf and HTML templates
 type WkhtmltopdfGenerator struct {
 	wkhtmltopdfPath string
@@ -42,6 +56,7 @@ type MemberListTemplateData struct {
 	GeneratedDate string
 	Members       []reports.MemberSummary
 	TotalMembers  int
+	TotalOverdue  int
 }
 
 // MemberDetailTemplateData holds data for the member detail template
@@ -50,7 +65,32 @@ type MemberDetailTemplateData struct {
 	GeneratedDate        string
 	Member               reports.MemberDetail
 	Facilities           []reports.FacilityRental
-	TotalFacilitiesPrice float64
+	TotalFacilitiesPrice money.Money
+}
+
+// MembershipCardsTemplateData holds data for the membership cards template
+type MembershipCardsTemplateData struct {
+	SeasonCode string
+	Sheets     []MembershipCardSheet
+}
+
+// MembershipCardSheet is an A4 sheet of cards, rows of fronts and rows of mirrored backs
+// Nil cards are empty slots
+type MembershipCardSheet struct {
+	Fronts [][]*MembershipCardView
+	Backs  [][]*MembershipCardView
+}
+
+// MembershipCardView is a card with its QR code as a data URL
+type MembershipCardView struct {
+	reports.MembershipCard
+	QRCode template.URL
+}
+
+// CashRegisterTemplateData holds data for the cash register template
+type CashRegisterTemplateData struct {
+	reports.CashRegister
+	GeneratedDate string
 }
 
 // GenerateMemberListPDF generates a PDF with the list of all members using wkhtmltopdf
@@ -61,10 +101,13 @@ func (g *WkhtmltopdfGenerator) GenerateMemberListPDF(members []reports.MemberSum
 		GeneratedDate: time.Now().Format("02/01/2006"),
 		Members:       members,
 		TotalMembers:  len(members),
+		TotalOverdue:  countOverdue(members),
 	}
 
 	// Parse and execute template
-	tmpl, err := template.New("member_list").Parse(memberListTemplate)
+	tmpl, err := template.New("member_list").Funcs(template.FuncMap{
+		"overdue": overdueText,
+	}).Parse(memberListTemplate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template: %w", err)
 	}
@@ -86,9 +129,9 @@ func (g *WkhtmltopdfGenerator) GenerateMemberListPDF(members []reports.MemberSum
 // GenerateMemberDetailPDF generates a PDF with detailed information about a member using wkhtmltopdf
 func (g *WkhtmltopdfGenerator) GenerateMemberDetailPDF(member reports.MemberDetail, facilities []reports.FacilityRental, seasonCode string) (*bytes.Buffer, error) {
 	// Calculate total facilities price
-	totalPrice := 0.0
-	for _, facility := range facilities {
-		totalPrice += facility.Price
+	totalPrice, err := reports.TotalPrice(facilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total the facilities: %w", err)
 	}
 
 	// Prepare template data
@@ -120,6 +163,139 @@ func (g *WkhtmltopdfGenerator) GenerateMemberDetailPDF(member reports.MemberDeta
 	return pdfBuf, nil
 }
 
+// GenerateMembershipCardsPDF generates A4 sheets of membership cards using wkhtmltopdf
+func (g *WkhtmltopdfGenerator) GenerateMembershipCardsPDF(cards []reports.MembershipCard, seasonCode string) (*bytes.Buffer, error) {
+	// Prepare template data
+	data := MembershipCardsTemplateData{SeasonCode: seasonCode, Sheets: []MembershipCardSheet{}}
+	for _, sheet := range cardSheets(cards) {
+		views := make([]MembershipCardView, len(sheet))
+		for i, card := range sheet {
+			qrCode, err := qrCodePNG(card.QRCodeContent())
+			if err != nil {
+				return nil, err
+			}
+			views[i] = MembershipCardView{
+				MembershipCard: card,
+				QRCode:         template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(qrCode)),
+			}
+		}
+		data.Sheets = append(data.Sheets, MembershipCardSheet{
+			Fronts: membershipCardGrid(frontSlots(sheet), views),
+			Backs:  membershipCardGrid(backSlots(sheet), views),
+		})
+	}
+
+	// Parse and execute template
+	tmpl, err := template.New("membership_cards").Parse(membershipCardsTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var htmlBuf bytes.Buffer
+	if err := tmpl.Execute(&htmlBuf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	// Generate PDF from HTML
+	pdfBuf, err := g.generatePDFFromHTML(htmlBuf.String(), "A4", "Portrait")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return pdfBuf, nil
+}
+
+// GenerateCashRegisterPDF generates the cash register (prima nota) of a period using wkhtmltopdf
+func (g *WkhtmltopdfGenerator) GenerateCashRegisterPDF(register reports.CashRegister) (*bytes.Buffer, error) {
+	// Prepare template data
+	data := CashRegisterTemplateData{
+		CashRegister:  register,
+		GeneratedDate: time.Now().Format("02/01/2006"),
+	}
+
+	// Parse and execute template
+	tmpl, err := template.New("cash_register").Funcs(template.FuncMap{
+		"discrepancy": discrepancyText,
+		"amount":      optionalAmount,
+	}).Parse(cashRegisterTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var htmlBuf bytes.Buffer
+	if err := tmpl.Execute(&htmlBuf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	// Generate PDF from HTML
+	pdfBuf, err := g.generatePDFFromHTML(htmlBuf.String(), "A4", "Landscape")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return pdfBuf, nil
+}
+
+// GenerateFiscalDocumentPDF generates a receipt or an invoice issued for payments using wkhtmltopdf
+func (g *WkhtmltopdfGenerator) GenerateFiscalDocumentPDF(document reports.FiscalDocument) (*bytes.Buffer, error) {
+	// Parse and execute template
+	tmpl, err := template.New("fiscal_document").Funcs(template.FuncMap{
+		"party": fiscalPartyLines,
+	}).Parse(fiscalDocumentTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var htmlBuf bytes.Buffer
+	if err := tmpl.Execute(&htmlBuf, document); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	// Generate PDF from HTML
+	pdfBuf, err := g.generatePDFFromHTML(htmlBuf.String(), "A4", "Portrait")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return pdfBuf, nil
+}
+
+// GenerateReminderLettersPDF generates payment reminder letters, one per page, using wkhtmltopdf
+func (g *WkhtmltopdfGenerator) GenerateReminderLettersPDF(letters []reports.ReminderLetter) (*bytes.Buffer, error) {
+	// Parse and execute template
+	tmpl, err := template.New("reminder_letters").Funcs(template.FuncMap{
+		"party": fiscalPartyLines,
+	}).Parse(reminderLettersTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var htmlBuf bytes.Buffer
+	if err := tmpl.Execute(&htmlBuf, letters); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	// Generate PDF from HTML
+	pdfBuf, err := g.generatePDFFromHTML(htmlBuf.String(), "A4", "Portrait")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return pdfBuf, nil
+}
+
+// membershipCardGrid lays out the cards of a sheet in rows, views are in the order of the slots
+func membershipCardGrid(slots []cardSlot, views []MembershipCardView) [][]*MembershipCardView {
+	grid := make([][]*MembershipCardView, (len(slots)+cardsPerRow-1)/cardsPerRow)
+	for i := range grid {
+		grid[i] = make([]*MembershipCardView, cardsPerRow)
+	}
+	for i, slot := range slots {
+		grid[slot.Row][slot.Column] = &views[i]
+	}
+	return grid
+}
+
 // generatePDFFromHTML converts HTML to PDF using wkhtmltopdf
 func (g *WkhtmltopdfGenerator) generatePDFFromHTML(html string, pageSize string, orientation string) (*bytes.Buffer, error) {
 	// Create temporary file for HTML input
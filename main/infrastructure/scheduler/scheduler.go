@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+)
+
+type SchedulerConfig struct {
+	MembershipExpiryInterval time.Duration // Zero disables the membership expiry job
+	EmailOutboxInterval      time.Duration // Zero disables the delivery of the emails, which stay in the outbox
+	LateFeeInterval          time.Duration // Zero leaves the late fees to be charged when the overdue payments are looked at
+}
+
+func NewSchedulerConfig() *SchedulerConfig {
+	return &SchedulerConfig{
+		MembershipExpiryInterval: getDurationEnv("MEMBERSHIP_EXPIRY_INTERVAL", 24*time.Hour),
+		EmailOutboxInterval:      getDurationEnv("EMAIL_OUTBOX_INTERVAL", time.Minute),
+		LateFeeInterval:          getDurationEnv("LATE_FEE_INTERVAL", 24*time.Hour),
+	}
+}
+
+// getDurationEnv parses a duration such as "24h" or "30m", "0" or "off" disable the job
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	if value == "off" {
+		return 0
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("⚠️ Invalid %s %q, using %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      func()
+}
+
+// Scheduler runs registered jobs periodically in background goroutines
+type Scheduler struct {
+	jobs []job
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every registers a job that runs at startup and then once per interval
+// Jobs with a non-positive interval are disabled
+func (s *Scheduler) Every(interval time.Duration, name string, run func()) {
+	if interval <= 0 {
+		log.Printf("⏸️ Job %s disabled", name)
+		return
+	}
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start launches every registered job, they stop when the context is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, j := range s.jobs {
+		go func(j job) {
+			log.Printf("⏱️ Job %s scheduled every %s", j.name, j.interval)
+
+			ticker := time.NewTicker(j.interval)
+			defer ticker.Stop()
+
+			s.runSafely(j)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					s.runSafely(j)
+				}
+			}
+		}(j)
+	}
+}
+
+// runSafely prevents a panicking job from stopping the scheduler
+func (s *Scheduler) runSafely(j job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("❌ Job %s panicked: %v", j.name, r)
+		}
+	}()
+	j.run()
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// DocumentsPath is the directory of the local document storage
+func DocumentsPath() string {
+	return getEnv("DOCUMENTS_PATH", "./data/documents")
+}
+
+// LocalDocumentStorage keeps the documents as files under a root directory
+type LocalDocumentStorage struct {
+	root string
+}
+
+func NewLocalDocumentStorage(root string) (*LocalDocumentStorage, error) {
+	absolute, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid documents path %q: %w", root, err)
+	}
+	if err := os.MkdirAll(absolute, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create documents directory: %w", err)
+	}
+	return &LocalDocumentStorage{root: absolute}, nil
+}
+
+func (s *LocalDocumentStorage) Save(key string, content io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Written to a temporary file first, so that a failed upload never leaves a partial document
+	temp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := io.Copy(temp, content); err != nil {
+		temp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(temp.Name(), path); err != nil {
+		return fmt.Errorf("failed to move file: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalDocumentStorage) Open(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+func (s *LocalDocumentStorage) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path resolves the key under the root, rejecting keys that would escape it
+func (s *LocalDocumentStorage) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, s.root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid document key %q", key)
+	}
+	return path, nil
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"time"
 
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
 	internalHttp "github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/http"
 	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/persistence"
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/scheduler"
 )
 
 func main() {
@@ -17,8 +20,45 @@ func main() {
 	}
 	defer db.Close()
 
-	// Initialize services with database
-	internalHttp.InitializeServices(db)
+	// Initialize services with database, the background jobs use the same instances as the handlers
+	services := internalHttp.InitializeServices(db)
+
+	// Start background jobs
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	schedulerConfig := scheduler.NewSchedulerConfig()
+	jobs := scheduler.NewScheduler()
+	jobs.Every(schedulerConfig.MembershipExpiryInterval, "membership expiry", func() {
+		result := services.Expiry.RunExpiry(time.Now(), club.ScheduledExpiry)
+		if !result.IsSuccess() {
+			log.Printf("❌ Membership expiry failed: %v", result.Error())
+			return
+		}
+		log.Printf("✅ Membership expiry: %d memberships expired, %d rentals flagged",
+			len(result.Value().ExpiredMemberships), len(result.Value().FlaggedRentals))
+	})
+	jobs.Every(schedulerConfig.EmailOutboxInterval, "email outbox", func() {
+		result := services.Notifications.DeliverPending(time.Now())
+		if !result.IsSuccess() {
+			log.Printf("❌ Email delivery failed: %v", result.Error())
+			return
+		}
+		if report := result.Value(); report.Sent+report.Retrying+report.Failed > 0 {
+			log.Printf("✉️ Email delivery: %d sent, %d to retry, %d failed", report.Sent, report.Retrying, report.Failed)
+		}
+	})
+	jobs.Every(schedulerConfig.LateFeeInterval, "late fees", func() {
+		result := services.PaymentDeadlines.ChargeLateFees(time.Now())
+		if !result.IsSuccess() {
+			log.Printf("❌ Late fee charging failed: %v", result.Error())
+			return
+		}
+		if charged := result.Value(); charged > 0 {
+			log.Printf("💶 Late fees: %d charged", charged)
+		}
+	})
+	jobs.Start(ctx)
 
 	mux := internalHttp.NewRouter()
 
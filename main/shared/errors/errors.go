@@ -8,6 +8,10 @@ type PhoneNumberError struct {
 	Description string
 }
 
+type TaxCodeError struct {
+	Description string
+}
+
 type DateError struct {
 	Description string
 }
@@ -24,6 +28,62 @@ type WaitingListError struct {
 	Description string
 }
 
+type SeasonError struct {
+	Description string
+}
+
+type GuardianError struct {
+	Description string
+}
+
+type MergeError struct {
+	Description string
+}
+
+type HouseholdError struct {
+	Description string
+}
+
+type MembershipCategoryError struct {
+	Description string
+}
+
+type MembershipNumberingError struct {
+	Description string
+}
+
+type DocumentError struct {
+	Description string
+}
+
+type ConsentError struct {
+	Description string
+}
+
+type BillingError struct {
+	Description string
+}
+
+type DunningError struct {
+	Description string
+}
+
+type NotificationError struct {
+	Description string
+}
+
+type PaymentError struct {
+	Description string
+}
+
+type MoneyError struct {
+	Description string
+}
+
+type CurrencyMismatchError struct {
+	Description string
+}
+
 type NotFoundError struct {
 	Description string
 }
@@ -40,6 +100,10 @@ func (p PhoneNumberError) Error() string {
 	return p.Description
 }
 
+func (t TaxCodeError) Error() string {
+	return t.Description
+}
+
 func (d DateError) Error() string {
 	return d.Description
 }
@@ -56,6 +120,62 @@ func (w WaitingListError) Error() string {
 	return w.Description
 }
 
+func (s SeasonError) Error() string {
+	return s.Description
+}
+
+func (g GuardianError) Error() string {
+	return g.Description
+}
+
+func (m MergeError) Error() string {
+	return m.Description
+}
+
+func (h HouseholdError) Error() string {
+	return h.Description
+}
+
+func (m MembershipCategoryError) Error() string {
+	return m.Description
+}
+
+func (m MembershipNumberingError) Error() string {
+	return m.Description
+}
+
+func (d DocumentError) Error() string {
+	return d.Description
+}
+
+func (c ConsentError) Error() string {
+	return c.Description
+}
+
+func (b BillingError) Error() string {
+	return b.Description
+}
+
+func (d DunningError) Error() string {
+	return d.Description
+}
+
+func (n NotificationError) Error() string {
+	return n.Description
+}
+
+func (p PaymentError) Error() string {
+	return p.Description
+}
+
+func (m MoneyError) Error() string {
+	return m.Description
+}
+
+func (c CurrencyMismatchError) Error() string {
+	return c.Description
+}
+
 func (n NotFoundError) Error() string {
 	return n.Description
 }
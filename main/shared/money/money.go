@@ -0,0 +1,250 @@
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+)
+
+// Currency is an ISO 4217 currency code
+type Currency string
+
+const EUR Currency = "EUR"
+
+// DefaultCurrency is the currency of the amounts stored or received without one
+const DefaultCurrency = EUR
+
+// minorUnits lists the currencies not divided in cents, every other currency has two decimals
+var minorUnits = map[Currency]int{
+	"JPY": 0,
+	"KRW": 0,
+	"ISK": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"TND": 3,
+}
+
+// ParseCurrency reads a three letter currency code, case insensitive
+func ParseCurrency(code string) result.Result[Currency] {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 || strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return result.Err[Currency](errors.MoneyError{Description: "invalid currency " + strconv.Quote(code) + ", expected a three letter ISO code"})
+	}
+	return result.Ok(Currency(code))
+}
+
+// Decimals is the number of digits after the decimal point of the currency
+func (c Currency) Decimals() int {
+	if decimals, ok := minorUnits[c]; ok {
+		return decimals
+	}
+	return 2
+}
+
+func (c Currency) scale() float64 {
+	return math.Pow10(c.Decimals())
+}
+
+// Money is an amount in the minor units of its currency, cents for euros
+// The zero value has no currency and can be added to an amount of any currency
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// New creates an amount from its minor units
+func New(minorUnits int64, currency Currency) Money {
+	return Money{amount: minorUnits, currency: currency}
+}
+
+func Zero(currency Currency) Money {
+	return Money{currency: currency}
+}
+
+// Euros creates an amount in euros, rounded to the cent
+func Euros(amount float64) Money {
+	return FromFloat(amount, EUR)
+}
+
+// FromFloat creates an amount rounded half away from zero to the minor unit of the currency
+func FromFloat(amount float64, currency Currency) Money {
+	return Money{amount: int64(math.Round(amount * currency.scale())), currency: currency}
+}
+
+// ExactFromFloat creates an amount, MoneyError when it is finer than the minor unit of the currency
+func ExactFromFloat(amount float64, currency Currency) result.Result[Money] {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return result.Err[Money](errors.MoneyError{Description: "invalid amount"})
+	}
+	scaled := amount * currency.scale()
+	if math.Abs(scaled-math.Round(scaled)) > 1e-6 {
+		return result.Err[Money](errors.MoneyError{Description: fmt.Sprintf("%v %s has more than %d decimals", amount, currency, currency.Decimals())})
+	}
+	return result.Ok(FromFloat(amount, currency))
+}
+
+// Sum adds up the amounts, CurrencyMismatchError when one is not in the given currency
+func Sum(currency Currency, amounts ...Money) result.Result[Money] {
+	total := Zero(currency)
+	for _, amount := range amounts {
+		if err := total.checkCurrency(amount); err != nil {
+			return result.Err[Money](err)
+		}
+		total = Money{amount: total.amount + amount.amount, currency: total.currencyWith(amount)}
+	}
+	return result.Ok(total)
+}
+
+// MinorUnits is the amount in cents, or in the minor unit of the currency
+func (m Money) MinorUnits() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// Float64 is the amount in units of the currency, only for display and serialisation
+func (m Money) Float64() float64 {
+	return float64(m.amount) / m.currency.scale()
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// SameCurrency tells whether the amounts can be added or compared, the zero value goes with any currency
+func (m Money) SameCurrency(other Money) bool {
+	return m.currency == other.currency || m.isUnset() || other.isUnset()
+}
+
+// Add sums the amounts, CurrencyMismatchError when their currencies differ
+func (m Money) Add(other Money) result.Result[Money] {
+	if err := m.checkCurrency(other); err != nil {
+		return result.Err[Money](err)
+	}
+	return result.Ok(Money{amount: m.amount + other.amount, currency: m.currencyWith(other)})
+}
+
+// Subtract takes the other amount off, CurrencyMismatchError when their currencies differ
+func (m Money) Subtract(other Money) result.Result[Money] {
+	return m.Add(other.Negate())
+}
+
+func (m Money) Negate() Money {
+	return Money{amount: -m.amount, currency: m.currency}
+}
+
+// Multiply scales the amount, rounding half away from zero to the minor unit
+func (m Money) Multiply(factor float64) Money {
+	return Money{amount: int64(math.Round(float64(m.amount) * factor)), currency: m.currency}
+}
+
+// Percentage is the given percent of the amount, rounded half away from zero to the minor unit
+func (m Money) Percentage(percent float64) Money {
+	return m.Multiply(percent / 100)
+}
+
+// Allocate splits the amount in equal parts without losing cents, the first parts take the remainder
+func (m Money) Allocate(parts int) []Money {
+	if parts <= 0 {
+		return []Money{}
+	}
+	share := m.amount / int64(parts)
+	remainder := m.amount % int64(parts)
+	allocated := make([]Money, parts)
+	for i := range allocated {
+		allocated[i] = Money{amount: share, currency: m.currency}
+		if remainder > 0 {
+			allocated[i].amount++
+			remainder--
+		} else if remainder < 0 {
+			allocated[i].amount--
+			remainder++
+		}
+	}
+	return allocated
+}
+
+// Compare is -1, 0 or 1 when the amount is less than, equal to or greater than the other, CurrencyMismatchError when their currencies differ
+func (m Money) Compare(other Money) result.Result[int] {
+	if err := m.checkCurrency(other); err != nil {
+		return result.Err[int](err)
+	}
+	switch {
+	case m.amount < other.amount:
+		return result.Ok(-1)
+	case m.amount > other.amount:
+		return result.Ok(1)
+	}
+	return result.Ok(0)
+}
+
+// LessThan tells whether the amount is less than the other, false when their currencies differ
+func (m Money) LessThan(other Money) bool {
+	return m.SameCurrency(other) && m.amount < other.amount
+}
+
+// Equal tells whether the amounts are the same, false when their currencies differ
+func (m Money) Equal(other Money) bool {
+	return m.SameCurrency(other) && m.amount == other.amount
+}
+
+// Decimal writes the amount with the decimals of its currency and a dot, as stored in NUMERIC columns
+func (m Money) Decimal() string {
+	decimals := m.currency.Decimals()
+	if decimals == 0 {
+		return strconv.FormatInt(m.amount, 10)
+	}
+	sign := ""
+	amount := m.amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	scale := int64(m.currency.scale())
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/scale, decimals, amount%scale)
+}
+
+func (m Money) String() string {
+	if m.currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + string(m.currency)
+}
+
+// Value stores the amount in a NUMERIC column, its currency goes in a column of its own
+func (m Money) Value() (driver.Value, error) {
+	return m.Decimal(), nil
+}
+
+func (m Money) isUnset() bool {
+	return m.currency == "" && m.amount == 0
+}
+
+func (m Money) currencyWith(other Money) Currency {
+	if m.currency == "" {
+		return other.currency
+	}
+	return m.currency
+}
+
+func (m Money) checkCurrency(other Money) error {
+	if !m.SameCurrency(other) {
+		return errors.CurrencyMismatchError{Description: fmt.Sprintf("cannot combine %s with %s", other, m)}
+	}
+	return nil
+}
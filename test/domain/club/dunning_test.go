@@ -0,0 +1,202 @@
+package club_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func outstandingItem(memberId int64, firstName string, lastName string, balance money.Money) payment.OutstandingItem {
+	return payment.OutstandingItem{
+		Target:    payment.MembershipTarget,
+		MemberId:  memberId,
+		FirstName: firstName,
+		LastName:  lastName,
+		Due:       balance,
+		Balance:   balance,
+	}
+}
+
+func reminder(memberId int64, level club.DunningLevel, sentOn time.Time) club.ReminderLetter {
+	return club.ReminderLetter{MemberId: domain.NewId[membership.Member](memberId), Level: level, SentOn: sentOn}
+}
+
+func debtWith(levels ...club.DunningLevel) club.MemberDebt {
+	reminders := []club.ReminderLetter{}
+	for i, level := range levels {
+		reminders = append(reminders, club.ReminderLetter{Level: level, SentOn: date(2026, 5, 1+15*i)})
+	}
+	return club.MemberDebt{
+		FirstName: "Mario",
+		LastName:  "Rossi",
+		Items:     []payment.OutstandingItem{outstandingItem(1, "Mario", "Rossi", money.Euros(100))},
+		Reminders: reminders,
+	}
+}
+
+func TestNewMemberDebts_GroupsItemsByMemberSortedByName(t *testing.T) {
+	// Arrange
+	items := []payment.OutstandingItem{
+		outstandingItem(2, "Luigi", "Verdi", money.Euros(50)),
+		outstandingItem(1, "Mario", "Rossi", money.Euros(100)),
+		outstandingItem(2, "Luigi", "Verdi", money.Euros(30.1)),
+		outstandingItem(3, "Anna", "rossi", money.Euros(20)),
+	}
+	reminders := []club.ReminderLetter{
+		reminder(2, club.SecondReminder, date(2026, 5, 16)),
+		reminder(2, club.FirstReminder, date(2026, 5, 1)),
+		reminder(4, club.FirstReminder, date(2026, 5, 1)),
+	}
+
+	// Act
+	debts := club.NewMemberDebts(items, reminders)
+
+	// Assert
+	assert.Len(t, debts, 3)
+	assert.Equal(t, "Anna", debts[0].FirstName)
+	assert.Equal(t, "Mario", debts[1].FirstName)
+	assert.Equal(t, "Luigi", debts[2].FirstName)
+	assert.Equal(t, money.Euros(80.1), debts[2].Total())
+	assert.Len(t, debts[2].Reminders, 2)
+	assert.Equal(t, club.FirstReminder, debts[2].Reminders[0].Level)
+	assert.Equal(t, club.SecondReminder, debts[2].LastLevel())
+	assert.Empty(t, debts[0].Reminders)
+}
+
+func TestMemberDebt_NextLevel(t *testing.T) {
+	testCases := []struct {
+		name     string
+		debt     club.MemberDebt
+		expected *club.DunningLevel
+	}{
+		{"never reminded", debtWith(), &club.DunningLevels[0]},
+		{"after the first reminder", debtWith(club.FirstReminder), &club.DunningLevels[1]},
+		{"after the second reminder", debtWith(club.FirstReminder, club.SecondReminder), &club.DunningLevels[2]},
+		{"after the formal notice", debtWith(club.FirstReminder, club.SecondReminder, club.FormalNotice), nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Assert
+			assert.Equal(t, tc.expected, tc.debt.NextLevel())
+		})
+	}
+}
+
+func TestMemberDebt_IsReminderDue(t *testing.T) {
+	// Arrange
+	paid := debtWith()
+	paid.Items[0].Balance = money.Zero(money.EUR)
+
+	testCases := []struct {
+		name     string
+		debt     club.MemberDebt
+		today    time.Time
+		expected bool
+	}{
+		{"first reminder any time", debtWith(), date(2026, 5, 1), true},
+		{"before the interval has passed", debtWith(club.FirstReminder), date(2026, 5, 15), false},
+		{"on the last day of the interval", debtWith(club.FirstReminder), date(2026, 5, 16), true},
+		{"after the formal notice", debtWith(club.FirstReminder, club.SecondReminder, club.FormalNotice), date(2026, 12, 31), false},
+		{"nothing left to pay", paid, date(2026, 5, 1), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Assert
+			assert.Equal(t, tc.expected, tc.debt.IsReminderDue(tc.today, 15))
+		})
+	}
+}
+
+func TestMemberDebt_IsLiableToExclusion(t *testing.T) {
+	// Arrange
+	noticed := debtWith(club.FirstReminder, club.SecondReminder, club.FormalNotice) // Formal notice sent on 2026-05-31
+
+	// Assert
+	assert.False(t, noticed.IsLiableToExclusion(date(2026, 6, 14), 15))
+	assert.True(t, noticed.IsLiableToExclusion(date(2026, 6, 15), 15))
+	assert.False(t, debtWith(club.FirstReminder, club.SecondReminder).IsLiableToExclusion(date(2026, 12, 31), 15))
+}
+
+func TestNewReminderLetter(t *testing.T) {
+	// Arrange
+	season := club.Season{ID: 7, Code: "2026"}
+	recipient := club.ReminderRecipient{FirstName: "Mario", LastName: "Rossi"}
+	debt := debtWith(club.FirstReminder)
+	debt.Items = append(debt.Items, payment.OutstandingItem{
+		Target:             payment.FacilityTarget,
+		MemberId:           1,
+		FacilityName:       "Posto barca",
+		FacilityIdentifier: "A12",
+		Due:                money.Euros(300),
+		Balance:            money.Euros(150),
+	})
+
+	// Act
+	letter := club.NewReminderLetter(debt, season, recipient, date(2026, 5, 20), 15)
+
+	// Assert
+	assert.True(t, letter.IsSuccess())
+	assert.Equal(t, club.SecondReminder, letter.Value().Level)
+	assert.Equal(t, int64(7), letter.Value().SeasonId)
+	assert.Equal(t, "Quota associativa - stagione 2026", letter.Value().Items[0].Description)
+	assert.Equal(t, "Posto barca A12 - stagione 2026", letter.Value().Items[1].Description)
+	assert.Equal(t, money.Euros(250), letter.Value().Total())
+}
+
+func TestNewReminderLetter_Fails(t *testing.T) {
+	// Arrange
+	season := club.Season{ID: 7, Code: "2026"}
+
+	testCases := []struct {
+		name  string
+		debt  club.MemberDebt
+		today time.Time
+	}{
+		{"too early", debtWith(club.FirstReminder), date(2026, 5, 10)},
+		{"formal notice already sent", debtWith(club.FirstReminder, club.SecondReminder, club.FormalNotice), date(2026, 12, 31)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			letter := club.NewReminderLetter(tc.debt, season, club.ReminderRecipient{}, tc.today, 15)
+
+			// Assert
+			assert.False(t, letter.IsSuccess())
+			assert.IsType(t, errors.DunningError{}, letter.Error())
+		})
+	}
+}
+
+func TestDunningDashboard(t *testing.T) {
+	// Arrange
+	dashboard := club.DunningDashboard{
+		Today:                date(2026, 5, 10),
+		DaysBetweenReminders: 15,
+		Debtors: []club.MemberDebt{
+			debtWith(),
+			debtWith(club.FirstReminder),
+			debtWith(club.FirstReminder, club.SecondReminder, club.FormalNotice),
+		},
+	}
+
+	// Act
+	counts := dashboard.CountByLevel()
+
+	// Assert
+	assert.Equal(t, money.Euros(300), dashboard.TotalOutstanding())
+	assert.Equal(t, 1, counts[club.NoReminder])
+	assert.Equal(t, 1, counts[club.FirstReminder])
+	assert.Equal(t, 0, counts[club.SecondReminder])
+	assert.Equal(t, 1, counts[club.FormalNotice])
+	assert.Len(t, dashboard.DueReminders(), 1)
+}
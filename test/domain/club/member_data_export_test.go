@@ -0,0 +1,218 @@
+package club_test
+
+import (
+	"testing"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
+	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/notification"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+// exportMemberRepository returns the member with the memberships held in each season
+type exportMemberRepository struct {
+	membership.MemberRepository
+	user                membership.User
+	membershipsBySeason map[int64][]membership.Membership
+}
+
+func (r *exportMemberRepository) GetMemberById(id domain.Id[membership.Member], season int64) result.Result[membership.MemberDetails] {
+	if id.Value != r.user.Id.Value {
+		return result.Err[membership.MemberDetails](errors.NotFoundError{Description: "Member not found"})
+	}
+	return result.Ok(membership.MemberDetails{User: r.user, Memberships: r.membershipsBySeason[season]})
+}
+
+func (r *exportMemberRepository) GetGuardedMembers(id domain.Id[membership.Member]) result.Result[[]membership.User] {
+	return result.Ok([]membership.User{{Id: domain.NewId[membership.User](3), FirstName: "Luca", LastName: "Rossi"}})
+}
+
+// exportFacilityRepository implements only the FacilityRepository methods used by the export
+type exportFacilityRepository struct {
+	facilityrental.FacilityRepository
+	rentalsBySeason map[int64][]facilityrental.RentedFacility
+}
+
+func (r *exportFacilityRepository) GetFacilitiesCatalog() []facilityrental.FacilityType {
+	return []facilityrental.FacilityType{boxType, rackType}
+}
+
+func (r *exportFacilityRepository) GetFacilitiesRentedByMember(memberId domain.Id[membership.User], season int64) []facilityrental.RentedFacility {
+	return r.rentalsBySeason[season]
+}
+
+// exportWaitingListRepository holds the member in the waiting list of the given facility types
+type exportWaitingListRepository struct {
+	facilityrental.WaitingListRepository
+	queuedFor []domain.Id[facilityrental.FacilityType]
+}
+
+func (r *exportWaitingListRepository) GetMemberEntry(
+	facilityType domain.Id[facilityrental.FacilityType],
+	memberId domain.Id[membership.Member],
+) result.Result[facilityrental.WaitingListEntry] {
+	for _, queued := range r.queuedFor {
+		if queued == facilityType {
+			return result.Ok(facilityrental.WaitingListEntry{MemberId: memberId, FacilityType: facilityType})
+		}
+	}
+	return result.Err[facilityrental.WaitingListEntry](errors.NotFoundError{Description: "entry not found"})
+}
+
+// exportDocumentRepository holds a medical certificate of the member
+type exportDocumentRepository struct {
+	membership.MemberDocumentRepository
+}
+
+func (r *exportDocumentRepository) GetDocuments(userId domain.Id[membership.User]) result.Result[[]membership.MemberDocument] {
+	return result.Ok([]membership.MemberDocument{{UserId: userId, Type: membership.MedicalCertificateDocument, FileName: "certificate.pdf"}})
+}
+
+// exportRecordsRepository holds the consents, payments and correspondence of the member
+type exportRecordsRepository struct {
+	membership.ConsentRepository
+	payment.PaymentRepository
+	club.DunningRepository
+	notification.OutboxRepository
+	payments       int
+	searchCriteria []payment.PaymentSearchCriteria
+}
+
+func (r *exportRecordsRepository) GetConsentHistory(userId domain.Id[membership.User]) result.Result[[]membership.Consent] {
+	return result.Ok([]membership.Consent{
+		{Type: membership.MarketingConsent, Granted: true},
+		{Type: membership.MarketingConsent, Granted: false},
+	})
+}
+
+// SearchPayments pages through the configured number of payments
+func (r *exportRecordsRepository) SearchPayments(criteria payment.PaymentSearchCriteria) result.Result[payment.PaymentPage] {
+	r.searchCriteria = append(r.searchCriteria, criteria)
+	records := []payment.PaymentRecord{}
+	for i := criteria.Offset; i < r.payments && i < criteria.Offset+criteria.Limit; i++ {
+		records = append(records, payment.PaymentRecord{MemberId: *criteria.MemberId})
+	}
+	return result.Ok(payment.PaymentPage{Payments: records, Total: r.payments, Limit: criteria.Limit, Offset: criteria.Offset})
+}
+
+func (r *exportRecordsRepository) GetReminders(criteria club.ReminderCriteria) result.Result[[]club.ReminderLetter] {
+	return result.Ok([]club.ReminderLetter{{MemberId: domain.NewId[membership.Member](*criteria.MemberId), SeasonId: 1}})
+}
+
+func (r *exportRecordsRepository) GetMessages(criteria notification.OutboxCriteria) result.Result[[]notification.OutboxMessage] {
+	return result.Ok([]notification.OutboxMessage{{MemberId: criteria.MemberId, Status: notification.Sent}})
+}
+
+// exportFiscalDocumentRepository holds a receipt issued to the member
+type exportFiscalDocumentRepository struct {
+	payment.FiscalDocumentRepository
+}
+
+func (r *exportFiscalDocumentRepository) GetDocuments(criteria payment.FiscalDocumentCriteria) result.Result[[]payment.FiscalDocument] {
+	return result.Ok([]payment.FiscalDocument{{Kind: payment.ReceiptDocument, Number: 1}})
+}
+
+func newExportService(memberRepository *exportMemberRepository, facilityRepository *exportFacilityRepository, waitingListRepository *exportWaitingListRepository) *club.MemberDataExportService {
+	return newExportServiceWithRecords(memberRepository, facilityRepository, waitingListRepository, &exportRecordsRepository{})
+}
+
+func newExportServiceWithRecords(
+	memberRepository *exportMemberRepository,
+	facilityRepository *exportFacilityRepository,
+	waitingListRepository *exportWaitingListRepository,
+	records *exportRecordsRepository,
+) *club.MemberDataExportService {
+	seasons := &inMemorySeasonRepository{seasons: []club.Season{
+		{ID: 1, Code: "2025", StartsAt: date(2025, 1, 1), EndsAt: date(2025, 12, 31)},
+		{ID: 2, Code: "2026", StartsAt: date(2026, 1, 1), EndsAt: date(2026, 12, 31)},
+	}}
+	return club.NewMemberDataExportService(
+		memberRepository,
+		seasons,
+		facilityRepository,
+		waitingListRepository,
+		&exportDocumentRepository{},
+		records,
+		records,
+		&exportFiscalDocumentRepository{},
+		records,
+		records,
+	)
+}
+
+func TestMemberDataExportService_ExportMemberData(t *testing.T) {
+	// Arrange
+	user := membership.User{Id: domain.NewId[membership.User](1), FirstName: "Mario", LastName: "Rossi"}
+	memberRepository := &exportMemberRepository{user: user, membershipsBySeason: map[int64][]membership.Membership{
+		1: {{Number: 42, Status: membership.Expired{PeriodId: 10}, Price: money.Euros(130)}},
+		2: {{Number: 42, Status: membership.Active{PeriodId: 11}, Price: money.Euros(150)}},
+	}}
+	facilityRepository := &exportFacilityRepository{rentalsBySeason: map[int64][]facilityrental.RentedFacility{
+		2: {rental(10, "B-10", boxType, 200)},
+	}}
+	waitingListRepository := &exportWaitingListRepository{queuedFor: []domain.Id[facilityrental.FacilityType]{rackType.Id}}
+	service := newExportService(memberRepository, facilityRepository, waitingListRepository)
+
+	// Act
+	export := service.ExportMemberData(domain.NewId[membership.Member](1))
+
+	// Assert
+	assert.True(t, export.IsSuccess())
+	assert.Equal(t, user, export.Value().Member.User)
+	assert.False(t, export.Value().ExportedAt.IsZero())
+
+	periods := []int64{}
+	for _, membership := range export.Value().Member.Memberships {
+		periods = append(periods, *membership.Status.GetPeriodId())
+	}
+	assert.Equal(t, []int64{10, 11}, periods, "memberships of every season")
+
+	assert.Len(t, export.Value().Rentals, 1, "seasons without rentals are left out")
+	assert.Equal(t, "2026", export.Value().Rentals[0].Season.Code)
+	assert.Equal(t, "B-10", export.Value().Rentals[0].Rentals[0].GetFacility().Identifier)
+
+	assert.Len(t, export.Value().WaitingListEntries, 1)
+	assert.Equal(t, rackType.Id, export.Value().WaitingListEntries[0].FacilityType)
+}
+
+func TestMemberDataExportService_ExportMemberData_Records(t *testing.T) {
+	// Arrange
+	user := membership.User{Id: domain.NewId[membership.User](1), FirstName: "Mario", LastName: "Rossi"}
+	memberRepository := &exportMemberRepository{user: user}
+	records := &exportRecordsRepository{payments: payment.MaxPaymentSearchLimit + 5}
+	service := newExportServiceWithRecords(memberRepository, &exportFacilityRepository{}, &exportWaitingListRepository{}, records)
+
+	// Act
+	export := service.ExportMemberData(domain.NewId[membership.Member](1))
+
+	// Assert
+	assert.True(t, export.IsSuccess())
+	assert.Len(t, export.Value().Documents, 1)
+	assert.Len(t, export.Value().ConsentHistory, 2, "every consent ever recorded")
+	assert.Len(t, export.Value().GuardianOf, 1)
+	assert.Len(t, export.Value().Payments, payment.MaxPaymentSearchLimit+5, "every page of payments")
+	assert.Len(t, records.searchCriteria, 2)
+	assert.Equal(t, int64(1), *records.searchCriteria[1].MemberId)
+	assert.Len(t, export.Value().FiscalDocuments, 1)
+	assert.Len(t, export.Value().ReminderLetters, 1)
+	assert.Len(t, export.Value().Emails, 1)
+}
+
+func TestMemberDataExportService_ExportMemberData_NotFound(t *testing.T) {
+	// Arrange
+	memberRepository := &exportMemberRepository{user: membership.User{Id: domain.NewId[membership.User](1)}}
+	service := newExportService(memberRepository, &exportFacilityRepository{}, &exportWaitingListRepository{})
+
+	// Act
+	export := service.ExportMemberData(domain.NewId[membership.Member](2))
+
+	// Assert
+	assert.False(t, export.IsSuccess())
+	assert.IsType(t, errors.NotFoundError{}, export.Error())
+}
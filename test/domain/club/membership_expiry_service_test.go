@@ -0,0 +1,175 @@
+package club_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+type expiringPeriod struct {
+	club.ExpiredMembership
+	status         membership.MembershipStatus
+	seasonEndsAt   time.Time
+	rentedFacility string
+}
+
+// fakeExpiryRepository expires periods kept in memory the way the SQL repository does
+type fakeExpiryRepository struct {
+	periods []expiringPeriod
+	runs    []club.ExpiryRun
+	running bool
+}
+
+func (r *fakeExpiryRepository) ExpireMemberships(date time.Time, trigger club.ExpiryTrigger, startedAt time.Time) result.Result[club.ExpiryRun] {
+	if r.running {
+		return result.Err[club.ExpiryRun](errors.MembershipStatusError{Description: "membership expiry is already running"})
+	}
+
+	run := club.ExpiryRun{
+		Id:                 int64(len(r.runs) + 1),
+		Trigger:            trigger,
+		ReferenceDate:      date,
+		StartedAt:          startedAt,
+		FinishedAt:         startedAt,
+		ExpiredMemberships: []club.ExpiredMembership{},
+		FlaggedRentals:     []club.FlaggedRental{},
+	}
+	for i, period := range r.periods {
+		ended := period.seasonEndsAt.Before(date)
+		expirable := period.status == membership.MembershipStatusActive || period.status == membership.MembershipStatusSuspended
+		if !ended || !expirable {
+			continue
+		}
+		r.periods[i].status = membership.MembershipStatusExpired
+		run.ExpiredMemberships = append(run.ExpiredMemberships, period.ExpiredMembership)
+		if period.rentedFacility != "" {
+			run.FlaggedRentals = append(run.FlaggedRentals, club.FlaggedRental{
+				MemberId:           period.MemberId,
+				FacilityIdentifier: period.rentedFacility,
+				SeasonId:           period.SeasonId,
+				Reason:             "membership expired",
+			})
+		}
+	}
+	r.runs = append([]club.ExpiryRun{run}, r.runs...)
+	return result.Ok(run)
+}
+
+func (r *fakeExpiryRepository) GetExpiryRuns(limit int) result.Result[[]club.ExpiryRun] {
+	if limit > len(r.runs) {
+		limit = len(r.runs)
+	}
+	return result.Ok(r.runs[:limit])
+}
+
+func expiringPeriodOf(periodId int64, memberId int64, status membership.MembershipStatus, seasonEndsAt time.Time, rentedFacility string) expiringPeriod {
+	return expiringPeriod{
+		ExpiredMembership: club.ExpiredMembership{
+			PeriodId: periodId,
+			MemberId: domain.NewId[membership.Member](memberId),
+			SeasonId: int64(seasonEndsAt.Year() - 1),
+		},
+		status:         status,
+		seasonEndsAt:   seasonEndsAt,
+		rentedFacility: rentedFacility,
+	}
+}
+
+func TestMembershipExpiryService_RunExpiry(t *testing.T) {
+	// Arrange
+	repository := &fakeExpiryRepository{periods: []expiringPeriod{
+		expiringPeriodOf(1, 1, membership.MembershipStatusActive, date(2025, 12, 31), "B-01"),
+		expiringPeriodOf(2, 2, membership.MembershipStatusSuspended, date(2025, 12, 31), ""),
+		expiringPeriodOf(3, 3, membership.MembershipStatusExcluded, date(2025, 12, 31), "B-03"),
+		expiringPeriodOf(4, 1, membership.MembershipStatusActive, date(2026, 1, 1), ""), // Last day of its season
+	}}
+	service := club.NewMembershipExpiryService(repository)
+	today := time.Date(2026, 1, 1, 15, 30, 0, 0, time.UTC)
+	before := time.Now()
+
+	// Act
+	run := service.RunExpiry(today, club.ScheduledExpiry)
+
+	// Assert
+	assert.True(t, run.IsSuccess())
+	assert.Equal(t, date(2026, 1, 1), run.Value().ReferenceDate, "only the day is considered")
+	assert.Equal(t, club.ScheduledExpiry, run.Value().Trigger)
+	assert.False(t, run.Value().StartedAt.Before(before))
+
+	expiredPeriods := []int64{}
+	for _, expired := range run.Value().ExpiredMemberships {
+		expiredPeriods = append(expiredPeriods, expired.PeriodId)
+	}
+	assert.Equal(t, []int64{1, 2}, expiredPeriods, "excluded periods and running seasons are left alone")
+	assert.Len(t, run.Value().FlaggedRentals, 1)
+	assert.Equal(t, "B-01", run.Value().FlaggedRentals[0].FacilityIdentifier)
+}
+
+func TestMembershipExpiryService_RunExpiry_IsIdempotent(t *testing.T) {
+	// Arrange
+	repository := &fakeExpiryRepository{periods: []expiringPeriod{
+		expiringPeriodOf(1, 1, membership.MembershipStatusActive, date(2026, 1, 1), "B-01"),
+	}}
+	service := club.NewMembershipExpiryService(repository)
+
+	// Act
+	first := service.RunExpiry(date(2026, 1, 2), club.ScheduledExpiry)
+	second := service.RunExpiry(date(2026, 1, 2), club.ManualExpiry)
+
+	// Assert
+	assert.Len(t, first.Value().ExpiredMemberships, 1)
+	assert.Empty(t, second.Value().ExpiredMemberships)
+	assert.Empty(t, second.Value().FlaggedRentals)
+	assert.Equal(t, club.ManualExpiry, second.Value().Trigger)
+}
+
+func TestMembershipExpiryService_RunExpiry_Rejected(t *testing.T) {
+	testCases := []struct {
+		name    string
+		running bool
+		trigger club.ExpiryTrigger
+	}{
+		{"unknown trigger", false, "NIGHTLY"},
+		{"another run in progress", true, club.ManualExpiry},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repository := &fakeExpiryRepository{running: tc.running}
+			service := club.NewMembershipExpiryService(repository)
+
+			// Act
+			run := service.RunExpiry(date(2026, 1, 2), tc.trigger)
+
+			// Assert
+			assert.False(t, run.IsSuccess())
+			assert.IsType(t, errors.MembershipStatusError{}, run.Error())
+			assert.Empty(t, repository.runs)
+		})
+	}
+}
+
+func TestMembershipExpiryService_GetExpiryRuns(t *testing.T) {
+	// Arrange
+	repository := &fakeExpiryRepository{}
+	service := club.NewMembershipExpiryService(repository)
+	for day := 1; day <= club.DefaultExpiryRunsLimit+5; day++ {
+		service.RunExpiry(date(2026, 1, day), club.ScheduledExpiry)
+	}
+
+	// Act
+	defaultLimit := service.GetExpiryRuns(0)
+	limited := service.GetExpiryRuns(3)
+
+	// Assert
+	assert.Len(t, defaultLimit.Value(), club.DefaultExpiryRunsLimit)
+	assert.Len(t, limited.Value(), 3)
+	assert.Equal(t, int64(club.DefaultExpiryRunsLimit+5), limited.Value()[0].Id, "newest first")
+}
@@ -0,0 +1,409 @@
+package club_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
+	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental/pricing"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	boxType  = facilityrental.FacilityType{Id: domain.NewId[facilityrental.FacilityType](1), FacilityName: "BOX", SuggestedPrice: money.Euros(200)}
+	rackType = facilityrental.FacilityType{Id: domain.NewId[facilityrental.FacilityType](2), FacilityName: "KAYAK_RACK", SuggestedPrice: money.Euros(100)}
+)
+
+// stubRolloverRepository returns the configured renewables and never stores anything
+type stubRolloverRepository struct {
+	club.SeasonRolloverRepository
+	memberships []club.RenewableMembership
+	rentals     []club.RenewableRental
+}
+
+func (r *stubRolloverRepository) GetRenewableMemberships(sourceSeasonId int64, targetSeasonId int64) result.Result[[]club.RenewableMembership] {
+	return result.Ok(r.memberships)
+}
+
+func (r *stubRolloverRepository) GetRenewableRentals(sourceSeasonId int64, targetSeasonId int64) result.Result[[]club.RenewableRental] {
+	return result.Ok(r.rentals)
+}
+
+// expiredRolloverRepository reads the renewables from the periods of a fake expiry repository
+// the way the SQL repository does, keeping only those with a renewable status
+type expiredRolloverRepository struct {
+	club.SeasonRolloverRepository
+	expiry *fakeExpiryRepository
+}
+
+func (r *expiredRolloverRepository) renewablePeriods(sourceSeasonId int64) []expiringPeriod {
+	periods := []expiringPeriod{}
+	for _, period := range r.expiry.periods {
+		if period.SeasonId == sourceSeasonId && slices.Contains(club.RenewableMembershipStatuses, period.status) {
+			periods = append(periods, period)
+		}
+	}
+	return periods
+}
+
+func (r *expiredRolloverRepository) GetRenewableMemberships(sourceSeasonId int64, targetSeasonId int64) result.Result[[]club.RenewableMembership] {
+	memberships := []club.RenewableMembership{}
+	for _, period := range r.renewablePeriods(sourceSeasonId) {
+		memberships = append(memberships, club.RenewableMembership{MemberId: period.MemberId, SourcePrice: money.Euros(130)})
+	}
+	return result.Ok(memberships)
+}
+
+func (r *expiredRolloverRepository) GetRenewableRentals(sourceSeasonId int64, targetSeasonId int64) result.Result[[]club.RenewableRental] {
+	rentals := []club.RenewableRental{}
+	for _, period := range r.renewablePeriods(sourceSeasonId) {
+		if period.rentedFacility != "" {
+			rentals = append(rentals, renewableRental(period.MemberId.Value, rental(period.PeriodId, period.rentedFacility, boxType, 180)))
+		}
+	}
+	return result.Ok(rentals)
+}
+
+// rolloverFacilityRepository implements only the FacilityRepository methods used by the rollover
+type rolloverFacilityRepository struct {
+	facilityrental.FacilityRepository
+	rentedInTarget map[int64][]facilityrental.RentedFacility
+}
+
+func (r *rolloverFacilityRepository) GetFacilitiesCatalog() []facilityrental.FacilityType {
+	return []facilityrental.FacilityType{boxType, rackType}
+}
+
+// GetPricingRules makes a kayak rack cost 60 EUR to members holding a box
+func (r *rolloverFacilityRepository) GetPricingRules() []facilityrental.PricingRule {
+	return []facilityrental.PricingRule{{
+		FacilityTypeId:         rackType.Id,
+		RequiredFacilityTypeId: boxType.Id,
+		SpecialPrice:           money.Euros(60),
+		Active:                 true,
+	}}
+}
+
+func (r *rolloverFacilityRepository) GetBoatLengthPricingTiers() []facilityrental.BoatLengthPricingTier {
+	return []facilityrental.BoatLengthPricingTier{}
+}
+
+func (r *rolloverFacilityRepository) GetFacilitiesRentedByMember(memberId domain.Id[membership.User], season int64) []facilityrental.RentedFacility {
+	return r.rentedInTarget[memberId.Value]
+}
+
+// rolloverHouseholdRepository prices families with the scheme, counting the configured paying members
+type rolloverHouseholdRepository struct {
+	membership.HouseholdRepository
+	payingInTarget map[int64]int
+}
+
+func (r *rolloverHouseholdRepository) GetFamilyPricingScheme() result.Result[membership.FamilyPricingScheme] {
+	return result.Ok(membership.FamilyPricingScheme{
+		FullPrice:             money.Euros(130),
+		AdditionalMemberPrice: money.Euros(90),
+		ChildPrice:            money.Euros(50),
+		ChildMaxAge:           14,
+	})
+}
+
+func (r *rolloverHouseholdRepository) CountPayingMembers(id domain.Id[membership.Household], seasonId int64, excluded *domain.Id[membership.Member]) result.Result[int] {
+	return result.Ok(r.payingInTarget[id.Value])
+}
+
+func rental(facilityId int64, identifier string, facilityType facilityrental.FacilityType, price float64) facilityrental.RentedFacility {
+	return facilityrental.SimpleRentedFacility{
+		Facility: facilityrental.Facility{Id: domain.NewId[facilityrental.Facility](facilityId), Identifier: identifier, FacilityType: facilityType},
+		Price:    money.Euros(price),
+	}
+}
+
+func renewableRental(memberId int64, rented facilityrental.RentedFacility) club.RenewableRental {
+	return club.RenewableRental{MemberId: domain.NewId[membership.Member](memberId), LastName: "Rossi", Rental: rented}
+}
+
+func newRolloverService(repository club.SeasonRolloverRepository, facilityRepository *rolloverFacilityRepository) *club.SeasonRolloverService {
+	return newFamilyRolloverService(repository, facilityRepository, &rolloverHouseholdRepository{})
+}
+
+func newFamilyRolloverService(
+	repository club.SeasonRolloverRepository,
+	facilityRepository *rolloverFacilityRepository,
+	householdRepository *rolloverHouseholdRepository,
+) *club.SeasonRolloverService {
+	seasons := &inMemorySeasonRepository{seasons: []club.Season{
+		{ID: 1, Code: "2025", StartsAt: date(2025, 1, 1), EndsAt: date(2025, 12, 31)},
+		{ID: 2, Code: "2026", StartsAt: date(2026, 1, 1), EndsAt: date(2026, 12, 31)},
+	}}
+	return club.NewSeasonRolloverService(repository, seasons, facilityrental.NewRentalManagementService(facilityRepository, nil), householdRepository)
+}
+
+func TestSeasonRolloverService_PreviewRollover_Memberships(t *testing.T) {
+	// Arrange
+	ordinary := membership.MembershipCategory{Code: membership.OrdinaryCategory, PaymentRequired: true}
+	honorary := membership.MembershipCategory{Code: membership.HonoraryCategory, PaymentRequired: false}
+	targetPrice := money.Euros(150)
+	repository := &stubRolloverRepository{memberships: []club.RenewableMembership{
+		{MemberId: domain.NewId[membership.Member](1), SourcePrice: money.Euros(130), Category: &ordinary, TargetPrice: &targetPrice},
+		{MemberId: domain.NewId[membership.Member](2), SourcePrice: money.Euros(130), Category: &ordinary},
+		{MemberId: domain.NewId[membership.Member](3), SourcePrice: money.Euros(0), Category: &honorary},
+		{MemberId: domain.NewId[membership.Member](4), SourcePrice: money.Euros(120)},
+		{MemberId: domain.NewId[membership.Member](5), SourcePrice: money.Euros(130), AlreadyInTarget: true},
+	}}
+	service := newRolloverService(repository, &rolloverFacilityRepository{})
+
+	// Act
+	plan := service.PreviewRollover(1, 2)
+
+	// Assert
+	assert.True(t, plan.IsSuccess())
+	renewals := plan.Value().Memberships
+	assert.Len(t, renewals, 4)
+	assert.Equal(t, money.Euros(150), renewals[0].Price, "re-priced at the category price of the target season")
+	assert.Equal(t, money.Euros(130), renewals[0].PreviousPrice)
+	assert.Equal(t, membership.SuggestedMembershipPrice, renewals[1].Price, "falls back when the target season has no price")
+	assert.True(t, renewals[2].Price.IsZero(), "exempt categories stay free")
+	assert.Equal(t, membership.SuggestedMembershipPrice, renewals[3].Price)
+
+	skipped := plan.Value().Skipped
+	assert.Len(t, skipped, 1)
+	assert.Equal(t, int64(5), skipped[0].MemberId.Value)
+	assert.Equal(t, club.MembershipRenewalKind, skipped[0].Kind)
+}
+
+func TestSeasonRolloverService_PreviewRollover_FamilyMemberships(t *testing.T) {
+	// Arrange
+	family := membership.MembershipCategory{Code: membership.FamilyCategory, PaymentRequired: true}
+	ordinary := membership.MembershipCategory{Code: membership.OrdinaryCategory, PaymentRequired: true}
+	rossi := domain.NewId[membership.Household](1)
+	bianchi := domain.NewId[membership.Household](2)
+	adult := date(1980, 1, 1)
+	child := date(2018, 1, 1)
+	repository := &stubRolloverRepository{memberships: []club.RenewableMembership{
+		{MemberId: domain.NewId[membership.Member](1), BirthDate: adult, HouseholdId: &rossi, Category: &ordinary},
+		{MemberId: domain.NewId[membership.Member](2), BirthDate: adult, HouseholdId: &rossi, Category: &family},
+		{MemberId: domain.NewId[membership.Member](3), BirthDate: child, HouseholdId: &rossi, Category: &family},
+		{MemberId: domain.NewId[membership.Member](4), BirthDate: adult, HouseholdId: &bianchi, Category: &family},
+		{MemberId: domain.NewId[membership.Member](5), BirthDate: adult, Category: &family},
+	}}
+	householdRepository := &rolloverHouseholdRepository{payingInTarget: map[int64]int{bianchi.Value: 1}}
+	service := newFamilyRolloverService(repository, &rolloverFacilityRepository{}, householdRepository)
+
+	// Act
+	plan := service.PreviewRollover(1, 2)
+
+	// Assert
+	assert.True(t, plan.IsSuccess())
+	renewals := plan.Value().Memberships
+	assert.Len(t, renewals, 5)
+	assert.Equal(t, money.Euros(90), renewals[1].Price, "household members renewed before are paying")
+	assert.Equal(t, money.Euros(50), renewals[2].Price, "children of the household pay the child price")
+	assert.Equal(t, money.Euros(90), renewals[3].Price, "household members already in the target season are paying")
+	assert.Equal(t, money.Euros(130), renewals[4].Price, "members without a household pay the full price")
+}
+
+func TestSeasonRolloverService_PreviewRollover_Rentals(t *testing.T) {
+	testCases := []struct {
+		name             string
+		rentals          []club.RenewableRental
+		rentedInTarget   map[int64][]facilityrental.RentedFacility
+		expectedPrices   []money.Money
+		expectedDiscount []bool
+		expectedSkipped  int
+	}{
+		{
+			name:             "re-priced at the current suggested price",
+			rentals:          []club.RenewableRental{renewableRental(1, rental(10, "B-10", boxType, 180))},
+			expectedPrices:   []money.Money{money.Euros(200)},
+			expectedDiscount: []bool{false},
+		},
+		{
+			name: "discount granted whatever the order of the rentals",
+			rentals: []club.RenewableRental{
+				renewableRental(1, rental(20, "R-20", rackType, 90)),
+				renewableRental(1, rental(10, "B-10", boxType, 180)),
+			},
+			expectedPrices:   []money.Money{money.Euros(60), money.Euros(200)},
+			expectedDiscount: []bool{true, false},
+		},
+		{
+			name: "at most one discount per member",
+			rentals: []club.RenewableRental{
+				renewableRental(1, rental(10, "B-10", boxType, 180)),
+				renewableRental(1, rental(20, "R-20", rackType, 60)),
+				renewableRental(1, rental(21, "R-21", rackType, 60)),
+			},
+			expectedPrices:   []money.Money{money.Euros(200), money.Euros(60), money.Euros(100)},
+			expectedDiscount: []bool{false, true, false},
+		},
+		{
+			name:    "discount from a box already rented in the target season",
+			rentals: []club.RenewableRental{renewableRental(1, rental(20, "R-20", rackType, 100))},
+			rentedInTarget: map[int64][]facilityrental.RentedFacility{
+				1: {rental(11, "B-11", boxType, 200)},
+			},
+			expectedPrices:   []money.Money{money.Euros(60)},
+			expectedDiscount: []bool{true},
+		},
+		{
+			name:    "no discount when one was already used in the target season",
+			rentals: []club.RenewableRental{renewableRental(1, rental(20, "R-20", rackType, 60))},
+			rentedInTarget: map[int64][]facilityrental.RentedFacility{
+				1: {
+					rental(11, "B-11", boxType, 200),
+					facilityrental.SimpleRentedFacility{
+						Facility:        facilityrental.Facility{Id: domain.NewId[facilityrental.Facility](22), FacilityType: rackType},
+						Price:           money.Euros(60),
+						DiscountApplied: true,
+					},
+				},
+			},
+			expectedPrices:   []money.Money{money.Euros(100)},
+			expectedDiscount: []bool{false},
+		},
+		{
+			name: "discounts are per member",
+			rentals: []club.RenewableRental{
+				renewableRental(1, rental(10, "B-10", boxType, 180)),
+				renewableRental(2, rental(20, "R-20", rackType, 60)),
+			},
+			expectedPrices:   []money.Money{money.Euros(200), money.Euros(100)},
+			expectedDiscount: []bool{false, false},
+		},
+		{
+			name: "facility already rented in the target season",
+			rentals: []club.RenewableRental{
+				{MemberId: domain.NewId[membership.Member](1), Rental: rental(10, "B-10", boxType, 180), FacilityRentedInTarget: true},
+				renewableRental(1, rental(20, "R-20", rackType, 60)),
+			},
+			expectedPrices:   []money.Money{money.Euros(100)},
+			expectedDiscount: []bool{false},
+			expectedSkipped:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repository := &stubRolloverRepository{rentals: tc.rentals}
+			service := newRolloverService(repository, &rolloverFacilityRepository{rentedInTarget: tc.rentedInTarget})
+
+			// Act
+			plan := service.PreviewRollover(1, 2)
+
+			// Assert
+			assert.True(t, plan.IsSuccess())
+			renewals := plan.Value().Rentals
+			prices := make([]money.Money, len(renewals))
+			discounts := make([]bool, len(renewals))
+			for i, renewal := range renewals {
+				prices[i] = renewal.Price
+				discounts[i] = renewal.DiscountApplied
+			}
+			assert.Equal(t, tc.expectedPrices, prices)
+			assert.Equal(t, tc.expectedDiscount, discounts)
+			assert.Len(t, plan.Value().Skipped, tc.expectedSkipped)
+			for _, skipped := range plan.Value().Skipped {
+				assert.Equal(t, club.RentalRenewalKind, skipped.Kind)
+			}
+		})
+	}
+}
+
+func TestSeasonRolloverService_PreviewRollover_AfterExpiry(t *testing.T) {
+	// Arrange
+	periodOf := func(periodId int64, memberId int64, status membership.MembershipStatus, rentedFacility string) expiringPeriod {
+		return expiringPeriod{
+			ExpiredMembership: club.ExpiredMembership{PeriodId: periodId, MemberId: domain.NewId[membership.Member](memberId), SeasonId: 1},
+			status:            status,
+			seasonEndsAt:      date(2025, 12, 31),
+			rentedFacility:    rentedFacility,
+		}
+	}
+	expiry := &fakeExpiryRepository{periods: []expiringPeriod{
+		periodOf(1, 1, membership.MembershipStatusActive, "B-01"),
+		periodOf(2, 2, membership.MembershipStatusExcluded, "B-02"),
+	}}
+	service := newRolloverService(&expiredRolloverRepository{expiry: expiry}, &rolloverFacilityRepository{})
+	expired := club.NewMembershipExpiryService(expiry).RunExpiry(time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC), club.ScheduledExpiry)
+
+	// Act
+	plan := service.PreviewRollover(1, 2)
+
+	// Assert
+	assert.True(t, expired.IsSuccess())
+	assert.Len(t, expired.Value().ExpiredMemberships, 1)
+	assert.True(t, plan.IsSuccess())
+	assert.Len(t, plan.Value().Memberships, 1, "periods expired at the end of the season are renewed")
+	assert.Equal(t, int64(1), plan.Value().Memberships[0].MemberId.Value)
+	assert.Len(t, plan.Value().Rentals, 1, "rentals of expired members are renewed, those of excluded members are not")
+	assert.Equal(t, "B-01", plan.Value().Rentals[0].SourceRental.GetFacility().Identifier)
+}
+
+func TestSeasonRolloverService_PreviewRollover_PreviousPriceAndMethod(t *testing.T) {
+	// Arrange
+	repository := &stubRolloverRepository{rentals: []club.RenewableRental{
+		renewableRental(1, rental(10, "B-10", boxType, 180)),
+		renewableRental(1, rental(20, "R-20", rackType, 90)),
+	}}
+	service := newRolloverService(repository, &rolloverFacilityRepository{})
+
+	// Act
+	plan := service.PreviewRollover(1, 2)
+
+	// Assert
+	assert.True(t, plan.IsSuccess())
+	renewals := plan.Value().Rentals
+	assert.Equal(t, money.Euros(180), renewals[0].PreviousPrice)
+	assert.Equal(t, pricing.BasePricing, renewals[0].PricingMethod)
+	assert.Equal(t, money.Euros(90), renewals[1].PreviousPrice)
+	assert.Equal(t, pricing.DiscountPricing, renewals[1].PricingMethod)
+}
+
+func TestSeasonRolloverService_PreviewRollover_InvalidSeasons(t *testing.T) {
+	// Arrange
+	closedAt := date(2026, 12, 31)
+	seasons := &inMemorySeasonRepository{seasons: []club.Season{
+		{ID: 1, Code: "2025", StartsAt: date(2025, 1, 1), EndsAt: date(2025, 12, 31)},
+		{ID: 2, Code: "2026", StartsAt: date(2026, 1, 1), EndsAt: date(2026, 12, 31), ClosedAt: &closedAt},
+		{ID: 3, Code: "2027", StartsAt: date(2027, 1, 1), EndsAt: date(2027, 12, 31)},
+	}}
+	service := club.NewSeasonRolloverService(
+		&stubRolloverRepository{},
+		seasons,
+		facilityrental.NewRentalManagementService(&rolloverFacilityRepository{}, nil),
+		&rolloverHouseholdRepository{},
+	)
+
+	testCases := []struct {
+		name           string
+		sourceSeasonId int64
+		targetSeasonId int64
+	}{
+		{"same season", 1, 1},
+		{"target before source", 3, 1},
+		{"closed target", 1, 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			plan := service.PreviewRollover(tc.sourceSeasonId, tc.targetSeasonId)
+
+			// Assert
+			assert.False(t, plan.IsSuccess())
+			assert.IsType(t, errors.SeasonError{}, plan.Error())
+		})
+	}
+
+	// Act & Assert
+	assert.IsType(t, errors.NotFoundError{}, service.PreviewRollover(1, 9).Error())
+	assert.True(t, service.PreviewRollover(1, 3).IsSuccess())
+}
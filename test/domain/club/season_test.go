@@ -0,0 +1,216 @@
+package club_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/club"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSeason_Contains(t *testing.T) {
+	// Arrange
+	season := club.Season{StartsAt: date(2025, 4, 1), EndsAt: date(2026, 3, 31)}
+
+	// Assert
+	assert.True(t, season.Contains(date(2025, 4, 1)))
+	assert.True(t, season.Contains(date(2026, 3, 31).Add(12*time.Hour)), "the end date is part of the season")
+	assert.False(t, season.Contains(date(2026, 4, 1)))
+	assert.False(t, season.Contains(date(2025, 3, 31)))
+}
+
+func TestSeason_Overlaps(t *testing.T) {
+	// Arrange
+	season := club.Season{StartsAt: date(2025, 4, 1), EndsAt: date(2026, 3, 31)}
+
+	testCases := []struct {
+		name     string
+		other    club.Season
+		expected bool
+	}{
+		{"following season", club.Season{StartsAt: date(2026, 4, 1), EndsAt: date(2027, 3, 31)}, false},
+		{"sharing the last day", club.Season{StartsAt: date(2026, 3, 31), EndsAt: date(2027, 3, 31)}, true},
+		{"previous season", club.Season{StartsAt: date(2024, 4, 1), EndsAt: date(2025, 3, 31)}, false},
+		{"starts inside", club.Season{StartsAt: date(2026, 1, 1), EndsAt: date(2027, 1, 1)}, true},
+		{"contained", club.Season{StartsAt: date(2025, 6, 1), EndsAt: date(2025, 9, 1)}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Assert
+			assert.Equal(t, tc.expected, season.Overlaps(tc.other))
+			assert.Equal(t, tc.expected, tc.other.Overlaps(season))
+		})
+	}
+}
+
+func TestSeason_IsInPreemptionWindow(t *testing.T) {
+	// Arrange
+	preemptionEndsAt := date(2026, 4, 30)
+	season := club.Season{StartsAt: date(2026, 4, 1), EndsAt: date(2027, 4, 1), PreemptionEndsAt: &preemptionEndsAt}
+
+	// Assert
+	assert.True(t, season.IsInPreemptionWindow(date(2026, 3, 15)))
+	assert.True(t, season.IsInPreemptionWindow(date(2026, 4, 30).Add(12*time.Hour)))
+	assert.False(t, season.IsInPreemptionWindow(date(2026, 5, 1)))
+	assert.False(t, club.Season{}.IsInPreemptionWindow(date(2026, 3, 15)))
+}
+
+// inMemorySeasonRepository is a minimal SeasonRepository used to exercise the service
+type inMemorySeasonRepository struct {
+	seasons []club.Season
+}
+
+func (r *inMemorySeasonRepository) GetSeasonById(seasonId int64) result.Result[club.Season] {
+	for _, season := range r.seasons {
+		if season.ID == seasonId {
+			return result.Ok(season)
+		}
+	}
+	return result.Err[club.Season](errors.NotFoundError{Description: "season not found"})
+}
+
+func (r *inMemorySeasonRepository) GetAllSeasons() result.Result[[]club.Season] {
+	return result.Ok(r.seasons)
+}
+
+func (r *inMemorySeasonRepository) GetSeasonByDate(date time.Time) result.Result[club.Season] {
+	for _, season := range r.seasons {
+		if season.Contains(date) {
+			return result.Ok(season)
+		}
+	}
+	return result.Err[club.Season](errors.NotFoundError{Description: "season not found"})
+}
+
+func (r *inMemorySeasonRepository) CreateSeason(season club.Season) result.Result[club.Season] {
+	season.ID = int64(len(r.seasons) + 1)
+	r.seasons = append(r.seasons, season)
+	return result.Ok(season)
+}
+
+func (r *inMemorySeasonRepository) UpdateSeason(season club.Season) result.Result[club.Season] {
+	for i := range r.seasons {
+		if r.seasons[i].ID == season.ID {
+			r.seasons[i] = season
+			return result.Ok(season)
+		}
+	}
+	return result.Err[club.Season](errors.NotFoundError{Description: "season not found"})
+}
+
+func (r *inMemorySeasonRepository) CloseSeason(seasonId int64, closedAt time.Time) result.Result[club.Season] {
+	for i := range r.seasons {
+		if r.seasons[i].ID == seasonId {
+			r.seasons[i].ClosedAt = &closedAt
+			return result.Ok(r.seasons[i])
+		}
+	}
+	return result.Err[club.Season](errors.NotFoundError{Description: "season not found"})
+}
+
+func (r *inMemorySeasonRepository) SetPreemptionWindow(seasonId int64, preemptionEndsAt *time.Time) result.Result[club.Season] {
+	for i := range r.seasons {
+		if r.seasons[i].ID == seasonId {
+			r.seasons[i].PreemptionEndsAt = preemptionEndsAt
+			return result.Ok(r.seasons[i])
+		}
+	}
+	return result.Err[club.Season](errors.NotFoundError{Description: "season not found"})
+}
+
+func TestSeasonManagementService_CreateSeason_RejectsOverlap(t *testing.T) {
+	// Arrange
+	repository := &inMemorySeasonRepository{}
+	service := club.NewSeasonManagementService(repository)
+	service.CreateSeason("2025", "Stagione 2025", date(2025, 4, 1), date(2026, 3, 31))
+
+	// Act
+	result := service.CreateSeason("2025-bis", "Stagione 2025 bis", date(2026, 1, 1), date(2026, 12, 31))
+
+	// Assert
+	assert.False(t, result.IsSuccess())
+	assert.IsType(t, errors.SeasonError{}, result.Error())
+}
+
+func TestSeasonManagementService_CreateSeason_RejectsInvertedDates(t *testing.T) {
+	// Arrange
+	service := club.NewSeasonManagementService(&inMemorySeasonRepository{})
+
+	// Act
+	result := service.CreateSeason("2025", "Stagione 2025", date(2026, 3, 31), date(2025, 4, 1))
+
+	// Assert
+	assert.False(t, result.IsSuccess())
+	assert.IsType(t, errors.DateError{}, result.Error())
+}
+
+func TestSeasonManagementService_UpdateSeason_RejectsClosedSeason(t *testing.T) {
+	// Arrange
+	repository := &inMemorySeasonRepository{}
+	service := club.NewSeasonManagementService(repository)
+	created := service.CreateSeason("2025", "Stagione 2025", date(2025, 4, 1), date(2026, 3, 31)).Value()
+	service.CloseSeason(created.ID)
+
+	// Act
+	result := service.UpdateSeason(created.ID, "2025", "Stagione 2025", date(2025, 4, 1), date(2026, 4, 30))
+
+	// Assert
+	assert.False(t, result.IsSuccess())
+	assert.IsType(t, errors.SeasonError{}, result.Error())
+}
+
+func TestSeasonManagementService_GetCurrentSeason(t *testing.T) {
+	// Arrange
+	repository := &inMemorySeasonRepository{}
+	service := club.NewSeasonManagementService(repository)
+	service.CreateSeason("2025", "Stagione 2025", date(2025, 4, 1), date(2026, 3, 31))
+	service.CreateSeason("2026", "Stagione 2026", date(2026, 4, 1), date(2027, 3, 31))
+
+	// Act
+	result := service.GetCurrentSeason(date(2026, 10, 16))
+	lastDay := service.GetCurrentSeason(date(2026, 3, 31))
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+	assert.Equal(t, "2026", result.Value().Code)
+	assert.True(t, lastDay.IsSuccess())
+	assert.Equal(t, "2025", lastDay.Value().Code, "the last day belongs to the season ending on it")
+}
+
+func TestSeasonManagementService_SetPreemptionWindow_RejectsWindowEndingAfterSeason(t *testing.T) {
+	// Arrange
+	repository := &inMemorySeasonRepository{}
+	service := club.NewSeasonManagementService(repository)
+	created := service.CreateSeason("2026", "Stagione 2026", date(2026, 4, 1), date(2027, 3, 31)).Value()
+	preemptionEndsAt := date(2027, 5, 1)
+
+	// Act
+	result := service.SetPreemptionWindow(created.ID, &preemptionEndsAt)
+
+	// Assert
+	assert.False(t, result.IsSuccess())
+	assert.IsType(t, errors.DateError{}, result.Error())
+}
+
+func TestSeasonManagementService_UpdateSeason_RejectsEndBeforePreemptionWindow(t *testing.T) {
+	// Arrange
+	repository := &inMemorySeasonRepository{}
+	service := club.NewSeasonManagementService(repository)
+	created := service.CreateSeason("2026", "Stagione 2026", date(2026, 4, 1), date(2027, 3, 31)).Value()
+	preemptionEndsAt := date(2026, 6, 30)
+	service.SetPreemptionWindow(created.ID, &preemptionEndsAt)
+
+	// Act
+	result := service.UpdateSeason(created.ID, "2026", "Stagione 2026", date(2026, 4, 1), date(2026, 6, 30))
+
+	// Assert
+	assert.False(t, result.IsSuccess())
+	assert.IsType(t, errors.DateError{}, result.Error())
+}
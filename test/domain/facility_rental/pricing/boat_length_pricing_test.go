@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental/pricing"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 )
 
 func TestBoatLengthPriceCalculator_CalculatePriceForBoatLength(t *testing.T) {
@@ -13,29 +14,29 @@ func TestBoatLengthPriceCalculator_CalculatePriceForBoatLength(t *testing.T) {
 		{
 			MinLengthMeters: 0,
 			MaxLengthMeters: 6.0,
-			Price:           100.0,
+			Price:           money.Euros(100.0),
 		},
 		{
 			MinLengthMeters: 6.0,
 			MaxLengthMeters: 8.0,
-			Price:           130.0,
+			Price:           money.Euros(130.0),
 		},
 		{
 			MinLengthMeters: 8.0,
 			MaxLengthMeters: 10.0,
-			Price:           160.0,
+			Price:           money.Euros(160.0),
 		},
 		{
 			MinLengthMeters: 10.0,
 			MaxLengthMeters: math.Inf(1),
-			Price:           200.0,
+			Price:           money.Euros(200.0),
 		},
 	}
 
 	config := pricing.BoatLengthPricingConfig{
 		FacilityTypeId: 1,
 		Tiers:          tiers,
-		DefaultPrice:   100.0,
+		DefaultPrice:   money.Euros(100.0),
 	}
 
 	calculator := pricing.NewBoatLengthPriceCalculator([]pricing.BoatLengthPricingConfig{config})
@@ -44,67 +45,67 @@ func TestBoatLengthPriceCalculator_CalculatePriceForBoatLength(t *testing.T) {
 		name             string
 		facilityTypeId   int64
 		boatLengthMeters float64
-		expectedPrice    float64
+		expectedPrice    money.Money
 	}{
 		{
 			name:             "Small boat in first tier",
 			facilityTypeId:   1,
 			boatLengthMeters: 5.5,
-			expectedPrice:    100.0,
+			expectedPrice:    money.Euros(100.0),
 		},
 		{
 			name:             "Boat at exact tier boundary (lower)",
 			facilityTypeId:   1,
 			boatLengthMeters: 6.0,
-			expectedPrice:    130.0,
+			expectedPrice:    money.Euros(130.0),
 		},
 		{
 			name:             "Medium boat in second tier",
 			facilityTypeId:   1,
 			boatLengthMeters: 7.0,
-			expectedPrice:    130.0,
+			expectedPrice:    money.Euros(130.0),
 		},
 		{
 			name:             "Boat at exact tier boundary (upper)",
 			facilityTypeId:   1,
 			boatLengthMeters: 8.0,
-			expectedPrice:    160.0,
+			expectedPrice:    money.Euros(160.0),
 		},
 		{
 			name:             "Large boat in third tier",
 			facilityTypeId:   1,
 			boatLengthMeters: 9.5,
-			expectedPrice:    160.0,
+			expectedPrice:    money.Euros(160.0),
 		},
 		{
 			name:             "Very large boat in unlimited tier",
 			facilityTypeId:   1,
 			boatLengthMeters: 15.0,
-			expectedPrice:    200.0,
+			expectedPrice:    money.Euros(200.0),
 		},
 		{
 			name:             "Huge boat in unlimited tier",
 			facilityTypeId:   1,
 			boatLengthMeters: 25.0,
-			expectedPrice:    200.0,
+			expectedPrice:    money.Euros(200.0),
 		},
 		{
 			name:             "Zero length returns default",
 			facilityTypeId:   1,
 			boatLengthMeters: 0,
-			expectedPrice:    100.0,
+			expectedPrice:    money.Euros(100.0),
 		},
 		{
 			name:             "Negative length returns default",
 			facilityTypeId:   1,
 			boatLengthMeters: -5.0,
-			expectedPrice:    100.0,
+			expectedPrice:    money.Euros(100.0),
 		},
 		{
 			name:             "Non-configured facility type returns 0",
 			facilityTypeId:   999,
 			boatLengthMeters: 7.0,
-			expectedPrice:    0,
+			expectedPrice:    money.Money{},
 		},
 	}
 
@@ -112,7 +113,7 @@ func TestBoatLengthPriceCalculator_CalculatePriceForBoatLength(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			result := calculator.CalculatePriceForBoatLength(tt.facilityTypeId, tt.boatLengthMeters)
 			if result != tt.expectedPrice {
-				t.Errorf("CalculatePriceForBoatLength() = %.2f, want %.2f", result, tt.expectedPrice)
+				t.Errorf("CalculatePriceForBoatLength() = %s, want %s", result, tt.expectedPrice)
 			}
 		})
 	}
@@ -122,9 +123,9 @@ func TestBoatLengthPriceCalculator_HasBoatLengthPricing(t *testing.T) {
 	config := pricing.BoatLengthPricingConfig{
 		FacilityTypeId: 1,
 		Tiers: []pricing.BoatLengthTier{
-			{MinLengthMeters: 0, MaxLengthMeters: 10, Price: 100},
+			{MinLengthMeters: 0, MaxLengthMeters: 10, Price: money.Euros(100)},
 		},
-		DefaultPrice: 100.0,
+		DefaultPrice: money.Euros(100.0),
 	}
 
 	calculator := pricing.NewBoatLengthPriceCalculator([]pricing.BoatLengthPricingConfig{config})
@@ -158,14 +159,14 @@ func TestBoatLengthPriceCalculator_HasBoatLengthPricing(t *testing.T) {
 
 func TestBoatLengthPriceCalculator_GetPricingTiersForFacilityType(t *testing.T) {
 	tiers := []pricing.BoatLengthTier{
-		{MinLengthMeters: 0, MaxLengthMeters: 6, Price: 100},
-		{MinLengthMeters: 6, MaxLengthMeters: 10, Price: 150},
+		{MinLengthMeters: 0, MaxLengthMeters: 6, Price: money.Euros(100)},
+		{MinLengthMeters: 6, MaxLengthMeters: 10, Price: money.Euros(150)},
 	}
 
 	config := pricing.BoatLengthPricingConfig{
 		FacilityTypeId: 1,
 		Tiers:          tiers,
-		DefaultPrice:   100.0,
+		DefaultPrice:   money.Euros(100.0),
 	}
 
 	calculator := pricing.NewBoatLengthPriceCalculator([]pricing.BoatLengthPricingConfig{config})
@@ -195,9 +196,9 @@ func TestBoatLengthPriceCalculator_GetDefaultPrice(t *testing.T) {
 	config := pricing.BoatLengthPricingConfig{
 		FacilityTypeId: 1,
 		Tiers: []pricing.BoatLengthTier{
-			{MinLengthMeters: 0, MaxLengthMeters: 10, Price: 100},
+			{MinLengthMeters: 0, MaxLengthMeters: 10, Price: money.Euros(100)},
 		},
-		DefaultPrice: 75.0,
+		DefaultPrice: money.Euros(75.0),
 	}
 
 	calculator := pricing.NewBoatLengthPriceCalculator([]pricing.BoatLengthPricingConfig{config})
@@ -207,8 +208,8 @@ func TestBoatLengthPriceCalculator_GetDefaultPrice(t *testing.T) {
 		if !exists {
 			t.Error("Expected facility type to exist")
 		}
-		if result != 75.0 {
-			t.Errorf("Expected default price 75.0, got %.2f", result)
+		if result != money.Euros(75) {
+			t.Errorf("Expected default price 75.0, got %s", result)
 		}
 	})
 
@@ -217,8 +218,8 @@ func TestBoatLengthPriceCalculator_GetDefaultPrice(t *testing.T) {
 		if exists {
 			t.Error("Expected facility type to not exist")
 		}
-		if result != 0 {
-			t.Errorf("Expected 0 for non-existent facility, got %.2f", result)
+		if !result.IsZero() {
+			t.Errorf("Expected 0 for non-existent facility, got %s", result)
 		}
 	})
 }
@@ -237,39 +238,39 @@ func TestValidateTiers(t *testing.T) {
 		{
 			name: "Valid continuous tiers",
 			tiers: []pricing.BoatLengthTier{
-				{MinLengthMeters: 0, MaxLengthMeters: 5, Price: 100},
-				{MinLengthMeters: 5, MaxLengthMeters: 10, Price: 150},
-				{MinLengthMeters: 10, MaxLengthMeters: math.Inf(1), Price: 200},
+				{MinLengthMeters: 0, MaxLengthMeters: 5, Price: money.Euros(100)},
+				{MinLengthMeters: 5, MaxLengthMeters: 10, Price: money.Euros(150)},
+				{MinLengthMeters: 10, MaxLengthMeters: math.Inf(1), Price: money.Euros(200)},
 			},
 			expectError: false,
 		},
 		{
 			name: "Valid tiers with gaps",
 			tiers: []pricing.BoatLengthTier{
-				{MinLengthMeters: 0, MaxLengthMeters: 5, Price: 100},
-				{MinLengthMeters: 7, MaxLengthMeters: 10, Price: 150},
+				{MinLengthMeters: 0, MaxLengthMeters: 5, Price: money.Euros(100)},
+				{MinLengthMeters: 7, MaxLengthMeters: 10, Price: money.Euros(150)},
 			},
 			expectError: false,
 		},
 		{
 			name: "Invalid overlapping tiers",
 			tiers: []pricing.BoatLengthTier{
-				{MinLengthMeters: 0, MaxLengthMeters: 6, Price: 100},
-				{MinLengthMeters: 5, MaxLengthMeters: 10, Price: 150},
+				{MinLengthMeters: 0, MaxLengthMeters: 6, Price: money.Euros(100)},
+				{MinLengthMeters: 5, MaxLengthMeters: 10, Price: money.Euros(150)},
 			},
 			expectError: true,
 		},
 		{
 			name: "Invalid tier - max less than min",
 			tiers: []pricing.BoatLengthTier{
-				{MinLengthMeters: 10, MaxLengthMeters: 5, Price: 100},
+				{MinLengthMeters: 10, MaxLengthMeters: 5, Price: money.Euros(100)},
 			},
 			expectError: true,
 		},
 		{
 			name: "Invalid tier - max equals min",
 			tiers: []pricing.BoatLengthTier{
-				{MinLengthMeters: 5, MaxLengthMeters: 5, Price: 100},
+				{MinLengthMeters: 5, MaxLengthMeters: 5, Price: money.Euros(100)},
 			},
 			expectError: true,
 		},
@@ -296,7 +297,7 @@ func TestCompositePriceCalculator_CalculatePrice(t *testing.T) {
 			PricingRules: []pricing.PricingRule{
 				{
 					RequiredFacilityTypeId: 2, // If member has facility type 2
-					SpecialPrice:           80.0,
+					SpecialPrice:           money.Euros(80.0),
 				},
 			},
 		},
@@ -308,11 +309,11 @@ func TestCompositePriceCalculator_CalculatePrice(t *testing.T) {
 		{
 			FacilityTypeId: 1,
 			Tiers: []pricing.BoatLengthTier{
-				{MinLengthMeters: 0, MaxLengthMeters: 6, Price: 100},
-				{MinLengthMeters: 6, MaxLengthMeters: 10, Price: 130},
-				{MinLengthMeters: 10, MaxLengthMeters: math.Inf(1), Price: 160},
+				{MinLengthMeters: 0, MaxLengthMeters: 6, Price: money.Euros(100)},
+				{MinLengthMeters: 6, MaxLengthMeters: 10, Price: money.Euros(130)},
+				{MinLengthMeters: 10, MaxLengthMeters: math.Inf(1), Price: money.Euros(160)},
 			},
-			DefaultPrice: 100.0,
+			DefaultPrice: money.Euros(100.0),
 		},
 	}
 	boatLengthCalc := pricing.NewBoatLengthPriceCalculator(boatLengthConfigs)
@@ -323,7 +324,7 @@ func TestCompositePriceCalculator_CalculatePrice(t *testing.T) {
 	tests := []struct {
 		name                      string
 		ctx                       pricing.PriceCalculationContext
-		expectedPrice             float64
+		expectedPrice             money.Money
 		expectedMethod            pricing.PricingMethod
 		expectedBoatLengthApplied bool
 		expectedDiscountApplied   bool
@@ -332,11 +333,11 @@ func TestCompositePriceCalculator_CalculatePrice(t *testing.T) {
 			name: "Base pricing - no boat length, no discount",
 			ctx: pricing.PriceCalculationContext{
 				FacilityTypeId:            1,
-				BaseSuggestedPrice:        100.0,
+				BaseSuggestedPrice:        money.Euros(100.0),
 				MemberRentedFacilityTypes: []int64{},
 				BoatLengthMeters:          nil,
 			},
-			expectedPrice:             100.0,
+			expectedPrice:             money.Euros(100.0),
 			expectedMethod:            pricing.BasePricing,
 			expectedBoatLengthApplied: false,
 			expectedDiscountApplied:   false,
@@ -345,11 +346,11 @@ func TestCompositePriceCalculator_CalculatePrice(t *testing.T) {
 			name: "Boat length pricing only",
 			ctx: pricing.PriceCalculationContext{
 				FacilityTypeId:            1,
-				BaseSuggestedPrice:        100.0,
+				BaseSuggestedPrice:        money.Euros(100.0),
 				MemberRentedFacilityTypes: []int64{},
 				BoatLengthMeters:          floatPtr(7.0),
 			},
-			expectedPrice:             130.0,
+			expectedPrice:             money.Euros(130.0),
 			expectedMethod:            pricing.BoatLengthPricing,
 			expectedBoatLengthApplied: true,
 			expectedDiscountApplied:   false,
@@ -358,11 +359,11 @@ func TestCompositePriceCalculator_CalculatePrice(t *testing.T) {
 			name: "Discount pricing only",
 			ctx: pricing.PriceCalculationContext{
 				FacilityTypeId:            1,
-				BaseSuggestedPrice:        100.0,
+				BaseSuggestedPrice:        money.Euros(100.0),
 				MemberRentedFacilityTypes: []int64{2}, // Has facility type 2
 				BoatLengthMeters:          nil,
 			},
-			expectedPrice:             80.0,
+			expectedPrice:             money.Euros(80.0),
 			expectedMethod:            pricing.DiscountPricing,
 			expectedBoatLengthApplied: false,
 			expectedDiscountApplied:   true,
@@ -371,11 +372,11 @@ func TestCompositePriceCalculator_CalculatePrice(t *testing.T) {
 			name: "Combined pricing - boat length + discount",
 			ctx: pricing.PriceCalculationContext{
 				FacilityTypeId:            1,
-				BaseSuggestedPrice:        100.0,
+				BaseSuggestedPrice:        money.Euros(100.0),
 				MemberRentedFacilityTypes: []int64{2}, // Has facility type 2
 				BoatLengthMeters:          floatPtr(7.0),
 			},
-			expectedPrice:             80.0, // Discount applied to boat length price
+			expectedPrice:             money.Euros(80.0), // Discount applied to boat length price
 			expectedMethod:            pricing.CombinedPricing,
 			expectedBoatLengthApplied: true,
 			expectedDiscountApplied:   true,
@@ -384,11 +385,11 @@ func TestCompositePriceCalculator_CalculatePrice(t *testing.T) {
 			name: "Large boat with discount",
 			ctx: pricing.PriceCalculationContext{
 				FacilityTypeId:            1,
-				BaseSuggestedPrice:        100.0,
+				BaseSuggestedPrice:        money.Euros(100.0),
 				MemberRentedFacilityTypes: []int64{2},
 				BoatLengthMeters:          floatPtr(12.0),
 			},
-			expectedPrice:             80.0, // Discount trumps boat length
+			expectedPrice:             money.Euros(80.0), // Discount trumps boat length
 			expectedMethod:            pricing.CombinedPricing,
 			expectedBoatLengthApplied: true,
 			expectedDiscountApplied:   true,
@@ -400,7 +401,7 @@ func TestCompositePriceCalculator_CalculatePrice(t *testing.T) {
 			result := composite.CalculatePrice(tt.ctx)
 
 			if result.FinalPrice != tt.expectedPrice {
-				t.Errorf("FinalPrice = %.2f, want %.2f", result.FinalPrice, tt.expectedPrice)
+				t.Errorf("FinalPrice = %s, want %s", result.FinalPrice, tt.expectedPrice)
 			}
 			if result.PricingMethod != tt.expectedMethod {
 				t.Errorf("PricingMethod = %s, want %s", result.PricingMethod, tt.expectedMethod)
@@ -422,14 +423,14 @@ func TestCompositePriceCalculator_CalculateSimplePrice(t *testing.T) {
 
 	ctx := pricing.PriceCalculationContext{
 		FacilityTypeId:            1,
-		BaseSuggestedPrice:        100.0,
+		BaseSuggestedPrice:        money.Euros(100.0),
 		MemberRentedFacilityTypes: []int64{},
 		BoatLengthMeters:          nil,
 	}
 
 	result := composite.CalculateSimplePrice(ctx)
-	if result != 100.0 {
-		t.Errorf("CalculateSimplePrice() = %.2f, want 100.00", result)
+	if result != money.Euros(100) {
+		t.Errorf("CalculateSimplePrice() = %s, want 100.00", result)
 	}
 }
 
@@ -0,0 +1,225 @@
+package facilityrental_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubFacilityRepository implements only the FacilityRepository methods used by the tests
+type stubFacilityRepository struct {
+	facilityrental.FacilityRepository
+	preemptions []facilityrental.PreemptionRight
+	rented      []domain.Id[facilityrental.Facility]
+}
+
+func (r *stubFacilityRepository) GetFacilitiesCatalog() []facilityrental.FacilityType {
+	return []facilityrental.FacilityType{}
+}
+
+func (r *stubFacilityRepository) GetPricingRules() []facilityrental.PricingRule {
+	return []facilityrental.PricingRule{}
+}
+
+func (r *stubFacilityRepository) GetBoatLengthPricingTiers() []facilityrental.BoatLengthPricingTier {
+	return []facilityrental.BoatLengthPricingTier{}
+}
+
+func (r *stubFacilityRepository) GetPendingPreemptions(season int64, date time.Time) result.Result[[]facilityrental.PreemptionRight] {
+	pending := []facilityrental.PreemptionRight{}
+	for _, preemption := range r.preemptions {
+		if !preemption.ExpiresAt.Before(date) {
+			pending = append(pending, preemption)
+		}
+	}
+	return result.Ok(pending)
+}
+
+func (r *stubFacilityRepository) RentFacility(
+	memberId domain.Id[membership.User],
+	facilityId domain.Id[facilityrental.Facility],
+	season int64,
+	price money.Money,
+	discountApplied bool,
+	boatInfo *facilityrental.BoatInfo,
+	leerboardInfo *facilityrental.LeerboardInfo,
+	date time.Time,
+) result.Result[facilityrental.RentedFacility] {
+	if err := facilityrental.CheckPreemption(r.GetPendingPreemptions(season, date).Value(), facilityId, memberId); err != nil {
+		return result.Err[facilityrental.RentedFacility](err)
+	}
+	r.rented = append(r.rented, facilityId)
+	return result.Ok[facilityrental.RentedFacility](facilityrental.SimpleRentedFacility{
+		MemberId: domain.NewId[membership.Member](memberId.Value),
+		Facility: facilityrental.Facility{Id: facilityId},
+		Price:    price,
+	})
+}
+
+// stubWaitingListRepository implements only the WaitingListRepository methods used by the tests
+type stubWaitingListRepository struct {
+	facilityrental.WaitingListRepository
+}
+
+func (r *stubWaitingListRepository) RemoveEntryByMemberAndType(
+	facilityType domain.Id[facilityrental.FacilityType],
+	memberId domain.Id[membership.Member],
+) result.Result[facilityrental.WaitingListEntry] {
+	return result.Ok(facilityrental.WaitingListEntry{})
+}
+
+func TestRentalManagementService_RentService_Preemption(t *testing.T) {
+	// Arrange
+	preemption := facilityrental.PreemptionRight{
+		Facility:  facilityrental.Facility{Id: domain.NewId[facilityrental.Facility](7), Identifier: "B-07"},
+		HolderId:  domain.NewId[membership.Member](1),
+		ExpiresAt: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name         string
+		facilityId   int64
+		memberId     int64
+		expectRented bool
+	}{
+		{"previous holder can renew", 7, 1, true},
+		{"other member is rejected", 7, 2, false},
+		{"facility without preemption is free", 8, 2, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := &stubFacilityRepository{preemptions: []facilityrental.PreemptionRight{preemption}}
+			service := facilityrental.NewRentalManagementService(repository, &stubWaitingListRepository{})
+
+			// Act
+			result := service.RentService(
+				domain.NewId[facilityrental.Facility](tc.facilityId),
+				domain.NewId[membership.User](tc.memberId),
+				2,
+				money.Euros(100),
+				false,
+				nil,
+				nil,
+				time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			)
+
+			// Assert
+			assert.Equal(t, tc.expectRented, result.IsSuccess())
+			if tc.expectRented {
+				assert.Len(t, repository.rented, 1)
+			} else {
+				assert.IsType(t, errors.RentError{}, result.Error())
+				assert.Empty(t, repository.rented)
+			}
+		})
+	}
+}
+
+func TestRentalManagementService_RentService_PreemptionOfRemovedHolder(t *testing.T) {
+	// Arrange
+	repository := &stubFacilityRepository{preemptions: []facilityrental.PreemptionRight{{
+		Facility:   facilityrental.Facility{Id: domain.NewId[facilityrental.Facility](7), Identifier: "B-07"},
+		HolderId:   domain.NewId[membership.Member](1),
+		ExpiresAt:  time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC),
+		HolderLeft: true,
+	}}}
+	service := facilityrental.NewRentalManagementService(repository, &stubWaitingListRepository{})
+
+	// Act
+	today := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	pending := service.GetPendingPreemptions(2, today)
+	rented := service.RentService(
+		domain.NewId[facilityrental.Facility](7),
+		domain.NewId[membership.User](2),
+		2,
+		money.Euros(100),
+		false,
+		nil,
+		nil,
+		today,
+	)
+
+	// Assert
+	assert.True(t, pending.IsSuccess())
+	assert.Empty(t, pending.Value(), "the right lapses when the holder is removed")
+	assert.True(t, rented.IsSuccess())
+	assert.Len(t, repository.rented, 1)
+}
+
+func TestRentalManagementService_RentService_PreemptionOnTheGivenDay(t *testing.T) {
+	// Arrange
+	preemption := facilityrental.PreemptionRight{
+		Facility:  facilityrental.Facility{Id: domain.NewId[facilityrental.Facility](7), Identifier: "B-07"},
+		HolderId:  domain.NewId[membership.Member](1),
+		ExpiresAt: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name         string
+		today        time.Time
+		expectRented bool
+	}{
+		{"reserved on the last day of the right", time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC), false},
+		{"free once the right has expired", time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := &stubFacilityRepository{preemptions: []facilityrental.PreemptionRight{preemption}}
+			service := facilityrental.NewRentalManagementService(repository, &stubWaitingListRepository{})
+
+			// Act
+			pending := service.GetPendingPreemptions(2, tc.today)
+			result := service.RentService(
+				domain.NewId[facilityrental.Facility](7),
+				domain.NewId[membership.User](2),
+				2,
+				money.Euros(100),
+				false,
+				nil,
+				nil,
+				tc.today,
+			)
+
+			// Assert
+			assert.True(t, pending.IsSuccess())
+			assert.Equal(t, tc.expectRented, len(pending.Value()) == 0)
+			assert.Equal(t, tc.expectRented, result.IsSuccess())
+		})
+	}
+}
+
+func TestCheckPreemption(t *testing.T) {
+	// Arrange
+	preemptions := []facilityrental.PreemptionRight{
+		{
+			Facility:  facilityrental.Facility{Id: domain.NewId[facilityrental.Facility](7), Identifier: "B-07"},
+			HolderId:  domain.NewId[membership.Member](1),
+			ExpiresAt: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Facility:   facilityrental.Facility{Id: domain.NewId[facilityrental.Facility](8), Identifier: "B-08"},
+			HolderId:   domain.NewId[membership.Member](3),
+			ExpiresAt:  time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC),
+			HolderLeft: true,
+		},
+	}
+
+	// Act
+	reserved := facilityrental.CheckPreemption(preemptions, domain.NewId[facilityrental.Facility](7), domain.NewId[membership.User](2))
+	renewed := facilityrental.CheckPreemption(preemptions, domain.NewId[facilityrental.Facility](7), domain.NewId[membership.User](1))
+	lapsed := facilityrental.CheckPreemption(preemptions, domain.NewId[facilityrental.Facility](8), domain.NewId[membership.User](2))
+
+	// Assert
+	assert.Equal(t, errors.RentError{Description: "facility B-07 is reserved to its previous holder until 2026-04-30"}, reserved)
+	assert.NoError(t, renewed)
+	assert.NoError(t, lapsed, "the right lapses when the holder is removed")
+}
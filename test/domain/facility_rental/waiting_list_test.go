@@ -0,0 +1,46 @@
+package facilityrental_test
+
+import (
+	"testing"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
+	"github.com/stretchr/testify/assert"
+)
+
+func waitingList(entryIds ...int64) facilityrental.WaitingList {
+	entries := make([]facilityrental.WaitingListEntry, len(entryIds))
+	for i, id := range entryIds {
+		entries[i] = facilityrental.WaitingListEntry{Id: domain.NewId[facilityrental.WaitingListEntry](id)}
+	}
+	return facilityrental.WaitingList{Entries: entries}
+}
+
+func TestWaitingList_PositionChanges(t *testing.T) {
+	testCases := []struct {
+		name     string
+		previous facilityrental.WaitingList
+		current  facilityrental.WaitingList
+		expected map[int64][2]int // Entry id to previous and current position
+	}{
+		{"first member removed", waitingList(1, 2, 3), waitingList(2, 3), map[int64][2]int{2: {2, 1}, 3: {3, 2}}},
+		{"middle member removed", waitingList(1, 2, 3), waitingList(1, 3), map[int64][2]int{3: {3, 2}}},
+		{"last member removed", waitingList(1, 2, 3), waitingList(1, 2), map[int64][2]int{}},
+		{"member added", waitingList(1, 2), waitingList(1, 2, 3), map[int64][2]int{}},
+		{"unchanged", waitingList(1, 2), waitingList(1, 2), map[int64][2]int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			changes := tc.current.PositionChanges(tc.previous)
+
+			// Assert
+			actual := map[int64][2]int{}
+			for _, change := range changes {
+				actual[change.Entry.Id.Value] = [2]int{change.PreviousPosition, change.Position}
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
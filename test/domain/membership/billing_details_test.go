@@ -0,0 +1,71 @@
+package membership_test
+
+import (
+	"testing"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBillingDetails(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		vatNumber             string
+		recipientCode         string
+		pec                   string
+		expectedVATNumber     string
+		expectedRecipientCode string
+		expectedPEC           string
+	}{
+		{"italian VAT number", "01234567897", "abc1234", "", "01234567897", "ABC1234", ""},
+		{"italian VAT number with country prefix", " IT 012 345 678 97", "", "", "01234567897", membership.DefaultRecipientCode, ""},
+		{"foreign VAT number", "DE123456789", "", "", "DE123456789", membership.DefaultRecipientCode, ""},
+		{"delivered by PEC", "01234567897", "", "Rossi.Charter@PEC.it", "01234567897", membership.DefaultRecipientCode, "rossi.charter@pec.it"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			details := membership.NewBillingDetails(" Rossi Charter ", tc.vatNumber, tc.recipientCode, tc.pec)
+
+			// Assert
+			assert.True(t, details.IsSuccess())
+			assert.Equal(t, "Rossi Charter", details.Value().CompanyName)
+			assert.Equal(t, tc.expectedVATNumber, details.Value().VATNumber)
+			assert.Equal(t, tc.expectedRecipientCode, details.Value().RecipientCode)
+			if tc.expectedPEC == "" {
+				assert.Nil(t, details.Value().PEC)
+			} else {
+				assert.Equal(t, tc.expectedPEC, details.Value().PEC.Value)
+			}
+		})
+	}
+}
+
+func TestNewBillingDetails_Invalid(t *testing.T) {
+	testCases := []struct {
+		name          string
+		companyName   string
+		vatNumber     string
+		recipientCode string
+		pec           string
+	}{
+		{"missing company name", " ", "01234567897", "", ""},
+		{"wrong check digit", "Rossi Charter", "01234567890", "", ""},
+		{"VAT number too short", "Rossi Charter", "0123456", "", ""},
+		{"recipient code of the public administration", "Rossi Charter", "01234567897", "UFABCD", ""},
+		{"invalid PEC", "Rossi Charter", "01234567897", "", "rossi.charter"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			details := membership.NewBillingDetails(tc.companyName, tc.vatNumber, tc.recipientCode, tc.pec)
+
+			// Assert
+			assert.False(t, details.IsSuccess())
+			assert.IsType(t, errors.BillingError{}, details.Error())
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package membership_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var currentPolicy = &membership.PrivacyPolicy{Version: "2026-01", Text: "Informativa privacy"}
+
+func TestValidateRequiredConsents(t *testing.T) {
+	testCases := []struct {
+		name          string
+		consents      []membership.Consent
+		policy        *membership.PrivacyPolicy
+		expectedValid bool
+	}{
+		{
+			name:          "privacy policy granted",
+			consents:      []membership.Consent{{Type: membership.PrivacyPolicyConsent, Granted: true}},
+			policy:        currentPolicy,
+			expectedValid: true,
+		},
+		{
+			name: "optional consents refused",
+			consents: []membership.Consent{
+				{Type: membership.PrivacyPolicyConsent, Granted: true},
+				{Type: membership.PhotoConsent, Granted: false},
+				{Type: membership.MarketingConsent, Granted: false},
+			},
+			policy:        currentPolicy,
+			expectedValid: true,
+		},
+		{
+			name:          "privacy policy missing",
+			consents:      []membership.Consent{{Type: membership.PhotoConsent, Granted: true}},
+			policy:        currentPolicy,
+			expectedValid: false,
+		},
+		{
+			name:          "privacy policy refused",
+			consents:      []membership.Consent{{Type: membership.PrivacyPolicyConsent, Granted: false}},
+			policy:        currentPolicy,
+			expectedValid: false,
+		},
+		{
+			name:          "outdated policy version",
+			consents:      []membership.Consent{{Type: membership.PrivacyPolicyConsent, Granted: true, PolicyVersion: "2025-01"}},
+			policy:        currentPolicy,
+			expectedValid: false,
+		},
+		{
+			name:          "unknown consent type",
+			consents:      []membership.Consent{{Type: membership.PrivacyPolicyConsent, Granted: true}, {Type: "NEWSLETTER", Granted: true}},
+			policy:        currentPolicy,
+			expectedValid: false,
+		},
+		{
+			name:          "consent given twice",
+			consents:      []membership.Consent{{Type: membership.PrivacyPolicyConsent, Granted: true}, {Type: membership.PrivacyPolicyConsent, Granted: false}},
+			policy:        currentPolicy,
+			expectedValid: false,
+		},
+		{
+			name:          "nothing required before a policy is published",
+			consents:      nil,
+			policy:        nil,
+			expectedValid: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			validated := membership.ValidateRequiredConsents(tc.consents, tc.policy)
+
+			// Assert
+			assert.Equal(t, tc.expectedValid, validated.IsSuccess())
+			if !tc.expectedValid {
+				assert.IsType(t, errors.ConsentError{}, validated.Error())
+			}
+		})
+	}
+}
+
+func TestValidateConsents_FillsCurrentPolicyVersion(t *testing.T) {
+	// Arrange
+	consents := []membership.Consent{{Type: membership.MarketingConsent, Granted: true}}
+
+	// Act
+	validated := membership.ValidateConsents(consents, currentPolicy)
+
+	// Assert
+	assert.True(t, validated.IsSuccess())
+	assert.Equal(t, "2026-01", validated.Value()[0].PolicyVersion)
+}
+
+func TestNewMemberConsents(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.January, d, 10, 0, 0, 0, time.UTC) }
+
+	testCases := []struct {
+		name             string
+		history          []membership.Consent
+		policy           *membership.PrivacyPolicy
+		expectedCurrent  []membership.Consent
+		expectedUpToDate bool
+	}{
+		{
+			name: "latest consent of each type wins",
+			history: []membership.Consent{
+				{Type: membership.MarketingConsent, Granted: true, PolicyVersion: "2026-01", RecordedAt: day(1)},
+				{Type: membership.PrivacyPolicyConsent, Granted: true, PolicyVersion: "2026-01", RecordedAt: day(1)},
+				{Type: membership.MarketingConsent, Granted: false, PolicyVersion: "2026-01", RecordedAt: day(5)},
+			},
+			policy: currentPolicy,
+			expectedCurrent: []membership.Consent{
+				{Type: membership.PrivacyPolicyConsent, Granted: true, PolicyVersion: "2026-01", RecordedAt: day(1)},
+				{Type: membership.MarketingConsent, Granted: false, PolicyVersion: "2026-01", RecordedAt: day(5)},
+			},
+			expectedUpToDate: true,
+		},
+		{
+			name: "privacy policy consent for an older version",
+			history: []membership.Consent{
+				{Type: membership.PrivacyPolicyConsent, Granted: true, PolicyVersion: "2025-01", RecordedAt: day(1)},
+			},
+			policy: currentPolicy,
+			expectedCurrent: []membership.Consent{
+				{Type: membership.PrivacyPolicyConsent, Granted: true, PolicyVersion: "2025-01", RecordedAt: day(1)},
+			},
+			expectedUpToDate: false,
+		},
+		{
+			name: "privacy policy consent revoked",
+			history: []membership.Consent{
+				{Type: membership.PrivacyPolicyConsent, Granted: true, PolicyVersion: "2026-01", RecordedAt: day(1)},
+				{Type: membership.PrivacyPolicyConsent, Granted: false, PolicyVersion: "2026-01", RecordedAt: day(2)},
+			},
+			policy: currentPolicy,
+			expectedCurrent: []membership.Consent{
+				{Type: membership.PrivacyPolicyConsent, Granted: false, PolicyVersion: "2026-01", RecordedAt: day(2)},
+			},
+			expectedUpToDate: false,
+		},
+		{
+			name:             "no policy published",
+			history:          []membership.Consent{},
+			policy:           nil,
+			expectedCurrent:  []membership.Consent{},
+			expectedUpToDate: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			consents := membership.NewMemberConsents(tc.history, tc.policy)
+
+			// Assert
+			assert.Equal(t, tc.expectedCurrent, consents.Current)
+			assert.Equal(t, tc.expectedUpToDate, consents.UpToDate)
+			assert.Equal(t, tc.history, consents.History)
+		})
+	}
+}
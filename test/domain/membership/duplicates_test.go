@@ -0,0 +1,106 @@
+package membership_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/stretchr/testify/assert"
+)
+
+func email(value string) *membership.EmailAddress {
+	address := membership.NewEmailAddress(value).Value()
+	return &address
+}
+
+func taxCode(value string) *membership.TaxCode {
+	code := membership.NewTaxCode(value).Value()
+	return &code
+}
+
+func TestScoreDuplicate(t *testing.T) {
+	// Arrange
+	birthDate := time.Date(1925, 4, 9, 0, 0, 0, 0, time.UTC)
+	original := membership.User{
+		FirstName:    "Matteo",
+		LastName:     "Moretti",
+		BirthDate:    birthDate,
+		TaxCode:      taxCode("MRTMTT25D09F205Z"),
+		Email:        email("matteo@example.com"),
+		PhoneNumbers: []membership.PhoneNumber{{Number: "+39 333 1234567"}},
+	}
+
+	testCases := []struct {
+		name     string
+		other    membership.User
+		expected int
+		reasons  []string
+	}{
+		{
+			name:     "same tax code with omocodia",
+			other:    membership.User{FirstName: "M.", LastName: "X", TaxCode: taxCode("MRTMTT25D09F20RU")},
+			expected: 50,
+			reasons:  []string{"same tax code"},
+		},
+		{
+			name:     "swapped name and same birth date",
+			other:    membership.User{FirstName: "Moretti", LastName: "Matteo", BirthDate: birthDate},
+			expected: 50,
+			reasons:  []string{"same name", "same birth date"},
+		},
+		{
+			name:     "phone in a different format",
+			other:    membership.User{FirstName: "Giulia", LastName: "Moretti", PhoneNumbers: []membership.PhoneNumber{{Number: "3331234567"}}},
+			expected: 30,
+			reasons:  []string{"same last name", "same phone number"},
+		},
+		{
+			name:     "everything matches",
+			other:    original,
+			expected: 100,
+			reasons:  []string{"same tax code", "same email", "same name", "same birth date", "same phone number"},
+		},
+		{
+			name:     "different person",
+			other:    membership.User{FirstName: "Anna", LastName: "Rossi"},
+			expected: 0,
+			reasons:  []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			score, reasons := membership.ScoreDuplicate(original, tc.other)
+
+			// Assert
+			assert.Equal(t, tc.expected, score)
+			assert.Equal(t, tc.reasons, reasons)
+		})
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	// Arrange
+	birthDate := time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+	users := []membership.User{
+		{Id: domain.NewId[membership.User](1), FirstName: "Mario", LastName: "Rossi", BirthDate: birthDate},
+		{Id: domain.NewId[membership.User](2), FirstName: "Anna", LastName: "Bianchi", BirthDate: birthDate},
+		{Id: domain.NewId[membership.User](3), FirstName: "mario", LastName: "rossi", BirthDate: birthDate, Email: email("mario@example.com")},
+		{Id: domain.NewId[membership.User](4), FirstName: "Mario", LastName: "Rossi", Email: email("mario@example.com")},
+	}
+
+	// Act
+	candidates := membership.FindDuplicates(users, membership.DefaultDuplicateThreshold)
+
+	// Assert
+	assert.Len(t, candidates, 2)
+	assert.Equal(t, int64(3), candidates[0].First.Id.Value)
+	assert.Equal(t, int64(4), candidates[0].Second.Id.Value)
+	assert.Equal(t, 60, candidates[0].Score)
+	for _, candidate := range candidates {
+		assert.NotEqual(t, int64(2), candidate.First.Id.Value)
+		assert.NotEqual(t, int64(2), candidate.Second.Id.Value)
+	}
+}
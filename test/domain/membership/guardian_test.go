@@ -0,0 +1,206 @@
+package membership_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_IsMinorAt(t *testing.T) {
+	user := membership.User{BirthDate: time.Date(2008, 5, 10, 0, 0, 0, 0, time.UTC)}
+
+	testCases := []struct {
+		name     string
+		date     time.Time
+		expected bool
+	}{
+		{name: "day before the 18th birthday", date: time.Date(2026, 5, 9, 0, 0, 0, 0, time.UTC), expected: true},
+		{name: "on the 18th birthday", date: time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC), expected: false},
+		{name: "after the 18th birthday", date: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			isMinor := user.IsMinorAt(tc.date)
+
+			// Assert
+			assert.Equal(t, tc.expected, isMinor)
+		})
+	}
+}
+
+func TestUser_IsMinorAt_WithoutBirthDate(t *testing.T) {
+	// Arrange
+	user := membership.User{}
+
+	// Act
+	isMinor := user.IsMinorAt(time.Now())
+
+	// Assert
+	assert.False(t, isMinor)
+}
+
+func TestUser_ValidateGuardians(t *testing.T) {
+	seasonStart := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	minorBirthDate := time.Date(2012, 7, 3, 0, 0, 0, 0, time.UTC)
+	adultBirthDate := time.Date(1980, 7, 3, 0, 0, 0, 0, time.UTC)
+	email := membership.NewEmailAddress("parent@example.com").Value()
+
+	testCases := []struct {
+		name        string
+		user        membership.User
+		expectError bool
+	}{
+		{
+			name:        "minor without guardian",
+			user:        membership.User{BirthDate: minorBirthDate},
+			expectError: true,
+		},
+		{
+			name: "minor with contact guardian",
+			user: membership.User{BirthDate: minorBirthDate, Guardians: []membership.Guardian{
+				{FirstName: "Anna", LastName: "Rossi", Email: &email, Relationship: "mother"},
+			}},
+			expectError: false,
+		},
+		{
+			name: "minor with member guardian",
+			user: membership.User{Id: domain.Id[membership.User]{Value: 1}, BirthDate: minorBirthDate, Guardians: []membership.Guardian{
+				{MemberId: &domain.Id[membership.User]{Value: 2}, Relationship: "father"},
+			}},
+			expectError: false,
+		},
+		{
+			name: "member as own guardian",
+			user: membership.User{Id: domain.Id[membership.User]{Value: 1}, BirthDate: minorBirthDate, Guardians: []membership.Guardian{
+				{MemberId: &domain.Id[membership.User]{Value: 1}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "contact guardian without name",
+			user: membership.User{BirthDate: minorBirthDate, Guardians: []membership.Guardian{
+				{FirstName: "Anna", Email: &email},
+			}},
+			expectError: true,
+		},
+		{
+			name: "contact guardian without contacts",
+			user: membership.User{BirthDate: minorBirthDate, Guardians: []membership.Guardian{
+				{FirstName: "Anna", LastName: "Rossi"},
+			}},
+			expectError: true,
+		},
+		{
+			name:        "adult without guardian",
+			user:        membership.User{BirthDate: adultBirthDate},
+			expectError: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := tc.user.ValidateGuardians(seasonStart)
+
+			// Assert
+			if tc.expectError {
+				assert.False(t, result.IsSuccess())
+				assert.IsType(t, errors.GuardianError{}, result.Error())
+			} else {
+				assert.True(t, result.IsSuccess())
+			}
+		})
+	}
+}
+
+func TestUser_ValidateGuardianMembers(t *testing.T) {
+	seasonStart := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	guardianId := domain.Id[membership.User]{Value: 2}
+	user := membership.User{Id: domain.Id[membership.User]{Value: 1}, BirthDate: time.Date(2012, 7, 3, 0, 0, 0, 0, time.UTC), Guardians: []membership.Guardian{
+		{MemberId: &guardianId, Relationship: "father"},
+	}}
+
+	testCases := []struct {
+		name        string
+		members     []membership.User
+		expectError bool
+	}{
+		{
+			name:        "adult guardian",
+			members:     []membership.User{{Id: guardianId, BirthDate: time.Date(1980, 7, 3, 0, 0, 0, 0, time.UTC)}},
+			expectError: false,
+		},
+		{
+			name:        "removed guardian",
+			members:     []membership.User{},
+			expectError: true,
+		},
+		{
+			name:        "minor guardian",
+			members:     []membership.User{{Id: guardianId, BirthDate: time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC)}},
+			expectError: true,
+		},
+		{
+			name:        "guardian coming of age at the start of the season",
+			members:     []membership.User{{Id: guardianId, BirthDate: time.Date(2008, 4, 1, 0, 0, 0, 0, time.UTC)}},
+			expectError: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := user.ValidateGuardianMembers(tc.members, seasonStart)
+
+			// Assert
+			if tc.expectError {
+				assert.IsType(t, errors.GuardianError{}, result.Error())
+			} else {
+				assert.True(t, result.IsSuccess())
+			}
+		})
+	}
+}
+
+// guardianRepository stores the member updated, its guardians are removed from the club
+type guardianRepository struct {
+	membership.MemberRepository
+	updated []membership.User
+}
+
+func (r *guardianRepository) GetSeasonStartDate(seasonId int64) result.Result[time.Time] {
+	return result.Ok(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func (r *guardianRepository) GetGuardianMembers(ids []domain.Id[membership.User]) result.Result[[]membership.User] {
+	return result.Ok([]membership.User{})
+}
+
+func (r *guardianRepository) UpdateMember(id domain.Id[membership.Member], user membership.User, season int64) result.Result[membership.MemberDetails] {
+	r.updated = append(r.updated, user)
+	return result.Ok(membership.MemberDetails{User: user})
+}
+
+func TestMemberManagementService_UpdateMember_RemovedGuardian(t *testing.T) {
+	// Arrange
+	repository := &guardianRepository{}
+	service := membership.NewMemberManagementService(repository, nil, nil, nil, nil)
+	guardianId := domain.Id[membership.User]{Value: 2}
+	user := membership.User{Id: domain.Id[membership.User]{Value: 1}, BirthDate: time.Date(2012, 7, 3, 0, 0, 0, 0, time.UTC), Guardians: []membership.Guardian{
+		{MemberId: &guardianId, Relationship: "mother"},
+	}}
+
+	// Act
+	result := service.UpdateMember(domain.Id[membership.Member]{Value: 1}, user, 1)
+
+	// Assert
+	assert.IsType(t, errors.GuardianError{}, result.Error())
+	assert.Empty(t, repository.updated)
+}
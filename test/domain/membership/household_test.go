@@ -0,0 +1,91 @@
+package membership_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFamilyPricingScheme_SuggestPrice(t *testing.T) {
+	scheme := membership.FamilyPricingScheme{FullPrice: money.Euros(130), AdditionalMemberPrice: money.Euros(100), ChildPrice: money.Euros(0), ChildMaxAge: 14}
+	seasonStart := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	householdId := domain.NewId[membership.Household](1)
+	adult := time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+	child := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	teenager := time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		user          membership.User
+		payingMembers int
+		expectedPrice float64
+		expectedRule  membership.FamilyPricingRule
+	}{
+		{name: "no household", user: membership.User{BirthDate: child}, payingMembers: 2, expectedPrice: 130, expectedRule: membership.FullPriceRule},
+		{name: "first member of the household", user: membership.User{BirthDate: adult, HouseholdId: &householdId}, payingMembers: 0, expectedPrice: 130, expectedRule: membership.FullPriceRule},
+		{name: "first member is a child", user: membership.User{BirthDate: child, HouseholdId: &householdId}, payingMembers: 0, expectedPrice: 130, expectedRule: membership.FullPriceRule},
+		{name: "second adult", user: membership.User{BirthDate: adult, HouseholdId: &householdId}, payingMembers: 1, expectedPrice: 100, expectedRule: membership.AdditionalMemberRule},
+		{name: "child", user: membership.User{BirthDate: child, HouseholdId: &householdId}, payingMembers: 1, expectedPrice: 0, expectedRule: membership.ChildRule},
+		{name: "teenager over the child age", user: membership.User{BirthDate: teenager, HouseholdId: &householdId}, payingMembers: 2, expectedPrice: 100, expectedRule: membership.AdditionalMemberRule},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			suggestion := scheme.SuggestPrice(tc.user, tc.payingMembers, seasonStart)
+
+			// Assert
+			assert.Equal(t, tc.expectedPrice, suggestion.Price.Float64())
+			assert.Equal(t, tc.expectedRule, suggestion.Rule)
+			assert.Equal(t, 130.0, suggestion.FullPrice.Float64())
+		})
+	}
+}
+
+func TestFamilyPricingScheme_SuggestPrice_ChildPriceDisabled(t *testing.T) {
+	// Arrange
+	scheme := membership.FamilyPricingScheme{FullPrice: money.Euros(130), AdditionalMemberPrice: money.Euros(100), ChildPrice: money.Euros(0)}
+	householdId := domain.NewId[membership.Household](1)
+	user := membership.User{BirthDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), HouseholdId: &householdId}
+
+	// Act
+	suggestion := scheme.SuggestPrice(user, 1, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.Equal(t, 100.0, suggestion.Price.Float64())
+	assert.Equal(t, membership.AdditionalMemberRule, suggestion.Rule)
+}
+
+func TestFamilyPricingScheme_Validate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		scheme      membership.FamilyPricingScheme
+		expectError bool
+	}{
+		{name: "valid", scheme: membership.FamilyPricingScheme{FullPrice: money.Euros(130), AdditionalMemberPrice: money.Euros(100), ChildPrice: money.Euros(0), ChildMaxAge: 14}},
+		{name: "default", scheme: membership.DefaultFamilyPricingScheme},
+		{name: "negative price", scheme: membership.FamilyPricingScheme{FullPrice: money.Euros(130), AdditionalMemberPrice: money.Euros(-1)}, expectError: true},
+		{name: "discount above full price", scheme: membership.FamilyPricingScheme{FullPrice: money.Euros(130), AdditionalMemberPrice: money.Euros(150)}, expectError: true},
+		{name: "child max age over majority", scheme: membership.FamilyPricingScheme{FullPrice: money.Euros(130), ChildMaxAge: 21}, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := tc.scheme.Validate()
+
+			// Assert
+			if tc.expectError {
+				assert.False(t, result.IsSuccess())
+				assert.IsType(t, errors.HouseholdError{}, result.Error())
+			} else {
+				assert.True(t, result.IsSuccess())
+			}
+		})
+	}
+}
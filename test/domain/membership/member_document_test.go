@@ -0,0 +1,190 @@
+package membership_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func validCertificateUpload() membership.DocumentUpload {
+	return membership.DocumentUpload{
+		Type:        membership.MedicalCertificateDocument,
+		FileName:    "certificato.pdf",
+		ContentType: "application/pdf",
+		Size:        1024,
+		IssuedAt:    date(2026, time.March, 1),
+		ExpiresAt:   date(2027, time.March, 1),
+	}
+}
+
+// stubDocumentRepository implements only the MemberDocumentRepository methods used by the deletion
+type stubDocumentRepository struct {
+	membership.MemberDocumentRepository
+	document membership.MemberDocument
+	deleted  bool
+}
+
+func (r *stubDocumentRepository) GetDocument(userId domain.Id[membership.User], id domain.Id[membership.MemberDocument]) result.Result[membership.MemberDocument] {
+	return result.Ok(r.document)
+}
+
+func (r *stubDocumentRepository) DeleteDocument(userId domain.Id[membership.User], id domain.Id[membership.MemberDocument]) result.Result[bool] {
+	r.deleted = true
+	return result.Ok(true)
+}
+
+func TestDocumentUpload_Validate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		modify        func(u *membership.DocumentUpload)
+		expectedValid bool
+	}{
+		{name: "valid medical certificate", modify: func(u *membership.DocumentUpload) {}, expectedValid: true},
+		{name: "document without expiry", modify: func(u *membership.DocumentUpload) {
+			u.Type = membership.PrivacyConsentDocument
+			u.ExpiresAt = nil
+		}, expectedValid: true},
+		{name: "unknown type", modify: func(u *membership.DocumentUpload) { u.Type = "PASSPORT" }, expectedValid: false},
+		{name: "missing file name", modify: func(u *membership.DocumentUpload) { u.FileName = "  " }, expectedValid: false},
+		{name: "unsupported content type", modify: func(u *membership.DocumentUpload) { u.ContentType = "application/zip" }, expectedValid: false},
+		{name: "empty file", modify: func(u *membership.DocumentUpload) { u.Size = 0 }, expectedValid: false},
+		{name: "file too large", modify: func(u *membership.DocumentUpload) { u.Size = membership.MaxDocumentSize + 1 }, expectedValid: false},
+		{name: "medical certificate without expiry", modify: func(u *membership.DocumentUpload) { u.ExpiresAt = nil }, expectedValid: false},
+		{name: "expiry before issue", modify: func(u *membership.DocumentUpload) { u.ExpiresAt = date(2026, time.February, 1) }, expectedValid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			upload := validCertificateUpload()
+			tc.modify(&upload)
+
+			// Act
+			validated := upload.Validate()
+
+			// Assert
+			assert.Equal(t, tc.expectedValid, validated.IsSuccess())
+			if !tc.expectedValid {
+				assert.IsType(t, errors.DocumentError{}, validated.Error())
+			}
+		})
+	}
+}
+
+func TestDocumentUpload_Validate_StripsDirectories(t *testing.T) {
+	// Arrange
+	upload := validCertificateUpload()
+	upload.FileName = `C:\Users\socio\..\certificato.pdf`
+
+	// Act
+	validated := upload.Validate()
+
+	// Assert
+	assert.True(t, validated.IsSuccess())
+	assert.Equal(t, "certificato.pdf", validated.Value().FileName)
+}
+
+func TestMemberDocument_IsValidAt(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expiresAt     *time.Time
+		at            time.Time
+		expectedValid bool
+	}{
+		{name: "no expiry", expiresAt: nil, at: *date(2030, time.January, 1), expectedValid: true},
+		{name: "before expiry", expiresAt: date(2026, time.June, 30), at: *date(2026, time.June, 1), expectedValid: true},
+		{name: "on the expiry day", expiresAt: date(2026, time.June, 30), at: time.Date(2026, time.June, 30, 18, 0, 0, 0, time.UTC), expectedValid: true},
+		{name: "after expiry", expiresAt: date(2026, time.June, 30), at: *date(2026, time.July, 1), expectedValid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			document := membership.MemberDocument{Type: membership.MedicalCertificateDocument, ExpiresAt: tc.expiresAt}
+
+			// Act
+			valid := document.IsValidAt(tc.at)
+
+			// Assert
+			assert.Equal(t, tc.expectedValid, valid)
+		})
+	}
+}
+
+func TestCheckCertificate(t *testing.T) {
+	seasonStart := *date(2026, time.April, 1)
+
+	testCases := []struct {
+		name           string
+		expiresAt      *time.Time
+		today          time.Time
+		expectedStatus membership.CertificateStatus
+	}{
+		{name: "missing certificate", expiresAt: nil, today: *date(2026, time.March, 1), expectedStatus: membership.CertificateMissing},
+		{name: "expiring before the season starts", expiresAt: date(2026, time.March, 15), today: *date(2026, time.March, 1), expectedStatus: membership.CertificateExpired},
+		{name: "valid at the season start", expiresAt: date(2026, time.September, 1), today: *date(2026, time.March, 1), expectedStatus: membership.CertificateValid},
+		{name: "expired during the season", expiresAt: date(2026, time.May, 31), today: *date(2026, time.June, 15), expectedStatus: membership.CertificateExpired},
+		{name: "still valid during the season", expiresAt: date(2026, time.June, 30), today: *date(2026, time.June, 15), expectedStatus: membership.CertificateValid},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			status := membership.CheckCertificate(tc.expiresAt, membership.CertificateReferenceDate(seasonStart, tc.today))
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, status)
+		})
+	}
+}
+
+func TestMemberDocumentService_DeleteDocument(t *testing.T) {
+	testCases := []struct {
+		name              string
+		undeletable       map[string]bool
+		expectedDeletion  membership.DocumentFilesDeletion
+		expectedLeftFiles map[string]string
+	}{
+		{
+			name:              "content deleted",
+			expectedDeletion:  membership.DocumentFilesDeletion{DeletedFiles: 1, UndeletedFiles: []membership.UndeletedFile{}},
+			expectedLeftFiles: map[string]string{},
+		},
+		{
+			name:        "content the storage fails to delete",
+			undeletable: map[string]bool{"members/1/certificate.pdf": true},
+			expectedDeletion: membership.DocumentFilesDeletion{UndeletedFiles: []membership.UndeletedFile{
+				{StorageKey: "members/1/certificate.pdf", Reason: "permission denied"},
+			}},
+			expectedLeftFiles: map[string]string{"members/1/certificate.pdf": "certificate"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repository := &stubDocumentRepository{document: membership.MemberDocument{StorageKey: "members/1/certificate.pdf"}}
+			storage := &fakeDocumentStorage{files: map[string]string{"members/1/certificate.pdf": "certificate"}, undeletable: tc.undeletable}
+			service := membership.NewMemberDocumentService(repository, storage, nil)
+
+			// Act
+			deleted := service.DeleteDocument(domain.NewId[membership.User](1), domain.NewId[membership.MemberDocument](3))
+
+			// Assert
+			assert.True(t, deleted.IsSuccess(), "the document is deleted even when its content is left behind")
+			assert.True(t, repository.deleted)
+			assert.Equal(t, tc.expectedDeletion, deleted.Value())
+			assert.Equal(t, tc.expectedLeftFiles, storage.files)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package membership_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubRemovalRepository implements only the MemberRepository methods used by the removal
+type stubRemovalRepository struct {
+	membership.MemberRepository
+	memberships []membership.Membership
+	documents   []string
+	removed     []membership.Excluded
+}
+
+func (r *stubRemovalRepository) GetMemberById(id domain.Id[membership.Member], season int64) result.Result[membership.MemberDetails] {
+	return result.Ok(membership.MemberDetails{
+		User:        membership.User{Id: domain.NewId[membership.User](id.Value), FirstName: "Mario", LastName: "Rossi"},
+		Memberships: r.memberships,
+	})
+}
+
+func (r *stubRemovalRepository) RemoveMember(id domain.Id[membership.Member], exclusion membership.Excluded) result.Result[[]string] {
+	r.removed = append(r.removed, exclusion)
+	return result.Ok(r.documents)
+}
+
+// fakeDocumentStorage keeps the documents in memory, failing to delete the undeletable ones
+type fakeDocumentStorage struct {
+	files       map[string]string
+	undeletable map[string]bool
+}
+
+func (s *fakeDocumentStorage) Save(key string, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	s.files[key] = string(data)
+	return err
+}
+
+func (s *fakeDocumentStorage) Open(key string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(s.files[key])), nil
+}
+
+func (s *fakeDocumentStorage) Delete(key string) error {
+	if s.undeletable[key] {
+		return fmt.Errorf("permission denied")
+	}
+	delete(s.files, key)
+	return nil
+}
+
+func TestMemberManagementService_RemoveMember(t *testing.T) {
+	// Arrange
+	excludedAt := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	excluded := membership.Excluded{PeriodId: 7, ExcludedAt: excludedAt, Reason: "unpaid fees"}
+
+	testCases := []struct {
+		name          string
+		memberships   []membership.Membership
+		expectRemoved bool
+		expectedError error
+	}{
+		{"excluded in the season", []membership.Membership{{Number: 42, Status: excluded}}, true, nil},
+		{"active in the season", []membership.Membership{{Number: 42, Status: membership.Active{PeriodId: 7}}}, false, errors.MembershipStatusError{}},
+		{"suspended in the season", []membership.Membership{{Number: 42, Status: membership.Suspended{PeriodId: 7}}}, false, errors.MembershipStatusError{}},
+		{"no membership in the season", []membership.Membership{}, false, errors.NotFoundError{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := &stubRemovalRepository{memberships: tc.memberships}
+			service := membership.NewMemberManagementService(repository, nil, nil, nil, &fakeDocumentStorage{})
+
+			// Act
+			removed := service.RemoveMember(domain.NewId[membership.Member](1), 2025)
+
+			// Assert
+			assert.Equal(t, tc.expectRemoved, removed.IsSuccess())
+			if tc.expectRemoved {
+				assert.Equal(t, []membership.Excluded{excluded}, repository.removed, "the decision is passed on to the other open periods")
+			} else {
+				assert.IsType(t, tc.expectedError, removed.Error())
+				assert.Empty(t, repository.removed)
+			}
+		})
+	}
+}
+
+func TestMemberManagementService_RemoveMember_DeletesDocumentFiles(t *testing.T) {
+	// Arrange
+	excluded := membership.Excluded{PeriodId: 7, ExcludedAt: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), Reason: "unpaid fees"}
+	repository := &stubRemovalRepository{
+		memberships: []membership.Membership{{Number: 42, Status: excluded}},
+		documents:   []string{"members/1/certificate.pdf", "members/1/id.png"},
+	}
+	documentStorage := &fakeDocumentStorage{files: map[string]string{
+		"members/1/certificate.pdf": "certificate",
+		"members/1/id.png":          "id",
+		"members/2/certificate.pdf": "certificate",
+	}}
+	service := membership.NewMemberManagementService(repository, nil, nil, nil, documentStorage)
+
+	// Act
+	removed := service.RemoveMember(domain.NewId[membership.Member](1), 2025)
+
+	// Assert
+	assert.True(t, removed.IsSuccess())
+	assert.Equal(t, map[string]string{"members/2/certificate.pdf": "certificate"}, documentStorage.files, "only the files of the removed member are deleted")
+	assert.Equal(t, membership.DocumentFilesDeletion{DeletedFiles: 2, UndeletedFiles: []membership.UndeletedFile{}}, removed.Value())
+}
+
+func TestMemberManagementService_RemoveMember_ReportsUndeletedFiles(t *testing.T) {
+	// Arrange
+	excluded := membership.Excluded{PeriodId: 7, ExcludedAt: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), Reason: "unpaid fees"}
+	newRepository := func() *stubRemovalRepository {
+		return &stubRemovalRepository{
+			memberships: []membership.Membership{{Number: 42, Status: excluded}},
+			documents:   []string{"members/1/certificate.pdf", "members/1/id.png"},
+		}
+	}
+	documentStorage := &fakeDocumentStorage{
+		files: map[string]string{
+			"members/1/certificate.pdf": "certificate",
+			"members/1/id.png":          "id",
+		},
+		undeletable: map[string]bool{"members/1/certificate.pdf": true},
+	}
+	service := membership.NewMemberManagementService(newRepository(), nil, nil, nil, documentStorage)
+	withoutStorage := membership.NewMemberManagementService(newRepository(), nil, nil, nil, nil)
+
+	// Act
+	removed := service.RemoveMember(domain.NewId[membership.Member](1), 2025)
+	removedWithoutStorage := withoutStorage.RemoveMember(domain.NewId[membership.Member](1), 2025)
+
+	// Assert
+	assert.True(t, removed.IsSuccess(), "the removal is stored even when files are left behind")
+	assert.Equal(t, 1, removed.Value().DeletedFiles)
+	assert.Equal(t, []membership.UndeletedFile{{StorageKey: "members/1/certificate.pdf", Reason: "permission denied"}}, removed.Value().UndeletedFiles)
+	assert.Equal(t, map[string]string{"members/1/certificate.pdf": "certificate"}, documentStorage.files)
+	assert.True(t, removedWithoutStorage.IsSuccess())
+	assert.Equal(t, 0, removedWithoutStorage.Value().DeletedFiles)
+	assert.Len(t, removedWithoutStorage.Value().UndeletedFiles, 2, "without a storage every file is left behind")
+}
@@ -0,0 +1,81 @@
+package membership_test
+
+import (
+	"testing"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberSearchCriteria_Normalized(t *testing.T) {
+	testCases := []struct {
+		name     string
+		criteria membership.MemberSearchCriteria
+		expected membership.MemberSearchCriteria
+	}{
+		{
+			name:     "defaults",
+			criteria: membership.MemberSearchCriteria{},
+			expected: membership.MemberSearchCriteria{SortBy: membership.SortByLastName, Limit: membership.DefaultMemberSearchLimit},
+		},
+		{
+			name:     "limit capped and negative offset reset",
+			criteria: membership.MemberSearchCriteria{Limit: 1000, Offset: -5},
+			expected: membership.MemberSearchCriteria{SortBy: membership.SortByLastName, Limit: membership.MaxMemberSearchLimit},
+		},
+		{
+			name:     "query trimmed and sort kept",
+			criteria: membership.MemberSearchCriteria{Query: "  rossi ", SortBy: membership.SortByBirthDate, Descending: true, Limit: 10, Offset: 20},
+			expected: membership.MemberSearchCriteria{Query: "rossi", SortBy: membership.SortByBirthDate, Descending: true, Limit: 10, Offset: 20},
+		},
+		{
+			name:     "unknown sort field",
+			criteria: membership.MemberSearchCriteria{SortBy: "email"},
+			expected: membership.MemberSearchCriteria{SortBy: membership.SortByLastName, Limit: membership.DefaultMemberSearchLimit},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			normalized := tc.criteria.Normalized()
+
+			// Assert
+			assert.Equal(t, tc.expected, normalized)
+		})
+	}
+}
+
+// stubSearchRepository records the criteria it is searched with
+type stubSearchRepository struct {
+	membership.MemberRepository
+	criteria []membership.MemberSearchCriteria
+	members  []membership.Member
+}
+
+func (r *stubSearchRepository) SearchMembers(criteria membership.MemberSearchCriteria) result.Result[membership.MemberPage] {
+	r.criteria = append(r.criteria, criteria)
+	return result.Ok(membership.MemberPage{Members: r.members, Total: len(r.members), Limit: criteria.Limit, Offset: criteria.Offset})
+}
+
+func TestMemberManagementService_SearchMembers(t *testing.T) {
+	// Arrange
+	withoutMembership := membership.Member{
+		User:       membership.User{Id: domain.NewId[membership.User](2), FirstName: "Giulia", LastName: "Bianchi"},
+		Membership: membership.Membership{Status: membership.None{}},
+	}
+	repository := &stubSearchRepository{members: []membership.Member{withoutMembership}}
+	service := membership.NewMemberManagementService(repository, nil, nil, nil, nil)
+
+	// Act
+	page := service.SearchMembers(membership.MemberSearchCriteria{Query: " bianchi ", Limit: 1000})
+
+	// Assert
+	assert.True(t, page.IsSuccess())
+	assert.Equal(t, []membership.MemberSearchCriteria{{Query: "bianchi", SortBy: membership.SortByLastName, Limit: membership.MaxMemberSearchLimit}}, repository.criteria)
+	assert.Equal(t, 1, page.Value().Total)
+	assert.False(t, page.Value().Members[0].IsActive(), "members without a membership are listed but not active")
+	assert.False(t, page.Value().Members[0].CanRentServices())
+}
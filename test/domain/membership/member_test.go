@@ -0,0 +1,157 @@
+package membership_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+func memberWithStatus(status membership.MembershipInfo) membership.Member {
+	return membership.Member{
+		Membership: membership.Membership{Number: 42, Status: status},
+	}
+}
+
+func TestMember_StatusTransitions(t *testing.T) {
+	// Arrange
+	validFrom := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	validUntil := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	active := membership.Active{PeriodId: 7, ValidFromDate: validFrom, ValidUntilDate: validUntil}
+	suspended := membership.Suspended{PeriodId: 7, ValidFromDate: validFrom, ValidUntilDate: validUntil}
+	excluded := membership.Excluded{PeriodId: 7, ValidFromDate: validFrom, ValidUntilDate: validUntil}
+	expired := membership.Expired{PeriodId: 7, ValidFromDate: validFrom, ValidUntilDate: validUntil}
+
+	suspend := membership.Member.Suspend
+	reinstate := membership.Member.Reinstate
+	expire := membership.Member.Expire
+	exclude := func(m membership.Member) result.Result[membership.Member] {
+		return m.Exclude(time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), "unpaid fees")
+	}
+
+	testCases := []struct {
+		name       string
+		from       membership.MembershipInfo
+		transition func(membership.Member) result.Result[membership.Member]
+		expected   membership.MembershipStatus
+		allowed    bool
+	}{
+		{"suspend active", active, suspend, membership.MembershipStatusSuspended, true},
+		{"suspend suspended", suspended, suspend, "", false},
+		{"reinstate suspended", suspended, reinstate, membership.MembershipStatusActive, true},
+		{"reinstate active", active, reinstate, "", false},
+		{"reinstate excluded", excluded, reinstate, "", false},
+		{"exclude active", active, exclude, membership.MembershipStatusExcluded, true},
+		{"exclude suspended", suspended, exclude, membership.MembershipStatusExcluded, true},
+		{"exclude excluded", excluded, exclude, "", false},
+		{"expire active", active, expire, membership.MembershipStatusExpired, true},
+		{"expire excluded", excluded, expire, "", false},
+		{"expire expired", expired, expire, "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := tc.transition(memberWithStatus(tc.from))
+
+			// Assert
+			assert.Equal(t, tc.allowed, result.IsSuccess())
+			if tc.allowed {
+				status := result.Value().Membership.Status
+				assert.Equal(t, tc.expected, status.GetStatus())
+				assert.Equal(t, int64(7), *status.GetPeriodId())
+				assert.Equal(t, int64(42), result.Value().Membership.Number)
+			} else {
+				assert.IsType(t, errors.MembershipStatusError{}, result.Error())
+			}
+		})
+	}
+}
+
+func TestMember_Exclude_RequiresReason(t *testing.T) {
+	// Arrange
+	member := memberWithStatus(membership.Active{PeriodId: 7})
+
+	// Act
+	result := member.Exclude(time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), "  ")
+
+	// Assert
+	assert.False(t, result.IsSuccess())
+	assert.IsType(t, errors.MembershipStatusError{}, result.Error())
+}
+
+func TestMember_Remove(t *testing.T) {
+	testCases := []struct {
+		name    string
+		status  membership.MembershipInfo
+		allowed bool
+	}{
+		{"active", membership.Active{PeriodId: 7}, false},
+		{"suspended", membership.Suspended{PeriodId: 7}, false},
+		{"expired", membership.Expired{PeriodId: 7}, false},
+		{"excluded", membership.Excluded{PeriodId: 7, ExcludedAt: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			member := memberWithStatus(tc.status)
+
+			// Act
+			result := member.Remove()
+
+			// Assert
+			assert.Equal(t, tc.allowed, result.IsSuccess())
+			if !tc.allowed {
+				assert.IsType(t, errors.MembershipStatusError{}, result.Error())
+			}
+		})
+	}
+}
+
+func TestFlagOverdue(t *testing.T) {
+	// Arrange
+	overdueItem := func(memberId int64, target payment.PaymentTarget, lateFee money.Money) payment.OverdueItem {
+		return payment.OverdueItem{Item: payment.OutstandingItem{MemberId: memberId, Target: target}, LateFee: lateFee}
+	}
+	members := []membership.Member{
+		{User: membership.User{Id: domain.NewId[membership.User](1)}},
+		{User: membership.User{Id: domain.NewId[membership.User](2)}},
+	}
+	overdue := []payment.OverdueItem{
+		overdueItem(1, payment.MembershipTarget, money.Euros(10)),
+		overdueItem(1, payment.FacilityTarget, money.Euros(5.5)),
+	}
+
+	// Act
+	flagged := membership.FlagOverdue(members, overdue)
+
+	// Assert
+	assert.True(t, flagged.IsSuccess())
+	assert.True(t, flagged.Value()[0].MembershipOverdue)
+	assert.True(t, flagged.Value()[0].HasOverdueFacilities)
+	assert.Equal(t, money.Euros(15.5), flagged.Value()[0].LateFees)
+	assert.False(t, flagged.Value()[1].IsOverdue())
+	assert.True(t, flagged.Value()[1].LateFees.IsZero())
+}
+
+func TestFlagOverdue_CurrencyMismatch(t *testing.T) {
+	// Arrange
+	members := []membership.Member{{User: membership.User{Id: domain.NewId[membership.User](1)}}}
+	overdue := []payment.OverdueItem{
+		{Item: payment.OutstandingItem{MemberId: 1, Target: payment.MembershipTarget}, LateFee: money.Euros(10)},
+		{Item: payment.OutstandingItem{MemberId: 1, Target: payment.FacilityTarget}, LateFee: money.New(500, "USD")},
+	}
+
+	// Act
+	flagged := membership.FlagOverdue(members, overdue)
+
+	// Assert
+	assert.IsType(t, errors.CurrencyMismatchError{}, flagged.Error())
+}
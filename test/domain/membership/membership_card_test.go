@@ -0,0 +1,74 @@
+package membership_test
+
+import (
+	"testing"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMembershipNumberingPolicy_Format(t *testing.T) {
+	testCases := []struct {
+		name         string
+		policy       membership.MembershipNumberingPolicy
+		number       int64
+		expectedCode string
+	}{
+		{name: "default policy", policy: membership.DefaultMembershipNumberingPolicy, number: 42, expectedCode: "42"},
+		{name: "prefix", policy: membership.MembershipNumberingPolicy{Prefix: "CNC-"}, number: 42, expectedCode: "CNC-42"},
+		{name: "zero padding", policy: membership.MembershipNumberingPolicy{Digits: 4}, number: 42, expectedCode: "0042"},
+		{name: "prefix and zero padding", policy: membership.MembershipNumberingPolicy{Prefix: "2026/", Digits: 3}, number: 7, expectedCode: "2026/007"},
+		{name: "number longer than the padding", policy: membership.MembershipNumberingPolicy{Digits: 2}, number: 1234, expectedCode: "1234"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			code := tc.policy.Format(tc.number)
+
+			// Assert
+			assert.Equal(t, tc.expectedCode, code)
+		})
+	}
+}
+
+func TestMembershipNumberingPolicy_Validate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		policy        membership.MembershipNumberingPolicy
+		expectedValid bool
+	}{
+		{name: "global numbering", policy: membership.MembershipNumberingPolicy{Scope: membership.GlobalNumbering}, expectedValid: true},
+		{name: "season numbering with prefix", policy: membership.MembershipNumberingPolicy{Scope: membership.SeasonNumbering, Prefix: "S-", Digits: 4, ReuseFreedNumbers: true}, expectedValid: true},
+		{name: "unknown scope", policy: membership.MembershipNumberingPolicy{Scope: "MONTHLY"}, expectedValid: false},
+		{name: "negative digits", policy: membership.MembershipNumberingPolicy{Scope: membership.GlobalNumbering, Digits: -1}, expectedValid: false},
+		{name: "too many digits", policy: membership.MembershipNumberingPolicy{Scope: membership.GlobalNumbering, Digits: 11}, expectedValid: false},
+		{name: "prefix too long", policy: membership.MembershipNumberingPolicy{Scope: membership.GlobalNumbering, Prefix: "CIRCOLO-NAUTICO-2026-"}, expectedValid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			validated := tc.policy.Validate()
+
+			// Assert
+			assert.Equal(t, tc.expectedValid, validated.IsSuccess())
+			if !tc.expectedValid {
+				assert.IsType(t, errors.MembershipNumberingError{}, validated.Error())
+			}
+		})
+	}
+}
+
+func TestMembershipNumberingPolicy_Validate_TrimsPrefix(t *testing.T) {
+	// Arrange
+	policy := membership.MembershipNumberingPolicy{Scope: membership.SeasonNumbering, Prefix: "  S-  "}
+
+	// Act
+	validated := policy.Validate()
+
+	// Assert
+	assert.True(t, validated.IsSuccess())
+	assert.Equal(t, "S-", validated.Value().Prefix)
+}
@@ -0,0 +1,256 @@
+package membership_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	ordinaryCategory = membership.MembershipCategory{Code: membership.OrdinaryCategory, PaymentRequired: true}
+	honoraryCategory = membership.MembershipCategory{Code: membership.HonoraryCategory, PaymentRequired: false}
+)
+
+func TestMembershipCategoryPrice_SuggestedPrice(t *testing.T) {
+	configured := money.Euros(150)
+
+	testCases := []struct {
+		name          string
+		price         membership.MembershipCategoryPrice
+		expectedPrice money.Money
+	}{
+		{name: "configured price", price: membership.MembershipCategoryPrice{Category: ordinaryCategory, Price: &configured}, expectedPrice: money.Euros(150)},
+		{name: "no price for the season", price: membership.MembershipCategoryPrice{Category: ordinaryCategory}, expectedPrice: membership.SuggestedMembershipPrice},
+		{name: "exempt from payment", price: membership.MembershipCategoryPrice{Category: honoraryCategory, Price: &configured}, expectedPrice: money.Euros(0)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			price := tc.price.SuggestedPrice()
+
+			// Assert
+			assert.Equal(t, tc.expectedPrice, price)
+		})
+	}
+}
+
+func TestMembership_IsPaid(t *testing.T) {
+	paid := []payment.Transaction{{Type: payment.PaymentTransaction, Amount: money.Euros(130), Date: time.Now()}}
+
+	testCases := []struct {
+		name             string
+		membership       membership.Membership
+		expectedRequired bool
+		expectedPaid     bool
+	}{
+		{name: "no category and unpaid", membership: membership.Membership{Price: money.Euros(130)}, expectedRequired: true, expectedPaid: false},
+		{name: "no category and partially paid", membership: membership.Membership{Price: money.Euros(130), Transactions: []payment.Transaction{{Type: payment.PaymentTransaction, Amount: money.Euros(50)}}}, expectedRequired: true, expectedPaid: false},
+		{name: "ordinary and paid", membership: membership.Membership{Category: &ordinaryCategory, Price: money.Euros(130), Transactions: paid}, expectedRequired: true, expectedPaid: true},
+		{name: "ordinary and unpaid", membership: membership.Membership{Category: &ordinaryCategory, Price: money.Euros(130)}, expectedRequired: true, expectedPaid: false},
+		{name: "honorary and unpaid", membership: membership.Membership{Category: &honoraryCategory, Price: money.Euros(130)}, expectedRequired: false, expectedPaid: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act & Assert
+			assert.Equal(t, tc.expectedRequired, tc.membership.RequiresPayment())
+			assert.Equal(t, tc.expectedPaid, tc.membership.IsPaid().Value())
+		})
+	}
+}
+
+func TestSuggestCategory(t *testing.T) {
+	seasonStart := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	householdId := domain.NewId[membership.Household](1)
+	adult := time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+	minor := time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name             string
+		user             membership.User
+		payingMembers    int
+		expectedCategory membership.MembershipCategoryCode
+	}{
+		{name: "adult", user: membership.User{BirthDate: adult}, expectedCategory: membership.OrdinaryCategory},
+		{name: "first adult of a household", user: membership.User{BirthDate: adult, HouseholdId: &householdId}, expectedCategory: membership.OrdinaryCategory},
+		{name: "adult joining a paying household", user: membership.User{BirthDate: adult, HouseholdId: &householdId}, payingMembers: 1, expectedCategory: membership.FamilyCategory},
+		{name: "minor", user: membership.User{BirthDate: minor}, expectedCategory: membership.JuniorCategory},
+		{name: "first minor of a household", user: membership.User{BirthDate: minor, HouseholdId: &householdId}, expectedCategory: membership.JuniorCategory},
+		{name: "minor joining a paying household", user: membership.User{BirthDate: minor, HouseholdId: &householdId}, payingMembers: 2, expectedCategory: membership.FamilyCategory},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			category := membership.SuggestCategory(tc.user, seasonStart, tc.payingMembers)
+
+			// Assert
+			assert.Equal(t, tc.expectedCategory, category)
+		})
+	}
+}
+
+// stubCategoryRepository returns the categories with the configured prices of the season
+type stubCategoryRepository struct {
+	membership.MembershipCategoryRepository
+	prices []membership.MembershipCategoryPrice
+}
+
+func (r *stubCategoryRepository) GetCategoryPrices(seasonId int64) result.Result[[]membership.MembershipCategoryPrice] {
+	return result.Ok(r.prices)
+}
+
+func (r *stubCategoryRepository) GetCategoryPrice(code membership.MembershipCategoryCode, seasonId int64) result.Result[membership.MembershipCategoryPrice] {
+	for _, price := range r.prices {
+		if price.Category.Code == code {
+			return result.Ok(price)
+		}
+	}
+	return result.Err[membership.MembershipCategoryPrice](errors.NotFoundError{Description: "category not found"})
+}
+
+// stubFamilyRepository prices families with the scheme, counting the configured paying members
+type stubFamilyRepository struct {
+	membership.HouseholdRepository
+	payingMembers int
+}
+
+func (r *stubFamilyRepository) GetFamilyPricingScheme() result.Result[membership.FamilyPricingScheme] {
+	return result.Ok(membership.FamilyPricingScheme{
+		FullPrice:             money.Euros(130),
+		AdditionalMemberPrice: money.Euros(90),
+		ChildPrice:            money.Euros(50),
+		ChildMaxAge:           14,
+	})
+}
+
+func (r *stubFamilyRepository) CountPayingMembers(id domain.Id[membership.Household], seasonId int64, excluded *domain.Id[membership.Member]) result.Result[int] {
+	return result.Ok(r.payingMembers)
+}
+
+// stubMembershipRepository holds a single member and records the memberships added to it
+type stubMembershipRepository struct {
+	membership.MemberRepository
+	user  membership.User
+	added []membership.Membership
+}
+
+func (r *stubMembershipRepository) GetMemberById(id domain.Id[membership.Member], season int64) result.Result[membership.MemberDetails] {
+	return result.Ok(membership.MemberDetails{User: r.user})
+}
+
+func (r *stubMembershipRepository) GetSeasonStartDate(seasonId int64) result.Result[time.Time] {
+	return result.Ok(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+// GetGuardianMembers returns the guardians as adult members of the club
+func (r *stubMembershipRepository) GetGuardianMembers(ids []domain.Id[membership.User]) result.Result[[]membership.User] {
+	guardians := []membership.User{}
+	for _, id := range ids {
+		guardians = append(guardians, membership.User{Id: id, BirthDate: time.Date(1975, 1, 1, 0, 0, 0, 0, time.UTC)})
+	}
+	return result.Ok(guardians)
+}
+
+func (r *stubMembershipRepository) AddMembership(memberId domain.Id[membership.Member], seasonId int64, price money.Money, category membership.MembershipCategory) result.Result[membership.MemberDetails] {
+	r.added = append(r.added, membership.Membership{Price: price, Category: &category})
+	return result.Ok(membership.MemberDetails{User: r.user, Memberships: r.added})
+}
+
+func familyCategories() []membership.MembershipCategoryPrice {
+	ordinaryPrice := money.Euros(150)
+	familyCategory := membership.MembershipCategory{Code: membership.FamilyCategory, PaymentRequired: true}
+	return []membership.MembershipCategoryPrice{
+		{Category: ordinaryCategory, Price: &ordinaryPrice},
+		{Category: familyCategory},
+	}
+}
+
+func TestMemberManagementService_AddMembership_FamilyPrice(t *testing.T) {
+	householdId := domain.NewId[membership.Household](1)
+	adult := time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+	child := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	parentId := domain.NewId[membership.User](2)
+	guardians := []membership.Guardian{{MemberId: &parentId}}
+
+	testCases := []struct {
+		name          string
+		user          membership.User
+		payingMembers int
+		category      membership.MembershipCategoryCode
+		expectedPrice money.Money
+	}{
+		{name: "ordinary category", user: membership.User{BirthDate: adult, HouseholdId: &householdId}, payingMembers: 1, category: membership.OrdinaryCategory, expectedPrice: money.Euros(150)},
+		{name: "first member of the household", user: membership.User{BirthDate: adult, HouseholdId: &householdId}, category: membership.FamilyCategory, expectedPrice: money.Euros(130)},
+		{name: "adult joining a paying household", user: membership.User{BirthDate: adult, HouseholdId: &householdId}, payingMembers: 1, category: membership.FamilyCategory, expectedPrice: money.Euros(90)},
+		{name: "child joining a paying household", user: membership.User{BirthDate: child, HouseholdId: &householdId, Guardians: guardians}, payingMembers: 1, category: membership.FamilyCategory, expectedPrice: money.Euros(50)},
+		{name: "no household", user: membership.User{BirthDate: adult}, category: membership.FamilyCategory, expectedPrice: money.Euros(130)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repository := &stubMembershipRepository{user: tc.user}
+			service := membership.NewMemberManagementService(
+				repository,
+				&stubCategoryRepository{prices: familyCategories()},
+				nil,
+				&stubFamilyRepository{payingMembers: tc.payingMembers},
+				nil,
+			)
+
+			// Act
+			details := service.AddMembership(domain.NewId[membership.Member](1), 2026, nil, tc.category)
+
+			// Assert
+			assert.True(t, details.IsSuccess())
+			assert.Len(t, repository.added, 1)
+			assert.Equal(t, tc.expectedPrice, repository.added[0].Price)
+			assert.Equal(t, tc.category, repository.added[0].Category.Code)
+		})
+	}
+}
+
+func TestMemberManagementService_SuggestMembershipCategories_FamilyPrice(t *testing.T) {
+	// Arrange
+	householdId := domain.NewId[membership.Household](1)
+	repository := &stubMembershipRepository{user: membership.User{BirthDate: time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC), HouseholdId: &householdId}}
+	service := membership.NewMemberManagementService(
+		repository,
+		&stubCategoryRepository{prices: familyCategories()},
+		nil,
+		&stubFamilyRepository{payingMembers: 1},
+		nil,
+	)
+	memberId := domain.NewId[membership.Member](1)
+
+	// Act
+	suggestions := service.SuggestMembershipCategories(2026, &memberId)
+
+	// Assert
+	assert.True(t, suggestions.IsSuccess())
+	assert.Len(t, suggestions.Value(), 2)
+	assert.False(t, suggestions.Value()[0].Suggested)
+	assert.True(t, suggestions.Value()[1].Suggested)
+	assert.Equal(t, money.Euros(90), suggestions.Value()[1].SuggestedPrice)
+}
+
+func TestMemberManagementService_SetMembershipCategoryPrice_Family(t *testing.T) {
+	// Arrange
+	service := membership.NewMemberManagementService(nil, &stubCategoryRepository{}, nil, nil, nil)
+
+	// Act
+	price := service.SetMembershipCategoryPrice(membership.FamilyCategory, 2026, money.Euros(90))
+
+	// Assert
+	assert.False(t, price.IsSuccess())
+	assert.IsType(t, errors.MembershipCategoryError{}, price.Error())
+}
@@ -2,24 +2,27 @@ package membership_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
 	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
 )
 
 func TestRenewedMembership(t *testing.T) {
 	// Setup initial membership
-	currentDate := domain.NewDate(2025, 1, 1).Value()
-	memberId := domain.NewId[membership.Membership](1)
+	currentDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	category := &membership.MembershipCategory{Code: membership.OrdinaryCategory, PaymentRequired: true}
 	currentMembership := membership.Membership{
-		Number: memberId,
+		Number: 1,
 		Status: membership.Active{
 			ValidUntilDate: currentDate,
 		},
-		Payment: payment.PaymentPaid{
-			AmountPaid:  membership.SuggestedMembershipPrice,
-			PaymentDate: currentDate,
+		Category: category,
+		Price:    membership.SuggestedMembershipPrice,
+		Transactions: []payment.Transaction{
+			{Type: payment.PaymentTransaction, Amount: money.Euros(130)},
 		},
 	}
 
@@ -33,104 +36,118 @@ func TestRenewedMembership(t *testing.T) {
 
 	renewed := renewedResult.Value()
 
-	// Check membership number remains the same
-	if renewed.Number != memberId {
-		t.Errorf("Expected membership number to be %v, got %v", memberId, renewed.Number)
+	// Check membership number and category remain the same
+	if renewed.Number != currentMembership.Number {
+		t.Errorf("Expected membership number to be %v, got %v", currentMembership.Number, renewed.Number)
+	}
+	if renewed.Category != category {
+		t.Errorf("Expected category to be %v, got %v", category, renewed.Category)
 	}
 
 	// Check validity date is extended by 1 year
-	expectedNewDate := domain.NewDate(2026, 1, 1).Value()
-	if renewed.Status.GetValidUntilDate() != expectedNewDate {
+	expectedNewDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !renewed.Status.GetValidUntilDate().Equal(expectedNewDate) {
 		t.Errorf("Expected new validity date to be %v, got %v", expectedNewDate, renewed.Status.GetValidUntilDate())
 	}
-
-	// Check payment is set to unpaid with correct amount
-	payment, ok := renewed.Payment.(payment.PaymentUnpaid)
-	if !ok {
-		t.Error("Expected payment to be unpaid")
-		return
+	if renewed.Status.GetStatus() != membership.MembershipStatusActive {
+		t.Errorf("Expected renewed membership to be active, got %v", renewed.Status.GetStatus())
 	}
-	if payment.AmountDue != membership.SuggestedMembershipPrice {
-		t.Errorf("Expected amount due to be %v, got %v", membership.SuggestedMembershipPrice, payment.AmountDue)
+
+	// Check the renewed period starts with nothing paid
+	if len(renewed.Transactions) != 0 {
+		t.Errorf("Expected no transactions on the renewed period, got %v", renewed.Transactions)
 	}
 }
 
-func TestDeliberatedExclusionMembership(t *testing.T) {
+func TestExcludedMembership(t *testing.T) {
 	// Setup initial membership
-	validUntilDate := domain.NewDate(2025, 1, 31).Value()
-	decisionDate := domain.NewDate(2025, 6, 15).Value()
-	memberId := domain.NewId[membership.Membership](1)
+	validFromDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	validUntilDate := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	decisionDate := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
 	currentMembership := membership.Membership{
-		Number: memberId,
+		Id:     domain.NewId[membership.Membership](3),
+		Number: 1,
 		Status: membership.Active{
+			PeriodId:       7,
+			ValidFromDate:  validFromDate,
 			ValidUntilDate: validUntilDate,
 		},
-		Payment: payment.PaymentUnpaid{
-			AmountDue: membership.SuggestedMembershipPrice,
-			DueDate:   validUntilDate,
-		},
+		Price: membership.SuggestedMembershipPrice,
 	}
 
-	// Test deliberated exclusion
-	excluded := membership.DeliberatedExclusionMembership(currentMembership, decisionDate)
+	// Test exclusion
+	excluded := membership.ExcludedMembership(currentMembership, decisionDate, "unpaid fees")
 
-	// Check membership number remains the same
-	if excluded.Number != memberId {
-		t.Errorf("Expected membership number to be %v, got %v", memberId, excluded.Number)
+	// Check membership identity remains the same
+	if excluded.Id != currentMembership.Id || excluded.Number != currentMembership.Number {
+		t.Errorf("Expected membership %v number %v, got %v number %v", currentMembership.Id, currentMembership.Number, excluded.Id, excluded.Number)
 	}
 
-	// Check the status is changed to ExclusionDeliberated
-	status, ok := excluded.Status.(membership.ExclusionDeliberated)
+	// Check the status is changed to Excluded
+	status, ok := excluded.Status.(membership.Excluded)
 	if !ok {
-		t.Error("Expected status to be ExclusionDeliberated")
+		t.Error("Expected status to be Excluded")
 		return
 	}
 
-	// Check dates are set correctly
-	if status.ValidUntilDate != validUntilDate {
-		t.Errorf("Expected valid until date to be %v, got %v", validUntilDate, status.ValidUntilDate)
+	// Check period, dates and reason are set correctly
+	if status.PeriodId != 7 {
+		t.Errorf("Expected period id to be 7, got %v", status.PeriodId)
+	}
+	if status.ValidFromDate != validFromDate || status.ValidUntilDate != validUntilDate {
+		t.Errorf("Expected validity %v - %v, got %v - %v", validFromDate, validUntilDate, status.ValidFromDate, status.ValidUntilDate)
 	}
-	if status.DecisionDate != decisionDate {
-		t.Errorf("Expected decision date to be %v, got %v", decisionDate, status.DecisionDate)
+	if status.ExcludedAt != decisionDate {
+		t.Errorf("Expected decision date to be %v, got %v", decisionDate, status.ExcludedAt)
+	}
+	if status.Reason != "unpaid fees" {
+		t.Errorf("Expected reason to be %q, got %q", "unpaid fees", status.Reason)
+	}
+	if excluded.Price != currentMembership.Price {
+		t.Errorf("Expected price to be %v, got %v", currentMembership.Price, excluded.Price)
 	}
 }
 
-func TestExcludedMembership(t *testing.T) {
+func TestMembershipFactories_KeepThePeriod(t *testing.T) {
 	// Setup initial membership
-	validUntilDate := domain.NewDate(2025, 1, 31).Value()
-	decisionDate := domain.NewDate(2025, 6, 15).Value()
-	memberId := domain.NewId[membership.Membership](1)
+	validFromDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	validUntilDate := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	transactions := []payment.Transaction{{Type: payment.PaymentTransaction, Amount: money.Euros(50)}}
 	currentMembership := membership.Membership{
-		Number: memberId,
+		Number: 1,
 		Status: membership.Active{
+			PeriodId:       7,
+			ValidFromDate:  validFromDate,
 			ValidUntilDate: validUntilDate,
 		},
-		Payment: payment.PaymentUnpaid{
-			AmountDue: membership.SuggestedMembershipPrice,
-			DueDate:   validUntilDate,
-		},
-	}
-
-	// Test exclusion
-	excluded := membership.ExcludedMembership(currentMembership, decisionDate)
-
-	// Check membership number remains the same
-	if excluded.Number != memberId {
-		t.Errorf("Expected membership number to be %v, got %v", memberId, excluded.Number)
-	}
-
-	// Check the status is changed to Excluded
-	status, ok := excluded.Status.(membership.Excluded)
-	if !ok {
-		t.Error("Expected status to be Excluded")
-		return
-	}
-
-	// Check dates are set correctly
-	if status.ValidUntilDate != validUntilDate {
-		t.Errorf("Expected valid until date to be %v, got %v", validUntilDate, status.ValidUntilDate)
-	}
-	if status.DecisionDate != decisionDate {
-		t.Errorf("Expected decision date to be %v, got %v", decisionDate, status.DecisionDate)
+		Price:        money.Euros(130),
+		Transactions: transactions,
+	}
+
+	factories := map[membership.MembershipStatus]func(membership.Membership) membership.Membership{
+		membership.MembershipStatusSuspended: membership.SuspendedMembership,
+		membership.MembershipStatusActive:    membership.ReinstatedMembership,
+		membership.MembershipStatusExpired:   membership.ExpiredMembership,
+	}
+
+	for expectedStatus, factory := range factories {
+		t.Run(string(expectedStatus), func(t *testing.T) {
+			// Test transition
+			transitioned := factory(currentMembership)
+
+			// Check the status changes while the period is kept
+			if transitioned.Status.GetStatus() != expectedStatus {
+				t.Errorf("Expected status to be %v, got %v", expectedStatus, transitioned.Status.GetStatus())
+			}
+			if *transitioned.Status.GetPeriodId() != 7 {
+				t.Errorf("Expected period id to be 7, got %v", *transitioned.Status.GetPeriodId())
+			}
+			if transitioned.Status.GetValidFromDate() != validFromDate || transitioned.Status.GetValidUntilDate() != validUntilDate {
+				t.Errorf("Expected validity %v - %v, got %v - %v", validFromDate, validUntilDate, transitioned.Status.GetValidFromDate(), transitioned.Status.GetValidUntilDate())
+			}
+			if transitioned.Price != currentMembership.Price || len(transitioned.Transactions) != len(transactions) {
+				t.Errorf("Expected price and transactions to be kept, got %v and %v", transitioned.Price, transitioned.Transactions)
+			}
+		})
 	}
 }
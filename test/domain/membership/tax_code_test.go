@@ -0,0 +1,133 @@
+package membership_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTaxCode(t *testing.T) {
+	testCases := []struct {
+		name  string
+		code  string
+		valid bool
+	}{
+		{"valid", "MRTMTT25D09F205Z", true},
+		{"lowercase with spaces", " mrtmtt25d09f205z ", true},
+		{"omocodia", "MRTMTT25D09F20RU", true},
+		{"wrong check character", "MRTMTT25D09F205A", false},
+		{"wrong month letter", "MRTMTT25F09F205Z", false},
+		{"invalid day", "MRTMTT25D35F205Z", false},
+		{"too short", "MRTMTT25D09F205", false},
+		{"empty", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := membership.NewTaxCode(tc.code)
+
+			// Assert
+			assert.Equal(t, tc.valid, result.IsSuccess())
+			if !tc.valid {
+				assert.IsType(t, errors.TaxCodeError{}, result.Error())
+			}
+		})
+	}
+}
+
+func TestTaxCode_Equals_IgnoresOmocodia(t *testing.T) {
+	// Arrange
+	original := membership.NewTaxCode("MRTMTT25D09F205Z").Value()
+	omocode := membership.NewTaxCode("MRTMTT25D09F20RU").Value()
+
+	// Act & Assert
+	assert.True(t, original.Equals(omocode))
+	assert.Equal(t, "F205", omocode.PlaceCode())
+}
+
+func TestStoredTaxCode(t *testing.T) {
+	// Act
+	valid := membership.StoredTaxCode("mrtmtt25d09f20ru")
+	legacy := membership.StoredTaxCode(" MRTMTT25D09F205A ")
+
+	// Assert
+	assert.True(t, valid.IsValid())
+	assert.Equal(t, "MRTMTT25D09F20RU", valid.Value)
+	assert.False(t, legacy.IsValid(), "the stored code is kept even with a wrong check character")
+	assert.Equal(t, "MRTMTT25D09F205A", legacy.Value)
+	assert.Empty(t, legacy.PlaceCode())
+	assert.True(t, legacy.Equals(membership.StoredTaxCode("mrtmtt25d09f205a")))
+	assert.False(t, legacy.Equals(valid))
+}
+
+func TestSuggestTaxCode(t *testing.T) {
+	testCases := []struct {
+		name      string
+		firstName string
+		lastName  string
+		birthDate time.Time
+		sex       membership.Sex
+		placeCode string
+		expected  string
+	}{
+		{"male", "Matteo", "Moretti", time.Date(1925, 4, 9, 0, 0, 0, 0, time.UTC), membership.Male, "F205", "MRTMTT25D09F205Z"},
+		{"female", "Anna", "Rossi", time.Date(1990, 12, 5, 0, 0, 0, 0, time.UTC), membership.Female, "H294", "RSSNNA90T45H294X"},
+		{"short and accented names", "Nicolò", "Fo", time.Date(2001, 7, 31, 0, 0, 0, 0, time.UTC), membership.Male, "c357", "FOXNCL01L31C357R"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := membership.SuggestTaxCode(tc.firstName, tc.lastName, tc.birthDate, tc.sex, tc.placeCode)
+
+			// Assert
+			assert.True(t, result.IsSuccess())
+			assert.Equal(t, tc.expected, result.Value().Value)
+			assert.Equal(t, tc.sex, result.Value().Sex())
+		})
+	}
+}
+
+func TestTaxCode_CheckAgainst(t *testing.T) {
+	// Arrange
+	taxCode := membership.NewTaxCode("MRTMTT25D09F205Z").Value()
+	user := membership.User{
+		FirstName:  "Matteo",
+		LastName:   "Moretti",
+		BirthDate:  time.Date(1925, 4, 9, 0, 0, 0, 0, time.UTC),
+		BirthPlace: &membership.Address{Country: "Italia", City: "Milano"},
+	}
+
+	testCases := []struct {
+		name   string
+		change func(membership.User) membership.User
+		valid  bool
+	}{
+		{"matching", func(u membership.User) membership.User { return u }, true},
+		{"different last name", func(u membership.User) membership.User { u.LastName = "Morelli"; return u }, false},
+		{"different first name", func(u membership.User) membership.User { u.FirstName = "Marco"; return u }, false},
+		{"different birth date", func(u membership.User) membership.User { u.BirthDate = u.BirthDate.AddDate(0, 0, 1); return u }, false},
+		{"born abroad", func(u membership.User) membership.User {
+			u.BirthPlace = &membership.Address{Country: "France", City: "Paris"}
+			return u
+		}, false},
+		{"no birth place", func(u membership.User) membership.User { u.BirthPlace = nil; return u }, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := taxCode.CheckAgainst(tc.change(user))
+
+			// Assert
+			assert.Equal(t, tc.valid, result.IsSuccess())
+			if !tc.valid {
+				assert.IsType(t, errors.TaxCodeError{}, result.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package notification_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/notification"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+
+// stubOutboxRepository keeps the emails in memory
+type stubOutboxRepository struct {
+	notification.OutboxRepository
+	messages []notification.OutboxMessage
+}
+
+func (r *stubOutboxRepository) Enqueue(message notification.OutboxMessage) result.Result[notification.OutboxMessage] {
+	message.Id = int64(len(r.messages) + 1)
+	r.messages = append(r.messages, message)
+	return result.Ok(message)
+}
+
+func (r *stubOutboxRepository) ClaimDueMessages(now time.Time, claimedUntil time.Time, limit int) result.Result[[]notification.OutboxMessage] {
+	due := []notification.OutboxMessage{}
+	for i, message := range r.messages {
+		if message.Status == notification.Pending && !message.NextAttemptAt.After(now) {
+			r.messages[i].NextAttemptAt = claimedUntil
+			due = append(due, message)
+		}
+	}
+	return result.Ok(due)
+}
+
+func (r *stubOutboxRepository) UpdateMessage(message notification.OutboxMessage) result.Result[notification.OutboxMessage] {
+	r.messages[message.Id-1] = message
+	return result.Ok(message)
+}
+
+type stubRenderer struct{}
+
+func (stubRenderer) Render(n notification.Notification) (notification.EmailMessage, error) {
+	return notification.EmailMessage{To: n.Recipient, Subject: string(n.Kind), Body: fmt.Sprint(n.Data)}, nil
+}
+
+// stubSender rejects the emails sent to the given addresses
+type stubSender struct {
+	rejected map[string]bool
+	sent     []notification.EmailMessage
+}
+
+func (s *stubSender) Send(message notification.EmailMessage) error {
+	if s.rejected[message.To.Email] {
+		return fmt.Errorf("mailbox unavailable")
+	}
+	s.sent = append(s.sent, message)
+	return nil
+}
+
+func TestRetryDelay_DoublesAfterEachAttempt(t *testing.T) {
+	// Assert
+	assert.Equal(t, time.Duration(0), notification.RetryDelay(0))
+	assert.Equal(t, notification.RetryBaseDelay, notification.RetryDelay(1))
+	assert.Equal(t, 2*notification.RetryBaseDelay, notification.RetryDelay(2))
+	assert.Equal(t, 8*notification.RetryBaseDelay, notification.RetryDelay(4))
+}
+
+func TestOutboxMessage_DeliveryFailed(t *testing.T) {
+	// Arrange
+	message := notification.NewOutboxMessage(notification.PaymentReceived, nil, notification.EmailMessage{}, now)
+
+	// Act
+	for range notification.MaxDeliveryAttempts - 1 {
+		message = message.DeliveryFailed(fmt.Errorf("connection refused"), now)
+	}
+
+	// Assert
+	assert.Equal(t, notification.Pending, message.Status)
+	assert.Equal(t, notification.MaxDeliveryAttempts-1, message.Attempts)
+	assert.Equal(t, now.Add(notification.RetryDelay(message.Attempts)), message.NextAttemptAt)
+	assert.Equal(t, "connection refused", message.LastError)
+
+	// Act
+	message = message.DeliveryFailed(fmt.Errorf("connection refused"), now)
+
+	// Assert
+	assert.Equal(t, notification.Failed, message.Status)
+	assert.Equal(t, notification.MaxDeliveryAttempts, message.Attempts)
+}
+
+func TestOutboxMessage_Retry(t *testing.T) {
+	// Arrange
+	pending := notification.NewOutboxMessage(notification.PaymentReceived, nil, notification.EmailMessage{}, now)
+	failed := pending
+	failed.Status = notification.Failed
+	failed.Attempts = notification.MaxDeliveryAttempts
+
+	// Act
+	retried := failed.Retry(now.Add(time.Hour))
+
+	// Assert
+	assert.True(t, retried.IsSuccess())
+	assert.Equal(t, notification.Pending, retried.Value().Status)
+	assert.Equal(t, 0, retried.Value().Attempts)
+	assert.Equal(t, now.Add(time.Hour), retried.Value().NextAttemptAt)
+	assert.IsType(t, errors.NotificationError{}, pending.Retry(now).Error())
+}
+
+func TestNotificationService_Notify_RequiresRecipientEmail(t *testing.T) {
+	// Arrange
+	repository := &stubOutboxRepository{}
+	service := notification.NewNotificationService(repository, stubRenderer{}, &stubSender{})
+
+	// Act
+	queued := service.Notify(notification.Notification{Kind: notification.MembershipCreated, Recipient: notification.Recipient{Name: "Mario Rossi"}}, now)
+
+	// Assert
+	assert.False(t, queued.IsSuccess())
+	assert.IsType(t, errors.NotificationError{}, queued.Error())
+	assert.Empty(t, repository.messages)
+}
+
+func TestNotificationService_DeliverPending(t *testing.T) {
+	// Arrange
+	repository := &stubOutboxRepository{}
+	sender := &stubSender{rejected: map[string]bool{"luigi@example.com": true}}
+	service := notification.NewNotificationService(repository, stubRenderer{}, sender)
+	for _, address := range []string{"mario@example.com", "luigi@example.com"} {
+		queued := service.Notify(notification.Notification{Kind: notification.PaymentReceived, Recipient: notification.Recipient{Email: address}}, now)
+		assert.True(t, queued.IsSuccess())
+	}
+
+	// Act
+	report := service.DeliverPending(now)
+
+	// Assert
+	assert.True(t, report.IsSuccess())
+	assert.Equal(t, notification.DeliveryReport{Sent: 1, Retrying: 1}, report.Value())
+	assert.Len(t, sender.sent, 1)
+	assert.Equal(t, notification.Sent, repository.messages[0].Status)
+	assert.NotNil(t, repository.messages[0].SentAt)
+	assert.Equal(t, notification.Pending, repository.messages[1].Status)
+
+	// Act, the failed email is not attempted again before its delay has passed
+	report = service.DeliverPending(now.Add(time.Minute))
+
+	// Assert
+	assert.Equal(t, notification.DeliveryReport{}, report.Value())
+}
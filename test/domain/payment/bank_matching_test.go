@@ -0,0 +1,327 @@
+package payment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func idOf(id int64) *int64 { return &id }
+
+func bankTransaction(id int64, amount float64, counterparty string, remittance string) payment.BankTransaction {
+	return payment.BankTransaction{
+		Id:               domain.NewId[payment.BankTransaction](id),
+		BookingDate:      time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC),
+		Amount:           money.Euros(amount),
+		CounterpartyName: counterparty,
+		RemittanceInfo:   remittance,
+	}
+}
+
+func membershipItem(periodId int64, firstName string, lastName string, taxCode string, balance float64) payment.OutstandingItem {
+	return payment.OutstandingItem{
+		Target:             payment.MembershipTarget,
+		MembershipPeriodId: idOf(periodId),
+		FirstName:          firstName,
+		LastName:           lastName,
+		TaxCode:            taxCode,
+		Due:                money.Euros(balance),
+		Balance:            money.Euros(balance),
+	}
+}
+
+func facilityItem(rentedFacilityId int64, lastName string, identifier string, balance float64) payment.OutstandingItem {
+	return payment.OutstandingItem{
+		Target:             payment.FacilityTarget,
+		RentedFacilityId:   idOf(rentedFacilityId),
+		LastName:           lastName,
+		FacilityIdentifier: identifier,
+		Due:                money.Euros(balance),
+		Balance:            money.Euros(balance),
+	}
+}
+
+func TestProposeMatches_Scoring(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tx       payment.BankTransaction
+		item     payment.OutstandingItem
+		score    int
+		reasons  []payment.MatchReason
+		proposed bool
+	}{
+		{
+			name:     "tax code and amount",
+			tx:       bankTransaction(1, 150, "Banca Sella", "Quota 2026 rssmra80a01h501u"),
+			item:     membershipItem(10, "Mario", "Rossi", "RSSMRA80A01H501U", 150),
+			score:    80,
+			reasons:  []payment.MatchReason{payment.TaxCodeReason, payment.ExactAmountReason},
+			proposed: true,
+		},
+		{
+			name:     "full name and amount",
+			tx:       bankTransaction(1, 150, "ROSSI MARIO", "Quota associativa"),
+			item:     membershipItem(10, "Mario", "Rossi", "RSSMRA80A01H501U", 150),
+			score:    60,
+			reasons:  []payment.MatchReason{payment.ExactAmountReason, payment.LastNameReason, payment.FirstNameReason},
+			proposed: true,
+		},
+		{
+			name:     "first name alone does not count",
+			tx:       bankTransaction(1, 150, "Mario Bianchi", "Quota"),
+			item:     membershipItem(10, "Mario", "Rossi", "", 150),
+			proposed: false,
+		},
+		{
+			name:     "last name must be a whole word",
+			tx:       bankTransaction(1, 150, "Rossini Anna", "Quota"),
+			item:     membershipItem(10, "Mario", "Rossi", "", 150),
+			proposed: false,
+		},
+		{
+			name:     "facility identifier",
+			tx:       bankTransaction(1, 420, "Verdi Luca", "Posto barca B-12 stagione 2026"),
+			item:     facilityItem(20, "Verdi", "B-12", 400),
+			score:    35,
+			proposed: false,
+		},
+		{
+			name:     "facility identifier and amount",
+			tx:       bankTransaction(1, 400, "Verdi Luca", "Posto barca B-12 stagione 2026"),
+			item:     facilityItem(20, "Verdi", "B-12", 400),
+			score:    65,
+			reasons:  []payment.MatchReason{payment.ExactAmountReason, payment.LastNameReason, payment.FacilityIdentifierReason},
+			proposed: true,
+		},
+		{
+			name:     "debits are never matched",
+			tx:       bankTransaction(1, -150, "Rossi Mario", "RSSMRA80A01H501U"),
+			item:     membershipItem(10, "Mario", "Rossi", "RSSMRA80A01H501U", 150),
+			proposed: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			proposals := payment.ProposeMatches([]payment.BankTransaction{tc.tx}, []payment.OutstandingItem{tc.item})
+
+			// Assert
+			assert.Len(t, proposals, 1)
+			if !tc.proposed {
+				assert.Nil(t, proposals[0].Proposed)
+				return
+			}
+			assert.NotNil(t, proposals[0].Proposed)
+			assert.Equal(t, tc.score, proposals[0].Proposed.Score)
+			assert.Equal(t, tc.reasons, proposals[0].Proposed.Reasons)
+		})
+	}
+}
+
+func TestProposeMatches_ProposesEachItemOnce(t *testing.T) {
+	// Arrange
+	transactions := []payment.BankTransaction{
+		bankTransaction(1, 150, "Rossi Mario", "Quota"),
+		bankTransaction(2, 150, "Rossi Mario", "Quota RSSMRA80A01H501U"),
+	}
+	items := []payment.OutstandingItem{membershipItem(10, "Mario", "Rossi", "RSSMRA80A01H501U", 150)}
+
+	// Act
+	proposals := payment.ProposeMatches(transactions, items)
+
+	// Assert
+	assert.Nil(t, proposals[0].Proposed)
+	assert.Len(t, proposals[0].Candidates, 1)
+	assert.NotNil(t, proposals[1].Proposed)
+	assert.Equal(t, 110, proposals[1].Proposed.Score)
+	assert.Equal(t, payment.HighConfidence, proposals[1].Proposed.Confidence())
+}
+
+func TestProposeMatches_SkipsMatchedTransactions(t *testing.T) {
+	// Arrange
+	matched := bankTransaction(1, 150, "Rossi Mario", "Quota")
+	matched.PaymentId = idOf(99)
+
+	// Act
+	proposals := payment.ProposeMatches(
+		[]payment.BankTransaction{matched},
+		[]payment.OutstandingItem{membershipItem(10, "Mario", "Rossi", "", 150)},
+	)
+
+	// Assert
+	assert.Empty(t, proposals[0].Candidates)
+	assert.Nil(t, proposals[0].Proposed)
+}
+
+func TestMatchConfirmation_Validate(t *testing.T) {
+	testCases := []struct {
+		name         string
+		confirmation payment.MatchConfirmation
+		valid        bool
+	}{
+		{name: "membership", confirmation: payment.MatchConfirmation{MembershipPeriodId: idOf(1)}, valid: true},
+		{name: "rental", confirmation: payment.MatchConfirmation{RentedFacilityId: idOf(1)}, valid: true},
+		{name: "no target", confirmation: payment.MatchConfirmation{}, valid: false},
+		{name: "both targets", confirmation: payment.MatchConfirmation{MembershipPeriodId: idOf(1), RentedFacilityId: idOf(2)}, valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := tc.confirmation.Validate()
+
+			// Assert
+			assert.Equal(t, tc.valid, result.IsSuccess())
+			if !tc.valid {
+				assert.IsType(t, errors.PaymentError{}, result.Error())
+			}
+		})
+	}
+}
+
+func TestDetectBankStatementFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fileName string
+		content  string
+		expected payment.BankStatementFormat
+		valid    bool
+	}{
+		{name: "csv extension", fileName: "movimenti.CSV", content: "Data;Importo", expected: payment.CSVStatement, valid: true},
+		{name: "xml extension", fileName: "estratto.xml", content: "<Document/>", expected: payment.CAMT053Statement, valid: true},
+		{name: "xml content", fileName: "export", content: "\ufeff  <?xml version=\"1.0\"?>", expected: payment.CAMT053Statement, valid: true},
+		{name: "text content", fileName: "export", content: "Data;Importo", expected: payment.CSVStatement, valid: true},
+		{name: "empty", fileName: "export", content: "", valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := payment.DetectBankStatementFormat(tc.fileName, []byte(tc.content))
+
+			// Assert
+			assert.Equal(t, tc.valid, result.IsSuccess())
+			if tc.valid {
+				assert.Equal(t, tc.expected, result.Value())
+			}
+		})
+	}
+}
+
+func TestBankStatement_Validate(t *testing.T) {
+	testCases := []struct {
+		name         string
+		transactions []payment.BankTransaction
+		valid        bool
+	}{
+		{name: "valid", transactions: []payment.BankTransaction{bankTransaction(1, 10, "", "")}, valid: true},
+		{name: "no transactions", transactions: []payment.BankTransaction{}, valid: false},
+		{name: "zero amount", transactions: []payment.BankTransaction{bankTransaction(1, 0.001, "", "")}, valid: false},
+		{name: "missing date", transactions: []payment.BankTransaction{{Amount: money.Euros(10)}}, valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := payment.BankStatement{Transactions: tc.transactions}.Validate()
+
+			// Assert
+			assert.Equal(t, tc.valid, result.IsSuccess())
+		})
+	}
+}
+
+func TestBankStatement_Credits(t *testing.T) {
+	// Arrange
+	statement := payment.BankStatement{Transactions: []payment.BankTransaction{
+		bankTransaction(1, 150, "", ""),
+		bankTransaction(2, -20, "", ""),
+		bankTransaction(3, 0.5, "", ""),
+	}}
+
+	// Act
+	credits := statement.Credits()
+
+	// Assert
+	assert.Len(t, credits, 2)
+	assert.Equal(t, money.Euros(150), credits[0].Amount)
+	assert.Equal(t, money.Euros(0.5), credits[1].Amount)
+}
+
+func TestBankTransaction_Fingerprint(t *testing.T) {
+	const account = "IT60X0542811101000000123456"
+
+	t.Run("same reference, same fingerprint", func(t *testing.T) {
+		// Arrange
+		first := bankTransaction(1, 150, "Rossi Mario", "Quota")
+		first.Reference = "REF-1"
+		second := bankTransaction(2, 200, "Bianchi Anna", "Altro")
+		second.Reference = "REF-1"
+
+		// Assert
+		assert.Equal(t, first.Fingerprint(account, 0), second.Fingerprint(account, 0))
+	})
+
+	t.Run("without reference the content is used", func(t *testing.T) {
+		// Arrange
+		first := bankTransaction(1, 150, "Rossi Mario", "Quota")
+		same := bankTransaction(2, 150.001, " ROSSI MARIO", "quota")
+		other := bankTransaction(3, 150, "Rossi Mario", "Quota 2026")
+
+		// Assert
+		assert.Equal(t, first.Fingerprint(account, 0), same.Fingerprint(account, 0))
+		assert.NotEqual(t, first.Fingerprint(account, 0), other.Fingerprint(account, 0))
+	})
+
+	t.Run("account and occurrence are part of the fingerprint", func(t *testing.T) {
+		// Arrange
+		transaction := bankTransaction(1, 150, "Rossi Mario", "Quota")
+
+		// Assert
+		assert.Equal(t, transaction.Fingerprint(account, 0), transaction.Fingerprint("IT60 X054 2811 1010 0000 0123 456", 0))
+		assert.NotEqual(t, transaction.Fingerprint(account, 0), transaction.Fingerprint("IT02L1234512345123456789012", 0))
+		assert.NotEqual(t, transaction.Fingerprint(account, 0), transaction.Fingerprint(account, 1))
+	})
+}
+
+func TestBankStatement_Fingerprints(t *testing.T) {
+	// Arrange
+	fee := bankTransaction(1, 150, "Rossi Mario", "Quota")
+	rack := bankTransaction(2, 60, "Rossi Mario", "Rastrelliera")
+	statement := payment.BankStatement{AccountIBAN: "IT60X0542811101000000123456", Transactions: []payment.BankTransaction{fee, rack, fee}}
+	overlapping := payment.BankStatement{AccountIBAN: statement.AccountIBAN, Transactions: []payment.BankTransaction{fee, fee, rack}}
+	otherAccount := payment.BankStatement{AccountIBAN: "IT02L1234512345123456789012", Transactions: []payment.BankTransaction{fee}}
+
+	// Act
+	fingerprints := statement.Fingerprints()
+
+	// Assert
+	assert.Len(t, fingerprints, 3)
+	assert.NotEqual(t, fingerprints[0], fingerprints[2], "identical transfers of the same statement are both imported")
+	assert.ElementsMatch(t, fingerprints, overlapping.Fingerprints(), "a statement covering the same days imports nothing new")
+	assert.NotContains(t, fingerprints, otherAccount.Fingerprints()[0], "statements of other accounts are not duplicates")
+}
+
+func TestBankTransaction_ToPayment(t *testing.T) {
+	// Arrange
+	transaction := bankTransaction(1, 150, "Rossi Mario", "  Quota 2026 ")
+	transaction.Amount = money.New(15000, "")
+
+	// Act
+	transfer := transaction.ToPayment()
+
+	// Assert
+	assert.Equal(t, payment.Transaction{
+		Type:          payment.PaymentTransaction,
+		Amount:        money.Euros(150),
+		Date:          transaction.BookingDate,
+		PaymentMethod: "bank transfer",
+		Notes:         "Quota 2026",
+	}, transfer)
+}
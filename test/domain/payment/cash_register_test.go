@@ -0,0 +1,215 @@
+package payment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func amountOf(a float64) *money.Money {
+	amount := money.Euros(a)
+	return &amount
+}
+
+func record(method string, transactionType payment.TransactionType, amount float64) payment.PaymentRecord {
+	return payment.PaymentRecord{Transaction: payment.Transaction{Type: transactionType, Amount: money.Euros(amount), PaymentMethod: method}}
+}
+
+func cashDay() payment.CashDay {
+	return payment.CashDay{
+		Date:           time.Date(2026, time.June, 15, 0, 0, 0, 0, time.UTC),
+		OpeningBalance: money.Euros(100),
+		Transactions: []payment.PaymentRecord{
+			record("cash", payment.PaymentTransaction, 130),
+			record("Cash", payment.PaymentTransaction, 50.5),
+			record("cash", payment.RefundTransaction, 20),
+			record("bank transfer", payment.PaymentTransaction, 300),
+			record("credit card", payment.PaymentTransaction, 80),
+		},
+	}
+}
+
+func TestCashDay_ExpectedCash(t *testing.T) {
+	// Act
+	expected := cashDay().ExpectedCash()
+
+	// Assert
+	assert.Equal(t, money.Euros(260.5), expected.Value())
+}
+
+func TestCashDay_TotalsByMethod(t *testing.T) {
+	// Act
+	totals := cashDay().TotalsByMethod()
+
+	// Assert
+	assert.True(t, totals.IsSuccess())
+	assert.Equal(t, []payment.MethodTotal{
+		{PaymentMethod: "bank transfer", Count: 1, Paid: money.Euros(300), Refunded: money.Zero(money.EUR)},
+		{PaymentMethod: "cash", Count: 3, Paid: money.Euros(180.5), Refunded: money.Euros(20)},
+		{PaymentMethod: "credit card", Count: 1, Paid: money.Euros(80), Refunded: money.Zero(money.EUR)},
+	}, totals.Value())
+}
+
+func TestCashDay_Discrepancies(t *testing.T) {
+	testCases := []struct {
+		name     string
+		modify   func(d *payment.CashDay)
+		expected []payment.Discrepancy
+	}{
+		{name: "nothing recorded", modify: func(d *payment.CashDay) {}, expected: []payment.Discrepancy{}},
+		{name: "counted cash matches", modify: func(d *payment.CashDay) {
+			d.CountedCash = amountOf(260.5)
+			d.BankTransfers = amountOf(300)
+		}, expected: []payment.Discrepancy{}},
+		{name: "cash missing from the box", modify: func(d *payment.CashDay) { d.CountedCash = amountOf(250) }, expected: []payment.Discrepancy{
+			{Type: payment.CashCountDiscrepancy, Expected: money.Euros(260.5), Actual: money.Euros(250)},
+		}},
+		{name: "transfer not on the bank statement", modify: func(d *payment.CashDay) { d.BankTransfers = amountOf(0) }, expected: []payment.Discrepancy{
+			{Type: payment.BankTransfersDiscrepancy, Expected: money.Euros(300), Actual: money.Euros(0)},
+		}},
+		{name: "opening balance differs from the previous closing", modify: func(d *payment.CashDay) { d.PreviousCountedCash = amountOf(90) }, expected: []payment.Discrepancy{
+			{Type: payment.OpeningBalanceDiscrepancy, Expected: money.Euros(90), Actual: money.Euros(100)},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			day := cashDay()
+			tc.modify(&day)
+
+			// Act
+			discrepancies := day.Discrepancies()
+
+			// Assert
+			assert.Equal(t, tc.expected, discrepancies.Value())
+		})
+	}
+}
+
+func TestDiscrepancy_Difference(t *testing.T) {
+	// Arrange
+	discrepancy := payment.Discrepancy{Type: payment.CashCountDiscrepancy, Expected: money.Euros(260.5), Actual: money.Euros(250.2)}
+
+	// Act
+	difference := discrepancy.Difference()
+
+	// Assert
+	assert.Equal(t, money.Euros(-10.3), difference.Value())
+}
+
+func TestCashDay_Close(t *testing.T) {
+	now := time.Date(2026, time.June, 15, 19, 30, 0, 0, time.UTC)
+	closedAt := now.Add(-time.Hour)
+
+	testCases := []struct {
+		name          string
+		modify        func(d *payment.CashDay)
+		record        payment.CashDayRecord
+		expectedValid bool
+	}{
+		{name: "cash counted", modify: func(d *payment.CashDay) {}, record: payment.CashDayRecord{CountedCash: amountOf(260.5)}, expectedValid: true},
+		{name: "cash counted before", modify: func(d *payment.CashDay) { d.CountedCash = amountOf(260.5) }, record: payment.CashDayRecord{}, expectedValid: true},
+		{name: "cash not counted", modify: func(d *payment.CashDay) {}, record: payment.CashDayRecord{}, expectedValid: false},
+		{name: "negative amount", modify: func(d *payment.CashDay) {}, record: payment.CashDayRecord{CountedCash: amountOf(-1)}, expectedValid: false},
+		{name: "already closed", modify: func(d *payment.CashDay) {
+			d.CountedCash = amountOf(260.5)
+			d.ClosedAt = &closedAt
+		}, record: payment.CashDayRecord{}, expectedValid: false},
+		{name: "day not started", modify: func(d *payment.CashDay) { d.Date = d.Date.AddDate(0, 0, 1) }, record: payment.CashDayRecord{CountedCash: amountOf(0)}, expectedValid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			day := cashDay()
+			tc.modify(&day)
+
+			// Act
+			closed := day.Close(tc.record, now)
+
+			// Assert
+			assert.Equal(t, tc.expectedValid, closed.IsSuccess())
+			if tc.expectedValid {
+				assert.True(t, closed.Value().IsClosed())
+				assert.Equal(t, payment.CashDayClosed, closed.Value().GetStatus())
+			} else {
+				assert.IsType(t, errors.PaymentError{}, closed.Error())
+			}
+		})
+	}
+}
+
+func TestCashDay_CurrencyMismatch(t *testing.T) {
+	now := time.Date(2026, time.June, 15, 19, 30, 0, 0, time.UTC)
+	inDollars := money.New(5000, "USD")
+
+	testCases := []struct {
+		name   string
+		modify func(d *payment.CashDay)
+		record payment.CashDayRecord
+	}{
+		{name: "cash payment in another currency", modify: func(d *payment.CashDay) {
+			d.Transactions = append(d.Transactions, payment.PaymentRecord{Transaction: payment.Transaction{Type: payment.PaymentTransaction, Amount: inDollars, PaymentMethod: "cash"}})
+		}, record: payment.CashDayRecord{CountedCash: amountOf(260.5)}},
+		{name: "bank transfer in another currency", modify: func(d *payment.CashDay) {
+			d.Transactions = append(d.Transactions, payment.PaymentRecord{Transaction: payment.Transaction{Type: payment.PaymentTransaction, Amount: inDollars, PaymentMethod: "bank transfer"}})
+		}, record: payment.CashDayRecord{CountedCash: amountOf(260.5)}},
+		{name: "cash counted in another currency", modify: func(d *payment.CashDay) {}, record: payment.CashDayRecord{CountedCash: &inDollars}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			day := cashDay()
+			tc.modify(&day)
+
+			// Act
+			closed := day.Close(tc.record, now)
+
+			// Assert
+			assert.False(t, closed.IsSuccess())
+			assert.IsType(t, errors.CurrencyMismatchError{}, closed.Error())
+		})
+	}
+
+	// Arrange
+	day := cashDay()
+	day.Transactions = append(day.Transactions, payment.PaymentRecord{Transaction: payment.Transaction{Type: payment.PaymentTransaction, Amount: inDollars, PaymentMethod: "credit card"}})
+
+	// Act & Assert
+	assert.IsType(t, errors.CurrencyMismatchError{}, day.TotalsByMethod().Error())
+	assert.True(t, day.ExpectedCash().IsSuccess(), "card payments are not in the cash box")
+}
+
+func TestValidateCashRegisterPeriod(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name          string
+		from          time.Time
+		to            time.Time
+		expectedValid bool
+	}{
+		{name: "single day", from: day(2026, time.June, 15), to: day(2026, time.June, 15), expectedValid: true},
+		{name: "a whole year", from: day(2026, time.January, 1), to: day(2026, time.December, 31), expectedValid: true},
+		{name: "dates inverted", from: day(2026, time.June, 16), to: day(2026, time.June, 15), expectedValid: false},
+		{name: "too long", from: day(2025, time.January, 1), to: day(2026, time.January, 2), expectedValid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			validated := payment.ValidateCashRegisterPeriod(tc.from, tc.to)
+
+			// Assert
+			assert.Equal(t, tc.expectedValid, validated.IsSuccess())
+		})
+	}
+}
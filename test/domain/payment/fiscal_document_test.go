@@ -0,0 +1,215 @@
+package payment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var clubIssuer = payment.Issuer{
+	Name:             "Circolo Nautico Cattolica",
+	TaxCode:          "91000000000",
+	VATExemptionNote: "Operazione fuori campo IVA",
+}
+
+func billable(id int64, target payment.PaymentTarget, amount money.Money, paidOn time.Time) payment.BillablePayment {
+	return payment.BillablePayment{
+		Record: payment.PaymentRecord{
+			Transaction: payment.Transaction{
+				Id:            domain.NewId[payment.Transaction](id),
+				Type:          payment.PaymentTransaction,
+				Amount:        amount,
+				Date:          paidOn,
+				PaymentMethod: "cash",
+			},
+			Target:             target,
+			MemberId:           7,
+			FirstName:          "Mario",
+			LastName:           "Rossi",
+			FacilityIdentifier: "B-12",
+		},
+		SeasonCode:   "2026",
+		FacilityName: "Posto barca",
+		TaxCode:      " rssmra80a01h501u",
+	}
+}
+
+func TestNewFiscalDocument(t *testing.T) {
+	// Arrange
+	issuedOn := time.Date(2026, time.March, 2, 15, 30, 0, 0, time.UTC)
+	payments := []payment.BillablePayment{
+		billable(3, payment.FacilityTarget, money.Euros(400), time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)),
+		billable(4, payment.MembershipTarget, money.Euros(150), time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)),
+	}
+
+	// Act
+	result := payment.NewFiscalDocument(payment.ReceiptDocument, clubIssuer, payments, issuedOn)
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+	document := result.Value()
+	assert.Equal(t, 2026, document.FiscalYear)
+	assert.Equal(t, time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC), document.IssuedOn)
+	assert.Equal(t, "RSSMRA80A01H501U", document.Recipient.TaxCode)
+	assert.Equal(t, int64(7), document.Recipient.MemberId)
+	assert.Equal(t, []payment.FiscalDocumentLine{
+		{PaymentId: 4, Description: "Quota associativa - stagione 2026", PaidOn: time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC), PaymentMethod: "cash", Amount: money.Euros(150)},
+		{PaymentId: 3, Description: "Posto barca B-12 - stagione 2026", PaidOn: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), PaymentMethod: "cash", Amount: money.Euros(400)},
+	}, document.Lines)
+	assert.Equal(t, money.Euros(550), document.Total())
+}
+
+func TestNewFiscalDocument_BusinessRecipient(t *testing.T) {
+	// Arrange
+	issuer := clubIssuer
+	issuer.RentalVATRate = 22
+	issuedOn := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	payments := []payment.BillablePayment{
+		billable(3, payment.FacilityTarget, money.Euros(122), issuedOn),
+		billable(4, payment.MembershipTarget, money.Euros(150), issuedOn),
+	}
+	for i := range payments {
+		payments[i].CompanyName = "Rossi Charter"
+		payments[i].VATNumber = "01234567897"
+		payments[i].RecipientCode = "abc1234"
+	}
+
+	// Act
+	business := payment.NewFiscalDocument(payment.InvoiceDocument, issuer, payments, issuedOn)
+	private := payment.NewFiscalDocument(payment.InvoiceDocument, issuer, []payment.BillablePayment{billable(3, payment.FacilityTarget, money.Euros(122), issuedOn)}, issuedOn)
+
+	// Assert
+	assert.True(t, business.IsSuccess())
+	recipient := business.Value().Recipient
+	assert.True(t, recipient.IsBusiness())
+	assert.Equal(t, "Rossi Charter", recipient.CompanyName)
+	assert.Equal(t, "ABC1234", recipient.RecipientCode)
+	assert.Equal(t, 0.0, business.Value().Lines[0].VATRate, "membership fees stay out of the scope of VAT")
+	assert.Equal(t, 22.0, business.Value().Lines[1].VATRate, "rentals to businesses are taxed")
+	assert.Equal(t, money.Euros(150), business.Value().ExemptTotal())
+	assert.True(t, business.Value().RequiresStampDuty(), "the stamp duty is due on the amount out of the scope of VAT")
+
+	assert.True(t, private.IsSuccess())
+	assert.False(t, private.Value().Recipient.IsBusiness())
+	assert.Equal(t, 0.0, private.Value().Lines[0].VATRate, "rentals to private members are out of the scope of VAT")
+}
+
+func TestNewFiscalDocument_Notes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		issuer   payment.Issuer
+		amount   money.Money
+		expected []string
+	}{
+		{name: "below the stamp duty threshold", issuer: clubIssuer, amount: money.Euros(77.47), expected: []string{"Operazione fuori campo IVA"}},
+		{name: "above the stamp duty threshold", issuer: clubIssuer, amount: money.Euros(77.48), expected: []string{
+			"Operazione fuori campo IVA",
+			"Imposta di bollo di 2.00 EUR assolta sull'originale",
+		}},
+		{name: "issuer subject to VAT", issuer: payment.Issuer{Name: "Circolo", VATNumber: "01234567890"}, amount: money.Euros(500), expected: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := payment.NewFiscalDocument(payment.ReceiptDocument, tc.issuer, []payment.BillablePayment{
+				billable(1, payment.MembershipTarget, tc.amount, time.Now()),
+			}, time.Now())
+
+			// Assert
+			assert.True(t, result.IsSuccess())
+			assert.Equal(t, tc.expected, result.Value().Notes)
+		})
+	}
+}
+
+func TestNewFiscalDocument_Invalid(t *testing.T) {
+	documentId := int64(12)
+	refund := billable(2, payment.MembershipTarget, money.Euros(50), time.Now())
+	refund.Record.Transaction.Type = payment.RefundTransaction
+	otherMember := billable(2, payment.MembershipTarget, money.Euros(50), time.Now())
+	otherMember.Record.MemberId = 8
+	otherCurrency := billable(2, payment.MembershipTarget, money.Euros(50), time.Now())
+	otherCurrency.Record.Transaction.Amount = money.New(5000, "USD")
+	documented := billable(2, payment.MembershipTarget, money.Euros(50), time.Now())
+	documented.FiscalDocumentId = &documentId
+
+	testCases := []struct {
+		name     string
+		kind     payment.FiscalDocumentKind
+		issuer   payment.Issuer
+		payments []payment.BillablePayment
+	}{
+		{name: "unknown kind", kind: "CREDIT_NOTE", issuer: clubIssuer, payments: []payment.BillablePayment{billable(1, payment.MembershipTarget, money.Euros(50), time.Now())}},
+		{name: "issuer without tax code", kind: payment.ReceiptDocument, issuer: payment.Issuer{Name: "Circolo"}, payments: []payment.BillablePayment{billable(1, payment.MembershipTarget, money.Euros(50), time.Now())}},
+		{name: "no payments", kind: payment.ReceiptDocument, issuer: clubIssuer, payments: []payment.BillablePayment{}},
+		{name: "refund", kind: payment.ReceiptDocument, issuer: clubIssuer, payments: []payment.BillablePayment{refund}},
+		{name: "another member", kind: payment.ReceiptDocument, issuer: clubIssuer, payments: []payment.BillablePayment{billable(1, payment.MembershipTarget, money.Euros(50), time.Now()), otherMember}},
+		{name: "another currency", kind: payment.ReceiptDocument, issuer: clubIssuer, payments: []payment.BillablePayment{billable(1, payment.MembershipTarget, money.Euros(50), time.Now()), otherCurrency}},
+		{name: "already documented", kind: payment.ReceiptDocument, issuer: clubIssuer, payments: []payment.BillablePayment{documented}},
+		{name: "zero total", kind: payment.InvoiceDocument, issuer: clubIssuer, payments: []payment.BillablePayment{billable(1, payment.MembershipTarget, money.Euros(0), time.Now())}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := payment.NewFiscalDocument(tc.kind, tc.issuer, tc.payments, time.Now())
+
+			// Assert
+			assert.False(t, result.IsSuccess())
+			assert.IsType(t, errors.PaymentError{}, result.Error())
+		})
+	}
+}
+
+func TestBillablePayment_Description(t *testing.T) {
+	// Arrange
+	withoutSeason := billable(1, payment.FacilityTarget, money.Euros(50), time.Now())
+	withoutSeason.SeasonCode = ""
+	unnamed := billable(1, payment.FacilityTarget, money.Euros(50), time.Now())
+	unnamed.FacilityName = ""
+	unnamed.Record.FacilityIdentifier = ""
+
+	// Assert
+	assert.Equal(t, "Quota associativa - stagione 2026", billable(1, payment.MembershipTarget, money.Euros(50), time.Now()).Description())
+	assert.Equal(t, "Posto barca B-12", withoutSeason.Description())
+	assert.Equal(t, "Servizio - stagione 2026", unnamed.Description())
+}
+
+func TestFiscalDocument_Code(t *testing.T) {
+	// Arrange
+	document := payment.FiscalDocument{FiscalYear: 2026, Number: 12}
+
+	// Assert
+	assert.Equal(t, "12/2026", document.Code())
+}
+
+func TestFiscalDocument_RequiresStampDuty(t *testing.T) {
+	testCases := []struct {
+		name     string
+		issuer   payment.Issuer
+		amount   money.Money
+		exempt   bool
+		expected bool
+	}{
+		{name: "exempt below the threshold", issuer: clubIssuer, amount: money.Euros(77.47), exempt: true, expected: false},
+		{name: "exempt above the threshold", issuer: clubIssuer, amount: money.Euros(77.48), exempt: true, expected: true},
+		{name: "subject to VAT", issuer: payment.Issuer{Name: "Circolo", TaxCode: "91000000000", VATExemptionNote: "  "}, amount: money.Euros(500), exempt: false, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			document := payment.FiscalDocument{Issuer: tc.issuer, Currency: "EUR", Lines: []payment.FiscalDocumentLine{{Amount: tc.amount}}}
+
+			// Assert
+			assert.Equal(t, tc.exempt, document.IsVATExempt())
+			assert.Equal(t, tc.expected, document.RequiresStampDuty())
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package payment_test
+
+import (
+	"testing"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func paid(id int64, amount money.Money) payment.Transaction {
+	return payment.Transaction{Id: domain.NewId[payment.Transaction](id), Type: payment.PaymentTransaction, Amount: amount}
+}
+
+func refunded(id int64, amount money.Money) payment.Transaction {
+	return payment.Transaction{Id: domain.NewId[payment.Transaction](id), Type: payment.RefundTransaction, Amount: amount}
+}
+
+func TestLedger_GetStatus(t *testing.T) {
+	testCases := []struct {
+		name            string
+		ledger          payment.Ledger
+		expectedStatus  payment.PaymentStatus
+		expectedBalance money.Money
+		expectedSettled bool
+	}{
+		{name: "nothing paid", ledger: payment.Ledger{Due: money.Euros(130)}, expectedStatus: payment.Unpaid, expectedBalance: money.Euros(130), expectedSettled: false},
+		{name: "first instalment", ledger: payment.Ledger{Due: money.Euros(130), Transactions: []payment.Transaction{paid(1, money.Euros(50))}}, expectedStatus: payment.PartiallyPaid, expectedBalance: money.Euros(80), expectedSettled: false},
+		{name: "paid in instalments", ledger: payment.Ledger{Due: money.Euros(0.3), Transactions: []payment.Transaction{paid(1, money.Euros(0.1)), paid(2, money.Euros(0.2))}}, expectedStatus: payment.Paid, expectedBalance: money.Euros(0), expectedSettled: true},
+		{name: "paid too much", ledger: payment.Ledger{Due: money.Euros(130), Transactions: []payment.Transaction{paid(1, money.Euros(150))}}, expectedStatus: payment.Overpaid, expectedBalance: money.Euros(-20), expectedSettled: true},
+		{name: "overpayment given back", ledger: payment.Ledger{Due: money.Euros(130), Transactions: []payment.Transaction{paid(1, money.Euros(150)), refunded(2, money.Euros(20))}}, expectedStatus: payment.Paid, expectedBalance: money.Euros(0), expectedSettled: true},
+		{name: "partially refunded", ledger: payment.Ledger{Due: money.Euros(130), Transactions: []payment.Transaction{paid(1, money.Euros(130)), refunded(2, money.Euros(30))}}, expectedStatus: payment.PartiallyPaid, expectedBalance: money.Euros(30), expectedSettled: false},
+		{name: "fully refunded", ledger: payment.Ledger{Due: money.Euros(130), Transactions: []payment.Transaction{paid(1, money.Euros(130)), refunded(2, money.Euros(130))}}, expectedStatus: payment.Refunded, expectedBalance: money.Euros(130), expectedSettled: false},
+		{name: "nothing due", ledger: payment.Ledger{Due: money.Euros(0)}, expectedStatus: payment.Paid, expectedBalance: money.Euros(0), expectedSettled: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act & Assert
+			assert.Equal(t, tc.expectedStatus, tc.ledger.GetStatus().Value())
+			assert.Equal(t, tc.expectedBalance, tc.ledger.Balance().Value())
+			assert.Equal(t, tc.expectedSettled, tc.ledger.IsSettled().Value())
+		})
+	}
+}
+
+func TestLedger_Totals(t *testing.T) {
+	// Arrange
+	ledger := payment.Ledger{Due: money.Euros(100), Transactions: []payment.Transaction{paid(1, money.Euros(60.1)), paid(2, money.Euros(50.2)), refunded(3, money.Euros(10.3))}}
+
+	// Act & Assert
+	assert.Equal(t, money.Euros(110.3), ledger.Paid().Value())
+	assert.Equal(t, money.Euros(10.3), ledger.Refunded().Value())
+	assert.Equal(t, money.Euros(100), ledger.NetPaid().Value())
+	assert.Equal(t, int64(2), ledger.LastPayment().Id.Value)
+}
+
+func TestLedger_Totals_CurrencyMismatch(t *testing.T) {
+	// Arrange
+	ledger := payment.Ledger{Due: money.Euros(130), Transactions: []payment.Transaction{paid(1, money.Euros(100)), paid(2, money.New(3000, "USD"))}}
+
+	// Act & Assert
+	assert.IsType(t, errors.CurrencyMismatchError{}, ledger.Paid().Error())
+	assert.IsType(t, errors.CurrencyMismatchError{}, ledger.NetPaid().Error())
+	assert.IsType(t, errors.CurrencyMismatchError{}, ledger.Balance().Error())
+	assert.IsType(t, errors.CurrencyMismatchError{}, ledger.GetStatus().Error())
+	assert.IsType(t, errors.CurrencyMismatchError{}, ledger.IsSettled().Error())
+	assert.True(t, ledger.Refunded().IsSuccess(), "no refund is in another currency")
+}
+
+func TestLedger_Validate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ledger        payment.Ledger
+		expectedValid bool
+	}{
+		{name: "no transactions", ledger: payment.Ledger{Due: money.Euros(130)}, expectedValid: true},
+		{name: "refund covered by payments", ledger: payment.Ledger{Due: money.Euros(130)}.With(paid(1, money.Euros(130))).With(refunded(2, money.Euros(130))), expectedValid: true},
+		{name: "refund larger than payments", ledger: payment.Ledger{Due: money.Euros(130)}.With(paid(1, money.Euros(50))).With(refunded(2, money.Euros(60))), expectedValid: false},
+		{name: "refunded payment removed", ledger: payment.Ledger{Due: money.Euros(130)}.With(paid(1, money.Euros(50))).With(refunded(2, money.Euros(50))).Without(domain.NewId[payment.Transaction](1)), expectedValid: false},
+		{name: "refunded payment reduced", ledger: payment.Ledger{Due: money.Euros(130)}.With(paid(1, money.Euros(50))).With(refunded(2, money.Euros(50))).With(paid(1, money.Euros(40))), expectedValid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			validated := tc.ledger.Validate()
+
+			// Assert
+			assert.Equal(t, tc.expectedValid, validated.IsSuccess())
+			if !tc.expectedValid {
+				assert.IsType(t, errors.PaymentError{}, validated.Error())
+			}
+		})
+	}
+}
+
+func TestLedger_Validate_CurrencyMismatch(t *testing.T) {
+	// Arrange
+	inDollars := paid(1, money.New(13000, "USD"))
+	inEuros := paid(2, money.Euros(130))
+
+	// Act
+	mismatched := payment.Ledger{Due: money.Euros(130)}.With(inDollars).Validate()
+	matched := payment.Ledger{Due: money.Euros(130)}.With(inEuros).Validate()
+
+	// Assert
+	assert.IsType(t, errors.CurrencyMismatchError{}, mismatched.Error())
+	assert.True(t, matched.IsSuccess())
+}
+
+func TestValidateTransaction(t *testing.T) {
+	testCases := []struct {
+		name          string
+		transaction   payment.Transaction
+		expectedValid bool
+		expectedType  payment.TransactionType
+	}{
+		{name: "type defaults to payment", transaction: payment.Transaction{Amount: money.Euros(50)}, expectedValid: true, expectedType: payment.PaymentTransaction},
+		{name: "refund", transaction: payment.Transaction{Type: payment.RefundTransaction, Amount: money.Euros(50)}, expectedValid: true, expectedType: payment.RefundTransaction},
+		{name: "unknown type", transaction: payment.Transaction{Type: "DISCOUNT", Amount: money.Euros(50)}, expectedValid: false},
+		{name: "negative amount", transaction: payment.Transaction{Amount: money.Euros(-1)}, expectedValid: false},
+		{name: "empty refund", transaction: payment.Transaction{Type: payment.RefundTransaction, Amount: money.Zero(money.EUR)}, expectedValid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			validated := payment.ValidateTransaction(tc.transaction)
+
+			// Assert
+			assert.Equal(t, tc.expectedValid, validated.IsSuccess())
+			if tc.expectedValid {
+				assert.Equal(t, tc.expectedType, validated.Value().Type)
+			} else {
+				assert.IsType(t, errors.PaymentError{}, validated.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package payment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+var dueOn = time.Date(2026, time.April, 30, 0, 0, 0, 0, time.UTC)
+
+// stubDeadlineRepository keeps the deadlines and the outstanding items in memory
+type stubDeadlineRepository struct {
+	payment.PaymentDeadlineRepository
+	deadlines []payment.PaymentDeadline
+	items     []payment.OutstandingItem
+	charged   []payment.ChargedLateFee
+}
+
+func (r *stubDeadlineRepository) GetDeadlines(seasonId *int64) result.Result[[]payment.PaymentDeadline] {
+	return result.Ok(r.deadlines)
+}
+
+func (r *stubDeadlineRepository) SetDeadlines(seasonId int64, deadlines []payment.PaymentDeadline) result.Result[[]payment.PaymentDeadline] {
+	r.deadlines = deadlines
+	return result.Ok(deadlines)
+}
+
+func (r *stubDeadlineRepository) GetOutstandingItems(seasonId *int64) result.Result[[]payment.OutstandingItem] {
+	return result.Ok(r.items)
+}
+
+func (r *stubDeadlineRepository) GetChargedLateFees(seasonId *int64) result.Result[[]payment.ChargedLateFee] {
+	return result.Ok(r.charged)
+}
+
+func (r *stubDeadlineRepository) ChargeLateFees(fees []payment.ChargedLateFee) result.Result[bool] {
+	r.charged = append(r.charged, fees...)
+	return result.Ok(true)
+}
+
+func seasonItem(target payment.PaymentTarget, memberId int64, facilityTypeId *int64, balance float64) payment.OutstandingItem {
+	return payment.OutstandingItem{
+		Target:         target,
+		MemberId:       memberId,
+		SeasonId:       1,
+		FacilityTypeId: facilityTypeId,
+		Due:            money.Euros(balance),
+		Balance:        money.Euros(balance),
+	}
+}
+
+func TestNewPaymentDeadline_Validation(t *testing.T) {
+	testCases := []struct {
+		name           string
+		target         payment.PaymentTarget
+		facilityTypeId *int64
+		lateFee        *payment.LateFeeRule
+		valid          bool
+	}{
+		{"membership", payment.MembershipTarget, nil, nil, true},
+		{"facility type", payment.FacilityTarget, idOf(2), &payment.LateFeeRule{Kind: payment.FixedLateFee, Amount: money.Euros(10)}, true},
+		{"membership with facility type", payment.MembershipTarget, idOf(2), nil, false},
+		{"rental without facility type", payment.FacilityTarget, nil, nil, false},
+		{"unknown target", payment.PaymentTarget("DONATION"), nil, nil, false},
+		{"unknown fee kind", payment.MembershipTarget, nil, &payment.LateFeeRule{Kind: "DAILY", Amount: money.Euros(1)}, false},
+		{"zero fee", payment.MembershipTarget, nil, &payment.LateFeeRule{Kind: payment.FixedLateFee}, false},
+		{"percentage over 100", payment.MembershipTarget, nil, &payment.LateFeeRule{Kind: payment.PercentageLateFee, Percentage: 150}, false},
+		{"negative grace days", payment.MembershipTarget, nil, &payment.LateFeeRule{Kind: payment.FixedLateFee, Amount: money.Euros(5), AfterDays: -1}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			deadline := payment.NewPaymentDeadline(1, tc.target, tc.facilityTypeId, dueOn, tc.lateFee)
+
+			// Assert
+			assert.Equal(t, tc.valid, deadline.IsSuccess())
+			if !tc.valid {
+				assert.IsType(t, errors.PaymentError{}, deadline.Error())
+			}
+		})
+	}
+}
+
+func TestLateFeeRule_Fee(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     payment.LateFeeRule
+		balance  money.Money
+		daysLate int
+		expected money.Money
+	}{
+		{"fixed", payment.LateFeeRule{Kind: payment.FixedLateFee, Amount: money.Euros(15)}, money.Euros(200), 1, money.Euros(15)},
+		{"percentage of the balance", payment.LateFeeRule{Kind: payment.PercentageLateFee, Percentage: 10}, money.Euros(123.45), 1, money.Euros(12.35)},
+		{"during the grace days", payment.LateFeeRule{Kind: payment.FixedLateFee, Amount: money.Euros(15), AfterDays: 10}, money.Euros(200), 10, money.Euros(0)},
+		{"after the grace days", payment.LateFeeRule{Kind: payment.FixedLateFee, Amount: money.Euros(15), AfterDays: 10}, money.Euros(200), 11, money.Euros(15)},
+		{"nothing owed", payment.LateFeeRule{Kind: payment.FixedLateFee, Amount: money.Euros(15)}, money.Euros(0), 30, money.Euros(0)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act & Assert
+			assert.Equal(t, tc.expected, tc.rule.Fee(tc.balance, tc.daysLate).Value())
+		})
+	}
+}
+
+func TestLateFeeRule_Fee_CurrencyMismatch(t *testing.T) {
+	// Arrange
+	rule := payment.LateFeeRule{Kind: payment.FixedLateFee, Amount: money.FromFloat(15, "CHF")}
+
+	// Act
+	fee := rule.Fee(money.Euros(200), 1)
+
+	// Assert
+	assert.IsType(t, errors.CurrencyMismatchError{}, fee.Error())
+}
+
+func TestPaymentDeadline_Overdue(t *testing.T) {
+	// Arrange
+	deadline := payment.PaymentDeadline{SeasonId: 1, Target: payment.MembershipTarget, DueOn: dueOn, LateFee: &payment.LateFeeRule{Kind: payment.FixedLateFee, Amount: money.Euros(20), AfterDays: 5}}
+	item := seasonItem(payment.MembershipTarget, 7, nil, 150)
+
+	// Act & Assert
+	assert.Nil(t, deadline.Overdue(item, nil, dueOn.Add(23*time.Hour)).Value(), "the due date itself is not late")
+	assert.Nil(t, deadline.Overdue(seasonItem(payment.MembershipTarget, 7, nil, 0), nil, dueOn.AddDate(0, 1, 0)).Value(), "nothing is owed")
+
+	overdue := deadline.Overdue(item, nil, dueOn.AddDate(0, 0, 3)).Value()
+	assert.NotNil(t, overdue)
+	assert.Equal(t, 3, overdue.DaysLate)
+	assert.Equal(t, money.Zero(money.EUR), overdue.LateFee)
+	assert.Equal(t, money.Euros(150), overdue.Total)
+
+	overdue = deadline.Overdue(item, nil, dueOn.AddDate(0, 0, 6)).Value()
+	assert.Equal(t, money.Euros(20), overdue.LateFee)
+	assert.Equal(t, money.Euros(170), overdue.Total)
+	assert.Equal(t, dueOn.AddDate(0, 0, 6), *overdue.LateFeeChargedOn, "the fee is charged the first day it applies")
+}
+
+func TestPaymentDeadline_Overdue_KeepsTheChargedFee(t *testing.T) {
+	// Arrange
+	deadline := payment.PaymentDeadline{SeasonId: 1, Target: payment.MembershipTarget, DueOn: dueOn, LateFee: &payment.LateFeeRule{Kind: payment.PercentageLateFee, Percentage: 10}}
+	chargedOn := dueOn.AddDate(0, 0, 1)
+	charged := &payment.ChargedLateFee{MembershipPeriodId: idOf(3), Amount: money.Euros(20), ChargedOn: chargedOn}
+	partlyPaid := seasonItem(payment.MembershipTarget, 7, nil, 50)
+	partlyPaid.MembershipPeriodId = idOf(3)
+
+	// Act
+	overdue := deadline.Overdue(partlyPaid, charged, dueOn.AddDate(0, 1, 0))
+
+	// Assert
+	assert.True(t, overdue.IsSuccess())
+	assert.Equal(t, money.Euros(20), overdue.Value().LateFee, "the fee charged on the balance of the day is not computed again")
+	assert.Equal(t, money.Euros(70), overdue.Value().Total)
+	assert.Equal(t, chargedOn, *overdue.Value().LateFeeChargedOn)
+}
+
+func TestNewOverdueItems_MatchesDeadlineByTargetAndFacilityType(t *testing.T) {
+	// Arrange
+	deadlines := []payment.PaymentDeadline{
+		{SeasonId: 1, Target: payment.MembershipTarget, DueOn: dueOn},
+		{SeasonId: 1, Target: payment.FacilityTarget, FacilityTypeId: idOf(2), DueOn: dueOn.AddDate(0, 2, 0)},
+		{SeasonId: 1, Target: payment.FacilityTarget, FacilityTypeId: idOf(3), DueOn: dueOn, LateFee: &payment.LateFeeRule{Kind: payment.PercentageLateFee, Percentage: 5}},
+	}
+	items := []payment.OutstandingItem{
+		seasonItem(payment.MembershipTarget, 1, nil, 150),
+		seasonItem(payment.FacilityTarget, 1, idOf(2), 300), // Its deadline has not passed yet
+		seasonItem(payment.FacilityTarget, 2, idOf(3), 400),
+		seasonItem(payment.FacilityTarget, 3, idOf(4), 100), // No deadline for the facility type
+	}
+
+	// Act
+	overdue := payment.NewOverdueItems(items, deadlines, nil, dueOn.AddDate(0, 0, 10)).Value()
+
+	// Assert
+	assert.Len(t, overdue, 2)
+	assert.Equal(t, payment.MembershipTarget, overdue[0].Item.Target)
+	assert.Equal(t, money.Zero(money.EUR), overdue[0].LateFee)
+	assert.Equal(t, int64(2), overdue[1].Item.MemberId)
+	assert.Equal(t, money.Euros(20), overdue[1].LateFee)
+}
+
+func TestNewOverdueItems_CurrencyMismatch(t *testing.T) {
+	// Arrange
+	deadlines := []payment.PaymentDeadline{
+		{SeasonId: 1, Target: payment.MembershipTarget, DueOn: dueOn, LateFee: &payment.LateFeeRule{Kind: payment.FixedLateFee, Amount: money.FromFloat(20, "CHF")}},
+	}
+	items := []payment.OutstandingItem{seasonItem(payment.MembershipTarget, 1, nil, 150)}
+
+	// Act
+	overdue := payment.NewOverdueItems(items, deadlines, nil, dueOn.AddDate(0, 0, 10))
+
+	// Assert
+	assert.IsType(t, errors.CurrencyMismatchError{}, overdue.Error())
+}
+
+func TestPaymentDeadlineService_SetDeadlines(t *testing.T) {
+	// Arrange
+	repository := &stubDeadlineRepository{}
+	service := payment.NewPaymentDeadlineService(repository)
+
+	// Act
+	repeated := service.SetDeadlines(1, []payment.PaymentDeadline{
+		{Target: payment.FacilityTarget, FacilityTypeId: idOf(2), DueOn: dueOn},
+		{Target: payment.FacilityTarget, FacilityTypeId: idOf(2), DueOn: dueOn.AddDate(0, 1, 0)},
+	})
+	set := service.SetDeadlines(1, []payment.PaymentDeadline{
+		{Target: payment.MembershipTarget, DueOn: dueOn.Add(15 * time.Hour)},
+		{Target: payment.FacilityTarget, FacilityTypeId: idOf(2), DueOn: dueOn},
+	})
+
+	// Assert
+	assert.IsType(t, errors.PaymentError{}, repeated.Error())
+	assert.True(t, set.IsSuccess())
+	assert.Len(t, repository.deadlines, 2)
+	assert.Equal(t, int64(1), repository.deadlines[0].SeasonId)
+	assert.Equal(t, dueOn, repository.deadlines[0].DueOn)
+}
+
+func TestPaymentDeadlineService_GetOverdueItems_WithoutDeadlines(t *testing.T) {
+	// Arrange
+	repository := &stubDeadlineRepository{items: []payment.OutstandingItem{seasonItem(payment.MembershipTarget, 1, nil, 150)}}
+	service := payment.NewPaymentDeadlineService(repository)
+	seasonId := int64(1)
+
+	// Act
+	overdue := service.GetOverdueItems(&seasonId, dueOn.AddDate(1, 0, 0))
+
+	// Assert
+	assert.True(t, overdue.IsSuccess())
+	assert.Empty(t, overdue.Value())
+}
+
+func TestPaymentDeadlineService_GetOverdueItems_ChargesTheLateFeeOnce(t *testing.T) {
+	// Arrange
+	item := seasonItem(payment.MembershipTarget, 1, nil, 200)
+	item.MembershipPeriodId = idOf(3)
+	repository := &stubDeadlineRepository{
+		deadlines: []payment.PaymentDeadline{
+			{SeasonId: 1, Target: payment.MembershipTarget, DueOn: dueOn, LateFee: &payment.LateFeeRule{Kind: payment.PercentageLateFee, Percentage: 10, AfterDays: 5}},
+		},
+		items: []payment.OutstandingItem{item},
+	}
+	service := payment.NewPaymentDeadlineService(repository)
+	seasonId := int64(1)
+
+	// Act
+	inGrace := service.GetOverdueItems(&seasonId, dueOn.AddDate(0, 0, 3))
+	chargedInGrace := len(repository.charged)
+	firstLate := service.GetOverdueItems(&seasonId, dueOn.AddDate(0, 0, 6))
+	repository.items[0].Balance = money.Euros(50)
+	afterPayment := service.GetOverdueItems(&seasonId, dueOn.AddDate(0, 1, 0))
+
+	// Assert
+	assert.True(t, inGrace.IsSuccess())
+	assert.Equal(t, 0, chargedInGrace, "nothing is charged during the grace days")
+	assert.Equal(t, money.Euros(20), firstLate.Value()[0].LateFee)
+	assert.Equal(t, []payment.ChargedLateFee{
+		{MembershipPeriodId: idOf(3), Amount: money.Euros(20), ChargedOn: dueOn.AddDate(0, 0, 6)},
+	}, repository.charged, "the fee is charged once")
+	assert.Equal(t, money.Euros(20), afterPayment.Value()[0].LateFee, "later payments do not change the fee")
+	assert.Equal(t, money.Euros(70), afterPayment.Value()[0].Total)
+}
+
+func TestPaymentDeadlineService_GetOverdueItems_FixedFeeInAnotherCurrency(t *testing.T) {
+	// Arrange
+	item := seasonItem(payment.MembershipTarget, 1, nil, 150)
+	item.MembershipPeriodId = idOf(3)
+	repository := &stubDeadlineRepository{
+		deadlines: []payment.PaymentDeadline{
+			{SeasonId: 1, Target: payment.MembershipTarget, DueOn: dueOn, LateFee: &payment.LateFeeRule{Kind: payment.FixedLateFee, Amount: money.FromFloat(20, "CHF")}},
+		},
+		items: []payment.OutstandingItem{item},
+	}
+	service := payment.NewPaymentDeadlineService(repository)
+	seasonId := int64(1)
+
+	// Act
+	overdue := service.GetOverdueItems(&seasonId, dueOn.AddDate(0, 0, 10))
+
+	// Assert
+	assert.IsType(t, errors.CurrencyMismatchError{}, overdue.Error(), "a fee in francs cannot be added to a balance in euros")
+	assert.Empty(t, repository.charged)
+}
+
+func TestPaymentDeadlineService_ChargeLateFees(t *testing.T) {
+	// Arrange
+	late := seasonItem(payment.MembershipTarget, 1, nil, 150)
+	late.MembershipPeriodId = idOf(3)
+	charged := seasonItem(payment.MembershipTarget, 2, nil, 150)
+	charged.MembershipPeriodId = idOf(4)
+	repository := &stubDeadlineRepository{
+		deadlines: []payment.PaymentDeadline{
+			{SeasonId: 1, Target: payment.MembershipTarget, DueOn: dueOn, LateFee: &payment.LateFeeRule{Kind: payment.FixedLateFee, Amount: money.Euros(20)}},
+		},
+		items:   []payment.OutstandingItem{late, charged},
+		charged: []payment.ChargedLateFee{{MembershipPeriodId: idOf(4), Amount: money.Euros(20), ChargedOn: dueOn.AddDate(0, 0, 1)}},
+	}
+	service := payment.NewPaymentDeadlineService(repository)
+
+	// Act
+	result := service.ChargeLateFees(dueOn.AddDate(0, 0, 2))
+
+	// Assert
+	assert.True(t, result.IsSuccess())
+	assert.Equal(t, 1, result.Value(), "only the fee not charged yet is charged")
+	assert.Len(t, repository.charged, 2)
+	assert.Equal(t, idOf(3), repository.charged[1].MembershipPeriodId)
+}
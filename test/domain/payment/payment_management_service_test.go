@@ -0,0 +1,77 @@
+package payment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/result"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubPaymentRepository keeps a ledger in memory, the documented payments appear on a receipt or invoice
+type stubPaymentRepository struct {
+	payment.PaymentRepository
+	ledger     payment.Ledger
+	documented map[int64]bool
+	updated    []payment.Transaction
+	deleted    []domain.Id[payment.Transaction]
+}
+
+func (r *stubPaymentRepository) IsPaymentDocumented(paymentId domain.Id[payment.Transaction]) result.Result[bool] {
+	return result.Ok(r.documented[paymentId.Value])
+}
+
+func (r *stubPaymentRepository) GetPaymentLedger(paymentId domain.Id[payment.Transaction]) result.Result[payment.Ledger] {
+	return result.Ok(r.ledger)
+}
+
+func (r *stubPaymentRepository) UpdatePayment(transaction payment.Transaction) result.Result[bool] {
+	r.updated = append(r.updated, transaction)
+	return result.Ok(true)
+}
+
+func (r *stubPaymentRepository) DeletePayment(paymentId domain.Id[payment.Transaction]) result.Result[bool] {
+	r.deleted = append(r.deleted, paymentId)
+	return result.Ok(true)
+}
+
+// openCashRegister has every day open
+type openCashRegister struct {
+	payment.CashRegisterRepository
+}
+
+func (r openCashRegister) IsDayClosed(day time.Time) result.Result[bool] {
+	return result.Ok(false)
+}
+
+func TestPaymentManagementService_DocumentedPayments(t *testing.T) {
+	// Arrange
+	paidOn := time.Now()
+	first, second := paid(1, money.Euros(100)), paid(2, money.Euros(50))
+	first.Date, second.Date = paidOn, paidOn
+	repository := &stubPaymentRepository{
+		ledger:     payment.Ledger{Due: money.Euros(150), Transactions: []payment.Transaction{first, second}},
+		documented: map[int64]bool{1: true},
+	}
+	service := payment.NewPaymentManagementService(repository, openCashRegister{})
+	corrected, undocumented := paid(1, money.Euros(90)), paid(2, money.Euros(40))
+
+	// Act
+	updateDocumented := service.UpdatePayment(corrected)
+	deleteDocumented := service.DeletePayment(first.Id)
+	updateUndocumented := service.UpdatePayment(undocumented)
+	deleteUndocumented := service.DeletePayment(second.Id)
+
+	// Assert
+	assert.IsType(t, errors.PaymentError{}, updateDocumented.Error())
+	assert.IsType(t, errors.PaymentError{}, deleteDocumented.Error())
+	assert.True(t, updateUndocumented.IsSuccess())
+	assert.True(t, deleteUndocumented.IsSuccess())
+	assert.Len(t, repository.updated, 1)
+	assert.Equal(t, int64(2), repository.updated[0].Id.Value)
+	assert.Equal(t, []domain.Id[payment.Transaction]{second.Id}, repository.deleted)
+}
@@ -0,0 +1,87 @@
+package payment_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentSearchCriteria_Validate(t *testing.T) {
+	day := func(d int) *time.Time {
+		date := time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+	amount := func(a float64) *money.Money {
+		amount := money.Euros(a)
+		return &amount
+	}
+	unknownTarget := payment.PaymentTarget("SERVICE")
+
+	testCases := []struct {
+		name          string
+		criteria      payment.PaymentSearchCriteria
+		expectedValid bool
+	}{
+		{name: "no filters", criteria: payment.PaymentSearchCriteria{}, expectedValid: true},
+		{name: "single day", criteria: payment.PaymentSearchCriteria{PaidFrom: day(1), PaidTo: day(1)}, expectedValid: true},
+		{name: "dates inverted", criteria: payment.PaymentSearchCriteria{PaidFrom: day(2), PaidTo: day(1)}, expectedValid: false},
+		{name: "amount range", criteria: payment.PaymentSearchCriteria{MinAmount: amount(10), MaxAmount: amount(100)}, expectedValid: true},
+		{name: "amounts inverted", criteria: payment.PaymentSearchCriteria{MinAmount: amount(100), MaxAmount: amount(10)}, expectedValid: false},
+		{name: "negative amount", criteria: payment.PaymentSearchCriteria{MinAmount: amount(-1)}, expectedValid: false},
+		{name: "unknown target", criteria: payment.PaymentSearchCriteria{Target: &unknownTarget}, expectedValid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			validated := tc.criteria.Validate()
+
+			// Assert
+			assert.Equal(t, tc.expectedValid, validated.IsSuccess())
+			if !tc.expectedValid {
+				assert.IsType(t, errors.PaymentError{}, validated.Error())
+			}
+		})
+	}
+}
+
+func TestPaymentSearchCriteria_Normalized(t *testing.T) {
+	testCases := []struct {
+		name           string
+		criteria       payment.PaymentSearchCriteria
+		expectedLimit  int
+		expectedOffset int
+	}{
+		{name: "defaults", criteria: payment.PaymentSearchCriteria{}, expectedLimit: payment.DefaultPaymentSearchLimit, expectedOffset: 0},
+		{name: "limit too large", criteria: payment.PaymentSearchCriteria{Limit: 1000, Offset: 20}, expectedLimit: payment.MaxPaymentSearchLimit, expectedOffset: 20},
+		{name: "negative offset", criteria: payment.PaymentSearchCriteria{Limit: 10, Offset: -5}, expectedLimit: 10, expectedOffset: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			normalized := tc.criteria.Normalized()
+
+			// Assert
+			assert.Equal(t, tc.expectedLimit, normalized.Limit)
+			assert.Equal(t, tc.expectedOffset, normalized.Offset)
+		})
+	}
+}
+
+func TestPaymentSearchCriteria_PaidBefore(t *testing.T) {
+	// Arrange
+	to := time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC)
+	criteria := payment.PaymentSearchCriteria{PaidTo: &to}
+
+	// Act
+	before := criteria.PaidBefore()
+
+	// Assert
+	assert.Equal(t, time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC), *before)
+	assert.Nil(t, payment.PaymentSearchCriteria{}.PaidBefore())
+}
@@ -0,0 +1,134 @@
+package bankstatements_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/bankstatements"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+// Statement of an Italian bank: a single transfer, a batch of two transfers, a debit and a pending entry
+const camt053Statement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Id>2026-03</Id>
+      <Acct><Id><IBAN>IT60X0542811101000000123456</IBAN></Id></Acct>
+      <Ntry>
+        <Amt Ccy="EUR">150.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2026-03-02</Dt></BookgDt>
+        <AcctSvcrRef>0306912345</AcctSvcrRef>
+        <NtryDtls>
+          <TxDtls>
+            <Refs><EndToEndId>NOTPROVIDED</EndToEndId></Refs>
+            <RltdPties>
+              <Dbtr><Nm>ROSSI MARIO</Nm></Dbtr>
+              <DbtrAcct><Id><IBAN>IT02L1234512345123456789012</IBAN></Id></DbtrAcct>
+            </RltdPties>
+            <RmtInf><Ustrd>Quota associativa 2026</Ustrd></RmtInf>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">260.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2026-03-03T10:15:00+01:00</DtTm></BookgDt>
+        <AcctSvcrRef>BATCH-7</AcctSvcrRef>
+        <NtryDtls>
+          <TxDtls>
+            <Amt Ccy="EUR">130.00</Amt>
+            <RltdPties><Dbtr><Pty><Nm>Bianchi Anna</Nm></Pty></Dbtr></RltdPties>
+            <RmtInf><Ustrd>Quota Bianchi Anna</Ustrd></RmtInf>
+          </TxDtls>
+          <TxDtls>
+            <AmtDtls><TxAmt><Amt Ccy="EUR">130.00</Amt></TxAmt></AmtDtls>
+            <RltdPties><Dbtr><Nm>Bianchi Luca</Nm></Dbtr></RltdPties>
+            <RmtInf><Strd><CdtrRefInf><Ref>RF18539007547034</Ref></CdtrRefInf></Strd></RmtInf>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">45.90</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2026-03-04</Dt></BookgDt>
+        <AddtlNtryInf>Commissioni tenuta conto</AddtlNtryInf>
+        <NtryDtls>
+          <TxDtls>
+            <RltdPties><Cdtr><Nm>Banca Esempio</Nm></Cdtr></RltdPties>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">80.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>PDNG</Sts>
+        <BookgDt><Dt>2026-03-05</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+func TestStatementParser_ParseCAMT053(t *testing.T) {
+	// Arrange
+	parser := bankstatements.NewStatementParser()
+
+	// Act
+	statement, err := parser.Parse(payment.CAMT053Statement, []byte(camt053Statement))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "IT60X0542811101000000123456", statement.AccountIBAN)
+	assert.Len(t, statement.Transactions, 4, "batches are split and pending entries skipped")
+
+	single := statement.Transactions[0]
+	assert.Equal(t, "0306912345", single.Reference, "the reference of the entry when the transfer has none")
+	assert.Equal(t, time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC), single.BookingDate)
+	assert.Equal(t, money.Euros(150), single.Amount)
+	assert.Equal(t, money.EUR, single.Amount.Currency())
+	assert.Equal(t, "ROSSI MARIO", single.CounterpartyName)
+	assert.Equal(t, "IT02L1234512345123456789012", single.CounterpartyIBAN)
+	assert.Equal(t, "Quota associativa 2026", single.RemittanceInfo)
+
+	first, second := statement.Transactions[1], statement.Transactions[2]
+	assert.Equal(t, "BATCH-7/1", first.Reference)
+	assert.Equal(t, "BATCH-7/2", second.Reference)
+	assert.Equal(t, money.Euros(130), first.Amount)
+	assert.Equal(t, money.Euros(130), second.Amount)
+	assert.Equal(t, "Bianchi Anna", first.CounterpartyName)
+	assert.Equal(t, "RF18539007547034", second.RemittanceInfo)
+	assert.Equal(t, 3, first.BookingDate.Day())
+
+	debit := statement.Transactions[3]
+	assert.Equal(t, money.Euros(-45.9), debit.Amount)
+	assert.Equal(t, "Banca Esempio", debit.CounterpartyName)
+	assert.Equal(t, "Commissioni tenuta conto", debit.RemittanceInfo)
+}
+
+func TestStatementParser_ParseCAMT053_Invalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{"not XML", "Data;Importo"},
+		{"no statement", `<Document><BkToCstmrStmt></BkToCstmrStmt></Document>`},
+		{"invalid amount", `<Document><BkToCstmrStmt><Stmt><Ntry><Amt Ccy="EUR">abc</Amt><BookgDt><Dt>2026-03-02</Dt></BookgDt></Ntry></Stmt></BkToCstmrStmt></Document>`},
+		{"missing booking date", `<Document><BkToCstmrStmt><Stmt><Ntry><Amt Ccy="EUR">10.00</Amt></Ntry></Stmt></BkToCstmrStmt></Document>`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := bankstatements.NewStatementParser().Parse(payment.CAMT053Statement, []byte(tc.content))
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
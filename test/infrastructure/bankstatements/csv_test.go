@@ -0,0 +1,121 @@
+package bankstatements_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/bankstatements"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+// Export of an Italian bank: byte order mark and account details first, semicolons, amounts written as 1.234,56
+const signedAmountExport = "\ufeffConto corrente;IT60 X054 2811 1010 0000 0123 456\n" +
+	"Periodo;01/03/2026 - 31/03/2026\n" +
+	"\n" +
+	"Data contabile;Data valuta;Importo;Divisa;Descrizione;Ordinante;IBAN ordinante;CRO\n" +
+	"02/03/2026;02/03/2026;150,00;EUR;BONIFICO Quota 2026 Rossi Mario;Rossi Mario;IT02 L123 4512 3451 2345 6789 012;0306912345\n" +
+	"03/03/2026;03/03/2026;1.234,56;EUR;BONIFICO Rimessaggio;Bianchi Anna;;\n" +
+	"04/03/2026;04/03/2026;-45,90;EUR;Commissioni;;;\n" +
+	";;;;Saldo finale;;;\n"
+
+// Export with separate debit and credit columns, amounts without sign
+const creditDebitExport = "Data operazione;Descrizione operazione;Dare;Avere\n" +
+	"05.03.2026;Quota socio Verdi;;130,00\n" +
+	"06.03.2026;Canone;20,00;\n"
+
+func TestStatementParser_ParseCSV(t *testing.T) {
+	// Arrange
+	parser := bankstatements.NewStatementParser()
+
+	// Act
+	statement, err := parser.Parse(payment.CSVStatement, []byte(signedAmountExport))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, statement.Transactions, 3, "the preamble and the totals are skipped")
+
+	first := statement.Transactions[0]
+	assert.Equal(t, time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC), first.BookingDate)
+	assert.Equal(t, money.Euros(150), first.Amount)
+	assert.Equal(t, money.EUR, first.Amount.Currency())
+	assert.Equal(t, "Rossi Mario", first.CounterpartyName)
+	assert.Equal(t, "IT02L1234512345123456789012", first.CounterpartyIBAN)
+	assert.Equal(t, "BONIFICO Quota 2026 Rossi Mario", first.RemittanceInfo)
+	assert.Equal(t, "0306912345", first.Reference)
+
+	assert.Equal(t, money.Euros(1234.56), statement.Transactions[1].Amount)
+	assert.Equal(t, money.Euros(-45.9), statement.Transactions[2].Amount)
+}
+
+func TestStatementParser_ParseCSV_CreditAndDebitColumns(t *testing.T) {
+	// Arrange
+	parser := bankstatements.NewStatementParser()
+
+	// Act
+	statement, err := parser.Parse(payment.CSVStatement, []byte(creditDebitExport))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, statement.Transactions, 2)
+	assert.Equal(t, money.Euros(130), statement.Transactions[0].Amount)
+	assert.Equal(t, money.Euros(-20), statement.Transactions[1].Amount)
+	assert.Equal(t, money.EUR, statement.Transactions[1].Amount.Currency(), "EUR when the statement has no currency")
+}
+
+func TestStatementParser_ParseCSV_Amounts(t *testing.T) {
+	testCases := []struct {
+		amount   string
+		expected float64
+	}{
+		{"150", 150},
+		{"150,5", 150.5},
+		{"150,00", 150},
+		{"12.50", 12.5},
+		{"1.234", 1234},
+		{"1,234", 1234},
+		{"1.234,56", 1234.56},
+		{"1,234.56", 1234.56},
+		{"1.234.567", 1234567},
+		{"1.234.567,89", 1234567.89},
+		{"-1.234", -1234},
+		{"€ 1.234,00", 1234},
+		{"1'234.50", 1234.5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.amount, func(t *testing.T) {
+			// Arrange
+			content := "Data;Importo\n02/03/2026;\"" + tc.amount + "\"\n"
+
+			// Act
+			statement, err := bankstatements.NewStatementParser().Parse(payment.CSVStatement, []byte(content))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, money.Euros(tc.expected), statement.Transactions[0].Amount)
+		})
+	}
+}
+
+func TestStatementParser_ParseCSV_Invalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{"no header", "02/03/2026;150,00\n"},
+		{"invalid date", "Data;Importo\n2026/13/45;150,00\n"},
+		{"invalid amount", "Data;Importo\n02/03/2026;abc\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := bankstatements.NewStatementParser().Parse(payment.CSVStatement, []byte(tc.content))
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package email_test
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/notification"
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/email"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSMTPServer speaks just enough SMTP to accept the messages of one session at a time, rejecting the
+// recipients in rejected, and records the commands and the data it received
+type fakeSMTPServer struct {
+	listener net.Listener
+	rejected map[string]bool
+
+	mutex    sync.Mutex
+	commands []string
+	data     string
+	done     chan struct{}
+}
+
+func startFakeSMTPServer(t *testing.T, rejected ...string) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := &fakeSMTPServer{listener: listener, rejected: map[string]bool{}, done: make(chan struct{})}
+	for _, address := range rejected {
+		server.rejected[address] = true
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		defer close(server.done)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		server.serve(conn)
+	}()
+	return server
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimRight(line, "\r\n")
+		s.mutex.Lock()
+		s.commands = append(s.commands, command)
+		s.mutex.Unlock()
+
+		switch verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0]); {
+		case verb == "EHLO" || verb == "HELO":
+			reply("250 localhost")
+		case strings.HasPrefix(strings.ToUpper(command), "RCPT TO:"):
+			address := strings.Trim(command[len("RCPT TO:"):], "<> ")
+			if s.rejected[address] {
+				reply("550 mailbox unavailable")
+			} else {
+				reply("250 OK")
+			}
+		case verb == "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			s.mutex.Lock()
+			s.data = data.String()
+			s.mutex.Unlock()
+			reply("250 OK queued")
+		case verb == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+// session waits for the session to end and returns what the server received
+func (s *fakeSMTPServer) session(t *testing.T) ([]string, string) {
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the SMTP session did not end")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.commands, s.data
+}
+
+func (s *fakeSMTPServer) config() email.SMTPConfig {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return email.SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     port,
+		From:     "segreteria@cnc.it",
+		FromName: "Circolo Nautico Cattolica",
+		Timeout:  5 * time.Second,
+	}
+}
+
+var message = notification.EmailMessage{
+	To:      notification.Recipient{Name: "Mario Rossi", Email: "mario.rossi@example.com"},
+	Subject: "Iscrizione alla stagione 2026",
+	Body:    "Ciao Mario,\nla tua iscrizione è confermata.",
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	// Arrange
+	server := startFakeSMTPServer(t)
+	sender := email.NewSMTPSender(server.config())
+
+	// Act
+	err := sender.Send(message)
+
+	// Assert
+	assert.NoError(t, err)
+	commands, data := server.session(t)
+	assert.Contains(t, commands, "MAIL FROM:<segreteria@cnc.it>")
+	assert.Contains(t, commands, "RCPT TO:<mario.rossi@example.com>")
+	assert.Equal(t, "QUIT", commands[len(commands)-1])
+	assert.Contains(t, data, "From: \"Circolo Nautico Cattolica\" <segreteria@cnc.it>\r\n")
+	assert.Contains(t, data, "To: \"Mario Rossi\" <mario.rossi@example.com>\r\n")
+	assert.Contains(t, data, "Subject: Iscrizione alla stagione 2026\r\n")
+	assert.Contains(t, data, "Content-Transfer-Encoding: quoted-printable\r\n")
+	assert.Contains(t, data, "Ciao Mario,\r\nla tua iscrizione =C3=A8 confermata.", "accented letters are quoted-printable and lines end with CRLF")
+}
+
+func TestSMTPSender_Send_RecipientRejected(t *testing.T) {
+	// Arrange
+	server := startFakeSMTPServer(t, "mario.rossi@example.com")
+	sender := email.NewSMTPSender(server.config())
+
+	// Act
+	err := sender.Send(message)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "recipient rejected")
+	commands, data := server.session(t)
+	assert.NotContains(t, commands, "DATA")
+	assert.Empty(t, data)
+}
+
+func TestSMTPSender_Send_NotAttempted(t *testing.T) {
+	testCases := []struct {
+		name      string
+		configure func(*email.SMTPConfig)
+		recipient string
+		expected  string
+	}{
+		{"no sender address", func(c *email.SMTPConfig) { c.From = "" }, "mario.rossi@example.com", "SMTP_FROM"},
+		{"invalid recipient", func(c *email.SMTPConfig) {}, "mario.rossi", "invalid recipient"},
+		{"mail server down", func(c *email.SMTPConfig) { c.Port = "1" }, "mario.rossi@example.com", "failed to connect"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			server := startFakeSMTPServer(t)
+			config := server.config()
+			tc.configure(&config)
+			recipient := message
+			recipient.To.Email = tc.recipient
+
+			// Act
+			err := email.NewSMTPSender(config).Send(recipient)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package fatturapa_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/fatturapa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchive(t *testing.T) {
+	// Arrange
+	invoices := []payment.ElectronicInvoice{
+		{FileName: "IT91000000000_00001.xml", Content: []byte("<first/>")},
+		{FileName: "IT91000000000_00002.xml", Content: []byte("<second/>")},
+	}
+
+	// Act
+	archive, err := fatturapa.Archive(invoices)
+
+	// Assert
+	assert.NoError(t, err)
+	reader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	assert.NoError(t, err)
+	assert.Len(t, reader.File, 2)
+	for i, file := range reader.File {
+		assert.Equal(t, invoices[i].FileName, file.Name)
+		opened, err := file.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(opened)
+		assert.NoError(t, err)
+		assert.Equal(t, invoices[i].Content, content)
+		opened.Close()
+	}
+}
+
+func TestArchive_Empty(t *testing.T) {
+	// Act
+	archive, err := fatturapa.Archive(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	reader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	assert.NoError(t, err)
+	assert.Empty(t, reader.File)
+}
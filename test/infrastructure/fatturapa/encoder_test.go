@@ -0,0 +1,232 @@
+package fatturapa_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain"
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/payment"
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/fatturapa"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var clubIssuer = payment.Issuer{
+	Name:      "Circolo Nautico Cattolica",
+	TaxCode:   "91000000000",
+	VATNumber: "01234567897",
+	Address: payment.FiscalAddress{
+		Street:       "Via del Porto",
+		StreetNumber: "1",
+		ZipCode:      "47841",
+		City:         "Cattolica",
+		Province:     "RN",
+		Country:      "Italia",
+	},
+	Email:            "segreteria@cnc.it",
+	VATExemptionNote: "Operazione fuori campo IVA ai sensi dell'art. 4, D.P.R. 633/1972",
+}
+
+var privateRecipient = payment.Recipient{
+	MemberId:  7,
+	FirstName: "Mario",
+	LastName:  "Rossi",
+	TaxCode:   "RSSMRA80A01H501U",
+	Address:   payment.FiscalAddress{Street: "Via Roma", StreetNumber: "10", ZipCode: "47841", City: "Cattolica", Country: "IT"},
+}
+
+func invoiceWith(recipient payment.Recipient, lines ...payment.FiscalDocumentLine) payment.FiscalDocument {
+	return payment.FiscalDocument{
+		Id:         domain.NewId[payment.FiscalDocument](42),
+		Kind:       payment.InvoiceDocument,
+		FiscalYear: 2026,
+		Number:     12,
+		IssuedOn:   time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC),
+		Issuer:     clubIssuer,
+		Recipient:  recipient,
+		Currency:   "EUR",
+		Lines:      lines,
+	}
+}
+
+func membershipLine(amount money.Money) payment.FiscalDocumentLine {
+	return payment.FiscalDocumentLine{PaymentId: 1, Description: "Quota associativa - stagione 2026", PaymentMethod: "cash", Amount: amount}
+}
+
+func rentalLine(amount money.Money, vatRate float64) payment.FiscalDocumentLine {
+	return payment.FiscalDocumentLine{PaymentId: 2, Description: "Posto barca B-12 - stagione 2026", PaymentMethod: "bank_transfer", Amount: amount, VATRate: vatRate}
+}
+
+func businessRecipient(recipientCode string, pec string) payment.Recipient {
+	recipient := privateRecipient
+	recipient.CompanyName = "Rossi Charter di Mario Rossi"
+	recipient.VATNumber = "12345678903"
+	recipient.RecipientCode = recipientCode
+	recipient.PEC = pec
+	return recipient
+}
+
+func TestEncoder_Encode_PrivateMember(t *testing.T) {
+	// Arrange
+	encoder := fatturapa.NewEncoder("rf01", "n2.2", fatturapa.RulesValidator{})
+
+	// Act
+	invoice, err := encoder.Encode(invoiceWith(privateRecipient, membershipLine(money.Euros(150))))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "IT91000000000_00016.xml", invoice.FileName)
+	content := string(invoice.Content)
+	assert.Contains(t, content, "<CodiceDestinatario>0000000</CodiceDestinatario>", "private members get the invoice in their tax drawer")
+	assert.NotContains(t, content, "PECDestinatario")
+	assert.Contains(t, content, "<CodiceFiscale>RSSMRA80A01H501U</CodiceFiscale>")
+	assert.Contains(t, content, "<Nome>Mario</Nome>")
+	assert.Contains(t, content, "<RegimeFiscale>RF01</RegimeFiscale>")
+	assert.Contains(t, content, "<AliquotaIVA>0.00</AliquotaIVA>\n        <Natura>N2.2</Natura>")
+	assert.Contains(t, content, "<BolloVirtuale>SI</BolloVirtuale>")
+	assert.Contains(t, content, "<ImportoTotaleDocumento>150.00</ImportoTotaleDocumento>")
+	assert.Contains(t, content, "<RiferimentoNormativo>Operazione fuori campo IVA ai sensi dell&#39;art. 4, D.P.R. 633/1972</RiferimentoNormativo>")
+}
+
+func TestEncoder_Encode_Business(t *testing.T) {
+	testCases := []struct {
+		name          string
+		recipient     payment.Recipient
+		recipientCode string
+		pec           string
+	}{
+		{"recipient code of the member", businessRecipient("ABC1234", ""), "ABC1234", ""},
+		{"delivered by PEC", businessRecipient("0000000", "fatture@pec.rossicharter.it"), "0000000", "fatture@pec.rossicharter.it"},
+		{"PEC ignored when there is a recipient code", businessRecipient("ABC1234", "fatture@pec.rossicharter.it"), "ABC1234", ""},
+		{"no recipient code", businessRecipient("", ""), "0000000", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			encoder := fatturapa.NewEncoder("RF01", "N2.2", fatturapa.RulesValidator{})
+
+			// Act
+			invoice, err := encoder.Encode(invoiceWith(tc.recipient, rentalLine(money.Euros(122), 22)))
+
+			// Assert
+			assert.NoError(t, err)
+			content := string(invoice.Content)
+			assert.Contains(t, content, "<CodiceDestinatario>"+tc.recipientCode+"</CodiceDestinatario>")
+			if tc.pec == "" {
+				assert.NotContains(t, content, "PECDestinatario")
+			} else {
+				assert.Contains(t, content, "<PECDestinatario>"+tc.pec+"</PECDestinatario>")
+			}
+			assert.Contains(t, content, "<IdFiscaleIVA>\n          <IdPaese>IT</IdPaese>\n          <IdCodice>12345678903</IdCodice>")
+			assert.Contains(t, content, "<Denominazione>Rossi Charter di Mario Rossi</Denominazione>")
+			assert.NotContains(t, content, "<Nome>Mario</Nome>", "businesses are named after the company")
+		})
+	}
+}
+
+func TestEncoder_Encode_TaxedLines(t *testing.T) {
+	// Arrange
+	encoder := fatturapa.NewEncoder("RF01", "N2.2", fatturapa.RulesValidator{})
+	document := invoiceWith(businessRecipient("ABC1234", ""), membershipLine(money.Euros(50)), rentalLine(money.Euros(122), 22), rentalLine(money.Euros(61), 22))
+
+	// Act
+	invoice, err := encoder.Encode(document)
+
+	// Assert
+	assert.NoError(t, err)
+	content := string(invoice.Content)
+	assert.Contains(t, content, "<PrezzoTotale>100.00</PrezzoTotale>\n        <AliquotaIVA>22.00</AliquotaIVA>\n      </DettaglioLinee>", "lines are net of VAT")
+	assert.Contains(t, content, "<PrezzoTotale>50.00</PrezzoTotale>\n        <AliquotaIVA>22.00</AliquotaIVA>")
+	assert.Contains(t, content, "<AliquotaIVA>0.00</AliquotaIVA>\n        <Natura>N2.2</Natura>\n        <ImponibileImporto>50.00</ImponibileImporto>\n        <Imposta>0.00</Imposta>")
+	assert.Contains(t, content, "<AliquotaIVA>22.00</AliquotaIVA>\n        <ImponibileImporto>150.00</ImponibileImporto>\n        <Imposta>33.00</Imposta>\n        <EsigibilitaIVA>I</EsigibilitaIVA>")
+	assert.Contains(t, content, "<ImportoTotaleDocumento>233.00</ImportoTotaleDocumento>")
+	assert.NotContains(t, content, "DatiBollo", "no stamp duty on 50.00 out of the scope of VAT")
+}
+
+func TestEncoder_Encode_TaxedLinesRounding(t *testing.T) {
+	// Arrange
+	encoder := fatturapa.NewEncoder("RF01", "N2.2", fatturapa.RulesValidator{})
+	document := invoiceWith(businessRecipient("ABC1234", ""), rentalLine(money.Euros(10), 22), rentalLine(money.Euros(10), 22), rentalLine(money.Euros(10), 22))
+
+	// Act
+	invoice, err := encoder.Encode(document)
+
+	// Assert
+	assert.NoError(t, err)
+	content := string(invoice.Content)
+	assert.Equal(t, 2, strings.Count(content, "<PrezzoTotale>8.20</PrezzoTotale>"))
+	assert.Equal(t, 1, strings.Count(content, "<PrezzoTotale>8.19</PrezzoTotale>"), "the last line takes the rounding of the others")
+	assert.Contains(t, content, "<ImponibileImporto>24.59</ImponibileImporto>\n        <Imposta>5.41</Imposta>")
+	assert.Contains(t, content, "<ImportoTotaleDocumento>"+document.Total().Decimal()+"</ImportoTotaleDocumento>", "the invoice adds up to the gross collected, 30.00")
+}
+
+func TestEncoder_Encode_ForeignMember(t *testing.T) {
+	// Arrange
+	encoder := fatturapa.NewEncoder("RF01", "N2.2", fatturapa.RulesValidator{})
+	recipient := privateRecipient
+	recipient.TaxCode = ""
+	recipient.Address = payment.FiscalAddress{Street: "Hauptstrasse", StreetNumber: "5", ZipCode: "80331", City: "München", Country: "Germania"}
+
+	// Act
+	invoice, err := encoder.Encode(invoiceWith(recipient, membershipLine(money.Euros(50))))
+
+	// Assert
+	assert.NoError(t, err)
+	content := string(invoice.Content)
+	assert.Contains(t, content, "<CodiceDestinatario>XXXXXXX</CodiceDestinatario>")
+	assert.Contains(t, content, "<IdPaese>DE</IdPaese>\n          <IdCodice>99999999999</IdCodice>")
+	assert.Contains(t, content, "<CAP>00000</CAP>")
+	assert.Contains(t, content, "<Comune>München</Comune>", "Latin-1 characters are allowed")
+}
+
+func TestEncoder_Encode_Rejected(t *testing.T) {
+	withoutTaxCode := privateRecipient
+	withoutTaxCode.TaxCode = ""
+	receipt := invoiceWith(privateRecipient, membershipLine(money.Euros(50)))
+	receipt.Kind = payment.ReceiptDocument
+	withoutExemptionNote := invoiceWith(privateRecipient, membershipLine(money.Euros(50)))
+	withoutExemptionNote.Issuer.VATExemptionNote = ""
+	withoutVATNumber := invoiceWith(privateRecipient, membershipLine(money.Euros(50)))
+	withoutVATNumber.Issuer.VATNumber = ""
+	invalidRecipientCode := invoiceWith(businessRecipient("ABC12", ""), rentalLine(money.Euros(122), 22))
+
+	testCases := []struct {
+		name     string
+		document payment.FiscalDocument
+		expected string
+	}{
+		{"receipt", receipt, "only invoices"},
+		{"no lines", invoiceWith(privateRecipient), "no lines"},
+		{"line out of the scope of VAT without exemption note", withoutExemptionNote, "no VAT exemption note"},
+		{"club without VAT number", withoutVATNumber, "VAT number"},
+		{"member without tax code", invoiceWith(withoutTaxCode, membershipLine(money.Euros(50))), "no tax code"},
+		{"invalid recipient code", invalidRecipientCode, "CodiceDestinatario"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			encoder := fatturapa.NewEncoder("RF01", "N2.2", fatturapa.RulesValidator{})
+
+			// Act
+			_, err := encoder.Encode(tc.document)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestEncoder_Encode_ValidForTheSchema(t *testing.T) {
+	// Arrange
+	encoder := fatturapa.NewEncoder("RF01", "N2.2", officialSchemaValidator(t))
+
+	// Act
+	_, err := encoder.Encode(invoiceWith(businessRecipient("0000000", "fatture@pec.rossicharter.it"), membershipLine(money.Euros(80)), rentalLine(money.Euros(122), 22)))
+
+	// Assert
+	assert.NoError(t, err)
+}
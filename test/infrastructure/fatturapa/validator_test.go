@@ -0,0 +1,209 @@
+package fatturapa_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/fatturapa"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+// validInvoice encodes a file with a line out of the scope of VAT and a taxed one, for the tests to break
+func validInvoice(t *testing.T) string {
+	invoice, err := fatturapa.NewEncoder("RF01", "N2.2", fatturapa.RulesValidator{}).Encode(invoiceWith(businessRecipient("ABC1234", ""), membershipLine(money.Euros(80)), rentalLine(money.Euros(122), 22)))
+	assert.NoError(t, err)
+	return string(invoice.Content)
+}
+
+func xmllint(t *testing.T) string {
+	path, err := exec.LookPath("xmllint")
+	if err != nil {
+		t.Skip("xmllint is not installed")
+	}
+	return path
+}
+
+// officialSchemaValidator validates against the official schema at FATTURAPA_SCHEMA_PATH, or the one vendored in the
+// schemas folder of the repository
+func officialSchemaValidator(t *testing.T) *fatturapa.SchemaValidator {
+	xmllintPath := xmllint(t)
+	schemaPath := os.Getenv("FATTURAPA_SCHEMA_PATH")
+	if schemaPath == "" {
+		schemaPath = "../../../schemas/fatturapa/Schema_del_file_xml_FatturaPA_v1.2.2.xsd"
+	}
+	if _, err := os.Stat(schemaPath); err != nil {
+		t.Fatalf("the official FatturaPA schema is missing: %v", err)
+	}
+	return fatturapa.NewSchemaValidatorAt(xmllintPath, schemaPath)
+}
+
+func TestRulesValidator_Validate_Valid(t *testing.T) {
+	// Act
+	err := fatturapa.RulesValidator{}.Validate([]byte(validInvoice(t)))
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestRulesValidator_Validate_Invalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		replace  string
+		with     string
+		expected string
+	}{
+		{"malformed XML", "</FatturaElettronicaBody>", "", "invalid FatturaPA"},
+		{"format not matching the version", "<FormatoTrasmissione>FPR12</FormatoTrasmissione>", "<FormatoTrasmissione>FPA12</FormatoTrasmissione>", "00428"},
+		{"recipient code of the public administration", "<CodiceDestinatario>ABC1234</CodiceDestinatario>", "<CodiceDestinatario>ABC123</CodiceDestinatario>", "00427"},
+		{"PEC with a recipient code", "<CodiceDestinatario>ABC1234</CodiceDestinatario>", "<CodiceDestinatario>ABC1234</CodiceDestinatario>\n      <PECDestinatario>fatture@pec.it</PECDestinatario>", "00426"},
+		{"number without digits", "<Numero>12/2026</Numero>", "<Numero>XII</Numero>", "00425"},
+		{"nature missing", "<AliquotaIVA>0.00</AliquotaIVA>\n        <Natura>N2.2</Natura>\n      </DettaglioLinee>", "<AliquotaIVA>0.00</AliquotaIVA>\n      </DettaglioLinee>", "Natura"},
+		{"summary not matching the lines", "<ImponibileImporto>100.00</ImponibileImporto>", "<ImponibileImporto>90.00</ImponibileImporto>", "00422"},
+		{"tax not matching the rate", "<Imposta>22.00</Imposta>", "<Imposta>20.00</Imposta>", "00421"},
+		{"total not matching the summaries", "<ImportoTotaleDocumento>202.00</ImportoTotaleDocumento>", "<ImportoTotaleDocumento>200.00</ImportoTotaleDocumento>", "ImportoTotaleDocumento"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			content := validInvoice(t)
+			assert.Contains(t, content, tc.replace)
+			content = strings.Replace(content, tc.replace, tc.with, 1)
+
+			// Act
+			err := fatturapa.RulesValidator{}.Validate([]byte(content))
+
+			// Assert
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestSchemaValidator_Validate_Valid(t *testing.T) {
+	// Arrange
+	validator := officialSchemaValidator(t)
+
+	// Act
+	err := validator.Validate([]byte(validInvoice(t)))
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestSchemaValidator_Validate_Invalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		replace  string
+		with     string
+		expected string
+	}{
+		{"malformed XML", "</FatturaElettronicaBody>", "", "invalid FatturaPA"},
+		{"wrong namespace", "docs/xsd/fatture/v1.2", "docs/xsd/fatture/v1.1", "FatturaElettronica"},
+		{"missing version", ` versione="FPR12"`, "", "versione"},
+		{"missing required element", "<CodiceDestinatario>ABC1234</CodiceDestinatario>", "", "CodiceDestinatario"},
+		{"elements out of order", "<Divisa>EUR</Divisa>\n        <Data>2026-03-02</Data>", "<Data>2026-03-02</Data>\n        <Divisa>EUR</Divisa>", "Data"},
+		{"unknown element", "<Divisa>EUR</Divisa>", "<Divisa>EUR</Divisa><Causale>Quote</Causale>", "Causale"},
+		{"pattern", "<CAP>47841</CAP>", "<CAP>4784</CAP>", "CAP"},
+		{"enumeration", "<TipoDocumento>TD01</TipoDocumento>", "<TipoDocumento>TD99</TipoDocumento>", "TipoDocumento"},
+		{"characters outside Latin-1", "<Comune>Cattolica</Comune>", "<Comune>Καττόλικα</Comune>", "Comune"},
+		{"amount without decimals", "<ImponibileImporto>80.00</ImponibileImporto>", "<ImponibileImporto>80</ImponibileImporto>", "ImponibileImporto"},
+		{"rules of the SdI", "<Numero>12/2026</Numero>", "<Numero>XII</Numero>", "00425"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			validator := officialSchemaValidator(t)
+			content := validInvoice(t)
+			assert.Contains(t, content, tc.replace)
+			content = strings.Replace(content, tc.replace, tc.with, 1)
+
+			// Act
+			err := validator.Validate([]byte(content))
+
+			// Assert
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+// The schema is not part of the repository, a stand-in importing another schema through the catalog checks how
+// xmllint is run and how its errors are reported
+const standInSchema = `<?xml version="1.0"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+  <xs:import namespace="http://www.w3.org/2000/09/xmldsig#" schemaLocation="http://www.w3.org/TR/2002/REC-xmldsig-core-20020212/xmldsig-core-schema.xsd"/>
+  <xs:element name="Indirizzo">
+    <xs:complexType>
+      <xs:sequence>
+        <xs:element name="CAP">
+          <xs:simpleType>
+            <xs:restriction base="xs:string">
+              <xs:pattern value="[0-9][0-9][0-9][0-9][0-9]"/>
+            </xs:restriction>
+          </xs:simpleType>
+        </xs:element>
+        <xs:element ref="ds:Signature" minOccurs="0"/>
+      </xs:sequence>
+    </xs:complexType>
+  </xs:element>
+</xs:schema>`
+
+const standInSignatureSchema = `<?xml version="1.0"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" targetNamespace="http://www.w3.org/2000/09/xmldsig#" elementFormDefault="qualified">
+  <xs:element name="Signature" type="xs:string"/>
+</xs:schema>`
+
+func standInSchemaValidator(t *testing.T) *fatturapa.SchemaValidator {
+	xmllintPath := xmllint(t)
+	folder := t.TempDir()
+	catalog, err := os.ReadFile("../../../schemas/fatturapa/catalog.xml")
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(folder, "catalog.xml"), catalog, 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(folder, "xmldsig-core-schema.xsd"), []byte(standInSignatureSchema), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(folder, "schema.xsd"), []byte(standInSchema), 0o600))
+	return fatturapa.NewSchemaValidatorAt(xmllintPath, filepath.Join(folder, "schema.xsd"))
+}
+
+func TestSchemaValidator_Validate_Xmllint(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"not matching the schema", `<Indirizzo><CAP>4784</CAP></Indirizzo>`, "Element 'CAP': [facet 'pattern']"},
+		{"malformed XML", `<Indirizzo><CAP>47841</CAP>`, "invalid FatturaPA"},
+		{"failing the rules of the SdI", `<Indirizzo><CAP>47841</CAP><ds:Signature xmlns:ds="http://www.w3.org/2000/09/xmldsig#">firma</ds:Signature></Indirizzo>`, "invalid FatturaPA: CessionarioCommittente/DatiAnagrafici"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			validator := standInSchemaValidator(t)
+
+			// Act
+			err := validator.Validate([]byte(tc.content))
+
+			// Assert
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestSchemaValidator_Validate_SchemaNotFound(t *testing.T) {
+	// Arrange
+	validator := fatturapa.NewSchemaValidatorAt(xmllint(t), filepath.Join(t.TempDir(), "missing.xsd"))
+
+	// Act
+	err := validator.Validate([]byte(validInvoice(t)))
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "schema not found")
+}
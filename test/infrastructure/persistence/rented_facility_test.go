@@ -0,0 +1,56 @@
+package persistence_test
+
+import (
+	"testing"
+	"time"
+
+	facilityrental "github.com/alessandro-marcantoni/cnc-backend/main/domain/facility_rental"
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/persistence"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertDTOToRentedFacility_Flag(t *testing.T) {
+	// Arrange
+	flaggedAt := time.Date(2026, 4, 2, 3, 0, 0, 0, time.UTC)
+	reason := "holder has no active membership in the season"
+	rental := persistence.GetRentedFacilitiesByMemberQueryResult{
+		RentedFacilityID:   7,
+		FacilityIdentifier: "B-12",
+		Price:              400,
+		Transactions:       []byte(`[]`),
+	}
+	flagged := rental
+	flagged.FlaggedAt = &flaggedAt
+	flagged.FlagReason = &reason
+
+	// Act
+	converted := persistence.ConvertDTOToRentedFacility(rental)
+	convertedFlagged := persistence.ConvertDTOToRentedFacility(flagged)
+
+	// Assert
+	assert.True(t, converted.IsSuccess())
+	assert.Nil(t, converted.Value().GetFlag())
+	assert.True(t, convertedFlagged.IsSuccess())
+	assert.Equal(t, &facilityrental.RentalFlag{FlaggedAt: flaggedAt, Reason: reason}, convertedFlagged.Value().GetFlag())
+}
+
+func TestConvertDTOToRentedFacility_Currency(t *testing.T) {
+	// Arrange
+	rental := persistence.GetRentedFacilitiesByMemberQueryResult{
+		RentedFacilityID:   7,
+		FacilityIdentifier: "B-12",
+		Price:              400,
+		Transactions:       []byte(`[]`),
+	}
+	stored := rental
+	stored.Currency = "chf"
+
+	// Act
+	converted := persistence.ConvertDTOToRentedFacility(rental)
+	convertedStored := persistence.ConvertDTOToRentedFacility(stored)
+
+	// Assert
+	assert.Equal(t, money.Euros(400), converted.Value().GetPrice())
+	assert.Equal(t, money.FromFloat(400, "CHF"), convertedStored.Value().GetPrice())
+}
@@ -0,0 +1,78 @@
+package persistence_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/domain/membership"
+	"github.com/alessandro-marcantoni/cnc-backend/main/infrastructure/persistence"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapToMemberFromSearchQuery(t *testing.T) {
+	// Arrange
+	number := int64(42)
+	price := 130.0
+	seasonStartsAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	seasonEndsAt := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		row            persistence.SearchMembersQueryResult
+		expectedStatus membership.MembershipStatus
+		expectedNumber int64
+		expectedPrice  money.Money
+		expectedFlag   bool
+	}{
+		{
+			name: "member with a membership",
+			row: persistence.SearchMembersQueryResult{
+				MemberID:          sql.NullInt64{Int64: 1, Valid: true},
+				FirstName:         sql.NullString{String: "Mario", Valid: true},
+				LastName:          sql.NullString{String: "Rossi", Valid: true},
+				MembershipNumber:  &number,
+				SeasonStartsAt:    sql.NullTime{Time: seasonStartsAt, Valid: true},
+				SeasonEndsAt:      sql.NullTime{Time: seasonEndsAt, Valid: true},
+				Price:             &price,
+				MembershipStatus:  sql.NullString{String: "ACTIVE", Valid: true},
+				Transactions:      []byte(`[]`),
+				HasFlaggedRentals: sql.NullBool{Bool: true, Valid: true},
+			},
+			expectedStatus: membership.MembershipStatusActive,
+			expectedNumber: 42,
+			expectedPrice:  money.Euros(130),
+			expectedFlag:   true,
+		},
+		{
+			// Rows of the LEFT JOINs on memberships and periods without a match
+			name: "member without a membership",
+			row: persistence.SearchMembersQueryResult{
+				MemberID:  sql.NullInt64{Int64: 2, Valid: true},
+				FirstName: sql.NullString{String: "Giulia", Valid: true},
+				LastName:  sql.NullString{String: "Bianchi", Valid: true},
+			},
+			expectedStatus: membership.MembershipStatusNone,
+			expectedNumber: 0,
+			expectedPrice:  money.Money{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			member := persistence.MapToMemberFromSearchQuery(tc.row)
+
+			// Assert
+			assert.True(t, member.IsSuccess())
+			assert.Equal(t, tc.row.MemberID.Int64, member.Value().Id.Value)
+			assert.Equal(t, tc.row.LastName.String, member.Value().LastName)
+			assert.Equal(t, tc.expectedStatus, member.Value().Membership.Status.GetStatus())
+			assert.Equal(t, tc.expectedNumber, member.Value().Membership.Number)
+			assert.Equal(t, tc.expectedPrice, member.Value().Membership.Price)
+			assert.Empty(t, member.Value().Membership.Transactions)
+			assert.Equal(t, tc.expectedFlag, member.Value().HasFlaggedRentals)
+		})
+	}
+}
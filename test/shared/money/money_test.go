@@ -0,0 +1,216 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/errors"
+	"github.com/alessandro-marcantoni/cnc-backend/main/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromFloat_RoundsToTheMinorUnit(t *testing.T) {
+	testCases := []struct {
+		name       string
+		amount     float64
+		currency   money.Currency
+		minorUnits int64
+	}{
+		{"euros", 130, money.EUR, 13000},
+		{"float artefact", 0.1 + 0.2, money.EUR, 30},
+		{"half cent rounded up", 12.345, money.EUR, 1235},
+		{"negative half cent rounded away from zero", -0.005, money.EUR, -1},
+		{"currency without decimals", 1234.5, "JPY", 1235},
+		{"currency with three decimals", 1.2345, "KWD", 1235},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			amount := money.FromFloat(tc.amount, tc.currency)
+
+			// Assert
+			assert.Equal(t, tc.minorUnits, amount.MinorUnits())
+			assert.Equal(t, tc.currency, amount.Currency())
+		})
+	}
+}
+
+func TestExactFromFloat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   float64
+		currency money.Currency
+		valid    bool
+	}{
+		{"cents", 12.34, money.EUR, true},
+		{"float artefact", 0.1 + 0.2, money.EUR, true},
+		{"fraction of a cent", 12.345, money.EUR, false},
+		{"yen with decimals", 100.5, "JPY", false},
+		{"dinar with three decimals", 1.234, "KWD", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			amount := money.ExactFromFloat(tc.amount, tc.currency)
+
+			// Assert
+			assert.Equal(t, tc.valid, amount.IsSuccess())
+			if !tc.valid {
+				assert.IsType(t, errors.MoneyError{}, amount.Error())
+			}
+		})
+	}
+}
+
+func TestMoney_Add(t *testing.T) {
+	// Act
+	sum := money.Euros(0.1).Add(money.Euros(0.2))
+	fromZeroValue := money.Money{}.Add(money.Euros(5))
+	mismatched := money.Euros(10).Add(money.New(1000, "USD"))
+
+	// Assert
+	assert.Equal(t, money.Euros(0.3), sum.Value())
+	assert.Equal(t, "0.30 EUR", sum.Value().String())
+	assert.Equal(t, money.Euros(5), fromZeroValue.Value())
+	assert.IsType(t, errors.CurrencyMismatchError{}, mismatched.Error())
+}
+
+func TestMoney_Subtract(t *testing.T) {
+	// Act
+	difference := money.Euros(100).Subtract(money.Euros(130.5))
+	mismatched := money.Euros(100).Subtract(money.New(100, "USD"))
+
+	// Assert
+	assert.Equal(t, money.Euros(-30.5), difference.Value())
+	assert.True(t, difference.Value().IsNegative())
+	assert.IsType(t, errors.CurrencyMismatchError{}, mismatched.Error())
+}
+
+func TestSum(t *testing.T) {
+	// Arrange
+	amounts := []money.Money{money.Euros(0.1), money.Euros(0.2), money.Euros(0.3)}
+
+	// Act
+	total := money.Sum(money.EUR, amounts...)
+	empty := money.Sum(money.EUR)
+	mismatched := money.Sum(money.EUR, money.Euros(1), money.New(100, "USD"))
+
+	// Assert
+	assert.Equal(t, "0.60", total.Value().Decimal())
+	assert.Equal(t, money.Zero(money.EUR), empty.Value())
+	assert.IsType(t, errors.CurrencyMismatchError{}, mismatched.Error())
+}
+
+func TestMoney_Percentage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   money.Money
+		percent  float64
+		expected money.Money
+	}{
+		{"round amount", money.Euros(200), 10, money.Euros(20)},
+		{"rounded half up", money.Euros(123.45), 10, money.Euros(12.35)},
+		{"rounded half away from zero", money.Euros(-123.45), 10, money.Euros(-12.35)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act & Assert
+			assert.Equal(t, tc.expected, tc.amount.Percentage(tc.percent))
+		})
+	}
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   money.Money
+		parts    int
+		expected []int64
+	}{
+		{"even split", money.Euros(90), 3, []int64{3000, 3000, 3000}},
+		{"remainder to the first parts", money.Euros(100), 3, []int64{3334, 3333, 3333}},
+		{"negative amount", money.Euros(-0.05), 2, []int64{-3, -2}},
+		{"no parts", money.Euros(100), 0, []int64{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			allocated := tc.amount.Allocate(tc.parts)
+
+			// Assert
+			minorUnits := make([]int64, len(allocated))
+			for i, part := range allocated {
+				minorUnits[i] = part.MinorUnits()
+			}
+			assert.Equal(t, tc.expected, minorUnits)
+		})
+	}
+}
+
+func TestMoney_Compare(t *testing.T) {
+	// Act & Assert
+	assert.Equal(t, -1, money.Euros(80).Compare(money.Euros(100)).Value())
+	assert.Equal(t, 0, money.Euros(100).Compare(money.Euros(100)).Value())
+	assert.Equal(t, 1, money.Euros(100).Compare(money.Euros(80)).Value())
+	assert.IsType(t, errors.CurrencyMismatchError{}, money.Euros(80).Compare(money.New(100, "USD")).Error())
+
+	assert.True(t, money.Euros(80).LessThan(money.Euros(100)))
+	assert.False(t, money.Euros(80).LessThan(money.New(10000, "USD")), "amounts in different currencies are not comparable")
+
+	assert.True(t, money.Euros(80).Equal(money.New(8000, money.EUR)))
+	assert.True(t, money.Money{}.Equal(money.Zero(money.EUR)), "the zero value goes with any currency")
+	assert.False(t, money.Euros(80).Equal(money.New(8000, "USD")))
+}
+
+func TestMoney_Decimal(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   money.Money
+		expected string
+	}{
+		{"euros", money.Euros(1234.5), "1234.50"},
+		{"cents only", money.Euros(0.05), "0.05"},
+		{"negative", money.Euros(-0.05), "-0.05"},
+		{"yen", money.New(1500, "JPY"), "1500"},
+		{"dinar", money.New(1234, "KWD"), "1.234"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act & Assert
+			assert.Equal(t, tc.expected, tc.amount.Decimal())
+		})
+	}
+}
+
+func TestParseCurrency(t *testing.T) {
+	testCases := []struct {
+		code     string
+		expected money.Currency
+		valid    bool
+	}{
+		{"EUR", money.EUR, true},
+		{" usd ", "USD", true},
+		{"EURO", "", false},
+		{"E1R", "", false},
+		{"", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.code, func(t *testing.T) {
+			// Act
+			currency := money.ParseCurrency(tc.code)
+
+			// Assert
+			assert.Equal(t, tc.valid, currency.IsSuccess())
+			if tc.valid {
+				assert.Equal(t, tc.expected, currency.Value())
+			} else {
+				assert.IsType(t, errors.MoneyError{}, currency.Error())
+			}
+		})
+	}
+}